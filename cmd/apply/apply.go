@@ -0,0 +1,126 @@
+// Package apply provides the 'apply' command, which converges ChainLaunch
+// to a declarative topology file.
+package apply
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/cmd/common"
+	"github.com/chainlaunch/chainlaunch/pkg/apply"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+type applyCmd struct {
+	file       string
+	dryRun     bool
+	yes        bool
+	output     string
+	externalIP string
+	providerID int64
+	logger     *logger.Logger
+}
+
+func (c *applyCmd) validate() error {
+	if c.file == "" {
+		return fmt.Errorf("--file is required")
+	}
+	if c.output != "text" && c.output != "json" {
+		return fmt.Errorf("--output must be text or json")
+	}
+	if c.output == "json" && !c.dryRun {
+		return fmt.Errorf("--output json is only supported with --dry-run")
+	}
+	return nil
+}
+
+func (c *applyCmd) run(ctx context.Context, out io.Writer) error {
+	spec, err := apply.LoadFile(c.file)
+	if err != nil {
+		return err
+	}
+
+	client, err := common.NewClientFromEnv()
+	if err != nil {
+		return err
+	}
+	backend := &restBackend{client: client, externalIP: c.externalIP, providerID: c.providerID}
+	applier := apply.NewApplier(backend, out)
+
+	plan, state, err := applier.Plan(ctx, spec)
+	if err != nil {
+		return err
+	}
+	if c.output == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(plan)
+	}
+	plan.Render(out)
+	if c.dryRun || plan.Empty() {
+		return nil
+	}
+
+	if !c.yes {
+		fmt.Fprint(out, "Apply these changes? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read user input: %w", err)
+		}
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(out, "Operation cancelled")
+			return nil
+		}
+	}
+
+	if err := applier.Apply(ctx, spec, state, plan); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Apply complete: %d changes applied\n", len(plan.Changes))
+	return nil
+}
+
+// NewApplyCmd returns the apply command
+func NewApplyCmd(logger *logger.Logger) *cobra.Command {
+	c := &applyCmd{
+		logger: logger,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Converge ChainLaunch to a topology file",
+		Long: `Compare a YAML topology of organizations, keys, nodes, networks, channel
+membership, anchor peers and chaincode definitions with what exists, print
+the difference and create or update whatever is missing. Resources are
+matched by name, so applying the same file again changes nothing. Resources
+that are not in the file are never deleted.`,
+		Example: `  chainlaunch apply -f topology.yaml --dry-run
+  chainlaunch apply -f topology.yaml --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := c.validate(); err != nil {
+				return err
+			}
+			return c.run(cmd.Context(), os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&c.file, "file", "f", "", "Topology file")
+	flags.BoolVar(&c.dryRun, "dry-run", false, "Only print the plan")
+	flags.BoolVarP(&c.yes, "yes", "y", false, "Skip confirmation")
+	flags.StringVarP(&c.output, "output", "o", "text", "Plan output format with --dry-run (text or json)")
+	flags.StringVar(&c.externalIP, "external-ip", "127.0.0.1", "Host used in node endpoints the file leaves empty")
+	flags.Int64Var(&c.providerID, "provider-id", 1, "Key provider for organizations and keys that do not set one")
+
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
@@ -0,0 +1,668 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/cmd/common"
+	"github.com/chainlaunch/chainlaunch/pkg/apply"
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+	"github.com/chainlaunch/chainlaunch/pkg/common/ports"
+	fabrictypes "github.com/chainlaunch/chainlaunch/pkg/fabric/handler"
+	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
+	networkshttp "github.com/chainlaunch/chainlaunch/pkg/networks/http"
+	nodeshttp "github.com/chainlaunch/chainlaunch/pkg/nodes/http"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+const (
+	defaultFabricVersion = "3.1.0"
+	defaultBesuVersion   = "25.5.0"
+	defaultMode          = "service"
+)
+
+// restBackend implements apply.Backend against the ChainLaunch REST API
+type restBackend struct {
+	client     *common.Client
+	externalIP string
+	providerID int64
+}
+
+var _ apply.Backend = (*restBackend)(nil)
+
+func (b *restBackend) LoadState(ctx context.Context) (*apply.State, error) {
+	state := apply.NewState()
+
+	orgs, err := b.client.ListAllOrganizations()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range orgs {
+		state.Organizations[o.MspID] = &apply.Organization{ID: o.ID, MspID: o.MspID, Description: o.Description}
+	}
+
+	keys, err := b.client.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		state.Keys[k.Name] = mapKey(&k)
+	}
+
+	nodes, err := b.client.ListAllNodes()
+	if err != nil {
+		return nil, err
+	}
+	nodeNames := map[int64]string{}
+	for i := range nodes {
+		nodeNames[nodes[i].ID] = nodes[i].Name
+		if node := mapNode(&nodes[i]); node != nil {
+			state.Nodes[node.Name] = node
+		}
+	}
+
+	fabricNetworks, err := b.client.ListAllNetworks("fabric")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range fabricNetworks {
+		network := &apply.Network{ID: n.ID, Name: n.Name, Platform: "FABRIC", Chaincodes: map[string]*apply.Chaincode{}}
+		networkNodes, err := b.client.GetFabricNetworkNodes(n.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, nn := range networkNodes.Nodes {
+			if nn.Status != "joined" {
+				continue
+			}
+			switch nn.Role {
+			case "peer":
+				network.Peers = append(network.Peers, nodeNames[nn.NodeID])
+			case "orderer":
+				network.Orderers = append(network.Orderers, nodeNames[nn.NodeID])
+			}
+		}
+		// Members and anchor peers stay unknown when no orderer is reachable
+		if config, err := b.client.GetFabricCurrentChannelConfig(n.ID); err == nil {
+			network.Members, network.AnchorPeers = apply.ChannelMembership(config.ChannelConfig)
+		}
+		state.Networks[n.Name] = network
+	}
+
+	besuNetworks, err := b.client.ListAllNetworks("besu")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range besuNetworks {
+		state.Networks[n.Name] = &apply.Network{ID: n.ID, Name: n.Name, Platform: "BESU"}
+	}
+
+	chaincodes, err := b.client.ListFabricChaincodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, cc := range chaincodes {
+		network, ok := state.Networks[cc.NetworkName]
+		if !ok || network.ID != cc.NetworkID {
+			continue
+		}
+		defs, err := b.client.ListChaincodeDefinitions(cc.ID)
+		if err != nil {
+			return nil, err
+		}
+		chaincode := &apply.Chaincode{ID: cc.ID, Name: cc.Name}
+		for _, d := range defs {
+			chaincode.Definitions = append(chaincode.Definitions, mapDefinition(&d))
+		}
+		network.Chaincodes[cc.Name] = chaincode
+	}
+
+	return state, nil
+}
+
+func (b *restBackend) CreateOrganization(ctx context.Context, spec apply.OrganizationSpec) (*apply.Organization, error) {
+	name := spec.Name
+	if name == "" {
+		name = spec.MspID
+	}
+	providerID := spec.ProviderID
+	if providerID == 0 {
+		providerID = b.providerID
+	}
+	org, err := b.client.CreateOrganization(fabrictypes.CreateOrganizationRequest{
+		MspID:       spec.MspID,
+		Name:        name,
+		Description: spec.Description,
+		ProviderID:  providerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apply.Organization{ID: org.ID, MspID: org.MspID, Description: org.Description}, nil
+}
+
+func (b *restBackend) CreateKey(ctx context.Context, spec apply.KeySpec) (*apply.Key, error) {
+	providerID := spec.ProviderID
+	if providerID == 0 {
+		providerID = int(b.providerID)
+	}
+	req := &models.CreateKeyRequest{
+		Name:       spec.Name,
+		Algorithm:  models.KeyAlgorithm(spec.Algorithm),
+		ProviderID: &providerID,
+	}
+	if spec.Description != "" {
+		req.Description = &spec.Description
+	}
+	if spec.Curve != "" {
+		curve := models.ECCurve(spec.Curve)
+		req.Curve = &curve
+	}
+	if spec.KeySize != 0 {
+		req.KeySize = &spec.KeySize
+	}
+	key, err := b.client.CreateKey(req)
+	if err != nil {
+		return nil, err
+	}
+	return mapKey(key), nil
+}
+
+func (b *restBackend) CreatePeer(ctx context.Context, spec apply.PeerSpec, organizationID int64) (*apply.Node, error) {
+	listen, err := b.address(spec.ListenAddress, "fabric-peer")
+	if err != nil {
+		return nil, err
+	}
+	chaincode, err := b.address(spec.ChaincodeAddress, "fabric-peer")
+	if err != nil {
+		return nil, err
+	}
+	events, err := b.address(spec.EventsAddress, "fabric-peer")
+	if err != nil {
+		return nil, err
+	}
+	operations, err := b.address(spec.OperationsAddress, "fabric-peer")
+	if err != nil {
+		return nil, err
+	}
+	externalEndpoint, err := b.externalEndpoint(spec.ExternalEndpoint, listen)
+	if err != nil {
+		return nil, err
+	}
+	node, err := b.client.CreatePeerNode(&types.FabricPeerConfig{
+		BaseNodeConfig:          types.BaseNodeConfig{Mode: orDefault(spec.Mode, defaultMode)},
+		Name:                    spec.Name,
+		OrganizationID:          organizationID,
+		MSPID:                   spec.Organization,
+		ListenAddress:           listen,
+		ChaincodeAddress:        chaincode,
+		EventsAddress:           events,
+		OperationsListenAddress: operations,
+		ExternalEndpoint:        externalEndpoint,
+		DomainNames:             b.domainNames(spec.DomainNames),
+		Env:                     orEmpty(spec.Env),
+		Version:                 orDefault(spec.Version, defaultFabricVersion),
+		AddressOverrides:        []types.AddressOverride{},
+		OrdererAddressOverrides: []types.OrdererAddressOverride{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapNode(node), nil
+}
+
+func (b *restBackend) CreateOrderer(ctx context.Context, spec apply.OrdererSpec, organizationID int64) (*apply.Node, error) {
+	listen, err := b.address(spec.ListenAddress, "fabric-orderer")
+	if err != nil {
+		return nil, err
+	}
+	admin, err := b.address(spec.AdminAddress, "fabric-orderer")
+	if err != nil {
+		return nil, err
+	}
+	operations, err := b.address(spec.OperationsAddress, "fabric-orderer")
+	if err != nil {
+		return nil, err
+	}
+	externalEndpoint, err := b.externalEndpoint(spec.ExternalEndpoint, listen)
+	if err != nil {
+		return nil, err
+	}
+	node, err := b.client.CreateOrdererNode(&types.FabricOrdererConfig{
+		BaseNodeConfig:          types.BaseNodeConfig{Mode: orDefault(spec.Mode, defaultMode)},
+		Name:                    spec.Name,
+		OrganizationID:          organizationID,
+		MSPID:                   spec.Organization,
+		ListenAddress:           listen,
+		AdminAddress:            admin,
+		OperationsListenAddress: operations,
+		ExternalEndpoint:        externalEndpoint,
+		DomainNames:             b.domainNames(spec.DomainNames),
+		Env:                     orEmpty(spec.Env),
+		Version:                 orDefault(spec.Version, defaultFabricVersion),
+		AddressOverrides:        []types.AddressOverride{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapNode(node), nil
+}
+
+func (b *restBackend) CreateBesuNode(ctx context.Context, spec apply.BesuNodeSpec, networkID, keyID int64) (*apply.Node, error) {
+	p2pPort, err := b.port(spec.P2PPort, "besu-p2p")
+	if err != nil {
+		return nil, err
+	}
+	rpcPort, err := b.port(spec.RPCPort, "besu")
+	if err != nil {
+		return nil, err
+	}
+	metricsPort := spec.MetricsPort
+	if metricsPort == 0 {
+		allocated, err := ports.GetFreePort("besu-metrics")
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate metrics port: %w", err)
+		}
+		metricsPort = int64(allocated.Port)
+	}
+	node, err := b.client.CreateBesuNode(spec.Name, &types.BesuNodeConfig{
+		BaseNodeConfig:  types.BaseNodeConfig{Mode: orDefault(spec.Mode, defaultMode), Type: "besu"},
+		NetworkID:       networkID,
+		KeyID:           keyID,
+		P2PPort:         p2pPort,
+		RPCPort:         rpcPort,
+		P2PHost:         orDefault(spec.P2PHost, "0.0.0.0"),
+		RPCHost:         orDefault(spec.RPCHost, "0.0.0.0"),
+		ExternalIP:      orDefault(spec.ExternalIP, b.externalIP),
+		InternalIP:      orDefault(spec.InternalIP, b.externalIP),
+		Env:             orEmpty(spec.Env),
+		BootNodes:       spec.BootNodes,
+		MetricsEnabled:  true,
+		MetricsPort:     metricsPort,
+		MetricsProtocol: "PROMETHEUS",
+		Version:         orDefault(spec.Version, defaultBesuVersion),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapNode(node), nil
+}
+
+func (b *restBackend) UpdateNode(ctx context.Context, node *apply.Node, diffs []apply.FieldDiff) (*apply.Node, error) {
+	req := &nodeshttp.UpdateNodeRequest{}
+	switch node.Kind {
+	case apply.KindPeer:
+		platform := types.PlatformFabric
+		peer := &nodeshttp.UpdateFabricPeerRequest{}
+		for _, d := range diffs {
+			to := d.To
+			switch d.Field {
+			case apply.FieldMode:
+				peer.Mode = to
+			case apply.FieldVersion:
+				peer.Version = &to
+			case apply.FieldExternalEndpoint:
+				peer.ExternalEndpoint = &to
+			case apply.FieldListenAddress:
+				peer.ListenAddress = &to
+			case apply.FieldOperationsAddress:
+				peer.OperationsListenAddress = &to
+			case apply.FieldChaincodeAddress:
+				peer.ChaincodeAddress = &to
+			case apply.FieldEventsAddress:
+				peer.EventsAddress = &to
+			case apply.FieldDomainNames:
+				peer.DomainNames = splitList(to)
+			}
+		}
+		req.BlockchainPlatform = &platform
+		req.FabricPeer = peer
+	case apply.KindOrderer:
+		platform := types.PlatformFabric
+		orderer := &nodeshttp.UpdateFabricOrdererRequest{}
+		for _, d := range diffs {
+			to := d.To
+			switch d.Field {
+			case apply.FieldMode:
+				orderer.Mode = to
+			case apply.FieldVersion:
+				orderer.Version = &to
+			case apply.FieldExternalEndpoint:
+				orderer.ExternalEndpoint = &to
+			case apply.FieldListenAddress:
+				orderer.ListenAddress = &to
+			case apply.FieldOperationsAddress:
+				orderer.OperationsListenAddress = &to
+			case apply.FieldAdminAddress:
+				orderer.AdminAddress = &to
+			case apply.FieldDomainNames:
+				orderer.DomainNames = splitList(to)
+			}
+		}
+		req.BlockchainPlatform = &platform
+		req.FabricOrderer = orderer
+	case apply.KindBesuNode:
+		// The Besu update replaces the whole configuration, so start from the current one
+		current, err := b.client.GetNode(node.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current.BesuNode == nil {
+			return nil, fmt.Errorf("node %s is not a besu node", node.Name)
+		}
+		props := current.BesuNode
+		besu := &nodeshttp.UpdateBesuNodeRequest{
+			NetworkID:      uint(props.NetworkID),
+			P2PHost:        props.P2PHost,
+			P2PPort:        props.P2PPort,
+			RPCHost:        props.RPCHost,
+			RPCPort:        props.RPCPort,
+			WSPort:         &props.WSPort,
+			Bootnodes:      props.BootNodes,
+			ExternalIP:     props.ExternalIP,
+			InternalIP:     props.InternalIP,
+			MetricsEnabled: props.MetricsEnabled,
+			MetricsPort:    int64(props.MetricsPort),
+			Mode:           props.Mode,
+			Version:        props.Version,
+		}
+		for _, d := range diffs {
+			switch d.Field {
+			case apply.FieldMode:
+				besu.Mode = d.To
+			case apply.FieldVersion:
+				besu.Version = d.To
+			case apply.FieldP2PHost:
+				besu.P2PHost = d.To
+			case apply.FieldRPCHost:
+				besu.RPCHost = d.To
+			case apply.FieldExternalIP:
+				besu.ExternalIP = d.To
+			case apply.FieldInternalIP:
+				besu.InternalIP = d.To
+			case apply.FieldP2PPort, apply.FieldRPCPort:
+				port, err := strconv.ParseUint(d.To, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s %q: %w", d.Field, d.To, err)
+				}
+				if d.Field == apply.FieldP2PPort {
+					besu.P2PPort = uint(port)
+				} else {
+					besu.RPCPort = uint(port)
+				}
+			}
+		}
+		platform := types.PlatformBesu
+		req.BlockchainPlatform = &platform
+		req.BesuNode = besu
+	default:
+		return nil, fmt.Errorf("cannot update %s %s", node.Kind, node.Name)
+	}
+
+	updated, err := b.client.UpdateNode(node.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapNode(updated), nil
+}
+
+func (b *restBackend) CreateFabricNetwork(ctx context.Context, spec apply.FabricNetworkSpec, peerOrgs, ordererOrgs []apply.OrgNodes) (*apply.Network, error) {
+	orgConfigs := func(groups []apply.OrgNodes) []networkshttp.OrganizationConfig {
+		configs := make([]networkshttp.OrganizationConfig, 0, len(groups))
+		for _, g := range groups {
+			configs = append(configs, networkshttp.OrganizationConfig{ID: g.OrganizationID, NodeIDs: g.NodeIDs})
+		}
+		return configs
+	}
+	network, err := b.client.CreateFabricNetwork(&networkshttp.CreateFabricNetworkRequest{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Config: networkshttp.FabricNetworkConfig{
+			PeerOrganizations:    orgConfigs(peerOrgs),
+			OrdererOrganizations: orgConfigs(ordererOrgs),
+			ConsensusType:        spec.ConsensusType,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apply.Network{ID: network.ID, Name: network.Name, Platform: "FABRIC", Chaincodes: map[string]*apply.Chaincode{}}, nil
+}
+
+func (b *restBackend) CreateBesuNetwork(ctx context.Context, spec apply.BesuNetworkSpec, validatorKeyIDs []int64) (*apply.Network, error) {
+	req := &networkshttp.CreateBesuNetworkRequest{
+		Name:        spec.Name,
+		Description: spec.Description,
+	}
+	req.Config.Consensus = orDefault(spec.Consensus, "qbft")
+	req.Config.ChainID = spec.ChainID
+	req.Config.BlockPeriod = orDefaultInt(spec.BlockPeriod, 5)
+	req.Config.EpochLength = orDefaultInt(spec.EpochLength, 30000)
+	req.Config.RequestTimeout = orDefaultInt(spec.RequestTimeout, 10)
+	req.Config.InitialValidatorKeyIds = validatorKeyIDs
+	req.Config.GasLimit = "0x29b92700"
+	req.Config.Difficulty = "0x1"
+	req.Config.MixHash = "0x63746963616c2062797a616e74696e65206661756c7420746f6c6572616e6365"
+	req.Config.Coinbase = "0x0000000000000000000000000000000000000000"
+	req.Config.Timestamp = fmt.Sprintf("0x%x", time.Now().Unix())
+	req.Config.Nonce = "0x0000000000000000"
+	req.Config.Alloc = make(map[string]struct {
+		Balance string `json:"balance" validate:"required,hexadecimal"`
+	})
+	for address, balance := range spec.Alloc {
+		req.Config.Alloc[address] = struct {
+			Balance string `json:"balance" validate:"required,hexadecimal"`
+		}{Balance: balance}
+	}
+
+	network, err := b.client.CreateBesuNetwork(req)
+	if err != nil {
+		return nil, err
+	}
+	return &apply.Network{ID: network.ID, Name: network.Name, Platform: "BESU"}, nil
+}
+
+func (b *restBackend) JoinNode(ctx context.Context, networkID int64, node *apply.Node) error {
+	var err error
+	if node.Kind == apply.KindOrderer {
+		_, err = b.client.JoinOrdererToFabricNetwork(networkID, node.ID)
+	} else {
+		_, err = b.client.JoinPeerToFabricNetwork(networkID, node.ID)
+	}
+	return err
+}
+
+func (b *restBackend) SetAnchorPeers(ctx context.Context, networkID, organizationID int64, endpoints []string) error {
+	req := &networkshttp.SetAnchorPeersRequest{OrganizationID: organizationID, AnchorPeers: []networkshttp.AnchorPeer{}}
+	for _, endpoint := range endpoints {
+		host, portStr, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid anchor peer endpoint %q: %w", endpoint, err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return fmt.Errorf("invalid anchor peer endpoint %q: %w", endpoint, err)
+		}
+		req.AnchorPeers = append(req.AnchorPeers, networkshttp.AnchorPeer{Host: host, Port: port})
+	}
+	_, err := b.client.SetFabricAnchorPeers(networkID, req)
+	return err
+}
+
+func (b *restBackend) CreateChaincode(ctx context.Context, networkID int64, name string) (*apply.Chaincode, error) {
+	cc, err := b.client.CreateFabricChaincode(&chainlaunchdeploy.CreateChaincodeRequest{Name: name, NetworkID: networkID})
+	if err != nil {
+		return nil, err
+	}
+	return &apply.Chaincode{ID: cc.ID, Name: cc.Name}, nil
+}
+
+func (b *restBackend) CreateChaincodeDefinition(ctx context.Context, chaincodeID int64, spec apply.ChaincodeSpec) (*apply.ChaincodeDefinition, error) {
+	def, err := b.client.CreateChaincodeDefinition(&chainlaunchdeploy.CreateChaincodeDefinitionRequest{
+		ChaincodeID:       chaincodeID,
+		Version:           spec.Version,
+		Sequence:          spec.Sequence,
+		DockerImage:       spec.DockerImage,
+		EndorsementPolicy: spec.EndorsementPolicy,
+		ChaincodeAddress:  spec.ChaincodeAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d := mapDefinition(def)
+	return &d, nil
+}
+
+func (b *restBackend) UpdateChaincodeDefinition(ctx context.Context, definitionID int64, spec apply.ChaincodeSpec) error {
+	_, err := b.client.UpdateChaincodeDefinition(definitionID, &chainlaunchdeploy.UpdateChaincodeDefinitionRequest{
+		Version:           spec.Version,
+		Sequence:          spec.Sequence,
+		DockerImage:       spec.DockerImage,
+		EndorsementPolicy: spec.EndorsementPolicy,
+		ChaincodeAddress:  spec.ChaincodeAddress,
+	})
+	return err
+}
+
+// address returns the given listen address, or 0.0.0.0 with a free port of the node type
+func (b *restBackend) address(addr, nodeType string) (string, error) {
+	if addr != "" {
+		return addr, nil
+	}
+	allocated, err := ports.GetFreePort(nodeType)
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate %s port: %w", nodeType, err)
+	}
+	return fmt.Sprintf("0.0.0.0:%d", allocated.Port), nil
+}
+
+func (b *restBackend) port(port uint, nodeType string) (uint, error) {
+	if port != 0 {
+		return port, nil
+	}
+	allocated, err := ports.GetFreePort(nodeType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate %s port: %w", nodeType, err)
+	}
+	return uint(allocated.Port), nil
+}
+
+// externalEndpoint defaults to the external IP on the listen port
+func (b *restBackend) externalEndpoint(endpoint, listen string) (string, error) {
+	if endpoint != "" {
+		return endpoint, nil
+	}
+	_, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", listen, err)
+	}
+	return net.JoinHostPort(b.externalIP, port), nil
+}
+
+func (b *restBackend) domainNames(names []string) []string {
+	if len(names) > 0 {
+		return names
+	}
+	return []string{b.externalIP}
+}
+
+func mapKey(k *models.KeyResponse) *apply.Key {
+	key := &apply.Key{ID: int64(k.ID), Name: k.Name, Algorithm: string(k.Algorithm)}
+	if k.Curve != nil {
+		key.Curve = string(*k.Curve)
+	}
+	return key
+}
+
+// mapNode converts peers, orderers and Besu nodes; other node types are not managed by apply
+func mapNode(n *nodeshttp.NodeResponse) *apply.Node {
+	switch {
+	case n.FabricPeer != nil:
+		p := n.FabricPeer
+		return &apply.Node{ID: n.ID, Name: n.Name, Kind: apply.KindPeer, Organization: p.MSPID, Fields: map[string]string{
+			apply.FieldMode:              p.Mode,
+			apply.FieldVersion:           p.Version,
+			apply.FieldExternalEndpoint:  p.ExternalEndpoint,
+			apply.FieldListenAddress:     p.ListenAddress,
+			apply.FieldOperationsAddress: p.OperationsAddress,
+			apply.FieldChaincodeAddress:  p.ChaincodeAddress,
+			apply.FieldEventsAddress:     p.EventsAddress,
+			apply.FieldDomainNames:       apply.JoinList(p.DomainNames),
+		}}
+	case n.FabricOrderer != nil:
+		o := n.FabricOrderer
+		return &apply.Node{ID: n.ID, Name: n.Name, Kind: apply.KindOrderer, Organization: o.MSPID, Fields: map[string]string{
+			apply.FieldMode:              o.Mode,
+			apply.FieldVersion:           o.Version,
+			apply.FieldExternalEndpoint:  o.ExternalEndpoint,
+			apply.FieldListenAddress:     o.ListenAddress,
+			apply.FieldOperationsAddress: o.OperationsAddress,
+			apply.FieldAdminAddress:      o.AdminAddress,
+			apply.FieldDomainNames:       apply.JoinList(o.DomainNames),
+		}}
+	case n.BesuNode != nil:
+		bn := n.BesuNode
+		return &apply.Node{ID: n.ID, Name: n.Name, Kind: apply.KindBesuNode, NetworkID: bn.NetworkID, KeyID: bn.KeyID, Fields: map[string]string{
+			apply.FieldMode:       bn.Mode,
+			apply.FieldVersion:    bn.Version,
+			apply.FieldP2PHost:    bn.P2PHost,
+			apply.FieldP2PPort:    strconv.FormatUint(uint64(bn.P2PPort), 10),
+			apply.FieldRPCHost:    bn.RPCHost,
+			apply.FieldRPCPort:    strconv.FormatUint(uint64(bn.RPCPort), 10),
+			apply.FieldExternalIP: bn.ExternalIP,
+			apply.FieldInternalIP: bn.InternalIP,
+		}}
+	default:
+		return nil
+	}
+}
+
+func mapDefinition(d *chainlaunchdeploy.ChaincodeDefinitionResponse) apply.ChaincodeDefinition {
+	return apply.ChaincodeDefinition{
+		ID:                d.ID,
+		Version:           d.Version,
+		Sequence:          d.Sequence,
+		DockerImage:       d.DockerImage,
+		EndorsementPolicy: d.EndorsementPolicy,
+		ChaincodeAddress:  d.ChaincodeAddress,
+	}
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	var list []string
+	start := 0
+	for i := 0; i <= len(v); i++ {
+		if i == len(v) || v[i] == ',' {
+			list = append(list, v[start:i])
+			start = i + 1
+		}
+	}
+	return list
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func orDefaultInt(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+func orEmpty(env map[string]string) map[string]string {
+	if env == nil {
+		return map[string]string{}
+	}
+	return env
+}
@@ -0,0 +1,368 @@
+// Package benchmark provides the 'benchmark' commands to load test deployed
+// chaincodes and contracts and compare the reports of runs.
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/cmd/common"
+	"github.com/chainlaunch/chainlaunch/pkg/benchmarks"
+	"github.com/spf13/cobra"
+)
+
+// NewBenchmarkCmd returns the root benchmark command
+func NewBenchmarkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Load test chaincodes and contracts and compare runs",
+	}
+	cmd.AddCommand(newRunCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newShowCmd())
+	cmd.AddCommand(newCompareCmd())
+	cmd.AddCommand(newCancelCmd())
+	return cmd
+}
+
+type runOptions struct {
+	name        string
+	platform    string
+	chaincodeID int64
+	keyID       int64
+	channel     string
+	nodeID      int64
+	contract    string
+	gasLimit    uint64
+	function    string
+	args        []string
+	mode        string
+	tps         float64
+	concurrency int
+	duration    time.Duration
+	timeout     time.Duration
+	detach      bool
+	output      string
+}
+
+func (o *runOptions) request() (benchmarks.StartRequest, error) {
+	req := benchmarks.StartRequest{
+		Name:     o.name,
+		Platform: benchmarks.Platform(strings.ToUpper(o.platform)),
+		Workload: benchmarks.Workload{
+			Function:        o.function,
+			Args:            o.args,
+			Mode:            benchmarks.Mode(strings.ToUpper(o.mode)),
+			TPS:             o.tps,
+			Concurrency:     o.concurrency,
+			DurationSeconds: int(o.duration.Seconds()),
+			TimeoutSeconds:  int(o.timeout.Seconds()),
+		},
+	}
+	if req.Workload.DurationSeconds <= 0 {
+		return req, fmt.Errorf("--duration must be at least 1s")
+	}
+	switch req.Platform {
+	case benchmarks.PlatformFabric:
+		if o.chaincodeID == 0 || o.keyID == 0 {
+			return req, fmt.Errorf("--chaincode-id and --key-id are required for fabric")
+		}
+		req.Fabric = &benchmarks.FabricTarget{ChaincodeID: o.chaincodeID, KeyID: o.keyID, Channel: o.channel}
+	case benchmarks.PlatformBesu:
+		if o.nodeID == 0 || o.contract == "" {
+			return req, fmt.Errorf("--node-id and --contract are required for besu")
+		}
+		req.Besu = &benchmarks.BesuTarget{NodeID: o.nodeID, ContractAddress: o.contract, KeyID: o.keyID, GasLimit: o.gasLimit}
+	default:
+		return req, fmt.Errorf("unsupported platform: %s (must be 'fabric' or 'besu')", o.platform)
+	}
+	return req, nil
+}
+
+func newRunCmd() *cobra.Command {
+	o := &runOptions{}
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run a workload against a chaincode or contract",
+		Long: `Run a workload against a deployed Fabric chaincode or Besu contract and
+print its report. Args are Go templates rendered for every transaction with
+the sprig functions, .Seq (the transaction number) and .Worker.`,
+		Example: `  # Fabric: create assets at 100 TPS for one minute
+  chainlaunch benchmark run --name v1.2 --platform fabric --chaincode-id 3 --key-id 12 \
+    --function CreateAsset --arg 'asset-{{.Seq}}' --arg blue --arg '{{randInt 1 100}}' \
+    --tps 100 --concurrency 20 --duration 1m
+
+  # Besu: send set(uint256) transactions as fast as 10 workers allow
+  chainlaunch benchmark run --name v1.2 --platform besu --node-id 4 --key-id 7 \
+    --contract 0x42699A7612A82f1d9C36148af9C77354759b210b \
+    --function 'set(uint256)' --arg '{{.Seq}}' --concurrency 10 --duration 30s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := o.request()
+			if err != nil {
+				return err
+			}
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			run, err := client.StartBenchmark(req)
+			if err != nil {
+				return err
+			}
+			if o.detach {
+				fmt.Printf("Benchmark run %d started\n", run.ID)
+				return nil
+			}
+
+			fmt.Fprintf(os.Stderr, "Benchmark run %d started, running for %s...\n", run.ID, o.duration)
+			for run.Status == benchmarks.StatusRunning {
+				time.Sleep(2 * time.Second)
+				if run, err = client.GetBenchmark(run.ID); err != nil {
+					return err
+				}
+			}
+			if err := printRun(os.Stdout, o.output, run); err != nil {
+				return err
+			}
+			if run.Status == benchmarks.StatusFailed {
+				return fmt.Errorf("benchmark run %d failed: %s", run.ID, run.Error)
+			}
+			return nil
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&o.name, "name", "", "Name of the run, e.g. the release under test")
+	f.StringVar(&o.platform, "platform", "fabric", "Platform: fabric or besu")
+	f.Int64Var(&o.chaincodeID, "chaincode-id", 0, "Fabric chaincode ID")
+	f.Int64Var(&o.keyID, "key-id", 0, "Key to sign transactions with")
+	f.StringVar(&o.channel, "channel", "", "Fabric channel (default: the chaincode's network)")
+	f.Int64Var(&o.nodeID, "node-id", 0, "Besu node whose RPC endpoint is used")
+	f.StringVar(&o.contract, "contract", "", "Besu contract address")
+	f.Uint64Var(&o.gasLimit, "gas-limit", 0, "Besu gas limit (default: estimated)")
+	f.StringVar(&o.function, "function", "", "Chaincode function, or Solidity signature such as 'set(uint256)'")
+	f.StringArrayVar(&o.args, "arg", nil, "Argument template, repeat for each argument")
+	f.StringVar(&o.mode, "mode", "", "submit or evaluate for fabric, send or call for besu (default: submit/send)")
+	f.Float64Var(&o.tps, "tps", 0, "Target transactions per second (default: as fast as possible)")
+	f.IntVar(&o.concurrency, "concurrency", 1, "Transactions in flight at most")
+	f.DurationVar(&o.duration, "duration", time.Minute, "How long to send transactions")
+	f.DurationVar(&o.timeout, "timeout", 30*time.Second, "Timeout of a single transaction")
+	f.BoolVar(&o.detach, "detach", false, "Start the run and return without waiting for the report")
+	f.StringVar(&o.output, "output", "tsv", "Output type: tsv or json")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("function")
+	return cmd
+}
+
+func newListCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List benchmark runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			runs, err := client.ListBenchmarks()
+			if err != nil {
+				return err
+			}
+			switch output {
+			case "json":
+				return printJSON(os.Stdout, runs)
+			case "tsv":
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+				fmt.Fprintln(w, "ID\tName\tPlatform\tFunction\tStatus\tTPS\tP95 (ms)\tFailed\tStarted At")
+				fmt.Fprintln(w, "--\t----\t--------\t--------\t------\t---\t--------\t------\t----------")
+				for _, r := range runs {
+					throughput, p95, failed := "-", "-", "-"
+					if r.Report != nil {
+						throughput = fmt.Sprintf("%.1f", r.Report.Throughput)
+						p95 = fmt.Sprintf("%.1f", r.Report.Latency.P95)
+						failed = strconv.FormatInt(r.Report.Failed, 10)
+					}
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Name, r.Platform, r.Workload.Function, r.Status, throughput, p95, failed, r.StartedAt.Format(time.RFC3339))
+				}
+				return w.Flush()
+			default:
+				return fmt.Errorf("unsupported output type: %s (must be 'tsv' or 'json')", output)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "tsv", "Output type: tsv or json")
+	return cmd
+}
+
+func newShowCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a benchmark run and its report",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid run ID: %s", args[0])
+			}
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			run, err := client.GetBenchmark(id)
+			if err != nil {
+				return err
+			}
+			return printRun(os.Stdout, output, run)
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "tsv", "Output type: tsv or json")
+	return cmd
+}
+
+func newCompareCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "compare <baseline-id> <id>...",
+		Short: "Compare the reports of finished runs against a baseline",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids := make([]int64, len(args))
+			for i, arg := range args {
+				id, err := strconv.ParseInt(arg, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid run ID: %s", arg)
+				}
+				ids[i] = id
+			}
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			comparison, err := client.CompareBenchmarks(ids)
+			if err != nil {
+				return err
+			}
+			switch output {
+			case "json":
+				return printJSON(os.Stdout, comparison)
+			case "tsv":
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+				fmt.Fprintln(w, "ID\tName\tTPS\tΔ TPS\tP50 (ms)\tP95 (ms)\tΔ P95\tP99 (ms)\tΔ P99\tFailure Rate")
+				fmt.Fprintln(w, "--\t----\t---\t-----\t--------\t--------\t-----\t--------\t-----\t------------")
+				for _, r := range comparison.Runs {
+					fmt.Fprintf(w, "%d\t%s\t%.1f\t%s\t%.1f\t%.1f\t%s\t%.1f\t%s\t%.2f%%\n",
+						r.ID, r.Name, r.Throughput, formatChange(r.ThroughputChange), r.P50, r.P95, formatChange(r.P95Change), r.P99, formatChange(r.P99Change), r.FailureRate)
+				}
+				return w.Flush()
+			default:
+				return fmt.Errorf("unsupported output type: %s (must be 'tsv' or 'json')", output)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "tsv", "Output type: tsv or json")
+	return cmd
+}
+
+func newCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Stop a running benchmark; its report covers what ran until then",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid run ID: %s", args[0])
+			}
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			if err := client.CancelBenchmark(id); err != nil {
+				return err
+			}
+			fmt.Printf("Benchmark run %d cancelled\n", id)
+			return nil
+		},
+	}
+}
+
+func formatChange(c *float64) string {
+	if c == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", *c)
+}
+
+func printJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printRun(out io.Writer, output string, r *benchmarks.Run) error {
+	switch output {
+	case "json":
+		return printJSON(out, r)
+	case "tsv":
+	default:
+		return fmt.Errorf("unsupported output type: %s (must be 'tsv' or 'json')", output)
+	}
+
+	fmt.Fprintf(out, "Run:         %d (%s)\n", r.ID, r.Name)
+	fmt.Fprintf(out, "Platform:    %s\n", r.Platform)
+	fmt.Fprintf(out, "Function:    %s %s\n", r.Workload.Mode, r.Workload.Function)
+	fmt.Fprintf(out, "Status:      %s\n", r.Status)
+	if r.Error != "" {
+		fmt.Fprintf(out, "Error:       %s\n", r.Error)
+	}
+	if r.Report == nil {
+		return nil
+	}
+	rep := r.Report
+	fmt.Fprintf(out, "Duration:    %.1fs\n", rep.DurationSeconds)
+	fmt.Fprintf(out, "Throughput:  %.1f tx/s\n", rep.Throughput)
+	fmt.Fprintf(out, "Succeeded:   %d of %d\n", rep.Succeeded, rep.Total)
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Latency (ms)\tMin\tMean\tP50\tP90\tP95\tP99\tMax")
+	l := rep.Latency
+	fmt.Fprintf(w, "\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\n", l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if rep.Failed == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+	kinds := make([]string, 0, len(rep.Failures))
+	for kind := range rep.Failures {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+	w = tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Failure\tCount")
+	fmt.Fprintln(w, "-------\t-----")
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "%s\t%d\n", kind, rep.Failures[benchmarks.FailureKind(kind)])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(rep.Errors) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Most frequent errors:")
+		for _, e := range rep.Errors {
+			fmt.Fprintf(out, "  %6d  %s  %s\n", e.Count, e.Kind, e.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/benchmarks"
+)
+
+// StartBenchmark starts a benchmark run in the background
+func (c *Client) StartBenchmark(req benchmarks.StartRequest) (*benchmarks.Run, error) {
+	resp, err := c.Post("/benchmarks", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start benchmark: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+	var run benchmarks.Run
+	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
+		return nil, fmt.Errorf("failed to decode benchmark response: %w", err)
+	}
+	return &run, nil
+}
+
+// GetBenchmark returns a benchmark run and its report
+func (c *Client) GetBenchmark(id int64) (*benchmarks.Run, error) {
+	resp, err := c.Get(fmt.Sprintf("/benchmarks/%d", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var run benchmarks.Run
+	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
+		return nil, fmt.Errorf("failed to decode benchmark response: %w", err)
+	}
+	return &run, nil
+}
+
+// ListBenchmarks lists benchmark runs, newest first
+func (c *Client) ListBenchmarks() ([]benchmarks.Run, error) {
+	resp, err := c.Get("/benchmarks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmarks: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var runs []benchmarks.Run
+	if err := json.NewDecoder(resp.Body).Decode(&runs); err != nil {
+		return nil, fmt.Errorf("failed to decode benchmarks list: %w", err)
+	}
+	return runs, nil
+}
+
+// CompareBenchmarks compares finished runs against the first one
+func (c *Client) CompareBenchmarks(ids []int64) (*benchmarks.Comparison, error) {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = strconv.FormatInt(id, 10)
+	}
+	resp, err := c.Get("/benchmarks/compare?ids=" + url.QueryEscape(strings.Join(refs, ",")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare benchmarks: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var comparison benchmarks.Comparison
+	if err := json.NewDecoder(resp.Body).Decode(&comparison); err != nil {
+		return nil, fmt.Errorf("failed to decode comparison: %w", err)
+	}
+	return &comparison, nil
+}
+
+// CancelBenchmark stops a running benchmark
+func (c *Client) CancelBenchmark(id int64) error {
+	resp, err := c.Post(fmt.Sprintf("/benchmarks/%d/cancel", id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel benchmark: %w", err)
+	}
+	defer resp.Body.Close()
+	return CheckResponse(resp, http.StatusNoContent)
+}
+
+// DeleteBenchmark deletes a finished benchmark run
+func (c *Client) DeleteBenchmark(id int64) error {
+	resp, err := c.Delete(fmt.Sprintf("/benchmarks/%d", id))
+	if err != nil {
+		return fmt.Errorf("failed to delete benchmark: %w", err)
+	}
+	defer resp.Body.Close()
+	return CheckResponse(resp, http.StatusNoContent)
+}
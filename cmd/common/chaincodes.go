@@ -0,0 +1,94 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+)
+
+// ListFabricChaincodes lists the chaincodes of every Fabric network
+func (c *Client) ListFabricChaincodes() ([]chainlaunchdeploy.ChaincodeResponse, error) {
+	resp, err := c.Get("/sc/fabric/chaincodes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chaincodes: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result chainlaunchdeploy.ListChaincodesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Chaincodes, nil
+}
+
+// CreateFabricChaincode registers a chaincode on a Fabric network
+func (c *Client) CreateFabricChaincode(req *chainlaunchdeploy.CreateChaincodeRequest) (*chainlaunchdeploy.ChaincodeResponse, error) {
+	resp, err := c.Post("/sc/fabric/chaincodes", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chaincode: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	var result chainlaunchdeploy.CreateChaincodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result.Chaincode, nil
+}
+
+// ListChaincodeDefinitions lists the definitions of a chaincode
+func (c *Client) ListChaincodeDefinitions(chaincodeID int64) ([]chainlaunchdeploy.ChaincodeDefinitionResponse, error) {
+	resp, err := c.Get(fmt.Sprintf("/sc/fabric/chaincodes/%d/definitions", chaincodeID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chaincode definitions: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result chainlaunchdeploy.ListChaincodeDefinitionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Definitions, nil
+}
+
+// CreateChaincodeDefinition creates a definition for a chaincode
+func (c *Client) CreateChaincodeDefinition(req *chainlaunchdeploy.CreateChaincodeDefinitionRequest) (*chainlaunchdeploy.ChaincodeDefinitionResponse, error) {
+	resp, err := c.Post(fmt.Sprintf("/sc/fabric/chaincodes/%d/definitions", req.ChaincodeID), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chaincode definition: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	var result chainlaunchdeploy.CreateChaincodeDefinitionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result.Definition, nil
+}
+
+// UpdateChaincodeDefinition updates a chaincode definition
+func (c *Client) UpdateChaincodeDefinition(definitionID int64, req *chainlaunchdeploy.UpdateChaincodeDefinitionRequest) (*chainlaunchdeploy.ChaincodeDefinitionResponse, error) {
+	resp, err := c.Put(fmt.Sprintf("/sc/fabric/definitions/%d", definitionID), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chaincode definition: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result chainlaunchdeploy.ChaincodeDefinitionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
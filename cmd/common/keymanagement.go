@@ -46,3 +46,30 @@ func (c *Client) GetKey(keyID string) (*models.KeyResponse, error) {
 	}
 	return &keyResp, nil
 }
+
+// ListKeys lists every key, following pagination
+func (c *Client) ListKeys() ([]models.KeyResponse, error) {
+	const pageSize = 100
+	var keys []models.KeyResponse
+	for page := 1; ; page++ {
+		resp, err := c.Get(fmt.Sprintf("/keys?page=%d&pageSize=%d", page, pageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keys: %w", err)
+		}
+		if err := CheckResponse(resp, 200); err != nil {
+			return nil, err
+		}
+		body, err := ReadBody(resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		var result models.PaginatedResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		keys = append(keys, result.Items...)
+		if len(result.Items) < pageSize || int64(len(keys)) >= result.TotalItems {
+			return keys, nil
+		}
+	}
+}
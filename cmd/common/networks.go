@@ -145,3 +145,79 @@ func (c *Client) ListBesuNetworks() (*httptypes.ListNetworksResponse, error) {
 	}
 	return &result, nil
 }
+
+// ListAllNetworks lists every network of a platform ("fabric" or "besu"), following pagination
+func (c *Client) ListAllNetworks(platform string) ([]httptypes.NetworkResponse, error) {
+	const limit = 100
+	var networks []httptypes.NetworkResponse
+	for offset := 0; ; offset += limit {
+		resp, err := c.Get(fmt.Sprintf("/networks/%s?limit=%d&offset=%d", platform, limit, offset))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s networks: %w", platform, err)
+		}
+		var page httptypes.ListNetworksResponse
+		err = CheckResponse(resp, http.StatusOK)
+		if err == nil {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s networks: %w", platform, err)
+		}
+		networks = append(networks, page.Networks...)
+		if len(page.Networks) < limit {
+			return networks, nil
+		}
+	}
+}
+
+// GetFabricNetworkNodes lists the nodes attached to a Fabric network
+func (c *Client) GetFabricNetworkNodes(networkID int64) (*httptypes.GetNetworkNodesResponse, error) {
+	resp, err := c.Get(fmt.Sprintf("/networks/fabric/%d/nodes", networkID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes of network %d: %w", networkID, err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result httptypes.GetNetworkNodesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// GetFabricCurrentChannelConfig fetches the latest config block of a Fabric network as JSON
+func (c *Client) GetFabricCurrentChannelConfig(networkID int64) (*httptypes.ChannelConfigResponse, error) {
+	resp, err := c.Get(fmt.Sprintf("/networks/fabric/%d/current-channel-config", networkID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel config of network %d: %w", networkID, err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result httptypes.ChannelConfigResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// SetFabricAnchorPeers sets the anchor peers of an organization on a Fabric network
+func (c *Client) SetFabricAnchorPeers(networkID int64, req *httptypes.SetAnchorPeersRequest) (*httptypes.SetAnchorPeersResponse, error) {
+	resp, err := c.Post(fmt.Sprintf("/networks/fabric/%d/anchor-peers", networkID), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set anchor peers on network %d: %w", networkID, err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result httptypes.SetAnchorPeersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
@@ -285,3 +285,37 @@ func (c *Client) GetNode(id int64) (*httptypes.NodeResponse, error) {
 
 	return &node, nil
 }
+
+// ListAllNodes lists every node, following pagination
+func (c *Client) ListAllNodes() ([]httptypes.NodeResponse, error) {
+	var nodes []httptypes.NodeResponse
+	for page := 1; ; page++ {
+		result, err := c.ListNodes("", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, result.Items...)
+		if !result.HasNextPage {
+			return nodes, nil
+		}
+	}
+}
+
+// UpdateNode applies a partial update to a node
+func (c *Client) UpdateNode(id int64, req *httptypes.UpdateNodeRequest) (*httptypes.NodeResponse, error) {
+	resp, err := c.Put(fmt.Sprintf("/nodes/%d", id), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	if err := CheckResponse(resp, stdhttp.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	var node httptypes.NodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&node); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &node, nil
+}
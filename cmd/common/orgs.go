@@ -39,3 +39,30 @@ func (c *Client) ListOrganizations() ([]types.OrganizationResponse, error) {
 	}
 	return orgs, nil
 }
+
+// ListAllOrganizations lists every organization, following pagination
+func (c *Client) ListAllOrganizations() ([]types.OrganizationResponse, error) {
+	const limit = 100
+	var orgs []types.OrganizationResponse
+	for offset := 0; ; offset += limit {
+		resp, err := c.Get(fmt.Sprintf("/organizations?limit=%d&offset=%d", limit, offset))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		var page types.PaginatedOrganizationsResponse
+		err = CheckResponse(resp, http.StatusOK)
+		if err == nil {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		for _, org := range page.Items {
+			orgs = append(orgs, *org)
+		}
+		if len(page.Items) < limit {
+			return orgs, nil
+		}
+	}
+}
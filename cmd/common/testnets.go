@@ -0,0 +1,101 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
+)
+
+// CreateTestnet registers a testnet before its resources are created
+func (c *Client) CreateTestnet(name string, platform testnets.Platform) (*testnets.Testnet, error) {
+	resp, err := c.Post("/testnets", testnets.CreateTestnetRequest{Name: name, Platform: platform})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create testnet: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	var testnet testnets.Testnet
+	if err := json.NewDecoder(resp.Body).Decode(&testnet); err != nil {
+		return nil, fmt.Errorf("failed to decode testnet response: %w", err)
+	}
+	return &testnet, nil
+}
+
+// AddTestnetResources records resources created for a testnet
+func (c *Client) AddTestnetResources(testnetID int64, refs ...testnets.ResourceRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	resp, err := c.Post(fmt.Sprintf("/testnets/%d/resources", testnetID), testnets.AddResourcesRequest{Resources: refs})
+	if err != nil {
+		return fmt.Errorf("failed to record testnet resources: %w", err)
+	}
+	defer resp.Body.Close()
+	return CheckResponse(resp, http.StatusOK)
+}
+
+// SetTestnetStatus marks the outcome of creating a testnet
+func (c *Client) SetTestnetStatus(testnetID int64, status testnets.Status, errMsg string) error {
+	resp, err := c.Put(fmt.Sprintf("/testnets/%d/status", testnetID), testnets.UpdateStatusRequest{Status: status, Error: errMsg})
+	if err != nil {
+		return fmt.Errorf("failed to update testnet status: %w", err)
+	}
+	defer resp.Body.Close()
+	return CheckResponse(resp, http.StatusOK)
+}
+
+// ListTestnets lists all testnets, newest first
+func (c *Client) ListTestnets() ([]testnets.Testnet, error) {
+	resp, err := c.Get("/testnets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list testnets: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var list []testnets.Testnet
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode testnets list: %w", err)
+	}
+	return list, nil
+}
+
+// GetTestnet returns a testnet by ID or name
+func (c *Client) GetTestnet(ref string) (*testnets.Testnet, error) {
+	resp, err := c.Get("/testnets/" + url.PathEscape(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get testnet: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var testnet testnets.Testnet
+	if err := json.NewDecoder(resp.Body).Decode(&testnet); err != nil {
+		return nil, fmt.Errorf("failed to decode testnet response: %w", err)
+	}
+	return &testnet, nil
+}
+
+// DestroyTestnet stops and deletes every resource of a testnet
+func (c *Client) DestroyTestnet(ref string) (*testnets.DestroyResult, error) {
+	resp, err := c.Delete("/testnets/" + url.PathEscape(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to destroy testnet: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result testnets.DestroyResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode destroy response: %w", err)
+	}
+	return &result, nil
+}
@@ -15,6 +15,8 @@ type pullCmd struct {
 	networkName string
 	mspID       string
 	output      string
+	format      string
+	identities  []string
 	baseURL     string
 	username    string
 	password    string
@@ -53,7 +55,7 @@ func (c *pullCmd) run(out io.Writer) error {
 	}
 
 	// Get network config
-	configBytes, err := apiClient.GetNetworkConfig(network.ID, org.ID)
+	configBytes, err := apiClient.GetConnectionProfile(network.ID, org.ID, c.format, c.identities)
 	if err != nil {
 		return fmt.Errorf("failed to get network config: %w", err)
 	}
@@ -100,6 +102,8 @@ func NewNCCmd(logger *logger.Logger) *cobra.Command {
 	flags.StringVarP(&pullCmd.networkName, "network", "n", "", "Network name")
 	flags.StringVarP(&pullCmd.mspID, "msp-id", "m", "", "MSP ID")
 	flags.StringVarP(&pullCmd.output, "output", "f", "", "Output file (default: stdout)")
+	flags.StringVar(&pullCmd.format, "format", "fabric-sdk", "Format: fabric-sdk, gateway, connection-profile, caliper or explorer")
+	flags.StringSliceVar(&pullCmd.identities, "identities", nil, "Identities to embed: admin, client or key IDs (default: admin for fabric-sdk)")
 	flags.StringVar(&pullCmd.baseURL, "url", pullCmd.baseURL, "Base URL of the API server")
 	flags.StringVarP(&pullCmd.username, "username", "u", "", "Username for basic auth")
 	flags.StringVarP(&pullCmd.password, "password", "p", "", "Password for basic auth")
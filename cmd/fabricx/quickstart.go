@@ -45,6 +45,7 @@ import (
 	"time"
 
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
 	"github.com/spf13/cobra"
 )
 
@@ -284,7 +285,10 @@ Hardening:
   --join-retries       retry count for transient Docker errors on node join.
   --join-retry-backoff delay between join retries.
   --clean              tear down any prior bundle with this network name
-                       before provisioning (API deletes — no sqlite poking).`,
+                       before provisioning (API deletes — no sqlite poking).
+
+Everything created is recorded as a testnet named after the network, so
+'chainlaunch testnet destroy <network-name>' removes the whole bundle.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if ctx == nil {
@@ -328,12 +332,21 @@ Hardening:
 			}
 
 			if clean {
+				if err := forgetTestnet(ctx, c, cfg.networkName); err != nil {
+					return fmt.Errorf("--clean failed to destroy testnet: %w", err)
+				}
 				if err := cleanBundle(ctx, c, cfg); err != nil {
 					return fmt.Errorf("--clean failed: %w", err)
 				}
 			}
 
-			return runQuickstart(ctx, c, cfg)
+			rec, err := registerTestnet(ctx, c, cfg.networkName)
+			if err != nil {
+				return fmt.Errorf("register testnet %q (pass --clean to replace a previous run): %w", cfg.networkName, err)
+			}
+			err = runQuickstart(ctx, c, cfg, rec)
+			rec.finish(ctx, err)
+			return err
 		},
 	}
 
@@ -396,7 +409,7 @@ type quickstartConfig struct {
 // runQuickstart is the orchestrator. Each phase prints a one-line status so the
 // operator can follow progress in CI logs; failures include the HTTP body so
 // the root cause is visible without re-reading the server log.
-func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) error {
+func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig, rec *testnetRecorder) error {
 	if cfg.channelID != defaultChannelID {
 		return fmt.Errorf("FabricX requires --channel=%s (got %q)", defaultChannelID, cfg.channelID)
 	}
@@ -426,10 +439,15 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 	// but we skip the redundant calls to keep the log readable.
 	if cfg.mode == "single" {
 		status("Ensuring organization %s (single-MSP mode — shared by all %d parties)", cfg.singleMSPID, cfg.numParties)
-		orgID, err := findOrCreateOrg(ctx, c, cfg.singleMSPID)
+		orgID, created, err := findOrCreateOrg(ctx, c, cfg.singleMSPID)
 		if err != nil {
 			return fmt.Errorf("org %s: %w", cfg.singleMSPID, err)
 		}
+		if created {
+			if err := rec.add(ctx, testnets.ResourceOrganization, orgID); err != nil {
+				return err
+			}
+		}
 		for i := range parties {
 			parties[i].organizationID = orgID
 		}
@@ -437,10 +455,15 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 	} else {
 		for i := range parties {
 			status("Ensuring organization %s", parties[i].mspID)
-			orgID, err := findOrCreateOrg(ctx, c, parties[i].mspID)
+			orgID, created, err := findOrCreateOrg(ctx, c, parties[i].mspID)
 			if err != nil {
 				return fmt.Errorf("org %s: %w", parties[i].mspID, err)
 			}
+			if created {
+				if err := rec.add(ctx, testnets.ResourceOrganization, orgID); err != nil {
+					return err
+				}
+			}
 			parties[i].organizationID = orgID
 			done("  → org #%d", orgID)
 		}
@@ -453,6 +476,9 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 	if err != nil {
 		return fmt.Errorf("postgres service: %w", err)
 	}
+	if err := rec.add(ctx, testnets.ResourceService, pgID); err != nil {
+		return err
+	}
 	done("  → service #%d", pgID)
 
 	status("Starting shared Postgres container (host :%d)", cfg.postgresPort)
@@ -476,6 +502,12 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 		if err != nil {
 			return fmt.Errorf("orderer group %s: %w", parties[i].mspID, err)
 		}
+		if err := rec.add(ctx, testnets.ResourceNodeGroup, groupID); err != nil {
+			return err
+		}
+		if err := rec.add(ctx, testnets.ResourceNode, childIDs...); err != nil {
+			return err
+		}
 		parties[i].ordererNodeGroupID = groupID
 		parties[i].ordererChildNodeIDs = childIDs
 		done("  → group #%d, children %v", groupID, childIDs)
@@ -499,6 +531,12 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 		if err != nil {
 			return fmt.Errorf("committer %s: %w", p.mspID, err)
 		}
+		if err := rec.add(ctx, testnets.ResourceNodeGroup, groupID); err != nil {
+			return err
+		}
+		if err := rec.add(ctx, testnets.ResourceNode, nodeID); err != nil {
+			return err
+		}
 		// Mirror onto every party so the existing Phase 8 join loop +
 		// Phase 4 network-create body keep working unchanged. They all
 		// point at the same committer.
@@ -514,6 +552,12 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 			if err != nil {
 				return fmt.Errorf("committer %s: %w", parties[i].mspID, err)
 			}
+			if err := rec.add(ctx, testnets.ResourceNodeGroup, groupID); err != nil {
+				return err
+			}
+			if err := rec.add(ctx, testnets.ResourceNode, nodeID); err != nil {
+				return err
+			}
 			parties[i].committerNodeGroupID = groupID
 			parties[i].committerNodeID = nodeID
 			done("  → group #%d, node #%d", groupID, nodeID)
@@ -526,6 +570,9 @@ func runQuickstart(ctx context.Context, c *apiClient, cfg quickstartConfig) erro
 	if err != nil {
 		return fmt.Errorf("create network: %w", err)
 	}
+	if err := rec.add(ctx, testnets.ResourceNetwork, networkID); err != nil {
+		return err
+	}
 	done("  → network #%d", networkID)
 
 	// Phase 8: joins with retry + post-join status verification.
@@ -691,24 +738,26 @@ func fetchDefaultExternalIP(ctx context.Context, c *apiClient) (string, error) {
 	return body.Defaults[0].ExternalIP, nil
 }
 
-func findOrCreateOrg(ctx context.Context, c *apiClient, mspID string) (int64, error) {
+// findOrCreateOrg returns the organization with mspID, creating it when
+// missing. The bool reports whether it was created by this call.
+func findOrCreateOrg(ctx context.Context, c *apiClient, mspID string) (int64, bool, error) {
 	resp, err := c.get(ctx, "/organizations?limit=1000")
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 	if resp.StatusCode != http.StatusOK {
 		b, _ := readBody(resp)
-		return 0, fmt.Errorf("list orgs: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+		return 0, false, fmt.Errorf("list orgs: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
 	}
 	var list orgListResponse
 	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
 		resp.Body.Close()
-		return 0, fmt.Errorf("decode org list: %w", err)
+		return 0, false, fmt.Errorf("decode org list: %w", err)
 	}
 	resp.Body.Close()
 	for _, o := range list.Items {
 		if o.MspID == mspID {
-			return o.ID, nil
+			return o.ID, false, nil
 		}
 	}
 
@@ -719,22 +768,22 @@ func findOrCreateOrg(ctx context.Context, c *apiClient, mspID string) (int64, er
 	}
 	cresp, err := c.post(ctx, "/organizations", body)
 	if err != nil {
-		return 0, err
+		return 0, false, err
 	}
 	defer cresp.Body.Close()
 	if cresp.StatusCode != http.StatusCreated {
-		return 0, errors.New(readErrorBody(cresp))
+		return 0, false, errors.New(readErrorBody(cresp))
 	}
 	var created struct {
 		ID int64 `json:"id"`
 	}
 	if err := json.NewDecoder(cresp.Body).Decode(&created); err != nil {
-		return 0, fmt.Errorf("decode created org: %w", err)
+		return 0, false, fmt.Errorf("decode created org: %w", err)
 	}
 	if created.ID == 0 {
-		return 0, fmt.Errorf("server returned no id for %s", mspID)
+		return 0, false, fmt.Errorf("server returned no id for %s", mspID)
 	}
-	return created.ID, nil
+	return created.ID, true, nil
 }
 
 func findOrCreatePostgresService(ctx context.Context, c *apiClient, cfg quickstartConfig) (int64, error) {
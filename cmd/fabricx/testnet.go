@@ -0,0 +1,90 @@
+package fabricx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
+)
+
+// testnetRecorder records what the quickstart creates as a FABRICX testnet so
+// `chainlaunch testnet destroy` can remove the whole bundle. A nil recorder
+// records nothing.
+type testnetRecorder struct {
+	c  *apiClient
+	id int64
+}
+
+// registerTestnet creates the testnet record named after the network
+func registerTestnet(ctx context.Context, c *apiClient, name string) (*testnetRecorder, error) {
+	resp, err := c.post(ctx, "/testnets", testnets.CreateTestnetRequest{Name: name, Platform: testnets.PlatformFabricX})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.New(readErrorBody(resp))
+	}
+	var created testnets.Testnet
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("decode testnet: %w", err)
+	}
+	return &testnetRecorder{c: c, id: created.ID}, nil
+}
+
+// forgetTestnet destroys the testnet record of a previous run, together with
+// whatever it still owns. A missing record is not an error.
+func forgetTestnet(ctx context.Context, c *apiClient, name string) error {
+	resp, err := c.delete_(ctx, "/testnets/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return errors.New(readErrorBody(resp))
+	}
+	return nil
+}
+
+func (r *testnetRecorder) add(ctx context.Context, t testnets.ResourceType, ids ...int64) error {
+	if r == nil || len(ids) == 0 {
+		return nil
+	}
+	refs := make([]testnets.ResourceRef, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, testnets.ResourceRef{Type: t, ID: id})
+	}
+	resp, err := r.c.post(ctx, fmt.Sprintf("/testnets/%d/resources", r.id), testnets.AddResourcesRequest{Resources: refs})
+	if err != nil {
+		return fmt.Errorf("record testnet resources: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("record testnet resources: %s", readErrorBody(resp))
+	}
+	return nil
+}
+
+// finish marks the testnet READY, or FAILED with runErr
+func (r *testnetRecorder) finish(ctx context.Context, runErr error) {
+	if r == nil {
+		return
+	}
+	req := testnets.UpdateStatusRequest{Status: testnets.StatusReady}
+	if runErr != nil {
+		req = testnets.UpdateStatusRequest{Status: testnets.StatusFailed, Error: runErr.Error()}
+	}
+	resp, err := r.c.do(ctx, http.MethodPut, fmt.Sprintf("/testnets/%d/status", r.id), req)
+	if err != nil {
+		warn("  ⚠ failed to update testnet status: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		warn("  ⚠ failed to update testnet status: %s", readErrorBody(resp))
+	}
+}
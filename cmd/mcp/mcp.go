@@ -0,0 +1,148 @@
+// Package mcp provides the 'mcp' command serving ChainLaunch operations over the Model Context Protocol.
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/mcp"
+	"github.com/chainlaunch/chainlaunch/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+const defaultAPIURL = "http://localhost:8100/api/v1"
+
+// MCPConfig holds the parameters of the MCP server
+type MCPConfig struct {
+	Transport string
+	Addr      string
+	APIURL    string
+	Username  string
+	Password  string
+	Token     string
+}
+
+// MCPRunner encapsulates the config and logic for running the MCP server
+type MCPRunner struct {
+	Config MCPConfig
+}
+
+// Validate checks the configuration for required fields
+func (r *MCPRunner) Validate() error {
+	switch r.Config.Transport {
+	case "stdio":
+		if r.Config.Token == "" && (r.Config.Username == "" || r.Config.Password == "") {
+			return fmt.Errorf("--token or --user and --password are required with the stdio transport")
+		}
+	case "http":
+		if r.Config.Addr == "" {
+			return fmt.Errorf("--addr is required with the http transport")
+		}
+	default:
+		return fmt.Errorf("--transport must be stdio or http")
+	}
+	return nil
+}
+
+// Run starts the MCP server until it is interrupted
+func (r *MCPRunner) Run() error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	// stdout carries the protocol messages of the stdio transport, so logs go to stderr
+	log, err := logger.New(&logger.Config{Level: "info", OutputPath: "stderr", Format: "console"})
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	username, password, token := r.Config.Username, r.Config.Password, r.Config.Token
+	if r.Config.Transport == "http" {
+		// HTTP callers authenticate with their own Authorization header
+		if token != "" || username != "" || password != "" {
+			log.Warn("The configured credentials are ignored by the http transport")
+		}
+		username, password, token = "", "", ""
+	}
+	client, err := mcp.NewClient(r.Config.APIURL, username, password, token)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if client.HasCredentials() {
+		user, err := client.CurrentUser(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to authenticate with %s: %w", r.Config.APIURL, err)
+		}
+		log.Info("Authenticated with ChainLaunch", "user", user.Username, "role", user.Role)
+	}
+	server := mcp.NewServer(client, log, version.Version)
+
+	if r.Config.Transport == "stdio" {
+		return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", server)
+	httpServer := &http.Server{
+		Addr:              r.Config.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+	log.Info("Serving MCP over HTTP", "url", fmt.Sprintf("http://%s/mcp", r.Config.Addr))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("MCP server failed: %w", err)
+	}
+	return nil
+}
+
+// NewMCPCmd creates the 'mcp' command
+func NewMCPCmd() *cobra.Command {
+	runner := &MCPRunner{}
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve ChainLaunch operations to AI clients over the Model Context Protocol",
+		Long: `Serve curated tools and resources over the Model Context Protocol: organizations, nodes,
+networks, blocks, chaincode definitions, key metadata and audit logs.
+
+The server calls the ChainLaunch API as the configured user, so the role of the user decides
+which tools are available. With the http transport, each request must carry an Authorization
+header, which is forwarded to the API; the configured credentials are only used by the stdio
+transport.`,
+		Example: `  # Serve over stdio for a local AI client
+  CHAINLAUNCH_USER=admin CHAINLAUNCH_PASSWORD=secret chainlaunch mcp
+
+  # Serve over HTTP on port 8200
+  chainlaunch mcp --transport http --addr 127.0.0.1:8200`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runner.Run()
+		},
+	}
+
+	apiURL := os.Getenv("CHAINLAUNCH_API_URL")
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&runner.Config.Transport, "transport", "stdio", "Transport: stdio or http")
+	flags.StringVar(&runner.Config.Addr, "addr", "127.0.0.1:8200", "Listen address of the http transport")
+	flags.StringVar(&runner.Config.APIURL, "url", apiURL, "ChainLaunch API URL (env CHAINLAUNCH_API_URL)")
+	flags.StringVar(&runner.Config.Username, "user", os.Getenv("CHAINLAUNCH_USER"), "ChainLaunch username of the stdio transport (env CHAINLAUNCH_USER)")
+	flags.StringVar(&runner.Config.Password, "password", os.Getenv("CHAINLAUNCH_PASSWORD"), "ChainLaunch password of the stdio transport (env CHAINLAUNCH_PASSWORD)")
+	flags.StringVar(&runner.Config.Token, "token", os.Getenv("CHAINLAUNCH_TOKEN"), "ChainLaunch session token of the stdio transport, used instead of the username and password (env CHAINLAUNCH_TOKEN)")
+	return cmd
+}
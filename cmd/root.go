@@ -4,11 +4,14 @@ Copyright © 2025 ChainLaunch <dviejo@chainlaunch.dev>
 package cmd
 
 import (
+	"github.com/chainlaunch/chainlaunch/cmd/apply"
 	"github.com/chainlaunch/chainlaunch/cmd/backup"
+	"github.com/chainlaunch/chainlaunch/cmd/benchmark"
 	"github.com/chainlaunch/chainlaunch/cmd/besu"
 	"github.com/chainlaunch/chainlaunch/cmd/fabric"
 	"github.com/chainlaunch/chainlaunch/cmd/fabricx"
 	"github.com/chainlaunch/chainlaunch/cmd/keymanagement"
+	"github.com/chainlaunch/chainlaunch/cmd/mcp"
 	"github.com/chainlaunch/chainlaunch/cmd/metrics"
 	"github.com/chainlaunch/chainlaunch/cmd/networks"
 	"github.com/chainlaunch/chainlaunch/cmd/serve"
@@ -41,6 +44,9 @@ func NewRootCmd(configCMD config.ConfigCMD) *cobra.Command {
 	rootCmd.AddCommand(keymanagement.NewKeyManagementCmd())
 	rootCmd.AddCommand(testnet.NewTestnetCmd())
 	rootCmd.AddCommand(metrics.NewMetricsCmd())
+	rootCmd.AddCommand(apply.NewApplyCmd(logger))
+	rootCmd.AddCommand(benchmark.NewBenchmarkCmd())
+	rootCmd.AddCommand(mcp.NewMCPCmd())
 	// In the function where rootCmd is defined and commands are added:
 	// rootCmd.AddCommand(testnet.NewTestnetCmd())
 	return rootCmd
@@ -19,11 +19,15 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/auth"
 	backuphttp "github.com/chainlaunch/chainlaunch/pkg/backups/http"
 	backupservice "github.com/chainlaunch/chainlaunch/pkg/backups/service"
+	"github.com/chainlaunch/chainlaunch/pkg/benchmarks"
+	benchmarkshttp "github.com/chainlaunch/chainlaunch/pkg/benchmarks/http"
 	configservice "github.com/chainlaunch/chainlaunch/pkg/config"
 	"github.com/chainlaunch/chainlaunch/pkg/crypto"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabrichandler "github.com/chainlaunch/chainlaunch/pkg/fabric/handler"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+	hostshttp "github.com/chainlaunch/chainlaunch/pkg/hosts/http"
 	"github.com/chainlaunch/chainlaunch/pkg/http/response"
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/handler"
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
@@ -32,8 +36,6 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/monitoring"
 	ngroupshttp "github.com/chainlaunch/chainlaunch/pkg/nodegroups/http"
 	ngroupsservice "github.com/chainlaunch/chainlaunch/pkg/nodegroups/service"
-	svchttp "github.com/chainlaunch/chainlaunch/pkg/services/http"
-	svcservice "github.com/chainlaunch/chainlaunch/pkg/services/service"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/fabricx"
 	nodeTypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/ai"
@@ -42,13 +44,21 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/scai/files"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/projectrunner"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/projects"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projecttests"
+	svchttp "github.com/chainlaunch/chainlaunch/pkg/services/http"
+	svcservice "github.com/chainlaunch/chainlaunch/pkg/services/service"
 
 	"github.com/chainlaunch/chainlaunch/pkg/audit"
 	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
 	"github.com/chainlaunch/chainlaunch/pkg/metrics"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/instrumentation"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/consensus"
 	networkshttp "github.com/chainlaunch/chainlaunch/pkg/networks/http"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/indexer"
 	networksservice "github.com/chainlaunch/chainlaunch/pkg/networks/service"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/template"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/upgrade"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
 	nodeshttp "github.com/chainlaunch/chainlaunch/pkg/nodes/http"
 	nodesservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
 	notificationhttp "github.com/chainlaunch/chainlaunch/pkg/notifications/http"
@@ -56,8 +66,10 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/plugin"
 	pluginregistry "github.com/chainlaunch/chainlaunch/pkg/plugin/registry"
 	settingshttp "github.com/chainlaunch/chainlaunch/pkg/settings/http"
-	systemhttp "github.com/chainlaunch/chainlaunch/pkg/system/http"
 	settingsservice "github.com/chainlaunch/chainlaunch/pkg/settings/service"
+	systemhttp "github.com/chainlaunch/chainlaunch/pkg/system/http"
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
+	testnetshttp "github.com/chainlaunch/chainlaunch/pkg/testnets/http"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -208,6 +220,7 @@ const (
 	keyLength         = 32 // 256 bits
 	encryptionKeyFile = "encryption_key"
 	sessionKeyFile    = "session_key"
+	metricsTokenFile  = "metrics_token"
 )
 
 // Add these new functions
@@ -310,6 +323,9 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 	logger := logger.NewDefault()
 
 	auditService := audit.NewService(queries, 10)
+	if err := instrumentation.RegisterAuditQueueDepth(auditService.QueueDepth); err != nil {
+		logger.Warnf("Failed to register audit queue depth metric: %v", err)
+	}
 
 	nodeEventService := nodesservice.NewNodeEventService(queries, logger)
 	settingsService := settingsservice.NewSettingsService(queries, logger)
@@ -322,16 +338,26 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 
 	// Initialize metrics service
 	metricsConfig := metricscommon.DefaultConfig()
+	// Let Prometheus scrape ChainLaunch's own /metrics endpoint
+	metricsConfig.SelfMetricsPort = c.port
+	metricsConfig.SelfMetricsTLS = c.tlsCertFile != "" && c.tlsKeyFile != ""
+	if !c.metricsPublic {
+		metricsConfig.SelfMetricsToken = c.metricsToken
+	}
 	nodesService := nodesservice.NewNodeService(queries, logger, keyManagementService, organizationService, nodeEventService, configService, settingsService)
-	metricsService, err := metrics.NewService(metricsConfig, queries, nodesService, configService)
+	notificationService := notificationservice.NewNotificationService(queries, logger)
+	metricsService, err := metrics.NewService(metricsConfig, queries, nodesService, configService, notificationService, encryptor)
 	if err != nil {
 		log.Fatal("Failed to initialize metrics service:", err)
 	}
 	nodesService.SetMetricsService(metricsService)
 	metricsHandler := metrics.NewHandler(metricsService, logger)
+	// Nodes placed on remote hosts are started there over SSH
+	hostService := hosts.NewService(queries, keyManagementService, configService.GetDataPath(), logger)
+	nodesService.SetHostService(hostService)
+	hostsHandler := hostshttp.NewHandler(hostService)
 
 	networksService := networksservice.NewNetworkService(queries, nodesService, keyManagementService, logger, organizationService, configService)
-	notificationService := notificationservice.NewNotificationService(queries, logger)
 
 	backupService := backupservice.NewBackupService(queries, logger, notificationService, dbPath, configService, encryptor)
 
@@ -472,13 +498,23 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 		log.Fatal("Failed to initialize plugin manager:", err)
 	}
 	// --- Registry integration for GitHub plugins ---
+	var pluginPublisherKeys []string
+	for _, keyFile := range c.pluginPublisherKeys {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			log.Fatalf("Failed to read plugin publisher key %s: %v", keyFile, err)
+		}
+		pluginPublisherKeys = append(pluginPublisherKeys, string(key))
+	}
 	regConfig := &pluginregistry.RegistryConfig{
 		Sources: []pluginregistry.RegistrySource{
 			{
-				Name:    "plugin-hlf-api",
-				Type:    "github",
-				URL:     "https://github.com/kfsoftware/plugin-hlf-api", // Example public repo
-				Enabled: true,
+				Name:          "plugin-hlf-api",
+				Type:          "github",
+				URL:           "https://github.com/kfsoftware/plugin-hlf-api", // Example public repo
+				Enabled:       true,
+				PublisherKeys: pluginPublisherKeys,
+				AllowUnsigned: c.pluginAllowUnsigned,
 			},
 		},
 	}
@@ -504,16 +540,42 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 	registryStore := plugin.NewRegistryStore(pluginStore, reg)
 	pluginHandler := plugin.NewHandler(registryStore, pluginManager, logger, reg, availablePluginsCache)
 
+	// Start the plugin supervisor, which restarts unhealthy plugins and reports them to monitoring
+	pluginSupervisor := plugin.NewSupervisor(pluginManager, registryStore, notificationService, monitoringService, logger, plugin.DefaultSupervisorConfig())
+	pluginSupervisorCtx, pluginSupervisorCancel := context.WithCancel(context.Background())
+	pluginSupervisor.Start(pluginSupervisorCtx)
+
+	// Register shutdown handler for the plugin supervisor
+	go func() {
+		c := make(chan os.Signal, 1)
+		<-c
+		pluginSupervisorCancel()
+		pluginSupervisor.Stop()
+	}()
+
 	// --- Smart contract deployment handler (Fabric & Besu) ---
 	// Import the EVM deployer constructor
 	besuDeployer := chainlaunchdeploy.NewDeployerWithAudit(auditService)
 	chaincodeService := chainlaunchdeploy.NewChaincodeService(queries, logger, nodesService, keyManagementService)
-	scHandler := chainlaunchdeploy.NewHandler(auditService, logger, besuDeployer, nodesService, chaincodeService, networksService)
+	contractService := chainlaunchdeploy.NewContractService(queries, logger, networksService, keyManagementService, besuDeployer)
+	scHandler := chainlaunchdeploy.NewHandler(auditService, logger, besuDeployer, nodesService, chaincodeService, networksService, contractService)
+	// Load tests against deployed chaincodes and contracts
+	benchmarksService := benchmarks.NewService(queries, chaincodeService, nodesService, keyManagementService, logger)
+	if err := benchmarksService.FailInterrupted(context.Background()); err != nil {
+		logger.Warn("Failed to mark interrupted benchmark runs", "error", err)
+	}
+	benchmarksHandler := benchmarkshttp.NewHandler(benchmarksService)
 
 	// Initialize handlers
 	keyManagementHandler := handler.NewKeyManagementHandler(keyManagementService)
 	organizationHandler := fabrichandler.NewOrganizationHandler(organizationService)
-	nodesHandler := nodeshttp.NewNodeHandler(nodesService, logger)
+	// Periodically compare running nodes with their stored configuration
+	var driftScanner *drift.Scanner
+	if c.driftInterval > 0 {
+		driftScanner = drift.NewScanner(nodesService, logger, c.driftInterval, c.driftReconcile)
+		go driftScanner.Start(context.Background())
+	}
+	nodesHandler := nodeshttp.NewNodeHandler(nodesService, logger, driftScanner)
 
 	// Node-groups coordinator and handler. The factories bake the heavy
 	// deps (org service, key service, config service) into fabricx
@@ -538,9 +600,28 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 	servicesService := svcservice.NewService(queries, logger).
 		WithDataPath(dataPath)
 	servicesHandler := svchttp.NewHandler(servicesService)
+	// Testnets record what the testnet commands create so they can be destroyed as a unit
+	testnetsService := testnets.NewService(queries, nodesService, networksService, nodeGroupsService, servicesService, organizationService, keyManagementService, logger)
+	testnetsHandler := testnetshttp.NewHandler(testnetsService)
+	// Start the block indexer for networks with indexing enabled
+	blockIndexer := indexer.NewService(queries, networksService, logger)
+	go blockIndexer.Start(context.Background())
+	// Rolling upgrades left running by a previous process are paused for review
+	upgradeService := upgrade.NewService(queries, networksService, nodesService, logger)
+	if err := upgradeService.RecoverInterrupted(context.Background()); err != nil {
+		logger.Warn("Failed to recover interrupted upgrade plans", "error", err)
+	}
+	// So are interrupted etcdraft to SmartBFT consensus migrations
+	consensusService := consensus.NewService(queries, networksService, nodesService, logger)
+	if err := consensusService.RecoverInterrupted(context.Background()); err != nil {
+		logger.Warn("Failed to recover interrupted consensus migrations", "error", err)
+	}
 	networksHandler := networkshttp.NewHandler(
 		networksService,
 		nodesService,
+		blockIndexer,
+		upgradeService,
+		consensusService,
 	)
 
 	// Initialize template service and handler
@@ -576,7 +657,7 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 		logger.Warnf("Failed to get absolute path for projects directory: %v", err)
 		return nil
 	}
-	aiHandler, filesHandler, dirsHandler, err := c.initializeAIServices(queries, logger, projectDirAbs, organizationService, keyManagementService, networksService)
+	aiHandler, filesHandler, dirsHandler, err := c.initializeAIServices(queries, logger, projectDirAbs, organizationService, keyManagementService, networksService, contractService, nodesService, metricsService)
 	if err != nil {
 		logger.Warnf("Failed to initialize AI services: %v", err)
 		return nil
@@ -589,13 +670,24 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 		dirsService := dirs.NewDirsService(projectDirAbs)
 		filesService := files.NewFilesService()
 		runner := projectrunner.NewRunner(queries)
-		projectsService, err := projects.NewProjectsService(queries, runner, projectDirAbs, organizationService, keyManagementService, networksService)
+		projectsService, err := projects.NewProjectsService(queries, runner, projectDirAbs, organizationService, keyManagementService, networksService, contractService)
 		if err != nil {
 			logger.Warnf("Failed to create projects service: %v - AI services will not be available", err)
 			return nil
 		}
+		projectsService.DeployService = chaincodeService
+		projectsService.ImageRegistry = projects.ImageRegistry{
+			URL:      c.chaincodeRegistry,
+			Username: c.chaincodeRegistryUsername,
+			Password: c.chaincodeRegistryPassword,
+		}
+		if err := projectsService.FailInterruptedPromotions(context.Background()); err != nil {
+			logger.Warnf("Failed to mark interrupted project promotions as failed: %v", err)
+		}
 		chaincodeProjectInvocationService := projects.NewChaincodeService(queries, logger, projectsService, networksService, nodesService)
 		projectsHandler = projects.NewProjectsHandler(projectsService, projectDirAbs, chaincodeProjectInvocationService, logger)
+		// Share the test service of the AI tools so a project runs one test suite at a time
+		projectsHandler.Tests = aiHandler.AIChatService.TestService
 		// Create handlers
 		dirsHandler = dirs.NewDirsHandler(dirsService, projectsService)
 		filesHandler = files.NewFilesHandler(filesService, projectsService)
@@ -611,6 +703,7 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(instrumentation.HTTPMiddleware)
 
 	// Add CORS middleware
 	r.Use(cors.Handler(cors.Options{
@@ -626,6 +719,8 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 	r.Route("/api/v1", func(r chi.Router) {
 		// Public routes (no auth required)
 		r.Post("/auth/login", response.Middleware(authHandler.LoginHandler))
+		// Alertmanager webhook (authenticated with its own bearer token)
+		metricsHandler.RegisterPublicRoutes(r)
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
@@ -645,8 +740,14 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 			nodeGroupsHandler.RegisterRoutes(r)
 
 			servicesHandler.RegisterRoutes(r)
+			// Mount remote hosts routes
+			hostsHandler.RegisterRoutes(r)
 			// Mount networks routes
 			networksHandler.RegisterRoutes(r)
+			// Mount testnet routes
+			testnetsHandler.RegisterRoutes(r)
+			// Mount benchmark routes
+			benchmarksHandler.RegisterRoutes(r)
 			// Mount template routes
 			templateHandler.RegisterTemplateRoutes(r)
 			// Mount backups routes
@@ -684,6 +785,14 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 			}
 		})
 	})
+	// ChainLaunch operational metrics in the Prometheus exposition format, they expose route
+	// patterns and node and network names so they require a bearer token unless made public
+	if c.metricsPublic {
+		r.Handle("/metrics", instrumentation.Handler())
+	} else {
+		r.Handle("/metrics", instrumentation.ProtectedHandler(c.metricsToken))
+	}
+
 	r.Get("/api/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/api/swagger/doc.json"),
 		httpSwagger.DeepLinking(true),
@@ -712,7 +821,7 @@ func (c *serveCmd) setupServer(queries *db.Queries, authService *auth.AuthServic
 }
 
 // initializeAIServices initializes AI-related services and returns the AI handler if successful
-func (c *serveCmd) initializeAIServices(queries *db.Queries, logger *logger.Logger, projectsDir string, organizationService *fabricservice.OrganizationService, keyManagementService *service.KeyManagementService, networksService *networksservice.NetworkService) (*ai.AIHandler, *files.FilesHandler, *dirs.DirsHandler, error) {
+func (c *serveCmd) initializeAIServices(queries *db.Queries, logger *logger.Logger, projectsDir string, organizationService *fabricservice.OrganizationService, keyManagementService *service.KeyManagementService, networksService *networksservice.NetworkService, contractService *chainlaunchdeploy.ContractService, nodesService *nodesservice.NodeService, metricsService metricscommon.Service) (*ai.AIHandler, *files.FilesHandler, *dirs.DirsHandler, error) {
 	// Check if AI provider is configured
 	if c.aiProvider == "" {
 		return nil, nil, nil, nil
@@ -720,7 +829,7 @@ func (c *serveCmd) initializeAIServices(queries *db.Queries, logger *logger.Logg
 
 	// Initialize projectsService
 	runner := projectrunner.NewRunner(queries)
-	projectsService, err := projects.NewProjectsService(queries, runner, projectsDir, organizationService, keyManagementService, networksService)
+	projectsService, err := projects.NewProjectsService(queries, runner, projectsDir, organizationService, keyManagementService, networksService, contractService)
 	if err != nil {
 		logger.Warnf("Failed to create projects service: %v - AI services will not be available", err)
 		return nil, nil, nil, nil
@@ -756,10 +865,34 @@ func (c *serveCmd) initializeAIServices(queries *db.Queries, logger *logger.Logg
 		}
 		openAIProvider := ai.NewOpenAIProvider(c.openaiKey, logger)
 		aiService = ai.NewAIChatService(logger, chatService, queries, projectsDir, openAIProvider, c.aiModel, boilerplateService)
+	case "openai-compatible":
+		compatibleProvider, err := ai.NewOpenAICompatibleProvider(ai.OpenAICompatibleConfig{
+			BaseURL:       c.aiBaseURL,
+			Models:        c.aiModels,
+			AuthHeader:    c.aiAuthHeader,
+			AuthValue:     c.aiAuthValue,
+			ToolCalling:   ai.ToolCallingMode(c.aiToolCalling),
+			ContextWindow: c.aiContextWindow,
+		}, logger)
+		if err != nil {
+			logger.Warnf("Invalid OpenAI-compatible provider configuration: %v - AI services will not be available", err)
+			return nil, nil, nil, nil
+		}
+		model := c.aiModel
+		if model == "" && len(c.aiModels) > 0 {
+			model = c.aiModels[0]
+		}
+		if model == "" {
+			logger.Warn("--ai-model or --ai-models is required for the openai-compatible provider - AI services will not be available")
+			return nil, nil, nil, nil
+		}
+		aiService = ai.NewAIChatService(logger, chatService, queries, projectsDir, compatibleProvider, model, boilerplateService)
 	default:
 		logger.Warnf("Unknown AI provider: %s - AI services will not be available", c.aiProvider)
 		return nil, nil, nil, nil
 	}
+	aiService.SetOpsTools(ai.NewOpsTools(nodesService, metricsService, networksService, logger))
+	aiService.SetTestService(projecttests.NewService(queries, runner, boilerplateService, projectsDir, logger))
 
 	// Create and return AI handler
 	return ai.NewAIHandler(aiService, chatService, projectsService, boilerplateService), filesHandler, dirsHandler, nil
@@ -783,6 +916,30 @@ type serveCmd struct {
 	anthropicKey string
 	aiProvider   string
 	aiModel      string
+
+	// OpenAI-compatible provider for self-hosted models
+	aiBaseURL       string
+	aiModels        []string
+	aiAuthHeader    string
+	aiAuthValue     string
+	aiToolCalling   string
+	aiContextWindow int
+
+	driftInterval  time.Duration
+	driftReconcile bool
+
+	// Plugin signature verification
+	pluginPublisherKeys []string
+	pluginAllowUnsigned bool
+
+	// Access to the /metrics endpoint
+	metricsPublic bool
+	metricsToken  string
+
+	// Registry the images of promoted chaincode projects are pushed to
+	chaincodeRegistry         string
+	chaincodeRegistryUsername string
+	chaincodeRegistryPassword string
 }
 
 // validate validates the serve command configuration
@@ -873,6 +1030,14 @@ func (c *serveCmd) run() error {
 		log.Fatalf("Failed to set session key environment variable: %v", err)
 	}
 
+	// Bearer token of the /metrics endpoint, which the Prometheus deployed by ChainLaunch scrapes with
+	if !c.metricsPublic && c.metricsToken == "" {
+		c.metricsToken, err = ensureKeyExists(metricsTokenFile, c.dataPath)
+		if err != nil {
+			log.Fatalf("Failed to initialize metrics token: %v", err)
+		}
+	}
+
 	c.logger.Infof("Starting server on port %d...", c.port)
 	c.logger.Infof("Using database: %s", c.dbPath)
 	if c.dev {
@@ -1021,8 +1186,31 @@ For example:
 	// Add new flags
 	cmd.Flags().StringVar(&serveCmd.openaiKey, "openai-key", os.Getenv("OPENAI_API_KEY"), "OpenAI API key (or set OPENAI_API_KEY env var)")
 	cmd.Flags().StringVar(&serveCmd.anthropicKey, "anthropic-key", os.Getenv("ANTHROPIC_API_KEY"), "Anthropic API key (or set ANTHROPIC_API_KEY env var)")
-	cmd.Flags().StringVar(&serveCmd.aiProvider, "ai-provider", "", "AI provider to use: openai or anthropic")
+	cmd.Flags().StringVar(&serveCmd.aiProvider, "ai-provider", "", "AI provider to use: openai, anthropic or openai-compatible")
 	cmd.Flags().StringVar(&serveCmd.aiModel, "ai-model", "", "AI model to use (e.g. gpt-4o, claude-3-opus-20240229)")
+	cmd.Flags().StringVar(&serveCmd.aiBaseURL, "ai-base-url", os.Getenv("AI_BASE_URL"), "Base URL of the OpenAI-compatible API (e.g. http://localhost:11434/v1 for Ollama)")
+	cmd.Flags().StringSliceVar(&serveCmd.aiModels, "ai-models", nil, "Models offered by the OpenAI-compatible provider, the models of the server when empty")
+	cmd.Flags().StringVar(&serveCmd.aiAuthHeader, "ai-auth-header", "Authorization", "Header used to authenticate to the OpenAI-compatible API")
+	cmd.Flags().StringVar(&serveCmd.aiAuthValue, "ai-auth-value", os.Getenv("AI_AUTH_VALUE"), "Value of the auth header, e.g. 'Bearer <token>' (or set AI_AUTH_VALUE env var)")
+	cmd.Flags().StringVar(&serveCmd.aiToolCalling, "ai-tool-calling", string(ai.ToolCallingAuto), "Tool calling of the OpenAI-compatible provider: auto, native or prompt")
+	cmd.Flags().IntVar(&serveCmd.aiContextWindow, "ai-context-window", 0, "Context window of the self-hosted models in tokens (default 32768)")
+
+	// Drift detection flags
+	cmd.Flags().DurationVar(&serveCmd.driftInterval, "drift-interval", 10*time.Minute, "How often to check running nodes for configuration drift (0 disables the scanner)")
+	cmd.Flags().BoolVar(&serveCmd.driftReconcile, "drift-reconcile", false, "Rewrite the configuration of drifted nodes and restart them automatically")
+
+	// Plugin signature flags
+	cmd.Flags().StringSliceVar(&serveCmd.pluginPublisherKeys, "plugin-publisher-key", nil, "Path to the public key of a trusted plugin publisher, PEM or base64 Ed25519 (repeatable)")
+	cmd.Flags().BoolVar(&serveCmd.pluginAllowUnsigned, "plugin-allow-unsigned", false, "Accept unsigned plugins from the plugin registry sources")
+
+	// Metrics endpoint flags
+	cmd.Flags().BoolVar(&serveCmd.metricsPublic, "metrics-public", false, "Serve the /metrics endpoint without authentication")
+	cmd.Flags().StringVar(&serveCmd.metricsToken, "metrics-token", "", "Bearer token required by the /metrics endpoint (or set METRICS_TOKEN env var), generated and stored in the data directory when empty")
+
+	// Chaincode project promotion flags
+	cmd.Flags().StringVar(&serveCmd.chaincodeRegistry, "chaincode-registry", os.Getenv("CHAINCODE_REGISTRY"), "Registry the images of promoted chaincode projects are pushed to, e.g. localhost:5000 (images are only built locally when empty)")
+	cmd.Flags().StringVar(&serveCmd.chaincodeRegistryUsername, "chaincode-registry-username", os.Getenv("CHAINCODE_REGISTRY_USERNAME"), "Username of the chaincode registry (or set CHAINCODE_REGISTRY_USERNAME env var)")
+	cmd.Flags().StringVar(&serveCmd.chaincodeRegistryPassword, "chaincode-registry-password", os.Getenv("CHAINCODE_REGISTRY_PASSWORD"), "Password of the chaincode registry (or set CHAINCODE_REGISTRY_PASSWORD env var)")
 
 	return cmd
 }
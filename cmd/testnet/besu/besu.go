@@ -11,6 +11,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/http"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/spf13/cobra"
 )
@@ -70,6 +71,22 @@ func (r *BesuTestnetRunner) Run() error {
 		return fmt.Errorf("failed to get external IP: %w", err)
 	}
 
+	// Register the testnet first so everything created below can be destroyed with it
+	testnet, err := client.CreateTestnet(r.Config.Name, testnets.PlatformBesu)
+	if err != nil {
+		return fmt.Errorf("failed to register testnet: %w", err)
+	}
+	if err := r.create(client, testnet.ID, externalIP); err != nil {
+		if statusErr := client.SetTestnetStatus(testnet.ID, testnets.StatusFailed, err.Error()); statusErr != nil {
+			fmt.Printf("Failed to mark testnet as failed: %v\n", statusErr)
+		}
+		return fmt.Errorf("%w (run 'chainlaunch testnet destroy %s' to remove what was created)", err, r.Config.Name)
+	}
+	return client.SetTestnetStatus(testnet.ID, testnets.StatusReady, "")
+}
+
+// create creates the keys, network and nodes of the testnet, recording each as it is created
+func (r *BesuTestnetRunner) create(client *common.Client, testnetID int64, externalIP string) error {
 	// 1. Create all keys and collect their IDs
 	fmt.Printf("Creating %d validator keys...\n", r.Config.Nodes)
 	keyIDs := make([]int64, 0, r.Config.Nodes)
@@ -91,6 +108,9 @@ func (r *BesuTestnetRunner) Run() error {
 		if err != nil {
 			return fmt.Errorf("failed to create key for node %s: %w", nodeName, err)
 		}
+		if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceKey, ID: int64(keyResp.ID)}); err != nil {
+			return err
+		}
 		fmt.Printf("    Key created: ID %d\n", keyResp.ID)
 		keyIDs = append(keyIDs, int64(keyResp.ID))
 	}
@@ -136,6 +156,9 @@ func (r *BesuTestnetRunner) Run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create besu network: %w", err)
 	}
+	if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceNetwork, ID: netResp.ID}); err != nil {
+		return err
+	}
 	fmt.Printf("  Besu network created: ID %d\n", netResp.ID)
 
 	// 3. Create each Besu node, using the corresponding key
@@ -187,6 +210,9 @@ func (r *BesuTestnetRunner) Run() error {
 		if err != nil {
 			return fmt.Errorf("failed to create besu node %s: %w", nodeName, err)
 		}
+		if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceNode, ID: nodeResp.ID}); err != nil {
+			return err
+		}
 		if i == 0 {
 			firstNodeEnode = nodeResp.BesuNode.EnodeURL
 		}
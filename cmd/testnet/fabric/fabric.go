@@ -9,6 +9,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/common/ports"
 	fabrictypes "github.com/chainlaunch/chainlaunch/pkg/fabric/handler"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
 
 	networkshttp "github.com/chainlaunch/chainlaunch/pkg/networks/http"
 	shortuuid "github.com/lithammer/shortuuid/v4"
@@ -107,6 +108,22 @@ func (r *FabricTestnetRunner) Run() error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
+	// Register the testnet first so everything created below can be destroyed with it
+	testnet, err := client.CreateTestnet(r.Config.Name, testnets.PlatformFabric)
+	if err != nil {
+		return fmt.Errorf("failed to register testnet: %w", err)
+	}
+	if err := r.create(client, testnet.ID); err != nil {
+		if statusErr := client.SetTestnetStatus(testnet.ID, testnets.StatusFailed, err.Error()); statusErr != nil {
+			fmt.Printf("Failed to mark testnet as failed: %v\n", statusErr)
+		}
+		return fmt.Errorf("%w (run 'chainlaunch testnet destroy %s' to remove what was created)", err, r.Config.Name)
+	}
+	return client.SetTestnetStatus(testnet.ID, testnets.StatusReady, "")
+}
+
+// create creates the organizations, nodes and network of the testnet, recording each as it is created
+func (r *FabricTestnetRunner) create(client *common.Client, testnetID int64) error {
 	// 1. Create organizations
 	orgIDs := map[string]int64{}
 	orgNamesWithUUID := map[string]string{}
@@ -116,6 +133,9 @@ func (r *FabricTestnetRunner) Run() error {
 		if err != nil {
 			return fmt.Errorf("failed to create peer org %s: %w", org, err)
 		}
+		if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceOrganization, ID: resp.ID}); err != nil {
+			return err
+		}
 		orgIDs[org] = resp.ID
 		orgNamesWithUUID[org] = org
 	}
@@ -125,6 +145,9 @@ func (r *FabricTestnetRunner) Run() error {
 		if err != nil {
 			return fmt.Errorf("failed to create orderer org %s: %w", org, err)
 		}
+		if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceOrganization, ID: resp.ID}); err != nil {
+			return err
+		}
 		orgIDs[org] = resp.ID
 		orgNamesWithUUID[org] = org
 	}
@@ -195,6 +218,9 @@ func (r *FabricTestnetRunner) Run() error {
 			if err != nil {
 				return fmt.Errorf("failed to create peer node for org %s: %w", org, err)
 			}
+			if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceNode, ID: nodeResp.ID}); err != nil {
+				return err
+			}
 			nodeIDs = append(nodeIDs, nodeResp.ID)
 			peerNodeIDs = append(peerNodeIDs, nodeResp.ID)
 			peerNodeIDsByOrg[org] = append(peerNodeIDsByOrg[org], nodeResp.ID)
@@ -254,6 +280,9 @@ func (r *FabricTestnetRunner) Run() error {
 			if err != nil {
 				return fmt.Errorf("failed to create orderer node for org %s: %w", org, err)
 			}
+			if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceNode, ID: nodeResp.ID}); err != nil {
+				return err
+			}
 			nodeIDs = append(nodeIDs, nodeResp.ID)
 			ordererNodeIDs = append(ordererNodeIDs, nodeResp.ID)
 			ordererNodeIDsByOrg[org] = append(ordererNodeIDsByOrg[org], nodeResp.ID)
@@ -290,6 +319,9 @@ func (r *FabricTestnetRunner) Run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create fabric network: %w", err)
 	}
+	if err := client.AddTestnetResources(testnetID, testnets.ResourceRef{Type: testnets.ResourceNetwork, ID: networkResp.ID}); err != nil {
+		return err
+	}
 
 	fmt.Printf("Fabric testnet created successfully! Network ID: %d\n", networkResp.ID)
 
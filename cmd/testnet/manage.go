@@ -0,0 +1,160 @@
+package testnet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/cmd/common"
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
+	"github.com/spf13/cobra"
+)
+
+func newListCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List testnets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			list, err := client.ListTestnets()
+			if err != nil {
+				return err
+			}
+			return printTestnets(os.Stdout, output, list)
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "tsv", "Output type: tsv or json")
+	return cmd
+}
+
+func printTestnets(out io.Writer, output string, list []testnets.Testnet) error {
+	switch output {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	case "tsv":
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Name\tPlatform\tStatus\tResources\tCreated At")
+		fmt.Fprintln(w, "----\t--------\t------\t---------\t----------")
+		for _, t := range list {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", t.Name, t.Platform, t.Status, len(t.Resources), t.CreatedAt.Format(time.RFC3339))
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unsupported output type: %s (must be 'tsv' or 'json')", output)
+	}
+}
+
+func newStatusCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "status <name>",
+		Short: "Show a testnet and the status of each of its resources",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			t, err := client.GetTestnet(args[0])
+			if err != nil {
+				return err
+			}
+			return printTestnet(os.Stdout, output, t)
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "tsv", "Output type: tsv or json")
+	return cmd
+}
+
+func printTestnet(out io.Writer, output string, t *testnets.Testnet) error {
+	switch output {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	case "tsv":
+		fmt.Fprintf(out, "Name:     %s\nPlatform: %s\nStatus:   %s\n", t.Name, t.Platform, t.Status)
+		if t.LastError != "" {
+			fmt.Fprintf(out, "Error:    %s\n", t.LastError)
+		}
+		fmt.Fprintln(out)
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Type\tID\tName\tStatus")
+		fmt.Fprintln(w, "----\t--\t----\t------")
+		for _, r := range t.Resources {
+			status := r.Status
+			if r.Missing {
+				status = "MISSING"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", r.Type, r.ID, r.Name, status)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unsupported output type: %s (must be 'tsv' or 'json')", output)
+	}
+}
+
+func newDestroyCmd() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "destroy <name>",
+		Short: "Stop and delete every resource of a testnet",
+		Long: `Stop and delete every resource recorded for a testnet: networks, then nodes,
+node groups, services, organizations and keys. When a resource cannot be
+removed the testnet is kept as FAILED with what is left, and the command can
+be run again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			t, err := client.GetTestnet(args[0])
+			if err != nil {
+				return err
+			}
+			if !yes {
+				fmt.Printf("Destroy testnet %s and its %d resources? [y/N]: ", t.Name, len(t.Resources))
+				response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("failed to read confirmation: %w", err)
+				}
+				response = strings.TrimSpace(strings.ToLower(response))
+				if response != "y" && response != "yes" {
+					fmt.Println("Destroy cancelled")
+					return nil
+				}
+			}
+
+			result, err := client.DestroyTestnet(strconv.FormatInt(t.ID, 10))
+			if err != nil {
+				return err
+			}
+			for _, r := range result.Removed {
+				fmt.Printf("Removed %s %d\n", r.Type, r.ID)
+			}
+			for _, f := range result.Failed {
+				fmt.Printf("Failed to remove %s %d: %s\n", f.Type, f.ID, f.Error)
+			}
+			if !result.Deleted {
+				return fmt.Errorf("testnet %s was not fully destroyed; fix the errors above and run destroy again", t.Name)
+			}
+			fmt.Printf("Testnet %s destroyed\n", t.Name)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&yes, "yes", false, "Skip confirmation prompt")
+	return cmd
+}
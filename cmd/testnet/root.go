@@ -18,5 +18,10 @@ func NewTestnetCmd() *cobra.Command {
 	cmd.AddCommand(fabric.NewFabricTestnetCmd())
 	cmd.AddCommand(besu.NewBesuTestnetCmd())
 
+	// Manage testnets recorded by the commands above and by fabricx quickstart
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newDestroyCmd())
+
 	return cmd
 }
@@ -0,0 +1,310 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// OrgNodes groups the nodes an organization contributes to a new channel
+type OrgNodes struct {
+	OrganizationID int64
+	NodeIDs        []int64
+}
+
+// Backend reads and changes the instance through the existing services.
+// Create methods return the resource as it now exists so later changes can
+// reference it.
+type Backend interface {
+	LoadState(ctx context.Context) (*State, error)
+
+	CreateOrganization(ctx context.Context, spec OrganizationSpec) (*Organization, error)
+	CreateKey(ctx context.Context, spec KeySpec) (*Key, error)
+
+	CreatePeer(ctx context.Context, spec PeerSpec, organizationID int64) (*Node, error)
+	CreateOrderer(ctx context.Context, spec OrdererSpec, organizationID int64) (*Node, error)
+	CreateBesuNode(ctx context.Context, spec BesuNodeSpec, networkID, keyID int64) (*Node, error)
+	// UpdateNode applies the diffs to an existing node and returns it updated
+	UpdateNode(ctx context.Context, node *Node, diffs []FieldDiff) (*Node, error)
+
+	CreateFabricNetwork(ctx context.Context, spec FabricNetworkSpec, peerOrgs, ordererOrgs []OrgNodes) (*Network, error)
+	CreateBesuNetwork(ctx context.Context, spec BesuNetworkSpec, validatorKeyIDs []int64) (*Network, error)
+	JoinNode(ctx context.Context, networkID int64, node *Node) error
+	SetAnchorPeers(ctx context.Context, networkID, organizationID int64, endpoints []string) error
+
+	CreateChaincode(ctx context.Context, networkID int64, name string) (*Chaincode, error)
+	CreateChaincodeDefinition(ctx context.Context, chaincodeID int64, spec ChaincodeSpec) (*ChaincodeDefinition, error)
+	UpdateChaincodeDefinition(ctx context.Context, definitionID int64, spec ChaincodeSpec) error
+}
+
+// Applier plans and applies specs against a backend
+type Applier struct {
+	backend Backend
+	out     io.Writer
+}
+
+// NewApplier creates an applier that reports progress to out
+func NewApplier(backend Backend, out io.Writer) *Applier {
+	if out == nil {
+		out = io.Discard
+	}
+	return &Applier{backend: backend, out: out}
+}
+
+// Plan loads the current state and diffs the spec against it
+func (a *Applier) Plan(ctx context.Context, spec *Spec) (*Plan, *State, error) {
+	state, err := a.backend.LoadState(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load current state: %w", err)
+	}
+	plan, err := Compute(spec, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan, state, nil
+}
+
+// Apply runs the changes of a plan in order. The state is updated as
+// resources are created so later changes can resolve their IDs. It stops at
+// the first failure; since every change is keyed by name, re-running apply
+// resumes where it stopped.
+func (a *Applier) Apply(ctx context.Context, spec *Spec, state *State, plan *Plan) error {
+	for _, change := range plan.Changes {
+		if err := a.apply(ctx, spec, state, change); err != nil {
+			return fmt.Errorf("failed to %s: %w", change, err)
+		}
+		fmt.Fprintf(a.out, "done: %s\n", change)
+	}
+	return nil
+}
+
+func (a *Applier) apply(ctx context.Context, spec *Spec, state *State, c Change) error {
+	switch c.Kind {
+	case KindOrganization:
+		org, err := a.backend.CreateOrganization(ctx, *spec.organization(c.Name))
+		if err != nil {
+			return err
+		}
+		state.Organizations[org.MspID] = org
+	case KindKey:
+		key, err := a.backend.CreateKey(ctx, *spec.key(c.Name))
+		if err != nil {
+			return err
+		}
+		state.Keys[key.Name] = key
+	case KindPeer, KindOrderer, KindBesuNode:
+		if c.Action == ActionJoin {
+			return a.join(ctx, state, c)
+		}
+		return a.applyNode(ctx, spec, state, c)
+	case KindBesuNetwork:
+		n := spec.besuNetwork(c.Name)
+		keyIDs := make([]int64, 0, len(n.Validators))
+		for _, name := range n.Validators {
+			key, err := lookup(state.Keys, name, KindKey)
+			if err != nil {
+				return err
+			}
+			keyIDs = append(keyIDs, key.ID)
+		}
+		network, err := a.backend.CreateBesuNetwork(ctx, *n, keyIDs)
+		if err != nil {
+			return err
+		}
+		state.Networks[network.Name] = network
+	case KindFabricNetwork:
+		return a.createFabricNetwork(ctx, spec, state, spec.fabricNetwork(c.Name))
+	case KindAnchorPeers:
+		network, err := lookup(state.Networks, c.Network, KindFabricNetwork)
+		if err != nil {
+			return err
+		}
+		org, err := lookup(state.Organizations, c.Name, KindOrganization)
+		if err != nil {
+			return err
+		}
+		var endpoints []string
+		for _, name := range spec.fabricNetwork(c.Network).AnchorPeers[c.Name] {
+			node, err := lookup(state.Nodes, name, KindPeer)
+			if err != nil {
+				return err
+			}
+			endpoint := node.Fields[FieldExternalEndpoint]
+			if endpoint == "" {
+				return fmt.Errorf("peer %s has no external endpoint", name)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		if err := a.backend.SetAnchorPeers(ctx, network.ID, org.ID, endpoints); err != nil {
+			return err
+		}
+		if network.AnchorPeers == nil {
+			network.AnchorPeers = map[string][]string{}
+		}
+		network.AnchorPeers[c.Name] = endpoints
+	case KindChaincodeDefinition:
+		return a.applyDefinition(ctx, spec, state, c)
+	default:
+		return fmt.Errorf("unknown kind %q", c.Kind)
+	}
+	return nil
+}
+
+func (a *Applier) applyNode(ctx context.Context, spec *Spec, state *State, c Change) error {
+	if c.Action == ActionUpdate {
+		node, err := lookup(state.Nodes, c.Name, c.Kind)
+		if err != nil {
+			return err
+		}
+		updated, err := a.backend.UpdateNode(ctx, node, c.Diffs)
+		if err != nil {
+			return err
+		}
+		state.Nodes[c.Name] = updated
+		return nil
+	}
+
+	var node *Node
+	switch c.Kind {
+	case KindPeer:
+		p := spec.peer(c.Name)
+		org, err := lookup(state.Organizations, p.Organization, KindOrganization)
+		if err != nil {
+			return err
+		}
+		if node, err = a.backend.CreatePeer(ctx, *p, org.ID); err != nil {
+			return err
+		}
+	case KindOrderer:
+		o := spec.orderer(c.Name)
+		org, err := lookup(state.Organizations, o.Organization, KindOrganization)
+		if err != nil {
+			return err
+		}
+		if node, err = a.backend.CreateOrderer(ctx, *o, org.ID); err != nil {
+			return err
+		}
+	case KindBesuNode:
+		b := spec.besuNode(c.Name)
+		network, err := lookup(state.Networks, b.Network, KindBesuNetwork)
+		if err != nil {
+			return err
+		}
+		key, err := lookup(state.Keys, b.Key, KindKey)
+		if err != nil {
+			return err
+		}
+		if node, err = a.backend.CreateBesuNode(ctx, *b, network.ID, key.ID); err != nil {
+			return err
+		}
+	}
+	state.Nodes[node.Name] = node
+	return nil
+}
+
+func (a *Applier) createFabricNetwork(ctx context.Context, spec *Spec, state *State, n *FabricNetworkSpec) error {
+	group := func(names []string) ([]OrgNodes, error) {
+		var groups []OrgNodes
+		for _, msp := range spec.memberOrgs(names) {
+			org, err := lookup(state.Organizations, msp, KindOrganization)
+			if err != nil {
+				return nil, err
+			}
+			g := OrgNodes{OrganizationID: org.ID}
+			for _, name := range names {
+				node, err := lookup(state.Nodes, name, "node")
+				if err != nil {
+					return nil, err
+				}
+				if node.Organization == msp {
+					g.NodeIDs = append(g.NodeIDs, node.ID)
+				}
+			}
+			groups = append(groups, g)
+		}
+		return groups, nil
+	}
+	peerOrgs, err := group(n.Peers)
+	if err != nil {
+		return err
+	}
+	ordererOrgs, err := group(n.Orderers)
+	if err != nil {
+		return err
+	}
+	network, err := a.backend.CreateFabricNetwork(ctx, *n, peerOrgs, ordererOrgs)
+	if err != nil {
+		return err
+	}
+	if network.Chaincodes == nil {
+		network.Chaincodes = map[string]*Chaincode{}
+	}
+	state.Networks[network.Name] = network
+	return nil
+}
+
+func (a *Applier) join(ctx context.Context, state *State, c Change) error {
+	network, err := lookup(state.Networks, c.Network, KindFabricNetwork)
+	if err != nil {
+		return err
+	}
+	node, err := lookup(state.Nodes, c.Name, c.Kind)
+	if err != nil {
+		return err
+	}
+	if err := a.backend.JoinNode(ctx, network.ID, node); err != nil {
+		return err
+	}
+	if c.Kind == KindOrderer {
+		network.Orderers = append(network.Orderers, c.Name)
+	} else {
+		network.Peers = append(network.Peers, c.Name)
+	}
+	return nil
+}
+
+func (a *Applier) applyDefinition(ctx context.Context, spec *Spec, state *State, c Change) error {
+	network, err := lookup(state.Networks, c.Network, KindFabricNetwork)
+	if err != nil {
+		return err
+	}
+	var cc ChaincodeSpec
+	for _, candidate := range spec.fabricNetwork(c.Network).Chaincodes {
+		if candidate.Name == c.Name && candidate.Sequence == c.Sequence {
+			cc = candidate
+		}
+	}
+
+	if c.Action == ActionUpdate {
+		def := network.definition(c.Name, c.Sequence)
+		if def == nil {
+			return fmt.Errorf("chaincode %s has no definition with sequence %d", c.Name, c.Sequence)
+		}
+		return a.backend.UpdateChaincodeDefinition(ctx, def.ID, cc)
+	}
+
+	if network.Chaincodes == nil {
+		network.Chaincodes = map[string]*Chaincode{}
+	}
+	chaincode := network.Chaincodes[c.Name]
+	if chaincode == nil {
+		if chaincode, err = a.backend.CreateChaincode(ctx, network.ID, c.Name); err != nil {
+			return err
+		}
+		network.Chaincodes[c.Name] = chaincode
+	}
+	def, err := a.backend.CreateChaincodeDefinition(ctx, chaincode.ID, cc)
+	if err != nil {
+		return err
+	}
+	chaincode.Definitions = append(chaincode.Definitions, *def)
+	return nil
+}
+
+func lookup[V any](m map[string]*V, name, kind string) (*V, error) {
+	v, ok := m[name]
+	if !ok || v == nil {
+		return nil, fmt.Errorf("%s %s does not exist", kind, name)
+	}
+	return v, nil
+}
@@ -0,0 +1,274 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend keeps the instance in memory and records every call
+type fakeBackend struct {
+	state  *State
+	nextID int64
+	calls  []string
+	failOn string
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{state: NewState(), nextID: 100}
+}
+
+func (f *fakeBackend) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeBackend) record(call string) error {
+	f.calls = append(f.calls, call)
+	if call == f.failOn {
+		return fmt.Errorf("boom")
+	}
+	return nil
+}
+
+// LoadState returns a deep copy so the applier cannot mutate the instance
+func (f *fakeBackend) LoadState(ctx context.Context) (*State, error) {
+	data, err := json.Marshal(f.state)
+	if err != nil {
+		return nil, err
+	}
+	state := NewState()
+	return state, json.Unmarshal(data, state)
+}
+
+func (f *fakeBackend) CreateOrganization(ctx context.Context, spec OrganizationSpec) (*Organization, error) {
+	if err := f.record("org " + spec.MspID); err != nil {
+		return nil, err
+	}
+	org := &Organization{ID: f.id(), MspID: spec.MspID}
+	f.state.Organizations[spec.MspID] = org
+	return org, nil
+}
+
+func (f *fakeBackend) CreateKey(ctx context.Context, spec KeySpec) (*Key, error) {
+	if err := f.record("key " + spec.Name); err != nil {
+		return nil, err
+	}
+	key := &Key{ID: f.id(), Name: spec.Name, Algorithm: spec.Algorithm, Curve: spec.Curve}
+	f.state.Keys[spec.Name] = key
+	return key, nil
+}
+
+func (f *fakeBackend) addNode(node *Node, fields map[string]string) *Node {
+	node.ID = f.id()
+	node.Fields = map[string]string{}
+	for k, v := range fields {
+		if v != "" {
+			node.Fields[k] = v
+		}
+	}
+	f.state.Nodes[node.Name] = node
+	return node
+}
+
+func (f *fakeBackend) CreatePeer(ctx context.Context, spec PeerSpec, organizationID int64) (*Node, error) {
+	if err := f.record("peer " + spec.Name); err != nil {
+		return nil, err
+	}
+	return f.addNode(&Node{Name: spec.Name, Kind: KindPeer, Organization: spec.Organization}, peerFields(spec)), nil
+}
+
+func (f *fakeBackend) CreateOrderer(ctx context.Context, spec OrdererSpec, organizationID int64) (*Node, error) {
+	if err := f.record("orderer " + spec.Name); err != nil {
+		return nil, err
+	}
+	return f.addNode(&Node{Name: spec.Name, Kind: KindOrderer, Organization: spec.Organization}, ordererFields(spec)), nil
+}
+
+func (f *fakeBackend) CreateBesuNode(ctx context.Context, spec BesuNodeSpec, networkID, keyID int64) (*Node, error) {
+	if err := f.record(fmt.Sprintf("besu %s network=%d key=%d", spec.Name, networkID, keyID)); err != nil {
+		return nil, err
+	}
+	return f.addNode(&Node{Name: spec.Name, Kind: KindBesuNode, NetworkID: networkID, KeyID: keyID}, besuFields(spec)), nil
+}
+
+func (f *fakeBackend) UpdateNode(ctx context.Context, node *Node, diffs []FieldDiff) (*Node, error) {
+	if err := f.record("update " + node.Name); err != nil {
+		return nil, err
+	}
+	for _, d := range diffs {
+		f.state.Nodes[node.Name].Fields[d.Field] = d.To
+	}
+	return f.state.Nodes[node.Name], nil
+}
+
+func (f *fakeBackend) CreateFabricNetwork(ctx context.Context, spec FabricNetworkSpec, peerOrgs, ordererOrgs []OrgNodes) (*Network, error) {
+	if err := f.record(fmt.Sprintf("fabric-network %s peers=%v orderers=%v", spec.Name, peerOrgs, ordererOrgs)); err != nil {
+		return nil, err
+	}
+	network := &Network{ID: f.id(), Name: spec.Name, Platform: "FABRIC", Members: []string{}, AnchorPeers: map[string][]string{}, Chaincodes: map[string]*Chaincode{}}
+	for _, name := range append(append([]string{}, spec.Peers...), spec.Orderers...) {
+		if org := f.state.Nodes[name].Organization; !contains(network.Members, org) {
+			network.Members = append(network.Members, org)
+		}
+	}
+	f.state.Networks[spec.Name] = network
+	return network, nil
+}
+
+func (f *fakeBackend) CreateBesuNetwork(ctx context.Context, spec BesuNetworkSpec, validatorKeyIDs []int64) (*Network, error) {
+	if err := f.record(fmt.Sprintf("besu-network %s validators=%v", spec.Name, validatorKeyIDs)); err != nil {
+		return nil, err
+	}
+	network := &Network{ID: f.id(), Name: spec.Name, Platform: "BESU"}
+	f.state.Networks[spec.Name] = network
+	return network, nil
+}
+
+func (f *fakeBackend) network(id int64) *Network {
+	for _, n := range f.state.Networks {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+func (f *fakeBackend) JoinNode(ctx context.Context, networkID int64, node *Node) error {
+	if err := f.record("join " + node.Name); err != nil {
+		return err
+	}
+	n := f.network(networkID)
+	if node.Kind == KindOrderer {
+		n.Orderers = append(n.Orderers, node.Name)
+	} else {
+		n.Peers = append(n.Peers, node.Name)
+	}
+	return nil
+}
+
+func (f *fakeBackend) SetAnchorPeers(ctx context.Context, networkID, organizationID int64, endpoints []string) error {
+	if err := f.record(fmt.Sprintf("anchors %v", endpoints)); err != nil {
+		return err
+	}
+	for _, org := range f.state.Organizations {
+		if org.ID == organizationID {
+			f.network(networkID).AnchorPeers[org.MspID] = endpoints
+		}
+	}
+	return nil
+}
+
+func (f *fakeBackend) CreateChaincode(ctx context.Context, networkID int64, name string) (*Chaincode, error) {
+	if err := f.record("chaincode " + name); err != nil {
+		return nil, err
+	}
+	cc := &Chaincode{ID: f.id(), Name: name}
+	f.network(networkID).Chaincodes[name] = cc
+	return cc, nil
+}
+
+func (f *fakeBackend) chaincode(id int64) *Chaincode {
+	for _, n := range f.state.Networks {
+		for _, cc := range n.Chaincodes {
+			if cc.ID == id {
+				return cc
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeBackend) CreateChaincodeDefinition(ctx context.Context, chaincodeID int64, spec ChaincodeSpec) (*ChaincodeDefinition, error) {
+	if err := f.record(fmt.Sprintf("definition %s %d", spec.Name, spec.Sequence)); err != nil {
+		return nil, err
+	}
+	def := ChaincodeDefinition{ID: f.id(), Version: spec.Version, Sequence: spec.Sequence, DockerImage: spec.DockerImage}
+	cc := f.chaincode(chaincodeID)
+	cc.Definitions = append(cc.Definitions, def)
+	return &def, nil
+}
+
+func (f *fakeBackend) UpdateChaincodeDefinition(ctx context.Context, definitionID int64, spec ChaincodeSpec) error {
+	if err := f.record(fmt.Sprintf("update-definition %d", definitionID)); err != nil {
+		return err
+	}
+	for _, n := range f.state.Networks {
+		for _, cc := range n.Chaincodes {
+			for i := range cc.Definitions {
+				if cc.Definitions[i].ID == definitionID {
+					cc.Definitions[i].DockerImage = spec.DockerImage
+					cc.Definitions[i].Version = spec.Version
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func planAndApply(t *testing.T, backend *fakeBackend, spec *Spec) *Plan {
+	t.Helper()
+	applier := NewApplier(backend, nil)
+	plan, state, err := applier.Plan(context.Background(), spec)
+	require.NoError(t, err)
+	require.NoError(t, applier.Apply(context.Background(), spec, state, plan))
+	return plan
+}
+
+func TestApplyConvergesAndIsIdempotent(t *testing.T) {
+	backend := newFakeBackend()
+	spec := parseTestSpec(t)
+
+	plan := planAndApply(t, backend, spec)
+	assert.Len(t, plan.Changes, 14)
+	assert.Contains(t, backend.calls, "besu-network besu1 validators=[103]")
+	assert.Contains(t, backend.calls, "besu besu0 network=107 key=103")
+	assert.Contains(t, backend.calls, "fabric-network mychannel peers=[{101 [104 105]}] orderers=[{102 [106]}]")
+	assert.Contains(t, backend.calls, "anchors [peer0.org1:7051]")
+
+	backend.calls = nil
+	plan = planAndApply(t, backend, spec)
+	assert.True(t, plan.Empty(), "second apply planned %v", changeStrings(plan))
+	assert.Empty(t, backend.calls)
+}
+
+func TestApplyUpdatesDrift(t *testing.T) {
+	backend := newFakeBackend()
+	spec := parseTestSpec(t)
+	planAndApply(t, backend, spec)
+
+	spec.Peers[0].Version = "3.1.1"
+	spec.FabricNetworks[0].Chaincodes = append(spec.FabricNetworks[0].Chaincodes, ChaincodeSpec{
+		Name: "basic", Version: "1.1", Sequence: 2, DockerImage: "basic:1.1",
+	})
+	backend.calls = nil
+	planAndApply(t, backend, spec)
+	assert.Equal(t, []string{"update peer0-org1", "definition basic 2"}, backend.calls)
+	assert.Equal(t, "3.1.1", backend.state.Nodes["peer0-org1"].Fields[FieldVersion])
+}
+
+func TestApplyStopsAtFirstFailureAndResumes(t *testing.T) {
+	backend := newFakeBackend()
+	backend.failOn = "peer peer1-org1"
+	spec := parseTestSpec(t)
+
+	var out bytes.Buffer
+	applier := NewApplier(backend, &out)
+	plan, state, err := applier.Plan(context.Background(), spec)
+	require.NoError(t, err)
+	err = applier.Apply(context.Background(), spec, state, plan)
+	require.ErrorContains(t, err, "failed to create peer peer1-org1: boom")
+	assert.Contains(t, out.String(), "done: create peer peer0-org1\n")
+	assert.NotContains(t, backend.calls, "orderer orderer0")
+
+	backend.failOn = ""
+	backend.calls = nil
+	plan = planAndApply(t, backend, spec)
+	assert.Equal(t, "peer peer1-org1", backend.calls[0])
+	assert.Len(t, plan.Changes, 10)
+}
@@ -0,0 +1,389 @@
+package apply
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resource kinds that appear in a plan
+const (
+	KindOrganization        = "organization"
+	KindKey                 = "key"
+	KindPeer                = "peer"
+	KindOrderer             = "orderer"
+	KindBesuNode            = "besu-node"
+	KindFabricNetwork       = "fabric-network"
+	KindBesuNetwork         = "besu-network"
+	KindAnchorPeers         = "anchor-peers"
+	KindChaincodeDefinition = "chaincode-definition"
+)
+
+// Comparable node fields, shared by FieldDiff and Node.Fields
+const (
+	FieldMode              = "mode"
+	FieldVersion           = "version"
+	FieldExternalEndpoint  = "externalEndpoint"
+	FieldListenAddress     = "listenAddress"
+	FieldOperationsAddress = "operationsAddress"
+	FieldChaincodeAddress  = "chaincodeAddress"
+	FieldEventsAddress     = "eventsAddress"
+	FieldAdminAddress      = "adminAddress"
+	FieldDomainNames       = "domainNames"
+	FieldP2PHost           = "p2pHost"
+	FieldP2PPort           = "p2pPort"
+	FieldRPCHost           = "rpcHost"
+	FieldRPCPort           = "rpcPort"
+	FieldExternalIP        = "externalIp"
+	FieldInternalIP        = "internalIp"
+)
+
+// Action is what a change does to a resource
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	// ActionJoin joins a node to a network
+	ActionJoin Action = "join"
+)
+
+// FieldDiff is a single field that differs between the spec and the instance
+type FieldDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Change is one step of a plan
+type Change struct {
+	Action Action `json:"action"`
+	Kind   string `json:"kind"`
+	// Name is the resource name: the node name for joins, the MSP ID for
+	// anchor peers and the chaincode name for definitions
+	Name     string      `json:"name"`
+	Network  string      `json:"network,omitempty"`
+	Sequence int64       `json:"sequence,omitempty"`
+	Diffs    []FieldDiff `json:"diffs,omitempty"`
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	switch c.Action {
+	case ActionJoin:
+		fmt.Fprintf(&b, "join %s %s to %s", c.Kind, c.Name, c.Network)
+		return b.String()
+	default:
+		fmt.Fprintf(&b, "%s %s %s", c.Action, c.Kind, c.Name)
+	}
+	if c.Sequence > 0 {
+		fmt.Fprintf(&b, " sequence %d", c.Sequence)
+	}
+	if c.Network != "" {
+		fmt.Fprintf(&b, " on %s", c.Network)
+	}
+	return b.String()
+}
+
+// Plan is the ordered list of changes that converges the instance to a
+// spec. Warnings report differences apply cannot reconcile, such as fields
+// that are fixed once a resource exists.
+type Plan struct {
+	Changes  []Change `json:"changes"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Empty reports whether the instance already matches the spec
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Render writes the plan as a diff
+func (p *Plan) Render(w io.Writer) {
+	counts := map[Action]int{}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		symbol := "+"
+		switch c.Action {
+		case ActionUpdate:
+			symbol = "~"
+		case ActionJoin:
+			symbol = ">"
+		}
+		fmt.Fprintf(w, "%s %s\n", symbol, c)
+		for _, d := range c.Diffs {
+			fmt.Fprintf(w, "    %s: %s -> %s\n", d.Field, display(d.From), display(d.To))
+		}
+	}
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(w, "! %s\n", warning)
+	}
+	if p.Empty() {
+		fmt.Fprintln(w, "No changes. The instance matches the spec.")
+		return
+	}
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to join.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionJoin])
+}
+
+func display(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+// Compute diffs a spec against the current state. Changes are ordered so
+// that every resource is created before the resources that reference it.
+func Compute(spec *Spec, state *State) (*Plan, error) {
+	plan := &Plan{Changes: []Change{}}
+	add := func(c Change) { plan.Changes = append(plan.Changes, c) }
+	warn := func(format string, args ...interface{}) {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	for _, o := range spec.Organizations {
+		if _, ok := state.Organizations[o.MspID]; !ok {
+			add(Change{Action: ActionCreate, Kind: KindOrganization, Name: o.MspID})
+		}
+	}
+
+	for _, k := range spec.Keys {
+		existing, ok := state.Keys[k.Name]
+		if !ok {
+			add(Change{Action: ActionCreate, Kind: KindKey, Name: k.Name})
+			continue
+		}
+		if existing.Algorithm != k.Algorithm || (k.Curve != "" && existing.Curve != k.Curve) {
+			warn("key %s is %s %s and cannot be changed to %s %s", k.Name, existing.Algorithm, existing.Curve, k.Algorithm, k.Curve)
+		}
+	}
+
+	nodeChange := func(kind, name string, desired map[string]string) error {
+		existing, ok := state.Nodes[name]
+		if !ok {
+			add(Change{Action: ActionCreate, Kind: kind, Name: name})
+			return nil
+		}
+		if existing.Kind != kind {
+			return fmt.Errorf("node %s exists as a %s, not a %s", name, existing.Kind, kind)
+		}
+		if diffs := diffFields(desired, existing.Fields); len(diffs) > 0 {
+			add(Change{Action: ActionUpdate, Kind: kind, Name: name, Diffs: diffs})
+		}
+		return nil
+	}
+	for _, p := range spec.Peers {
+		if err := nodeChange(KindPeer, p.Name, peerFields(p)); err != nil {
+			return nil, err
+		}
+		if n := state.Nodes[p.Name]; n != nil && n.Organization != p.Organization {
+			warn("peer %s belongs to %s and cannot be moved to %s", p.Name, n.Organization, p.Organization)
+		}
+	}
+	for _, o := range spec.Orderers {
+		if err := nodeChange(KindOrderer, o.Name, ordererFields(o)); err != nil {
+			return nil, err
+		}
+		if n := state.Nodes[o.Name]; n != nil && n.Organization != o.Organization {
+			warn("orderer %s belongs to %s and cannot be moved to %s", o.Name, n.Organization, o.Organization)
+		}
+	}
+
+	for _, n := range spec.BesuNetworks {
+		existing, ok := state.Networks[n.Name]
+		if !ok {
+			add(Change{Action: ActionCreate, Kind: KindBesuNetwork, Name: n.Name})
+			continue
+		}
+		if existing.Platform != "BESU" {
+			return nil, fmt.Errorf("network %s exists as a %s network", n.Name, existing.Platform)
+		}
+	}
+	for _, b := range spec.BesuNodes {
+		if err := nodeChange(KindBesuNode, b.Name, besuFields(b)); err != nil {
+			return nil, err
+		}
+		existing := state.Nodes[b.Name]
+		if existing == nil {
+			continue
+		}
+		if network := state.Networks[b.Network]; network != nil && existing.NetworkID != network.ID {
+			warn("besu node %s belongs to another network and cannot be moved to %s", b.Name, b.Network)
+		}
+		if key := state.Keys[b.Key]; key != nil && existing.KeyID != key.ID {
+			warn("besu node %s uses another key and cannot be switched to %s", b.Name, b.Key)
+		}
+	}
+
+	for _, n := range spec.FabricNetworks {
+		existing, ok := state.Networks[n.Name]
+		if ok && existing.Platform != "FABRIC" {
+			return nil, fmt.Errorf("network %s exists as a %s network", n.Name, existing.Platform)
+		}
+		if !ok {
+			add(Change{Action: ActionCreate, Kind: KindFabricNetwork, Name: n.Name})
+			existing = &Network{Name: n.Name, Platform: "FABRIC", Members: spec.memberOrgs(append(append([]string{}, n.Peers...), n.Orderers...))}
+		}
+
+		for _, kind := range []string{KindPeer, KindOrderer} {
+			names, joined := n.Peers, existing.Peers
+			if kind == KindOrderer {
+				names, joined = n.Orderers, existing.Orderers
+			}
+			for _, name := range names {
+				if contains(joined, name) {
+					continue
+				}
+				org := spec.memberOrgs([]string{name})[0]
+				if existing.Members != nil && !contains(existing.Members, org) {
+					warn("%s is not a member of %s; add the organization to the channel before joining %s", org, n.Name, name)
+					continue
+				}
+				add(Change{Action: ActionJoin, Kind: kind, Name: name, Network: n.Name})
+			}
+		}
+
+		for _, msp := range sortedKeys(n.AnchorPeers) {
+			desired := anchorEndpoints(spec, state, n.AnchorPeers[msp])
+			current := append([]string{}, existing.AnchorPeers[msp]...)
+			sort.Strings(current)
+			if existing.AnchorPeers != nil && strings.Join(current, ",") == strings.Join(desired, ",") {
+				continue
+			}
+			action := ActionUpdate
+			if len(current) == 0 {
+				action = ActionCreate
+			}
+			add(Change{
+				Action:  action,
+				Kind:    KindAnchorPeers,
+				Name:    msp,
+				Network: n.Name,
+				Diffs:   []FieldDiff{{Field: "anchorPeers", From: strings.Join(current, ","), To: strings.Join(desired, ",")}},
+			})
+		}
+
+		for _, cc := range n.Chaincodes {
+			def := existing.definition(cc.Name, cc.Sequence)
+			if def == nil {
+				add(Change{Action: ActionCreate, Kind: KindChaincodeDefinition, Name: cc.Name, Network: n.Name, Sequence: cc.Sequence})
+				continue
+			}
+			if diffs := diffFields(definitionFields(cc), map[string]string{
+				"version":           def.Version,
+				"dockerImage":       def.DockerImage,
+				"endorsementPolicy": def.EndorsementPolicy,
+				"chaincodeAddress":  def.ChaincodeAddress,
+			}); len(diffs) > 0 {
+				add(Change{Action: ActionUpdate, Kind: KindChaincodeDefinition, Name: cc.Name, Network: n.Name, Sequence: cc.Sequence, Diffs: diffs})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// anchorEndpoints resolves anchor peer names to their external endpoints.
+// Peers whose endpoint is only known once they are created are shown by name.
+func anchorEndpoints(spec *Spec, state *State, names []string) []string {
+	endpoints := make([]string, 0, len(names))
+	for _, name := range names {
+		endpoint := ""
+		if p := spec.peer(name); p != nil {
+			endpoint = p.ExternalEndpoint
+		}
+		if endpoint == "" {
+			if n := state.Nodes[name]; n != nil {
+				endpoint = n.Fields[FieldExternalEndpoint]
+			}
+		}
+		if endpoint == "" {
+			endpoint = name
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// diffFields compares the fields set in desired with current, in a stable order
+func diffFields(desired, current map[string]string) []FieldDiff {
+	var diffs []FieldDiff
+	for _, field := range sortedKeys(desired) {
+		want := desired[field]
+		if want == "" || want == current[field] {
+			continue
+		}
+		diffs = append(diffs, FieldDiff{Field: field, From: current[field], To: want})
+	}
+	return diffs
+}
+
+func fabricNodeFields(n FabricNodeSpec) map[string]string {
+	return map[string]string{
+		FieldMode:              n.Mode,
+		FieldVersion:           n.Version,
+		FieldExternalEndpoint:  n.ExternalEndpoint,
+		FieldListenAddress:     n.ListenAddress,
+		FieldOperationsAddress: n.OperationsAddress,
+		FieldDomainNames:       JoinList(n.DomainNames),
+	}
+}
+
+func peerFields(p PeerSpec) map[string]string {
+	fields := fabricNodeFields(p.FabricNodeSpec)
+	fields[FieldChaincodeAddress] = p.ChaincodeAddress
+	fields[FieldEventsAddress] = p.EventsAddress
+	return fields
+}
+
+func ordererFields(o OrdererSpec) map[string]string {
+	fields := fabricNodeFields(o.FabricNodeSpec)
+	fields[FieldAdminAddress] = o.AdminAddress
+	return fields
+}
+
+func besuFields(b BesuNodeSpec) map[string]string {
+	return map[string]string{
+		FieldMode:       b.Mode,
+		FieldVersion:    b.Version,
+		FieldP2PHost:    b.P2PHost,
+		FieldP2PPort:    formatPort(b.P2PPort),
+		FieldRPCHost:    b.RPCHost,
+		FieldRPCPort:    formatPort(b.RPCPort),
+		FieldExternalIP: b.ExternalIP,
+		FieldInternalIP: b.InternalIP,
+	}
+}
+
+func definitionFields(cc ChaincodeSpec) map[string]string {
+	return map[string]string{
+		"version":           cc.Version,
+		"dockerImage":       cc.DockerImage,
+		"endorsementPolicy": cc.EndorsementPolicy,
+		"chaincodeAddress":  cc.ChaincodeAddress,
+	}
+}
+
+// JoinList renders a list field the way Node.Fields stores it: sorted and
+// comma separated
+func JoinList(list []string) string {
+	sorted := append([]string{}, list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func formatPort(port uint) string {
+	if port == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(port), 10)
+}
+
+func joinHostPort(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
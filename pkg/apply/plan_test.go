@@ -0,0 +1,228 @@
+package apply
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+organizations:
+  - mspId: Org1MSP
+  - mspId: OrdererMSP
+keys:
+  - name: val0
+    algorithm: EC
+    curve: secp256k1
+peers:
+  - name: peer0-org1
+    organization: Org1MSP
+    version: 3.1.0
+    externalEndpoint: peer0.org1:7051
+  - name: peer1-org1
+    organization: Org1MSP
+    externalEndpoint: peer1.org1:7051
+orderers:
+  - name: orderer0
+    organization: OrdererMSP
+fabricNetworks:
+  - name: mychannel
+    peers: [peer0-org1, peer1-org1]
+    orderers: [orderer0]
+    anchorPeers:
+      Org1MSP: [peer0-org1]
+    chaincodes:
+      - name: basic
+        version: "1.0"
+        sequence: 1
+        dockerImage: basic:1.0
+besuNetworks:
+  - name: besu1
+    chainId: 1337
+    validators: [val0]
+besuNodes:
+  - name: besu0
+    network: besu1
+    key: val0
+    p2pPort: 30303
+`
+
+func parseTestSpec(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Parse([]byte(testSpec))
+	require.NoError(t, err)
+	return spec
+}
+
+func changeStrings(plan *Plan) []string {
+	var out []string
+	for _, c := range plan.Changes {
+		out = append(out, c.String())
+	}
+	return out
+}
+
+func TestParseRejectsBadSpecs(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown field":     "organizations:\n  - mspId: Org1MSP\n    mspid: typo\n",
+		"unknown org":       "peers:\n  - name: p\n    organization: Org1MSP\n",
+		"duplicate node":    "organizations:\n  - mspId: O\npeers:\n  - {name: n, organization: O}\norderers:\n  - {name: n, organization: O}\n",
+		"unknown validator": "besuNetworks:\n  - {name: b, chainId: 1, validators: [k]}\n",
+		"foreign anchor":    "organizations:\n  - mspId: A\n  - mspId: B\npeers:\n  - {name: p, organization: A}\nfabricNetworks:\n  - {name: c, peers: [p], anchorPeers: {B: [p]}}\n",
+		"bad algorithm":     "keys:\n  - {name: k, algorithm: DSA}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestComputeFromEmptyState(t *testing.T) {
+	plan, err := Compute(parseTestSpec(t), NewState())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"create organization Org1MSP",
+		"create organization OrdererMSP",
+		"create key val0",
+		"create peer peer0-org1",
+		"create peer peer1-org1",
+		"create orderer orderer0",
+		"create besu-network besu1",
+		"create besu-node besu0",
+		"create fabric-network mychannel",
+		"join peer peer0-org1 to mychannel",
+		"join peer peer1-org1 to mychannel",
+		"join orderer orderer0 to mychannel",
+		"create anchor-peers Org1MSP on mychannel",
+		"create chaincode-definition basic sequence 1 on mychannel",
+	}, changeStrings(plan))
+	assert.Empty(t, plan.Warnings)
+}
+
+// convergedState is the state an instance is in after applying testSpec
+func convergedState() *State {
+	state := NewState()
+	state.Organizations["Org1MSP"] = &Organization{ID: 1, MspID: "Org1MSP"}
+	state.Organizations["OrdererMSP"] = &Organization{ID: 2, MspID: "OrdererMSP"}
+	state.Keys["val0"] = &Key{ID: 10, Name: "val0", Algorithm: "EC", Curve: "secp256k1"}
+	state.Nodes["peer0-org1"] = &Node{ID: 1, Name: "peer0-org1", Kind: KindPeer, Organization: "Org1MSP", Fields: map[string]string{
+		FieldVersion: "3.1.0", FieldExternalEndpoint: "peer0.org1:7051", FieldListenAddress: "0.0.0.0:7051",
+	}}
+	state.Nodes["peer1-org1"] = &Node{ID: 2, Name: "peer1-org1", Kind: KindPeer, Organization: "Org1MSP", Fields: map[string]string{
+		FieldVersion: "3.1.0", FieldExternalEndpoint: "peer1.org1:7051",
+	}}
+	state.Nodes["orderer0"] = &Node{ID: 3, Name: "orderer0", Kind: KindOrderer, Organization: "OrdererMSP", Fields: map[string]string{}}
+	state.Nodes["besu0"] = &Node{ID: 4, Name: "besu0", Kind: KindBesuNode, NetworkID: 20, KeyID: 10, Fields: map[string]string{FieldP2PPort: "30303"}}
+	state.Networks["besu1"] = &Network{ID: 20, Name: "besu1", Platform: "BESU"}
+	state.Networks["mychannel"] = &Network{
+		ID:          21,
+		Name:        "mychannel",
+		Platform:    "FABRIC",
+		Members:     []string{"Org1MSP", "OrdererMSP"},
+		Peers:       []string{"peer0-org1", "peer1-org1"},
+		Orderers:    []string{"orderer0"},
+		AnchorPeers: map[string][]string{"Org1MSP": {"peer0.org1:7051"}},
+		Chaincodes: map[string]*Chaincode{"basic": {ID: 30, Name: "basic", Definitions: []ChaincodeDefinition{
+			{ID: 31, Version: "1.0", Sequence: 1, DockerImage: "basic:1.0"},
+		}}},
+	}
+	return state
+}
+
+func TestComputeConvergedIsEmpty(t *testing.T) {
+	plan, err := Compute(parseTestSpec(t), convergedState())
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "unexpected changes: %v", changeStrings(plan))
+
+	var out bytes.Buffer
+	plan.Render(&out)
+	assert.Contains(t, out.String(), "No changes")
+}
+
+func TestComputeDrift(t *testing.T) {
+	state := convergedState()
+	state.Nodes["peer0-org1"].Fields[FieldVersion] = "2.5.12"
+	network := state.Networks["mychannel"]
+	network.Peers = []string{"peer0-org1"}
+	network.AnchorPeers = map[string][]string{"Org1MSP": {"peer1.org1:7051"}}
+	network.Chaincodes["basic"].Definitions[0].DockerImage = "basic:0.9"
+	state.Keys["val0"].Curve = "P-256"
+
+	plan, err := Compute(parseTestSpec(t), state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"update peer peer0-org1",
+		"join peer peer1-org1 to mychannel",
+		"update anchor-peers Org1MSP on mychannel",
+		"update chaincode-definition basic sequence 1 on mychannel",
+	}, changeStrings(plan))
+	assert.Equal(t, []FieldDiff{{Field: FieldVersion, From: "2.5.12", To: "3.1.0"}}, plan.Changes[0].Diffs)
+	assert.Equal(t, []FieldDiff{{Field: "anchorPeers", From: "peer1.org1:7051", To: "peer0.org1:7051"}}, plan.Changes[2].Diffs)
+	assert.Equal(t, []FieldDiff{{Field: "dockerImage", From: "basic:0.9", To: "basic:1.0"}}, plan.Changes[3].Diffs)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0], "key val0")
+
+	var out bytes.Buffer
+	plan.Render(&out)
+	assert.Contains(t, out.String(), "~ update peer peer0-org1\n    version: 2.5.12 -> 3.1.0\n")
+	assert.Contains(t, out.String(), "> join peer peer1-org1 to mychannel\n")
+	assert.Contains(t, out.String(), "Plan: 0 to create, 3 to update, 1 to join.")
+}
+
+func TestComputeSkipsJoinsForNonMembers(t *testing.T) {
+	state := convergedState()
+	network := state.Networks["mychannel"]
+	network.Members = []string{"OrdererMSP"}
+	network.Peers = nil
+
+	plan, err := Compute(parseTestSpec(t), state)
+	require.NoError(t, err)
+	for _, c := range plan.Changes {
+		assert.NotEqual(t, ActionJoin, c.Action)
+	}
+	assert.Len(t, plan.Warnings, 2)
+}
+
+func TestComputeRejectsKindConflicts(t *testing.T) {
+	state := convergedState()
+	state.Nodes["orderer0"].Kind = KindPeer
+	_, err := Compute(parseTestSpec(t), state)
+	assert.ErrorContains(t, err, "orderer0")
+
+	state = convergedState()
+	state.Networks["besu1"].Platform = "FABRIC"
+	_, err = Compute(parseTestSpec(t), state)
+	assert.ErrorContains(t, err, "besu1")
+}
+
+func TestChannelMembership(t *testing.T) {
+	block := map[string]interface{}{
+		"data": map[string]interface{}{"data": []interface{}{map[string]interface{}{
+			"payload": map[string]interface{}{"data": map[string]interface{}{"config": map[string]interface{}{
+				"channel_group": map[string]interface{}{"groups": map[string]interface{}{
+					"Application": map[string]interface{}{"groups": map[string]interface{}{
+						"Org1MSP": map[string]interface{}{"values": map[string]interface{}{
+							"AnchorPeers": map[string]interface{}{"value": map[string]interface{}{
+								"anchor_peers": []interface{}{map[string]interface{}{"host": "peer0.org1", "port": float64(7051)}},
+							}},
+						}},
+						"Org2MSP": map[string]interface{}{"values": map[string]interface{}{}},
+					}},
+					"Orderer": map[string]interface{}{"groups": map[string]interface{}{
+						"OrdererMSP": map[string]interface{}{},
+					}},
+				}},
+			}}},
+		}}},
+	}
+	members, anchors := ChannelMembership(block)
+	assert.Equal(t, []string{"OrdererMSP", "Org1MSP", "Org2MSP"}, members)
+	assert.Equal(t, map[string][]string{"Org1MSP": {"peer0.org1:7051"}}, anchors)
+
+	members, anchors = ChannelMembership(map[string]interface{}{})
+	assert.Empty(t, members)
+	assert.Empty(t, anchors)
+}
@@ -0,0 +1,390 @@
+// Package apply converges a ChainLaunch instance towards a declarative
+// topology file.
+//
+// A Spec lists the organizations, keys, nodes, networks, channel membership,
+// anchor peers and chaincode definitions that should exist. Compute diffs it
+// against a State snapshot of the instance and returns a Plan; Applier runs
+// the plan through a Backend that talks to the existing services. Resources
+// are identified by name (organizations by MSP ID), so applying the same
+// file twice is a no-op. Resources that exist but are not in the spec are
+// left alone: apply never deletes.
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of a ChainLaunch instance
+type Spec struct {
+	Organizations  []OrganizationSpec  `yaml:"organizations,omitempty"`
+	Keys           []KeySpec           `yaml:"keys,omitempty"`
+	Peers          []PeerSpec          `yaml:"peers,omitempty"`
+	Orderers       []OrdererSpec       `yaml:"orderers,omitempty"`
+	FabricNetworks []FabricNetworkSpec `yaml:"fabricNetworks,omitempty"`
+	BesuNetworks   []BesuNetworkSpec   `yaml:"besuNetworks,omitempty"`
+	BesuNodes      []BesuNodeSpec      `yaml:"besuNodes,omitempty"`
+}
+
+// OrganizationSpec declares a Fabric organization, identified by its MSP ID
+type OrganizationSpec struct {
+	MspID       string `yaml:"mspId"`
+	Name        string `yaml:"name,omitempty"` // defaults to the MSP ID
+	Description string `yaml:"description,omitempty"`
+	ProviderID  int64  `yaml:"providerId,omitempty"`
+}
+
+// KeySpec declares a standalone key, such as a Besu validator key
+type KeySpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Algorithm   string `yaml:"algorithm"` // RSA, EC or ED25519
+	Curve       string `yaml:"curve,omitempty"`
+	KeySize     int    `yaml:"keySize,omitempty"`
+	ProviderID  int    `yaml:"providerId,omitempty"`
+}
+
+// FabricNodeSpec holds the fields shared by peers and orderers. Empty
+// addresses are allocated when the node is created and not compared after.
+type FabricNodeSpec struct {
+	Name              string            `yaml:"name"`
+	Organization      string            `yaml:"organization"` // MSP ID
+	Mode              string            `yaml:"mode,omitempty"`
+	Version           string            `yaml:"version,omitempty"`
+	ExternalEndpoint  string            `yaml:"externalEndpoint,omitempty"`
+	ListenAddress     string            `yaml:"listenAddress,omitempty"`
+	OperationsAddress string            `yaml:"operationsAddress,omitempty"`
+	DomainNames       []string          `yaml:"domainNames,omitempty"`
+	Env               map[string]string `yaml:"env,omitempty"`
+}
+
+// PeerSpec declares a Fabric peer
+type PeerSpec struct {
+	FabricNodeSpec   `yaml:",inline"`
+	ChaincodeAddress string `yaml:"chaincodeAddress,omitempty"`
+	EventsAddress    string `yaml:"eventsAddress,omitempty"`
+}
+
+// OrdererSpec declares a Fabric orderer
+type OrdererSpec struct {
+	FabricNodeSpec `yaml:",inline"`
+	AdminAddress   string `yaml:"adminAddress,omitempty"`
+}
+
+// FabricNetworkSpec declares a Fabric channel. The organizations of the
+// listed peers and orderers are its members.
+type FabricNetworkSpec struct {
+	Name          string `yaml:"name"`
+	Description   string `yaml:"description,omitempty"`
+	ConsensusType string `yaml:"consensusType,omitempty"` // etcdraft or smartbft
+	// Peers and Orderers are node names joined to the channel
+	Peers    []string `yaml:"peers,omitempty"`
+	Orderers []string `yaml:"orderers,omitempty"`
+	// AnchorPeers maps an MSP ID to the names of its anchor peers
+	AnchorPeers map[string][]string `yaml:"anchorPeers,omitempty"`
+	Chaincodes  []ChaincodeSpec     `yaml:"chaincodes,omitempty"`
+}
+
+// ChaincodeSpec declares a chaincode definition on a Fabric network. A
+// definition is identified by its sequence.
+type ChaincodeSpec struct {
+	Name              string `yaml:"name"`
+	Version           string `yaml:"version"`
+	Sequence          int64  `yaml:"sequence"`
+	DockerImage       string `yaml:"dockerImage"`
+	EndorsementPolicy string `yaml:"endorsementPolicy,omitempty"`
+	ChaincodeAddress  string `yaml:"chaincodeAddress,omitempty"`
+}
+
+// BesuNetworkSpec declares a Besu network. Validators are key names.
+type BesuNetworkSpec struct {
+	Name           string            `yaml:"name"`
+	Description    string            `yaml:"description,omitempty"`
+	Consensus      string            `yaml:"consensus,omitempty"`
+	ChainID        int64             `yaml:"chainId"`
+	BlockPeriod    int               `yaml:"blockPeriod,omitempty"`
+	EpochLength    int               `yaml:"epochLength,omitempty"`
+	RequestTimeout int               `yaml:"requestTimeout,omitempty"`
+	Validators     []string          `yaml:"validators"`
+	Alloc          map[string]string `yaml:"alloc,omitempty"` // address -> balance in hex
+}
+
+// BesuNodeSpec declares a Besu node. Zero ports are allocated when the node
+// is created and not compared after.
+type BesuNodeSpec struct {
+	Name        string            `yaml:"name"`
+	Network     string            `yaml:"network"`
+	Key         string            `yaml:"key"`
+	Mode        string            `yaml:"mode,omitempty"`
+	Version     string            `yaml:"version,omitempty"`
+	P2PHost     string            `yaml:"p2pHost,omitempty"`
+	P2PPort     uint              `yaml:"p2pPort,omitempty"`
+	RPCHost     string            `yaml:"rpcHost,omitempty"`
+	RPCPort     uint              `yaml:"rpcPort,omitempty"`
+	ExternalIP  string            `yaml:"externalIp,omitempty"`
+	InternalIP  string            `yaml:"internalIp,omitempty"`
+	BootNodes   []string          `yaml:"bootNodes,omitempty"`
+	MetricsPort int64             `yaml:"metricsPort,omitempty"`
+	Env         map[string]string `yaml:"env,omitempty"`
+}
+
+// LoadFile reads and validates a spec from a YAML file
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a YAML spec. Unknown fields are rejected so
+// typos do not silently drop part of the topology.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that names are unique and that every reference resolves
+// to a resource declared in the spec
+func (s *Spec) Validate() error {
+	orgs := map[string]bool{}
+	for _, o := range s.Organizations {
+		if o.MspID == "" {
+			return fmt.Errorf("organization without mspId")
+		}
+		if orgs[o.MspID] {
+			return fmt.Errorf("duplicate organization %q", o.MspID)
+		}
+		orgs[o.MspID] = true
+	}
+
+	keys := map[string]bool{}
+	for _, k := range s.Keys {
+		if k.Name == "" {
+			return fmt.Errorf("key without name")
+		}
+		if keys[k.Name] {
+			return fmt.Errorf("duplicate key %q", k.Name)
+		}
+		switch k.Algorithm {
+		case "RSA", "EC", "ED25519":
+		default:
+			return fmt.Errorf("key %q: unsupported algorithm %q", k.Name, k.Algorithm)
+		}
+		keys[k.Name] = true
+	}
+
+	// Node names share one namespace, as they do in the nodes table
+	nodes := map[string]string{}
+	addNode := func(kind, name, org string) error {
+		if name == "" {
+			return fmt.Errorf("%s without name", kind)
+		}
+		if _, ok := nodes[name]; ok {
+			return fmt.Errorf("duplicate node %q", name)
+		}
+		if org != "" && !orgs[org] {
+			return fmt.Errorf("%s %q: unknown organization %q", kind, name, org)
+		}
+		nodes[name] = kind
+		return nil
+	}
+	for _, p := range s.Peers {
+		if err := addNode(KindPeer, p.Name, p.Organization); err != nil {
+			return err
+		}
+		if p.Organization == "" {
+			return fmt.Errorf("peer %q: organization is required", p.Name)
+		}
+	}
+	for _, o := range s.Orderers {
+		if err := addNode(KindOrderer, o.Name, o.Organization); err != nil {
+			return err
+		}
+		if o.Organization == "" {
+			return fmt.Errorf("orderer %q: organization is required", o.Name)
+		}
+	}
+
+	networks := map[string]bool{}
+	for _, n := range s.FabricNetworks {
+		if n.Name == "" {
+			return fmt.Errorf("fabric network without name")
+		}
+		if networks[n.Name] {
+			return fmt.Errorf("duplicate network %q", n.Name)
+		}
+		networks[n.Name] = true
+		for _, p := range n.Peers {
+			if nodes[p] != KindPeer {
+				return fmt.Errorf("network %q: unknown peer %q", n.Name, p)
+			}
+		}
+		for _, o := range n.Orderers {
+			if nodes[o] != KindOrderer {
+				return fmt.Errorf("network %q: unknown orderer %q", n.Name, o)
+			}
+		}
+		for _, msp := range sortedKeys(n.AnchorPeers) {
+			for _, p := range n.AnchorPeers[msp] {
+				peer := s.peer(p)
+				if peer == nil || !contains(n.Peers, p) {
+					return fmt.Errorf("network %q: anchor peer %q is not a peer of the network", n.Name, p)
+				}
+				if peer.Organization != msp {
+					return fmt.Errorf("network %q: anchor peer %q does not belong to %s", n.Name, p, msp)
+				}
+			}
+		}
+		seen := map[string]bool{}
+		for _, cc := range n.Chaincodes {
+			if cc.Name == "" || cc.Sequence < 1 {
+				return fmt.Errorf("network %q: chaincode needs a name and a sequence >= 1", n.Name)
+			}
+			id := fmt.Sprintf("%s/%d", cc.Name, cc.Sequence)
+			if seen[id] {
+				return fmt.Errorf("network %q: duplicate chaincode %s sequence %d", n.Name, cc.Name, cc.Sequence)
+			}
+			seen[id] = true
+		}
+	}
+	for _, n := range s.BesuNetworks {
+		if n.Name == "" {
+			return fmt.Errorf("besu network without name")
+		}
+		if networks[n.Name] {
+			return fmt.Errorf("duplicate network %q", n.Name)
+		}
+		networks[n.Name] = true
+		if len(n.Validators) == 0 {
+			return fmt.Errorf("besu network %q: at least one validator key is required", n.Name)
+		}
+		for _, v := range n.Validators {
+			if !keys[v] {
+				return fmt.Errorf("besu network %q: unknown validator key %q", n.Name, v)
+			}
+		}
+	}
+	for _, b := range s.BesuNodes {
+		if err := addNode(KindBesuNode, b.Name, ""); err != nil {
+			return err
+		}
+		if s.besuNetwork(b.Network) == nil {
+			return fmt.Errorf("besu node %q: unknown besu network %q", b.Name, b.Network)
+		}
+		if !keys[b.Key] {
+			return fmt.Errorf("besu node %q: unknown key %q", b.Name, b.Key)
+		}
+	}
+	return nil
+}
+
+func (s *Spec) organization(mspID string) *OrganizationSpec {
+	for i := range s.Organizations {
+		if s.Organizations[i].MspID == mspID {
+			return &s.Organizations[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) key(name string) *KeySpec {
+	for i := range s.Keys {
+		if s.Keys[i].Name == name {
+			return &s.Keys[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) peer(name string) *PeerSpec {
+	for i := range s.Peers {
+		if s.Peers[i].Name == name {
+			return &s.Peers[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) orderer(name string) *OrdererSpec {
+	for i := range s.Orderers {
+		if s.Orderers[i].Name == name {
+			return &s.Orderers[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) fabricNetwork(name string) *FabricNetworkSpec {
+	for i := range s.FabricNetworks {
+		if s.FabricNetworks[i].Name == name {
+			return &s.FabricNetworks[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) besuNetwork(name string) *BesuNetworkSpec {
+	for i := range s.BesuNetworks {
+		if s.BesuNetworks[i].Name == name {
+			return &s.BesuNetworks[i]
+		}
+	}
+	return nil
+}
+
+func (s *Spec) besuNode(name string) *BesuNodeSpec {
+	for i := range s.BesuNodes {
+		if s.BesuNodes[i].Name == name {
+			return &s.BesuNodes[i]
+		}
+	}
+	return nil
+}
+
+// memberOrgs returns the MSP IDs owning the given nodes, in first-seen order
+func (s *Spec) memberOrgs(names []string) []string {
+	var orgs []string
+	for _, name := range names {
+		var org string
+		if p := s.peer(name); p != nil {
+			org = p.Organization
+		} else if o := s.orderer(name); o != nil {
+			org = o.Organization
+		}
+		if org != "" && !contains(orgs, org) {
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
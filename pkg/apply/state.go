@@ -0,0 +1,143 @@
+package apply
+
+import "sort"
+
+// State is a snapshot of the resources that exist on the instance, keyed
+// the same way the spec identifies them
+type State struct {
+	Organizations map[string]*Organization // by MSP ID
+	Keys          map[string]*Key          // by name
+	Nodes         map[string]*Node         // by name
+	Networks      map[string]*Network      // by name
+}
+
+// NewState returns an empty state
+func NewState() *State {
+	return &State{
+		Organizations: map[string]*Organization{},
+		Keys:          map[string]*Key{},
+		Nodes:         map[string]*Node{},
+		Networks:      map[string]*Network{},
+	}
+}
+
+// Organization is an existing Fabric organization
+type Organization struct {
+	ID          int64
+	MspID       string
+	Description string
+}
+
+// Key is an existing key
+type Key struct {
+	ID        int64
+	Name      string
+	Algorithm string
+	Curve     string
+}
+
+// Node is an existing peer, orderer or Besu node. Fields holds the
+// comparable configuration under the same names FieldDiff uses.
+type Node struct {
+	ID           int64
+	Name         string
+	Kind         string // KindPeer, KindOrderer or KindBesuNode
+	Organization string // MSP ID, Fabric nodes only
+	NetworkID    int64  // Besu nodes only
+	KeyID        int64  // Besu nodes only
+	Fields       map[string]string
+}
+
+// Network is an existing Fabric or Besu network
+type Network struct {
+	ID       int64
+	Name     string
+	Platform string // FABRIC or BESU
+	// Members are the MSP IDs in the channel's application and orderer groups
+	Members []string
+	// Peers and Orderers are the names of the nodes joined to the channel
+	Peers    []string
+	Orderers []string
+	// AnchorPeers maps an MSP ID to its anchor peer endpoints (host:port)
+	AnchorPeers map[string][]string
+	Chaincodes  map[string]*Chaincode // by name
+}
+
+// Chaincode is an existing chaincode with its definitions
+type Chaincode struct {
+	ID          int64
+	Name        string
+	Definitions []ChaincodeDefinition
+}
+
+// ChaincodeDefinition is an existing chaincode definition
+type ChaincodeDefinition struct {
+	ID                int64
+	Version           string
+	Sequence          int64
+	DockerImage       string
+	EndorsementPolicy string
+	ChaincodeAddress  string
+}
+
+func (n *Network) definition(chaincode string, sequence int64) *ChaincodeDefinition {
+	cc := n.Chaincodes[chaincode]
+	if cc == nil {
+		return nil
+	}
+	for i := range cc.Definitions {
+		if cc.Definitions[i].Sequence == sequence {
+			return &cc.Definitions[i]
+		}
+	}
+	return nil
+}
+
+// ChannelMembership extracts the member MSP IDs and the anchor peers from a
+// config block rendered as JSON by protolator, as returned by the
+// current-channel-config endpoint
+func ChannelMembership(block map[string]interface{}) (members []string, anchorPeers map[string][]string) {
+	anchorPeers = map[string][]string{}
+	groups := path(block, "data", "data", 0, "payload", "data", "config", "channel_group", "groups")
+	for _, section := range []string{"Application", "Orderer"} {
+		orgs, _ := path(groups, section, "groups").(map[string]interface{})
+		for msp, org := range orgs {
+			if !contains(members, msp) {
+				members = append(members, msp)
+			}
+			list, _ := path(org, "values", "AnchorPeers", "value", "anchor_peers").([]interface{})
+			for _, item := range list {
+				ap, _ := item.(map[string]interface{})
+				host, _ := ap["host"].(string)
+				port, _ := ap["port"].(float64)
+				if host == "" {
+					continue
+				}
+				anchorPeers[msp] = append(anchorPeers[msp], joinHostPort(host, int(port)))
+			}
+		}
+	}
+	sort.Strings(members)
+	return members, anchorPeers
+}
+
+// path walks nested maps and slices decoded from JSON
+func path(v interface{}, keys ...interface{}) interface{} {
+	for _, k := range keys {
+		switch key := k.(type) {
+		case string:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = m[key]
+		case int:
+			s, ok := v.([]interface{})
+			if !ok || key >= len(s) {
+				return nil
+			}
+			v = s[key]
+		}
+	}
+	return v
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/instrumentation"
 	"github.com/google/uuid"
 )
 
@@ -76,9 +77,15 @@ func (s *AuditService) LogEventAsync(event Event) {
 		// Event queued successfully
 	default:
 		// Queue is full, log error but don't block
+		instrumentation.AuditEventsDropped.Inc()
 	}
 }
 
+// QueueDepth returns the number of events waiting to be persisted
+func (s *AuditService) QueueDepth() int {
+	return len(s.queue)
+}
+
 // worker processes events from the channel
 func (s *AuditService) worker() {
 	defer s.wg.Done()
@@ -22,6 +22,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/crypto"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/instrumentation"
 	"github.com/chainlaunch/chainlaunch/pkg/notifications"
 	notificationService "github.com/chainlaunch/chainlaunch/pkg/notifications/service"
 	"github.com/robfig/cron/v3"
@@ -630,6 +631,7 @@ func (s *BackupService) createScheduledBackup(ctx context.Context, schedule *db.
 // performBackup executes the actual backup process
 func (s *BackupService) performBackup(backup *db.Backup) {
 	ctx := context.Background()
+	start := time.Now()
 
 	// Update status to in progress
 	_, err := s.queries.UpdateBackupStatus(ctx, &db.UpdateBackupStatusParams{
@@ -666,6 +668,7 @@ func (s *BackupService) performBackup(backup *db.Backup) {
 		backupErr = fmt.Errorf("Configuration error: Unsupported backup target type: %s", target.Type)
 	}
 
+	instrumentation.BackupDuration.WithLabelValues(target.Type, instrumentation.Status(backupErr)).Observe(time.Since(start).Seconds())
 	if backupErr != nil {
 		s.markBackupFailed(ctx, backup.ID, backupErr.Error())
 		s.notifyBackupFailure(ctx, backup, backupErr.Error())
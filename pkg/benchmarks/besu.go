@@ -0,0 +1,296 @@
+package benchmarks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nodesservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const receiptPollInterval = 250 * time.Millisecond
+
+// besuExecutor calls or sends transactions to a contract through a Besu
+// node's JSON-RPC endpoint. Sent transactions are signed locally; nonces are
+// handed out in order and re-read from the node when a send fails, so one
+// rejected transaction does not leave a gap that stalls the ones after it.
+type besuExecutor struct {
+	rpc    *nodesservice.RPCClient
+	to     common.Address
+	method abi.Method
+	call   bool
+
+	from     common.Address
+	key      *ecdsa.PrivateKey
+	signer   types.Signer
+	gasLimit uint64
+	gasPrice *big.Int
+
+	mu    sync.Mutex
+	nonce uint64
+}
+
+// newBesuExecutor prepares an executor; key is nil for CALL workloads.
+// sampleArgs are used to estimate the gas limit when the target has none.
+func newBesuExecutor(ctx context.Context, rpc *nodesservice.RPCClient, target *BesuTarget, w Workload, key *ecdsa.PrivateKey, sampleArgs []string) (*besuExecutor, error) {
+	if !common.IsHexAddress(target.ContractAddress) {
+		return nil, fmt.Errorf("invalid contract address %q", target.ContractAddress)
+	}
+	method, err := parseSignature(w.Function)
+	if err != nil {
+		return nil, err
+	}
+	e := &besuExecutor{
+		rpc:    rpc,
+		to:     common.HexToAddress(target.ContractAddress),
+		method: method,
+		call:   w.Mode == ModeCall,
+	}
+	if e.call {
+		return e, nil
+	}
+
+	e.key = key
+	e.from = crypto.PubkeyToAddress(key.PublicKey)
+
+	chainIDHex, err := rpc.GetChainId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	chainID, err := hexutil.DecodeBig(chainIDHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain ID %q: %w", chainIDHex, err)
+	}
+	e.signer = types.NewEIP155Signer(chainID)
+
+	gasPriceHex, err := rpc.GetGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	if e.gasPrice, err = hexutil.DecodeBig(gasPriceHex); err != nil {
+		return nil, fmt.Errorf("invalid gas price %q: %w", gasPriceHex, err)
+	}
+
+	e.gasLimit = target.GasLimit
+	if e.gasLimit == 0 {
+		data, err := encodeCall(method, sampleArgs)
+		if err != nil {
+			return nil, err
+		}
+		gasHex, err := rpc.EstimateGas(ctx, e.message(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		gas, err := hexutil.DecodeUint64(gasHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas estimate %q: %w", gasHex, err)
+		}
+		// Leave headroom for args that touch more storage than the sample
+		e.gasLimit = gas * 3 / 2
+	}
+
+	if err := e.syncNonce(ctx); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *besuExecutor) message(data []byte) map[string]interface{} {
+	msg := map[string]interface{}{
+		"to":   e.to.Hex(),
+		"data": hexutil.Encode(data),
+	}
+	if e.key != nil {
+		msg["from"] = e.from.Hex()
+	}
+	return msg
+}
+
+// syncNonce reads the next nonce of the sender from the node; callers hold mu
+// or have not shared the executor yet
+func (e *besuExecutor) syncNonce(ctx context.Context) error {
+	nonceHex, err := e.rpc.GetTransactionCount(ctx, e.from.Hex(), "pending")
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	nonce, err := hexutil.DecodeUint64(nonceHex)
+	if err != nil {
+		return fmt.Errorf("invalid nonce %q: %w", nonceHex, err)
+	}
+	e.nonce = nonce
+	return nil
+}
+
+func (e *besuExecutor) execute(ctx context.Context, args []string) error {
+	data, err := encodeCall(e.method, args)
+	if err != nil {
+		return failure(FailureOther, err)
+	}
+	if e.call {
+		if _, err := e.rpc.Call(ctx, e.message(data), "latest"); err != nil {
+			return failure(FailureCall, err)
+		}
+		return nil
+	}
+
+	txHash, err := e.send(ctx, data)
+	if err != nil {
+		return failure(FailureSend, err)
+	}
+	for {
+		receipt, err := e.rpc.GetTransactionReceipt(ctx, txHash)
+		if err != nil && ctx.Err() != nil {
+			return err
+		}
+		if err == nil && receipt != nil {
+			if status, _ := receipt["status"].(string); status != "0x1" {
+				return failure(FailureReverted, fmt.Errorf("transaction reverted (status %s)", status))
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(receiptPollInterval):
+		}
+	}
+}
+
+func (e *besuExecutor) send(ctx context.Context, data []byte) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    e.nonce,
+		To:       &e.to,
+		Gas:      e.gasLimit,
+		GasPrice: e.gasPrice,
+		Data:     data,
+	}), e.signer, e.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	txHash, err := e.rpc.SendRawTransaction(ctx, hexutil.Encode(raw))
+	if err != nil {
+		if syncErr := e.syncNonce(ctx); syncErr != nil {
+			return "", fmt.Errorf("%w (and %v)", err, syncErr)
+		}
+		return "", err
+	}
+	e.nonce++
+	return txHash, nil
+}
+
+func (e *besuExecutor) close() {}
+
+// parseSignature parses a Solidity function signature such as
+// "transfer(address,uint256)". Tuple and array parameters are not supported.
+func parseSignature(signature string) (abi.Method, error) {
+	signature = strings.ReplaceAll(signature, " ", "")
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return abi.Method{}, fmt.Errorf("invalid function signature %q: expected name(type,...)", signature)
+	}
+	name := signature[:open]
+	var inputs abi.Arguments
+	if params := signature[open+1 : len(signature)-1]; params != "" {
+		for i, param := range strings.Split(params, ",") {
+			if strings.ContainsAny(param, "[(") {
+				return abi.Method{}, fmt.Errorf("unsupported parameter type %q", param)
+			}
+			typ, err := abi.NewType(param, "", nil)
+			if err != nil {
+				return abi.Method{}, fmt.Errorf("invalid parameter type %q: %w", param, err)
+			}
+			inputs = append(inputs, abi.Argument{Name: fmt.Sprintf("arg%d", i), Type: typ})
+		}
+	}
+	return abi.NewMethod(name, name, abi.Function, "nonpayable", false, false, inputs, nil), nil
+}
+
+// encodeCall ABI-encodes a call of method with string args
+func encodeCall(method abi.Method, args []string) ([]byte, error) {
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("%s takes %d args, got %d", method.Sig, len(method.Inputs), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := abiValue(method.Inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	packed, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, method.ID...), packed...), nil
+}
+
+// abiValue converts s to the Go value the abi package packs for typ
+func abiValue(typ abi.Type, s string) (interface{}, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		goType := typ.GetType()
+		if goType == reflect.TypeOf(n) {
+			return n, nil
+		}
+		v := reflect.New(goType).Elem()
+		if typ.T == abi.IntTy {
+			if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+				return nil, fmt.Errorf("%s out of range for %s", s, typ)
+			}
+			v.SetInt(n.Int64())
+		} else {
+			if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+				return nil, fmt.Errorf("%s out of range for %s", s, typ)
+			}
+			v.SetUint(n.Uint64())
+		}
+		return v.Interface(), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+	case abi.StringTy:
+		return s, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+		return common.HexToAddress(s), nil
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > typ.Size {
+			return nil, fmt.Errorf("%d bytes do not fit in %s", len(b), typ)
+		}
+		v := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
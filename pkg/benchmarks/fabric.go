@@ -0,0 +1,53 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc"
+)
+
+// fabricExecutor sends transactions through the Fabric Gateway of one peer.
+// Submitted transactions go through each stage separately so a failure is
+// attributed to endorsement, ordering or commit.
+type fabricExecutor struct {
+	contract *client.Contract
+	conn     *grpc.ClientConn
+	function string
+	evaluate bool
+}
+
+func (e *fabricExecutor) execute(ctx context.Context, args []string) error {
+	if e.evaluate {
+		if _, err := e.contract.EvaluateWithContext(ctx, e.function, client.WithArguments(args...)); err != nil {
+			return failure(FailureEvaluate, err)
+		}
+		return nil
+	}
+
+	proposal, err := e.contract.NewProposal(e.function, client.WithArguments(args...))
+	if err != nil {
+		return failure(FailureOther, err)
+	}
+	transaction, err := proposal.EndorseWithContext(ctx)
+	if err != nil {
+		return failure(FailureEndorsement, err)
+	}
+	commit, err := transaction.SubmitWithContext(ctx)
+	if err != nil {
+		return failure(FailureSubmit, err)
+	}
+	status, err := commit.StatusWithContext(ctx)
+	if err != nil {
+		return failure(FailureCommit, err)
+	}
+	if !status.Successful {
+		return failure(FailureCommit, fmt.Errorf("transaction committed as invalid: %s", status.Code))
+	}
+	return nil
+}
+
+func (e *fabricExecutor) close() {
+	e.conn.Close()
+}
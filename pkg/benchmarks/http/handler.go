@@ -0,0 +1,202 @@
+// Package http exposes REST endpoints for benchmark runs.
+//
+// Routes mounted under /benchmarks:
+//
+//	GET    /benchmarks                  list runs, newest first
+//	POST   /benchmarks                  start a run in the background
+//	GET    /benchmarks/compare?ids=1,2  compare the reports of finished runs
+//	GET    /benchmarks/{id}             get a run and its report
+//	POST   /benchmarks/{id}/cancel      stop a running benchmark
+//	DELETE /benchmarks/{id}             delete a finished run
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/benchmarks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	service  *benchmarks.Service
+	validate *validator.Validate
+}
+
+func NewHandler(svc *benchmarks.Service) *Handler {
+	return &Handler{service: svc, validate: validator.New()}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/benchmarks", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Start)
+		r.Get("/compare", h.Compare)
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", h.Get)
+			r.Post("/cancel", h.Cancel)
+			r.Delete("/", h.Delete)
+		})
+	})
+}
+
+// @Summary List benchmark runs
+// @Tags Benchmarks
+// @Produce json
+// @Success 200 {array} benchmarks.Run
+// @Router /benchmarks [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.service.List(r.Context())
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, runs)
+}
+
+// @Summary Start a benchmark run
+// @Description Connects to the chaincode or contract and drives the workload in the background. Poll the run for its report.
+// @Tags Benchmarks
+// @Accept json
+// @Produce json
+// @Param request body benchmarks.StartRequest true "Target and workload"
+// @Success 202 {object} benchmarks.Run
+// @Router /benchmarks [post]
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	var req benchmarks.StartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	run, err := h.service.Start(r.Context(), req)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+// @Summary Compare benchmark runs
+// @Description Lines up throughput, latency percentiles and failure rates of finished runs; changes are relative to the first run
+// @Tags Benchmarks
+// @Produce json
+// @Param ids query string true "Comma-separated run IDs, baseline first"
+// @Success 200 {object} benchmarks.Comparison
+// @Router /benchmarks/compare [get]
+func (h *Handler) Compare(w http.ResponseWriter, r *http.Request) {
+	var ids []int64
+	for _, s := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			writeErr(w, http.StatusBadRequest, "invalid run ID: "+s)
+			return
+		}
+		ids = append(ids, id)
+	}
+	comparison, err := h.service.Compare(r.Context(), ids)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, comparison)
+}
+
+// @Summary Get a benchmark run
+// @Tags Benchmarks
+// @Produce json
+// @Param id path int true "Run ID"
+// @Success 200 {object} benchmarks.Run
+// @Router /benchmarks/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	run, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+// @Summary Cancel a benchmark run
+// @Description The run stops sending and its report covers what ran until then
+// @Tags Benchmarks
+// @Param id path int true "Run ID"
+// @Success 204
+// @Router /benchmarks/{id}/cancel [post]
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Cancel(r.Context(), id); err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Delete a benchmark run
+// @Tags Benchmarks
+// @Param id path int true "Run ID"
+// @Success 204
+// @Router /benchmarks/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid run ID")
+		return 0, false
+	}
+	return id, true
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, benchmarks.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, benchmarks.ErrNotRunning), errors.Is(err, benchmarks.ErrRunning):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErr(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,274 @@
+package benchmarks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	// maxErrorMessages caps the distinct error messages kept in a report
+	maxErrorMessages = 20
+)
+
+// executor sends one transaction of a workload. Failures are returned as a
+// *txError carrying the stage that failed.
+type executor interface {
+	execute(ctx context.Context, args []string) error
+	close()
+}
+
+type txError struct {
+	kind FailureKind
+	err  error
+}
+
+func (e *txError) Error() string { return e.err.Error() }
+
+func (e *txError) Unwrap() error { return e.err }
+
+func failure(kind FailureKind, err error) error {
+	return &txError{kind: kind, err: err}
+}
+
+// argsGenerator renders the args of each transaction
+type argsGenerator struct {
+	templates []*template.Template
+}
+
+type argsData struct {
+	Seq    int64
+	Worker int
+}
+
+func newArgsGenerator(args []string) (*argsGenerator, error) {
+	g := &argsGenerator{}
+	for i, arg := range args {
+		tmpl, err := template.New(fmt.Sprintf("arg%d", i)).Funcs(sprig.TxtFuncMap()).Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arg %d: %w", i, err)
+		}
+		g.templates = append(g.templates, tmpl)
+	}
+	return g, nil
+}
+
+func (g *argsGenerator) render(seq int64, worker int) ([]string, error) {
+	args := make([]string, len(g.templates))
+	data := argsData{Seq: seq, Worker: worker}
+	for i, tmpl := range g.templates {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render arg %d: %w", i, err)
+		}
+		args[i] = buf.String()
+	}
+	return args, nil
+}
+
+// recorder collects the outcome of every transaction
+type recorder struct {
+	mu        sync.Mutex
+	start     time.Time
+	latencies []time.Duration
+	failures  map[FailureKind]int64
+	errors    map[ErrorCount]int64
+	timeline  []Interval
+}
+
+func newRecorder(start time.Time) *recorder {
+	return &recorder{
+		start:    start,
+		failures: make(map[FailureKind]int64),
+		errors:   make(map[ErrorCount]int64),
+	}
+}
+
+func (r *recorder) record(finished time.Time, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	second := int(finished.Sub(r.start) / time.Second)
+	for len(r.timeline) <= second {
+		r.timeline = append(r.timeline, Interval{Second: len(r.timeline)})
+	}
+	if err == nil {
+		r.latencies = append(r.latencies, latency)
+		r.timeline[second].Succeeded++
+		return
+	}
+
+	r.timeline[second].Failed++
+	kind := FailureOther
+	var txErr *txError
+	if errors.As(err, &txErr) {
+		kind = txErr.kind
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		kind = FailureTimeout
+	}
+	r.failures[kind]++
+	key := ErrorCount{Kind: kind, Message: err.Error()}
+	if _, ok := r.errors[key]; ok || len(r.errors) < maxErrorMessages {
+		r.errors[key]++
+	}
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		DurationSeconds: elapsed.Seconds(),
+		Succeeded:       int64(len(r.latencies)),
+		Failures:        make(map[FailureKind]int64, len(r.failures)),
+		Timeline:        append([]Interval{}, r.timeline...),
+	}
+	for kind, n := range r.failures {
+		report.Failures[kind] = n
+		report.Failed += n
+	}
+	report.Total = report.Succeeded + report.Failed
+	if elapsed > 0 {
+		report.Throughput = float64(report.Succeeded) / elapsed.Seconds()
+	}
+	report.Latency = latencyStats(r.latencies)
+
+	for key, n := range r.errors {
+		key.Count = n
+		report.Errors = append(report.Errors, key)
+	}
+	sort.Slice(report.Errors, func(i, j int) bool {
+		if report.Errors[i].Count != report.Errors[j].Count {
+			return report.Errors[i].Count > report.Errors[j].Count
+		}
+		return report.Errors[i].Message < report.Errors[j].Message
+	})
+	return report
+}
+
+func latencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, l := range sorted {
+		sum += l
+	}
+	return LatencyStats{
+		Min:  millis(sorted[0]),
+		Mean: millis(sum / time.Duration(len(sorted))),
+		P50:  millis(percentile(sorted, 50)),
+		P90:  millis(percentile(sorted, 90)),
+		P95:  millis(percentile(sorted, 95)),
+		P99:  millis(percentile(sorted, 99)),
+		Max:  millis(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// run drives the workload through exec until its duration has elapsed or ctx
+// is cancelled. Transactions in flight when the duration ends are waited for;
+// cancelling ctx aborts them.
+func run(ctx context.Context, w Workload, args *argsGenerator, exec executor) *Report {
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	timeout := defaultTimeout
+	if w.TimeoutSeconds > 0 {
+		timeout = time.Duration(w.TimeoutSeconds) * time.Second
+	}
+
+	start := time.Now()
+	rec := newRecorder(start)
+	jobs := make(chan int64)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for seq := range jobs {
+				txArgs, err := args.render(seq, worker)
+				if err != nil {
+					rec.record(time.Now(), 0, failure(FailureOther, err))
+					continue
+				}
+				txCtx, cancel := context.WithTimeout(ctx, timeout)
+				sent := time.Now()
+				err = exec.execute(txCtx, txArgs)
+				if err != nil && txCtx.Err() == context.DeadlineExceeded {
+					err = failure(FailureTimeout, fmt.Errorf("no outcome within %s: %w", timeout, err))
+				}
+				cancel()
+				finished := time.Now()
+				rec.record(finished, finished.Sub(sent), err)
+			}
+		}(worker)
+	}
+
+	dispatchCtx, cancel := context.WithTimeout(ctx, time.Duration(w.DurationSeconds)*time.Second)
+	defer cancel()
+	dispatch(dispatchCtx, w.TPS, jobs)
+	close(jobs)
+	wg.Wait()
+
+	return rec.report(time.Since(start))
+}
+
+// dispatch hands out transaction numbers at tps per second, or as fast as
+// they are taken when tps is 0, until ctx is done. Ticks that find every
+// worker busy are dropped, so a saturated target shows as lower throughput.
+func dispatch(ctx context.Context, tps float64, jobs chan<- int64) {
+	var seq int64
+	if tps <= 0 {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- seq:
+				seq++
+			}
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / tps))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case jobs <- seq:
+				seq++
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package benchmarks
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExecutor struct {
+	calls  atomic.Int64
+	delay  time.Duration
+	failAt func(args []string) error
+}
+
+func (f *fakeExecutor) execute(ctx context.Context, args []string) error {
+	f.calls.Add(1)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(f.delay):
+	}
+	if f.failAt != nil {
+		return f.failAt(args)
+	}
+	return nil
+}
+
+func (f *fakeExecutor) close() {}
+
+func TestArgsGenerator(t *testing.T) {
+	g, err := newArgsGenerator([]string{"asset-{{.Seq}}", "{{.Worker}}", `{{randInt 5 6}}`})
+	require.NoError(t, err)
+	args, err := g.render(42, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"asset-42", "3", "5"}, args)
+
+	_, err = newArgsGenerator([]string{"{{.Seq"})
+	assert.Error(t, err)
+}
+
+func TestLatencyStats(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	stats := latencyStats(latencies)
+	assert.Equal(t, 1.0, stats.Min)
+	assert.Equal(t, 50.0, stats.P50)
+	assert.Equal(t, 95.0, stats.P95)
+	assert.Equal(t, 99.0, stats.P99)
+	assert.Equal(t, 100.0, stats.Max)
+	assert.Equal(t, 50.5, stats.Mean)
+
+	assert.Equal(t, LatencyStats{}, latencyStats(nil))
+}
+
+func TestRunClassifiesFailures(t *testing.T) {
+	g, err := newArgsGenerator([]string{"{{.Seq}}"})
+	require.NoError(t, err)
+	exec := &fakeExecutor{
+		delay: time.Millisecond,
+		failAt: func(args []string) error {
+			switch args[0][len(args[0])-1] {
+			case '1':
+				return failure(FailureEndorsement, errors.New("chaincode response 500"))
+			case '2':
+				return failure(FailureCommit, errors.New("transaction committed as invalid: MVCC_READ_CONFLICT"))
+			}
+			return nil
+		},
+	}
+
+	report := run(context.Background(), Workload{Function: "f", Concurrency: 4, DurationSeconds: 1}, g, exec)
+
+	assert.Equal(t, exec.calls.Load(), report.Total)
+	assert.Equal(t, report.Total, report.Succeeded+report.Failed)
+	assert.Positive(t, report.Failures[FailureEndorsement])
+	assert.Positive(t, report.Failures[FailureCommit])
+	assert.Greater(t, report.Succeeded, report.Failed)
+	assert.Positive(t, report.Throughput)
+	assert.GreaterOrEqual(t, report.Latency.P50, 1.0)
+	require.Len(t, report.Errors, 2)
+	assert.NotEmpty(t, report.Timeline)
+}
+
+func TestRunRespectsTPSAndTimeout(t *testing.T) {
+	g, err := newArgsGenerator(nil)
+	require.NoError(t, err)
+
+	exec := &fakeExecutor{}
+	report := run(context.Background(), Workload{Function: "f", TPS: 20, Concurrency: 2, DurationSeconds: 1}, g, exec)
+	assert.InDelta(t, 20, report.Total, 3)
+
+	slow := &fakeExecutor{delay: 2 * time.Second}
+	report = run(context.Background(), Workload{Function: "f", TPS: 5, Concurrency: 1, DurationSeconds: 1, TimeoutSeconds: 1}, g, slow)
+	assert.Equal(t, int64(1), report.Failures[FailureTimeout])
+}
+
+func TestEncodeCall(t *testing.T) {
+	method, err := parseSignature("transfer(address, uint256)")
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", method.Sig)
+
+	data, err := encodeCall(method, []string{"0x00000000000000000000000000000000000000aa", "1000"})
+	require.NoError(t, err)
+	// transfer(address,uint256) selector
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(data[:4]))
+	assert.Len(t, data, 4+64)
+	assert.Equal(t, byte(0xaa), data[4+31])
+	assert.Equal(t, byte(0xe8), data[4+63])
+
+	small, err := parseSignature("set(uint8,bool,bytes32,string)")
+	require.NoError(t, err)
+	_, err = encodeCall(small, []string{"7", "true", "0x01", "hello"})
+	require.NoError(t, err)
+	_, err = encodeCall(small, []string{"300", "true", "0x01", "hello"})
+	assert.Error(t, err)
+
+	_, err = parseSignature("set(uint256[])")
+	assert.Error(t, err)
+	_, err = parseSignature("set")
+	assert.Error(t, err)
+}
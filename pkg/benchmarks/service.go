@@ -0,0 +1,373 @@
+// Package benchmarks load tests chaincodes and contracts deployed through
+// ChainLaunch and keeps a report of every run so releases can be compared.
+//
+// A run drives a workload (function, templated args, target TPS, concurrency
+// and duration) through the Fabric Gateway of a peer or the JSON-RPC endpoint
+// of a Besu node, and records latency percentiles, throughput and failures by
+// the stage they happened at.
+package benchmarks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	nodesservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrInvalidRequest is returned when a run can't be started as requested
+	ErrInvalidRequest = errors.New("invalid benchmark request")
+	// ErrNotRunning is returned when cancelling a run that has finished
+	ErrNotRunning = errors.New("benchmark run is not running")
+	// ErrRunning is returned when deleting a run that has not finished
+	ErrRunning = errors.New("benchmark run is still running")
+)
+
+// executorFactory connects to the target of a run
+type executorFactory func(ctx context.Context, req StartRequest, args *argsGenerator) (executor, error)
+
+// Service starts benchmark runs and stores their reports
+type Service struct {
+	queries     *db.Queries
+	logger      *logger.Logger
+	newExecutor executorFactory
+
+	mu      sync.Mutex
+	running map[int64]context.CancelFunc
+}
+
+// NewService creates a benchmarks service that reaches Fabric chaincodes
+// through the chaincode service and Besu contracts through node RPC clients
+func NewService(
+	queries *db.Queries,
+	chaincodes *chainlaunchdeploy.ChaincodeService,
+	nodes *nodesservice.NodeService,
+	keys *keymanagement.KeyManagementService,
+	logger *logger.Logger,
+) *Service {
+	return &Service{
+		queries: queries,
+		logger:  logger,
+		running: make(map[int64]context.CancelFunc),
+		newExecutor: func(ctx context.Context, req StartRequest, args *argsGenerator) (executor, error) {
+			switch req.Platform {
+			case PlatformFabric:
+				contract, conn, err := chaincodes.GetContract(ctx, req.Fabric.ChaincodeID, req.Fabric.Channel, req.Fabric.KeyID)
+				if err != nil {
+					return nil, err
+				}
+				return &fabricExecutor{
+					contract: contract,
+					conn:     conn,
+					function: req.Workload.Function,
+					evaluate: req.Workload.Mode == ModeEvaluate,
+				}, nil
+			case PlatformBesu:
+				rpc, err := nodes.GetBesuRPCClient(ctx, req.Besu.NodeID)
+				if err != nil {
+					return nil, err
+				}
+				sampleArgs, err := args.render(0, 0)
+				if err != nil {
+					return nil, err
+				}
+				if req.Workload.Mode == ModeCall {
+					return newBesuExecutor(ctx, rpc, req.Besu, req.Workload, nil, sampleArgs)
+				}
+				privateKey, err := keys.GetDecryptedPrivateKey(int(req.Besu.KeyID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get signing key: %w", err)
+				}
+				key, err := crypto.HexToECDSA(privateKey)
+				if err != nil {
+					return nil, fmt.Errorf("%w: key %d is not a secp256k1 key", ErrInvalidRequest, req.Besu.KeyID)
+				}
+				return newBesuExecutor(ctx, rpc, req.Besu, req.Workload, key, sampleArgs)
+			default:
+				return nil, fmt.Errorf("%w: unsupported platform %s", ErrInvalidRequest, req.Platform)
+			}
+		},
+	}
+}
+
+// FailInterrupted marks runs left RUNNING by a previous server process as failed
+func (s *Service) FailInterrupted(ctx context.Context) error {
+	return s.queries.FailRunningBenchmarkRuns(ctx, sql.NullString{String: "interrupted by a server restart", Valid: true})
+}
+
+// normalize fills in defaults and checks what struct validation can't
+func normalize(req *StartRequest) error {
+	switch req.Platform {
+	case PlatformFabric:
+		if req.Fabric == nil || req.Besu != nil {
+			return fmt.Errorf("%w: a FABRIC run needs a fabric target only", ErrInvalidRequest)
+		}
+		if req.Workload.Mode == "" {
+			req.Workload.Mode = ModeSubmit
+		}
+		if req.Workload.Mode != ModeSubmit && req.Workload.Mode != ModeEvaluate {
+			return fmt.Errorf("%w: mode %s does not apply to Fabric", ErrInvalidRequest, req.Workload.Mode)
+		}
+	case PlatformBesu:
+		if req.Besu == nil || req.Fabric != nil {
+			return fmt.Errorf("%w: a BESU run needs a besu target only", ErrInvalidRequest)
+		}
+		if req.Workload.Mode == "" {
+			req.Workload.Mode = ModeSend
+		}
+		if req.Workload.Mode != ModeSend && req.Workload.Mode != ModeCall {
+			return fmt.Errorf("%w: mode %s does not apply to Besu", ErrInvalidRequest, req.Workload.Mode)
+		}
+		if req.Workload.Mode == ModeSend && req.Besu.KeyID == 0 {
+			return fmt.Errorf("%w: SEND needs a keyId to sign with", ErrInvalidRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported platform %s", ErrInvalidRequest, req.Platform)
+	}
+	if req.Workload.Concurrency == 0 {
+		req.Workload.Concurrency = 1
+	}
+	return nil
+}
+
+// Start connects to the target and runs the workload in the background. The
+// returned run is RUNNING; its report is stored when it finishes.
+func (s *Service) Start(ctx context.Context, req StartRequest) (*Run, error) {
+	if err := normalize(&req); err != nil {
+		return nil, err
+	}
+	args, err := newArgsGenerator(req.Workload.Args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	exec, err := s.newExecutor(ctx, req, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the benchmark target: %w", err)
+	}
+
+	config, err := json.Marshal(runConfig{Fabric: req.Fabric, Besu: req.Besu, Workload: req.Workload})
+	if err != nil {
+		exec.close()
+		return nil, fmt.Errorf("failed to encode benchmark config: %w", err)
+	}
+	row, err := s.queries.CreateBenchmarkRun(ctx, &db.CreateBenchmarkRunParams{
+		Name:     req.Name,
+		Platform: string(req.Platform),
+		Config:   string(config),
+	})
+	if err != nil {
+		exec.close()
+		return nil, fmt.Errorf("failed to create benchmark run: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	s.running[row.ID] = cancel
+	s.mu.Unlock()
+
+	go s.execute(runCtx, row.ID, req.Workload, args, exec)
+
+	return toRun(row)
+}
+
+func (s *Service) execute(ctx context.Context, id int64, w Workload, args *argsGenerator, exec executor) {
+	defer func() {
+		exec.close()
+		s.mu.Lock()
+		if cancel, ok := s.running[id]; ok {
+			cancel()
+			delete(s.running, id)
+		}
+		s.mu.Unlock()
+	}()
+
+	s.logger.Info("Starting benchmark run", "id", id, "function", w.Function, "tps", w.TPS, "concurrency", w.Concurrency, "duration", w.DurationSeconds)
+	report := run(ctx, w, args, exec)
+
+	status := StatusCompleted
+	if ctx.Err() != nil {
+		status = StatusCancelled
+	}
+	params := &db.FinishBenchmarkRunParams{ID: id, Status: string(status)}
+	if encoded, err := json.Marshal(report); err != nil {
+		params.Status = string(StatusFailed)
+		params.Error = sql.NullString{String: fmt.Sprintf("failed to encode report: %v", err), Valid: true}
+	} else {
+		params.Report = sql.NullString{String: string(encoded), Valid: true}
+	}
+	if err := s.queries.FinishBenchmarkRun(context.Background(), params); err != nil {
+		s.logger.Error("Failed to store benchmark report", "id", id, "error", err)
+		return
+	}
+	s.logger.Info("Benchmark run finished", "id", id, "status", params.Status, "throughput", report.Throughput, "p95", report.Latency.P95, "failed", report.Failed)
+}
+
+// Cancel stops a running benchmark; the report covers what ran until then
+func (s *Service) Cancel(ctx context.Context, id int64) error {
+	if _, err := s.queries.GetBenchmarkRun(ctx, id); err != nil {
+		return fmt.Errorf("failed to get benchmark run: %w", err)
+	}
+	s.mu.Lock()
+	cancel, ok := s.running[id]
+	s.mu.Unlock()
+	if !ok {
+		return ErrNotRunning
+	}
+	cancel()
+	return nil
+}
+
+// Get returns a run with its report
+func (s *Service) Get(ctx context.Context, id int64) (*Run, error) {
+	row, err := s.queries.GetBenchmarkRun(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark run: %w", err)
+	}
+	return toRun(row)
+}
+
+// List returns all runs, newest first
+func (s *Service) List(ctx context.Context) ([]Run, error) {
+	rows, err := s.queries.ListBenchmarkRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list benchmark runs: %w", err)
+	}
+	runs := make([]Run, 0, len(rows))
+	for _, row := range rows {
+		r, err := toRun(row)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *r)
+	}
+	return runs, nil
+}
+
+// Delete removes a finished run
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	if _, err := s.queries.GetBenchmarkRun(ctx, id); err != nil {
+		return fmt.Errorf("failed to get benchmark run: %w", err)
+	}
+	s.mu.Lock()
+	_, running := s.running[id]
+	s.mu.Unlock()
+	if running {
+		return ErrRunning
+	}
+	return s.queries.DeleteBenchmarkRun(ctx, id)
+}
+
+// Compare lines up the reports of finished runs against the first one
+func (s *Service) Compare(ctx context.Context, ids []int64) (*Comparison, error) {
+	if len(ids) < 2 {
+		return nil, fmt.Errorf("%w: compare needs at least two runs", ErrInvalidRequest)
+	}
+	comparison := &Comparison{Baseline: ids[0]}
+	var baseline *Report
+	for _, id := range ids {
+		r, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if r.Report == nil {
+			return nil, fmt.Errorf("%w: run %d has no report yet", ErrInvalidRequest, id)
+		}
+		row := ComparisonRow{
+			ID:         r.ID,
+			Name:       r.Name,
+			StartedAt:  r.StartedAt,
+			Throughput: r.Report.Throughput,
+			P50:        r.Report.Latency.P50,
+			P95:        r.Report.Latency.P95,
+			P99:        r.Report.Latency.P99,
+		}
+		if r.Report.Total > 0 {
+			row.FailureRate = float64(r.Report.Failed) / float64(r.Report.Total) * 100
+		}
+		if baseline == nil {
+			baseline = r.Report
+		} else {
+			row.ThroughputChange = change(baseline.Throughput, r.Report.Throughput)
+			row.P95Change = change(baseline.Latency.P95, r.Report.Latency.P95)
+			row.P99Change = change(baseline.Latency.P99, r.Report.Latency.P99)
+		}
+		comparison.Runs = append(comparison.Runs, row)
+	}
+	return comparison, nil
+}
+
+// change is the percentage change from base to v, nil when base is 0
+func change(base, v float64) *float64 {
+	if base == 0 {
+		return nil
+	}
+	c := (v - base) / base * 100
+	return &c
+}
+
+// runConfig is what the config column holds
+type runConfig struct {
+	Fabric   *FabricTarget `json:"fabric,omitempty"`
+	Besu     *BesuTarget   `json:"besu,omitempty"`
+	Workload Workload      `json:"workload"`
+}
+
+func toRun(row *db.BenchmarkRun) (*Run, error) {
+	var config runConfig
+	if err := json.Unmarshal([]byte(row.Config), &config); err != nil {
+		return nil, fmt.Errorf("failed to decode config of benchmark run %d: %w", row.ID, err)
+	}
+	r := &Run{
+		ID:        row.ID,
+		Name:      row.Name,
+		Platform:  Platform(row.Platform),
+		Status:    Status(row.Status),
+		Fabric:    config.Fabric,
+		Besu:      config.Besu,
+		Workload:  config.Workload,
+		Error:     row.Error.String,
+		StartedAt: row.StartedAt,
+	}
+	if row.FinishedAt.Valid {
+		finished := row.FinishedAt.Time
+		r.FinishedAt = &finished
+	}
+	if row.Report.Valid {
+		var report Report
+		if err := json.Unmarshal([]byte(row.Report.String), &report); err != nil {
+			return nil, fmt.Errorf("failed to decode report of benchmark run %d: %w", row.ID, err)
+		}
+		r.Report = &report
+	}
+	return r, nil
+}
+
+// Wait blocks until run id has finished or ctx is done
+func (s *Service) Wait(ctx context.Context, id int64) (*Run, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		r, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if r.Status != StatusRunning {
+			return r, nil
+		}
+		select {
+		case <-ctx.Done():
+			return r, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package benchmarks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+)
+
+func newTestService(t *testing.T, exec executor) *Service {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	return &Service{
+		queries: db.New(sqlDB),
+		logger:  logger.NewDefault(),
+		running: make(map[int64]context.CancelFunc),
+		newExecutor: func(ctx context.Context, req StartRequest, args *argsGenerator) (executor, error) {
+			return exec, nil
+		},
+	}
+}
+
+func fabricRequest(name string, duration int) StartRequest {
+	return StartRequest{
+		Name:     name,
+		Platform: PlatformFabric,
+		Fabric:   &FabricTarget{ChaincodeID: 1, KeyID: 1},
+		Workload: Workload{Function: "CreateAsset", Args: []string{"asset-{{.Seq}}"}, DurationSeconds: duration},
+	}
+}
+
+func TestStartStoresReportAndCompare(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, &fakeExecutor{delay: time.Millisecond})
+
+	first, err := s.Start(ctx, fabricRequest("v1.0", 1))
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, first.Status)
+	assert.Equal(t, ModeSubmit, first.Workload.Mode)
+	assert.ErrorIs(t, s.Delete(ctx, first.ID), ErrRunning)
+
+	first, err = s.Wait(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, first.Status)
+	require.NotNil(t, first.Report)
+	assert.Positive(t, first.Report.Succeeded)
+	assert.NotNil(t, first.FinishedAt)
+
+	second, err := s.Start(ctx, fabricRequest("v1.1", 1))
+	require.NoError(t, err)
+	_, err = s.Compare(ctx, []int64{first.ID, second.ID})
+	assert.ErrorIs(t, err, ErrInvalidRequest, "a running benchmark has no report yet")
+	_, err = s.Wait(ctx, second.ID)
+	require.NoError(t, err)
+
+	comparison, err := s.Compare(ctx, []int64{first.ID, second.ID})
+	require.NoError(t, err)
+	require.Len(t, comparison.Runs, 2)
+	assert.Nil(t, comparison.Runs[0].ThroughputChange)
+	assert.NotNil(t, comparison.Runs[1].ThroughputChange)
+
+	runs, err := s.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+	require.NoError(t, s.Delete(ctx, first.ID))
+	_, err = s.Get(ctx, first.ID)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestCancelAndInterruptedRuns(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, &fakeExecutor{delay: time.Millisecond})
+
+	r, err := s.Start(ctx, fabricRequest("long", 3600))
+	require.NoError(t, err)
+	require.NoError(t, s.Cancel(ctx, r.ID))
+	r, err = s.Wait(ctx, r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCancelled, r.Status)
+	assert.NotNil(t, r.Report)
+	assert.ErrorIs(t, s.Cancel(ctx, r.ID), ErrNotRunning)
+
+	// A run the server never finished
+	row, err := s.queries.CreateBenchmarkRun(ctx, &db.CreateBenchmarkRunParams{Name: "lost", Platform: "FABRIC", Config: `{"workload":{}}`})
+	require.NoError(t, err)
+	require.NoError(t, s.FailInterrupted(ctx))
+	lost, err := s.Get(ctx, row.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, lost.Status)
+	assert.NotEmpty(t, lost.Error)
+}
+
+func TestStartValidatesTarget(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, &fakeExecutor{})
+
+	req := fabricRequest("bad", 1)
+	req.Workload.Mode = ModeSend
+	_, err := s.Start(ctx, req)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = s.Start(ctx, StartRequest{
+		Name:     "besu",
+		Platform: PlatformBesu,
+		Besu:     &BesuTarget{NodeID: 1, ContractAddress: "0x00000000000000000000000000000000000000aa"},
+		Workload: Workload{Function: "set(uint256)", Args: []string{"{{.Seq}}"}, DurationSeconds: 1},
+	})
+	assert.ErrorIs(t, err, ErrInvalidRequest, "SEND needs a key")
+}
@@ -0,0 +1,192 @@
+package benchmarks
+
+import (
+	"time"
+)
+
+// Platform is the kind of network a benchmark runs against
+type Platform string
+
+const (
+	PlatformFabric Platform = "FABRIC"
+	PlatformBesu   Platform = "BESU"
+)
+
+// Status is the state of a benchmark run
+type Status string
+
+const (
+	StatusRunning   Status = "RUNNING"
+	StatusCompleted Status = "COMPLETED"
+	StatusCancelled Status = "CANCELLED"
+	StatusFailed    Status = "FAILED"
+)
+
+// Mode selects whether transactions are committed or only evaluated
+type Mode string
+
+const (
+	// ModeSubmit endorses, orders and waits for the commit of Fabric transactions
+	ModeSubmit Mode = "SUBMIT"
+	// ModeEvaluate queries a Fabric chaincode without ordering
+	ModeEvaluate Mode = "EVALUATE"
+	// ModeSend signs and sends Besu transactions and waits for their receipt
+	ModeSend Mode = "SEND"
+	// ModeCall executes eth_call against a Besu contract
+	ModeCall Mode = "CALL"
+)
+
+// FailureKind is the stage a transaction failed at
+type FailureKind string
+
+const (
+	// FailureEndorsement is a Fabric proposal rejected by the endorsing peers
+	FailureEndorsement FailureKind = "ENDORSEMENT"
+	// FailureSubmit is a Fabric transaction the orderer did not accept
+	FailureSubmit FailureKind = "SUBMIT"
+	// FailureCommit is a Fabric transaction that was ordered but not committed
+	// as valid, e.g. an MVCC read conflict
+	FailureCommit FailureKind = "COMMIT"
+	// FailureEvaluate is a failed Fabric query
+	FailureEvaluate FailureKind = "EVALUATE"
+	// FailureCall is a failed Besu eth_call
+	FailureCall FailureKind = "CALL"
+	// FailureSend is a Besu transaction rejected by the node
+	FailureSend FailureKind = "SEND"
+	// FailureReverted is a mined Besu transaction with a failed receipt
+	FailureReverted FailureKind = "REVERTED"
+	// FailureTimeout is a transaction without an outcome within the timeout
+	FailureTimeout FailureKind = "TIMEOUT"
+	// FailureOther covers everything else, e.g. args that fail to render
+	FailureOther FailureKind = "OTHER"
+)
+
+// Workload describes the transactions a benchmark sends
+type Workload struct {
+	// Function is the chaincode function, or for Besu the Solidity signature,
+	// e.g. "transfer(address,uint256)"
+	Function string `json:"function" validate:"required"`
+	// Args are rendered for every transaction as Go templates with the sprig
+	// functions, .Seq (the transaction number from 0) and .Worker, e.g.
+	// "asset-{{.Seq}}" or "{{randInt 1 100}}"
+	Args []string `json:"args,omitempty"`
+	// Mode defaults to SUBMIT for Fabric and SEND for Besu
+	Mode Mode `json:"mode,omitempty" validate:"omitempty,oneof=SUBMIT EVALUATE SEND CALL"`
+	// TPS is the target send rate; 0 sends as fast as the workers allow
+	TPS float64 `json:"tps,omitempty" validate:"gte=0,lte=100000"`
+	// Concurrency is the number of transactions in flight at most
+	Concurrency     int `json:"concurrency,omitempty" validate:"gte=0,lte=1000"`
+	DurationSeconds int `json:"durationSeconds" validate:"required,gt=0,lte=86400"`
+	// TimeoutSeconds bounds a single transaction, 30 by default
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty" validate:"gte=0"`
+}
+
+// FabricTarget is a chaincode deployed through ChainLaunch
+type FabricTarget struct {
+	ChaincodeID int64 `json:"chaincodeId" validate:"required"`
+	// KeyID is the identity transactions are signed with; the gateway peer is
+	// one of its organization's peers
+	KeyID int64 `json:"keyId" validate:"required"`
+	// Channel defaults to the chaincode's network
+	Channel string `json:"channel,omitempty"`
+}
+
+// BesuTarget is a contract reached through a Besu node's RPC endpoint
+type BesuTarget struct {
+	NodeID          int64  `json:"nodeId" validate:"required"`
+	ContractAddress string `json:"contractAddress" validate:"required"`
+	// KeyID is the secp256k1 key transactions are signed with; required for SEND
+	KeyID int64 `json:"keyId,omitempty"`
+	// GasLimit defaults to an estimate of the first transaction with headroom
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+}
+
+// StartRequest starts a benchmark run
+type StartRequest struct {
+	// Name labels the run, e.g. the release under test; runs with the same
+	// name are easy to compare
+	Name     string        `json:"name" validate:"required"`
+	Platform Platform      `json:"platform" validate:"required,oneof=FABRIC BESU"`
+	Fabric   *FabricTarget `json:"fabric,omitempty"`
+	Besu     *BesuTarget   `json:"besu,omitempty"`
+	Workload Workload      `json:"workload"`
+}
+
+// Run is a benchmark run and, once finished, its report
+type Run struct {
+	ID         int64         `json:"id"`
+	Name       string        `json:"name"`
+	Platform   Platform      `json:"platform"`
+	Status     Status        `json:"status"`
+	Fabric     *FabricTarget `json:"fabric,omitempty"`
+	Besu       *BesuTarget   `json:"besu,omitempty"`
+	Workload   Workload      `json:"workload"`
+	Report     *Report       `json:"report,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+}
+
+// Report is what a run measured
+type Report struct {
+	DurationSeconds float64 `json:"durationSeconds"`
+	Total           int64   `json:"total"`
+	Succeeded       int64   `json:"succeeded"`
+	Failed          int64   `json:"failed"`
+	// Throughput is successful transactions per second
+	Throughput float64 `json:"throughput"`
+	// Latency covers successful transactions
+	Latency  LatencyStats          `json:"latency"`
+	Failures map[FailureKind]int64 `json:"failures"`
+	// Errors are the most frequent distinct error messages
+	Errors []ErrorCount `json:"errors,omitempty"`
+	// Timeline has the outcomes per second since the start
+	Timeline []Interval `json:"timeline"`
+}
+
+// LatencyStats are latencies in milliseconds
+type LatencyStats struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// ErrorCount is how often an error message was seen
+type ErrorCount struct {
+	Kind    FailureKind `json:"kind"`
+	Message string      `json:"message"`
+	Count   int64       `json:"count"`
+}
+
+// Interval is the outcome of the transactions finished in one second
+type Interval struct {
+	Second    int   `json:"second"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+// Comparison lines up the reports of several runs against the first one
+type Comparison struct {
+	Baseline int64           `json:"baseline"`
+	Runs     []ComparisonRow `json:"runs"`
+}
+
+// ComparisonRow is one run of a comparison. Changes are percentages relative
+// to the baseline and are omitted for the baseline itself.
+type ComparisonRow struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	StartedAt        time.Time `json:"startedAt"`
+	Throughput       float64   `json:"throughput"`
+	P50              float64   `json:"p50"`
+	P95              float64   `json:"p95"`
+	P99              float64   `json:"p99"`
+	FailureRate      float64   `json:"failureRate"`
+	ThroughputChange *float64  `json:"throughputChange,omitempty"`
+	P95Change        *float64  `json:"p95Change,omitempty"`
+	P99Change        *float64  `json:"p99Change,omitempty"`
+}
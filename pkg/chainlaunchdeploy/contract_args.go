@@ -0,0 +1,304 @@
+package chainlaunchdeploy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractValue is a decoded ABI value. Integers are rendered as decimal strings
+// and bytes as 0x-prefixed hex so that no precision is lost in JSON.
+type ContractValue struct {
+	Name  string      `json:"name,omitempty"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// findMethod looks up a method by name, or by signature (e.g. "transfer(address,uint256)")
+// for overloaded methods
+func findMethod(parsed abi.ABI, name string) (abi.Method, error) {
+	if method, ok := parsed.Methods[name]; ok {
+		return method, nil
+	}
+	var matches []abi.Method
+	for _, method := range parsed.Methods {
+		if method.Sig == name || method.RawName == name {
+			matches = append(matches, method)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return abi.Method{}, fmt.Errorf("method %s not found in ABI", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return abi.Method{}, fmt.Errorf("method %s is overloaded, use its signature (e.g. %s)", name, matches[0].Sig)
+	}
+}
+
+// packArgs converts JSON arguments to the Go values expected by the ABI and packs them
+func packArgs(args abi.Arguments, raw []json.RawMessage) ([]byte, error) {
+	values, err := convertArgs(args, raw)
+	if err != nil {
+		return nil, err
+	}
+	return args.Pack(values...)
+}
+
+// convertArgs converts JSON arguments to the Go values expected by the ABI
+func convertArgs(args abi.Arguments, raw []json.RawMessage) ([]interface{}, error) {
+	if len(raw) != len(args) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(args), len(raw))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := convertArg(arg.Type, raw[i])
+		if err != nil {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("argument %s (%s): %w", name, arg.Type, err)
+		}
+		values[i] = value.Interface()
+	}
+	return values, nil
+}
+
+func convertArg(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		n, err := parseInteger(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return integerValue(t, n)
+	case abi.BoolTy:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			var s string
+			if json.Unmarshal(raw, &s) != nil || (s != "true" && s != "false") {
+				return reflect.Value{}, fmt.Errorf("expected a boolean")
+			}
+			b = s == "true"
+		}
+		return reflect.ValueOf(b), nil
+	case abi.StringTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, fmt.Errorf("expected a string")
+		}
+		return reflect.ValueOf(s), nil
+	case abi.AddressTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil || !common.IsHexAddress(s) {
+			return reflect.Value{}, fmt.Errorf("expected a hex address")
+		}
+		return reflect.ValueOf(common.HexToAddress(s)), nil
+	case abi.BytesTy:
+		b, err := parseHexBytes(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+	case abi.FixedBytesTy:
+		b, err := parseHexBytes(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(b) != t.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value, nil
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return reflect.Value{}, fmt.Errorf("expected an array")
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(t.GetType(), len(items), len(items))
+		} else {
+			if len(items) != t.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", t.Size, len(items))
+			}
+			value = reflect.New(t.GetType()).Elem()
+		}
+		for i, item := range items {
+			elem, err := convertArg(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			value.Index(i).Set(elem)
+		}
+		return value, nil
+	case abi.TupleTy:
+		items, err := tupleItems(t, raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value := reflect.New(t.GetType()).Elem()
+		for i, elemType := range t.TupleElems {
+			elem, err := convertArg(*elemType, items[i])
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", t.TupleRawNames[i], err)
+			}
+			value.Field(i).Set(elem)
+		}
+		return value, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported ABI type")
+	}
+}
+
+// tupleItems accepts a tuple as an object keyed by component name or as an array
+func tupleItems(t abi.Type, raw json.RawMessage) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err == nil {
+		if len(items) != len(t.TupleElems) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(t.TupleElems), len(items))
+		}
+		return items, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("expected an object or an array")
+	}
+	items = make([]json.RawMessage, len(t.TupleRawNames))
+	for i, name := range t.TupleRawNames {
+		item, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("missing field %s", name)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// parseInteger accepts a JSON number, or a decimal or 0x-prefixed hex string
+func parseInteger(raw json.RawMessage) (*big.Int, error) {
+	s := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("expected an integer")
+		}
+	}
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("expected an integer, got %s", s)
+	}
+	return n, nil
+}
+
+// integerValue checks the integer fits the ABI type and converts it to its Go type
+func integerValue(t abi.Type, n *big.Int) (reflect.Value, error) {
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return reflect.Value{}, fmt.Errorf("%s out of range for %s", n, t)
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return reflect.Value{}, fmt.Errorf("%s out of range for %s", n, t)
+		}
+	}
+	goType := t.GetType()
+	switch goType.Kind() {
+	case reflect.Ptr:
+		return reflect.ValueOf(n), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(n.Uint64()).Convert(goType), nil
+	default:
+		return reflect.ValueOf(n.Int64()).Convert(goType), nil
+	}
+}
+
+func parseHexBytes(raw json.RawMessage) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("expected a hex string")
+	}
+	return decodeHex(s)
+}
+
+func decodeHex(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return b, nil
+}
+
+// formatValues pairs decoded values with their ABI arguments
+func formatValues(args abi.Arguments, values []interface{}) []ContractValue {
+	result := make([]ContractValue, 0, len(values))
+	for i, value := range values {
+		result = append(result, ContractValue{
+			Name:  args[i].Name,
+			Type:  args[i].Type.String(),
+			Value: formatValue(args[i].Type, reflect.ValueOf(value)),
+		})
+	}
+	return result
+}
+
+// formatValue renders a decoded value of the ABI type as JSON friendly data. The ABI type
+// tells arrays of small integers apart from fixed size byte arrays, which decode to the
+// same Go type.
+func formatValue(t abi.Type, v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	switch value := v.Interface().(type) {
+	case *big.Int:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	case common.Address:
+		return value.Hex()
+	case common.Hash:
+		// Indexed event arguments of dynamic types are only available as the hash of their value
+		return value.Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(value)
+	}
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		return formatValue(t, v.Elem())
+	}
+	switch t.T {
+	case abi.IntTy:
+		return fmt.Sprintf("%d", v.Int())
+	case abi.UintTy:
+		return fmt.Sprintf("%d", v.Uint())
+	case abi.FixedBytesTy:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return "0x" + hex.EncodeToString(b)
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = formatValue(*t.Elem, v.Index(i))
+		}
+		return items
+	case abi.TupleTy:
+		fields := make(map[string]interface{}, len(t.TupleElems))
+		for i, elemType := range t.TupleElems {
+			fields[t.TupleRawNames[i]] = formatValue(*elemType, v.Field(i))
+		}
+		return fields
+	default:
+		return v.Interface()
+	}
+}
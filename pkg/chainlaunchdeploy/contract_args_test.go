@@ -0,0 +1,154 @@
+package chainlaunchdeploy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustType(t *testing.T, typ string, components ...abi.ArgumentMarshaling) abi.Type {
+	t.Helper()
+	parsed, err := abi.NewType(typ, "", components)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestPackArgs(t *testing.T) {
+	tuple := []abi.ArgumentMarshaling{
+		{Name: "owner", Type: "address"},
+		{Name: "amount", Type: "uint64"},
+	}
+
+	tests := []struct {
+		name       string
+		typ        string
+		components []abi.ArgumentMarshaling
+		raw        string
+		// want is the value decoded back from the packed argument, as formatValue renders it
+		want    interface{}
+		wantErr string
+	}{
+		{name: "uint8 number", typ: "uint8", raw: `255`, want: "255"},
+		{name: "uint8 overflow", typ: "uint8", raw: `256`, wantErr: "out of range"},
+		{name: "uint negative", typ: "uint32", raw: `-1`, wantErr: "out of range"},
+		{name: "int8 lower bound", typ: "int8", raw: `-128`, want: "-128"},
+		{name: "int8 overflow", typ: "int8", raw: `128`, wantErr: "out of range"},
+		{name: "int64 decimal string", typ: "int64", raw: `"-9223372036854775808"`, want: "-9223372036854775808"},
+		{name: "uint256 big decimal string", typ: "uint256", raw: `"115792089237316195423570985008687907853269984665640564039457584007913129639935"`, want: "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{name: "uint256 hex string", typ: "uint256", raw: `"0xde0b6b3a7640000"`, want: "1000000000000000000"},
+		{name: "int256 negative", typ: "int256", raw: `"-1000000000000000000000"`, want: "-1000000000000000000000"},
+		{name: "int not a number", typ: "uint256", raw: `"ten"`, wantErr: "expected an integer"},
+		{name: "bool", typ: "bool", raw: `true`, want: true},
+		{name: "bool string", typ: "bool", raw: `"false"`, want: false},
+		{name: "bool invalid", typ: "bool", raw: `"yes"`, wantErr: "expected a boolean"},
+		{name: "string", typ: "string", raw: `"hello"`, want: "hello"},
+		{name: "string not a string", typ: "string", raw: `1`, wantErr: "expected a string"},
+		{name: "address", typ: "address", raw: `"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"`, want: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "address invalid", typ: "address", raw: `"0x1234"`, wantErr: "expected a hex address"},
+		{name: "bytes", typ: "bytes", raw: `"0xdeadbeef"`, want: "0xdeadbeef"},
+		{name: "bytes invalid hex", typ: "bytes", raw: `"0xzz"`, wantErr: "invalid hex"},
+		{name: "bytes4", typ: "bytes4", raw: `"0xdeadbeef"`, want: "0xdeadbeef"},
+		{name: "bytes4 wrong size", typ: "bytes4", raw: `"0xdead"`, wantErr: "expected 4 bytes, got 2"},
+		{name: "bytes32", typ: "bytes32", raw: `"0x` + strings.Repeat("ab", 32) + `"`, want: "0x" + strings.Repeat("ab", 32)},
+		{name: "dynamic array", typ: "uint256[]", raw: `[1, "2", "0x3"]`, want: []interface{}{"1", "2", "3"}},
+		{name: "empty dynamic array", typ: "address[]", raw: `[]`, want: []interface{}{}},
+		{name: "fixed array", typ: "uint8[2]", raw: `[1, 2]`, want: []interface{}{"1", "2"}},
+		{name: "fixed array of bytes", typ: "bytes2[2]", raw: `["0x0102", "0x0304"]`, want: []interface{}{"0x0102", "0x0304"}},
+		{name: "nested array", typ: "uint16[][]", raw: `[[1], [2, 3]]`, want: []interface{}{[]interface{}{"1"}, []interface{}{"2", "3"}}},
+		{name: "fixed array wrong length", typ: "uint8[2]", raw: `[1]`, wantErr: "expected 2 elements, got 1"},
+		{name: "array bad element", typ: "uint8[]", raw: `[1, 300]`, wantErr: "element 1"},
+		{name: "array not an array", typ: "uint8[]", raw: `1`, wantErr: "expected an array"},
+		{
+			name:       "tuple object",
+			typ:        "tuple",
+			components: tuple,
+			raw:        `{"owner": "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "amount": 7}`,
+			want:       map[string]interface{}{"owner": "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "amount": "7"},
+		},
+		{
+			name:       "tuple array",
+			typ:        "tuple",
+			components: tuple,
+			raw:        `["0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "7"]`,
+			want:       map[string]interface{}{"owner": "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "amount": "7"},
+		},
+		{name: "tuple missing field", typ: "tuple", components: tuple, raw: `{"owner": "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}`, wantErr: "missing field amount"},
+		{name: "tuple wrong arity", typ: "tuple", components: tuple, raw: `["0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"]`, wantErr: "expected 2 fields, got 1"},
+		{name: "tuple bad field", typ: "tuple", components: tuple, raw: `{"owner": "nope", "amount": 7}`, wantErr: "field owner"},
+		{
+			name:       "tuple array of tuples",
+			typ:        "tuple[]",
+			components: tuple,
+			raw:        `[{"owner": "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "amount": 1}]`,
+			want:       []interface{}{map[string]interface{}{"owner": "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "amount": "1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := abi.Arguments{{Name: "value", Type: mustType(t, tt.typ, tt.components...)}}
+			packed, err := packArgs(args, []json.RawMessage{json.RawMessage(tt.raw)})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "argument value")
+				return
+			}
+			require.NoError(t, err)
+
+			values, err := args.Unpack(packed)
+			require.NoError(t, err)
+			formatted := formatValues(args, values)
+			require.Len(t, formatted, 1)
+			assert.Equal(t, "value", formatted[0].Name)
+			assert.Equal(t, tt.want, formatted[0].Value)
+		})
+	}
+}
+
+func TestPackArgsArgumentCount(t *testing.T) {
+	args := abi.Arguments{{Name: "a", Type: mustType(t, "uint256")}, {Type: mustType(t, "bool")}}
+
+	_, err := packArgs(args, []json.RawMessage{json.RawMessage(`1`)})
+	assert.EqualError(t, err, "expected 2 arguments, got 1")
+
+	// Unnamed arguments are reported by position
+	_, err = packArgs(args, []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`"maybe"`)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "argument #1 (bool)")
+}
+
+func TestFindMethod(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"mint","inputs":[{"name":"amount","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"mint","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]}
+	]`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{name: "transfer", want: "transfer(address,uint256)"},
+		{name: "transfer(address,uint256)", want: "transfer(address,uint256)"},
+		{name: "mint(uint256)", want: "mint(uint256)"},
+		{name: "mint(address,uint256)", want: "mint(address,uint256)"},
+		{name: "burn", wantErr: "method burn not found in ABI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, err := findMethod(parsed, tt.name)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, method.Sig)
+		})
+	}
+}
@@ -0,0 +1,808 @@
+package chainlaunchdeploy
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	keymgmtservice "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	networkService "github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// eip1967ImplementationSlot is the storage slot holding the implementation address
+// of an EIP-1967 proxy: bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+var eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// Contract is a smart contract registered on a Besu network
+type Contract struct {
+	ID                    int64           `json:"id"`
+	NetworkID             int64           `json:"network_id"`
+	Name                  string          `json:"name"`
+	Address               string          `json:"address"`
+	ABI                   json.RawMessage `json:"abi" swaggertype:"object"`
+	BytecodeHash          string          `json:"bytecode_hash,omitempty"`
+	DeployerKeyID         *int64          `json:"deployer_key_id,omitempty"`
+	DeployTxHash          string          `json:"deploy_tx_hash,omitempty"`
+	DeployBlockNumber     *int64          `json:"deploy_block_number,omitempty"`
+	IsProxy               bool            `json:"is_proxy"`
+	ImplementationAddress string          `json:"implementation_address,omitempty"`
+	ProjectID             *int64          `json:"project_id,omitempty"`
+	CreatedAt             string          `json:"created_at"` // ISO8601
+	UpdatedAt             string          `json:"updated_at,omitempty"`
+}
+
+// ContractUpgrade records an implementation change of a proxy contract
+type ContractUpgrade struct {
+	ID                     int64  `json:"id"`
+	ContractID             int64  `json:"contract_id"`
+	PreviousImplementation string `json:"previous_implementation,omitempty"`
+	ImplementationAddress  string `json:"implementation_address"`
+	BytecodeHash           string `json:"bytecode_hash,omitempty"`
+	TxHash                 string `json:"tx_hash,omitempty"`
+	CreatedAt              string `json:"created_at"` // ISO8601
+}
+
+// RegisterContractParams registers a contract already deployed on the network
+type RegisterContractParams struct {
+	NetworkID     int64  `json:"network_id" validate:"required"`
+	Name          string `json:"name" validate:"required"`
+	Address       string `json:"address" validate:"required"`
+	ABI           string `json:"abi" validate:"required"`
+	DeployTxHash  string `json:"deploy_tx_hash"`
+	DeployerKeyID *int64 `json:"deployer_key_id"`
+	IsProxy       bool   `json:"is_proxy"`
+}
+
+// DeployContractParams deploys a contract with a key of the key management service and registers it
+type DeployContractParams struct {
+	NetworkID       int64             `json:"network_id" validate:"required"`
+	Name            string            `json:"name" validate:"required"`
+	ABI             string            `json:"abi" validate:"required"`
+	Bytecode        string            `json:"bytecode" validate:"required"` // hex encoded creation bytecode
+	ConstructorArgs []json.RawMessage `json:"constructor_args" swaggertype:"array,object"`
+	KeyID           int64             `json:"key_id" validate:"required"`
+	IsProxy         bool              `json:"is_proxy"`
+	// SourceCode is the Solidity source, kept for reference (optional)
+	SourceCode string `json:"source_code,omitempty"`
+	// ProjectID links the contract to the scai project it was built from (optional)
+	ProjectID *int64 `json:"project_id,omitempty"`
+}
+
+// UpdateContractParams updates the registry entry of a contract
+type UpdateContractParams struct {
+	Name    string `json:"name" validate:"required"`
+	ABI     string `json:"abi" validate:"required"`
+	IsProxy bool   `json:"is_proxy"`
+}
+
+// CallContractParams calls a contract method. Arguments are JSON values matching the
+// ABI inputs: integers as numbers or strings, addresses and bytes as hex strings,
+// arrays as arrays and tuples as objects.
+type CallContractParams struct {
+	Method string            `json:"method" validate:"required"`
+	Args   []json.RawMessage `json:"args" swaggertype:"array,object"`
+	// From is the caller address of read calls (optional)
+	From string `json:"from,omitempty"`
+}
+
+// TransactContractParams sends a transaction calling a contract method
+type TransactContractParams struct {
+	Method string            `json:"method" validate:"required"`
+	Args   []json.RawMessage `json:"args" swaggertype:"array,object"`
+	KeyID  int64             `json:"key_id" validate:"required"`
+	// Value is the amount of wei sent with the transaction (optional)
+	Value    string `json:"value,omitempty"`
+	GasLimit uint64 `json:"gas_limit,omitempty"`
+}
+
+// RecordUpgradeParams records a new implementation of a proxy contract. The ABI
+// replaces the contract's ABI when the implementation changed its interface.
+type RecordUpgradeParams struct {
+	ImplementationAddress string `json:"implementation_address" validate:"required"`
+	ABI                   string `json:"abi,omitempty"`
+	TxHash                string `json:"tx_hash,omitempty"`
+}
+
+// CallResult is the decoded result of a read call
+type CallResult struct {
+	Method  string          `json:"method"`
+	Outputs []ContractValue `json:"outputs"`
+}
+
+// ContractEvent is a log emitted by the contract, decoded with its ABI
+type ContractEvent struct {
+	Name     string                 `json:"name"`
+	LogIndex uint                   `json:"log_index"`
+	Args     map[string]interface{} `json:"args"`
+}
+
+// TransactResult is the outcome of a mined transaction
+type TransactResult struct {
+	Method          string          `json:"method"`
+	TransactionHash string          `json:"transaction_hash"`
+	BlockNumber     uint64          `json:"block_number"`
+	GasUsed         uint64          `json:"gas_used"`
+	Success         bool            `json:"success"`
+	Events          []ContractEvent `json:"events"`
+}
+
+// ContractService keeps the registry of contracts deployed on Besu networks and
+// calls them through their ABI
+type ContractService struct {
+	queries              *db.Queries
+	logger               *logger.Logger
+	networkService       *networkService.NetworkService
+	keyManagementService *keymgmtservice.KeyManagementService
+	deployer             Deployer
+}
+
+// NewContractService creates a new contract registry service. Contracts are deployed
+// through the given deployer.
+func NewContractService(queries *db.Queries, logger *logger.Logger, networkService *networkService.NetworkService, keyManagementService *keymgmtservice.KeyManagementService, deployer Deployer) *ContractService {
+	return &ContractService{
+		queries:              queries,
+		logger:               logger,
+		networkService:       networkService,
+		keyManagementService: keyManagementService,
+		deployer:             deployer,
+	}
+}
+
+// ListContracts lists the registered contracts, of one network when networkID is not 0
+func (s *ContractService) ListContracts(ctx context.Context, networkID int64) ([]*Contract, error) {
+	var (
+		contracts []*db.BesuContract
+		err       error
+	)
+	if networkID != 0 {
+		contracts, err = s.queries.ListBesuContractsByNetwork(ctx, networkID)
+	} else {
+		contracts, err = s.queries.ListBesuContracts(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		result = append(result, dbContractToContract(contract))
+	}
+	return result, nil
+}
+
+// ListProjectContracts lists the contracts deployed from a scai project, newest first
+func (s *ContractService) ListProjectContracts(ctx context.Context, projectID int64) ([]*Contract, error) {
+	contracts, err := s.queries.ListBesuContractsByProject(ctx, sql.NullInt64{Int64: projectID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		result = append(result, dbContractToContract(contract))
+	}
+	return result, nil
+}
+
+// GetContract returns a registered contract
+func (s *ContractService) GetContract(ctx context.Context, id int64) (*Contract, error) {
+	contract, err := s.getContract(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dbContractToContract(contract), nil
+}
+
+// RegisterContract adds a contract already deployed on the network to the registry
+func (s *ContractService) RegisterContract(ctx context.Context, params RegisterContractParams) (*Contract, error) {
+	if _, err := parseABI(params.ABI); err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(params.Address) {
+		return nil, errors.NewValidationError("invalid contract address", nil)
+	}
+	client, err := s.dial(ctx, params.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	address := common.HexToAddress(params.Address)
+	bytecodeHash, err := codeHash(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+
+	create := &db.CreateBesuContractParams{
+		NetworkID:    params.NetworkID,
+		Name:         params.Name,
+		Address:      address.Hex(),
+		Abi:          params.ABI,
+		BytecodeHash: sql.NullString{String: bytecodeHash, Valid: true},
+		DeployTxHash: sql.NullString{String: params.DeployTxHash, Valid: params.DeployTxHash != ""},
+		IsProxy:      params.IsProxy,
+	}
+	if params.DeployerKeyID != nil {
+		create.DeployerKeyID = sql.NullInt64{Int64: *params.DeployerKeyID, Valid: true}
+	}
+	if params.DeployTxHash != "" {
+		receipt, err := client.TransactionReceipt(ctx, common.HexToHash(params.DeployTxHash))
+		if err != nil {
+			return nil, errors.NewValidationError("deployment transaction not found", map[string]interface{}{
+				"tx_hash": params.DeployTxHash,
+			})
+		}
+		create.DeployBlockNumber = sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true}
+	}
+	if params.IsProxy {
+		if implementation, err := proxyImplementation(ctx, client, address); err == nil {
+			create.ImplementationAddress = sql.NullString{String: implementation.Hex(), Valid: true}
+		}
+	}
+	return s.createContract(ctx, create)
+}
+
+// DeployContract deploys a contract signed with a secp256k1 key of the key management
+// service, waits until it is mined and registers it
+func (s *ContractService) DeployContract(ctx context.Context, params DeployContractParams) (*Contract, error) {
+	parsed, err := parseABI(params.ABI)
+	if err != nil {
+		return nil, err
+	}
+	bytecode, err := decodeHex(params.Bytecode)
+	if err != nil || len(bytecode) == 0 {
+		return nil, errors.NewValidationError("invalid bytecode", nil)
+	}
+	constructorArgs, err := convertArgs(parsed.Constructor.Inputs, params.ConstructorArgs)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid constructor arguments: %v", err), nil)
+	}
+
+	rpcURL, err := s.RPCURL(ctx, params.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to besu node: %w", err)
+	}
+	defer client.Close()
+	auth, err := s.transactor(ctx, client, params.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	s.logger.Info("Deploying contract", "name", params.Name, "network", params.NetworkID)
+	result, err := s.deployer.DeployEVMContract(EVMParams{
+		SolidityCode:    params.SourceCode,
+		ABI:             params.ABI,
+		Bytecode:        bytecode,
+		RPCURL:          rpcURL,
+		ChainID:         chainID.Int64(),
+		ConstructorArgs: constructorArgs,
+		Signer:          auth.Signer,
+		From:            auth.From,
+	}, NewInMemoryDeploymentStatusReporter())
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy contract: %w", err)
+	}
+	address := common.HexToAddress(result.ContractAddress)
+	receipt, err := client.TransactionReceipt(ctx, common.HexToHash(result.TransactionHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment receipt %s: %w", result.TransactionHash, err)
+	}
+	bytecodeHash, err := codeHash(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+
+	create := &db.CreateBesuContractParams{
+		NetworkID:         params.NetworkID,
+		Name:              params.Name,
+		Address:           address.Hex(),
+		Abi:               params.ABI,
+		BytecodeHash:      sql.NullString{String: bytecodeHash, Valid: true},
+		DeployerKeyID:     sql.NullInt64{Int64: params.KeyID, Valid: true},
+		DeployTxHash:      sql.NullString{String: result.TransactionHash, Valid: true},
+		DeployBlockNumber: sql.NullInt64{Int64: receipt.BlockNumber.Int64(), Valid: true},
+		IsProxy:           params.IsProxy,
+	}
+	if params.ProjectID != nil {
+		create.ProjectID = sql.NullInt64{Int64: *params.ProjectID, Valid: true}
+	}
+	if params.IsProxy {
+		if implementation, err := proxyImplementation(ctx, client, address); err == nil {
+			create.ImplementationAddress = sql.NullString{String: implementation.Hex(), Valid: true}
+		}
+	}
+	return s.createContract(ctx, create)
+}
+
+// UpdateContract updates the name, ABI and proxy flag of a registered contract
+func (s *ContractService) UpdateContract(ctx context.Context, id int64, params UpdateContractParams) (*Contract, error) {
+	if _, err := s.getContract(ctx, id); err != nil {
+		return nil, err
+	}
+	if _, err := parseABI(params.ABI); err != nil {
+		return nil, err
+	}
+	contract, err := s.queries.UpdateBesuContract(ctx, &db.UpdateBesuContractParams{
+		Name:    params.Name,
+		Abi:     params.ABI,
+		IsProxy: params.IsProxy,
+		ID:      id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbContractToContract(contract), nil
+}
+
+// DeleteContract removes a contract from the registry. The contract stays on chain.
+func (s *ContractService) DeleteContract(ctx context.Context, id int64) error {
+	if _, err := s.getContract(ctx, id); err != nil {
+		return err
+	}
+	return s.queries.DeleteBesuContract(ctx, id)
+}
+
+// CallContract runs a method with eth_call and decodes its outputs
+func (s *ContractService) CallContract(ctx context.Context, id int64, params CallContractParams) (*CallResult, error) {
+	contract, parsed, err := s.contractABI(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	method, data, err := packCall(parsed, params.Method, params.Args)
+	if err != nil {
+		return nil, err
+	}
+	msg := ethereum.CallMsg{To: addressPtr(common.HexToAddress(contract.Address)), Data: data}
+	if params.From != "" {
+		if !common.IsHexAddress(params.From) {
+			return nil, errors.NewValidationError("invalid from address", nil)
+		}
+		msg.From = common.HexToAddress(params.From)
+	}
+
+	client, err := s.dial(ctx, contract.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	output, err := client.CallContract(ctx, msg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("call to %s failed: %w", method.Sig, err)
+	}
+	values, err := method.Outputs.Unpack(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s outputs: %w", method.Sig, err)
+	}
+	return &CallResult{Method: method.Sig, Outputs: formatValues(method.Outputs, values)}, nil
+}
+
+// TransactContract sends a transaction calling a state changing method, waits until it
+// is mined and decodes the events the contract emitted
+func (s *ContractService) TransactContract(ctx context.Context, id int64, params TransactContractParams) (*TransactResult, error) {
+	contract, parsed, err := s.contractABI(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	method, data, err := packCall(parsed, params.Method, params.Args)
+	if err != nil {
+		return nil, err
+	}
+	if method.IsConstant() {
+		return nil, errors.NewValidationError(fmt.Sprintf("method %s does not change state, call it instead", method.Sig), nil)
+	}
+
+	client, err := s.dial(ctx, contract.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	auth, err := s.transactor(ctx, client, params.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if params.Value != "" {
+		value, ok := new(big.Int).SetString(params.Value, 10)
+		if !ok || value.Sign() < 0 {
+			return nil, errors.NewValidationError("invalid value", nil)
+		}
+		if !method.IsPayable() && value.Sign() > 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("method %s is not payable", method.Sig), nil)
+		}
+		auth.Value = value
+	}
+	auth.GasLimit = params.GasLimit
+
+	address := common.HexToAddress(contract.Address)
+	bound := bind.NewBoundContract(address, parsed, client, client, client)
+	tx, err := bound.RawTransact(auth, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s transaction: %w", method.Sig, err)
+	}
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	return &TransactResult{
+		Method:          method.Sig,
+		TransactionHash: tx.Hash().Hex(),
+		BlockNumber:     receipt.BlockNumber.Uint64(),
+		GasUsed:         receipt.GasUsed,
+		Success:         receipt.Status == types.ReceiptStatusSuccessful,
+		Events:          decodeEvents(parsed, address, receipt.Logs),
+	}, nil
+}
+
+// ListContractUpgrades lists the implementation changes of a proxy contract, newest first
+func (s *ContractService) ListContractUpgrades(ctx context.Context, id int64) ([]*ContractUpgrade, error) {
+	if _, err := s.getContract(ctx, id); err != nil {
+		return nil, err
+	}
+	upgrades, err := s.queries.ListBesuContractUpgrades(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ContractUpgrade, 0, len(upgrades))
+	for _, upgrade := range upgrades {
+		result = append(result, dbUpgradeToUpgrade(upgrade))
+	}
+	return result, nil
+}
+
+// RecordUpgrade records a new implementation of a proxy contract
+func (s *ContractService) RecordUpgrade(ctx context.Context, id int64, params RecordUpgradeParams) (*ContractUpgrade, error) {
+	contract, err := s.getContract(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !contract.IsProxy {
+		return nil, errors.NewValidationError("contract is not a proxy", nil)
+	}
+	if !common.IsHexAddress(params.ImplementationAddress) {
+		return nil, errors.NewValidationError("invalid implementation address", nil)
+	}
+	abiJSON := contract.Abi
+	if params.ABI != "" {
+		if _, err := parseABI(params.ABI); err != nil {
+			return nil, err
+		}
+		abiJSON = params.ABI
+	}
+
+	client, err := s.dial(ctx, contract.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return s.recordUpgrade(ctx, client, contract, common.HexToAddress(params.ImplementationAddress), abiJSON, params.TxHash)
+}
+
+// SyncImplementation reads the implementation of an EIP-1967 proxy from chain and
+// records an upgrade when it changed. It returns nil when the implementation is unchanged.
+func (s *ContractService) SyncImplementation(ctx context.Context, id int64) (*ContractUpgrade, error) {
+	contract, err := s.getContract(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !contract.IsProxy {
+		return nil, errors.NewValidationError("contract is not a proxy", nil)
+	}
+	client, err := s.dial(ctx, contract.NetworkID)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	implementation, err := proxyImplementation(ctx, client, common.HexToAddress(contract.Address))
+	if err != nil {
+		return nil, err
+	}
+	if contract.ImplementationAddress.Valid && common.HexToAddress(contract.ImplementationAddress.String) == implementation {
+		return nil, nil
+	}
+	return s.recordUpgrade(ctx, client, contract, implementation, contract.Abi, "")
+}
+
+func (s *ContractService) recordUpgrade(ctx context.Context, client *ethclient.Client, contract *db.BesuContract, implementation common.Address, abiJSON, txHash string) (*ContractUpgrade, error) {
+	bytecodeHash, err := codeHash(ctx, client, implementation)
+	if err != nil {
+		return nil, err
+	}
+	var upgrade *db.BesuContractUpgrade
+	err = s.queries.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		upgrade, err = storeUpgrade(ctx, q, contract, implementation, bytecodeHash, abiJSON, txHash)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record contract upgrade: %w", err)
+	}
+	s.logger.Info("Recorded contract upgrade", "contract", contract.Name, "implementation", implementation.Hex())
+	return dbUpgradeToUpgrade(upgrade), nil
+}
+
+// storeUpgrade adds an upgrade to the history of a proxy and points the contract to the
+// new implementation. It runs in the transaction of recordUpgrade so the history and
+// the contract can't disagree.
+func storeUpgrade(ctx context.Context, q *db.Queries, contract *db.BesuContract, implementation common.Address, bytecodeHash, abiJSON, txHash string) (*db.BesuContractUpgrade, error) {
+	upgrade, err := q.CreateBesuContractUpgrade(ctx, &db.CreateBesuContractUpgradeParams{
+		ContractID:             contract.ID,
+		PreviousImplementation: contract.ImplementationAddress,
+		ImplementationAddress:  implementation.Hex(),
+		BytecodeHash:           sql.NullString{String: bytecodeHash, Valid: true},
+		TxHash:                 sql.NullString{String: txHash, Valid: txHash != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := q.UpdateBesuContractImplementation(ctx, &db.UpdateBesuContractImplementationParams{
+		ImplementationAddress: sql.NullString{String: implementation.Hex(), Valid: true},
+		Abi:                   abiJSON,
+		ID:                    contract.ID,
+	}); err != nil {
+		return nil, err
+	}
+	return upgrade, nil
+}
+
+func (s *ContractService) createContract(ctx context.Context, params *db.CreateBesuContractParams) (*Contract, error) {
+	if _, err := s.queries.GetBesuContractByAddress(ctx, &db.GetBesuContractByAddressParams{
+		NetworkID: params.NetworkID,
+		Address:   params.Address,
+	}); err == nil {
+		return nil, errors.NewConflictError("contract already registered", map[string]interface{}{
+			"address": params.Address,
+		})
+	}
+	contract, err := s.queries.CreateBesuContract(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return dbContractToContract(contract), nil
+}
+
+func (s *ContractService) getContract(ctx context.Context, id int64) (*db.BesuContract, error) {
+	contract, err := s.queries.GetBesuContract(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("contract not found", map[string]interface{}{
+				"id": id,
+			})
+		}
+		return nil, err
+	}
+	return contract, nil
+}
+
+func (s *ContractService) contractABI(ctx context.Context, id int64) (*db.BesuContract, abi.ABI, error) {
+	contract, err := s.getContract(ctx, id)
+	if err != nil {
+		return nil, abi.ABI{}, err
+	}
+	parsed, err := parseABI(contract.Abi)
+	if err != nil {
+		return nil, abi.ABI{}, err
+	}
+	return contract, parsed, nil
+}
+
+// dial connects to the JSON-RPC endpoint of a running node of the Besu network
+func (s *ContractService) dial(ctx context.Context, networkID int64) (*ethclient.Client, error) {
+	rpcURL, err := s.RPCURL(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to besu node: %w", err)
+	}
+	return client, nil
+}
+
+// RPCURL returns the JSON-RPC endpoint of a running node of the Besu network
+func (s *ContractService) RPCURL(ctx context.Context, networkID int64) (string, error) {
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.NewNotFoundError("network not found", map[string]interface{}{
+				"id": networkID,
+			})
+		}
+		return "", err
+	}
+	if network.Platform != string(networkService.BlockchainTypeBesu) {
+		return "", errors.NewValidationError("network is not a Besu network", nil)
+	}
+	networkNodes, err := s.networkService.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return "", err
+	}
+	for _, networkNode := range networkNodes {
+		node := networkNode.Node
+		if node == nil || node.BesuNode == nil || node.Status != string(nodetypes.NodeStatusRunning) {
+			continue
+		}
+		host := node.BesuNode.RPCHost
+		if host == "" || host == "0.0.0.0" {
+			host = "127.0.0.1"
+		}
+		return fmt.Sprintf("http://%s:%d", host, node.BesuNode.RPCPort), nil
+	}
+	return "", errors.NewValidationError("no running Besu node found in network", nil)
+}
+
+// transactor returns transaction options signing with a secp256k1 key of the key management service
+func (s *ContractService) transactor(ctx context.Context, client *ethclient.Client, keyID int64) (*bind.TransactOpts, error) {
+	key, err := s.keyManagementService.GetKey(ctx, int(keyID))
+	if err != nil {
+		return nil, errors.NewNotFoundError("key not found", map[string]interface{}{
+			"id": keyID,
+		})
+	}
+	if key.EthereumAddress == "" {
+		return nil, errors.NewValidationError("key is not a secp256k1 key", nil)
+	}
+	privateKeyHex, err := s.keyManagementService.GetDecryptedPrivateKey(int(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key: %w", err)
+	}
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	if err != nil {
+		return nil, err
+	}
+	auth.Context = ctx
+	return auth, nil
+}
+
+func parseABI(abiJSON string) (abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return abi.ABI{}, errors.NewValidationError(fmt.Sprintf("invalid ABI: %v", err), nil)
+	}
+	return parsed, nil
+}
+
+// packCall encodes the selector and arguments of a method call
+func packCall(parsed abi.ABI, name string, args []json.RawMessage) (abi.Method, []byte, error) {
+	method, err := findMethod(parsed, name)
+	if err != nil {
+		return abi.Method{}, nil, errors.NewValidationError(err.Error(), nil)
+	}
+	packed, err := packArgs(method.Inputs, args)
+	if err != nil {
+		return abi.Method{}, nil, errors.NewValidationError(fmt.Sprintf("invalid arguments for %s: %v", method.Sig, err), nil)
+	}
+	return method, append(append([]byte{}, method.ID...), packed...), nil
+}
+
+// decodeEvents decodes the logs the contract emitted with its ABI. Logs of other
+// contracts and unknown events are skipped.
+func decodeEvents(parsed abi.ABI, address common.Address, logs []*types.Log) []ContractEvent {
+	events := []ContractEvent{}
+	for _, log := range logs {
+		if log.Address != address || len(log.Topics) == 0 {
+			continue
+		}
+		event, err := parsed.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		values := map[string]interface{}{}
+		if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			continue
+		}
+		var indexed abi.Arguments
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+			continue
+		}
+		args := make(map[string]interface{}, len(values))
+		for _, input := range event.Inputs {
+			args[input.Name] = formatValue(input.Type, reflect.ValueOf(values[input.Name]))
+		}
+		events = append(events, ContractEvent{Name: event.Name, LogIndex: log.Index, Args: args})
+	}
+	return events
+}
+
+// proxyImplementation reads the implementation address of an EIP-1967 proxy
+func proxyImplementation(ctx context.Context, client *ethclient.Client, proxy common.Address) (common.Address, error) {
+	slot, err := client.StorageAt(ctx, proxy, eip1967ImplementationSlot, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read implementation slot: %w", err)
+	}
+	implementation := common.BytesToAddress(slot)
+	if implementation == (common.Address{}) {
+		return common.Address{}, errors.NewValidationError("contract has no EIP-1967 implementation", nil)
+	}
+	return implementation, nil
+}
+
+// codeHash returns the keccak256 hash of the runtime bytecode at the address
+func codeHash(ctx context.Context, client *ethclient.Client, address common.Address) (string, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get contract code: %w", err)
+	}
+	if len(code) == 0 {
+		return "", errors.NewValidationError("no contract deployed at address", map[string]interface{}{
+			"address": address.Hex(),
+		})
+	}
+	return crypto.Keccak256Hash(code).Hex(), nil
+}
+
+func addressPtr(address common.Address) *common.Address {
+	return &address
+}
+
+func dbContractToContract(contract *db.BesuContract) *Contract {
+	result := &Contract{
+		ID:                    contract.ID,
+		NetworkID:             contract.NetworkID,
+		Name:                  contract.Name,
+		Address:               contract.Address,
+		ABI:                   json.RawMessage(contract.Abi),
+		BytecodeHash:          nullStringToString(contract.BytecodeHash),
+		DeployTxHash:          nullStringToString(contract.DeployTxHash),
+		IsProxy:               contract.IsProxy,
+		ImplementationAddress: nullStringToString(contract.ImplementationAddress),
+		CreatedAt:             contract.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             nullTimeToString(contract.UpdatedAt),
+	}
+	if contract.DeployerKeyID.Valid {
+		result.DeployerKeyID = &contract.DeployerKeyID.Int64
+	}
+	if contract.DeployBlockNumber.Valid {
+		result.DeployBlockNumber = &contract.DeployBlockNumber.Int64
+	}
+	if contract.ProjectID.Valid {
+		result.ProjectID = &contract.ProjectID.Int64
+	}
+	return result
+}
+
+func dbUpgradeToUpgrade(upgrade *db.BesuContractUpgrade) *ContractUpgrade {
+	return &ContractUpgrade{
+		ID:                     upgrade.ID,
+		ContractID:             upgrade.ContractID,
+		PreviousImplementation: nullStringToString(upgrade.PreviousImplementation),
+		ImplementationAddress:  upgrade.ImplementationAddress,
+		BytecodeHash:           nullStringToString(upgrade.BytecodeHash),
+		TxHash:                 nullStringToString(upgrade.TxHash),
+		CreatedAt:              upgrade.CreatedAt.Format(time.RFC3339),
+	}
+}
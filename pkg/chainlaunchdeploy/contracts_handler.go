@@ -0,0 +1,349 @@
+package chainlaunchdeploy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// ListContractsResponse represents the response for listing registered contracts
+type ListContractsResponse struct {
+	Contracts []*Contract `json:"contracts"`
+}
+
+// ListContractUpgradesResponse represents the response for listing proxy upgrades
+type ListContractUpgradesResponse struct {
+	Upgrades []*ContractUpgrade `json:"upgrades"`
+}
+
+// SyncImplementationResponse reports the upgrade recorded by a sync, if any
+type SyncImplementationResponse struct {
+	Upgraded bool             `json:"upgraded"`
+	Upgrade  *ContractUpgrade `json:"upgrade,omitempty"`
+}
+
+// decodeContractRequest decodes and validates a contract registry request body
+func (h *Handler) decodeContractRequest(r *http.Request, req interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"detail": err.Error(),
+			"code":   "INVALID_REQUEST_BODY",
+		})
+	}
+	if err := h.validate.Struct(req); err != nil {
+		validationErrors := make(map[string]string)
+		for _, err := range err.(validator.ValidationErrors) {
+			validationErrors[err.Field()] = err.Tag()
+		}
+		return errors.NewValidationError("validation failed", map[string]interface{}{
+			"detail": "Request validation failed",
+			"code":   "VALIDATION_ERROR",
+			"errors": validationErrors,
+		})
+	}
+	return nil
+}
+
+func parseContractID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "contractId"), 10, 64)
+	if err != nil {
+		return 0, errors.NewValidationError("invalid contract ID", map[string]interface{}{"detail": "Invalid contract ID"})
+	}
+	return id, nil
+}
+
+// contractError keeps the application errors of the contract service and reports
+// anything else as an internal error
+func contractError(err error, msg string) error {
+	if _, ok := err.(*errors.AppError); ok {
+		return err
+	}
+	return errors.NewInternalError(msg, err, nil)
+}
+
+// ListBesuContracts lists the registered contracts
+// @Summary List registered Besu contracts
+// @Description List the smart contracts of the registry, optionally of a single network
+// @Tags SmartContracts
+// @Produce json
+// @Param networkId query int false "Network ID"
+// @Success 200 {object} ListContractsResponse
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts [get]
+func (h *Handler) ListBesuContracts(w http.ResponseWriter, r *http.Request) error {
+	var networkID int64
+	if value := r.URL.Query().Get("networkId"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.NewValidationError("invalid network ID", map[string]interface{}{"detail": "Invalid network ID"})
+		}
+		networkID = id
+	}
+	contracts, err := h.contractService.ListContracts(r.Context(), networkID)
+	if err != nil {
+		h.logger.Error("Failed to list contracts", "error", err)
+		return errors.NewInternalError("failed to list contracts", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, ListContractsResponse{Contracts: contracts})
+}
+
+// RegisterBesuContract registers an already deployed contract
+// @Summary Register a Besu contract
+// @Description Add a contract already deployed on a Besu network to the registry with its ABI
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param request body RegisterContractParams true "Contract"
+// @Success 201 {object} Contract
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts [post]
+func (h *Handler) RegisterBesuContract(w http.ResponseWriter, r *http.Request) error {
+	var req RegisterContractParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	contract, err := h.contractService.RegisterContract(r.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to register contract", "error", err)
+		return contractError(err, "failed to register contract")
+	}
+	return response.WriteJSON(w, http.StatusCreated, contract)
+}
+
+// DeployRegisteredBesuContract deploys a contract and registers it
+// @Summary Deploy and register a Besu contract
+// @Description Deploy a contract signed with a secp256k1 key of the key management service, wait until it is mined and add it to the registry
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param request body DeployContractParams true "Contract deployment"
+// @Success 201 {object} Contract
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/deploy [post]
+func (h *Handler) DeployRegisteredBesuContract(w http.ResponseWriter, r *http.Request) error {
+	var req DeployContractParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	contract, err := h.contractService.DeployContract(r.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to deploy contract", "error", err)
+		return contractError(err, "failed to deploy contract")
+	}
+	return response.WriteJSON(w, http.StatusCreated, contract)
+}
+
+// GetBesuContract returns a registered contract
+// @Summary Get a registered Besu contract
+// @Description Get a contract of the registry with its ABI
+// @Tags SmartContracts
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Success 200 {object} Contract
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId} [get]
+func (h *Handler) GetBesuContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	contract, err := h.contractService.GetContract(r.Context(), id)
+	if err != nil {
+		return contractError(err, "failed to get contract")
+	}
+	return response.WriteJSON(w, http.StatusOK, contract)
+}
+
+// UpdateBesuContract updates a registered contract
+// @Summary Update a registered Besu contract
+// @Description Update the name, ABI and proxy flag of a contract of the registry
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Param request body UpdateContractParams true "Contract"
+// @Success 200 {object} Contract
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId} [put]
+func (h *Handler) UpdateBesuContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	var req UpdateContractParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	contract, err := h.contractService.UpdateContract(r.Context(), id, req)
+	if err != nil {
+		return contractError(err, "failed to update contract")
+	}
+	return response.WriteJSON(w, http.StatusOK, contract)
+}
+
+// DeleteBesuContract removes a contract from the registry
+// @Summary Delete a registered Besu contract
+// @Description Remove a contract from the registry; the contract stays on chain
+// @Tags SmartContracts
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId} [delete]
+func (h *Handler) DeleteBesuContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.contractService.DeleteContract(r.Context(), id); err != nil {
+		return contractError(err, "failed to delete contract")
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// CallBesuContract runs a read call
+// @Summary Call a Besu contract method
+// @Description Run a method with eth_call using the contract's ABI and decode its outputs. Nothing is written on chain.
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Param request body CallContractParams true "Method call"
+// @Success 200 {object} CallResult
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId}/call [post]
+func (h *Handler) CallBesuContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	var req CallContractParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	result, err := h.contractService.CallContract(r.Context(), id, req)
+	if err != nil {
+		return contractError(err, "failed to call contract")
+	}
+	return response.WriteJSON(w, http.StatusOK, result)
+}
+
+// TransactBesuContract sends a transaction
+// @Summary Send a Besu contract transaction
+// @Description Call a state changing method in a transaction signed with a key of the key management service, wait until it is mined and decode the emitted events
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Param request body TransactContractParams true "Method call"
+// @Success 200 {object} TransactResult
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId}/transact [post]
+func (h *Handler) TransactBesuContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	var req TransactContractParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	result, err := h.contractService.TransactContract(r.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Contract transaction failed", "error", err)
+		return contractError(err, "failed to send transaction")
+	}
+	return response.WriteJSON(w, http.StatusOK, result)
+}
+
+// ListBesuContractUpgrades lists the implementation changes of a proxy
+// @Summary List proxy contract upgrades
+// @Description List the implementation changes recorded for a proxy contract, newest first
+// @Tags SmartContracts
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Success 200 {object} ListContractUpgradesResponse
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId}/upgrades [get]
+func (h *Handler) ListBesuContractUpgrades(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	upgrades, err := h.contractService.ListContractUpgrades(r.Context(), id)
+	if err != nil {
+		return contractError(err, "failed to list contract upgrades")
+	}
+	return response.WriteJSON(w, http.StatusOK, ListContractUpgradesResponse{Upgrades: upgrades})
+}
+
+// RecordBesuContractUpgrade records a new proxy implementation
+// @Summary Record a proxy contract upgrade
+// @Description Record a new implementation of a proxy contract, optionally with the ABI of the new implementation
+// @Tags SmartContracts
+// @Accept json
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Param request body RecordUpgradeParams true "Upgrade"
+// @Success 201 {object} ContractUpgrade
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId}/upgrades [post]
+func (h *Handler) RecordBesuContractUpgrade(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	var req RecordUpgradeParams
+	if err := h.decodeContractRequest(r, &req); err != nil {
+		return err
+	}
+	upgrade, err := h.contractService.RecordUpgrade(r.Context(), id, req)
+	if err != nil {
+		return contractError(err, "failed to record contract upgrade")
+	}
+	return response.WriteJSON(w, http.StatusCreated, upgrade)
+}
+
+// SyncBesuContractImplementation reads the implementation of an EIP-1967 proxy
+// @Summary Sync a proxy contract implementation
+// @Description Read the implementation of an EIP-1967 proxy from chain and record an upgrade when it changed
+// @Tags SmartContracts
+// @Produce json
+// @Param contractId path int true "Contract ID"
+// @Success 200 {object} SyncImplementationResponse
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /sc/besu/contracts/{contractId}/upgrades/sync [post]
+func (h *Handler) SyncBesuContractImplementation(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseContractID(r)
+	if err != nil {
+		return err
+	}
+	upgrade, err := h.contractService.SyncImplementation(r.Context(), id)
+	if err != nil {
+		return contractError(err, "failed to sync contract implementation")
+	}
+	return response.WriteJSON(w, http.StatusOK, SyncImplementationResponse{Upgraded: upgrade != nil, Upgrade: upgrade})
+}
@@ -0,0 +1,160 @@
+package chainlaunchdeploy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+			{"name":"from","type":"address","indexed":true},
+			{"name":"to","type":"address","indexed":true},
+			{"name":"value","type":"uint256","indexed":false}
+		]},
+		{"type":"event","name":"Note","anonymous":false,"inputs":[
+			{"name":"id","type":"bytes32","indexed":true},
+			{"name":"text","type":"string","indexed":false}
+		]}
+	]`))
+	require.NoError(t, err)
+
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	other := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	from := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	to := common.HexToAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+	transfer := parsed.Events["Transfer"]
+	note := parsed.Events["Note"]
+
+	transferData, err := transfer.Inputs.NonIndexed().Pack(big.NewInt(1000))
+	require.NoError(t, err)
+	noteData, err := note.Inputs.NonIndexed().Pack("hello")
+	require.NoError(t, err)
+	noteID := common.HexToHash("0x" + strings.Repeat("01", 32))
+	transferTopics := []common.Hash{transfer.ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}
+
+	tests := []struct {
+		name string
+		logs []*types.Log
+		want []ContractEvent
+	}{
+		{
+			name: "indexed and data arguments",
+			logs: []*types.Log{{Address: contract, Topics: transferTopics, Data: transferData, Index: 3}},
+			want: []ContractEvent{{Name: "Transfer", LogIndex: 3, Args: map[string]interface{}{
+				"from":  from.Hex(),
+				"to":    to.Hex(),
+				"value": "1000",
+			}}},
+		},
+		{
+			name: "bytes32 topic and string data",
+			logs: []*types.Log{{Address: contract, Topics: []common.Hash{note.ID, noteID}, Data: noteData, Index: 1}},
+			want: []ContractEvent{{Name: "Note", LogIndex: 1, Args: map[string]interface{}{
+				"id":   noteID.Hex(),
+				"text": "hello",
+			}}},
+		},
+		{
+			name: "logs of other contracts are skipped",
+			logs: []*types.Log{{Address: other, Topics: transferTopics, Data: transferData}},
+			want: []ContractEvent{},
+		},
+		{
+			name: "unknown and anonymous events are skipped",
+			logs: []*types.Log{
+				{Address: contract, Topics: []common.Hash{common.HexToHash("0x1234")}, Data: transferData},
+				{Address: contract, Data: transferData},
+			},
+			want: []ContractEvent{},
+		},
+		{
+			name: "undecodable logs are skipped",
+			logs: []*types.Log{
+				{Address: contract, Topics: transferTopics, Data: []byte{0x01}},
+				{Address: contract, Topics: transferTopics[:2], Data: transferData},
+				{Address: contract, Topics: transferTopics, Data: transferData, Index: 9},
+			},
+			want: []ContractEvent{{Name: "Transfer", LogIndex: 9, Args: map[string]interface{}{
+				"from":  from.Hex(),
+				"to":    to.Hex(),
+				"value": "1000",
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, decodeEvents(parsed, contract, tt.logs))
+		})
+	}
+}
+
+func TestStoreUpgrade(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	queries := db.New(sqlDB)
+
+	network, err := queries.CreateNetwork(ctx, &db.CreateNetworkParams{Name: "besu", Platform: "BESU", Status: "running"})
+	require.NoError(t, err)
+	v1 := common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	v2 := common.HexToAddress("0x00000000000000000000000000000000000000c2")
+	contract, err := queries.CreateBesuContract(ctx, &db.CreateBesuContractParams{
+		NetworkID:             network.ID,
+		Name:                  "token",
+		Address:               "0x00000000000000000000000000000000000000a1",
+		Abi:                   "[]",
+		IsProxy:               true,
+		ImplementationAddress: sql.NullString{String: v1.Hex(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// A failed transaction leaves neither the upgrade nor the new implementation behind
+	errAbort := errors.New("abort")
+	err = queries.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := storeUpgrade(ctx, q, contract, v2, "0xhash", `[{"type":"fallback"}]`, "0xtx"); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+	upgrades, err := queries.ListBesuContractUpgrades(ctx, contract.ID)
+	require.NoError(t, err)
+	assert.Empty(t, upgrades)
+	stored, err := queries.GetBesuContract(ctx, contract.ID)
+	require.NoError(t, err)
+	assert.Equal(t, v1.Hex(), stored.ImplementationAddress.String)
+	assert.Equal(t, "[]", stored.Abi)
+
+	require.NoError(t, queries.ExecTx(ctx, func(q *db.Queries) error {
+		_, err := storeUpgrade(ctx, q, contract, v2, "0xhash", `[{"type":"fallback"}]`, "0xtx")
+		return err
+	}))
+	upgrades, err = queries.ListBesuContractUpgrades(ctx, contract.ID)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 1)
+	assert.Equal(t, v1.Hex(), upgrades[0].PreviousImplementation.String)
+	assert.Equal(t, v2.Hex(), upgrades[0].ImplementationAddress)
+	assert.Equal(t, "0xtx", upgrades[0].TxHash.String)
+	stored, err = queries.GetBesuContract(ctx, contract.ID)
+	require.NoError(t, err)
+	assert.Equal(t, v2.Hex(), stored.ImplementationAddress.String)
+	assert.Equal(t, `[{"type":"fallback"}]`, stored.Abi)
+}
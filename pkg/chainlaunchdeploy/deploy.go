@@ -3,18 +3,20 @@ package chainlaunchdeploy
 import (
 	"github.com/chainlaunch/chainlaunch/pkg/audit"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/hyperledger/fabric-admin-sdk/pkg/chaincode"
 )
 
 // EVMParams defines the parameters required for EVM (e.g., Besu) smart contract deployment.
 type EVMParams struct {
-	SolidityCode    string        // (Optional) Solidity source code (for reference)
-	ABI             string        // Contract ABI (JSON string)
-	Bytecode        []byte        // Compiled contract bytecode
-	RPCURL          string        // RPC endpoint for Besu node
-	ChainID         int64         // Chain ID for the target network
-	ConstructorArgs []interface{} // Constructor arguments for the contract
-	Signer          bind.SignerFn // Signer function to sign transactions (delegated to caller for security)
+	SolidityCode    string         // (Optional) Solidity source code (for reference)
+	ABI             string         // Contract ABI (JSON string)
+	Bytecode        []byte         // Compiled contract bytecode
+	RPCURL          string         // RPC endpoint for Besu node
+	ChainID         int64          // Chain ID for the target network
+	ConstructorArgs []interface{}  // Constructor arguments for the contract
+	Signer          bind.SignerFn  // Signer function to sign transactions (delegated to caller for security)
+	From            common.Address // Address of the account the Signer signs for
 	// Add more fields as needed (e.g., gas, nonce, etc.)
 }
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
 	"strings"
 	"time"
 
@@ -196,21 +195,12 @@ func (d *evmDeployer) DeployEVMContract(params EVMParams, reporter DeploymentSta
 		})
 		return DeploymentResult{Success: false, Error: fmt.Errorf("invalid ABI: %w", err)}, err
 	}
-	auth, err := bind.NewKeyedTransactorWithChainID(nil, big.NewInt(params.ChainID))
-	if err != nil {
-		d.logAuditEvent(ctx, "EVM_DEPLOYMENT_TRANSACTOR_FAILED", audit.EventOutcomeFailure, deploymentID, map[string]interface{}{
-			"error":   err.Error(),
-			"chainID": params.ChainID,
-		})
-		reporter.ReportStatus(DeploymentStatusUpdate{
-			DeploymentID: deploymentID,
-			Status:       StatusFailed,
-			Message:      "Failed to create transactor",
-			Error:        err,
-		})
-		return DeploymentResult{Success: false, Error: err}, err
+	// The key stays with the caller, the transaction is signed through params.Signer
+	auth := &bind.TransactOpts{
+		From:    params.From,
+		Signer:  params.Signer,
+		Context: ctx,
 	}
-	auth.Signer = params.Signer
 
 	reporter.ReportStatus(DeploymentStatusUpdate{
 		DeploymentID: deploymentID,
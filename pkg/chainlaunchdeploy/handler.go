@@ -31,10 +31,11 @@ type Handler struct {
 	nodeService      *nodeService.NodeService
 	networkService   *networkService.NetworkService
 	chaincodeService *ChaincodeService
+	contractService  *ContractService
 }
 
 // NewHandler creates a new smart contract deploy handler
-func NewHandler(auditService *audit.AuditService, logger *logger.Logger, besuDeployer DeployerWithAudit, nodeService *nodeService.NodeService, chaincodeService *ChaincodeService, networkService *networkService.NetworkService) *Handler {
+func NewHandler(auditService *audit.AuditService, logger *logger.Logger, besuDeployer DeployerWithAudit, nodeService *nodeService.NodeService, chaincodeService *ChaincodeService, networkService *networkService.NetworkService, contractService *ContractService) *Handler {
 	SetFabricAuditService(auditService)
 	if besuDeployer != nil {
 		besuDeployer.SetAuditService(auditService)
@@ -47,6 +48,7 @@ func NewHandler(auditService *audit.AuditService, logger *logger.Logger, besuDep
 		nodeService:      nodeService,
 		networkService:   networkService,
 		chaincodeService: chaincodeService,
+		contractService:  contractService,
 	}
 }
 
@@ -85,6 +87,17 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 
 	r.Route("/sc/besu", func(r chi.Router) {
 		r.Post("/deploy", response.Middleware(h.DeployBesuContract))
+		r.Get("/contracts", response.Middleware(h.ListBesuContracts))
+		r.Post("/contracts", response.Middleware(h.RegisterBesuContract))
+		r.Post("/contracts/deploy", response.Middleware(h.DeployRegisteredBesuContract))
+		r.Get("/contracts/{contractId}", response.Middleware(h.GetBesuContract))
+		r.Put("/contracts/{contractId}", response.Middleware(h.UpdateBesuContract))
+		r.Delete("/contracts/{contractId}", response.Middleware(h.DeleteBesuContract))
+		r.Post("/contracts/{contractId}/call", response.Middleware(h.CallBesuContract))
+		r.Post("/contracts/{contractId}/transact", response.Middleware(h.TransactBesuContract))
+		r.Get("/contracts/{contractId}/upgrades", response.Middleware(h.ListBesuContractUpgrades))
+		r.Post("/contracts/{contractId}/upgrades", response.Middleware(h.RecordBesuContractUpgrade))
+		r.Post("/contracts/{contractId}/upgrades/sync", response.Middleware(h.SyncBesuContractImplementation))
 	})
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric-admin-sdk/pkg/chaincode"
 	fabricclient "github.com/hyperledger/fabric-gateway/pkg/client"
 	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
 
@@ -744,6 +745,29 @@ func (s *ChaincodeService) CommitChaincodeByDefinition(ctx context.Context, defi
 	return nil
 }
 
+// QueryCommittedSequence returns the sequence of the definition of a chaincode committed on the
+// channel of its network, queried through the given peer. It returns 0 when no definition is committed.
+func (s *ChaincodeService) QueryCommittedSequence(ctx context.Context, chaincodeID int64, peerID int64) (int64, error) {
+	chaincodeDB, err := s.GetChaincode(ctx, chaincodeID)
+	if err != nil {
+		return 0, err
+	}
+	peerGateway, peerConn, err := s.nodeService.GetFabricPeerGateway(ctx, peerID)
+	if err != nil {
+		return 0, err
+	}
+	defer peerConn.Close()
+	committed, err := peerGateway.QueryCommittedWithName(ctx, chaincodeDB.NetworkName, chaincodeDB.Name)
+	if err != nil {
+		// The peer reports a chaincode without committed definition as an error
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not defined") {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to query committed chaincode: %w", err)
+	}
+	return committed.GetSequence(), nil
+}
+
 // buildChaincodeDefinition builds a chaincode.Definition from a ChaincodeDefinition
 func (s *ChaincodeService) buildChaincodeDefinition(ctx context.Context, definition *ChaincodeDefinition) (*chaincode.Definition, error) {
 	chaincodeDB, err := s.GetChaincode(ctx, definition.ChaincodeID)
@@ -997,38 +1021,40 @@ func (s *ChaincodeService) RemoveDeploymentByDefinition(ctx context.Context, def
 	return nil
 }
 
-// InvokeChaincode submits a transaction to a chaincode
-func (s *ChaincodeService) InvokeChaincode(ctx context.Context, chaincodeId int64, function string, args []string, channel string, transient map[string][]byte, keyID int64) (interface{}, error) {
+// GetContract connects to a peer of the key's organization and returns the
+// chaincode contract on channel (the chaincode's network when empty). The
+// caller closes the returned connection.
+func (s *ChaincodeService) GetContract(ctx context.Context, chaincodeId int64, channel string, keyID int64) (*fabricclient.Contract, *grpc.ClientConn, error) {
 	cc, err := s.GetChaincode(ctx, chaincodeId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if cc == nil {
-		return nil, fmt.Errorf("chaincode not found")
+		return nil, nil, fmt.Errorf("chaincode not found")
 	}
 	if keyID < math.MinInt || keyID > math.MaxInt {
-		return nil, fmt.Errorf("keyID value %d is out of valid range for int type", keyID)
+		return nil, nil, fmt.Errorf("keyID value %d is out of valid range for int type", keyID)
 	}
 	// Get MSP ID from key
 	key, err := s.keyManagementService.GetKey(ctx, int(keyID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get key: %w", err)
+		return nil, nil, fmt.Errorf("failed to get key: %w", err)
 	}
 	if key.Certificate == nil || *key.Certificate == "" {
-		return nil, fmt.Errorf("key does not have a certificate")
+		return nil, nil, fmt.Errorf("key does not have a certificate")
 	}
 	cert, err := keymgmtservice.ParseCertificate(*key.Certificate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
 	}
 	if len(cert.Subject.Organization) == 0 {
-		return nil, fmt.Errorf("certificate does not contain organization (MSP ID)")
+		return nil, nil, fmt.Errorf("certificate does not contain organization (MSP ID)")
 	}
 	mspID := cert.Subject.Organization[0]
 	// Get all nodes and select a peer with matching MSP ID
 	nodes, err := s.nodeService.GetAllNodes(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nodes: %w", err)
+		return nil, nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
 	var matchingPeerIDs []int64
 	for _, node := range nodes.Items {
@@ -1037,7 +1063,7 @@ func (s *ChaincodeService) InvokeChaincode(ctx context.Context, chaincodeId int6
 		}
 	}
 	if len(matchingPeerIDs) == 0 {
-		return nil, fmt.Errorf("no peer found for MSP ID: %s", mspID)
+		return nil, nil, fmt.Errorf("no peer found for MSP ID: %s", mspID)
 	}
 	// Pick a random peer if more than one
 	rand.Seed(time.Now().UnixNano())
@@ -1045,15 +1071,22 @@ func (s *ChaincodeService) InvokeChaincode(ctx context.Context, chaincodeId int6
 	// Use selected peer
 	gateway, conn, err := s.nodeService.GetFabricPeerClientGateway(ctx, peerID, keyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get peer gateway: %w", err)
+		return nil, nil, fmt.Errorf("failed to get peer gateway: %w", err)
 	}
-	defer conn.Close()
 	networkName := channel
 	if networkName == "" {
 		networkName = cc.NetworkName
 	}
-	network := gateway.GetNetwork(networkName)
-	contract := network.GetContract(cc.Name)
+	return gateway.GetNetwork(networkName).GetContract(cc.Name), conn, nil
+}
+
+// InvokeChaincode submits a transaction to a chaincode
+func (s *ChaincodeService) InvokeChaincode(ctx context.Context, chaincodeId int64, function string, args []string, channel string, transient map[string][]byte, keyID int64) (interface{}, error) {
+	contract, conn, err := s.GetContract(ctx, chaincodeId, channel, keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
 	var result []byte
 	var commit *fabricclient.Commit
 	if transient == nil || len(transient) <= 0 {
@@ -1078,61 +1111,11 @@ func (s *ChaincodeService) InvokeChaincode(ctx context.Context, chaincodeId int6
 
 // QueryChaincode evaluates a transaction on a chaincode
 func (s *ChaincodeService) QueryChaincode(ctx context.Context, chaincodeId int64, function string, args []string, channel string, transient map[string][]byte, keyID int64) (interface{}, error) {
-	cc, err := s.GetChaincode(ctx, chaincodeId)
+	contract, conn, err := s.GetContract(ctx, chaincodeId, channel, keyID)
 	if err != nil {
 		return nil, err
 	}
-	if cc == nil {
-		return nil, fmt.Errorf("chaincode not found")
-	}
-	if keyID < math.MinInt || keyID > math.MaxInt {
-		return nil, fmt.Errorf("keyID value %d is out of valid range for int type", keyID)
-	}
-	// Get MSP ID from key
-	key, err := s.keyManagementService.GetKey(ctx, int(keyID))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get key: %w", err)
-	}
-	if key.Certificate == nil || *key.Certificate == "" {
-		return nil, fmt.Errorf("key does not have a certificate")
-	}
-	cert, err := keymgmtservice.ParseCertificate(*key.Certificate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse certificate: %w", err)
-	}
-	if len(cert.Subject.Organization) == 0 {
-		return nil, fmt.Errorf("certificate does not contain organization (MSP ID)")
-	}
-	mspID := cert.Subject.Organization[0]
-	// Get all nodes and select a peer with matching MSP ID
-	nodes, err := s.nodeService.GetAllNodes(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nodes: %w", err)
-	}
-	var matchingPeerIDs []int64
-	for _, node := range nodes.Items {
-		if node.NodeType == "FABRIC_PEER" && node.FabricPeer != nil && node.FabricPeer.MSPID == mspID && node.Platform == "FABRIC" {
-			matchingPeerIDs = append(matchingPeerIDs, node.ID)
-		}
-	}
-	if len(matchingPeerIDs) == 0 {
-		return nil, fmt.Errorf("no peer found for MSP ID: %s", mspID)
-	}
-	// Pick a random peer if more than one
-	rand.Seed(time.Now().UnixNano())
-	peerID := matchingPeerIDs[rand.Intn(len(matchingPeerIDs))]
-	// Use selected peer
-	gatewayFabric, conn, err := s.nodeService.GetFabricPeerClientGateway(ctx, peerID, keyID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get peer gateway: %w", err)
-	}
 	defer conn.Close()
-	networkName := channel
-	if networkName == "" {
-		networkName = cc.NetworkName
-	}
-	network := gatewayFabric.GetNetwork(networkName)
-	contract := network.GetContract(cc.Name)
 	result, err := contract.EvaluateTransaction(function, args...)
 	if err != nil {
 		endorseError, ok := err.(*fabricclient.EndorseError)
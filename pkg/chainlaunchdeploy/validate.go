@@ -2,12 +2,15 @@ package chainlaunchdeploy
 
 import (
 	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // ValidateEVMParams checks that all required EVM deployment parameters are present and valid.
 func ValidateEVMParams(params EVMParams) error {
-	if params.SolidityCode == "" {
-		return errors.New("SolidityCode is required")
+	// SolidityCode is kept for reference only, the bytecode is what gets deployed
+	if params.From == (common.Address{}) {
+		return errors.New("From is required")
 	}
 	// Optionally, validate constructor params, network, etc.
 	return nil
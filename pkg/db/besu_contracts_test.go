@@ -0,0 +1,157 @@
+package db_test
+
+// DB-layer tests for the Besu contract registry queries introduced in
+// migration 0033. Contracts are unique per network and address, and proxy
+// contracts keep the history of their implementations.
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func TestBesuContracts(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+
+	network, err := q.CreateNetwork(ctx, &db.CreateNetworkParams{Name: "besu-net", Platform: "BESU", Status: "running"})
+	if err != nil {
+		t.Fatalf("create network: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  db.CreateBesuContractParams
+		wantErr bool
+	}{
+		{
+			name: "token",
+			params: db.CreateBesuContractParams{
+				NetworkID:         network.ID,
+				Name:              "Token",
+				Address:           "0x00000000000000000000000000000000000000a1",
+				Abi:               `[]`,
+				BytecodeHash:      sql.NullString{String: "0x01", Valid: true},
+				DeployTxHash:      sql.NullString{String: "0xaa", Valid: true},
+				DeployBlockNumber: sql.NullInt64{Int64: 12, Valid: true},
+			},
+		},
+		{
+			name: "proxy",
+			params: db.CreateBesuContractParams{
+				NetworkID:             network.ID,
+				Name:                  "Proxy",
+				Address:               "0x00000000000000000000000000000000000000b2",
+				Abi:                   `[]`,
+				IsProxy:               true,
+				ImplementationAddress: sql.NullString{String: "0x00000000000000000000000000000000000000c3", Valid: true},
+			},
+		},
+		{
+			name: "duplicate address",
+			params: db.CreateBesuContractParams{
+				NetworkID: network.ID,
+				Name:      "Token again",
+				Address:   "0x00000000000000000000000000000000000000a1",
+				Abi:       `[]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown network",
+			params: db.CreateBesuContractParams{
+				NetworkID: network.ID + 100,
+				Name:      "Orphan",
+				Address:   "0x00000000000000000000000000000000000000d4",
+				Abi:       `[]`,
+			},
+			wantErr: true,
+		},
+	}
+	created := map[string]*db.BesuContract{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			contract, err := q.CreateBesuContract(ctx, &params)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CreateBesuContract: expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateBesuContract: %v", err)
+			}
+			if contract.Name != params.Name || contract.IsProxy != params.IsProxy || contract.BytecodeHash != params.BytecodeHash {
+				t.Fatalf("CreateBesuContract: got %+v, want %+v", contract, params)
+			}
+			created[tt.name] = contract
+		})
+	}
+
+	token, proxy := created["token"], created["proxy"]
+	if token == nil || proxy == nil {
+		t.Fatalf("contracts were not created")
+	}
+
+	got, err := q.GetBesuContractByAddress(ctx, &db.GetBesuContractByAddressParams{NetworkID: network.ID, Address: token.Address})
+	if err != nil || got.ID != token.ID {
+		t.Fatalf("GetBesuContractByAddress: got %+v, err=%v, want contract %d", got, err, token.ID)
+	}
+	if _, err := q.GetBesuContractByAddress(ctx, &db.GetBesuContractByAddressParams{NetworkID: network.ID + 1, Address: token.Address}); err != sql.ErrNoRows {
+		t.Fatalf("GetBesuContractByAddress on another network: err=%v, want sql.ErrNoRows", err)
+	}
+
+	// Newest first
+	contracts, err := q.ListBesuContractsByNetwork(ctx, network.ID)
+	if err != nil || len(contracts) != 2 || contracts[0].ID != proxy.ID || contracts[1].ID != token.ID {
+		t.Fatalf("ListBesuContractsByNetwork: got %d contracts, err=%v", len(contracts), err)
+	}
+
+	updated, err := q.UpdateBesuContract(ctx, &db.UpdateBesuContractParams{ID: token.ID, Name: "TokenV1", Abi: `[{"type":"function"}]`})
+	if err != nil || updated.Name != "TokenV1" || !updated.UpdatedAt.Valid || updated.Address != token.Address {
+		t.Fatalf("UpdateBesuContract: got %+v, err=%v", updated, err)
+	}
+
+	// Upgrading the proxy records its previous implementation
+	for _, implementation := range []string{"0x00000000000000000000000000000000000000e5", "0x00000000000000000000000000000000000000f6"} {
+		current, err := q.GetBesuContract(ctx, proxy.ID)
+		if err != nil {
+			t.Fatalf("GetBesuContract: %v", err)
+		}
+		if _, err := q.CreateBesuContractUpgrade(ctx, &db.CreateBesuContractUpgradeParams{
+			ContractID:             proxy.ID,
+			PreviousImplementation: current.ImplementationAddress,
+			ImplementationAddress:  implementation,
+			TxHash:                 sql.NullString{String: "0xbb", Valid: true},
+		}); err != nil {
+			t.Fatalf("CreateBesuContractUpgrade: %v", err)
+		}
+		if err := q.UpdateBesuContractImplementation(ctx, &db.UpdateBesuContractImplementationParams{
+			ID:                    proxy.ID,
+			ImplementationAddress: sql.NullString{String: implementation, Valid: true},
+			Abi:                   `[]`,
+		}); err != nil {
+			t.Fatalf("UpdateBesuContractImplementation: %v", err)
+		}
+	}
+	upgrades, err := q.ListBesuContractUpgrades(ctx, proxy.ID)
+	if err != nil || len(upgrades) != 2 {
+		t.Fatalf("ListBesuContractUpgrades: got %d upgrades, err=%v", len(upgrades), err)
+	}
+	if upgrades[0].ImplementationAddress != "0x00000000000000000000000000000000000000f6" ||
+		upgrades[0].PreviousImplementation.String != "0x00000000000000000000000000000000000000e5" ||
+		upgrades[1].PreviousImplementation.String != "0x00000000000000000000000000000000000000c3" {
+		t.Fatalf("ListBesuContractUpgrades: got %+v, %+v", upgrades[0], upgrades[1])
+	}
+
+	// Deleting a contract removes its upgrade history
+	if err := q.DeleteBesuContract(ctx, proxy.ID); err != nil {
+		t.Fatalf("DeleteBesuContract: %v", err)
+	}
+	if upgrades, err := q.ListBesuContractUpgrades(ctx, proxy.ID); err != nil || len(upgrades) != 0 {
+		t.Fatalf("ListBesuContractUpgrades after delete: got %d upgrades, err=%v", len(upgrades), err)
+	}
+}
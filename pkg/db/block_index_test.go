@@ -0,0 +1,230 @@
+package db_test
+
+// DB-layer tests for the block index queries introduced in migration 0027.
+// The search queries use "? IS NULL OR" / "? = '' OR" guards that sqlc can't
+// type, so they are exercised against a real sqlite database here.
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func seedIndexedNetwork(t *testing.T, q *db.Queries) int64 {
+	t.Helper()
+	network, err := q.CreateNetwork(context.Background(), &db.CreateNetworkParams{
+		Name:     "net-" + t.Name(),
+		Platform: "FABRIC",
+		Status:   "running",
+	})
+	if err != nil {
+		t.Fatalf("create network: %v", err)
+	}
+	return network.ID
+}
+
+func indexTx(t *testing.T, q *db.Queries, networkID, block int64, txID, chaincode, msp, code string, ts time.Time, keys ...string) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := q.CreateIndexedTransaction(ctx, &db.CreateIndexedTransactionParams{
+		NetworkID:      networkID,
+		BlockNumber:    block,
+		TxID:           txID,
+		TxType:         "ENDORSER_TRANSACTION",
+		TxTimestamp:    sql.NullTime{Time: ts.UTC(), Valid: true},
+		Chaincode:      chaincode,
+		Function:       "CreateAsset",
+		CreatorMspID:   msp,
+		ValidationCode: code,
+	})
+	if err != nil {
+		t.Fatalf("CreateIndexedTransaction %s: %v", txID, err)
+	}
+	for _, key := range keys {
+		if err := q.CreateIndexedTransactionKey(ctx, &db.CreateIndexedTransactionKeyParams{
+			TransactionID: tx.ID,
+			NetworkID:     networkID,
+			Namespace:     chaincode,
+			Key:           key,
+			Access:        "write",
+		}); err != nil {
+			t.Fatalf("CreateIndexedTransactionKey %s: %v", key, err)
+		}
+	}
+}
+
+// searchTxIDs runs the search with the guard columns set the way the indexer service sets them
+func searchTxIDs(t *testing.T, q *db.Queries, networkID int64, from, to *time.Time, chaincode, msp, code, key string) ([]string, int64) {
+	t.Helper()
+	ctx := context.Background()
+	nullTime := func(v *time.Time) (interface{}, sql.NullTime) {
+		if v == nil {
+			return nil, sql.NullTime{}
+		}
+		return v.UTC(), sql.NullTime{Time: v.UTC(), Valid: true}
+	}
+	fromGuard, fromTime := nullTime(from)
+	toGuard, toTime := nullTime(to)
+
+	rows, err := q.SearchIndexedTransactions(ctx, &db.SearchIndexedTransactionsParams{
+		NetworkID:      networkID,
+		Column2:        fromGuard,
+		TxTimestamp:    fromTime,
+		Column4:        toGuard,
+		TxTimestamp_2:  toTime,
+		Column6:        chaincode,
+		Chaincode:      chaincode,
+		Column8:        "",
+		Column10:       msp,
+		CreatorMspID:   msp,
+		Column12:       code,
+		ValidationCode: code,
+		Column14:       key,
+		Key:            key,
+		Limit:          100,
+	})
+	if err != nil {
+		t.Fatalf("SearchIndexedTransactions: %v", err)
+	}
+	count, err := q.CountSearchIndexedTransactions(ctx, &db.CountSearchIndexedTransactionsParams{
+		NetworkID:      networkID,
+		Column2:        fromGuard,
+		TxTimestamp:    fromTime,
+		Column4:        toGuard,
+		TxTimestamp_2:  toTime,
+		Column6:        chaincode,
+		Chaincode:      chaincode,
+		Column8:        "",
+		Column10:       msp,
+		CreatorMspID:   msp,
+		Column12:       code,
+		ValidationCode: code,
+		Column14:       key,
+		Key:            key,
+	})
+	if err != nil {
+		t.Fatalf("CountSearchIndexedTransactions: %v", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.TxID)
+	}
+	return ids, count
+}
+
+func TestBlockIndex_SearchFilters(t *testing.T) {
+	q, _ := newTestQueries(t)
+	networkID := seedIndexedNetwork(t, q)
+
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	indexTx(t, q, networkID, 1, "tx1", "basic", "Org1MSP", "VALID", base, "asset1")
+	indexTx(t, q, networkID, 2, "tx2", "basic", "Org2MSP", "MVCC_READ_CONFLICT", base.Add(time.Hour), "asset1", "asset2")
+	indexTx(t, q, networkID, 3, "tx3", "tokens", "Org1MSP", "VALID", base.Add(2*time.Hour), "token1")
+
+	cases := []struct {
+		name      string
+		from, to  *time.Time
+		chaincode string
+		msp       string
+		code      string
+		key       string
+		want      []string
+	}{
+		{name: "no filter", want: []string{"tx3", "tx2", "tx1"}},
+		{name: "chaincode", chaincode: "basic", want: []string{"tx2", "tx1"}},
+		{name: "creator", msp: "Org1MSP", want: []string{"tx3", "tx1"}},
+		{name: "validation code", code: "MVCC_READ_CONFLICT", want: []string{"tx2"}},
+		{name: "key", key: "asset1", want: []string{"tx2", "tx1"}},
+		{name: "time range", from: ptrTime(base.Add(30 * time.Minute)), to: ptrTime(base.Add(90 * time.Minute)), want: []string{"tx2"}},
+		{name: "combined", chaincode: "basic", msp: "Org1MSP", key: "asset1", want: []string{"tx1"}},
+		{name: "no match", key: "missing", want: []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, count := searchTxIDs(t, q, networkID, tc.from, tc.to, tc.chaincode, tc.msp, tc.code, tc.key)
+			if len(got) != len(tc.want) || int(count) != len(tc.want) {
+				t.Fatalf("got %v (count %d), want %v", got, count, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockIndex_DeleteByBlockCascadesKeys(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+	networkID := seedIndexedNetwork(t, q)
+
+	indexTx(t, q, networkID, 5, "tx5", "basic", "Org1MSP", "VALID", time.Now(), "asset5")
+	tx, err := q.GetIndexedTransactionByTxID(ctx, &db.GetIndexedTransactionByTxIDParams{NetworkID: networkID, TxID: "tx5"})
+	if err != nil {
+		t.Fatalf("GetIndexedTransactionByTxID: %v", err)
+	}
+
+	if err := q.DeleteIndexedTransactionsByBlock(ctx, &db.DeleteIndexedTransactionsByBlockParams{
+		NetworkID:   networkID,
+		BlockNumber: 5,
+	}); err != nil {
+		t.Fatalf("DeleteIndexedTransactionsByBlock: %v", err)
+	}
+	keys, err := q.ListIndexedTransactionKeys(ctx, tx.ID)
+	if err != nil {
+		t.Fatalf("ListIndexedTransactionKeys: %v", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("expected keys to be deleted with their transaction, got %d", len(keys))
+	}
+}
+
+func TestBlockIndex_IndexerProgress(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+	networkID := seedIndexedNetwork(t, q)
+
+	if _, err := q.CreateBlockIndexer(ctx, &db.CreateBlockIndexerParams{NetworkID: networkID, NextBlock: 10}); err != nil {
+		t.Fatalf("CreateBlockIndexer: %v", err)
+	}
+	if err := q.UpdateBlockIndexerError(ctx, &db.UpdateBlockIndexerErrorParams{
+		LastError: sql.NullString{String: "peer unavailable", Valid: true},
+		NetworkID: networkID,
+	}); err != nil {
+		t.Fatalf("UpdateBlockIndexerError: %v", err)
+	}
+	if err := q.UpdateBlockIndexerProgress(ctx, &db.UpdateBlockIndexerProgressParams{NextBlock: 11, NetworkID: networkID}); err != nil {
+		t.Fatalf("UpdateBlockIndexerProgress: %v", err)
+	}
+
+	idx, err := q.GetBlockIndexer(ctx, networkID)
+	if err != nil {
+		t.Fatalf("GetBlockIndexer: %v", err)
+	}
+	if idx.NextBlock != 11 {
+		t.Errorf("nextBlock: got %d, want 11", idx.NextBlock)
+	}
+	if idx.LastError.Valid {
+		t.Errorf("expected progress to clear the last error, got %q", idx.LastError.String)
+	}
+	if !idx.LastIndexedAt.Valid {
+		t.Error("expected lastIndexedAt to be set")
+	}
+
+	if _, err := q.SetBlockIndexerEnabled(ctx, &db.SetBlockIndexerEnabledParams{Enabled: false, NetworkID: networkID}); err != nil {
+		t.Fatalf("SetBlockIndexerEnabled: %v", err)
+	}
+	enabled, err := q.ListEnabledBlockIndexers(ctx)
+	if err != nil {
+		t.Fatalf("ListEnabledBlockIndexers: %v", err)
+	}
+	if len(enabled) != 0 {
+		t.Errorf("expected no enabled indexers, got %d", len(enabled))
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
@@ -73,3 +73,83 @@ UPDATE messages SET enhanced_content = ? WHERE id = ? RETURNING *;
 
 -- name: GetConversation :one
 SELECT id, project_id, started_at FROM conversations WHERE id = ? LIMIT 1;
+
+-- name: CreateProjectTestRun :one
+INSERT INTO project_test_runs (
+    project_id,
+    commit_hash,
+    commit_message,
+    dirty,
+    status,
+    total,
+    passed,
+    failed,
+    skipped,
+    coverage,
+    duration_ms,
+    exit_code,
+    output,
+    error
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: CreateProjectTestResult :exec
+INSERT INTO project_test_results (run_id, suite, name, status, duration_ms, message)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetProjectTestRun :one
+SELECT * FROM project_test_runs WHERE id = ? AND project_id = ?;
+
+-- name: GetLatestProjectTestRun :one
+SELECT * FROM project_test_runs WHERE project_id = ? ORDER BY id DESC LIMIT 1;
+
+-- name: GetLatestProjectTestRunForCommit :one
+SELECT * FROM project_test_runs WHERE project_id = ? AND commit_hash = ? ORDER BY id DESC LIMIT 1;
+
+-- name: GetPreviousProjectTestRun :one
+SELECT * FROM project_test_runs
+WHERE project_id = ? AND commit_hash != ? AND id < ?
+ORDER BY id DESC LIMIT 1;
+
+-- name: ListProjectTestRuns :many
+SELECT * FROM project_test_runs WHERE project_id = ? ORDER BY id DESC LIMIT ?;
+
+-- name: ListProjectTestResults :many
+SELECT * FROM project_test_results WHERE run_id = ? ORDER BY suite, name;
+
+-- name: CreateProjectPromotion :one
+INSERT INTO project_promotions (
+    project_id,
+    commit_hash,
+    network_id,
+    chaincode_name,
+    version,
+    docker_image,
+    status
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateProjectPromotionStatus :exec
+UPDATE project_promotions
+SET status = ?, error = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: SetProjectPromotionDefinition :exec
+UPDATE project_promotions
+SET chaincode_id = ?, definition_id = ?, sequence = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetProjectPromotion :one
+SELECT * FROM project_promotions WHERE id = ? AND project_id = ?;
+
+-- name: ListProjectPromotions :many
+SELECT * FROM project_promotions WHERE project_id = ? ORDER BY id DESC;
+
+-- name: FailInterruptedProjectPromotions :exec
+UPDATE project_promotions
+SET status = 'failed', error = 'interrupted by a restart', updated_at = CURRENT_TIMESTAMP
+WHERE status NOT IN ('completed', 'failed');
@@ -65,6 +65,165 @@ func (q *Queries) CreateProject(ctx context.Context, arg *CreateProjectParams) (
 	return &i, err
 }
 
+const CreateProjectPromotion = `-- name: CreateProjectPromotion :one
+INSERT INTO project_promotions (
+    project_id,
+    commit_hash,
+    network_id,
+    chaincode_name,
+    version,
+    docker_image,
+    status
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, project_id, commit_hash, network_id, chaincode_name, version, docker_image, chaincode_id, definition_id, sequence, status, error, created_at, updated_at
+`
+
+type CreateProjectPromotionParams struct {
+	ProjectID     int64  `json:"projectId"`
+	CommitHash    string `json:"commitHash"`
+	NetworkID     int64  `json:"networkId"`
+	ChaincodeName string `json:"chaincodeName"`
+	Version       string `json:"version"`
+	DockerImage   string `json:"dockerImage"`
+	Status        string `json:"status"`
+}
+
+func (q *Queries) CreateProjectPromotion(ctx context.Context, arg *CreateProjectPromotionParams) (*ProjectPromotion, error) {
+	row := q.db.QueryRowContext(ctx, CreateProjectPromotion,
+		arg.ProjectID,
+		arg.CommitHash,
+		arg.NetworkID,
+		arg.ChaincodeName,
+		arg.Version,
+		arg.DockerImage,
+		arg.Status,
+	)
+	var i ProjectPromotion
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.NetworkID,
+		&i.ChaincodeName,
+		&i.Version,
+		&i.DockerImage,
+		&i.ChaincodeID,
+		&i.DefinitionID,
+		&i.Sequence,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const CreateProjectTestResult = `-- name: CreateProjectTestResult :exec
+INSERT INTO project_test_results (run_id, suite, name, status, duration_ms, message)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateProjectTestResultParams struct {
+	RunID      int64          `json:"runId"`
+	Suite      string         `json:"suite"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	DurationMs int64          `json:"durationMs"`
+	Message    sql.NullString `json:"message"`
+}
+
+func (q *Queries) CreateProjectTestResult(ctx context.Context, arg *CreateProjectTestResultParams) error {
+	_, err := q.db.ExecContext(ctx, CreateProjectTestResult,
+		arg.RunID,
+		arg.Suite,
+		arg.Name,
+		arg.Status,
+		arg.DurationMs,
+		arg.Message,
+	)
+	return err
+}
+
+const CreateProjectTestRun = `-- name: CreateProjectTestRun :one
+INSERT INTO project_test_runs (
+    project_id,
+    commit_hash,
+    commit_message,
+    dirty,
+    status,
+    total,
+    passed,
+    failed,
+    skipped,
+    coverage,
+    duration_ms,
+    exit_code,
+    output,
+    error
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at
+`
+
+type CreateProjectTestRunParams struct {
+	ProjectID     int64           `json:"projectId"`
+	CommitHash    string          `json:"commitHash"`
+	CommitMessage sql.NullString  `json:"commitMessage"`
+	Dirty         bool            `json:"dirty"`
+	Status        string          `json:"status"`
+	Total         int64           `json:"total"`
+	Passed        int64           `json:"passed"`
+	Failed        int64           `json:"failed"`
+	Skipped       int64           `json:"skipped"`
+	Coverage      sql.NullFloat64 `json:"coverage"`
+	DurationMs    int64           `json:"durationMs"`
+	ExitCode      int64           `json:"exitCode"`
+	Output        sql.NullString  `json:"output"`
+	Error         sql.NullString  `json:"error"`
+}
+
+func (q *Queries) CreateProjectTestRun(ctx context.Context, arg *CreateProjectTestRunParams) (*ProjectTestRun, error) {
+	row := q.db.QueryRowContext(ctx, CreateProjectTestRun,
+		arg.ProjectID,
+		arg.CommitHash,
+		arg.CommitMessage,
+		arg.Dirty,
+		arg.Status,
+		arg.Total,
+		arg.Passed,
+		arg.Failed,
+		arg.Skipped,
+		arg.Coverage,
+		arg.DurationMs,
+		arg.ExitCode,
+		arg.Output,
+		arg.Error,
+	)
+	var i ProjectTestRun
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.CommitMessage,
+		&i.Dirty,
+		&i.Status,
+		&i.Total,
+		&i.Passed,
+		&i.Failed,
+		&i.Skipped,
+		&i.Coverage,
+		&i.DurationMs,
+		&i.ExitCode,
+		&i.Output,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const DeleteProject = `-- name: DeleteProject :exec
 DELETE FROM chaincode_projects WHERE id = ?
 `
@@ -74,6 +233,17 @@ func (q *Queries) DeleteProject(ctx context.Context, id int64) error {
 	return err
 }
 
+const FailInterruptedProjectPromotions = `-- name: FailInterruptedProjectPromotions :exec
+UPDATE project_promotions
+SET status = 'failed', error = 'interrupted by a restart', updated_at = CURRENT_TIMESTAMP
+WHERE status NOT IN ('completed', 'failed')
+`
+
+func (q *Queries) FailInterruptedProjectPromotions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, FailInterruptedProjectPromotions)
+	return err
+}
+
 const GetConversation = `-- name: GetConversation :one
 SELECT id, project_id, started_at FROM conversations WHERE id = ? LIMIT 1
 `
@@ -96,6 +266,110 @@ func (q *Queries) GetDefaultConversationForProject(ctx context.Context, projectI
 	return &i, err
 }
 
+const GetLatestProjectTestRun = `-- name: GetLatestProjectTestRun :one
+SELECT id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at FROM project_test_runs WHERE project_id = ? ORDER BY id DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestProjectTestRun(ctx context.Context, projectID int64) (*ProjectTestRun, error) {
+	row := q.db.QueryRowContext(ctx, GetLatestProjectTestRun, projectID)
+	var i ProjectTestRun
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.CommitMessage,
+		&i.Dirty,
+		&i.Status,
+		&i.Total,
+		&i.Passed,
+		&i.Failed,
+		&i.Skipped,
+		&i.Coverage,
+		&i.DurationMs,
+		&i.ExitCode,
+		&i.Output,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetLatestProjectTestRunForCommit = `-- name: GetLatestProjectTestRunForCommit :one
+SELECT id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at FROM project_test_runs WHERE project_id = ? AND commit_hash = ? ORDER BY id DESC LIMIT 1
+`
+
+type GetLatestProjectTestRunForCommitParams struct {
+	ProjectID  int64  `json:"projectId"`
+	CommitHash string `json:"commitHash"`
+}
+
+func (q *Queries) GetLatestProjectTestRunForCommit(ctx context.Context, arg *GetLatestProjectTestRunForCommitParams) (*ProjectTestRun, error) {
+	row := q.db.QueryRowContext(ctx, GetLatestProjectTestRunForCommit,
+		arg.ProjectID,
+		arg.CommitHash,
+	)
+	var i ProjectTestRun
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.CommitMessage,
+		&i.Dirty,
+		&i.Status,
+		&i.Total,
+		&i.Passed,
+		&i.Failed,
+		&i.Skipped,
+		&i.Coverage,
+		&i.DurationMs,
+		&i.ExitCode,
+		&i.Output,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetPreviousProjectTestRun = `-- name: GetPreviousProjectTestRun :one
+SELECT id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at FROM project_test_runs
+WHERE project_id = ? AND commit_hash != ? AND id < ?
+ORDER BY id DESC LIMIT 1
+`
+
+type GetPreviousProjectTestRunParams struct {
+	ProjectID  int64  `json:"projectId"`
+	CommitHash string `json:"commitHash"`
+	ID         int64  `json:"id"`
+}
+
+func (q *Queries) GetPreviousProjectTestRun(ctx context.Context, arg *GetPreviousProjectTestRunParams) (*ProjectTestRun, error) {
+	row := q.db.QueryRowContext(ctx, GetPreviousProjectTestRun,
+		arg.ProjectID,
+		arg.CommitHash,
+		arg.ID,
+	)
+	var i ProjectTestRun
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.CommitMessage,
+		&i.Dirty,
+		&i.Status,
+		&i.Total,
+		&i.Passed,
+		&i.Failed,
+		&i.Skipped,
+		&i.Coverage,
+		&i.DurationMs,
+		&i.ExitCode,
+		&i.Output,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const GetProject = `-- name: GetProject :one
 SELECT cp.id, cp.name, cp.description, cp.boilerplate, cp.created_at, cp.updated_at, cp.slug, cp.container_id, cp.container_name, cp.status, cp.last_started_at, cp.last_stopped_at, cp.container_port, cp.network_id, cp.endorsement_policy, n.name as network_name, n.platform as network_platform 
 FROM chaincode_projects cp 
@@ -200,6 +474,76 @@ func (q *Queries) GetProjectBySlug(ctx context.Context, slug string) (*GetProjec
 	return &i, err
 }
 
+const GetProjectPromotion = `-- name: GetProjectPromotion :one
+SELECT id, project_id, commit_hash, network_id, chaincode_name, version, docker_image, chaincode_id, definition_id, sequence, status, error, created_at, updated_at FROM project_promotions WHERE id = ? AND project_id = ?
+`
+
+type GetProjectPromotionParams struct {
+	ID        int64 `json:"id"`
+	ProjectID int64 `json:"projectId"`
+}
+
+func (q *Queries) GetProjectPromotion(ctx context.Context, arg *GetProjectPromotionParams) (*ProjectPromotion, error) {
+	row := q.db.QueryRowContext(ctx, GetProjectPromotion,
+		arg.ID,
+		arg.ProjectID,
+	)
+	var i ProjectPromotion
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.NetworkID,
+		&i.ChaincodeName,
+		&i.Version,
+		&i.DockerImage,
+		&i.ChaincodeID,
+		&i.DefinitionID,
+		&i.Sequence,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetProjectTestRun = `-- name: GetProjectTestRun :one
+SELECT id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at FROM project_test_runs WHERE id = ? AND project_id = ?
+`
+
+type GetProjectTestRunParams struct {
+	ID        int64 `json:"id"`
+	ProjectID int64 `json:"projectId"`
+}
+
+func (q *Queries) GetProjectTestRun(ctx context.Context, arg *GetProjectTestRunParams) (*ProjectTestRun, error) {
+	row := q.db.QueryRowContext(ctx, GetProjectTestRun,
+		arg.ID,
+		arg.ProjectID,
+	)
+	var i ProjectTestRun
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.CommitHash,
+		&i.CommitMessage,
+		&i.Dirty,
+		&i.Status,
+		&i.Total,
+		&i.Passed,
+		&i.Failed,
+		&i.Skipped,
+		&i.Coverage,
+		&i.DurationMs,
+		&i.ExitCode,
+		&i.Output,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const InsertMessage = `-- name: InsertMessage :one
 INSERT INTO messages (conversation_id, parent_id, sender, content, enhanced_content, tool_arguments, is_internal) VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id, conversation_id, parent_id, sender, content, enhanced_content, tool_arguments, is_internal, created_at
 `
@@ -337,6 +681,135 @@ func (q *Queries) ListMessagesForConversation(ctx context.Context, conversationI
 	return items, nil
 }
 
+const ListProjectPromotions = `-- name: ListProjectPromotions :many
+SELECT id, project_id, commit_hash, network_id, chaincode_name, version, docker_image, chaincode_id, definition_id, sequence, status, error, created_at, updated_at FROM project_promotions WHERE project_id = ? ORDER BY id DESC
+`
+
+func (q *Queries) ListProjectPromotions(ctx context.Context, projectID int64) ([]*ProjectPromotion, error) {
+	rows, err := q.db.QueryContext(ctx, ListProjectPromotions, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ProjectPromotion{}
+	for rows.Next() {
+		var i ProjectPromotion
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.CommitHash,
+			&i.NetworkID,
+			&i.ChaincodeName,
+			&i.Version,
+			&i.DockerImage,
+			&i.ChaincodeID,
+			&i.DefinitionID,
+			&i.Sequence,
+			&i.Status,
+			&i.Error,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListProjectTestResults = `-- name: ListProjectTestResults :many
+SELECT id, run_id, suite, name, status, duration_ms, message FROM project_test_results WHERE run_id = ? ORDER BY suite, name
+`
+
+func (q *Queries) ListProjectTestResults(ctx context.Context, runID int64) ([]*ProjectTestResult, error) {
+	rows, err := q.db.QueryContext(ctx, ListProjectTestResults, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ProjectTestResult{}
+	for rows.Next() {
+		var i ProjectTestResult
+		if err := rows.Scan(
+			&i.ID,
+			&i.RunID,
+			&i.Suite,
+			&i.Name,
+			&i.Status,
+			&i.DurationMs,
+			&i.Message,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListProjectTestRuns = `-- name: ListProjectTestRuns :many
+SELECT id, project_id, commit_hash, commit_message, dirty, status, total, passed, failed, skipped, coverage, duration_ms, exit_code, output, error, created_at FROM project_test_runs WHERE project_id = ? ORDER BY id DESC LIMIT ?
+`
+
+type ListProjectTestRunsParams struct {
+	ProjectID int64 `json:"projectId"`
+	Limit     int64 `json:"limit"`
+}
+
+func (q *Queries) ListProjectTestRuns(ctx context.Context, arg *ListProjectTestRunsParams) ([]*ProjectTestRun, error) {
+	rows, err := q.db.QueryContext(ctx, ListProjectTestRuns,
+		arg.ProjectID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ProjectTestRun{}
+	for rows.Next() {
+		var i ProjectTestRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.CommitHash,
+			&i.CommitMessage,
+			&i.Dirty,
+			&i.Status,
+			&i.Total,
+			&i.Passed,
+			&i.Failed,
+			&i.Skipped,
+			&i.Coverage,
+			&i.DurationMs,
+			&i.ExitCode,
+			&i.Output,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListProjects = `-- name: ListProjects :many
 SELECT cp.id, cp.name, cp.description, cp.boilerplate, cp.created_at, cp.updated_at, cp.slug, cp.container_id, cp.container_name, cp.status, cp.last_started_at, cp.last_stopped_at, cp.container_port, cp.network_id, cp.endorsement_policy, n.name as network_name, n.platform as network_platform 
 FROM chaincode_projects cp 
@@ -478,6 +951,29 @@ func (q *Queries) ListToolCallsForMessage(ctx context.Context, messageID int64)
 	return items, nil
 }
 
+const SetProjectPromotionDefinition = `-- name: SetProjectPromotionDefinition :exec
+UPDATE project_promotions
+SET chaincode_id = ?, definition_id = ?, sequence = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type SetProjectPromotionDefinitionParams struct {
+	ChaincodeID  sql.NullInt64 `json:"chaincodeId"`
+	DefinitionID sql.NullInt64 `json:"definitionId"`
+	Sequence     sql.NullInt64 `json:"sequence"`
+	ID           int64         `json:"id"`
+}
+
+func (q *Queries) SetProjectPromotionDefinition(ctx context.Context, arg *SetProjectPromotionDefinitionParams) error {
+	_, err := q.db.ExecContext(ctx, SetProjectPromotionDefinition,
+		arg.ChaincodeID,
+		arg.DefinitionID,
+		arg.Sequence,
+		arg.ID,
+	)
+	return err
+}
+
 const UpdateMessageEnhancedContent = `-- name: UpdateMessageEnhancedContent :one
 UPDATE messages SET enhanced_content = ? WHERE id = ? RETURNING id, conversation_id, parent_id, sender, content, enhanced_content, tool_arguments, is_internal, created_at
 `
@@ -488,7 +984,10 @@ type UpdateMessageEnhancedContentParams struct {
 }
 
 func (q *Queries) UpdateMessageEnhancedContent(ctx context.Context, arg *UpdateMessageEnhancedContentParams) (*Message, error) {
-	row := q.db.QueryRowContext(ctx, UpdateMessageEnhancedContent, arg.EnhancedContent, arg.ID)
+	row := q.db.QueryRowContext(ctx, UpdateMessageEnhancedContent,
+		arg.EnhancedContent,
+		arg.ID,
+	)
 	var i Message
 	err := row.Scan(
 		&i.ID,
@@ -553,7 +1052,10 @@ type UpdateProjectEndorsementPolicyParams struct {
 }
 
 func (q *Queries) UpdateProjectEndorsementPolicy(ctx context.Context, arg *UpdateProjectEndorsementPolicyParams) (*ChaincodeProject, error) {
-	row := q.db.QueryRowContext(ctx, UpdateProjectEndorsementPolicy, arg.EndorsementPolicy, arg.ID)
+	row := q.db.QueryRowContext(ctx, UpdateProjectEndorsementPolicy,
+		arg.EndorsementPolicy,
+		arg.ID,
+	)
 	var i ChaincodeProject
 	err := row.Scan(
 		&i.ID,
@@ -574,3 +1076,24 @@ func (q *Queries) UpdateProjectEndorsementPolicy(ctx context.Context, arg *Updat
 	)
 	return &i, err
 }
+
+const UpdateProjectPromotionStatus = `-- name: UpdateProjectPromotionStatus :exec
+UPDATE project_promotions
+SET status = ?, error = ?, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateProjectPromotionStatusParams struct {
+	Status string         `json:"status"`
+	Error  sql.NullString `json:"error"`
+	ID     int64          `json:"id"`
+}
+
+func (q *Queries) UpdateProjectPromotionStatus(ctx context.Context, arg *UpdateProjectPromotionStatusParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateProjectPromotionStatus,
+		arg.Status,
+		arg.Error,
+		arg.ID,
+	)
+	return err
+}
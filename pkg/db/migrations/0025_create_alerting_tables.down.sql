@@ -0,0 +1,6 @@
+-- Reverse of 0025_create_alerting_tables.up.sql.
+
+DROP TABLE IF EXISTS alertmanager_config;
+
+DROP INDEX IF EXISTS idx_prometheus_alert_rules_group_name;
+DROP TABLE IF EXISTS prometheus_alert_rules;
@@ -0,0 +1,35 @@
+-- Custom Prometheus alerting rules. Built-in Fabric/Besu rule groups live in
+-- code (pkg/metrics/alerting.go) and are rendered alongside these rows.
+CREATE TABLE prometheus_alert_rules (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_name      TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    expr            TEXT NOT NULL,
+    for_duration    TEXT,
+    severity        TEXT NOT NULL DEFAULT 'warning',
+    summary         TEXT,
+    description     TEXT,
+    labels          TEXT,
+    enabled         BOOLEAN NOT NULL DEFAULT true,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP,
+    UNIQUE (group_name, name)
+);
+
+CREATE INDEX idx_prometheus_alert_rules_group_name ON prometheus_alert_rules(group_name);
+
+-- Alertmanager deployment, mirroring prometheus_config. A single row is
+-- expected; webhook_token authenticates Alertmanager against the public
+-- alert webhook that forwards alerts to the notification providers.
+CREATE TABLE alertmanager_config (
+    id                     INTEGER PRIMARY KEY AUTOINCREMENT,
+    alertmanager_port      INTEGER NOT NULL,
+    alertmanager_version   TEXT NOT NULL,
+    deployment_mode        TEXT NOT NULL DEFAULT 'docker',
+    network_mode           TEXT,
+    webhook_url            TEXT NOT NULL,
+    webhook_token          TEXT NOT NULL,
+    default_rules_enabled  BOOLEAN NOT NULL DEFAULT true,
+    created_at             TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at             TIMESTAMP
+);
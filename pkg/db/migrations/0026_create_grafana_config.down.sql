@@ -0,0 +1,3 @@
+-- Reverse of 0026_create_grafana_config.up.sql.
+
+DROP TABLE IF EXISTS grafana_config;
@@ -0,0 +1,14 @@
+-- Grafana deployment, mirroring alertmanager_config. A single row is expected.
+-- admin_password is kept so redeployments reuse the credentials Grafana
+-- stored in its data directory on first start.
+CREATE TABLE grafana_config (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    grafana_port     INTEGER NOT NULL,
+    grafana_version  TEXT NOT NULL,
+    deployment_mode  TEXT NOT NULL DEFAULT 'docker',
+    network_mode     TEXT,
+    admin_user       TEXT NOT NULL DEFAULT 'admin',
+    admin_password   TEXT NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP
+);
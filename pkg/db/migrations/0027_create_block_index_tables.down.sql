@@ -0,0 +1,14 @@
+-- Reverse of 0027_create_block_index_tables.up.sql.
+
+DROP INDEX IF EXISTS idx_indexed_transaction_keys_transaction;
+DROP INDEX IF EXISTS idx_indexed_transaction_keys_key;
+DROP TABLE IF EXISTS indexed_transaction_keys;
+
+DROP INDEX IF EXISTS idx_indexed_transactions_creator;
+DROP INDEX IF EXISTS idx_indexed_transactions_chaincode;
+DROP INDEX IF EXISTS idx_indexed_transactions_timestamp;
+DROP INDEX IF EXISTS idx_indexed_transactions_tx_id;
+DROP TABLE IF EXISTS indexed_transactions;
+
+DROP TABLE IF EXISTS indexed_blocks;
+DROP TABLE IF EXISTS block_indexers;
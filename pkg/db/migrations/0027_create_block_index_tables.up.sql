@@ -0,0 +1,60 @@
+-- Background block indexer state, one row per indexed network. next_block is
+-- the first block not stored yet, so indexing resumes there after a restart.
+CREATE TABLE block_indexers (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id       INTEGER NOT NULL UNIQUE REFERENCES networks(id) ON DELETE CASCADE,
+    enabled          BOOLEAN NOT NULL DEFAULT true,
+    next_block       INTEGER NOT NULL DEFAULT 0,
+    last_error       TEXT,
+    last_indexed_at  TIMESTAMP,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP
+);
+
+CREATE TABLE indexed_blocks (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id    INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    block_number  INTEGER NOT NULL,
+    data_hash     TEXT NOT NULL,
+    tx_count      INTEGER NOT NULL,
+    block_time    TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (network_id, block_number)
+);
+
+-- For FabricX transactions, which have no chaincode invocation, chaincode holds
+-- the first application namespace the transaction touches.
+CREATE TABLE indexed_transactions (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id       INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    block_number     INTEGER NOT NULL,
+    tx_index         INTEGER NOT NULL,
+    tx_id            TEXT NOT NULL,
+    tx_type          TEXT NOT NULL,
+    tx_timestamp     TIMESTAMP,
+    chaincode        TEXT NOT NULL DEFAULT '',
+    function         TEXT NOT NULL DEFAULT '',
+    creator_msp_id   TEXT NOT NULL DEFAULT '',
+    validation_code  TEXT NOT NULL DEFAULT '',
+    event_name       TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (network_id, block_number, tx_index)
+);
+
+CREATE INDEX idx_indexed_transactions_tx_id ON indexed_transactions(network_id, tx_id);
+CREATE INDEX idx_indexed_transactions_timestamp ON indexed_transactions(network_id, tx_timestamp);
+CREATE INDEX idx_indexed_transactions_chaincode ON indexed_transactions(network_id, chaincode, function);
+CREATE INDEX idx_indexed_transactions_creator ON indexed_transactions(network_id, creator_msp_id);
+
+-- Keys read or written by an indexed transaction. access is read, write or delete.
+CREATE TABLE indexed_transaction_keys (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id  INTEGER NOT NULL REFERENCES indexed_transactions(id) ON DELETE CASCADE,
+    network_id      INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    namespace       TEXT NOT NULL,
+    key             TEXT NOT NULL,
+    access          TEXT NOT NULL
+);
+
+CREATE INDEX idx_indexed_transaction_keys_key ON indexed_transaction_keys(network_id, key);
+CREATE INDEX idx_indexed_transaction_keys_transaction ON indexed_transaction_keys(transaction_id);
@@ -0,0 +1,7 @@
+-- Reverse of 0028_create_upgrade_plans.up.sql.
+
+DROP INDEX IF EXISTS idx_upgrade_plan_steps_plan;
+DROP TABLE IF EXISTS upgrade_plan_steps;
+
+DROP INDEX IF EXISTS idx_upgrade_plans_network;
+DROP TABLE IF EXISTS upgrade_plans;
@@ -0,0 +1,35 @@
+-- Rolling version upgrade plans. A plan upgrades the nodes of a network one
+-- step at a time; steps record the version each node is moved from so the
+-- upgrade can be rolled back.
+CREATE TABLE upgrade_plans (
+    id                      INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id              INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    target_version          TEXT NOT NULL,
+    status                  TEXT NOT NULL,
+    health_timeout_seconds  INTEGER NOT NULL,
+    allow_downtime          BOOLEAN NOT NULL DEFAULT false,
+    error                   TEXT,
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at              TIMESTAMP,
+    completed_at            TIMESTAMP,
+    updated_at              TIMESTAMP
+);
+
+CREATE INDEX idx_upgrade_plans_network ON upgrade_plans(network_id);
+
+CREATE TABLE upgrade_plan_steps (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    plan_id       INTEGER NOT NULL REFERENCES upgrade_plans(id) ON DELETE CASCADE,
+    step_order    INTEGER NOT NULL,
+    node_id       INTEGER NOT NULL REFERENCES nodes(id) ON DELETE CASCADE,
+    node_type     TEXT NOT NULL,
+    from_version  TEXT NOT NULL,
+    to_version    TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    error         TEXT,
+    started_at    TIMESTAMP,
+    completed_at  TIMESTAMP,
+    UNIQUE (plan_id, step_order)
+);
+
+CREATE INDEX idx_upgrade_plan_steps_plan ON upgrade_plan_steps(plan_id);
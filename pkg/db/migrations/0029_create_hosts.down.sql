@@ -0,0 +1,6 @@
+-- Reverse of 0029_create_hosts.up.sql.
+
+DROP INDEX IF EXISTS idx_node_hosts_host;
+DROP TABLE IF EXISTS node_hosts;
+
+DROP TABLE IF EXISTS hosts;
@@ -0,0 +1,27 @@
+-- Remote Linux hosts that nodes can be deployed to over SSH. The SSH private
+-- key lives in key management; host_key pins the server key seen on first connect.
+CREATE TABLE hosts (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    name             TEXT NOT NULL UNIQUE,
+    description      TEXT,
+    address          TEXT NOT NULL,
+    port             INTEGER NOT NULL DEFAULT 22,
+    username         TEXT NOT NULL,
+    ssh_key_id       INTEGER NOT NULL REFERENCES keys(id) ON DELETE RESTRICT,
+    host_key         TEXT,
+    data_path        TEXT NOT NULL,
+    status           TEXT NOT NULL DEFAULT 'UNKNOWN',
+    last_error       TEXT,
+    last_checked_at  TIMESTAMP,
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Placement of a node on a remote host. Nodes without a row run locally.
+CREATE TABLE node_hosts (
+    node_id     INTEGER PRIMARY KEY REFERENCES nodes(id) ON DELETE CASCADE,
+    host_id     INTEGER NOT NULL REFERENCES hosts(id) ON DELETE RESTRICT,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_node_hosts_host ON node_hosts(host_id);
@@ -0,0 +1,6 @@
+-- Reverse of 0030_create_testnets.up.sql.
+
+DROP INDEX IF EXISTS idx_testnet_resources_testnet;
+DROP TABLE IF EXISTS testnet_resources;
+
+DROP TABLE IF EXISTS testnets;
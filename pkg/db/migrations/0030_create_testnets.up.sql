@@ -0,0 +1,25 @@
+-- Testnets created by `chainlaunch testnet` and the FabricX quickstart. A
+-- testnet is a named bundle of the resources that were created for it, so the
+-- whole bundle can be inspected and destroyed together.
+CREATE TABLE testnets (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT NOT NULL UNIQUE,
+    platform    TEXT NOT NULL,
+    status      TEXT NOT NULL DEFAULT 'CREATING',
+    last_error  TEXT,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Resources owned by a testnet. resource_type is one of NETWORK, NODE,
+-- NODE_GROUP, SERVICE, ORGANIZATION or KEY.
+CREATE TABLE testnet_resources (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    testnet_id     INTEGER NOT NULL REFERENCES testnets(id) ON DELETE CASCADE,
+    resource_type  TEXT NOT NULL,
+    resource_id    INTEGER NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(testnet_id, resource_type, resource_id)
+);
+
+CREATE INDEX idx_testnet_resources_testnet ON testnet_resources(testnet_id);
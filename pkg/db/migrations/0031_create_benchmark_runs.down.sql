@@ -0,0 +1,4 @@
+-- Reverse of 0031_create_benchmark_runs.up.sql.
+
+DROP INDEX IF EXISTS idx_benchmark_runs_name;
+DROP TABLE IF EXISTS benchmark_runs;
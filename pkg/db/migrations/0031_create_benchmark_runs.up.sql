@@ -0,0 +1,16 @@
+-- Load test runs against a deployed Fabric chaincode or Besu contract. config
+-- holds the target and workload as JSON; report holds the measured latency,
+-- throughput and failures once the run is finished.
+CREATE TABLE benchmark_runs (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         TEXT NOT NULL,
+    platform     TEXT NOT NULL,
+    config       TEXT NOT NULL,
+    status       TEXT NOT NULL DEFAULT 'RUNNING',
+    report       TEXT,
+    error        TEXT,
+    started_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at  TIMESTAMP
+);
+
+CREATE INDEX idx_benchmark_runs_name ON benchmark_runs(name);
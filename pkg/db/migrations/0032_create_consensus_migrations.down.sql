@@ -0,0 +1,7 @@
+-- Reverse of 0032_create_consensus_migrations.up.sql.
+
+DROP INDEX IF EXISTS idx_consensus_migration_steps_migration;
+DROP TABLE IF EXISTS consensus_migration_steps;
+
+DROP INDEX IF EXISTS idx_consensus_migrations_network;
+DROP TABLE IF EXISTS consensus_migrations;
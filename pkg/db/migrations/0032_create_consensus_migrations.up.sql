@@ -0,0 +1,33 @@
+-- Consensus migrations move a live Fabric channel from etcdraft to SmartBFT.
+-- Steps are persisted so a migration that fails or is interrupted resumes
+-- from its first unfinished step.
+
+CREATE TABLE consensus_migrations (
+    id                INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id        INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    target_consensus  TEXT NOT NULL,
+    status            TEXT NOT NULL,
+    -- JSON with the BFT consenter mapping and options computed when the migration was planned
+    config            TEXT NOT NULL,
+    error             TEXT,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at        TIMESTAMP,
+    completed_at      TIMESTAMP,
+    updated_at        TIMESTAMP
+);
+
+CREATE INDEX idx_consensus_migrations_network ON consensus_migrations(network_id);
+
+CREATE TABLE consensus_migration_steps (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    migration_id  INTEGER NOT NULL REFERENCES consensus_migrations(id) ON DELETE CASCADE,
+    step_order    INTEGER NOT NULL,
+    name          TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    error         TEXT,
+    started_at    TIMESTAMP,
+    completed_at  TIMESTAMP,
+    UNIQUE (migration_id, step_order)
+);
+
+CREATE INDEX idx_consensus_migration_steps_migration ON consensus_migration_steps(migration_id);
@@ -0,0 +1,7 @@
+-- Reverse of 0033_create_besu_contracts.up.sql.
+
+DROP INDEX IF EXISTS idx_besu_contract_upgrades_contract;
+DROP TABLE IF EXISTS besu_contract_upgrades;
+
+DROP INDEX IF EXISTS idx_besu_contracts_network;
+DROP TABLE IF EXISTS besu_contracts;
@@ -0,0 +1,35 @@
+-- Registry of smart contracts deployed on Besu networks, with the ABI used to
+-- call them. Proxy contracts keep the history of their implementations.
+
+CREATE TABLE besu_contracts (
+    id                      INTEGER PRIMARY KEY AUTOINCREMENT,
+    network_id              INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    name                    TEXT NOT NULL,
+    address                 TEXT NOT NULL,
+    abi                     TEXT NOT NULL,
+    -- keccak256 of the runtime bytecode found at the address
+    bytecode_hash           TEXT,
+    deployer_key_id         INTEGER REFERENCES keys(id) ON DELETE SET NULL,
+    deploy_tx_hash          TEXT,
+    deploy_block_number     INTEGER,
+    is_proxy                BOOLEAN NOT NULL DEFAULT FALSE,
+    implementation_address  TEXT,
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP,
+    UNIQUE (network_id, address)
+);
+
+CREATE INDEX idx_besu_contracts_network ON besu_contracts(network_id);
+
+CREATE TABLE besu_contract_upgrades (
+    id                       INTEGER PRIMARY KEY AUTOINCREMENT,
+    contract_id              INTEGER NOT NULL REFERENCES besu_contracts(id) ON DELETE CASCADE,
+    previous_implementation  TEXT,
+    implementation_address   TEXT NOT NULL,
+    -- keccak256 of the runtime bytecode of the implementation
+    bytecode_hash            TEXT,
+    tx_hash                  TEXT,
+    created_at               TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_besu_contract_upgrades_contract ON besu_contract_upgrades(contract_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_besu_contracts_project;
+ALTER TABLE besu_contracts DROP COLUMN project_id;
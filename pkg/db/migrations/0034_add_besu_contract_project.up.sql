@@ -0,0 +1,4 @@
+-- Link contracts deployed from a scai project to it, so the project API can
+-- call the contracts deployed on start.
+ALTER TABLE besu_contracts ADD COLUMN project_id INTEGER REFERENCES chaincode_projects(id) ON DELETE SET NULL;
+CREATE INDEX idx_besu_contracts_project ON besu_contracts(project_id);
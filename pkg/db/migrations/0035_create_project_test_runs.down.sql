@@ -0,0 +1,7 @@
+-- Reverse of 0035_create_project_test_runs.up.sql.
+
+DROP INDEX IF EXISTS idx_project_test_results_run;
+DROP TABLE IF EXISTS project_test_results;
+
+DROP INDEX IF EXISTS idx_project_test_runs_project;
+DROP TABLE IF EXISTS project_test_runs;
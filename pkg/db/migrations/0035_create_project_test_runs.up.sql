@@ -0,0 +1,38 @@
+-- Unit test runs of chaincode projects. Each run is tied to the git commit of
+-- the project that was tested, so results can be compared between versions.
+
+CREATE TABLE project_test_runs (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id      INTEGER NOT NULL REFERENCES chaincode_projects(id) ON DELETE CASCADE,
+    commit_hash     TEXT NOT NULL,
+    commit_message  TEXT,
+    -- the working tree had uncommitted changes when the tests ran
+    dirty           BOOLEAN NOT NULL DEFAULT FALSE,
+    status          TEXT NOT NULL, -- passed, failed or error
+    total           INTEGER NOT NULL DEFAULT 0,
+    passed          INTEGER NOT NULL DEFAULT 0,
+    failed          INTEGER NOT NULL DEFAULT 0,
+    skipped         INTEGER NOT NULL DEFAULT 0,
+    -- percentage of covered statements or lines, NULL without a coverage report
+    coverage        REAL,
+    duration_ms     INTEGER NOT NULL DEFAULT 0,
+    exit_code       INTEGER NOT NULL DEFAULT 0,
+    output          TEXT,
+    error           TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_project_test_runs_project ON project_test_runs(project_id, commit_hash);
+
+CREATE TABLE project_test_results (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id       INTEGER NOT NULL REFERENCES project_test_runs(id) ON DELETE CASCADE,
+    -- Go package or JUnit test suite
+    suite        TEXT NOT NULL,
+    name         TEXT NOT NULL,
+    status       TEXT NOT NULL, -- passed, failed or skipped
+    duration_ms  INTEGER NOT NULL DEFAULT 0,
+    message      TEXT
+);
+
+CREATE INDEX idx_project_test_results_run ON project_test_results(run_id);
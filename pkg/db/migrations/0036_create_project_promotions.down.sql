@@ -0,0 +1,4 @@
+-- Reverse of 0036_create_project_promotions.up.sql.
+
+DROP INDEX IF EXISTS idx_project_promotions_project;
+DROP TABLE IF EXISTS project_promotions;
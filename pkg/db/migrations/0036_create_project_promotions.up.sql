@@ -0,0 +1,23 @@
+-- Promotions of a chaincode project commit to a Fabric network. The commit is
+-- built into a versioned image and deployed as a new chaincode definition.
+
+CREATE TABLE project_promotions (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    project_id      INTEGER NOT NULL REFERENCES chaincode_projects(id) ON DELETE CASCADE,
+    commit_hash     TEXT NOT NULL,
+    network_id      INTEGER NOT NULL REFERENCES networks(id) ON DELETE CASCADE,
+    chaincode_name  TEXT NOT NULL,
+    version         TEXT NOT NULL,
+    docker_image    TEXT NOT NULL,
+    -- set once the chaincode definition is created
+    chaincode_id    INTEGER REFERENCES fabric_chaincodes(id) ON DELETE SET NULL,
+    definition_id   INTEGER REFERENCES fabric_chaincode_definitions(id) ON DELETE SET NULL,
+    sequence        INTEGER,
+    -- pending, building, pushing, installing, approving, committing, deploying, completed or failed
+    status          TEXT NOT NULL,
+    error           TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_project_promotions_project ON project_promotions(project_id);
@@ -0,0 +1,4 @@
+-- Reverse of 0037_create_plugin_versions.up.sql.
+
+DROP INDEX IF EXISTS idx_plugin_versions_plugin;
+DROP TABLE IF EXISTS plugin_versions;
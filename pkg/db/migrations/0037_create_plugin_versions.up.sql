@@ -0,0 +1,36 @@
+-- Versions of the installed plugins, newest last. The latest row of a plugin is
+-- its current definition, rows with deployed_at were deployed and can be rolled
+-- back to with the parameters they were deployed with. parameters is never NULL
+-- and JSON is stored as BLOB like the values written by the queries, so that it
+-- scans into json.RawMessage.
+
+CREATE TABLE plugin_versions (
+    id            INTEGER PRIMARY KEY AUTOINCREMENT,
+    plugin_name   VARCHAR(255) NOT NULL REFERENCES plugins(name) ON DELETE CASCADE,
+    version       TEXT NOT NULL,
+    -- registry source the version was installed from, NULL for uploaded manifests
+    source        TEXT,
+    api_version   VARCHAR(50) NOT NULL,
+    kind          VARCHAR(50) NOT NULL,
+    metadata      JSON NOT NULL,
+    spec          JSON NOT NULL,
+    parameters    JSON NOT NULL DEFAULT (CAST('{}' AS BLOB)),
+    deployed_at   TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_plugin_versions_plugin ON plugin_versions(plugin_name);
+
+-- Track the plugins installed before versions were recorded
+INSERT INTO plugin_versions (plugin_name, version, api_version, kind, metadata, spec, parameters, deployed_at, created_at)
+SELECT
+    name,
+    COALESCE(json_extract(metadata, '$.version'), ''),
+    api_version,
+    kind,
+    CAST(metadata AS BLOB),
+    CAST(spec AS BLOB),
+    CAST(COALESCE(json_extract(deployment_metadata, '$.parameters'), '{}') AS BLOB),
+    CASE WHEN deployment_status = 'deployed' THEN updated_at END,
+    created_at
+FROM plugins;
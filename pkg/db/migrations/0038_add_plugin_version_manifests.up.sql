@@ -0,0 +1,6 @@
+-- Keep the manifest of each plugin version exactly as it was fetched or uploaded,
+-- with its detached signature, so that a version can be verified again before it
+-- is restored. Both are NULL for versions recorded before and for plugins
+-- installed without a manifest.
+ALTER TABLE plugin_versions ADD COLUMN manifest BLOB;
+ALTER TABLE plugin_versions ADD COLUMN signature TEXT;
@@ -10,6 +10,19 @@ import (
 	"time"
 )
 
+type AlertmanagerConfig struct {
+	ID                  int64          `json:"id"`
+	AlertmanagerPort    int64          `json:"alertmanagerPort"`
+	AlertmanagerVersion string         `json:"alertmanagerVersion"`
+	DeploymentMode      string         `json:"deploymentMode"`
+	NetworkMode         sql.NullString `json:"networkMode"`
+	WebhookUrl          string         `json:"webhookUrl"`
+	WebhookToken        string         `json:"webhookToken"`
+	DefaultRulesEnabled bool           `json:"defaultRulesEnabled"`
+	CreatedAt           time.Time      `json:"createdAt"`
+	UpdatedAt           sql.NullTime   `json:"updatedAt"`
+}
+
 type AuditLog struct {
 	ID               int64          `json:"id"`
 	Timestamp        time.Time      `json:"timestamp"`
@@ -70,6 +83,56 @@ type BackupTarget struct {
 	UpdatedAt      sql.NullTime   `json:"updatedAt"`
 }
 
+type BenchmarkRun struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Platform   string         `json:"platform"`
+	Config     string         `json:"config"`
+	Status     string         `json:"status"`
+	Report     sql.NullString `json:"report"`
+	Error      sql.NullString `json:"error"`
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt sql.NullTime   `json:"finishedAt"`
+}
+
+type BesuContract struct {
+	ID                    int64          `json:"id"`
+	NetworkID             int64          `json:"networkId"`
+	Name                  string         `json:"name"`
+	Address               string         `json:"address"`
+	Abi                   string         `json:"abi"`
+	BytecodeHash          sql.NullString `json:"bytecodeHash"`
+	DeployerKeyID         sql.NullInt64  `json:"deployerKeyId"`
+	DeployTxHash          sql.NullString `json:"deployTxHash"`
+	DeployBlockNumber     sql.NullInt64  `json:"deployBlockNumber"`
+	IsProxy               bool           `json:"isProxy"`
+	ImplementationAddress sql.NullString `json:"implementationAddress"`
+	CreatedAt             time.Time      `json:"createdAt"`
+	UpdatedAt             sql.NullTime   `json:"updatedAt"`
+	ProjectID             sql.NullInt64  `json:"projectId"`
+}
+
+type BesuContractUpgrade struct {
+	ID                     int64          `json:"id"`
+	ContractID             int64          `json:"contractId"`
+	PreviousImplementation sql.NullString `json:"previousImplementation"`
+	ImplementationAddress  string         `json:"implementationAddress"`
+	BytecodeHash           sql.NullString `json:"bytecodeHash"`
+	TxHash                 sql.NullString `json:"txHash"`
+	CreatedAt              time.Time      `json:"createdAt"`
+}
+
+type BlockIndexer struct {
+	ID            int64          `json:"id"`
+	NetworkID     int64          `json:"networkId"`
+	Enabled       bool           `json:"enabled"`
+	NextBlock     int64          `json:"nextBlock"`
+	LastError     sql.NullString `json:"lastError"`
+	LastIndexedAt sql.NullTime   `json:"lastIndexedAt"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     sql.NullTime   `json:"updatedAt"`
+}
+
 type BlockchainPlatform struct {
 	Name string `json:"name"`
 }
@@ -92,6 +155,30 @@ type ChaincodeProject struct {
 	EndorsementPolicy sql.NullString `json:"endorsementPolicy"`
 }
 
+type ConsensusMigration struct {
+	ID              int64          `json:"id"`
+	NetworkID       int64          `json:"networkId"`
+	TargetConsensus string         `json:"targetConsensus"`
+	Status          string         `json:"status"`
+	Config          string         `json:"config"`
+	Error           sql.NullString `json:"error"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	StartedAt       sql.NullTime   `json:"startedAt"`
+	CompletedAt     sql.NullTime   `json:"completedAt"`
+	UpdatedAt       sql.NullTime   `json:"updatedAt"`
+}
+
+type ConsensusMigrationStep struct {
+	ID          int64          `json:"id"`
+	MigrationID int64          `json:"migrationId"`
+	StepOrder   int64          `json:"stepOrder"`
+	Name        string         `json:"name"`
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	StartedAt   sql.NullTime   `json:"startedAt"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+}
+
 type Conversation struct {
 	ID        int64     `json:"id"`
 	ProjectID int64     `json:"projectId"`
@@ -176,6 +263,70 @@ type FabricxNamespace struct {
 	UpdatedAt      sql.NullTime   `json:"updatedAt"`
 }
 
+type GrafanaConfig struct {
+	ID             int64          `json:"id"`
+	GrafanaPort    int64          `json:"grafanaPort"`
+	GrafanaVersion string         `json:"grafanaVersion"`
+	DeploymentMode string         `json:"deploymentMode"`
+	NetworkMode    sql.NullString `json:"networkMode"`
+	AdminUser      string         `json:"adminUser"`
+	AdminPassword  string         `json:"adminPassword"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      sql.NullTime   `json:"updatedAt"`
+}
+
+type Host struct {
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	Description   sql.NullString `json:"description"`
+	Address       string         `json:"address"`
+	Port          int64          `json:"port"`
+	Username      string         `json:"username"`
+	SshKeyID      int64          `json:"sshKeyId"`
+	HostKey       sql.NullString `json:"hostKey"`
+	DataPath      string         `json:"dataPath"`
+	Status        string         `json:"status"`
+	LastError     sql.NullString `json:"lastError"`
+	LastCheckedAt sql.NullTime   `json:"lastCheckedAt"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+type IndexedBlock struct {
+	ID          int64        `json:"id"`
+	NetworkID   int64        `json:"networkId"`
+	BlockNumber int64        `json:"blockNumber"`
+	DataHash    string       `json:"dataHash"`
+	TxCount     int64        `json:"txCount"`
+	BlockTime   sql.NullTime `json:"blockTime"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+type IndexedTransaction struct {
+	ID             int64        `json:"id"`
+	NetworkID      int64        `json:"networkId"`
+	BlockNumber    int64        `json:"blockNumber"`
+	TxIndex        int64        `json:"txIndex"`
+	TxID           string       `json:"txId"`
+	TxType         string       `json:"txType"`
+	TxTimestamp    sql.NullTime `json:"txTimestamp"`
+	Chaincode      string       `json:"chaincode"`
+	Function       string       `json:"function"`
+	CreatorMspID   string       `json:"creatorMspId"`
+	ValidationCode string       `json:"validationCode"`
+	EventName      string       `json:"eventName"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
+
+type IndexedTransactionKey struct {
+	ID            int64  `json:"id"`
+	TransactionID int64  `json:"transactionId"`
+	NetworkID     int64  `json:"networkId"`
+	Namespace     string `json:"namespace"`
+	Key           string `json:"key"`
+	Access        string `json:"access"`
+}
+
 type Key struct {
 	ID                int64          `json:"id"`
 	Name              string         `json:"name"`
@@ -319,6 +470,12 @@ type NodeGroup struct {
 	PostgresServiceID sql.NullInt64  `json:"postgresServiceId"`
 }
 
+type NodeHost struct {
+	NodeID    int64     `json:"nodeId"`
+	HostID    int64     `json:"hostId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type NodeKey struct {
 	ID        int64     `json:"id"`
 	NodeID    int64     `json:"nodeId"`
@@ -370,6 +527,83 @@ type Plugin struct {
 	DeploymentStatus   sql.NullString  `json:"deploymentStatus"`
 }
 
+type PluginVersion struct {
+	ID         int64           `json:"id"`
+	PluginName string          `json:"pluginName"`
+	Version    string          `json:"version"`
+	Source     sql.NullString  `json:"source"`
+	ApiVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   json.RawMessage `json:"metadata"`
+	Spec       json.RawMessage `json:"spec"`
+	Parameters json.RawMessage `json:"parameters"`
+	DeployedAt sql.NullTime    `json:"deployedAt"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Manifest   []byte          `json:"manifest"`
+	Signature  sql.NullString  `json:"signature"`
+}
+
+type ProjectPromotion struct {
+	ID            int64          `json:"id"`
+	ProjectID     int64          `json:"projectId"`
+	CommitHash    string         `json:"commitHash"`
+	NetworkID     int64          `json:"networkId"`
+	ChaincodeName string         `json:"chaincodeName"`
+	Version       string         `json:"version"`
+	DockerImage   string         `json:"dockerImage"`
+	ChaincodeID   sql.NullInt64  `json:"chaincodeId"`
+	DefinitionID  sql.NullInt64  `json:"definitionId"`
+	Sequence      sql.NullInt64  `json:"sequence"`
+	Status        string         `json:"status"`
+	Error         sql.NullString `json:"error"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+type ProjectTestResult struct {
+	ID         int64          `json:"id"`
+	RunID      int64          `json:"runId"`
+	Suite      string         `json:"suite"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	DurationMs int64          `json:"durationMs"`
+	Message    sql.NullString `json:"message"`
+}
+
+type ProjectTestRun struct {
+	ID            int64           `json:"id"`
+	ProjectID     int64           `json:"projectId"`
+	CommitHash    string          `json:"commitHash"`
+	CommitMessage sql.NullString  `json:"commitMessage"`
+	Dirty         bool            `json:"dirty"`
+	Status        string          `json:"status"`
+	Total         int64           `json:"total"`
+	Passed        int64           `json:"passed"`
+	Failed        int64           `json:"failed"`
+	Skipped       int64           `json:"skipped"`
+	Coverage      sql.NullFloat64 `json:"coverage"`
+	DurationMs    int64           `json:"durationMs"`
+	ExitCode      int64           `json:"exitCode"`
+	Output        sql.NullString  `json:"output"`
+	Error         sql.NullString  `json:"error"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+type PrometheusAlertRule struct {
+	ID          int64          `json:"id"`
+	GroupName   string         `json:"groupName"`
+	Name        string         `json:"name"`
+	Expr        string         `json:"expr"`
+	ForDuration sql.NullString `json:"forDuration"`
+	Severity    string         `json:"severity"`
+	Summary     sql.NullString `json:"summary"`
+	Description sql.NullString `json:"description"`
+	Labels      sql.NullString `json:"labels"`
+	Enabled     bool           `json:"enabled"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   sql.NullTime   `json:"updatedAt"`
+}
+
 type PrometheusConfig struct {
 	ID                  int64          `json:"id"`
 	PrometheusPort      int64          `json:"prometheusPort"`
@@ -455,6 +689,24 @@ type Setting struct {
 	UpdatedAt sql.NullTime `json:"updatedAt"`
 }
 
+type Testnet struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Platform  string         `json:"platform"`
+	Status    string         `json:"status"`
+	LastError sql.NullString `json:"lastError"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+type TestnetResource struct {
+	ID           int64     `json:"id"`
+	TestnetID    int64     `json:"testnetId"`
+	ResourceType string    `json:"resourceType"`
+	ResourceID   int64     `json:"resourceId"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
 type ToolCall struct {
 	ID        int64          `json:"id"`
 	MessageID int64          `json:"messageId"`
@@ -465,6 +717,34 @@ type ToolCall struct {
 	CreatedAt time.Time      `json:"createdAt"`
 }
 
+type UpgradePlan struct {
+	ID                   int64          `json:"id"`
+	NetworkID            int64          `json:"networkId"`
+	TargetVersion        string         `json:"targetVersion"`
+	Status               string         `json:"status"`
+	HealthTimeoutSeconds int64          `json:"healthTimeoutSeconds"`
+	AllowDowntime        bool           `json:"allowDowntime"`
+	Error                sql.NullString `json:"error"`
+	CreatedAt            time.Time      `json:"createdAt"`
+	StartedAt            sql.NullTime   `json:"startedAt"`
+	CompletedAt          sql.NullTime   `json:"completedAt"`
+	UpdatedAt            sql.NullTime   `json:"updatedAt"`
+}
+
+type UpgradePlanStep struct {
+	ID          int64          `json:"id"`
+	PlanID      int64          `json:"planId"`
+	StepOrder   int64          `json:"stepOrder"`
+	NodeID      int64          `json:"nodeId"`
+	NodeType    string         `json:"nodeType"`
+	FromVersion string         `json:"fromVersion"`
+	ToVersion   string         `json:"toVersion"`
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	StartedAt   sql.NullTime   `json:"startedAt"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+}
+
 type User struct {
 	ID          int64          `json:"id"`
 	Username    string         `json:"username"`
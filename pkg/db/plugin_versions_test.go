@@ -0,0 +1,96 @@
+package db_test
+
+// DB-layer tests for the plugin_versions queries introduced in migration
+// 0037. The latest version of a plugin is its current definition, rollback
+// goes back to the previous version that was deployed.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func TestPluginVersions(t *testing.T) {
+	q, sqlDB := newTestQueries(t)
+	ctx := context.Background()
+
+	// Inserted directly: CreatePlugin cannot scan the NULL deployment metadata of a new plugin
+	if _, err := sqlDB.ExecContext(ctx, `INSERT INTO plugins (name, api_version, kind, metadata, spec, deployment_metadata)
+		VALUES ('hlf-api', 'dev.chainlaunch/v1', 'Plugin', '{"name":"hlf-api","version":"1.0.0"}', '{}', '{}')`); err != nil {
+		t.Fatalf("insert plugin: %v", err)
+	}
+
+	createVersion := func(version string) *db.PluginVersion {
+		t.Helper()
+		v, err := q.CreatePluginVersion(ctx, &db.CreatePluginVersionParams{
+			PluginName: "hlf-api",
+			Version:    version,
+			Source:     sql.NullString{String: "plugin-hlf-api", Valid: true},
+			ApiVersion: "dev.chainlaunch/v1",
+			Kind:       "Plugin",
+			Metadata:   json.RawMessage(`{"name":"hlf-api","version":"` + version + `"}`),
+			Spec:       json.RawMessage(`{}`),
+			Manifest:   []byte("metadata:\n  name: hlf-api\n  version: " + version + "\n"),
+			Signature:  sql.NullString{String: "sig-" + version, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("CreatePluginVersion %s: %v", version, err)
+		}
+		return v
+	}
+
+	v1 := createVersion("1.0.0")
+	if err := q.MarkPluginVersionDeployed(ctx, &db.MarkPluginVersionDeployedParams{
+		Parameters: json.RawMessage(`{"PEER_ID":"1"}`),
+		ID:         v1.ID,
+	}); err != nil {
+		t.Fatalf("MarkPluginVersionDeployed: %v", err)
+	}
+	v2 := createVersion("1.1.0")
+	v3 := createVersion("1.2.0")
+
+	current, err := q.GetCurrentPluginVersion(ctx, "hlf-api")
+	if err != nil || current.ID != v3.ID {
+		t.Fatalf("GetCurrentPluginVersion: got %+v, err=%v, want version %d", current, err, v3.ID)
+	}
+
+	// 1.1.0 was never deployed, the rollback target of 1.2.0 is 1.0.0
+	previous, err := q.GetPreviousDeployedPluginVersion(ctx, &db.GetPreviousDeployedPluginVersionParams{PluginName: "hlf-api", ID: v3.ID})
+	if err != nil {
+		t.Fatalf("GetPreviousDeployedPluginVersion: %v", err)
+	}
+	if previous.ID != v1.ID || !previous.DeployedAt.Valid || string(previous.Parameters) != `{"PEER_ID":"1"}` {
+		t.Fatalf("previous deployed version: got %+v", previous)
+	}
+	if string(previous.Manifest) != "metadata:\n  name: hlf-api\n  version: 1.0.0\n" || previous.Signature.String != "sig-1.0.0" {
+		t.Fatalf("previous deployed version manifest: got %q signed %q", previous.Manifest, previous.Signature.String)
+	}
+	if string(v2.Parameters) != "{}" || v2.DeployedAt.Valid {
+		t.Fatalf("new version: got parameters %s deployed %v", v2.Parameters, v2.DeployedAt.Valid)
+	}
+	if _, err := q.GetPreviousDeployedPluginVersion(ctx, &db.GetPreviousDeployedPluginVersionParams{PluginName: "hlf-api", ID: v1.ID}); err != sql.ErrNoRows {
+		t.Fatalf("previous of the first version: got err=%v, want sql.ErrNoRows", err)
+	}
+
+	if err := q.DeletePluginVersionsAfter(ctx, &db.DeletePluginVersionsAfterParams{PluginName: "hlf-api", ID: v1.ID}); err != nil {
+		t.Fatalf("DeletePluginVersionsAfter: %v", err)
+	}
+	versions, err := q.ListPluginVersions(ctx, "hlf-api")
+	if err != nil {
+		t.Fatalf("ListPluginVersions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].ID != v1.ID {
+		t.Fatalf("versions after rollback: got %d, want only %d (dropped %d and %d)", len(versions), v1.ID, v2.ID, v3.ID)
+	}
+
+	if err := q.DeletePlugin(ctx, "hlf-api"); err != nil {
+		t.Fatalf("DeletePlugin: %v", err)
+	}
+	versions, err = q.ListPluginVersions(ctx, "hlf-api")
+	if err != nil || len(versions) != 0 {
+		t.Fatalf("versions after plugin delete: got %d, err=%v", len(versions), err)
+	}
+}
@@ -0,0 +1,126 @@
+package db_test
+
+// DB-layer tests for the project_promotions queries introduced in migration
+// 0036. A promotion links a project commit to the chaincode definition it
+// was deployed as.
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func TestProjectPromotions(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+
+	project, err := q.CreateProject(ctx, &db.CreateProjectParams{Name: "promoted", Slug: "promoted-abc12"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	network, err := q.CreateNetwork(ctx, &db.CreateNetworkParams{Name: "prod", Platform: "fabric", Status: "running"})
+	if err != nil {
+		t.Fatalf("CreateNetwork: %v", err)
+	}
+
+	first, err := q.CreateProjectPromotion(ctx, &db.CreateProjectPromotionParams{
+		ProjectID:     project.ID,
+		CommitHash:    "aaaaaaaaaaaa",
+		NetworkID:     network.ID,
+		ChaincodeName: "promoted",
+		Version:       "aaaaaaaa",
+		DockerImage:   "localhost:5000/chaincode-promoted-abc12:aaaaaaaa",
+		Status:        "pending",
+	})
+	if err != nil {
+		t.Fatalf("CreateProjectPromotion: %v", err)
+	}
+	if first.DefinitionID.Valid || first.Sequence.Valid {
+		t.Fatalf("new promotion should have no definition, got %+v", first)
+	}
+
+	chaincode, err := q.CreateChaincode(ctx, &db.CreateChaincodeParams{Name: "promoted", NetworkID: network.ID})
+	if err != nil {
+		t.Fatalf("CreateChaincode: %v", err)
+	}
+	definition, err := q.CreateChaincodeDefinition(ctx, &db.CreateChaincodeDefinitionParams{
+		ChaincodeID: chaincode.ID,
+		Version:     first.Version,
+		Sequence:    2,
+		DockerImage: first.DockerImage,
+	})
+	if err != nil {
+		t.Fatalf("CreateChaincodeDefinition: %v", err)
+	}
+	if err := q.SetProjectPromotionDefinition(ctx, &db.SetProjectPromotionDefinitionParams{
+		ChaincodeID:  sql.NullInt64{Int64: chaincode.ID, Valid: true},
+		DefinitionID: sql.NullInt64{Int64: definition.ID, Valid: true},
+		Sequence:     sql.NullInt64{Int64: 2, Valid: true},
+		ID:           first.ID,
+	}); err != nil {
+		t.Fatalf("SetProjectPromotionDefinition: %v", err)
+	}
+	if err := q.UpdateProjectPromotionStatus(ctx, &db.UpdateProjectPromotionStatusParams{Status: "completed", ID: first.ID}); err != nil {
+		t.Fatalf("UpdateProjectPromotionStatus: %v", err)
+	}
+
+	second, err := q.CreateProjectPromotion(ctx, &db.CreateProjectPromotionParams{
+		ProjectID:     project.ID,
+		CommitHash:    "bbbbbbbbbbbb",
+		NetworkID:     network.ID,
+		ChaincodeName: "promoted",
+		Version:       "bbbbbbbb",
+		DockerImage:   "localhost:5000/chaincode-promoted-abc12:bbbbbbbb",
+		Status:        "installing",
+	})
+	if err != nil {
+		t.Fatalf("CreateProjectPromotion: %v", err)
+	}
+
+	// Promotions still running when the server stopped are failed on startup
+	if err := q.FailInterruptedProjectPromotions(ctx); err != nil {
+		t.Fatalf("FailInterruptedProjectPromotions: %v", err)
+	}
+	got, err := q.GetProjectPromotion(ctx, &db.GetProjectPromotionParams{ID: second.ID, ProjectID: project.ID})
+	if err != nil {
+		t.Fatalf("GetProjectPromotion: %v", err)
+	}
+	if got.Status != "failed" || !got.Error.Valid {
+		t.Fatalf("interrupted promotion: got status %q error %v, want failed with an error", got.Status, got.Error)
+	}
+
+	promotions, err := q.ListProjectPromotions(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("ListProjectPromotions: %v", err)
+	}
+	if len(promotions) != 2 || promotions[0].ID != second.ID {
+		t.Fatalf("ListProjectPromotions: got %d promotions, want 2 newest first", len(promotions))
+	}
+	completed := promotions[1]
+	if completed.Status != "completed" || completed.DefinitionID.Int64 != definition.ID || completed.Sequence.Int64 != 2 {
+		t.Fatalf("completed promotion: got %+v", completed)
+	}
+
+	if _, err := q.GetProjectPromotion(ctx, &db.GetProjectPromotionParams{ID: first.ID, ProjectID: project.ID + 1}); err != sql.ErrNoRows {
+		t.Fatalf("GetProjectPromotion of another project: got err=%v, want sql.ErrNoRows", err)
+	}
+
+	// Deleting the definition keeps the promotion history
+	if err := q.DeleteChaincodeDefinition(ctx, definition.ID); err != nil {
+		t.Fatalf("DeleteChaincodeDefinition: %v", err)
+	}
+	got, err = q.GetProjectPromotion(ctx, &db.GetProjectPromotionParams{ID: first.ID, ProjectID: project.ID})
+	if err != nil || got.DefinitionID.Valid {
+		t.Fatalf("promotion after definition delete: got %+v, err=%v", got, err)
+	}
+
+	if err := q.DeleteProject(ctx, project.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	promotions, err = q.ListProjectPromotions(ctx, project.ID)
+	if err != nil || len(promotions) != 0 {
+		t.Fatalf("promotions after project delete: got %d, err=%v", len(promotions), err)
+	}
+}
@@ -0,0 +1,98 @@
+package db_test
+
+// DB-layer tests for the project_test_runs queries introduced in migration
+// 0035. Runs are looked up by commit to compare results between versions.
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func createTestRun(t *testing.T, q *db.Queries, projectID int64, commit, status string) *db.ProjectTestRun {
+	t.Helper()
+	run, err := q.CreateProjectTestRun(context.Background(), &db.CreateProjectTestRunParams{
+		ProjectID:  projectID,
+		CommitHash: commit,
+		Status:     status,
+		Total:      2,
+		Passed:     1,
+		Failed:     1,
+		Coverage:   sql.NullFloat64{Float64: 72.5, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateProjectTestRun %s: %v", commit, err)
+	}
+	return run
+}
+
+func TestProjectTestRuns(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+
+	project, err := q.CreateProject(ctx, &db.CreateProjectParams{Name: "tested", Slug: "tested-abc12"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	first := createTestRun(t, q, project.ID, "aaa", "passed")
+	second := createTestRun(t, q, project.ID, "bbb", "failed")
+	third := createTestRun(t, q, project.ID, "bbb", "passed")
+
+	for _, tc := range []struct{ suite, name, status string }{
+		{"pkg/assets", "TestCreateAsset", "passed"},
+		{"pkg/assets", "TestDeleteAsset", "failed"},
+	} {
+		if err := q.CreateProjectTestResult(ctx, &db.CreateProjectTestResultParams{
+			RunID:  third.ID,
+			Suite:  tc.suite,
+			Name:   tc.name,
+			Status: tc.status,
+		}); err != nil {
+			t.Fatalf("CreateProjectTestResult %s: %v", tc.name, err)
+		}
+	}
+
+	latest, err := q.GetLatestProjectTestRunForCommit(ctx, &db.GetLatestProjectTestRunForCommitParams{ProjectID: project.ID, CommitHash: "bbb"})
+	if err != nil || latest.ID != third.ID {
+		t.Fatalf("GetLatestProjectTestRunForCommit: got %+v, err=%v, want run %d", latest, err, third.ID)
+	}
+
+	// The previous run skips the runs of the same commit
+	previous, err := q.GetPreviousProjectTestRun(ctx, &db.GetPreviousProjectTestRunParams{ProjectID: project.ID, CommitHash: "bbb", ID: third.ID})
+	if err != nil || previous.ID != first.ID {
+		t.Fatalf("GetPreviousProjectTestRun: got %+v, err=%v, want run %d", previous, err, first.ID)
+	}
+	if _, err := q.GetPreviousProjectTestRun(ctx, &db.GetPreviousProjectTestRunParams{ProjectID: project.ID, CommitHash: "aaa", ID: first.ID}); err != sql.ErrNoRows {
+		t.Errorf("GetPreviousProjectTestRun of the first run: expected no rows, got err=%v", err)
+	}
+
+	runs, err := q.ListProjectTestRuns(ctx, &db.ListProjectTestRunsParams{ProjectID: project.ID, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListProjectTestRuns: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != third.ID || runs[1].ID != second.ID {
+		t.Errorf("ListProjectTestRuns: expected runs %d and %d, newest first", third.ID, second.ID)
+	}
+	if !runs[0].Coverage.Valid || runs[0].Coverage.Float64 != 72.5 {
+		t.Errorf("expected coverage 72.5, got %+v", runs[0].Coverage)
+	}
+
+	results, err := q.ListProjectTestResults(ctx, third.ID)
+	if err != nil {
+		t.Fatalf("ListProjectTestResults: %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "TestCreateAsset" || results[1].Status != "failed" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	// Runs and their results are removed with the project
+	if err := q.DeleteProject(ctx, project.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	if _, err := q.GetProjectTestRun(ctx, &db.GetProjectTestRunParams{ID: third.ID, ProjectID: project.ID}); err != sql.ErrNoRows {
+		t.Errorf("expected cascade delete, got err=%v", err)
+	}
+}
@@ -13,31 +13,49 @@ import (
 type Querier interface {
 	AddChaincodeDefinitionEvent(ctx context.Context, arg *AddChaincodeDefinitionEventParams) error
 	AddRevokedCertificate(ctx context.Context, arg *AddRevokedCertificateParams) error
+	AddTestnetResource(ctx context.Context, arg *AddTestnetResourceParams) error
 	CheckNetworkNodeExists(ctx context.Context, arg *CheckNetworkNodeExistsParams) (int64, error)
+	CountActiveConsensusMigrations(ctx context.Context, networkID int64) (int64, error)
+	CountActiveUpgradePlans(ctx context.Context, networkID int64) (int64, error)
 	CountAuditLogs(ctx context.Context, arg *CountAuditLogsParams) (int64, error)
 	CountBackupsBySchedule(ctx context.Context, scheduleID sql.NullInt64) (int64, error)
 	CountBackupsByTarget(ctx context.Context, targetID int64) (int64, error)
 	CountFabricOrganizations(ctx context.Context) (int64, error)
+	CountIndexedBlocks(ctx context.Context, networkID int64) (int64, error)
 	CountNetworks(ctx context.Context) (int64, error)
 	CountNodeEvents(ctx context.Context, nodeID int64) (int64, error)
 	CountNodeGroups(ctx context.Context) (int64, error)
 	CountNodeGroupsByStatus(ctx context.Context, status string) (int64, error)
+	CountNodeHostsByHost(ctx context.Context, hostID int64) (int64, error)
 	CountNodes(ctx context.Context) (int64, error)
 	CountNodesByPlatform(ctx context.Context, platform string) (int64, error)
+	CountSearchIndexedTransactions(ctx context.Context, arg *CountSearchIndexedTransactionsParams) (int64, error)
 	CountServiceBackupsByService(ctx context.Context, serviceID int64) (int64, error)
 	CountServiceEventsByService(ctx context.Context, serviceID int64) (int64, error)
 	CountServices(ctx context.Context) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateAlertmanagerConfig(ctx context.Context, arg *CreateAlertmanagerConfigParams) (*AlertmanagerConfig, error)
 	CreateAuditLog(ctx context.Context, arg *CreateAuditLogParams) (*AuditLog, error)
 	CreateBackup(ctx context.Context, arg *CreateBackupParams) (*Backup, error)
 	CreateBackupSchedule(ctx context.Context, arg *CreateBackupScheduleParams) (*BackupSchedule, error)
 	CreateBackupTarget(ctx context.Context, arg *CreateBackupTargetParams) (*BackupTarget, error)
+	CreateBenchmarkRun(ctx context.Context, arg *CreateBenchmarkRunParams) (*BenchmarkRun, error)
+	CreateBesuContract(ctx context.Context, arg *CreateBesuContractParams) (*BesuContract, error)
+	CreateBesuContractUpgrade(ctx context.Context, arg *CreateBesuContractUpgradeParams) (*BesuContractUpgrade, error)
+	CreateBlockIndexer(ctx context.Context, arg *CreateBlockIndexerParams) (*BlockIndexer, error)
 	CreateChaincode(ctx context.Context, arg *CreateChaincodeParams) (*FabricChaincode, error)
 	CreateChaincodeDefinition(ctx context.Context, arg *CreateChaincodeDefinitionParams) (*FabricChaincodeDefinition, error)
+	CreateConsensusMigration(ctx context.Context, arg *CreateConsensusMigrationParams) (*ConsensusMigration, error)
+	CreateConsensusMigrationStep(ctx context.Context, arg *CreateConsensusMigrationStepParams) (*ConsensusMigrationStep, error)
 	CreateConversation(ctx context.Context, projectID int64) (*Conversation, error)
 	CreateFabricChaincode(ctx context.Context, arg *CreateFabricChaincodeParams) (*CreateFabricChaincodeRow, error)
 	CreateFabricOrganization(ctx context.Context, arg *CreateFabricOrganizationParams) (*FabricOrganization, error)
 	CreateFabricXNamespace(ctx context.Context, arg *CreateFabricXNamespaceParams) (*FabricxNamespace, error)
+	CreateGrafanaConfig(ctx context.Context, arg *CreateGrafanaConfigParams) (*GrafanaConfig, error)
+	CreateHost(ctx context.Context, arg *CreateHostParams) (*Host, error)
+	CreateIndexedBlock(ctx context.Context, arg *CreateIndexedBlockParams) error
+	CreateIndexedTransaction(ctx context.Context, arg *CreateIndexedTransactionParams) (*IndexedTransaction, error)
+	CreateIndexedTransactionKey(ctx context.Context, arg *CreateIndexedTransactionKeyParams) error
 	CreateKey(ctx context.Context, arg *CreateKeyParams) (*Key, error)
 	CreateKeyProvider(ctx context.Context, arg *CreateKeyProviderParams) (*KeyProvider, error)
 	CreateNetwork(ctx context.Context, arg *CreateNetworkParams) (*Network, error)
@@ -49,44 +67,72 @@ type Querier interface {
 	CreateNodeGroup(ctx context.Context, arg *CreateNodeGroupParams) (*NodeGroup, error)
 	CreateNotificationProvider(ctx context.Context, arg *CreateNotificationProviderParams) (*NotificationProvider, error)
 	CreatePlugin(ctx context.Context, arg *CreatePluginParams) (*Plugin, error)
+	CreatePluginVersion(ctx context.Context, arg *CreatePluginVersionParams) (*PluginVersion, error)
 	CreateProject(ctx context.Context, arg *CreateProjectParams) (*ChaincodeProject, error)
+	CreateProjectPromotion(ctx context.Context, arg *CreateProjectPromotionParams) (*ProjectPromotion, error)
+	CreateProjectTestResult(ctx context.Context, arg *CreateProjectTestResultParams) error
+	CreateProjectTestRun(ctx context.Context, arg *CreateProjectTestRunParams) (*ProjectTestRun, error)
+	CreatePrometheusAlertRule(ctx context.Context, arg *CreatePrometheusAlertRuleParams) (*PrometheusAlertRule, error)
 	CreatePrometheusConfig(ctx context.Context, arg *CreatePrometheusConfigParams) (*PrometheusConfig, error)
 	CreateService(ctx context.Context, arg *CreateServiceParams) (*Service, error)
 	CreateServiceBackup(ctx context.Context, arg *CreateServiceBackupParams) (*ServiceBackup, error)
 	CreateServiceEvent(ctx context.Context, arg *CreateServiceEventParams) (*ServiceEvent, error)
 	CreateSession(ctx context.Context, arg *CreateSessionParams) (*Session, error)
 	CreateSetting(ctx context.Context, config string) (*Setting, error)
+	CreateTestnet(ctx context.Context, arg *CreateTestnetParams) (*Testnet, error)
+	CreateUpgradePlan(ctx context.Context, arg *CreateUpgradePlanParams) (*UpgradePlan, error)
+	CreateUpgradePlanStep(ctx context.Context, arg *CreateUpgradePlanStepParams) (*UpgradePlanStep, error)
 	CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error)
+	DeleteAlertmanagerConfig(ctx context.Context) error
 	DeleteBackup(ctx context.Context, id int64) error
 	DeleteBackupSchedule(ctx context.Context, id int64) error
 	DeleteBackupTarget(ctx context.Context, id int64) error
 	DeleteBackupsBySchedule(ctx context.Context, scheduleID sql.NullInt64) error
 	DeleteBackupsByTarget(ctx context.Context, targetID int64) error
+	DeleteBenchmarkRun(ctx context.Context, id int64) error
+	DeleteBesuContract(ctx context.Context, id int64) error
+	DeleteBlockIndexer(ctx context.Context, networkID int64) error
 	DeleteChaincode(ctx context.Context, id int64) error
 	DeleteChaincodeDefinition(ctx context.Context, id int64) error
 	DeleteChaincodesByNetwork(ctx context.Context, networkID int64) error
 	DeleteExpiredSessions(ctx context.Context) error
 	DeleteFabricOrganization(ctx context.Context, id int64) error
 	DeleteFabricXNamespace(ctx context.Context, id int64) error
+	DeleteGrafanaConfig(ctx context.Context) error
+	DeleteHost(ctx context.Context, id int64) error
+	DeleteIndexedBlock(ctx context.Context, arg *DeleteIndexedBlockParams) error
+	DeleteIndexedBlocksByNetwork(ctx context.Context, networkID int64) error
+	DeleteIndexedTransactionKeysByNetwork(ctx context.Context, networkID int64) error
+	DeleteIndexedTransactionsByBlock(ctx context.Context, arg *DeleteIndexedTransactionsByBlockParams) error
+	DeleteIndexedTransactionsByNetwork(ctx context.Context, networkID int64) error
 	DeleteKey(ctx context.Context, id int64) error
 	DeleteKeyProvider(ctx context.Context, id int64) error
 	DeleteNetwork(ctx context.Context, id int64) error
 	DeleteNetworkNode(ctx context.Context, arg *DeleteNetworkNodeParams) error
 	DeleteNode(ctx context.Context, id int64) error
 	DeleteNodeGroup(ctx context.Context, id int64) error
+	DeleteNodeHost(ctx context.Context, nodeID int64) error
 	DeleteNotificationProvider(ctx context.Context, id int64) error
 	DeleteOldBackups(ctx context.Context, arg *DeleteOldBackupsParams) error
 	DeletePlugin(ctx context.Context, name string) error
+	DeletePluginVersionsAfter(ctx context.Context, arg *DeletePluginVersionsAfterParams) error
 	DeleteProject(ctx context.Context, id int64) error
+	DeletePrometheusAlertRule(ctx context.Context, id int64) error
 	DeleteRevokedCertificate(ctx context.Context, arg *DeleteRevokedCertificateParams) error
 	DeleteService(ctx context.Context, id int64) error
 	DeleteServiceBackupsOlderThan(ctx context.Context, arg *DeleteServiceBackupsOlderThanParams) error
 	DeleteSession(ctx context.Context, token string) error
 	DeleteSetting(ctx context.Context, id int64) error
+	DeleteTestnet(ctx context.Context, id int64) error
+	DeleteTestnetResource(ctx context.Context, arg *DeleteTestnetResourceParams) error
 	DeleteUser(ctx context.Context, id int64) error
 	DeleteUserSessions(ctx context.Context, userID int64) error
 	DisableBackupSchedule(ctx context.Context, id int64) (*BackupSchedule, error)
 	EnableBackupSchedule(ctx context.Context, id int64) (*BackupSchedule, error)
+	FailInterruptedProjectPromotions(ctx context.Context) error
+	FailRunningBenchmarkRuns(ctx context.Context, error sql.NullString) error
+	FinishBenchmarkRun(ctx context.Context, arg *FinishBenchmarkRunParams) error
+	GetAlertmanagerConfig(ctx context.Context) (*AlertmanagerConfig, error)
 	GetAllKeys(ctx context.Context, arg *GetAllKeysParams) ([]*GetAllKeysRow, error)
 	GetAllNodes(ctx context.Context) ([]*Node, error)
 	GetAuditLog(ctx context.Context, id int64) (*AuditLog, error)
@@ -96,9 +142,15 @@ type Querier interface {
 	GetBackupsByDateRange(ctx context.Context, arg *GetBackupsByDateRangeParams) ([]*Backup, error)
 	GetBackupsByScheduleAndStatus(ctx context.Context, arg *GetBackupsByScheduleAndStatusParams) ([]*Backup, error)
 	GetBackupsByStatus(ctx context.Context, status string) ([]*Backup, error)
+	GetBenchmarkRun(ctx context.Context, id int64) (*BenchmarkRun, error)
+	GetBesuContract(ctx context.Context, id int64) (*BesuContract, error)
+	GetBesuContractByAddress(ctx context.Context, arg *GetBesuContractByAddressParams) (*BesuContract, error)
+	GetBlockIndexer(ctx context.Context, networkID int64) (*BlockIndexer, error)
 	GetChaincode(ctx context.Context, id int64) (*GetChaincodeRow, error)
 	GetChaincodeDefinition(ctx context.Context, id int64) (*FabricChaincodeDefinition, error)
+	GetConsensusMigration(ctx context.Context, id int64) (*ConsensusMigration, error)
 	GetConversation(ctx context.Context, id int64) (*Conversation, error)
+	GetCurrentPluginVersion(ctx context.Context, pluginName string) (*PluginVersion, error)
 	GetDefaultConversationForProject(ctx context.Context, projectID int64) (*Conversation, error)
 	GetDefaultNotificationProvider(ctx context.Context, type_ string) (*NotificationProvider, error)
 	GetDefaultNotificationProviderForType(ctx context.Context, notificationType interface{}) (*NotificationProvider, error)
@@ -113,6 +165,9 @@ type Querier interface {
 	GetFabricOrganizationWithKeys(ctx context.Context, id int64) (*GetFabricOrganizationWithKeysRow, error)
 	GetFabricXNamespace(ctx context.Context, id int64) (*FabricxNamespace, error)
 	GetFabricXNamespaceByName(ctx context.Context, arg *GetFabricXNamespaceByNameParams) (*FabricxNamespace, error)
+	GetGrafanaConfig(ctx context.Context) (*GrafanaConfig, error)
+	GetHost(ctx context.Context, id int64) (*Host, error)
+	GetIndexedTransactionByTxID(ctx context.Context, arg *GetIndexedTransactionByTxIDParams) (*IndexedTransaction, error)
 	GetKey(ctx context.Context, id int64) (*GetKeyRow, error)
 	GetKeyByEthereumAddress(ctx context.Context, ethereumAddress sql.NullString) (*GetKeyByEthereumAddressRow, error)
 	GetKeyByID(ctx context.Context, id int64) (*GetKeyByIDRow, error)
@@ -123,6 +178,8 @@ type Querier interface {
 	GetKeysByFilter(ctx context.Context, arg *GetKeysByFilterParams) ([]*GetKeysByFilterRow, error)
 	GetKeysCount(ctx context.Context) (int64, error)
 	GetLatestNodeEvent(ctx context.Context, nodeID int64) (*NodeEvent, error)
+	GetLatestProjectTestRun(ctx context.Context, projectID int64) (*ProjectTestRun, error)
+	GetLatestProjectTestRunForCommit(ctx context.Context, arg *GetLatestProjectTestRunForCommitParams) (*ProjectTestRun, error)
 	GetNetwork(ctx context.Context, id int64) (*Network, error)
 	GetNetworkByName(ctx context.Context, name string) (*Network, error)
 	GetNetworkByNetworkId(ctx context.Context, networkID sql.NullString) (*Network, error)
@@ -135,14 +192,20 @@ type Querier interface {
 	GetNodeGroup(ctx context.Context, id int64) (*NodeGroup, error)
 	GetNodeGroupByName(ctx context.Context, name string) (*NodeGroup, error)
 	GetNodeGroupPostgresServiceID(ctx context.Context, id int64) (sql.NullInt64, error)
+	GetNodeHost(ctx context.Context, nodeID int64) (*NodeHost, error)
 	GetNotificationProvider(ctx context.Context, id int64) (*NotificationProvider, error)
 	GetOldestBackupByTarget(ctx context.Context, targetID int64) (*Backup, error)
 	GetOrdererPorts(ctx context.Context) ([]*GetOrdererPortsRow, error)
 	GetOrganizationCRLInfo(ctx context.Context, id int64) (*GetOrganizationCRLInfoRow, error)
 	GetPeerPorts(ctx context.Context) ([]*GetPeerPortsRow, error)
 	GetPlugin(ctx context.Context, name string) (*Plugin, error)
+	GetPreviousDeployedPluginVersion(ctx context.Context, arg *GetPreviousDeployedPluginVersionParams) (*PluginVersion, error)
+	GetPreviousProjectTestRun(ctx context.Context, arg *GetPreviousProjectTestRunParams) (*ProjectTestRun, error)
 	GetProject(ctx context.Context, id int64) (*GetProjectRow, error)
 	GetProjectBySlug(ctx context.Context, slug string) (*GetProjectBySlugRow, error)
+	GetProjectPromotion(ctx context.Context, arg *GetProjectPromotionParams) (*ProjectPromotion, error)
+	GetProjectTestRun(ctx context.Context, arg *GetProjectTestRunParams) (*ProjectTestRun, error)
+	GetPrometheusAlertRule(ctx context.Context, id int64) (*PrometheusAlertRule, error)
 	GetPrometheusConfig(ctx context.Context) (*GetPrometheusConfigRow, error)
 	GetProvidersByNotificationType(ctx context.Context, arg *GetProvidersByNotificationTypeParams) ([]*NotificationProvider, error)
 	GetRecentCompletedBackups(ctx context.Context) ([]*Backup, error)
@@ -156,6 +219,9 @@ type Querier interface {
 	GetSessionBySessionID(ctx context.Context, sessionID string) (*Session, error)
 	GetSessionByToken(ctx context.Context, token string) (*Session, error)
 	GetSetting(ctx context.Context, id int64) (*Setting, error)
+	GetTestnet(ctx context.Context, id int64) (*Testnet, error)
+	GetTestnetByName(ctx context.Context, name string) (*Testnet, error)
+	GetUpgradePlan(ctx context.Context, id int64) (*UpgradePlan, error)
 	GetUser(ctx context.Context, id int64) (*User, error)
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	InsertMessage(ctx context.Context, arg *InsertMessageParams) (*Message, error)
@@ -166,14 +232,27 @@ type Querier interface {
 	ListBackups(ctx context.Context, arg *ListBackupsParams) ([]*Backup, error)
 	ListBackupsBySchedule(ctx context.Context, scheduleID sql.NullInt64) ([]*Backup, error)
 	ListBackupsByTarget(ctx context.Context, targetID int64) ([]*Backup, error)
+	ListBenchmarkRuns(ctx context.Context) ([]*BenchmarkRun, error)
+	ListBesuContractUpgrades(ctx context.Context, contractID int64) ([]*BesuContractUpgrade, error)
+	ListBesuContracts(ctx context.Context) ([]*BesuContract, error)
+	ListBesuContractsByNetwork(ctx context.Context, networkID int64) ([]*BesuContract, error)
+	ListBesuContractsByProject(ctx context.Context, projectID sql.NullInt64) ([]*BesuContract, error)
+	ListBlockIndexers(ctx context.Context) ([]*BlockIndexer, error)
 	ListChaincodeDefinitionEvents(ctx context.Context, definitionID int64) ([]*FabricChaincodeDefinitionEvent, error)
 	ListChaincodeDefinitions(ctx context.Context, chaincodeID int64) ([]*FabricChaincodeDefinition, error)
 	ListChaincodes(ctx context.Context) ([]*FabricChaincode, error)
+	ListConsensusMigrationSteps(ctx context.Context, migrationID int64) ([]*ConsensusMigrationStep, error)
+	ListConsensusMigrationsByNetwork(ctx context.Context, networkID int64) ([]*ConsensusMigration, error)
+	ListConsensusMigrationsByStatus(ctx context.Context, status string) ([]*ConsensusMigration, error)
 	ListConversationsForProject(ctx context.Context, projectID int64) ([]*Conversation, error)
+	ListEnabledBlockIndexers(ctx context.Context) ([]*BlockIndexer, error)
+	ListEnabledPrometheusAlertRules(ctx context.Context) ([]*PrometheusAlertRule, error)
 	ListFabricChaincodes(ctx context.Context) ([]*FabricChaincode, error)
 	ListFabricOrganizations(ctx context.Context) ([]*FabricOrganization, error)
 	ListFabricOrganizationsWithKeys(ctx context.Context, arg *ListFabricOrganizationsWithKeysParams) ([]*ListFabricOrganizationsWithKeysRow, error)
 	ListFabricXNamespacesByNetwork(ctx context.Context, networkID int64) ([]*FabricxNamespace, error)
+	ListHosts(ctx context.Context) ([]*Host, error)
+	ListIndexedTransactionKeys(ctx context.Context, transactionID int64) ([]*IndexedTransactionKey, error)
 	ListKeyProviders(ctx context.Context) ([]*KeyProvider, error)
 	ListKeys(ctx context.Context, arg *ListKeysParams) ([]*ListKeysRow, error)
 	ListMessagesForConversation(ctx context.Context, conversationID int64) ([]*Message, error)
@@ -186,28 +265,47 @@ type Querier interface {
 	ListNodeGroups(ctx context.Context, arg *ListNodeGroupsParams) ([]*NodeGroup, error)
 	ListNodeGroupsByPlatform(ctx context.Context, arg *ListNodeGroupsByPlatformParams) ([]*NodeGroup, error)
 	ListNodeGroupsByPostgresServiceID(ctx context.Context, postgresServiceID sql.NullInt64) ([]*NodeGroup, error)
+	ListNodeHostsByHost(ctx context.Context, hostID int64) ([]*NodeHost, error)
 	ListNodes(ctx context.Context, arg *ListNodesParams) ([]*Node, error)
 	ListNodesByGroup(ctx context.Context, nodeGroupID sql.NullInt64) ([]*Node, error)
 	ListNodesByNetwork(ctx context.Context, arg *ListNodesByNetworkParams) ([]*Node, error)
 	ListNodesByPlatform(ctx context.Context, arg *ListNodesByPlatformParams) ([]*Node, error)
 	ListNotificationProviders(ctx context.Context) ([]*NotificationProvider, error)
 	ListPeerStatuses(ctx context.Context, definitionID int64) ([]*FabricChaincodeDefinitionPeerStatus, error)
+	ListPluginVersions(ctx context.Context, pluginName string) ([]*PluginVersion, error)
 	ListPlugins(ctx context.Context) ([]*Plugin, error)
+	ListProjectPromotions(ctx context.Context, projectID int64) ([]*ProjectPromotion, error)
+	ListProjectTestResults(ctx context.Context, runID int64) ([]*ProjectTestResult, error)
+	ListProjectTestRuns(ctx context.Context, arg *ListProjectTestRunsParams) ([]*ProjectTestRun, error)
 	ListProjects(ctx context.Context) ([]*ListProjectsRow, error)
+	ListPrometheusAlertRules(ctx context.Context) ([]*PrometheusAlertRule, error)
 	ListServiceBackupsByService(ctx context.Context, arg *ListServiceBackupsByServiceParams) ([]*ServiceBackup, error)
 	ListServiceEventsByService(ctx context.Context, arg *ListServiceEventsByServiceParams) ([]*ServiceEvent, error)
 	ListServices(ctx context.Context, arg *ListServicesParams) ([]*Service, error)
 	ListServicesByNodeGroup(ctx context.Context, nodeGroupID sql.NullInt64) ([]*Service, error)
 	ListServicesByType(ctx context.Context, serviceType string) ([]*Service, error)
 	ListSettings(ctx context.Context) ([]*Setting, error)
+	ListTestnetResources(ctx context.Context, testnetID int64) ([]*TestnetResource, error)
+	ListTestnets(ctx context.Context) ([]*Testnet, error)
 	ListToolCallsForConversation(ctx context.Context, conversationID int64) ([]*ToolCall, error)
 	ListToolCallsForMessage(ctx context.Context, messageID int64) ([]*ToolCall, error)
+	ListUpgradePlanSteps(ctx context.Context, planID int64) ([]*UpgradePlanStep, error)
+	ListUpgradePlansByNetwork(ctx context.Context, networkID int64) ([]*UpgradePlan, error)
+	ListUpgradePlansByStatus(ctx context.Context, status string) ([]*UpgradePlan, error)
 	ListUsers(ctx context.Context) ([]*User, error)
 	MarkBackupNotified(ctx context.Context, id int64) error
+	MarkPluginVersionDeployed(ctx context.Context, arg *MarkPluginVersionDeployedParams) error
 	ResetPrometheusConfig(ctx context.Context) (*PrometheusConfig, error)
+	SearchIndexedTransactions(ctx context.Context, arg *SearchIndexedTransactionsParams) ([]*IndexedTransaction, error)
+	SetBlockIndexerEnabled(ctx context.Context, arg *SetBlockIndexerEnabledParams) (*BlockIndexer, error)
+	SetNodeHost(ctx context.Context, arg *SetNodeHostParams) error
 	SetPeerStatus(ctx context.Context, arg *SetPeerStatusParams) (*FabricChaincodeDefinitionPeerStatus, error)
+	SetProjectPromotionDefinition(ctx context.Context, arg *SetProjectPromotionDefinitionParams) error
+	StartConsensusMigration(ctx context.Context, id int64) error
+	StartUpgradePlan(ctx context.Context, id int64) error
 	UnsetDefaultNotificationProvider(ctx context.Context, type_ string) error
 	UnsetDefaultProvider(ctx context.Context) error
+	UpdateAlertmanagerConfig(ctx context.Context, arg *UpdateAlertmanagerConfigParams) (*AlertmanagerConfig, error)
 	UpdateBackupCompleted(ctx context.Context, arg *UpdateBackupCompletedParams) (*Backup, error)
 	UpdateBackupFailed(ctx context.Context, arg *UpdateBackupFailedParams) (*Backup, error)
 	UpdateBackupSchedule(ctx context.Context, arg *UpdateBackupScheduleParams) (*BackupSchedule, error)
@@ -215,17 +313,27 @@ type Querier interface {
 	UpdateBackupSize(ctx context.Context, arg *UpdateBackupSizeParams) (*Backup, error)
 	UpdateBackupStatus(ctx context.Context, arg *UpdateBackupStatusParams) (*Backup, error)
 	UpdateBackupTarget(ctx context.Context, arg *UpdateBackupTargetParams) (*BackupTarget, error)
+	UpdateBesuContract(ctx context.Context, arg *UpdateBesuContractParams) (*BesuContract, error)
+	UpdateBesuContractImplementation(ctx context.Context, arg *UpdateBesuContractImplementationParams) error
+	UpdateBlockIndexerError(ctx context.Context, arg *UpdateBlockIndexerErrorParams) error
+	UpdateBlockIndexerProgress(ctx context.Context, arg *UpdateBlockIndexerProgressParams) error
 	UpdateChaincode(ctx context.Context, arg *UpdateChaincodeParams) (*FabricChaincode, error)
 	UpdateChaincodeDefinition(ctx context.Context, arg *UpdateChaincodeDefinitionParams) (*FabricChaincodeDefinition, error)
+	UpdateConsensusMigrationStatus(ctx context.Context, arg *UpdateConsensusMigrationStatusParams) error
+	UpdateConsensusMigrationStep(ctx context.Context, arg *UpdateConsensusMigrationStepParams) error
 	UpdateDeploymentConfig(ctx context.Context, arg *UpdateDeploymentConfigParams) (*Node, error)
 	UpdateDeploymentMetadata(ctx context.Context, arg *UpdateDeploymentMetadataParams) error
 	UpdateDeploymentStatus(ctx context.Context, arg *UpdateDeploymentStatusParams) error
 	UpdateFabricChaincodeDefinitionAddress(ctx context.Context, arg *UpdateFabricChaincodeDefinitionAddressParams) error
 	UpdateFabricOrganization(ctx context.Context, arg *UpdateFabricOrganizationParams) (*FabricOrganization, error)
 	UpdateFabricXNamespaceStatus(ctx context.Context, arg *UpdateFabricXNamespaceStatusParams) (*FabricxNamespace, error)
+	UpdateHost(ctx context.Context, arg *UpdateHostParams) (*Host, error)
+	UpdateHostKey(ctx context.Context, arg *UpdateHostKeyParams) error
+	UpdateHostStatus(ctx context.Context, arg *UpdateHostStatusParams) error
 	UpdateKey(ctx context.Context, arg *UpdateKeyParams) (*Key, error)
 	UpdateKeyProvider(ctx context.Context, arg *UpdateKeyProviderParams) (*KeyProvider, error)
 	UpdateMessageEnhancedContent(ctx context.Context, arg *UpdateMessageEnhancedContentParams) (*Message, error)
+	UpdateNetworkConfig(ctx context.Context, arg *UpdateNetworkConfigParams) error
 	UpdateNetworkCurrentConfigBlock(ctx context.Context, arg *UpdateNetworkCurrentConfigBlockParams) error
 	UpdateNetworkGenesisBlock(ctx context.Context, arg *UpdateNetworkGenesisBlockParams) (*Network, error)
 	UpdateNetworkGenesisBlockWithTracking(ctx context.Context, arg *UpdateNetworkGenesisBlockWithTrackingParams) (*Network, error)
@@ -248,6 +356,8 @@ type Querier interface {
 	UpdatePlugin(ctx context.Context, arg *UpdatePluginParams) (*Plugin, error)
 	UpdateProjectContainerInfo(ctx context.Context, arg *UpdateProjectContainerInfoParams) error
 	UpdateProjectEndorsementPolicy(ctx context.Context, arg *UpdateProjectEndorsementPolicyParams) (*ChaincodeProject, error)
+	UpdateProjectPromotionStatus(ctx context.Context, arg *UpdateProjectPromotionStatusParams) error
+	UpdatePrometheusAlertRule(ctx context.Context, arg *UpdatePrometheusAlertRuleParams) (*PrometheusAlertRule, error)
 	UpdatePrometheusConfig(ctx context.Context, arg *UpdatePrometheusConfigParams) (*PrometheusConfig, error)
 	UpdateProviderTestResults(ctx context.Context, arg *UpdateProviderTestResultsParams) (*NotificationProvider, error)
 	UpdateService(ctx context.Context, arg *UpdateServiceParams) (*Service, error)
@@ -256,6 +366,9 @@ type Querier interface {
 	UpdateServiceStatus(ctx context.Context, arg *UpdateServiceStatusParams) (*Service, error)
 	UpdateServiceStatusWithError(ctx context.Context, arg *UpdateServiceStatusWithErrorParams) (*Service, error)
 	UpdateSetting(ctx context.Context, arg *UpdateSettingParams) (*Setting, error)
+	UpdateTestnetStatus(ctx context.Context, arg *UpdateTestnetStatusParams) error
+	UpdateUpgradePlanStatus(ctx context.Context, arg *UpdateUpgradePlanStatusParams) error
+	UpdateUpgradePlanStep(ctx context.Context, arg *UpdateUpgradePlanStepParams) error
 	UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error)
 	UpdateUserLastLogin(ctx context.Context, id int64) (*User, error)
 	UpdateUserPassword(ctx context.Context, arg *UpdateUserPasswordParams) (*User, error)
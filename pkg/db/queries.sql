@@ -1040,6 +1040,47 @@ SELECT deployment_status
 FROM plugins
 WHERE name = ?; 
 
+-- name: CreatePluginVersion :one
+INSERT INTO plugin_versions (
+  plugin_name,
+  version,
+  source,
+  api_version,
+  kind,
+  metadata,
+  spec,
+  manifest,
+  signature
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?
+) RETURNING *;
+
+-- name: GetCurrentPluginVersion :one
+SELECT * FROM plugin_versions
+WHERE plugin_name = ?
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetPreviousDeployedPluginVersion :one
+SELECT * FROM plugin_versions
+WHERE plugin_name = ? AND id < ? AND deployed_at IS NOT NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListPluginVersions :many
+SELECT * FROM plugin_versions
+WHERE plugin_name = ?
+ORDER BY id DESC;
+
+-- name: MarkPluginVersionDeployed :exec
+UPDATE plugin_versions
+SET parameters = ?, deployed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeletePluginVersionsAfter :exec
+DELETE FROM plugin_versions
+WHERE plugin_name = ? AND id > ?;
+
 
 -- name: GetSessionBySessionID :one
 SELECT * FROM sessions
@@ -1541,3 +1582,563 @@ LIMIT ? OFFSET ?;
 
 -- name: CountServiceEventsByService :one
 SELECT COUNT(*) FROM service_events WHERE service_id = ?;
+
+-- name: CreatePrometheusAlertRule :one
+INSERT INTO prometheus_alert_rules (
+    group_name,
+    name,
+    expr,
+    for_duration,
+    severity,
+    summary,
+    description,
+    labels,
+    enabled
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetPrometheusAlertRule :one
+SELECT * FROM prometheus_alert_rules WHERE id = ? LIMIT 1;
+
+-- name: ListPrometheusAlertRules :many
+SELECT * FROM prometheus_alert_rules
+ORDER BY group_name ASC, name ASC;
+
+-- name: ListEnabledPrometheusAlertRules :many
+SELECT * FROM prometheus_alert_rules
+WHERE enabled = true
+ORDER BY group_name ASC, name ASC;
+
+-- name: UpdatePrometheusAlertRule :one
+UPDATE prometheus_alert_rules
+SET group_name = ?,
+    name = ?,
+    expr = ?,
+    for_duration = ?,
+    severity = ?,
+    summary = ?,
+    description = ?,
+    labels = ?,
+    enabled = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeletePrometheusAlertRule :exec
+DELETE FROM prometheus_alert_rules WHERE id = ?;
+
+-- name: GetAlertmanagerConfig :one
+SELECT * FROM alertmanager_config LIMIT 1;
+
+-- name: CreateAlertmanagerConfig :one
+INSERT INTO alertmanager_config (
+    alertmanager_port,
+    alertmanager_version,
+    deployment_mode,
+    network_mode,
+    webhook_url,
+    webhook_token,
+    default_rules_enabled
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateAlertmanagerConfig :one
+UPDATE alertmanager_config
+SET alertmanager_port = ?,
+    alertmanager_version = ?,
+    deployment_mode = ?,
+    network_mode = ?,
+    webhook_url = ?,
+    default_rules_enabled = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteAlertmanagerConfig :exec
+DELETE FROM alertmanager_config;
+
+-- name: GetGrafanaConfig :one
+SELECT * FROM grafana_config LIMIT 1;
+
+-- name: CreateGrafanaConfig :one
+INSERT INTO grafana_config (
+    grafana_port,
+    grafana_version,
+    deployment_mode,
+    network_mode,
+    admin_user,
+    admin_password
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: DeleteGrafanaConfig :exec
+DELETE FROM grafana_config;
+
+-- name: GetBlockIndexer :one
+SELECT * FROM block_indexers WHERE network_id = ? LIMIT 1;
+
+-- name: ListBlockIndexers :many
+SELECT * FROM block_indexers ORDER BY network_id;
+
+-- name: ListEnabledBlockIndexers :many
+SELECT * FROM block_indexers WHERE enabled = true ORDER BY network_id;
+
+-- name: CreateBlockIndexer :one
+INSERT INTO block_indexers (
+    network_id,
+    next_block
+) VALUES (
+    ?, ?
+)
+RETURNING *;
+
+-- name: SetBlockIndexerEnabled :one
+UPDATE block_indexers
+SET enabled = ?,
+    last_error = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?
+RETURNING *;
+
+-- name: UpdateBlockIndexerProgress :exec
+UPDATE block_indexers
+SET next_block = ?,
+    last_error = NULL,
+    last_indexed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?;
+
+-- name: UpdateBlockIndexerError :exec
+UPDATE block_indexers
+SET last_error = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?;
+
+-- name: DeleteBlockIndexer :exec
+DELETE FROM block_indexers WHERE network_id = ?;
+
+-- name: CreateIndexedBlock :exec
+INSERT INTO indexed_blocks (
+    network_id,
+    block_number,
+    data_hash,
+    tx_count,
+    block_time
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: CountIndexedBlocks :one
+SELECT COUNT(*) FROM indexed_blocks WHERE network_id = ?;
+
+-- name: DeleteIndexedBlock :exec
+DELETE FROM indexed_blocks WHERE network_id = ? AND block_number = ?;
+
+-- name: DeleteIndexedBlocksByNetwork :exec
+DELETE FROM indexed_blocks WHERE network_id = ?;
+
+-- name: CreateIndexedTransaction :one
+INSERT INTO indexed_transactions (
+    network_id,
+    block_number,
+    tx_index,
+    tx_id,
+    tx_type,
+    tx_timestamp,
+    chaincode,
+    function,
+    creator_msp_id,
+    validation_code,
+    event_name
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetIndexedTransactionByTxID :one
+SELECT * FROM indexed_transactions
+WHERE network_id = ? AND tx_id = ?
+ORDER BY block_number
+LIMIT 1;
+
+-- name: DeleteIndexedTransactionsByBlock :exec
+DELETE FROM indexed_transactions WHERE network_id = ? AND block_number = ?;
+
+-- name: DeleteIndexedTransactionsByNetwork :exec
+DELETE FROM indexed_transactions WHERE network_id = ?;
+
+-- name: SearchIndexedTransactions :many
+SELECT * FROM indexed_transactions
+WHERE network_id = ?
+  AND (? IS NULL OR tx_timestamp >= ?)
+  AND (? IS NULL OR tx_timestamp <= ?)
+  AND (? = '' OR chaincode = ?)
+  AND (? = '' OR function = ?)
+  AND (? = '' OR creator_msp_id = ?)
+  AND (? = '' OR validation_code = ?)
+  AND (? = '' OR id IN (SELECT transaction_id FROM indexed_transaction_keys WHERE key = ?))
+ORDER BY block_number DESC, tx_index DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountSearchIndexedTransactions :one
+SELECT COUNT(*) FROM indexed_transactions
+WHERE network_id = ?
+  AND (? IS NULL OR tx_timestamp >= ?)
+  AND (? IS NULL OR tx_timestamp <= ?)
+  AND (? = '' OR chaincode = ?)
+  AND (? = '' OR function = ?)
+  AND (? = '' OR creator_msp_id = ?)
+  AND (? = '' OR validation_code = ?)
+  AND (? = '' OR id IN (SELECT transaction_id FROM indexed_transaction_keys WHERE key = ?));
+
+-- name: CreateIndexedTransactionKey :exec
+INSERT INTO indexed_transaction_keys (
+    transaction_id,
+    network_id,
+    namespace,
+    key,
+    access
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: ListIndexedTransactionKeys :many
+SELECT * FROM indexed_transaction_keys WHERE transaction_id = ? ORDER BY id;
+
+-- name: DeleteIndexedTransactionKeysByNetwork :exec
+DELETE FROM indexed_transaction_keys WHERE network_id = ?;
+
+-- name: CreateUpgradePlan :one
+INSERT INTO upgrade_plans (
+    network_id,
+    target_version,
+    status,
+    health_timeout_seconds,
+    allow_downtime
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetUpgradePlan :one
+SELECT * FROM upgrade_plans WHERE id = ?;
+
+-- name: ListUpgradePlansByNetwork :many
+SELECT * FROM upgrade_plans WHERE network_id = ? ORDER BY id DESC;
+
+-- name: ListUpgradePlansByStatus :many
+SELECT * FROM upgrade_plans WHERE status = ? ORDER BY id;
+
+-- name: CountActiveUpgradePlans :one
+SELECT COUNT(*) FROM upgrade_plans
+WHERE network_id = ? AND status IN ('pending', 'running', 'paused');
+
+-- name: StartUpgradePlan :exec
+UPDATE upgrade_plans
+SET status = 'running',
+    error = NULL,
+    started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: UpdateUpgradePlanStatus :exec
+UPDATE upgrade_plans
+SET status = ?,
+    error = ?,
+    completed_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreateUpgradePlanStep :one
+INSERT INTO upgrade_plan_steps (
+    plan_id,
+    step_order,
+    node_id,
+    node_type,
+    from_version,
+    to_version,
+    status
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListUpgradePlanSteps :many
+SELECT * FROM upgrade_plan_steps WHERE plan_id = ? ORDER BY step_order;
+
+-- name: UpdateUpgradePlanStep :exec
+UPDATE upgrade_plan_steps
+SET status = ?,
+    error = ?,
+    started_at = ?,
+    completed_at = ?
+WHERE id = ?;
+
+-- name: CreateHost :one
+INSERT INTO hosts (
+    name,
+    description,
+    address,
+    port,
+    username,
+    ssh_key_id,
+    data_path
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetHost :one
+SELECT * FROM hosts WHERE id = ?;
+
+-- name: ListHosts :many
+SELECT * FROM hosts ORDER BY name;
+
+-- name: UpdateHost :one
+UPDATE hosts
+SET name = ?,
+    description = ?,
+    address = ?,
+    port = ?,
+    username = ?,
+    ssh_key_id = ?,
+    data_path = ?,
+    host_key = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdateHostKey :exec
+UPDATE hosts
+SET host_key = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: UpdateHostStatus :exec
+UPDATE hosts
+SET status = ?,
+    last_error = ?,
+    last_checked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteHost :exec
+DELETE FROM hosts WHERE id = ?;
+
+-- name: SetNodeHost :exec
+INSERT INTO node_hosts (node_id, host_id) VALUES (?, ?)
+ON CONFLICT (node_id) DO UPDATE SET host_id = excluded.host_id;
+
+-- name: GetNodeHost :one
+SELECT * FROM node_hosts WHERE node_id = ?;
+
+-- name: DeleteNodeHost :exec
+DELETE FROM node_hosts WHERE node_id = ?;
+
+-- name: ListNodeHostsByHost :many
+SELECT * FROM node_hosts WHERE host_id = ? ORDER BY node_id;
+
+-- name: CountNodeHostsByHost :one
+SELECT COUNT(*) FROM node_hosts WHERE host_id = ?;
+
+-- name: CreateTestnet :one
+INSERT INTO testnets (name, platform) VALUES (?, ?)
+RETURNING *;
+
+-- name: GetTestnet :one
+SELECT * FROM testnets WHERE id = ?;
+
+-- name: GetTestnetByName :one
+SELECT * FROM testnets WHERE name = ?;
+
+-- name: ListTestnets :many
+SELECT * FROM testnets ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateTestnetStatus :exec
+UPDATE testnets
+SET status = ?,
+    last_error = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteTestnet :exec
+DELETE FROM testnets WHERE id = ?;
+
+-- name: AddTestnetResource :exec
+INSERT INTO testnet_resources (testnet_id, resource_type, resource_id) VALUES (?, ?, ?)
+ON CONFLICT (testnet_id, resource_type, resource_id) DO NOTHING;
+
+-- name: ListTestnetResources :many
+SELECT * FROM testnet_resources WHERE testnet_id = ? ORDER BY id;
+
+-- name: DeleteTestnetResource :exec
+DELETE FROM testnet_resources WHERE testnet_id = ? AND resource_type = ? AND resource_id = ?;
+
+-- name: CreateBenchmarkRun :one
+INSERT INTO benchmark_runs (name, platform, config) VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetBenchmarkRun :one
+SELECT * FROM benchmark_runs WHERE id = ?;
+
+-- name: ListBenchmarkRuns :many
+SELECT * FROM benchmark_runs ORDER BY started_at DESC, id DESC;
+
+-- name: FinishBenchmarkRun :exec
+UPDATE benchmark_runs
+SET status = ?,
+    report = ?,
+    error = ?,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: FailRunningBenchmarkRuns :exec
+UPDATE benchmark_runs
+SET status = 'FAILED',
+    error = ?,
+    finished_at = CURRENT_TIMESTAMP
+WHERE status = 'RUNNING';
+
+-- name: DeleteBenchmarkRun :exec
+DELETE FROM benchmark_runs WHERE id = ?;
+
+-- name: UpdateNetworkConfig :exec
+UPDATE networks
+SET config = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreateConsensusMigration :one
+INSERT INTO consensus_migrations (
+    network_id,
+    target_consensus,
+    status,
+    config
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetConsensusMigration :one
+SELECT * FROM consensus_migrations WHERE id = ?;
+
+-- name: ListConsensusMigrationsByNetwork :many
+SELECT * FROM consensus_migrations WHERE network_id = ? ORDER BY id DESC;
+
+-- name: ListConsensusMigrationsByStatus :many
+SELECT * FROM consensus_migrations WHERE status = ? ORDER BY id;
+
+-- name: CountActiveConsensusMigrations :one
+SELECT COUNT(*) FROM consensus_migrations
+WHERE network_id = ? AND status IN ('pending', 'running', 'paused');
+
+-- name: StartConsensusMigration :exec
+UPDATE consensus_migrations
+SET status = 'running',
+    error = NULL,
+    started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: UpdateConsensusMigrationStatus :exec
+UPDATE consensus_migrations
+SET status = ?,
+    error = ?,
+    completed_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: CreateConsensusMigrationStep :one
+INSERT INTO consensus_migration_steps (
+    migration_id,
+    step_order,
+    name,
+    status
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListConsensusMigrationSteps :many
+SELECT * FROM consensus_migration_steps WHERE migration_id = ? ORDER BY step_order;
+
+-- name: UpdateConsensusMigrationStep :exec
+UPDATE consensus_migration_steps
+SET status = ?,
+    error = ?,
+    started_at = ?,
+    completed_at = ?
+WHERE id = ?;
+
+-- name: CreateBesuContract :one
+INSERT INTO besu_contracts (
+    network_id,
+    name,
+    address,
+    abi,
+    bytecode_hash,
+    deployer_key_id,
+    deploy_tx_hash,
+    deploy_block_number,
+    is_proxy,
+    implementation_address,
+    project_id
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetBesuContract :one
+SELECT * FROM besu_contracts WHERE id = ?;
+
+-- name: GetBesuContractByAddress :one
+SELECT * FROM besu_contracts WHERE network_id = ? AND address = ?;
+
+-- name: ListBesuContracts :many
+SELECT * FROM besu_contracts ORDER BY id DESC;
+
+-- name: ListBesuContractsByNetwork :many
+SELECT * FROM besu_contracts WHERE network_id = ? ORDER BY id DESC;
+
+-- name: ListBesuContractsByProject :many
+SELECT * FROM besu_contracts WHERE project_id = ? ORDER BY id DESC;
+
+-- name: UpdateBesuContract :one
+UPDATE besu_contracts
+SET name = ?,
+    abi = ?,
+    is_proxy = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdateBesuContractImplementation :exec
+UPDATE besu_contracts
+SET implementation_address = ?,
+    abi = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: DeleteBesuContract :exec
+DELETE FROM besu_contracts WHERE id = ?;
+
+-- name: CreateBesuContractUpgrade :one
+INSERT INTO besu_contract_upgrades (
+    contract_id,
+    previous_implementation,
+    implementation_address,
+    bytecode_hash,
+    tx_hash
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListBesuContractUpgrades :many
+SELECT * FROM besu_contract_upgrades WHERE contract_id = ? ORDER BY id DESC;
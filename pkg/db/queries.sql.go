@@ -56,6 +56,22 @@ func (q *Queries) AddRevokedCertificate(ctx context.Context, arg *AddRevokedCert
 	return err
 }
 
+const AddTestnetResource = `-- name: AddTestnetResource :exec
+INSERT INTO testnet_resources (testnet_id, resource_type, resource_id) VALUES (?, ?, ?)
+ON CONFLICT (testnet_id, resource_type, resource_id) DO NOTHING
+`
+
+type AddTestnetResourceParams struct {
+	TestnetID    int64  `json:"testnetId"`
+	ResourceType string `json:"resourceType"`
+	ResourceID   int64  `json:"resourceId"`
+}
+
+func (q *Queries) AddTestnetResource(ctx context.Context, arg *AddTestnetResourceParams) error {
+	_, err := q.db.ExecContext(ctx, AddTestnetResource, arg.TestnetID, arg.ResourceType, arg.ResourceID)
+	return err
+}
+
 const CheckNetworkNodeExists = `-- name: CheckNetworkNodeExists :one
 SELECT EXISTS(SELECT 1 FROM network_nodes WHERE network_id = ? AND node_id = ?)
 `
@@ -72,6 +88,30 @@ func (q *Queries) CheckNetworkNodeExists(ctx context.Context, arg *CheckNetworkN
 	return column_1, err
 }
 
+const CountActiveConsensusMigrations = `-- name: CountActiveConsensusMigrations :one
+SELECT COUNT(*) FROM consensus_migrations
+WHERE network_id = ? AND status IN ('pending', 'running', 'paused')
+`
+
+func (q *Queries) CountActiveConsensusMigrations(ctx context.Context, networkID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountActiveConsensusMigrations, networkID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountActiveUpgradePlans = `-- name: CountActiveUpgradePlans :one
+SELECT COUNT(*) FROM upgrade_plans
+WHERE network_id = ? AND status IN ('pending', 'running', 'paused')
+`
+
+func (q *Queries) CountActiveUpgradePlans(ctx context.Context, networkID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountActiveUpgradePlans, networkID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CountAuditLogs = `-- name: CountAuditLogs :one
 SELECT COUNT(*) FROM audit_logs
 WHERE (? IS NULL OR timestamp >= ?)
@@ -142,6 +182,17 @@ func (q *Queries) CountFabricOrganizations(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const CountIndexedBlocks = `-- name: CountIndexedBlocks :one
+SELECT COUNT(*) FROM indexed_blocks WHERE network_id = ?
+`
+
+func (q *Queries) CountIndexedBlocks(ctx context.Context, networkID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountIndexedBlocks, networkID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CountNetworks = `-- name: CountNetworks :one
 SELECT COUNT(*) FROM networks
 `
@@ -187,6 +238,17 @@ func (q *Queries) CountNodeGroupsByStatus(ctx context.Context, status string) (i
 	return count, err
 }
 
+const CountNodeHostsByHost = `-- name: CountNodeHostsByHost :one
+SELECT COUNT(*) FROM node_hosts WHERE host_id = ?
+`
+
+func (q *Queries) CountNodeHostsByHost(ctx context.Context, hostID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountNodeHostsByHost, hostID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CountNodes = `-- name: CountNodes :one
 SELECT COUNT(*) FROM nodes
 `
@@ -210,6 +272,59 @@ func (q *Queries) CountNodesByPlatform(ctx context.Context, platform string) (in
 	return count, err
 }
 
+const CountSearchIndexedTransactions = `-- name: CountSearchIndexedTransactions :one
+SELECT COUNT(*) FROM indexed_transactions
+WHERE network_id = ?
+  AND (? IS NULL OR tx_timestamp >= ?)
+  AND (? IS NULL OR tx_timestamp <= ?)
+  AND (? = '' OR chaincode = ?)
+  AND (? = '' OR function = ?)
+  AND (? = '' OR creator_msp_id = ?)
+  AND (? = '' OR validation_code = ?)
+  AND (? = '' OR id IN (SELECT transaction_id FROM indexed_transaction_keys WHERE key = ?))
+`
+
+type CountSearchIndexedTransactionsParams struct {
+	NetworkID      int64        `json:"networkId"`
+	Column2        interface{}  `json:"column2"`
+	TxTimestamp    sql.NullTime `json:"txTimestamp"`
+	Column4        interface{}  `json:"column4"`
+	TxTimestamp_2  sql.NullTime `json:"txTimestamp2"`
+	Column6        interface{}  `json:"column6"`
+	Chaincode      string       `json:"chaincode"`
+	Column8        interface{}  `json:"column8"`
+	Function       string       `json:"function"`
+	Column10       interface{}  `json:"column10"`
+	CreatorMspID   string       `json:"creatorMspId"`
+	Column12       interface{}  `json:"column12"`
+	ValidationCode string       `json:"validationCode"`
+	Column14       interface{}  `json:"column14"`
+	Key            string       `json:"key"`
+}
+
+func (q *Queries) CountSearchIndexedTransactions(ctx context.Context, arg *CountSearchIndexedTransactionsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountSearchIndexedTransactions,
+		arg.NetworkID,
+		arg.Column2,
+		arg.TxTimestamp,
+		arg.Column4,
+		arg.TxTimestamp_2,
+		arg.Column6,
+		arg.Chaincode,
+		arg.Column8,
+		arg.Function,
+		arg.Column10,
+		arg.CreatorMspID,
+		arg.Column12,
+		arg.ValidationCode,
+		arg.Column14,
+		arg.Key,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CountServiceBackupsByService = `-- name: CountServiceBackupsByService :one
 SELECT COUNT(*) FROM service_backups WHERE service_id = ?
 `
@@ -254,6 +369,57 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const CreateAlertmanagerConfig = `-- name: CreateAlertmanagerConfig :one
+INSERT INTO alertmanager_config (
+    alertmanager_port,
+    alertmanager_version,
+    deployment_mode,
+    network_mode,
+    webhook_url,
+    webhook_token,
+    default_rules_enabled
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, alertmanager_port, alertmanager_version, deployment_mode, network_mode, webhook_url, webhook_token, default_rules_enabled, created_at, updated_at
+`
+
+type CreateAlertmanagerConfigParams struct {
+	AlertmanagerPort    int64          `json:"alertmanagerPort"`
+	AlertmanagerVersion string         `json:"alertmanagerVersion"`
+	DeploymentMode      string         `json:"deploymentMode"`
+	NetworkMode         sql.NullString `json:"networkMode"`
+	WebhookUrl          string         `json:"webhookUrl"`
+	WebhookToken        string         `json:"webhookToken"`
+	DefaultRulesEnabled bool           `json:"defaultRulesEnabled"`
+}
+
+func (q *Queries) CreateAlertmanagerConfig(ctx context.Context, arg *CreateAlertmanagerConfigParams) (*AlertmanagerConfig, error) {
+	row := q.db.QueryRowContext(ctx, CreateAlertmanagerConfig,
+		arg.AlertmanagerPort,
+		arg.AlertmanagerVersion,
+		arg.DeploymentMode,
+		arg.NetworkMode,
+		arg.WebhookUrl,
+		arg.WebhookToken,
+		arg.DefaultRulesEnabled,
+	)
+	var i AlertmanagerConfig
+	err := row.Scan(
+		&i.ID,
+		&i.AlertmanagerPort,
+		&i.AlertmanagerVersion,
+		&i.DeploymentMode,
+		&i.NetworkMode,
+		&i.WebhookUrl,
+		&i.WebhookToken,
+		&i.DefaultRulesEnabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const CreateAuditLog = `-- name: CreateAuditLog :one
 INSERT INTO audit_logs (
     timestamp,
@@ -499,6 +665,174 @@ func (q *Queries) CreateBackupTarget(ctx context.Context, arg *CreateBackupTarge
 	return &i, err
 }
 
+const CreateBenchmarkRun = `-- name: CreateBenchmarkRun :one
+INSERT INTO benchmark_runs (name, platform, config) VALUES (?, ?, ?)
+RETURNING id, name, platform, config, status, report, error, started_at, finished_at
+`
+
+type CreateBenchmarkRunParams struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	Config   string `json:"config"`
+}
+
+func (q *Queries) CreateBenchmarkRun(ctx context.Context, arg *CreateBenchmarkRunParams) (*BenchmarkRun, error) {
+	row := q.db.QueryRowContext(ctx, CreateBenchmarkRun, arg.Name, arg.Platform, arg.Config)
+	var i BenchmarkRun
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Platform,
+		&i.Config,
+		&i.Status,
+		&i.Report,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return &i, err
+}
+
+const CreateBesuContract = `-- name: CreateBesuContract :one
+INSERT INTO besu_contracts (
+    network_id,
+    name,
+    address,
+    abi,
+    bytecode_hash,
+    deployer_key_id,
+    deploy_tx_hash,
+    deploy_block_number,
+    is_proxy,
+    implementation_address,
+    project_id
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id
+`
+
+type CreateBesuContractParams struct {
+	NetworkID             int64          `json:"networkId"`
+	Name                  string         `json:"name"`
+	Address               string         `json:"address"`
+	Abi                   string         `json:"abi"`
+	BytecodeHash          sql.NullString `json:"bytecodeHash"`
+	DeployerKeyID         sql.NullInt64  `json:"deployerKeyId"`
+	DeployTxHash          sql.NullString `json:"deployTxHash"`
+	DeployBlockNumber     sql.NullInt64  `json:"deployBlockNumber"`
+	IsProxy               bool           `json:"isProxy"`
+	ImplementationAddress sql.NullString `json:"implementationAddress"`
+	ProjectID             sql.NullInt64  `json:"projectId"`
+}
+
+func (q *Queries) CreateBesuContract(ctx context.Context, arg *CreateBesuContractParams) (*BesuContract, error) {
+	row := q.db.QueryRowContext(ctx, CreateBesuContract,
+		arg.NetworkID,
+		arg.Name,
+		arg.Address,
+		arg.Abi,
+		arg.BytecodeHash,
+		arg.DeployerKeyID,
+		arg.DeployTxHash,
+		arg.DeployBlockNumber,
+		arg.IsProxy,
+		arg.ImplementationAddress,
+		arg.ProjectID,
+	)
+	var i BesuContract
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Name,
+		&i.Address,
+		&i.Abi,
+		&i.BytecodeHash,
+		&i.DeployerKeyID,
+		&i.DeployTxHash,
+		&i.DeployBlockNumber,
+		&i.IsProxy,
+		&i.ImplementationAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+	)
+	return &i, err
+}
+
+const CreateBesuContractUpgrade = `-- name: CreateBesuContractUpgrade :one
+INSERT INTO besu_contract_upgrades (
+    contract_id,
+    previous_implementation,
+    implementation_address,
+    bytecode_hash,
+    tx_hash
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING id, contract_id, previous_implementation, implementation_address, bytecode_hash, tx_hash, created_at
+`
+
+type CreateBesuContractUpgradeParams struct {
+	ContractID             int64          `json:"contractId"`
+	PreviousImplementation sql.NullString `json:"previousImplementation"`
+	ImplementationAddress  string         `json:"implementationAddress"`
+	BytecodeHash           sql.NullString `json:"bytecodeHash"`
+	TxHash                 sql.NullString `json:"txHash"`
+}
+
+func (q *Queries) CreateBesuContractUpgrade(ctx context.Context, arg *CreateBesuContractUpgradeParams) (*BesuContractUpgrade, error) {
+	row := q.db.QueryRowContext(ctx, CreateBesuContractUpgrade,
+		arg.ContractID,
+		arg.PreviousImplementation,
+		arg.ImplementationAddress,
+		arg.BytecodeHash,
+		arg.TxHash,
+	)
+	var i BesuContractUpgrade
+	err := row.Scan(
+		&i.ID,
+		&i.ContractID,
+		&i.PreviousImplementation,
+		&i.ImplementationAddress,
+		&i.BytecodeHash,
+		&i.TxHash,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const CreateBlockIndexer = `-- name: CreateBlockIndexer :one
+INSERT INTO block_indexers (
+    network_id,
+    next_block
+) VALUES (
+    ?, ?
+)
+RETURNING id, network_id, enabled, next_block, last_error, last_indexed_at, created_at, updated_at
+`
+
+type CreateBlockIndexerParams struct {
+	NetworkID int64 `json:"networkId"`
+	NextBlock int64 `json:"nextBlock"`
+}
+
+func (q *Queries) CreateBlockIndexer(ctx context.Context, arg *CreateBlockIndexerParams) (*BlockIndexer, error) {
+	row := q.db.QueryRowContext(ctx, CreateBlockIndexer, arg.NetworkID, arg.NextBlock)
+	var i BlockIndexer
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Enabled,
+		&i.NextBlock,
+		&i.LastError,
+		&i.LastIndexedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const CreateChaincode = `-- name: CreateChaincode :one
 INSERT INTO fabric_chaincodes (name, network_id)
 VALUES (?, ?)
@@ -562,6 +896,88 @@ func (q *Queries) CreateChaincodeDefinition(ctx context.Context, arg *CreateChai
 	return &i, err
 }
 
+const CreateConsensusMigration = `-- name: CreateConsensusMigration :one
+INSERT INTO consensus_migrations (
+    network_id,
+    target_consensus,
+    status,
+    config
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, network_id, target_consensus, status, config, error, created_at, started_at, completed_at, updated_at
+`
+
+type CreateConsensusMigrationParams struct {
+	NetworkID       int64  `json:"networkId"`
+	TargetConsensus string `json:"targetConsensus"`
+	Status          string `json:"status"`
+	Config          string `json:"config"`
+}
+
+func (q *Queries) CreateConsensusMigration(ctx context.Context, arg *CreateConsensusMigrationParams) (*ConsensusMigration, error) {
+	row := q.db.QueryRowContext(ctx, CreateConsensusMigration,
+		arg.NetworkID,
+		arg.TargetConsensus,
+		arg.Status,
+		arg.Config,
+	)
+	var i ConsensusMigration
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.TargetConsensus,
+		&i.Status,
+		&i.Config,
+		&i.Error,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const CreateConsensusMigrationStep = `-- name: CreateConsensusMigrationStep :one
+INSERT INTO consensus_migration_steps (
+    migration_id,
+    step_order,
+    name,
+    status
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, migration_id, step_order, name, status, error, started_at, completed_at
+`
+
+type CreateConsensusMigrationStepParams struct {
+	MigrationID int64  `json:"migrationId"`
+	StepOrder   int64  `json:"stepOrder"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+}
+
+func (q *Queries) CreateConsensusMigrationStep(ctx context.Context, arg *CreateConsensusMigrationStepParams) (*ConsensusMigrationStep, error) {
+	row := q.db.QueryRowContext(ctx, CreateConsensusMigrationStep,
+		arg.MigrationID,
+		arg.StepOrder,
+		arg.Name,
+		arg.Status,
+	)
+	var i ConsensusMigrationStep
+	err := row.Scan(
+		&i.ID,
+		&i.MigrationID,
+		&i.StepOrder,
+		&i.Name,
+		&i.Status,
+		&i.Error,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return &i, err
+}
+
 const CreateFabricChaincode = `-- name: CreateFabricChaincode :one
 INSERT INTO fabric_chaincodes (name, network_id)
 VALUES (?, ?)
@@ -691,75 +1107,305 @@ func (q *Queries) CreateFabricXNamespace(ctx context.Context, arg *CreateFabricX
 	return &i, err
 }
 
-const CreateKey = `-- name: CreateKey :one
-INSERT INTO keys (
-    name, description, algorithm, key_size, curve, format,
-    public_key, private_key, certificate, status, expires_at, sha256_fingerprint,
-    sha1_fingerprint, provider_id, user_id, is_ca, ethereum_address
+const CreateGrafanaConfig = `-- name: CreateGrafanaConfig :one
+INSERT INTO grafana_config (
+    grafana_port,
+    grafana_version,
+    deployment_mode,
+    network_mode,
+    admin_user,
+    admin_password
+) VALUES (
+    ?, ?, ?, ?, ?, ?
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, name, description, algorithm, key_size, curve, format, public_key, private_key, certificate, status, created_at, updated_at, expires_at, last_rotated_at, signing_key_id, sha256_fingerprint, sha1_fingerprint, provider_id, user_id, is_ca, ethereum_address
+RETURNING id, grafana_port, grafana_version, deployment_mode, network_mode, admin_user, admin_password, created_at, updated_at
 `
 
-type CreateKeyParams struct {
-	Name              string         `json:"name"`
-	Description       sql.NullString `json:"description"`
-	Algorithm         string         `json:"algorithm"`
-	KeySize           sql.NullInt64  `json:"keySize"`
-	Curve             sql.NullString `json:"curve"`
-	Format            string         `json:"format"`
-	PublicKey         string         `json:"publicKey"`
-	PrivateKey        string         `json:"privateKey"`
-	Certificate       sql.NullString `json:"certificate"`
-	Status            string         `json:"status"`
-	ExpiresAt         sql.NullTime   `json:"expiresAt"`
-	Sha256Fingerprint string         `json:"sha256Fingerprint"`
-	Sha1Fingerprint   string         `json:"sha1Fingerprint"`
-	ProviderID        int64          `json:"providerId"`
-	UserID            int64          `json:"userId"`
-	IsCa              int64          `json:"isCa"`
-	EthereumAddress   sql.NullString `json:"ethereumAddress"`
+type CreateGrafanaConfigParams struct {
+	GrafanaPort    int64          `json:"grafanaPort"`
+	GrafanaVersion string         `json:"grafanaVersion"`
+	DeploymentMode string         `json:"deploymentMode"`
+	NetworkMode    sql.NullString `json:"networkMode"`
+	AdminUser      string         `json:"adminUser"`
+	AdminPassword  string         `json:"adminPassword"`
 }
 
-func (q *Queries) CreateKey(ctx context.Context, arg *CreateKeyParams) (*Key, error) {
-	row := q.db.QueryRowContext(ctx, CreateKey,
-		arg.Name,
-		arg.Description,
-		arg.Algorithm,
-		arg.KeySize,
-		arg.Curve,
-		arg.Format,
-		arg.PublicKey,
-		arg.PrivateKey,
-		arg.Certificate,
-		arg.Status,
-		arg.ExpiresAt,
-		arg.Sha256Fingerprint,
-		arg.Sha1Fingerprint,
-		arg.ProviderID,
-		arg.UserID,
-		arg.IsCa,
-		arg.EthereumAddress,
+func (q *Queries) CreateGrafanaConfig(ctx context.Context, arg *CreateGrafanaConfigParams) (*GrafanaConfig, error) {
+	row := q.db.QueryRowContext(ctx, CreateGrafanaConfig,
+		arg.GrafanaPort,
+		arg.GrafanaVersion,
+		arg.DeploymentMode,
+		arg.NetworkMode,
+		arg.AdminUser,
+		arg.AdminPassword,
 	)
-	var i Key
+	var i GrafanaConfig
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.Algorithm,
-		&i.KeySize,
-		&i.Curve,
-		&i.Format,
-		&i.PublicKey,
-		&i.PrivateKey,
-		&i.Certificate,
-		&i.Status,
+		&i.GrafanaPort,
+		&i.GrafanaVersion,
+		&i.DeploymentMode,
+		&i.NetworkMode,
+		&i.AdminUser,
+		&i.AdminPassword,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.ExpiresAt,
-		&i.LastRotatedAt,
-		&i.SigningKeyID,
-		&i.Sha256Fingerprint,
+	)
+	return &i, err
+}
+
+const CreateHost = `-- name: CreateHost :one
+INSERT INTO hosts (
+    name,
+    description,
+    address,
+    port,
+    username,
+    ssh_key_id,
+    data_path
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, name, description, address, port, username, ssh_key_id, host_key, data_path, status, last_error, last_checked_at, created_at, updated_at
+`
+
+type CreateHostParams struct {
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Address     string         `json:"address"`
+	Port        int64          `json:"port"`
+	Username    string         `json:"username"`
+	SshKeyID    int64          `json:"sshKeyId"`
+	DataPath    string         `json:"dataPath"`
+}
+
+func (q *Queries) CreateHost(ctx context.Context, arg *CreateHostParams) (*Host, error) {
+	row := q.db.QueryRowContext(ctx, CreateHost,
+		arg.Name,
+		arg.Description,
+		arg.Address,
+		arg.Port,
+		arg.Username,
+		arg.SshKeyID,
+		arg.DataPath,
+	)
+	var i Host
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Address,
+		&i.Port,
+		&i.Username,
+		&i.SshKeyID,
+		&i.HostKey,
+		&i.DataPath,
+		&i.Status,
+		&i.LastError,
+		&i.LastCheckedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const CreateIndexedBlock = `-- name: CreateIndexedBlock :exec
+INSERT INTO indexed_blocks (
+    network_id,
+    block_number,
+    data_hash,
+    tx_count,
+    block_time
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateIndexedBlockParams struct {
+	NetworkID   int64        `json:"networkId"`
+	BlockNumber int64        `json:"blockNumber"`
+	DataHash    string       `json:"dataHash"`
+	TxCount     int64        `json:"txCount"`
+	BlockTime   sql.NullTime `json:"blockTime"`
+}
+
+func (q *Queries) CreateIndexedBlock(ctx context.Context, arg *CreateIndexedBlockParams) error {
+	_, err := q.db.ExecContext(ctx, CreateIndexedBlock,
+		arg.NetworkID,
+		arg.BlockNumber,
+		arg.DataHash,
+		arg.TxCount,
+		arg.BlockTime,
+	)
+	return err
+}
+
+const CreateIndexedTransaction = `-- name: CreateIndexedTransaction :one
+INSERT INTO indexed_transactions (
+    network_id,
+    block_number,
+    tx_index,
+    tx_id,
+    tx_type,
+    tx_timestamp,
+    chaincode,
+    function,
+    creator_msp_id,
+    validation_code,
+    event_name
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, network_id, block_number, tx_index, tx_id, tx_type, tx_timestamp, chaincode, function, creator_msp_id, validation_code, event_name, created_at
+`
+
+type CreateIndexedTransactionParams struct {
+	NetworkID      int64        `json:"networkId"`
+	BlockNumber    int64        `json:"blockNumber"`
+	TxIndex        int64        `json:"txIndex"`
+	TxID           string       `json:"txId"`
+	TxType         string       `json:"txType"`
+	TxTimestamp    sql.NullTime `json:"txTimestamp"`
+	Chaincode      string       `json:"chaincode"`
+	Function       string       `json:"function"`
+	CreatorMspID   string       `json:"creatorMspId"`
+	ValidationCode string       `json:"validationCode"`
+	EventName      string       `json:"eventName"`
+}
+
+func (q *Queries) CreateIndexedTransaction(ctx context.Context, arg *CreateIndexedTransactionParams) (*IndexedTransaction, error) {
+	row := q.db.QueryRowContext(ctx, CreateIndexedTransaction,
+		arg.NetworkID,
+		arg.BlockNumber,
+		arg.TxIndex,
+		arg.TxID,
+		arg.TxType,
+		arg.TxTimestamp,
+		arg.Chaincode,
+		arg.Function,
+		arg.CreatorMspID,
+		arg.ValidationCode,
+		arg.EventName,
+	)
+	var i IndexedTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.BlockNumber,
+		&i.TxIndex,
+		&i.TxID,
+		&i.TxType,
+		&i.TxTimestamp,
+		&i.Chaincode,
+		&i.Function,
+		&i.CreatorMspID,
+		&i.ValidationCode,
+		&i.EventName,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const CreateIndexedTransactionKey = `-- name: CreateIndexedTransactionKey :exec
+INSERT INTO indexed_transaction_keys (
+    transaction_id,
+    network_id,
+    namespace,
+    key,
+    access
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateIndexedTransactionKeyParams struct {
+	TransactionID int64  `json:"transactionId"`
+	NetworkID     int64  `json:"networkId"`
+	Namespace     string `json:"namespace"`
+	Key           string `json:"key"`
+	Access        string `json:"access"`
+}
+
+func (q *Queries) CreateIndexedTransactionKey(ctx context.Context, arg *CreateIndexedTransactionKeyParams) error {
+	_, err := q.db.ExecContext(ctx, CreateIndexedTransactionKey,
+		arg.TransactionID,
+		arg.NetworkID,
+		arg.Namespace,
+		arg.Key,
+		arg.Access,
+	)
+	return err
+}
+
+const CreateKey = `-- name: CreateKey :one
+INSERT INTO keys (
+    name, description, algorithm, key_size, curve, format,
+    public_key, private_key, certificate, status, expires_at, sha256_fingerprint,
+    sha1_fingerprint, provider_id, user_id, is_ca, ethereum_address
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, description, algorithm, key_size, curve, format, public_key, private_key, certificate, status, created_at, updated_at, expires_at, last_rotated_at, signing_key_id, sha256_fingerprint, sha1_fingerprint, provider_id, user_id, is_ca, ethereum_address
+`
+
+type CreateKeyParams struct {
+	Name              string         `json:"name"`
+	Description       sql.NullString `json:"description"`
+	Algorithm         string         `json:"algorithm"`
+	KeySize           sql.NullInt64  `json:"keySize"`
+	Curve             sql.NullString `json:"curve"`
+	Format            string         `json:"format"`
+	PublicKey         string         `json:"publicKey"`
+	PrivateKey        string         `json:"privateKey"`
+	Certificate       sql.NullString `json:"certificate"`
+	Status            string         `json:"status"`
+	ExpiresAt         sql.NullTime   `json:"expiresAt"`
+	Sha256Fingerprint string         `json:"sha256Fingerprint"`
+	Sha1Fingerprint   string         `json:"sha1Fingerprint"`
+	ProviderID        int64          `json:"providerId"`
+	UserID            int64          `json:"userId"`
+	IsCa              int64          `json:"isCa"`
+	EthereumAddress   sql.NullString `json:"ethereumAddress"`
+}
+
+func (q *Queries) CreateKey(ctx context.Context, arg *CreateKeyParams) (*Key, error) {
+	row := q.db.QueryRowContext(ctx, CreateKey,
+		arg.Name,
+		arg.Description,
+		arg.Algorithm,
+		arg.KeySize,
+		arg.Curve,
+		arg.Format,
+		arg.PublicKey,
+		arg.PrivateKey,
+		arg.Certificate,
+		arg.Status,
+		arg.ExpiresAt,
+		arg.Sha256Fingerprint,
+		arg.Sha1Fingerprint,
+		arg.ProviderID,
+		arg.UserID,
+		arg.IsCa,
+		arg.EthereumAddress,
+	)
+	var i Key
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Algorithm,
+		&i.KeySize,
+		&i.Curve,
+		&i.Format,
+		&i.PublicKey,
+		&i.PrivateKey,
+		&i.Certificate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.LastRotatedAt,
+		&i.SigningKeyID,
+		&i.Sha256Fingerprint,
 		&i.Sha1Fingerprint,
 		&i.ProviderID,
 		&i.UserID,
@@ -1325,6 +1971,124 @@ func (q *Queries) CreatePlugin(ctx context.Context, arg *CreatePluginParams) (*P
 	return &i, err
 }
 
+const CreatePluginVersion = `-- name: CreatePluginVersion :one
+INSERT INTO plugin_versions (
+  plugin_name,
+  version,
+  source,
+  api_version,
+  kind,
+  metadata,
+  spec,
+  manifest,
+  signature
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?
+) RETURNING id, plugin_name, version, source, api_version, kind, metadata, spec, parameters, deployed_at, created_at, manifest, signature
+`
+
+type CreatePluginVersionParams struct {
+	PluginName string          `json:"pluginName"`
+	Version    string          `json:"version"`
+	Source     sql.NullString  `json:"source"`
+	ApiVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   json.RawMessage `json:"metadata"`
+	Spec       json.RawMessage `json:"spec"`
+	Manifest   []byte          `json:"manifest"`
+	Signature  sql.NullString  `json:"signature"`
+}
+
+func (q *Queries) CreatePluginVersion(ctx context.Context, arg *CreatePluginVersionParams) (*PluginVersion, error) {
+	row := q.db.QueryRowContext(ctx, CreatePluginVersion,
+		arg.PluginName,
+		arg.Version,
+		arg.Source,
+		arg.ApiVersion,
+		arg.Kind,
+		arg.Metadata,
+		arg.Spec,
+		arg.Manifest,
+		arg.Signature,
+	)
+	var i PluginVersion
+	err := row.Scan(
+		&i.ID,
+		&i.PluginName,
+		&i.Version,
+		&i.Source,
+		&i.ApiVersion,
+		&i.Kind,
+		&i.Metadata,
+		&i.Spec,
+		&i.Parameters,
+		&i.DeployedAt,
+		&i.CreatedAt,
+		&i.Manifest,
+		&i.Signature,
+	)
+	return &i, err
+}
+
+const CreatePrometheusAlertRule = `-- name: CreatePrometheusAlertRule :one
+INSERT INTO prometheus_alert_rules (
+    group_name,
+    name,
+    expr,
+    for_duration,
+    severity,
+    summary,
+    description,
+    labels,
+    enabled
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, group_name, name, expr, for_duration, severity, summary, description, labels, enabled, created_at, updated_at
+`
+
+type CreatePrometheusAlertRuleParams struct {
+	GroupName   string         `json:"groupName"`
+	Name        string         `json:"name"`
+	Expr        string         `json:"expr"`
+	ForDuration sql.NullString `json:"forDuration"`
+	Severity    string         `json:"severity"`
+	Summary     sql.NullString `json:"summary"`
+	Description sql.NullString `json:"description"`
+	Labels      sql.NullString `json:"labels"`
+	Enabled     bool           `json:"enabled"`
+}
+
+func (q *Queries) CreatePrometheusAlertRule(ctx context.Context, arg *CreatePrometheusAlertRuleParams) (*PrometheusAlertRule, error) {
+	row := q.db.QueryRowContext(ctx, CreatePrometheusAlertRule,
+		arg.GroupName,
+		arg.Name,
+		arg.Expr,
+		arg.ForDuration,
+		arg.Severity,
+		arg.Summary,
+		arg.Description,
+		arg.Labels,
+		arg.Enabled,
+	)
+	var i PrometheusAlertRule
+	err := row.Scan(
+		&i.ID,
+		&i.GroupName,
+		&i.Name,
+		&i.Expr,
+		&i.ForDuration,
+		&i.Severity,
+		&i.Summary,
+		&i.Description,
+		&i.Labels,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const CreatePrometheusConfig = `-- name: CreatePrometheusConfig :one
 INSERT INTO prometheus_config (
     prometheus_port,
@@ -1632,53 +2396,185 @@ func (q *Queries) CreateSetting(ctx context.Context, config string) (*Setting, e
 	return &i, err
 }
 
-const CreateUser = `-- name: CreateUser :one
-INSERT INTO users (
-    username, password, role, created_at, last_login_at, updated_at
-) VALUES (
-    ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
-)
-RETURNING id, username, password, name, email, role, provider, provider_id, avatar_url, created_at, last_login_at, updated_at
+const CreateTestnet = `-- name: CreateTestnet :one
+INSERT INTO testnets (name, platform) VALUES (?, ?)
+RETURNING id, name, platform, status, last_error, created_at, updated_at
 `
 
-type CreateUserParams struct {
-	Username string         `json:"username"`
-	Password string         `json:"password"`
-	Role     sql.NullString `json:"role"`
+type CreateTestnetParams struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
 }
 
-func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
-	row := q.db.QueryRowContext(ctx, CreateUser, arg.Username, arg.Password, arg.Role)
-	var i User
+func (q *Queries) CreateTestnet(ctx context.Context, arg *CreateTestnetParams) (*Testnet, error) {
+	row := q.db.QueryRowContext(ctx, CreateTestnet, arg.Name, arg.Platform)
+	var i Testnet
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Password,
 		&i.Name,
-		&i.Email,
-		&i.Role,
-		&i.Provider,
-		&i.ProviderID,
-		&i.AvatarUrl,
+		&i.Platform,
+		&i.Status,
+		&i.LastError,
 		&i.CreatedAt,
-		&i.LastLoginAt,
 		&i.UpdatedAt,
 	)
 	return &i, err
 }
 
-const DeleteBackup = `-- name: DeleteBackup :exec
-DELETE FROM backups WHERE id = ?
+const CreateUpgradePlan = `-- name: CreateUpgradePlan :one
+INSERT INTO upgrade_plans (
+    network_id,
+    target_version,
+    status,
+    health_timeout_seconds,
+    allow_downtime
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING id, network_id, target_version, status, health_timeout_seconds, allow_downtime, error, created_at, started_at, completed_at, updated_at
 `
 
-func (q *Queries) DeleteBackup(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, DeleteBackup, id)
-	return err
+type CreateUpgradePlanParams struct {
+	NetworkID            int64  `json:"networkId"`
+	TargetVersion        string `json:"targetVersion"`
+	Status               string `json:"status"`
+	HealthTimeoutSeconds int64  `json:"healthTimeoutSeconds"`
+	AllowDowntime        bool   `json:"allowDowntime"`
 }
 
-const DeleteBackupSchedule = `-- name: DeleteBackupSchedule :exec
-DELETE FROM backup_schedules WHERE id = ?
-`
+func (q *Queries) CreateUpgradePlan(ctx context.Context, arg *CreateUpgradePlanParams) (*UpgradePlan, error) {
+	row := q.db.QueryRowContext(ctx, CreateUpgradePlan,
+		arg.NetworkID,
+		arg.TargetVersion,
+		arg.Status,
+		arg.HealthTimeoutSeconds,
+		arg.AllowDowntime,
+	)
+	var i UpgradePlan
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.TargetVersion,
+		&i.Status,
+		&i.HealthTimeoutSeconds,
+		&i.AllowDowntime,
+		&i.Error,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const CreateUpgradePlanStep = `-- name: CreateUpgradePlanStep :one
+INSERT INTO upgrade_plan_steps (
+    plan_id,
+    step_order,
+    node_id,
+    node_type,
+    from_version,
+    to_version,
+    status
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, plan_id, step_order, node_id, node_type, from_version, to_version, status, error, started_at, completed_at
+`
+
+type CreateUpgradePlanStepParams struct {
+	PlanID      int64  `json:"planId"`
+	StepOrder   int64  `json:"stepOrder"`
+	NodeID      int64  `json:"nodeId"`
+	NodeType    string `json:"nodeType"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	Status      string `json:"status"`
+}
+
+func (q *Queries) CreateUpgradePlanStep(ctx context.Context, arg *CreateUpgradePlanStepParams) (*UpgradePlanStep, error) {
+	row := q.db.QueryRowContext(ctx, CreateUpgradePlanStep,
+		arg.PlanID,
+		arg.StepOrder,
+		arg.NodeID,
+		arg.NodeType,
+		arg.FromVersion,
+		arg.ToVersion,
+		arg.Status,
+	)
+	var i UpgradePlanStep
+	err := row.Scan(
+		&i.ID,
+		&i.PlanID,
+		&i.StepOrder,
+		&i.NodeID,
+		&i.NodeType,
+		&i.FromVersion,
+		&i.ToVersion,
+		&i.Status,
+		&i.Error,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return &i, err
+}
+
+const CreateUser = `-- name: CreateUser :one
+INSERT INTO users (
+    username, password, role, created_at, last_login_at, updated_at
+) VALUES (
+    ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+)
+RETURNING id, username, password, name, email, role, provider, provider_id, avatar_url, created_at, last_login_at, updated_at
+`
+
+type CreateUserParams struct {
+	Username string         `json:"username"`
+	Password string         `json:"password"`
+	Role     sql.NullString `json:"role"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
+	row := q.db.QueryRowContext(ctx, CreateUser, arg.Username, arg.Password, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.Name,
+		&i.Email,
+		&i.Role,
+		&i.Provider,
+		&i.ProviderID,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const DeleteAlertmanagerConfig = `-- name: DeleteAlertmanagerConfig :exec
+DELETE FROM alertmanager_config
+`
+
+func (q *Queries) DeleteAlertmanagerConfig(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, DeleteAlertmanagerConfig)
+	return err
+}
+
+const DeleteBackup = `-- name: DeleteBackup :exec
+DELETE FROM backups WHERE id = ?
+`
+
+func (q *Queries) DeleteBackup(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteBackup, id)
+	return err
+}
+
+const DeleteBackupSchedule = `-- name: DeleteBackupSchedule :exec
+DELETE FROM backup_schedules WHERE id = ?
+`
 
 func (q *Queries) DeleteBackupSchedule(ctx context.Context, id int64) error {
 	_, err := q.db.ExecContext(ctx, DeleteBackupSchedule, id)
@@ -1714,6 +2610,33 @@ func (q *Queries) DeleteBackupsByTarget(ctx context.Context, targetID int64) err
 	return err
 }
 
+const DeleteBenchmarkRun = `-- name: DeleteBenchmarkRun :exec
+DELETE FROM benchmark_runs WHERE id = ?
+`
+
+func (q *Queries) DeleteBenchmarkRun(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteBenchmarkRun, id)
+	return err
+}
+
+const DeleteBesuContract = `-- name: DeleteBesuContract :exec
+DELETE FROM besu_contracts WHERE id = ?
+`
+
+func (q *Queries) DeleteBesuContract(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteBesuContract, id)
+	return err
+}
+
+const DeleteBlockIndexer = `-- name: DeleteBlockIndexer :exec
+DELETE FROM block_indexers WHERE network_id = ?
+`
+
+func (q *Queries) DeleteBlockIndexer(ctx context.Context, networkID int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteBlockIndexer, networkID)
+	return err
+}
+
 const DeleteChaincode = `-- name: DeleteChaincode :exec
 DELETE FROM fabric_chaincodes WHERE id = ?
 `
@@ -1768,6 +2691,79 @@ func (q *Queries) DeleteFabricXNamespace(ctx context.Context, id int64) error {
 	return err
 }
 
+const DeleteGrafanaConfig = `-- name: DeleteGrafanaConfig :exec
+DELETE FROM grafana_config
+`
+
+func (q *Queries) DeleteGrafanaConfig(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, DeleteGrafanaConfig)
+	return err
+}
+
+const DeleteHost = `-- name: DeleteHost :exec
+DELETE FROM hosts WHERE id = ?
+`
+
+func (q *Queries) DeleteHost(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteHost, id)
+	return err
+}
+
+const DeleteIndexedBlock = `-- name: DeleteIndexedBlock :exec
+DELETE FROM indexed_blocks WHERE network_id = ? AND block_number = ?
+`
+
+type DeleteIndexedBlockParams struct {
+	NetworkID   int64 `json:"networkId"`
+	BlockNumber int64 `json:"blockNumber"`
+}
+
+func (q *Queries) DeleteIndexedBlock(ctx context.Context, arg *DeleteIndexedBlockParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteIndexedBlock, arg.NetworkID, arg.BlockNumber)
+	return err
+}
+
+const DeleteIndexedBlocksByNetwork = `-- name: DeleteIndexedBlocksByNetwork :exec
+DELETE FROM indexed_blocks WHERE network_id = ?
+`
+
+func (q *Queries) DeleteIndexedBlocksByNetwork(ctx context.Context, networkID int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteIndexedBlocksByNetwork, networkID)
+	return err
+}
+
+const DeleteIndexedTransactionKeysByNetwork = `-- name: DeleteIndexedTransactionKeysByNetwork :exec
+DELETE FROM indexed_transaction_keys WHERE network_id = ?
+`
+
+func (q *Queries) DeleteIndexedTransactionKeysByNetwork(ctx context.Context, networkID int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteIndexedTransactionKeysByNetwork, networkID)
+	return err
+}
+
+const DeleteIndexedTransactionsByBlock = `-- name: DeleteIndexedTransactionsByBlock :exec
+DELETE FROM indexed_transactions WHERE network_id = ? AND block_number = ?
+`
+
+type DeleteIndexedTransactionsByBlockParams struct {
+	NetworkID   int64 `json:"networkId"`
+	BlockNumber int64 `json:"blockNumber"`
+}
+
+func (q *Queries) DeleteIndexedTransactionsByBlock(ctx context.Context, arg *DeleteIndexedTransactionsByBlockParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteIndexedTransactionsByBlock, arg.NetworkID, arg.BlockNumber)
+	return err
+}
+
+const DeleteIndexedTransactionsByNetwork = `-- name: DeleteIndexedTransactionsByNetwork :exec
+DELETE FROM indexed_transactions WHERE network_id = ?
+`
+
+func (q *Queries) DeleteIndexedTransactionsByNetwork(ctx context.Context, networkID int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteIndexedTransactionsByNetwork, networkID)
+	return err
+}
+
 const DeleteKey = `-- name: DeleteKey :exec
 DELETE FROM keys WHERE id = ?
 `
@@ -1829,6 +2825,15 @@ func (q *Queries) DeleteNodeGroup(ctx context.Context, id int64) error {
 	return err
 }
 
+const DeleteNodeHost = `-- name: DeleteNodeHost :exec
+DELETE FROM node_hosts WHERE node_id = ?
+`
+
+func (q *Queries) DeleteNodeHost(ctx context.Context, nodeID int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteNodeHost, nodeID)
+	return err
+}
+
 const DeleteNotificationProvider = `-- name: DeleteNotificationProvider :exec
 DELETE FROM notification_providers
 WHERE id = ?
@@ -1864,6 +2869,30 @@ func (q *Queries) DeletePlugin(ctx context.Context, name string) error {
 	return err
 }
 
+const DeletePluginVersionsAfter = `-- name: DeletePluginVersionsAfter :exec
+DELETE FROM plugin_versions
+WHERE plugin_name = ? AND id > ?
+`
+
+type DeletePluginVersionsAfterParams struct {
+	PluginName string `json:"pluginName"`
+	ID         int64  `json:"id"`
+}
+
+func (q *Queries) DeletePluginVersionsAfter(ctx context.Context, arg *DeletePluginVersionsAfterParams) error {
+	_, err := q.db.ExecContext(ctx, DeletePluginVersionsAfter, arg.PluginName, arg.ID)
+	return err
+}
+
+const DeletePrometheusAlertRule = `-- name: DeletePrometheusAlertRule :exec
+DELETE FROM prometheus_alert_rules WHERE id = ?
+`
+
+func (q *Queries) DeletePrometheusAlertRule(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeletePrometheusAlertRule, id)
+	return err
+}
+
 const DeleteRevokedCertificate = `-- name: DeleteRevokedCertificate :exec
 DELETE FROM fabric_revoked_certificates
 WHERE fabric_organization_id = ? AND serial_number = ?
@@ -1925,6 +2954,30 @@ func (q *Queries) DeleteSetting(ctx context.Context, id int64) error {
 	return err
 }
 
+const DeleteTestnet = `-- name: DeleteTestnet :exec
+DELETE FROM testnets WHERE id = ?
+`
+
+func (q *Queries) DeleteTestnet(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteTestnet, id)
+	return err
+}
+
+const DeleteTestnetResource = `-- name: DeleteTestnetResource :exec
+DELETE FROM testnet_resources WHERE testnet_id = ? AND resource_type = ? AND resource_id = ?
+`
+
+type DeleteTestnetResourceParams struct {
+	TestnetID    int64  `json:"testnetId"`
+	ResourceType string `json:"resourceType"`
+	ResourceID   int64  `json:"resourceId"`
+}
+
+func (q *Queries) DeleteTestnetResource(ctx context.Context, arg *DeleteTestnetResourceParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteTestnetResource, arg.TestnetID, arg.ResourceType, arg.ResourceID)
+	return err
+}
+
 const DeleteUser = `-- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = ?
@@ -1998,6 +3051,67 @@ func (q *Queries) EnableBackupSchedule(ctx context.Context, id int64) (*BackupSc
 	return &i, err
 }
 
+const FailRunningBenchmarkRuns = `-- name: FailRunningBenchmarkRuns :exec
+UPDATE benchmark_runs
+SET status = 'FAILED',
+    error = ?,
+    finished_at = CURRENT_TIMESTAMP
+WHERE status = 'RUNNING'
+`
+
+func (q *Queries) FailRunningBenchmarkRuns(ctx context.Context, error sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, FailRunningBenchmarkRuns, error)
+	return err
+}
+
+const FinishBenchmarkRun = `-- name: FinishBenchmarkRun :exec
+UPDATE benchmark_runs
+SET status = ?,
+    report = ?,
+    error = ?,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type FinishBenchmarkRunParams struct {
+	Status string         `json:"status"`
+	Report sql.NullString `json:"report"`
+	Error  sql.NullString `json:"error"`
+	ID     int64          `json:"id"`
+}
+
+func (q *Queries) FinishBenchmarkRun(ctx context.Context, arg *FinishBenchmarkRunParams) error {
+	_, err := q.db.ExecContext(ctx, FinishBenchmarkRun,
+		arg.Status,
+		arg.Report,
+		arg.Error,
+		arg.ID,
+	)
+	return err
+}
+
+const GetAlertmanagerConfig = `-- name: GetAlertmanagerConfig :one
+SELECT id, alertmanager_port, alertmanager_version, deployment_mode, network_mode, webhook_url, webhook_token, default_rules_enabled, created_at, updated_at FROM alertmanager_config LIMIT 1
+`
+
+func (q *Queries) GetAlertmanagerConfig(ctx context.Context) (*AlertmanagerConfig, error) {
+	row := q.db.QueryRowContext(ctx, GetAlertmanagerConfig)
+	var i AlertmanagerConfig
+	err := row.Scan(
+		&i.ID,
+		&i.AlertmanagerPort,
+		&i.AlertmanagerVersion,
+		&i.DeploymentMode,
+		&i.NetworkMode,
+		&i.WebhookUrl,
+		&i.WebhookToken,
+		&i.DefaultRulesEnabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetAllKeys = `-- name: GetAllKeys :many
 SELECT k.id, k.name, k.description, k.algorithm, k.key_size, k.curve, k.format, k.public_key, k.private_key, k.certificate, k.status, k.created_at, k.updated_at, k.expires_at, k.last_rotated_at, k.signing_key_id, k.sha256_fingerprint, k.sha1_fingerprint, k.provider_id, k.user_id, k.is_ca, k.ethereum_address, kp.name as provider_name, kp.type as provider_type
 FROM keys k
@@ -2364,6 +3478,107 @@ func (q *Queries) GetBackupsByStatus(ctx context.Context, status string) ([]*Bac
 	return items, nil
 }
 
+const GetBenchmarkRun = `-- name: GetBenchmarkRun :one
+SELECT id, name, platform, config, status, report, error, started_at, finished_at FROM benchmark_runs WHERE id = ?
+`
+
+func (q *Queries) GetBenchmarkRun(ctx context.Context, id int64) (*BenchmarkRun, error) {
+	row := q.db.QueryRowContext(ctx, GetBenchmarkRun, id)
+	var i BenchmarkRun
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Platform,
+		&i.Config,
+		&i.Status,
+		&i.Report,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return &i, err
+}
+
+const GetBesuContract = `-- name: GetBesuContract :one
+SELECT id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id FROM besu_contracts WHERE id = ?
+`
+
+func (q *Queries) GetBesuContract(ctx context.Context, id int64) (*BesuContract, error) {
+	row := q.db.QueryRowContext(ctx, GetBesuContract, id)
+	var i BesuContract
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Name,
+		&i.Address,
+		&i.Abi,
+		&i.BytecodeHash,
+		&i.DeployerKeyID,
+		&i.DeployTxHash,
+		&i.DeployBlockNumber,
+		&i.IsProxy,
+		&i.ImplementationAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+	)
+	return &i, err
+}
+
+const GetBesuContractByAddress = `-- name: GetBesuContractByAddress :one
+SELECT id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id FROM besu_contracts WHERE network_id = ? AND address = ?
+`
+
+type GetBesuContractByAddressParams struct {
+	NetworkID int64  `json:"networkId"`
+	Address   string `json:"address"`
+}
+
+func (q *Queries) GetBesuContractByAddress(ctx context.Context, arg *GetBesuContractByAddressParams) (*BesuContract, error) {
+	row := q.db.QueryRowContext(ctx, GetBesuContractByAddress,
+		arg.NetworkID,
+		arg.Address,
+	)
+	var i BesuContract
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Name,
+		&i.Address,
+		&i.Abi,
+		&i.BytecodeHash,
+		&i.DeployerKeyID,
+		&i.DeployTxHash,
+		&i.DeployBlockNumber,
+		&i.IsProxy,
+		&i.ImplementationAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+	)
+	return &i, err
+}
+
+const GetBlockIndexer = `-- name: GetBlockIndexer :one
+SELECT id, network_id, enabled, next_block, last_error, last_indexed_at, created_at, updated_at FROM block_indexers WHERE network_id = ? LIMIT 1
+`
+
+func (q *Queries) GetBlockIndexer(ctx context.Context, networkID int64) (*BlockIndexer, error) {
+	row := q.db.QueryRowContext(ctx, GetBlockIndexer, networkID)
+	var i BlockIndexer
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Enabled,
+		&i.NextBlock,
+		&i.LastError,
+		&i.LastIndexedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetChaincode = `-- name: GetChaincode :one
 SELECT fc.id, fc.name, fc.network_id, fc.created_at, n.id as network_id, n.name as network_name, n.platform as network_platform
 FROM fabric_chaincodes fc
@@ -2416,15 +3631,65 @@ func (q *Queries) GetChaincodeDefinition(ctx context.Context, id int64) (*Fabric
 	return &i, err
 }
 
-const GetDefaultNotificationProvider = `-- name: GetDefaultNotificationProvider :one
-SELECT id, name, type, config, is_default, is_enabled, created_at, updated_at, notify_node_downtime, notify_backup_success, notify_backup_failure, notify_s3_connection_issue, last_test_at, last_test_status, last_test_message, notify_disk_space_warning FROM notification_providers
-WHERE is_default = 1 AND type = ?
-LIMIT 1
+const GetConsensusMigration = `-- name: GetConsensusMigration :one
+SELECT id, network_id, target_consensus, status, config, error, created_at, started_at, completed_at, updated_at FROM consensus_migrations WHERE id = ?
 `
 
-func (q *Queries) GetDefaultNotificationProvider(ctx context.Context, type_ string) (*NotificationProvider, error) {
-	row := q.db.QueryRowContext(ctx, GetDefaultNotificationProvider, type_)
-	var i NotificationProvider
+func (q *Queries) GetConsensusMigration(ctx context.Context, id int64) (*ConsensusMigration, error) {
+	row := q.db.QueryRowContext(ctx, GetConsensusMigration, id)
+	var i ConsensusMigration
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.TargetConsensus,
+		&i.Status,
+		&i.Config,
+		&i.Error,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetCurrentPluginVersion = `-- name: GetCurrentPluginVersion :one
+SELECT id, plugin_name, version, source, api_version, kind, metadata, spec, parameters, deployed_at, created_at, manifest, signature FROM plugin_versions
+WHERE plugin_name = ?
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetCurrentPluginVersion(ctx context.Context, pluginName string) (*PluginVersion, error) {
+	row := q.db.QueryRowContext(ctx, GetCurrentPluginVersion, pluginName)
+	var i PluginVersion
+	err := row.Scan(
+		&i.ID,
+		&i.PluginName,
+		&i.Version,
+		&i.Source,
+		&i.ApiVersion,
+		&i.Kind,
+		&i.Metadata,
+		&i.Spec,
+		&i.Parameters,
+		&i.DeployedAt,
+		&i.CreatedAt,
+		&i.Manifest,
+		&i.Signature,
+	)
+	return &i, err
+}
+
+const GetDefaultNotificationProvider = `-- name: GetDefaultNotificationProvider :one
+SELECT id, name, type, config, is_default, is_enabled, created_at, updated_at, notify_node_downtime, notify_backup_success, notify_backup_failure, notify_s3_connection_issue, last_test_at, last_test_status, last_test_message, notify_disk_space_warning FROM notification_providers
+WHERE is_default = 1 AND type = ?
+LIMIT 1
+`
+
+func (q *Queries) GetDefaultNotificationProvider(ctx context.Context, type_ string) (*NotificationProvider, error) {
+	row := q.db.QueryRowContext(ctx, GetDefaultNotificationProvider, type_)
+	var i NotificationProvider
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
@@ -2822,6 +4087,86 @@ func (q *Queries) GetFabricXNamespaceByName(ctx context.Context, arg *GetFabricX
 	return &i, err
 }
 
+const GetGrafanaConfig = `-- name: GetGrafanaConfig :one
+SELECT id, grafana_port, grafana_version, deployment_mode, network_mode, admin_user, admin_password, created_at, updated_at FROM grafana_config LIMIT 1
+`
+
+func (q *Queries) GetGrafanaConfig(ctx context.Context) (*GrafanaConfig, error) {
+	row := q.db.QueryRowContext(ctx, GetGrafanaConfig)
+	var i GrafanaConfig
+	err := row.Scan(
+		&i.ID,
+		&i.GrafanaPort,
+		&i.GrafanaVersion,
+		&i.DeploymentMode,
+		&i.NetworkMode,
+		&i.AdminUser,
+		&i.AdminPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetHost = `-- name: GetHost :one
+SELECT id, name, description, address, port, username, ssh_key_id, host_key, data_path, status, last_error, last_checked_at, created_at, updated_at FROM hosts WHERE id = ?
+`
+
+func (q *Queries) GetHost(ctx context.Context, id int64) (*Host, error) {
+	row := q.db.QueryRowContext(ctx, GetHost, id)
+	var i Host
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Address,
+		&i.Port,
+		&i.Username,
+		&i.SshKeyID,
+		&i.HostKey,
+		&i.DataPath,
+		&i.Status,
+		&i.LastError,
+		&i.LastCheckedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetIndexedTransactionByTxID = `-- name: GetIndexedTransactionByTxID :one
+SELECT id, network_id, block_number, tx_index, tx_id, tx_type, tx_timestamp, chaincode, function, creator_msp_id, validation_code, event_name, created_at FROM indexed_transactions
+WHERE network_id = ? AND tx_id = ?
+ORDER BY block_number
+LIMIT 1
+`
+
+type GetIndexedTransactionByTxIDParams struct {
+	NetworkID int64  `json:"networkId"`
+	TxID      string `json:"txId"`
+}
+
+func (q *Queries) GetIndexedTransactionByTxID(ctx context.Context, arg *GetIndexedTransactionByTxIDParams) (*IndexedTransaction, error) {
+	row := q.db.QueryRowContext(ctx, GetIndexedTransactionByTxID, arg.NetworkID, arg.TxID)
+	var i IndexedTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.BlockNumber,
+		&i.TxIndex,
+		&i.TxID,
+		&i.TxType,
+		&i.TxTimestamp,
+		&i.Chaincode,
+		&i.Function,
+		&i.CreatorMspID,
+		&i.ValidationCode,
+		&i.EventName,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const GetKey = `-- name: GetKey :one
 SELECT k.id, k.name, k.description, k.algorithm, k.key_size, k.curve, k.format, k.public_key, k.private_key, k.certificate, k.status, k.created_at, k.updated_at, k.expires_at, k.last_rotated_at, k.signing_key_id, k.sha256_fingerprint, k.sha1_fingerprint, k.provider_id, k.user_id, k.is_ca, k.ethereum_address, kp.name as provider_name, kp.type as provider_type
 FROM keys k
@@ -3613,6 +4958,21 @@ func (q *Queries) GetNodeGroupPostgresServiceID(ctx context.Context, id int64) (
 	return postgres_service_id, err
 }
 
+const GetNodeHost = `-- name: GetNodeHost :one
+SELECT node_id, host_id, created_at FROM node_hosts WHERE node_id = ?
+`
+
+func (q *Queries) GetNodeHost(ctx context.Context, nodeID int64) (*NodeHost, error) {
+	row := q.db.QueryRowContext(ctx, GetNodeHost, nodeID)
+	var i NodeHost
+	err := row.Scan(
+		&i.NodeID,
+		&i.HostID,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const GetNotificationProvider = `-- name: GetNotificationProvider :one
 SELECT id, name, type, config, is_default, is_enabled, created_at, updated_at, notify_node_downtime, notify_backup_success, notify_backup_failure, notify_s3_connection_issue, last_test_at, last_test_status, last_test_message, notify_disk_space_warning FROM notification_providers
 WHERE id = ? LIMIT 1
@@ -3776,6 +5136,63 @@ func (q *Queries) GetPlugin(ctx context.Context, name string) (*Plugin, error) {
 	return &i, err
 }
 
+const GetPreviousDeployedPluginVersion = `-- name: GetPreviousDeployedPluginVersion :one
+SELECT id, plugin_name, version, source, api_version, kind, metadata, spec, parameters, deployed_at, created_at, manifest, signature FROM plugin_versions
+WHERE plugin_name = ? AND id < ? AND deployed_at IS NOT NULL
+ORDER BY id DESC
+LIMIT 1
+`
+
+type GetPreviousDeployedPluginVersionParams struct {
+	PluginName string `json:"pluginName"`
+	ID         int64  `json:"id"`
+}
+
+func (q *Queries) GetPreviousDeployedPluginVersion(ctx context.Context, arg *GetPreviousDeployedPluginVersionParams) (*PluginVersion, error) {
+	row := q.db.QueryRowContext(ctx, GetPreviousDeployedPluginVersion, arg.PluginName, arg.ID)
+	var i PluginVersion
+	err := row.Scan(
+		&i.ID,
+		&i.PluginName,
+		&i.Version,
+		&i.Source,
+		&i.ApiVersion,
+		&i.Kind,
+		&i.Metadata,
+		&i.Spec,
+		&i.Parameters,
+		&i.DeployedAt,
+		&i.CreatedAt,
+		&i.Manifest,
+		&i.Signature,
+	)
+	return &i, err
+}
+
+const GetPrometheusAlertRule = `-- name: GetPrometheusAlertRule :one
+SELECT id, group_name, name, expr, for_duration, severity, summary, description, labels, enabled, created_at, updated_at FROM prometheus_alert_rules WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetPrometheusAlertRule(ctx context.Context, id int64) (*PrometheusAlertRule, error) {
+	row := q.db.QueryRowContext(ctx, GetPrometheusAlertRule, id)
+	var i PrometheusAlertRule
+	err := row.Scan(
+		&i.ID,
+		&i.GroupName,
+		&i.Name,
+		&i.Expr,
+		&i.ForDuration,
+		&i.Severity,
+		&i.Summary,
+		&i.Description,
+		&i.Labels,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetPrometheusConfig = `-- name: GetPrometheusConfig :one
 SELECT 
     id,
@@ -4189,6 +5606,67 @@ func (q *Queries) GetSetting(ctx context.Context, id int64) (*Setting, error) {
 	return &i, err
 }
 
+const GetTestnet = `-- name: GetTestnet :one
+SELECT id, name, platform, status, last_error, created_at, updated_at FROM testnets WHERE id = ?
+`
+
+func (q *Queries) GetTestnet(ctx context.Context, id int64) (*Testnet, error) {
+	row := q.db.QueryRowContext(ctx, GetTestnet, id)
+	var i Testnet
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Platform,
+		&i.Status,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetTestnetByName = `-- name: GetTestnetByName :one
+SELECT id, name, platform, status, last_error, created_at, updated_at FROM testnets WHERE name = ?
+`
+
+func (q *Queries) GetTestnetByName(ctx context.Context, name string) (*Testnet, error) {
+	row := q.db.QueryRowContext(ctx, GetTestnetByName, name)
+	var i Testnet
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Platform,
+		&i.Status,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetUpgradePlan = `-- name: GetUpgradePlan :one
+SELECT id, network_id, target_version, status, health_timeout_seconds, allow_downtime, error, created_at, started_at, completed_at, updated_at FROM upgrade_plans WHERE id = ?
+`
+
+func (q *Queries) GetUpgradePlan(ctx context.Context, id int64) (*UpgradePlan, error) {
+	row := q.db.QueryRowContext(ctx, GetUpgradePlan, id)
+	var i UpgradePlan
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.TargetVersion,
+		&i.Status,
+		&i.HealthTimeoutSeconds,
+		&i.AllowDowntime,
+		&i.Error,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetUser = `-- name: GetUser :one
 SELECT id, username, password, name, email, role, provider, provider_id, avatar_url, created_at, last_login_at, updated_at FROM users
 WHERE id = ? LIMIT 1
@@ -4518,25 +5996,29 @@ func (q *Queries) ListBackupsByTarget(ctx context.Context, targetID int64) ([]*B
 	return items, nil
 }
 
-const ListChaincodeDefinitionEvents = `-- name: ListChaincodeDefinitionEvents :many
-SELECT id, definition_id, event_type, event_data, created_at FROM fabric_chaincode_definition_events WHERE definition_id = ? ORDER BY created_at ASC
+const ListBenchmarkRuns = `-- name: ListBenchmarkRuns :many
+SELECT id, name, platform, config, status, report, error, started_at, finished_at FROM benchmark_runs ORDER BY started_at DESC, id DESC
 `
 
-func (q *Queries) ListChaincodeDefinitionEvents(ctx context.Context, definitionID int64) ([]*FabricChaincodeDefinitionEvent, error) {
-	rows, err := q.db.QueryContext(ctx, ListChaincodeDefinitionEvents, definitionID)
+func (q *Queries) ListBenchmarkRuns(ctx context.Context) ([]*BenchmarkRun, error) {
+	rows, err := q.db.QueryContext(ctx, ListBenchmarkRuns)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*FabricChaincodeDefinitionEvent{}
+	items := []*BenchmarkRun{}
 	for rows.Next() {
-		var i FabricChaincodeDefinitionEvent
+		var i BenchmarkRun
 		if err := rows.Scan(
 			&i.ID,
-			&i.DefinitionID,
-			&i.EventType,
-			&i.EventData,
-			&i.CreatedAt,
+			&i.Name,
+			&i.Platform,
+			&i.Config,
+			&i.Status,
+			&i.Report,
+			&i.Error,
+			&i.StartedAt,
+			&i.FinishedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -4551,27 +6033,26 @@ func (q *Queries) ListChaincodeDefinitionEvents(ctx context.Context, definitionI
 	return items, nil
 }
 
-const ListChaincodeDefinitions = `-- name: ListChaincodeDefinitions :many
-SELECT id, chaincode_id, version, sequence, docker_image, endorsement_policy, chaincode_address, created_at FROM fabric_chaincode_definitions WHERE chaincode_id = ? ORDER BY id
+const ListBesuContractUpgrades = `-- name: ListBesuContractUpgrades :many
+SELECT id, contract_id, previous_implementation, implementation_address, bytecode_hash, tx_hash, created_at FROM besu_contract_upgrades WHERE contract_id = ? ORDER BY id DESC
 `
 
-func (q *Queries) ListChaincodeDefinitions(ctx context.Context, chaincodeID int64) ([]*FabricChaincodeDefinition, error) {
-	rows, err := q.db.QueryContext(ctx, ListChaincodeDefinitions, chaincodeID)
+func (q *Queries) ListBesuContractUpgrades(ctx context.Context, contractID int64) ([]*BesuContractUpgrade, error) {
+	rows, err := q.db.QueryContext(ctx, ListBesuContractUpgrades, contractID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*FabricChaincodeDefinition{}
+	items := []*BesuContractUpgrade{}
 	for rows.Next() {
-		var i FabricChaincodeDefinition
+		var i BesuContractUpgrade
 		if err := rows.Scan(
 			&i.ID,
-			&i.ChaincodeID,
-			&i.Version,
-			&i.Sequence,
-			&i.DockerImage,
-			&i.EndorsementPolicy,
-			&i.ChaincodeAddress,
+			&i.ContractID,
+			&i.PreviousImplementation,
+			&i.ImplementationAddress,
+			&i.BytecodeHash,
+			&i.TxHash,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -4587,24 +6068,34 @@ func (q *Queries) ListChaincodeDefinitions(ctx context.Context, chaincodeID int6
 	return items, nil
 }
 
-const ListChaincodes = `-- name: ListChaincodes :many
-SELECT id, name, network_id, created_at FROM fabric_chaincodes ORDER BY created_at DESC
+const ListBesuContracts = `-- name: ListBesuContracts :many
+SELECT id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id FROM besu_contracts ORDER BY id DESC
 `
 
-func (q *Queries) ListChaincodes(ctx context.Context) ([]*FabricChaincode, error) {
-	rows, err := q.db.QueryContext(ctx, ListChaincodes)
+func (q *Queries) ListBesuContracts(ctx context.Context) ([]*BesuContract, error) {
+	rows, err := q.db.QueryContext(ctx, ListBesuContracts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*FabricChaincode{}
+	items := []*BesuContract{}
 	for rows.Next() {
-		var i FabricChaincode
+		var i BesuContract
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
 			&i.NetworkID,
+			&i.Name,
+			&i.Address,
+			&i.Abi,
+			&i.BytecodeHash,
+			&i.DeployerKeyID,
+			&i.DeployTxHash,
+			&i.DeployBlockNumber,
+			&i.IsProxy,
+			&i.ImplementationAddress,
 			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -4619,24 +6110,34 @@ func (q *Queries) ListChaincodes(ctx context.Context) ([]*FabricChaincode, error
 	return items, nil
 }
 
-const ListFabricChaincodes = `-- name: ListFabricChaincodes :many
-SELECT id, name, network_id, created_at FROM fabric_chaincodes ORDER BY created_at DESC
+const ListBesuContractsByNetwork = `-- name: ListBesuContractsByNetwork :many
+SELECT id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id FROM besu_contracts WHERE network_id = ? ORDER BY id DESC
 `
 
-func (q *Queries) ListFabricChaincodes(ctx context.Context) ([]*FabricChaincode, error) {
-	rows, err := q.db.QueryContext(ctx, ListFabricChaincodes)
+func (q *Queries) ListBesuContractsByNetwork(ctx context.Context, networkID int64) ([]*BesuContract, error) {
+	rows, err := q.db.QueryContext(ctx, ListBesuContractsByNetwork, networkID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*FabricChaincode{}
+	items := []*BesuContract{}
 	for rows.Next() {
-		var i FabricChaincode
+		var i BesuContract
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
 			&i.NetworkID,
+			&i.Name,
+			&i.Address,
+			&i.Abi,
+			&i.BytecodeHash,
+			&i.DeployerKeyID,
+			&i.DeployTxHash,
+			&i.DeployBlockNumber,
+			&i.IsProxy,
+			&i.ImplementationAddress,
 			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -4651,37 +6152,34 @@ func (q *Queries) ListFabricChaincodes(ctx context.Context) ([]*FabricChaincode,
 	return items, nil
 }
 
-const ListFabricOrganizations = `-- name: ListFabricOrganizations :many
-SELECT id, msp_id, description, config, ca_config, sign_key_id, tls_root_key_id, admin_tls_key_id, admin_sign_key_id, client_sign_key_id, provider_id, created_at, created_by, updated_at, crl_key_id, crl_last_update FROM fabric_organizations
-ORDER BY created_at DESC
+const ListBesuContractsByProject = `-- name: ListBesuContractsByProject :many
+SELECT id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id FROM besu_contracts WHERE project_id = ? ORDER BY id DESC
 `
 
-func (q *Queries) ListFabricOrganizations(ctx context.Context) ([]*FabricOrganization, error) {
-	rows, err := q.db.QueryContext(ctx, ListFabricOrganizations)
+func (q *Queries) ListBesuContractsByProject(ctx context.Context, projectID sql.NullInt64) ([]*BesuContract, error) {
+	rows, err := q.db.QueryContext(ctx, ListBesuContractsByProject, projectID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*FabricOrganization{}
+	items := []*BesuContract{}
 	for rows.Next() {
-		var i FabricOrganization
+		var i BesuContract
 		if err := rows.Scan(
 			&i.ID,
-			&i.MspID,
-			&i.Description,
-			&i.Config,
-			&i.CaConfig,
-			&i.SignKeyID,
-			&i.TlsRootKeyID,
-			&i.AdminTlsKeyID,
-			&i.AdminSignKeyID,
-			&i.ClientSignKeyID,
-			&i.ProviderID,
+			&i.NetworkID,
+			&i.Name,
+			&i.Address,
+			&i.Abi,
+			&i.BytecodeHash,
+			&i.DeployerKeyID,
+			&i.DeployTxHash,
+			&i.DeployBlockNumber,
+			&i.IsProxy,
+			&i.ImplementationAddress,
 			&i.CreatedAt,
-			&i.CreatedBy,
 			&i.UpdatedAt,
-			&i.CrlKeyID,
-			&i.CrlLastUpdate,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -4696,34 +6194,438 @@ func (q *Queries) ListFabricOrganizations(ctx context.Context) ([]*FabricOrganiz
 	return items, nil
 }
 
-const ListFabricOrganizationsWithKeys = `-- name: ListFabricOrganizationsWithKeys :many
-SELECT 
-    fo.id, fo.msp_id, fo.description, fo.config, fo.ca_config, fo.sign_key_id, fo.tls_root_key_id, fo.admin_tls_key_id, fo.admin_sign_key_id, fo.client_sign_key_id, fo.provider_id, fo.created_at, fo.created_by, fo.updated_at, fo.crl_key_id, fo.crl_last_update,
-    sk.public_key as sign_public_key,
-    sk.certificate as sign_certificate,
-    tk.public_key as tls_public_key,
-    tk.certificate as tls_certificate,
-    p.name as provider_name
-FROM fabric_organizations fo
-LEFT JOIN keys sk ON fo.sign_key_id = sk.id
-LEFT JOIN keys tk ON fo.tls_root_key_id = tk.id
-LEFT JOIN key_providers p ON fo.provider_id = p.id
-ORDER BY fo.created_at DESC
-LIMIT ? OFFSET ?
+const ListBlockIndexers = `-- name: ListBlockIndexers :many
+SELECT id, network_id, enabled, next_block, last_error, last_indexed_at, created_at, updated_at FROM block_indexers ORDER BY network_id
 `
 
-type ListFabricOrganizationsWithKeysParams struct {
-	Limit  int64 `json:"limit"`
-	Offset int64 `json:"offset"`
-}
-
-type ListFabricOrganizationsWithKeysRow struct {
-	ID              int64          `json:"id"`
-	MspID           string         `json:"mspId"`
-	Description     sql.NullString `json:"description"`
-	Config          sql.NullString `json:"config"`
-	CaConfig        sql.NullString `json:"caConfig"`
-	SignKeyID       sql.NullInt64  `json:"signKeyId"`
+func (q *Queries) ListBlockIndexers(ctx context.Context) ([]*BlockIndexer, error) {
+	rows, err := q.db.QueryContext(ctx, ListBlockIndexers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*BlockIndexer{}
+	for rows.Next() {
+		var i BlockIndexer
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.Enabled,
+			&i.NextBlock,
+			&i.LastError,
+			&i.LastIndexedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListChaincodeDefinitionEvents = `-- name: ListChaincodeDefinitionEvents :many
+SELECT id, definition_id, event_type, event_data, created_at FROM fabric_chaincode_definition_events WHERE definition_id = ? ORDER BY created_at ASC
+`
+
+func (q *Queries) ListChaincodeDefinitionEvents(ctx context.Context, definitionID int64) ([]*FabricChaincodeDefinitionEvent, error) {
+	rows, err := q.db.QueryContext(ctx, ListChaincodeDefinitionEvents, definitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*FabricChaincodeDefinitionEvent{}
+	for rows.Next() {
+		var i FabricChaincodeDefinitionEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.DefinitionID,
+			&i.EventType,
+			&i.EventData,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListChaincodeDefinitions = `-- name: ListChaincodeDefinitions :many
+SELECT id, chaincode_id, version, sequence, docker_image, endorsement_policy, chaincode_address, created_at FROM fabric_chaincode_definitions WHERE chaincode_id = ? ORDER BY id
+`
+
+func (q *Queries) ListChaincodeDefinitions(ctx context.Context, chaincodeID int64) ([]*FabricChaincodeDefinition, error) {
+	rows, err := q.db.QueryContext(ctx, ListChaincodeDefinitions, chaincodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*FabricChaincodeDefinition{}
+	for rows.Next() {
+		var i FabricChaincodeDefinition
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChaincodeID,
+			&i.Version,
+			&i.Sequence,
+			&i.DockerImage,
+			&i.EndorsementPolicy,
+			&i.ChaincodeAddress,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListChaincodes = `-- name: ListChaincodes :many
+SELECT id, name, network_id, created_at FROM fabric_chaincodes ORDER BY created_at DESC
+`
+
+func (q *Queries) ListChaincodes(ctx context.Context) ([]*FabricChaincode, error) {
+	rows, err := q.db.QueryContext(ctx, ListChaincodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*FabricChaincode{}
+	for rows.Next() {
+		var i FabricChaincode
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.NetworkID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListConsensusMigrationSteps = `-- name: ListConsensusMigrationSteps :many
+SELECT id, migration_id, step_order, name, status, error, started_at, completed_at FROM consensus_migration_steps WHERE migration_id = ? ORDER BY step_order
+`
+
+func (q *Queries) ListConsensusMigrationSteps(ctx context.Context, migrationID int64) ([]*ConsensusMigrationStep, error) {
+	rows, err := q.db.QueryContext(ctx, ListConsensusMigrationSteps, migrationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ConsensusMigrationStep{}
+	for rows.Next() {
+		var i ConsensusMigrationStep
+		if err := rows.Scan(
+			&i.ID,
+			&i.MigrationID,
+			&i.StepOrder,
+			&i.Name,
+			&i.Status,
+			&i.Error,
+			&i.StartedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListConsensusMigrationsByNetwork = `-- name: ListConsensusMigrationsByNetwork :many
+SELECT id, network_id, target_consensus, status, config, error, created_at, started_at, completed_at, updated_at FROM consensus_migrations WHERE network_id = ? ORDER BY id DESC
+`
+
+func (q *Queries) ListConsensusMigrationsByNetwork(ctx context.Context, networkID int64) ([]*ConsensusMigration, error) {
+	rows, err := q.db.QueryContext(ctx, ListConsensusMigrationsByNetwork, networkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ConsensusMigration{}
+	for rows.Next() {
+		var i ConsensusMigration
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.TargetConsensus,
+			&i.Status,
+			&i.Config,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListConsensusMigrationsByStatus = `-- name: ListConsensusMigrationsByStatus :many
+SELECT id, network_id, target_consensus, status, config, error, created_at, started_at, completed_at, updated_at FROM consensus_migrations WHERE status = ? ORDER BY id
+`
+
+func (q *Queries) ListConsensusMigrationsByStatus(ctx context.Context, status string) ([]*ConsensusMigration, error) {
+	rows, err := q.db.QueryContext(ctx, ListConsensusMigrationsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ConsensusMigration{}
+	for rows.Next() {
+		var i ConsensusMigration
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.TargetConsensus,
+			&i.Status,
+			&i.Config,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListEnabledBlockIndexers = `-- name: ListEnabledBlockIndexers :many
+SELECT id, network_id, enabled, next_block, last_error, last_indexed_at, created_at, updated_at FROM block_indexers WHERE enabled = true ORDER BY network_id
+`
+
+func (q *Queries) ListEnabledBlockIndexers(ctx context.Context) ([]*BlockIndexer, error) {
+	rows, err := q.db.QueryContext(ctx, ListEnabledBlockIndexers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*BlockIndexer{}
+	for rows.Next() {
+		var i BlockIndexer
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.Enabled,
+			&i.NextBlock,
+			&i.LastError,
+			&i.LastIndexedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListEnabledPrometheusAlertRules = `-- name: ListEnabledPrometheusAlertRules :many
+SELECT id, group_name, name, expr, for_duration, severity, summary, description, labels, enabled, created_at, updated_at FROM prometheus_alert_rules
+WHERE enabled = true
+ORDER BY group_name ASC, name ASC
+`
+
+func (q *Queries) ListEnabledPrometheusAlertRules(ctx context.Context) ([]*PrometheusAlertRule, error) {
+	rows, err := q.db.QueryContext(ctx, ListEnabledPrometheusAlertRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*PrometheusAlertRule{}
+	for rows.Next() {
+		var i PrometheusAlertRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.GroupName,
+			&i.Name,
+			&i.Expr,
+			&i.ForDuration,
+			&i.Severity,
+			&i.Summary,
+			&i.Description,
+			&i.Labels,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListFabricChaincodes = `-- name: ListFabricChaincodes :many
+SELECT id, name, network_id, created_at FROM fabric_chaincodes ORDER BY created_at DESC
+`
+
+func (q *Queries) ListFabricChaincodes(ctx context.Context) ([]*FabricChaincode, error) {
+	rows, err := q.db.QueryContext(ctx, ListFabricChaincodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*FabricChaincode{}
+	for rows.Next() {
+		var i FabricChaincode
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.NetworkID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListFabricOrganizations = `-- name: ListFabricOrganizations :many
+SELECT id, msp_id, description, config, ca_config, sign_key_id, tls_root_key_id, admin_tls_key_id, admin_sign_key_id, client_sign_key_id, provider_id, created_at, created_by, updated_at, crl_key_id, crl_last_update FROM fabric_organizations
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListFabricOrganizations(ctx context.Context) ([]*FabricOrganization, error) {
+	rows, err := q.db.QueryContext(ctx, ListFabricOrganizations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*FabricOrganization{}
+	for rows.Next() {
+		var i FabricOrganization
+		if err := rows.Scan(
+			&i.ID,
+			&i.MspID,
+			&i.Description,
+			&i.Config,
+			&i.CaConfig,
+			&i.SignKeyID,
+			&i.TlsRootKeyID,
+			&i.AdminTlsKeyID,
+			&i.AdminSignKeyID,
+			&i.ClientSignKeyID,
+			&i.ProviderID,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+			&i.CrlKeyID,
+			&i.CrlLastUpdate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListFabricOrganizationsWithKeys = `-- name: ListFabricOrganizationsWithKeys :many
+SELECT 
+    fo.id, fo.msp_id, fo.description, fo.config, fo.ca_config, fo.sign_key_id, fo.tls_root_key_id, fo.admin_tls_key_id, fo.admin_sign_key_id, fo.client_sign_key_id, fo.provider_id, fo.created_at, fo.created_by, fo.updated_at, fo.crl_key_id, fo.crl_last_update,
+    sk.public_key as sign_public_key,
+    sk.certificate as sign_certificate,
+    tk.public_key as tls_public_key,
+    tk.certificate as tls_certificate,
+    p.name as provider_name
+FROM fabric_organizations fo
+LEFT JOIN keys sk ON fo.sign_key_id = sk.id
+LEFT JOIN keys tk ON fo.tls_root_key_id = tk.id
+LEFT JOIN key_providers p ON fo.provider_id = p.id
+ORDER BY fo.created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListFabricOrganizationsWithKeysParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+type ListFabricOrganizationsWithKeysRow struct {
+	ID              int64          `json:"id"`
+	MspID           string         `json:"mspId"`
+	Description     sql.NullString `json:"description"`
+	Config          sql.NullString `json:"config"`
+	CaConfig        sql.NullString `json:"caConfig"`
+	SignKeyID       sql.NullInt64  `json:"signKeyId"`
 	TlsRootKeyID    sql.NullInt64  `json:"tlsRootKeyId"`
 	AdminTlsKeyID   sql.NullInt64  `json:"adminTlsKeyId"`
 	AdminSignKeyID  sql.NullInt64  `json:"adminSignKeyId"`
@@ -4825,6 +6727,82 @@ func (q *Queries) ListFabricXNamespacesByNetwork(ctx context.Context, networkID
 	return items, nil
 }
 
+const ListHosts = `-- name: ListHosts :many
+SELECT id, name, description, address, port, username, ssh_key_id, host_key, data_path, status, last_error, last_checked_at, created_at, updated_at FROM hosts ORDER BY name
+`
+
+func (q *Queries) ListHosts(ctx context.Context) ([]*Host, error) {
+	rows, err := q.db.QueryContext(ctx, ListHosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Host{}
+	for rows.Next() {
+		var i Host
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Address,
+			&i.Port,
+			&i.Username,
+			&i.SshKeyID,
+			&i.HostKey,
+			&i.DataPath,
+			&i.Status,
+			&i.LastError,
+			&i.LastCheckedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListIndexedTransactionKeys = `-- name: ListIndexedTransactionKeys :many
+SELECT id, transaction_id, network_id, namespace, key, access FROM indexed_transaction_keys WHERE transaction_id = ? ORDER BY id
+`
+
+func (q *Queries) ListIndexedTransactionKeys(ctx context.Context, transactionID int64) ([]*IndexedTransactionKey, error) {
+	rows, err := q.db.QueryContext(ctx, ListIndexedTransactionKeys, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*IndexedTransactionKey{}
+	for rows.Next() {
+		var i IndexedTransactionKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.NetworkID,
+			&i.Namespace,
+			&i.Key,
+			&i.Access,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListKeyProviders = `-- name: ListKeyProviders :many
 SELECT id, name, type, is_default, config, created_at, updated_at FROM key_providers
 `
@@ -5385,6 +7363,37 @@ func (q *Queries) ListNodeGroupsByPostgresServiceID(ctx context.Context, postgre
 	return items, nil
 }
 
+const ListNodeHostsByHost = `-- name: ListNodeHostsByHost :many
+SELECT node_id, host_id, created_at FROM node_hosts WHERE host_id = ? ORDER BY node_id
+`
+
+func (q *Queries) ListNodeHostsByHost(ctx context.Context, hostID int64) ([]*NodeHost, error) {
+	rows, err := q.db.QueryContext(ctx, ListNodeHostsByHost, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*NodeHost{}
+	for rows.Next() {
+		var i NodeHost
+		if err := rows.Scan(
+			&i.NodeID,
+			&i.HostID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListNodes = `-- name: ListNodes :many
 SELECT id, name, slug, platform, status, description, network_id, config, resources, endpoint, public_endpoint, p2p_address, created_at, created_by, updated_at, fabric_organization_id, node_type, node_config, deployment_config, error_message, node_group_id FROM nodes
 ORDER BY created_at DESC
@@ -5686,29 +7695,113 @@ func (q *Queries) ListPeerStatuses(ctx context.Context, definitionID int64) ([]*
 	return items, nil
 }
 
-const ListPlugins = `-- name: ListPlugins :many
-SELECT name, api_version, kind, metadata, spec, created_at, updated_at, deployment_metadata, deployment_status FROM plugins ORDER BY name
+const ListPluginVersions = `-- name: ListPluginVersions :many
+SELECT id, plugin_name, version, source, api_version, kind, metadata, spec, parameters, deployed_at, created_at, manifest, signature FROM plugin_versions
+WHERE plugin_name = ?
+ORDER BY id DESC
+`
+
+func (q *Queries) ListPluginVersions(ctx context.Context, pluginName string) ([]*PluginVersion, error) {
+	rows, err := q.db.QueryContext(ctx, ListPluginVersions, pluginName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*PluginVersion{}
+	for rows.Next() {
+		var i PluginVersion
+		if err := rows.Scan(
+			&i.ID,
+			&i.PluginName,
+			&i.Version,
+			&i.Source,
+			&i.ApiVersion,
+			&i.Kind,
+			&i.Metadata,
+			&i.Spec,
+			&i.Parameters,
+			&i.DeployedAt,
+			&i.CreatedAt,
+			&i.Manifest,
+			&i.Signature,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListPlugins = `-- name: ListPlugins :many
+SELECT name, api_version, kind, metadata, spec, created_at, updated_at, deployment_metadata, deployment_status FROM plugins ORDER BY name
+`
+
+func (q *Queries) ListPlugins(ctx context.Context) ([]*Plugin, error) {
+	rows, err := q.db.QueryContext(ctx, ListPlugins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Plugin{}
+	for rows.Next() {
+		var i Plugin
+		if err := rows.Scan(
+			&i.Name,
+			&i.ApiVersion,
+			&i.Kind,
+			&i.Metadata,
+			&i.Spec,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeploymentMetadata,
+			&i.DeploymentStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListPrometheusAlertRules = `-- name: ListPrometheusAlertRules :many
+SELECT id, group_name, name, expr, for_duration, severity, summary, description, labels, enabled, created_at, updated_at FROM prometheus_alert_rules
+ORDER BY group_name ASC, name ASC
 `
 
-func (q *Queries) ListPlugins(ctx context.Context) ([]*Plugin, error) {
-	rows, err := q.db.QueryContext(ctx, ListPlugins)
+func (q *Queries) ListPrometheusAlertRules(ctx context.Context) ([]*PrometheusAlertRule, error) {
+	rows, err := q.db.QueryContext(ctx, ListPrometheusAlertRules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*Plugin{}
+	items := []*PrometheusAlertRule{}
 	for rows.Next() {
-		var i Plugin
+		var i PrometheusAlertRule
 		if err := rows.Scan(
+			&i.ID,
+			&i.GroupName,
 			&i.Name,
-			&i.ApiVersion,
-			&i.Kind,
-			&i.Metadata,
-			&i.Spec,
+			&i.Expr,
+			&i.ForDuration,
+			&i.Severity,
+			&i.Summary,
+			&i.Description,
+			&i.Labels,
+			&i.Enabled,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.DeploymentMetadata,
-			&i.DeploymentStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -5982,6 +8075,191 @@ func (q *Queries) ListSettings(ctx context.Context) ([]*Setting, error) {
 	return items, nil
 }
 
+const ListTestnetResources = `-- name: ListTestnetResources :many
+SELECT id, testnet_id, resource_type, resource_id, created_at FROM testnet_resources WHERE testnet_id = ? ORDER BY id
+`
+
+func (q *Queries) ListTestnetResources(ctx context.Context, testnetID int64) ([]*TestnetResource, error) {
+	rows, err := q.db.QueryContext(ctx, ListTestnetResources, testnetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*TestnetResource{}
+	for rows.Next() {
+		var i TestnetResource
+		if err := rows.Scan(
+			&i.ID,
+			&i.TestnetID,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListTestnets = `-- name: ListTestnets :many
+SELECT id, name, platform, status, last_error, created_at, updated_at FROM testnets ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListTestnets(ctx context.Context) ([]*Testnet, error) {
+	rows, err := q.db.QueryContext(ctx, ListTestnets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Testnet{}
+	for rows.Next() {
+		var i Testnet
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Platform,
+			&i.Status,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUpgradePlanSteps = `-- name: ListUpgradePlanSteps :many
+SELECT id, plan_id, step_order, node_id, node_type, from_version, to_version, status, error, started_at, completed_at FROM upgrade_plan_steps WHERE plan_id = ? ORDER BY step_order
+`
+
+func (q *Queries) ListUpgradePlanSteps(ctx context.Context, planID int64) ([]*UpgradePlanStep, error) {
+	rows, err := q.db.QueryContext(ctx, ListUpgradePlanSteps, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*UpgradePlanStep{}
+	for rows.Next() {
+		var i UpgradePlanStep
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlanID,
+			&i.StepOrder,
+			&i.NodeID,
+			&i.NodeType,
+			&i.FromVersion,
+			&i.ToVersion,
+			&i.Status,
+			&i.Error,
+			&i.StartedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUpgradePlansByNetwork = `-- name: ListUpgradePlansByNetwork :many
+SELECT id, network_id, target_version, status, health_timeout_seconds, allow_downtime, error, created_at, started_at, completed_at, updated_at FROM upgrade_plans WHERE network_id = ? ORDER BY id DESC
+`
+
+func (q *Queries) ListUpgradePlansByNetwork(ctx context.Context, networkID int64) ([]*UpgradePlan, error) {
+	rows, err := q.db.QueryContext(ctx, ListUpgradePlansByNetwork, networkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*UpgradePlan{}
+	for rows.Next() {
+		var i UpgradePlan
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.TargetVersion,
+			&i.Status,
+			&i.HealthTimeoutSeconds,
+			&i.AllowDowntime,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUpgradePlansByStatus = `-- name: ListUpgradePlansByStatus :many
+SELECT id, network_id, target_version, status, health_timeout_seconds, allow_downtime, error, created_at, started_at, completed_at, updated_at FROM upgrade_plans WHERE status = ? ORDER BY id
+`
+
+func (q *Queries) ListUpgradePlansByStatus(ctx context.Context, status string) ([]*UpgradePlan, error) {
+	rows, err := q.db.QueryContext(ctx, ListUpgradePlansByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*UpgradePlan{}
+	for rows.Next() {
+		var i UpgradePlan
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.TargetVersion,
+			&i.Status,
+			&i.HealthTimeoutSeconds,
+			&i.AllowDowntime,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListUsers = `-- name: ListUsers :many
 SELECT id, username, password, name, email, role, provider, provider_id, avatar_url, created_at, last_login_at, updated_at FROM users
 ORDER BY created_at DESC
@@ -6034,6 +8312,22 @@ func (q *Queries) MarkBackupNotified(ctx context.Context, id int64) error {
 	return err
 }
 
+const MarkPluginVersionDeployed = `-- name: MarkPluginVersionDeployed :exec
+UPDATE plugin_versions
+SET parameters = ?, deployed_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type MarkPluginVersionDeployedParams struct {
+	Parameters json.RawMessage `json:"parameters"`
+	ID         int64           `json:"id"`
+}
+
+func (q *Queries) MarkPluginVersionDeployed(ctx context.Context, arg *MarkPluginVersionDeployedParams) error {
+	_, err := q.db.ExecContext(ctx, MarkPluginVersionDeployed, arg.Parameters, arg.ID)
+	return err
+}
+
 const ResetPrometheusConfig = `-- name: ResetPrometheusConfig :one
 UPDATE prometheus_config
 SET prometheus_port = 9090,
@@ -6084,6 +8378,140 @@ func (q *Queries) ResetPrometheusConfig(ctx context.Context) (*PrometheusConfig,
 	return &i, err
 }
 
+const SearchIndexedTransactions = `-- name: SearchIndexedTransactions :many
+SELECT id, network_id, block_number, tx_index, tx_id, tx_type, tx_timestamp, chaincode, function, creator_msp_id, validation_code, event_name, created_at FROM indexed_transactions
+WHERE network_id = ?
+  AND (? IS NULL OR tx_timestamp >= ?)
+  AND (? IS NULL OR tx_timestamp <= ?)
+  AND (? = '' OR chaincode = ?)
+  AND (? = '' OR function = ?)
+  AND (? = '' OR creator_msp_id = ?)
+  AND (? = '' OR validation_code = ?)
+  AND (? = '' OR id IN (SELECT transaction_id FROM indexed_transaction_keys WHERE key = ?))
+ORDER BY block_number DESC, tx_index DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchIndexedTransactionsParams struct {
+	NetworkID      int64        `json:"networkId"`
+	Column2        interface{}  `json:"column2"`
+	TxTimestamp    sql.NullTime `json:"txTimestamp"`
+	Column4        interface{}  `json:"column4"`
+	TxTimestamp_2  sql.NullTime `json:"txTimestamp2"`
+	Column6        interface{}  `json:"column6"`
+	Chaincode      string       `json:"chaincode"`
+	Column8        interface{}  `json:"column8"`
+	Function       string       `json:"function"`
+	Column10       interface{}  `json:"column10"`
+	CreatorMspID   string       `json:"creatorMspId"`
+	Column12       interface{}  `json:"column12"`
+	ValidationCode string       `json:"validationCode"`
+	Column14       interface{}  `json:"column14"`
+	Key            string       `json:"key"`
+	Limit          int64        `json:"limit"`
+	Offset         int64        `json:"offset"`
+}
+
+func (q *Queries) SearchIndexedTransactions(ctx context.Context, arg *SearchIndexedTransactionsParams) ([]*IndexedTransaction, error) {
+	rows, err := q.db.QueryContext(ctx, SearchIndexedTransactions,
+		arg.NetworkID,
+		arg.Column2,
+		arg.TxTimestamp,
+		arg.Column4,
+		arg.TxTimestamp_2,
+		arg.Column6,
+		arg.Chaincode,
+		arg.Column8,
+		arg.Function,
+		arg.Column10,
+		arg.CreatorMspID,
+		arg.Column12,
+		arg.ValidationCode,
+		arg.Column14,
+		arg.Key,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*IndexedTransaction{}
+	for rows.Next() {
+		var i IndexedTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.NetworkID,
+			&i.BlockNumber,
+			&i.TxIndex,
+			&i.TxID,
+			&i.TxType,
+			&i.TxTimestamp,
+			&i.Chaincode,
+			&i.Function,
+			&i.CreatorMspID,
+			&i.ValidationCode,
+			&i.EventName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SetBlockIndexerEnabled = `-- name: SetBlockIndexerEnabled :one
+UPDATE block_indexers
+SET enabled = ?,
+    last_error = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?
+RETURNING id, network_id, enabled, next_block, last_error, last_indexed_at, created_at, updated_at
+`
+
+type SetBlockIndexerEnabledParams struct {
+	Enabled   bool  `json:"enabled"`
+	NetworkID int64 `json:"networkId"`
+}
+
+func (q *Queries) SetBlockIndexerEnabled(ctx context.Context, arg *SetBlockIndexerEnabledParams) (*BlockIndexer, error) {
+	row := q.db.QueryRowContext(ctx, SetBlockIndexerEnabled, arg.Enabled, arg.NetworkID)
+	var i BlockIndexer
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Enabled,
+		&i.NextBlock,
+		&i.LastError,
+		&i.LastIndexedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const SetNodeHost = `-- name: SetNodeHost :exec
+INSERT INTO node_hosts (node_id, host_id) VALUES (?, ?)
+ON CONFLICT (node_id) DO UPDATE SET host_id = excluded.host_id
+`
+
+type SetNodeHostParams struct {
+	NodeID int64 `json:"nodeId"`
+	HostID int64 `json:"hostId"`
+}
+
+func (q *Queries) SetNodeHost(ctx context.Context, arg *SetNodeHostParams) error {
+	_, err := q.db.ExecContext(ctx, SetNodeHost, arg.NodeID, arg.HostID)
+	return err
+}
+
 const SetPeerStatus = `-- name: SetPeerStatus :one
 INSERT INTO fabric_chaincode_definition_peer_status (definition_id, peer_id, status)
 VALUES (?, ?, ?)
@@ -6097,17 +8525,45 @@ type SetPeerStatusParams struct {
 	Status       string `json:"status"`
 }
 
-func (q *Queries) SetPeerStatus(ctx context.Context, arg *SetPeerStatusParams) (*FabricChaincodeDefinitionPeerStatus, error) {
-	row := q.db.QueryRowContext(ctx, SetPeerStatus, arg.DefinitionID, arg.PeerID, arg.Status)
-	var i FabricChaincodeDefinitionPeerStatus
-	err := row.Scan(
-		&i.ID,
-		&i.DefinitionID,
-		&i.PeerID,
-		&i.Status,
-		&i.LastUpdated,
-	)
-	return &i, err
+func (q *Queries) SetPeerStatus(ctx context.Context, arg *SetPeerStatusParams) (*FabricChaincodeDefinitionPeerStatus, error) {
+	row := q.db.QueryRowContext(ctx, SetPeerStatus, arg.DefinitionID, arg.PeerID, arg.Status)
+	var i FabricChaincodeDefinitionPeerStatus
+	err := row.Scan(
+		&i.ID,
+		&i.DefinitionID,
+		&i.PeerID,
+		&i.Status,
+		&i.LastUpdated,
+	)
+	return &i, err
+}
+
+const StartConsensusMigration = `-- name: StartConsensusMigration :exec
+UPDATE consensus_migrations
+SET status = 'running',
+    error = NULL,
+    started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+func (q *Queries) StartConsensusMigration(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, StartConsensusMigration, id)
+	return err
+}
+
+const StartUpgradePlan = `-- name: StartUpgradePlan :exec
+UPDATE upgrade_plans
+SET status = 'running',
+    error = NULL,
+    started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+func (q *Queries) StartUpgradePlan(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, StartUpgradePlan, id)
+	return err
 }
 
 const UnsetDefaultNotificationProvider = `-- name: UnsetDefaultNotificationProvider :exec
@@ -6131,6 +8587,55 @@ func (q *Queries) UnsetDefaultProvider(ctx context.Context) error {
 	return err
 }
 
+const UpdateAlertmanagerConfig = `-- name: UpdateAlertmanagerConfig :one
+UPDATE alertmanager_config
+SET alertmanager_port = ?,
+    alertmanager_version = ?,
+    deployment_mode = ?,
+    network_mode = ?,
+    webhook_url = ?,
+    default_rules_enabled = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING id, alertmanager_port, alertmanager_version, deployment_mode, network_mode, webhook_url, webhook_token, default_rules_enabled, created_at, updated_at
+`
+
+type UpdateAlertmanagerConfigParams struct {
+	AlertmanagerPort    int64          `json:"alertmanagerPort"`
+	AlertmanagerVersion string         `json:"alertmanagerVersion"`
+	DeploymentMode      string         `json:"deploymentMode"`
+	NetworkMode         sql.NullString `json:"networkMode"`
+	WebhookUrl          string         `json:"webhookUrl"`
+	DefaultRulesEnabled bool           `json:"defaultRulesEnabled"`
+	ID                  int64          `json:"id"`
+}
+
+func (q *Queries) UpdateAlertmanagerConfig(ctx context.Context, arg *UpdateAlertmanagerConfigParams) (*AlertmanagerConfig, error) {
+	row := q.db.QueryRowContext(ctx, UpdateAlertmanagerConfig,
+		arg.AlertmanagerPort,
+		arg.AlertmanagerVersion,
+		arg.DeploymentMode,
+		arg.NetworkMode,
+		arg.WebhookUrl,
+		arg.DefaultRulesEnabled,
+		arg.ID,
+	)
+	var i AlertmanagerConfig
+	err := row.Scan(
+		&i.ID,
+		&i.AlertmanagerPort,
+		&i.AlertmanagerVersion,
+		&i.DeploymentMode,
+		&i.NetworkMode,
+		&i.WebhookUrl,
+		&i.WebhookToken,
+		&i.DefaultRulesEnabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const UpdateBackupCompleted = `-- name: UpdateBackupCompleted :one
 UPDATE backups
 SET status = ?,
@@ -6407,6 +8912,105 @@ func (q *Queries) UpdateBackupTarget(ctx context.Context, arg *UpdateBackupTarge
 	return &i, err
 }
 
+const UpdateBesuContract = `-- name: UpdateBesuContract :one
+UPDATE besu_contracts
+SET name = ?,
+    abi = ?,
+    is_proxy = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING id, network_id, name, address, abi, bytecode_hash, deployer_key_id, deploy_tx_hash, deploy_block_number, is_proxy, implementation_address, created_at, updated_at, project_id
+`
+
+type UpdateBesuContractParams struct {
+	Name    string `json:"name"`
+	Abi     string `json:"abi"`
+	IsProxy bool   `json:"isProxy"`
+	ID      int64  `json:"id"`
+}
+
+func (q *Queries) UpdateBesuContract(ctx context.Context, arg *UpdateBesuContractParams) (*BesuContract, error) {
+	row := q.db.QueryRowContext(ctx, UpdateBesuContract,
+		arg.Name,
+		arg.Abi,
+		arg.IsProxy,
+		arg.ID,
+	)
+	var i BesuContract
+	err := row.Scan(
+		&i.ID,
+		&i.NetworkID,
+		&i.Name,
+		&i.Address,
+		&i.Abi,
+		&i.BytecodeHash,
+		&i.DeployerKeyID,
+		&i.DeployTxHash,
+		&i.DeployBlockNumber,
+		&i.IsProxy,
+		&i.ImplementationAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+	)
+	return &i, err
+}
+
+const UpdateBesuContractImplementation = `-- name: UpdateBesuContractImplementation :exec
+UPDATE besu_contracts
+SET implementation_address = ?,
+    abi = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateBesuContractImplementationParams struct {
+	ImplementationAddress sql.NullString `json:"implementationAddress"`
+	Abi                   string         `json:"abi"`
+	ID                    int64          `json:"id"`
+}
+
+func (q *Queries) UpdateBesuContractImplementation(ctx context.Context, arg *UpdateBesuContractImplementationParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateBesuContractImplementation, arg.ImplementationAddress, arg.Abi, arg.ID)
+	return err
+}
+
+const UpdateBlockIndexerError = `-- name: UpdateBlockIndexerError :exec
+UPDATE block_indexers
+SET last_error = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?
+`
+
+type UpdateBlockIndexerErrorParams struct {
+	LastError sql.NullString `json:"lastError"`
+	NetworkID int64          `json:"networkId"`
+}
+
+func (q *Queries) UpdateBlockIndexerError(ctx context.Context, arg *UpdateBlockIndexerErrorParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateBlockIndexerError, arg.LastError, arg.NetworkID)
+	return err
+}
+
+const UpdateBlockIndexerProgress = `-- name: UpdateBlockIndexerProgress :exec
+UPDATE block_indexers
+SET next_block = ?,
+    last_error = NULL,
+    last_indexed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE network_id = ?
+`
+
+type UpdateBlockIndexerProgressParams struct {
+	NextBlock int64 `json:"nextBlock"`
+	NetworkID int64 `json:"networkId"`
+}
+
+func (q *Queries) UpdateBlockIndexerProgress(ctx context.Context, arg *UpdateBlockIndexerProgressParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateBlockIndexerProgress, arg.NextBlock, arg.NetworkID)
+	return err
+}
+
 const UpdateChaincode = `-- name: UpdateChaincode :one
 UPDATE fabric_chaincodes
 SET name = ?, network_id = ?
@@ -6471,6 +9075,60 @@ func (q *Queries) UpdateChaincodeDefinition(ctx context.Context, arg *UpdateChai
 	return &i, err
 }
 
+const UpdateConsensusMigrationStatus = `-- name: UpdateConsensusMigrationStatus :exec
+UPDATE consensus_migrations
+SET status = ?,
+    error = ?,
+    completed_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateConsensusMigrationStatusParams struct {
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateConsensusMigrationStatus(ctx context.Context, arg *UpdateConsensusMigrationStatusParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateConsensusMigrationStatus,
+		arg.Status,
+		arg.Error,
+		arg.CompletedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const UpdateConsensusMigrationStep = `-- name: UpdateConsensusMigrationStep :exec
+UPDATE consensus_migration_steps
+SET status = ?,
+    error = ?,
+    started_at = ?,
+    completed_at = ?
+WHERE id = ?
+`
+
+type UpdateConsensusMigrationStepParams struct {
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	StartedAt   sql.NullTime   `json:"startedAt"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateConsensusMigrationStep(ctx context.Context, arg *UpdateConsensusMigrationStepParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateConsensusMigrationStep,
+		arg.Status,
+		arg.Error,
+		arg.StartedAt,
+		arg.CompletedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const UpdateDeploymentConfig = `-- name: UpdateDeploymentConfig :one
 UPDATE nodes
 SET deployment_config = ?,
@@ -6635,6 +9293,102 @@ func (q *Queries) UpdateFabricXNamespaceStatus(ctx context.Context, arg *UpdateF
 	return &i, err
 }
 
+const UpdateHost = `-- name: UpdateHost :one
+UPDATE hosts
+SET name = ?,
+    description = ?,
+    address = ?,
+    port = ?,
+    username = ?,
+    ssh_key_id = ?,
+    data_path = ?,
+    host_key = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING id, name, description, address, port, username, ssh_key_id, host_key, data_path, status, last_error, last_checked_at, created_at, updated_at
+`
+
+type UpdateHostParams struct {
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Address     string         `json:"address"`
+	Port        int64          `json:"port"`
+	Username    string         `json:"username"`
+	SshKeyID    int64          `json:"sshKeyId"`
+	DataPath    string         `json:"dataPath"`
+	HostKey     sql.NullString `json:"hostKey"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateHost(ctx context.Context, arg *UpdateHostParams) (*Host, error) {
+	row := q.db.QueryRowContext(ctx, UpdateHost,
+		arg.Name,
+		arg.Description,
+		arg.Address,
+		arg.Port,
+		arg.Username,
+		arg.SshKeyID,
+		arg.DataPath,
+		arg.HostKey,
+		arg.ID,
+	)
+	var i Host
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Address,
+		&i.Port,
+		&i.Username,
+		&i.SshKeyID,
+		&i.HostKey,
+		&i.DataPath,
+		&i.Status,
+		&i.LastError,
+		&i.LastCheckedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const UpdateHostKey = `-- name: UpdateHostKey :exec
+UPDATE hosts
+SET host_key = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateHostKeyParams struct {
+	HostKey sql.NullString `json:"hostKey"`
+	ID      int64          `json:"id"`
+}
+
+func (q *Queries) UpdateHostKey(ctx context.Context, arg *UpdateHostKeyParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateHostKey, arg.HostKey, arg.ID)
+	return err
+}
+
+const UpdateHostStatus = `-- name: UpdateHostStatus :exec
+UPDATE hosts
+SET status = ?,
+    last_error = ?,
+    last_checked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateHostStatusParams struct {
+	Status    string         `json:"status"`
+	LastError sql.NullString `json:"lastError"`
+	ID        int64          `json:"id"`
+}
+
+func (q *Queries) UpdateHostStatus(ctx context.Context, arg *UpdateHostStatusParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateHostStatus, arg.Status, arg.LastError, arg.ID)
+	return err
+}
+
 const UpdateKey = `-- name: UpdateKey :one
 UPDATE keys
 SET name = ?,
@@ -6769,6 +9523,23 @@ func (q *Queries) UpdateKeyProvider(ctx context.Context, arg *UpdateKeyProviderP
 	return &i, err
 }
 
+const UpdateNetworkConfig = `-- name: UpdateNetworkConfig :exec
+UPDATE networks
+SET config = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateNetworkConfigParams struct {
+	Config sql.NullString `json:"config"`
+	ID     int64          `json:"id"`
+}
+
+func (q *Queries) UpdateNetworkConfig(ctx context.Context, arg *UpdateNetworkConfigParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateNetworkConfig, arg.Config, arg.ID)
+	return err
+}
+
 const UpdateNetworkCurrentConfigBlock = `-- name: UpdateNetworkCurrentConfigBlock :exec
 UPDATE networks
 SET current_config_block_b64 = ?,
@@ -7572,6 +10343,66 @@ func (q *Queries) UpdatePlugin(ctx context.Context, arg *UpdatePluginParams) (*P
 	return &i, err
 }
 
+const UpdatePrometheusAlertRule = `-- name: UpdatePrometheusAlertRule :one
+UPDATE prometheus_alert_rules
+SET group_name = ?,
+    name = ?,
+    expr = ?,
+    for_duration = ?,
+    severity = ?,
+    summary = ?,
+    description = ?,
+    labels = ?,
+    enabled = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING id, group_name, name, expr, for_duration, severity, summary, description, labels, enabled, created_at, updated_at
+`
+
+type UpdatePrometheusAlertRuleParams struct {
+	GroupName   string         `json:"groupName"`
+	Name        string         `json:"name"`
+	Expr        string         `json:"expr"`
+	ForDuration sql.NullString `json:"forDuration"`
+	Severity    string         `json:"severity"`
+	Summary     sql.NullString `json:"summary"`
+	Description sql.NullString `json:"description"`
+	Labels      sql.NullString `json:"labels"`
+	Enabled     bool           `json:"enabled"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdatePrometheusAlertRule(ctx context.Context, arg *UpdatePrometheusAlertRuleParams) (*PrometheusAlertRule, error) {
+	row := q.db.QueryRowContext(ctx, UpdatePrometheusAlertRule,
+		arg.GroupName,
+		arg.Name,
+		arg.Expr,
+		arg.ForDuration,
+		arg.Severity,
+		arg.Summary,
+		arg.Description,
+		arg.Labels,
+		arg.Enabled,
+		arg.ID,
+	)
+	var i PrometheusAlertRule
+	err := row.Scan(
+		&i.ID,
+		&i.GroupName,
+		&i.Name,
+		&i.Expr,
+		&i.ForDuration,
+		&i.Severity,
+		&i.Summary,
+		&i.Description,
+		&i.Labels,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const UpdatePrometheusConfig = `-- name: UpdatePrometheusConfig :one
 UPDATE prometheus_config
 SET prometheus_port = ?,
@@ -7938,6 +10769,79 @@ func (q *Queries) UpdateSetting(ctx context.Context, arg *UpdateSettingParams) (
 	return &i, err
 }
 
+const UpdateTestnetStatus = `-- name: UpdateTestnetStatus :exec
+UPDATE testnets
+SET status = ?,
+    last_error = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateTestnetStatusParams struct {
+	Status    string         `json:"status"`
+	LastError sql.NullString `json:"lastError"`
+	ID        int64          `json:"id"`
+}
+
+func (q *Queries) UpdateTestnetStatus(ctx context.Context, arg *UpdateTestnetStatusParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateTestnetStatus, arg.Status, arg.LastError, arg.ID)
+	return err
+}
+
+const UpdateUpgradePlanStatus = `-- name: UpdateUpgradePlanStatus :exec
+UPDATE upgrade_plans
+SET status = ?,
+    error = ?,
+    completed_at = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateUpgradePlanStatusParams struct {
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateUpgradePlanStatus(ctx context.Context, arg *UpdateUpgradePlanStatusParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateUpgradePlanStatus,
+		arg.Status,
+		arg.Error,
+		arg.CompletedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const UpdateUpgradePlanStep = `-- name: UpdateUpgradePlanStep :exec
+UPDATE upgrade_plan_steps
+SET status = ?,
+    error = ?,
+    started_at = ?,
+    completed_at = ?
+WHERE id = ?
+`
+
+type UpdateUpgradePlanStepParams struct {
+	Status      string         `json:"status"`
+	Error       sql.NullString `json:"error"`
+	StartedAt   sql.NullTime   `json:"startedAt"`
+	CompletedAt sql.NullTime   `json:"completedAt"`
+	ID          int64          `json:"id"`
+}
+
+func (q *Queries) UpdateUpgradePlanStep(ctx context.Context, arg *UpdateUpgradePlanStepParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateUpgradePlanStep,
+		arg.Status,
+		arg.Error,
+		arg.StartedAt,
+		arg.CompletedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const UpdateUser = `-- name: UpdateUser :one
 UPDATE users
 SET username = ?,
@@ -0,0 +1,60 @@
+package db_test
+
+// DB-layer tests for the testnet queries introduced in migration 0030.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func TestTestnetResources(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+
+	testnet, err := q.CreateTestnet(ctx, &db.CreateTestnetParams{Name: "tn1", Platform: "FABRIC"})
+	if err != nil {
+		t.Fatalf("CreateTestnet: %v", err)
+	}
+	if testnet.Status != "CREATING" {
+		t.Fatalf("status = %q, want CREATING", testnet.Status)
+	}
+	if _, err := q.CreateTestnet(ctx, &db.CreateTestnetParams{Name: "tn1", Platform: "BESU"}); err == nil {
+		t.Fatalf("expected duplicate testnet name to be rejected")
+	}
+
+	for _, r := range []db.AddTestnetResourceParams{
+		{TestnetID: testnet.ID, ResourceType: "ORGANIZATION", ResourceID: 1},
+		{TestnetID: testnet.ID, ResourceType: "NODE", ResourceID: 7},
+		{TestnetID: testnet.ID, ResourceType: "NODE", ResourceID: 7},
+		{TestnetID: testnet.ID, ResourceType: "NETWORK", ResourceID: 7},
+	} {
+		if err := q.AddTestnetResource(ctx, &r); err != nil {
+			t.Fatalf("AddTestnetResource: %v", err)
+		}
+	}
+	resources, err := q.ListTestnetResources(ctx, testnet.ID)
+	if err != nil {
+		t.Fatalf("ListTestnetResources: %v", err)
+	}
+	if len(resources) != 3 {
+		t.Fatalf("got %d resources, want 3 (duplicates are ignored)", len(resources))
+	}
+
+	if err := q.DeleteTestnetResource(ctx, &db.DeleteTestnetResourceParams{TestnetID: testnet.ID, ResourceType: "NODE", ResourceID: 7}); err != nil {
+		t.Fatalf("DeleteTestnetResource: %v", err)
+	}
+	resources, _ = q.ListTestnetResources(ctx, testnet.ID)
+	if len(resources) != 2 {
+		t.Fatalf("got %d resources after delete, want 2", len(resources))
+	}
+
+	if err := q.DeleteTestnet(ctx, testnet.ID); err != nil {
+		t.Fatalf("DeleteTestnet: %v", err)
+	}
+	resources, _ = q.ListTestnetResources(ctx, testnet.ID)
+	if len(resources) != 0 {
+		t.Fatalf("resources survived testnet delete: %d", len(resources))
+	}
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ExecTx runs fn in a single transaction, which is committed when fn returns nil and rolled
+// back otherwise. fn must only use the queries it is given: the database allows a single
+// connection, which the transaction holds until it ends.
+func (q *Queries) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	database, ok := q.db.(*sql.DB)
+	if !ok {
+		return fmt.Errorf("queries are not bound to a database")
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(q.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func createAlertmanagerConfig(ctx context.Context, q *db.Queries, port int64) error {
+	_, err := q.CreateAlertmanagerConfig(ctx, &db.CreateAlertmanagerConfigParams{
+		AlertmanagerPort:    port,
+		AlertmanagerVersion: "v0.28.1",
+		DeploymentMode:      "docker",
+		WebhookUrl:          "http://localhost:8100/api/v1/metrics/alerts/webhook",
+		WebhookToken:        "token",
+		DefaultRulesEnabled: true,
+	})
+	return err
+}
+
+func TestExecTx(t *testing.T) {
+	q, _ := newTestQueries(t)
+	ctx := context.Background()
+
+	if err := q.ExecTx(ctx, func(q *db.Queries) error {
+		return createAlertmanagerConfig(ctx, q, 9093)
+	}); err != nil {
+		t.Fatalf("ExecTx commit: %v", err)
+	}
+	if config, err := q.GetAlertmanagerConfig(ctx); err != nil || config.AlertmanagerPort != 9093 {
+		t.Fatalf("committed config: %+v, err=%v", config, err)
+	}
+
+	// A failure after the first statement rolls the whole transaction back
+	errBoom := errors.New("boom")
+	err := q.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteAlertmanagerConfig(ctx); err != nil {
+			return err
+		}
+		if err := createAlertmanagerConfig(ctx, q, 9094); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("ExecTx rollback: expected errBoom, got %v", err)
+	}
+	config, err := q.GetAlertmanagerConfig(ctx)
+	if err != nil || config.AlertmanagerPort != 9093 {
+		t.Fatalf("config after rollback: %+v, err=%v", config, err)
+	}
+
+	if err := q.ExecTx(ctx, func(q *db.Queries) error { return q.DeleteAlertmanagerConfig(ctx) }); err != nil {
+		t.Fatalf("ExecTx delete: %v", err)
+	}
+	if _, err := q.GetAlertmanagerConfig(ctx); err != sql.ErrNoRows {
+		t.Fatalf("expected no config, got err=%v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+)
+
+// RegistryAuth holds the credentials used to push images to a registry.
+// Both fields are empty for registries without authentication.
+type RegistryAuth struct {
+	Username string
+	Password string
+}
+
+// jsonMessage is a line of the JSON stream returned by the Docker API for builds and pushes
+type jsonMessage struct {
+	Stream      string `json:"stream"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	ErrorDetail *struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// BuildImage builds an image from a tar build context with the Dockerfile at its root and
+// tags it with the given tags. It returns the build output, which is also returned with a
+// failed build.
+func BuildImage(ctx context.Context, cli *client.Client, buildContext io.Reader, tags []string, labels map[string]string) (string, error) {
+	resp, err := cli.ImageBuild(ctx, buildContext, build.ImageBuildOptions{
+		Tags:        tags,
+		Labels:      labels,
+		Dockerfile:  "Dockerfile",
+		Remove:      true,
+		ForceRemove: true,
+		PullParent:  true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build image: %w", err)
+	}
+	defer resp.Body.Close()
+	output, err := readJSONMessages(resp.Body)
+	if err != nil {
+		return output, fmt.Errorf("failed to build image: %w", err)
+	}
+	return output, nil
+}
+
+// PushImage pushes an image to the registry of its name
+func PushImage(ctx context.Context, cli *client.Client, imageName string, auth RegistryAuth) error {
+	encodedAuth, err := registry.EncodeAuthConfig(registry.AuthConfig{
+		Username: auth.Username,
+		Password: auth.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode registry auth: %w", err)
+	}
+	reader, err := cli.ImagePush(ctx, imageName, image.PushOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return fmt.Errorf("failed to push image %s: %w", imageName, err)
+	}
+	defer reader.Close()
+	if _, err := readJSONMessages(reader); err != nil {
+		return fmt.Errorf("failed to push image %s: %w", imageName, err)
+	}
+	return nil
+}
+
+// readJSONMessages reads a JSON message stream of the Docker API until its end. It returns
+// the text output of the stream, and the error reported in the stream if any.
+func readJSONMessages(r io.Reader) (string, error) {
+	var output strings.Builder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var msg jsonMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			output.WriteString(line + "\n")
+			continue
+		}
+		if msg.ErrorDetail != nil && msg.ErrorDetail.Message != "" {
+			return output.String(), fmt.Errorf("%s", msg.ErrorDetail.Message)
+		}
+		if msg.Error != "" {
+			return output.String(), fmt.Errorf("%s", msg.Error)
+		}
+		if msg.Stream != "" {
+			output.WriteString(msg.Stream)
+		} else if msg.Status != "" {
+			output.WriteString(msg.Status + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return output.String(), err
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestReadJSONMessages tests that build and push streams are turned into output and errors
+func TestReadJSONMessages(t *testing.T) {
+	stream := strings.Join([]string{
+		`{"stream":"Step 1/2 : FROM alpine\n"}`,
+		`{"status":"Pulling from library/alpine","id":"latest"}`,
+		`{"stream":"Step 2/2 : RUN go build\n"}`,
+	}, "\n")
+	output, err := readJSONMessages(strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Step 2/2") || !strings.Contains(output, "Pulling from library/alpine") {
+		t.Errorf("missing output lines in %q", output)
+	}
+
+	failed := stream + "\n" + `{"errorDetail":{"code":1,"message":"The command '/bin/sh -c go build' returned a non-zero code: 1"},"error":"The command '/bin/sh -c go build' returned a non-zero code: 1"}`
+	output, err = readJSONMessages(strings.NewReader(failed))
+	if err == nil || !strings.Contains(err.Error(), "non-zero code: 1") {
+		t.Fatalf("expected the build error, got %v", err)
+	}
+	if !strings.Contains(output, "Step 2/2") {
+		t.Errorf("output before the error should be kept, got %q", output)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	// Import the types package that contains the shared types
@@ -180,3 +182,21 @@ func (c *Client) GetNetworkConfig(networkID int64, organizationID int64) ([]byte
 	}
 	return respBody, nil
 }
+
+// GetConnectionProfile returns the organization's connection settings in the
+// given format (empty for the default network config), embedding the given
+// identities: admin, client or key IDs
+func (c *Client) GetConnectionProfile(networkID int64, organizationID int64, format string, identities []string) ([]byte, error) {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+	if len(identities) > 0 {
+		query.Set("identities", strings.Join(identities, ","))
+	}
+	path := fmt.Sprintf("/networks/fabric/%d/organizations/%d/network-config", networkID, organizationID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return c.doRequest("GET", path, nil)
+}
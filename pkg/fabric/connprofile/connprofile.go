@@ -0,0 +1,432 @@
+// Package connprofile renders the settings client applications and tools need
+// to connect to a Fabric network as one organization.
+//
+// The fabric-sdk format is the YAML network config the networks service has
+// always produced and pkg/fabric/networkconfig parses; it is rendered by the
+// Fabric deployer. This package renders the other formats from the same data.
+package connprofile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the shape of a rendered connection profile
+type Format string
+
+const (
+	// FormatFabricSDK is the YAML network config read by chainlaunch and fabric-sdk-go
+	FormatFabricSDK Format = "fabric-sdk"
+	// FormatGateway is a JSON document with what the Fabric Gateway client API
+	// needs in Node, Java and Go: the MSP ID, peer endpoints with their TLS CA
+	// and host alias, and identities
+	FormatGateway Format = "gateway"
+	// FormatConnectionProfile is a common connection profile (JSON), read by the
+	// fabric-network Node SDK, fabric-gateway-java and fabric-sdk-go
+	FormatConnectionProfile Format = "connection-profile"
+	// FormatCaliper is a Hyperledger Caliper v2 network config (YAML). It points
+	// at a connection profile saved next to it as connection-profile.json.
+	FormatCaliper Format = "caliper"
+	// FormatExplorer is a Hyperledger Explorer connection profile (JSON)
+	FormatExplorer Format = "explorer"
+)
+
+// CaliperConnectionProfilePath is where a Caliper network config expects the
+// connection-profile format to be saved, relative to the Caliper workspace
+const CaliperConnectionProfilePath = "connection-profile.json"
+
+// ErrIdentity is returned when the selected identities are unknown or do not
+// suit the format
+var ErrIdentity = errors.New("invalid identity selection")
+
+// Formats lists every supported format
+var Formats = []Format{FormatFabricSDK, FormatGateway, FormatConnectionProfile, FormatCaliper, FormatExplorer}
+
+// ParseFormat returns the format named s. An empty string is FormatFabricSDK.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatFabricSDK, nil
+	}
+	for _, f := range Formats {
+		if Format(s) == f {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (supported: %v)", s, Formats)
+}
+
+// ContentType is the media type of a rendered profile
+func (f Format) ContentType() string {
+	switch f {
+	case FormatFabricSDK, FormatCaliper:
+		return "text/yaml"
+	default:
+		return "application/json"
+	}
+}
+
+// Endpoint is a peer or orderer of the network
+type Endpoint struct {
+	Name  string
+	MSPID string
+	// Address is the external host:port of the node
+	Address   string
+	TLSCACert string
+}
+
+// Identity is a certificate and private key the application signs with
+type Identity struct {
+	Name        string
+	Certificate string
+	PrivateKey  string
+}
+
+// Network is what a profile is rendered from
+type Network struct {
+	Name        string
+	ChannelName string
+	// MSPID is the organization the profile connects as
+	MSPID      string
+	Peers      []Endpoint
+	Orderers   []Endpoint
+	Identities []Identity
+}
+
+// Render renders n in format f. FormatFabricSDK is rendered by the Fabric deployer.
+func Render(f Format, n *Network) ([]byte, error) {
+	switch f {
+	case FormatGateway:
+		return marshalJSON(gatewayProfile(n))
+	case FormatConnectionProfile:
+		return marshalJSON(connectionProfile(n))
+	case FormatCaliper:
+		if len(n.Identities) == 0 {
+			return nil, fmt.Errorf("%w: the caliper format needs at least one identity", ErrIdentity)
+		}
+		return yaml.Marshal(caliperProfile(n))
+	case FormatExplorer:
+		if len(n.Identities) == 0 {
+			return nil, fmt.Errorf("%w: the explorer format needs an identity to sign with", ErrIdentity)
+		}
+		return marshalJSON(explorerProfile(n))
+	default:
+		return nil, fmt.Errorf("format %q is not rendered by connprofile", f)
+	}
+}
+
+func marshalJSON(v any) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// hostOf returns the host part of a host:port address
+func hostOf(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// sortedPeers returns the peers of the profile's organization first, each group ordered by name
+func sortedPeers(n *Network) []Endpoint {
+	peers := append([]Endpoint(nil), n.Peers...)
+	sort.SliceStable(peers, func(i, j int) bool {
+		iOwn, jOwn := peers[i].MSPID == n.MSPID, peers[j].MSPID == n.MSPID
+		if iOwn != jOwn {
+			return iOwn
+		}
+		return peers[i].Name < peers[j].Name
+	})
+	return peers
+}
+
+func ownPeerNames(n *Network) []string {
+	names := []string{}
+	for _, p := range sortedPeers(n) {
+		if p.MSPID == n.MSPID {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+type pem struct {
+	PEM string `json:"pem" yaml:"pem"`
+}
+
+type gatewayPeer struct {
+	Name     string `json:"name"`
+	MSPID    string `json:"mspId"`
+	Endpoint string `json:"endpoint"`
+	// HostAlias is the TLS server name to verify, passed as the
+	// grpc.ssl_target_name_override / authority override
+	HostAlias string `json:"hostAlias"`
+	TLSCACert string `json:"tlsCACert"`
+}
+
+type gatewayIdentity struct {
+	Name        string `json:"name"`
+	MSPID       string `json:"mspId"`
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+}
+
+type gateway struct {
+	MSPID       string            `json:"mspId"`
+	ChannelName string            `json:"channelName"`
+	Peers       []gatewayPeer     `json:"peers"`
+	Identities  []gatewayIdentity `json:"identities,omitempty"`
+}
+
+func gatewayProfile(n *Network) gateway {
+	g := gateway{MSPID: n.MSPID, ChannelName: n.ChannelName, Peers: []gatewayPeer{}}
+	for _, p := range sortedPeers(n) {
+		g.Peers = append(g.Peers, gatewayPeer{
+			Name:      p.Name,
+			MSPID:     p.MSPID,
+			Endpoint:  p.Address,
+			HostAlias: hostOf(p.Address),
+			TLSCACert: p.TLSCACert,
+		})
+	}
+	for _, id := range n.Identities {
+		g.Identities = append(g.Identities, gatewayIdentity{
+			Name:        id.Name,
+			MSPID:       n.MSPID,
+			Certificate: id.Certificate,
+			PrivateKey:  id.PrivateKey,
+		})
+	}
+	return g
+}
+
+type ccpUser struct {
+	Cert pem `json:"cert"`
+	Key  pem `json:"key"`
+}
+
+type ccpOrganization struct {
+	MSPID                  string             `json:"mspid"`
+	Peers                  []string           `json:"peers"`
+	CertificateAuthorities []string           `json:"certificateAuthorities"`
+	Users                  map[string]ccpUser `json:"users,omitempty"`
+}
+
+type ccpNode struct {
+	URL         string            `json:"url"`
+	TLSCACerts  pem               `json:"tlsCACerts"`
+	GRPCOptions map[string]string `json:"grpcOptions"`
+}
+
+type ccpChannelPeer struct {
+	EndorsingPeer  bool `json:"endorsingPeer"`
+	ChaincodeQuery bool `json:"chaincodeQuery"`
+	LedgerQuery    bool `json:"ledgerQuery"`
+	EventSource    bool `json:"eventSource"`
+}
+
+type ccpChannel struct {
+	Orderers []string                  `json:"orderers"`
+	Peers    map[string]ccpChannelPeer `json:"peers"`
+}
+
+type ccp struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Client  struct {
+		Organization string `json:"organization"`
+		Connection   struct {
+			Timeout struct {
+				Peer struct {
+					Endorser string `json:"endorser"`
+				} `json:"peer"`
+				Orderer string `json:"orderer"`
+			} `json:"timeout"`
+		} `json:"connection"`
+	} `json:"client"`
+	Organizations map[string]ccpOrganization `json:"organizations"`
+	Peers         map[string]ccpNode         `json:"peers"`
+	Orderers      map[string]ccpNode         `json:"orderers"`
+	Channels      map[string]ccpChannel      `json:"channels"`
+}
+
+func ccpNodeFor(e Endpoint) ccpNode {
+	host := hostOf(e.Address)
+	return ccpNode{
+		URL:        "grpcs://" + e.Address,
+		TLSCACerts: pem{PEM: e.TLSCACert},
+		GRPCOptions: map[string]string{
+			"ssl-target-name-override": host,
+			"hostnameOverride":         host,
+		},
+	}
+}
+
+func connectionProfile(n *Network) ccp {
+	var c ccp
+	c.Name = n.Name
+	c.Version = "1.0.0"
+	c.Client.Organization = n.MSPID
+	c.Client.Connection.Timeout.Peer.Endorser = "300"
+	c.Client.Connection.Timeout.Orderer = "300"
+
+	org := ccpOrganization{MSPID: n.MSPID, Peers: ownPeerNames(n), CertificateAuthorities: []string{}}
+	if len(n.Identities) > 0 {
+		org.Users = make(map[string]ccpUser, len(n.Identities))
+		for _, id := range n.Identities {
+			org.Users[id.Name] = ccpUser{Cert: pem{PEM: id.Certificate}, Key: pem{PEM: id.PrivateKey}}
+		}
+	}
+	c.Organizations = map[string]ccpOrganization{n.MSPID: org}
+
+	channel := ccpChannel{Orderers: []string{}, Peers: map[string]ccpChannelPeer{}}
+	c.Peers = make(map[string]ccpNode, len(n.Peers))
+	for _, p := range n.Peers {
+		c.Peers[p.Name] = ccpNodeFor(p)
+		channel.Peers[p.Name] = ccpChannelPeer{EndorsingPeer: true, ChaincodeQuery: true, LedgerQuery: true, EventSource: true}
+	}
+	c.Orderers = make(map[string]ccpNode, len(n.Orderers))
+	for _, o := range n.Orderers {
+		c.Orderers[o.Name] = ccpNodeFor(o)
+		channel.Orderers = append(channel.Orderers, o.Name)
+	}
+	sort.Strings(channel.Orderers)
+	c.Channels = map[string]ccpChannel{n.ChannelName: channel}
+	return c
+}
+
+type caliperCertificate struct {
+	Name             string `yaml:"name"`
+	ClientPrivateKey pem    `yaml:"clientPrivateKey"`
+	ClientSignedCert pem    `yaml:"clientSignedCert"`
+}
+
+type caliperOrganization struct {
+	MSPID      string `yaml:"mspid"`
+	Identities struct {
+		Certificates []caliperCertificate `yaml:"certificates"`
+	} `yaml:"identities"`
+	ConnectionProfile struct {
+		Path     string `yaml:"path"`
+		Discover bool   `yaml:"discover"`
+	} `yaml:"connectionProfile"`
+}
+
+type caliperChannel struct {
+	ChannelName string   `yaml:"channelName"`
+	Contracts   []string `yaml:"contracts"`
+}
+
+type caliper struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Caliper struct {
+		Blockchain string `yaml:"blockchain"`
+	} `yaml:"caliper"`
+	Channels      []caliperChannel      `yaml:"channels"`
+	Organizations []caliperOrganization `yaml:"organizations"`
+}
+
+func caliperProfile(n *Network) caliper {
+	var c caliper
+	c.Name = n.Name
+	c.Version = "2.0.0"
+	c.Caliper.Blockchain = "fabric"
+	c.Channels = []caliperChannel{{ChannelName: n.ChannelName, Contracts: []string{}}}
+
+	org := caliperOrganization{MSPID: n.MSPID}
+	for _, id := range n.Identities {
+		org.Identities.Certificates = append(org.Identities.Certificates, caliperCertificate{
+			Name:             id.Name,
+			ClientPrivateKey: pem{PEM: id.PrivateKey},
+			ClientSignedCert: pem{PEM: id.Certificate},
+		})
+	}
+	org.ConnectionProfile.Path = CaliperConnectionProfilePath
+	org.ConnectionProfile.Discover = true
+	c.Organizations = []caliperOrganization{org}
+	return c
+}
+
+type explorerOrganization struct {
+	MSPID           string   `json:"mspid"`
+	AdminPrivateKey pem      `json:"adminPrivateKey"`
+	Peers           []string `json:"peers"`
+	SignedCert      pem      `json:"signedCert"`
+}
+
+type explorerPeer struct {
+	URL        string `json:"url"`
+	TLSCACerts pem    `json:"tlsCACerts"`
+}
+
+type explorer struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Client  struct {
+		TLSEnable            bool   `json:"tlsEnable"`
+		EnableAuthentication bool   `json:"enableAuthentication"`
+		Organization         string `json:"organization"`
+		Connection           struct {
+			Timeout struct {
+				Peer struct {
+					Endorser string `json:"endorser"`
+				} `json:"peer"`
+				Orderer string `json:"orderer"`
+			} `json:"timeout"`
+		} `json:"connection"`
+	} `json:"client"`
+	Channels map[string]struct {
+		Peers map[string]struct{} `json:"peers"`
+	} `json:"channels"`
+	Organizations map[string]explorerOrganization `json:"organizations"`
+	Peers         map[string]explorerPeer         `json:"peers"`
+}
+
+// explorerProfile signs with the first identity. Explorer only reads from the
+// organization's own peers, so peers of other organizations are left out.
+// Explorer's own login is disabled; set adminCredential and
+// enableAuthentication to turn it on.
+func explorerProfile(n *Network) explorer {
+	var e explorer
+	e.Name = n.Name
+	e.Version = "1.0.0"
+	e.Client.TLSEnable = true
+	e.Client.Organization = n.MSPID
+	e.Client.Connection.Timeout.Peer.Endorser = "300"
+	e.Client.Connection.Timeout.Orderer = "300"
+
+	peers := ownPeerNames(n)
+	channelPeers := make(map[string]struct{}, len(peers))
+	e.Peers = make(map[string]explorerPeer, len(peers))
+	for _, p := range n.Peers {
+		if p.MSPID != n.MSPID {
+			continue
+		}
+		channelPeers[p.Name] = struct{}{}
+		e.Peers[p.Name] = explorerPeer{URL: "grpcs://" + p.Address, TLSCACerts: pem{PEM: p.TLSCACert}}
+	}
+	e.Channels = map[string]struct {
+		Peers map[string]struct{} `json:"peers"`
+	}{n.ChannelName: {Peers: channelPeers}}
+
+	id := n.Identities[0]
+	e.Organizations = map[string]explorerOrganization{
+		n.MSPID: {
+			MSPID:           n.MSPID,
+			AdminPrivateKey: pem{PEM: id.PrivateKey},
+			Peers:           peers,
+			SignedCert:      pem{PEM: id.Certificate},
+		},
+	}
+	return e
+}
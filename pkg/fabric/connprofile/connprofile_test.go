@@ -0,0 +1,103 @@
+package connprofile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testNetwork() *Network {
+	return &Network{
+		Name:        "net1",
+		ChannelName: "net1",
+		MSPID:       "Org1MSP",
+		Peers: []Endpoint{
+			{Name: "peer0-org2", MSPID: "Org2MSP", Address: "10.0.0.2:7051", TLSCACert: "ca2"},
+			{Name: "peer1-org1", MSPID: "Org1MSP", Address: "peer1.org1:7051", TLSCACert: "ca1"},
+			{Name: "peer0-org1", MSPID: "Org1MSP", Address: "peer0.org1:7051", TLSCACert: "ca1"},
+		},
+		Orderers: []Endpoint{
+			{Name: "orderer0", MSPID: "OrdererMSP", Address: "orderer0:7050", TLSCACert: "caO"},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatFabricSDK, f)
+
+	f, err = ParseFormat("caliper")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCaliper, f)
+	assert.Equal(t, "text/yaml", f.ContentType())
+
+	_, err = ParseFormat("unknown")
+	assert.Error(t, err)
+}
+
+func TestRenderGateway(t *testing.T) {
+	n := testNetwork()
+	n.Identities = []Identity{{Name: "client", Certificate: "cert", PrivateKey: "key"}}
+	out, err := Render(FormatGateway, n)
+	require.NoError(t, err)
+
+	var g gateway
+	require.NoError(t, json.Unmarshal(out, &g))
+	assert.Equal(t, "Org1MSP", g.MSPID)
+	require.Len(t, g.Peers, 3)
+	// Own organization's peers come first
+	assert.Equal(t, "peer0-org1", g.Peers[0].Name)
+	assert.Equal(t, "peer0.org1", g.Peers[0].HostAlias)
+	assert.Equal(t, "peer0-org2", g.Peers[2].Name)
+	require.Len(t, g.Identities, 1)
+	assert.Equal(t, "Org1MSP", g.Identities[0].MSPID)
+}
+
+func TestRenderConnectionProfile(t *testing.T) {
+	out, err := Render(FormatConnectionProfile, testNetwork())
+	require.NoError(t, err)
+
+	var c ccp
+	require.NoError(t, json.Unmarshal(out, &c))
+	assert.Equal(t, []string{"peer0-org1", "peer1-org1"}, c.Organizations["Org1MSP"].Peers)
+	assert.Empty(t, c.Organizations["Org1MSP"].Users)
+	assert.Equal(t, "grpcs://10.0.0.2:7051", c.Peers["peer0-org2"].URL)
+	assert.Equal(t, "10.0.0.2", c.Peers["peer0-org2"].GRPCOptions["ssl-target-name-override"])
+	assert.Equal(t, []string{"orderer0"}, c.Channels["net1"].Orderers)
+	assert.Len(t, c.Channels["net1"].Peers, 3)
+}
+
+func TestRenderCaliperNeedsIdentity(t *testing.T) {
+	n := testNetwork()
+	_, err := Render(FormatCaliper, n)
+	assert.ErrorIs(t, err, ErrIdentity)
+
+	n.Identities = []Identity{{Name: "admin", Certificate: "cert", PrivateKey: "key"}}
+	out, err := Render(FormatCaliper, n)
+	require.NoError(t, err)
+
+	var c caliper
+	require.NoError(t, yaml.Unmarshal(out, &c))
+	assert.Equal(t, "fabric", c.Caliper.Blockchain)
+	require.Len(t, c.Organizations, 1)
+	assert.Equal(t, CaliperConnectionProfilePath, c.Organizations[0].ConnectionProfile.Path)
+	assert.Equal(t, "key", c.Organizations[0].Identities.Certificates[0].ClientPrivateKey.PEM)
+}
+
+func TestRenderExplorerUsesOwnPeers(t *testing.T) {
+	n := testNetwork()
+	n.Identities = []Identity{{Name: "admin", Certificate: "cert", PrivateKey: "key"}}
+	out, err := Render(FormatExplorer, n)
+	require.NoError(t, err)
+
+	var e explorer
+	require.NoError(t, json.Unmarshal(out, &e))
+	assert.Len(t, e.Peers, 2)
+	assert.NotContains(t, e.Peers, "peer0-org2")
+	assert.Equal(t, "key", e.Organizations["Org1MSP"].AdminPrivateKey.PEM)
+	assert.Len(t, e.Channels["net1"].Peers, 2)
+}
@@ -0,0 +1,168 @@
+//go:build integration
+
+package hosts
+
+// Docker-gated integration test against a real sshd. Starts the
+// linuxserver/openssh-server image with a generated key authorized,
+// pins its host key, uploads a node directory and tails a log file
+// through the same Runner the node runtimes use.
+//
+// Run with: go test -tags=integration ./pkg/hosts/... -run TestRemoteHostSSHD -v
+//
+// Skipped automatically when the docker daemon is unreachable.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/chainlaunch/chainlaunch/pkg/docker"
+)
+
+const sshdImage = "lscr.io/linuxserver/openssh-server:latest"
+
+func requireDocker(t *testing.T) *dockerclient.Client {
+	t.Helper()
+	cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		t.Skipf("docker client unavailable: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := cli.Ping(ctx); err != nil {
+		t.Skipf("docker daemon not reachable: %v", err)
+	}
+	return cli
+}
+
+func TestRemoteHostSSHD(t *testing.T) {
+	cli := requireDocker(t)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	signer := newTestSigner(t)
+	containerName := fmt.Sprintf("chainlaunch-sshd-it-%d", time.Now().UnixNano())
+	if err := docker.PullImageIfNeeded(ctx, cli, sshdImage); err != nil {
+		t.Fatalf("pull %s: %v", sshdImage, err)
+	}
+	resp, err := cli.ContainerCreate(ctx, &container.Config{
+		Image: sshdImage,
+		Env: []string{
+			"USER_NAME=chainlaunch",
+			"PUBLIC_KEY=" + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+		},
+		ExposedPorts: nat.PortSet{"2222/tcp": {}},
+	}, &container.HostConfig{
+		PortBindings: nat.PortMap{"2222/tcp": {{HostIP: "127.0.0.1", HostPort: "0"}}},
+	}, nil, nil, containerName)
+	if err != nil {
+		t.Fatalf("create sshd container: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cli.ContainerRemove(context.Background(), containerName, container.RemoveOptions{Force: true})
+	})
+	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		t.Fatalf("start sshd container: %v", err)
+	}
+	info, err := cli.ContainerInspect(ctx, resp.ID)
+	if err != nil {
+		t.Fatalf("inspect sshd container: %v", err)
+	}
+	bindings := info.NetworkSettings.Ports["2222/tcp"]
+	if len(bindings) == 0 {
+		t.Fatalf("sshd port not published")
+	}
+	var port int
+	fmt.Sscanf(bindings[0].HostPort, "%d", &port)
+
+	// sshd takes a few seconds to generate keys and accept connections
+	var client *Client
+	var hostKey ssh.PublicKey
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		client, hostKey, err = dial(ctx, dialConfig{address: "127.0.0.1", port: port, username: "chainlaunch", signer: signer})
+		if err == nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(2 * time.Second)
+	}
+	if err != nil {
+		t.Fatalf("connect to sshd: %v", err)
+	}
+	client.Close()
+
+	// Reconnect with the pinned key like Service.Connect does after the first check
+	client, _, err = dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     port,
+		username: "chainlaunch",
+		signer:   signer,
+		hostKey:  string(ssh.MarshalAuthorizedKey(hostKey)),
+	})
+	if err != nil {
+		t.Fatalf("reconnect with pinned host key: %v", err)
+	}
+
+	localRoot := t.TempDir()
+	runner := &Runner{
+		client:    client,
+		host:      &Host{Name: "sshd", DataPath: "/config/chainlaunch"},
+		localRoot: localRoot,
+	}
+	defer runner.Close()
+
+	out, err := client.Run(ctx, "uname -sm")
+	if err != nil {
+		t.Fatalf("uname: %v", err)
+	}
+	if goos, _ := parseUname(string(out)); goos != "linux" {
+		t.Fatalf("unexpected platform %q", out)
+	}
+
+	configDir := filepath.Join(localRoot, "peers", "peer0", "config")
+	if err := os.MkdirAll(filepath.Join(configDir, "tlscacerts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "tlscacerts", "cacert.pem"), []byte("ca"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "core.yaml"), []byte("fileSystemPath: "+localRoot+"/peers/peer0/data\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Upload(ctx, configDir); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	out, err = client.Run(ctx, "cat /config/chainlaunch/peers/peer0/config/core.yaml /config/chainlaunch/peers/peer0/config/tlscacerts/cacert.pem")
+	if err != nil {
+		t.Fatalf("read uploaded files: %v", err)
+	}
+	if want := "fileSystemPath: /config/chainlaunch/peers/peer0/data\nca"; string(out) != want {
+		t.Fatalf("uploaded content = %q, want %q", out, want)
+	}
+
+	logFile := filepath.Join(localRoot, "peers", "peer0", "peer.log")
+	if err := client.WriteFile(ctx, runner.RemotePath(logFile), []byte("line1\nline2\nline3\n"), 0644, false); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+	lines, err := runner.TailFile(ctx, logFile, 2, false)
+	if err != nil {
+		t.Fatalf("tail: %v", err)
+	}
+	var got []string
+	for line := range lines {
+		got = append(got, line)
+	}
+	if strings.Join(got, "") != "line2\nline3\n" {
+		t.Fatalf("tail = %q", got)
+	}
+}
@@ -0,0 +1,280 @@
+// Package http exposes REST endpoints for remote hosts.
+//
+// Routes mounted under /hosts:
+//
+//	GET    /hosts                       list
+//	POST   /hosts                       register a host
+//	GET    /hosts/{id}                  get
+//	PUT    /hosts/{id}                  update
+//	DELETE /hosts/{id}                  delete (rejected while nodes are placed on it)
+//	POST   /hosts/{id}/check            connect and report platform, systemd and docker
+//	GET    /hosts/{id}/nodes            IDs of the nodes placed on the host
+//	POST   /hosts/{id}/nodes/{nodeId}   place a node on the host
+//	DELETE /hosts/{id}/nodes/{nodeId}   move a node back to the local machine
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	service  *hosts.Service
+	validate *validator.Validate
+}
+
+func NewHandler(svc *hosts.Service) *Handler {
+	return &Handler{service: svc, validate: validator.New()}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/hosts", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", h.Get)
+			r.Put("/", h.Update)
+			r.Delete("/", h.Delete)
+			r.Post("/check", h.Check)
+			r.Get("/nodes", h.ListNodes)
+			r.Post("/nodes/{nodeId}", h.AssignNode)
+			r.Delete("/nodes/{nodeId}", h.UnassignNode)
+		})
+	})
+}
+
+// HostNodesResponse lists the nodes placed on a host
+type HostNodesResponse struct {
+	NodeIDs []int64 `json:"nodeIds"`
+}
+
+// @Summary List hosts
+// @Tags Hosts
+// @Produce json
+// @Success 200 {array} hosts.Host
+// @Router /hosts [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListHosts(r.Context())
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// @Summary Register a host
+// @Description The returned authorizedKey must be added to the SSH user's authorized_keys on the host
+// @Tags Hosts
+// @Accept json
+// @Produce json
+// @Param request body hosts.CreateHostRequest true "Host"
+// @Success 201 {object} hosts.Host
+// @Router /hosts [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req hosts.CreateHostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	host, err := h.service.CreateHost(r.Context(), req)
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, host)
+}
+
+// @Summary Get a host
+// @Tags Hosts
+// @Produce json
+// @Param id path int true "Host ID"
+// @Success 200 {object} hosts.Host
+// @Router /hosts/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	host, err := h.service.GetHost(r.Context(), id)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, host)
+}
+
+// @Summary Update a host
+// @Tags Hosts
+// @Accept json
+// @Produce json
+// @Param id path int true "Host ID"
+// @Param request body hosts.UpdateHostRequest true "Mutable fields"
+// @Success 200 {object} hosts.Host
+// @Router /hosts/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var req hosts.UpdateHostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	host, err := h.service.UpdateHost(r.Context(), id, req)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, host)
+}
+
+// @Summary Delete a host
+// @Tags Hosts
+// @Param id path int true "Host ID"
+// @Success 204
+// @Router /hosts/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteHost(r.Context(), id); err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Check a host
+// @Description Connects over SSH, pins the server key on first use and reports what the host can run
+// @Tags Hosts
+// @Produce json
+// @Param id path int true "Host ID"
+// @Success 200 {object} hosts.CheckResult
+// @Router /hosts/{id}/check [post]
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	result, err := h.service.CheckHost(r.Context(), id)
+	if err != nil {
+		status := statusFor(err)
+		if status == http.StatusBadRequest {
+			status = http.StatusBadGateway
+		}
+		writeErr(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary List the nodes placed on a host
+// @Tags Hosts
+// @Produce json
+// @Param id path int true "Host ID"
+// @Success 200 {object} HostNodesResponse
+// @Router /hosts/{id}/nodes [get]
+func (h *Handler) ListNodes(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	ids, err := h.service.ListHostNodes(r.Context(), id)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, HostNodesResponse{NodeIDs: ids})
+}
+
+// @Summary Place a node on a host
+// @Description The node runs on the host from its next start
+// @Tags Hosts
+// @Param id path int true "Host ID"
+// @Param nodeId path int true "Node ID"
+// @Success 204
+// @Router /hosts/{id}/nodes/{nodeId} [post]
+func (h *Handler) AssignNode(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	nodeID, ok := pathID(w, r, "nodeId")
+	if !ok {
+		return
+	}
+	if err := h.service.AssignNode(r.Context(), id, nodeID); err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Move a node back to the local machine
+// @Tags Hosts
+// @Param id path int true "Host ID"
+// @Param nodeId path int true "Node ID"
+// @Success 204
+// @Router /hosts/{id}/nodes/{nodeId} [delete]
+func (h *Handler) UnassignNode(w http.ResponseWriter, r *http.Request) {
+	nodeID, ok := pathID(w, r, "nodeId")
+	if !ok {
+		return
+	}
+	if err := h.service.UnassignNode(r.Context(), nodeID); err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, hosts.ErrHostInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	s := chi.URLParam(r, name)
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, fmt.Sprintf("invalid %s %q", name, s))
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErr(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,479 @@
+package hosts
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ServiceSpec describes a node process run as a systemd unit on a host
+type ServiceSpec struct {
+	// Name is the systemd unit name, without the .service suffix
+	Name        string
+	Description string
+	// Binary is the local executable copied to the host. It must be built
+	// for the host platform.
+	Binary string
+	Args   []string
+	Env    map[string]string
+	// WorkDir is the local node directory; the unit runs in its host counterpart
+	WorkDir string
+	// LogFile is the local log path; output is appended to its host counterpart
+	LogFile string
+	// Upload lists the local files and directories copied before the unit starts
+	Upload []string
+	// Exclude lists local paths inside Upload that are never copied, such as
+	// ledger directories the node writes to on the host
+	Exclude []string
+}
+
+// ContainerSpec describes a node container run on a host
+type ContainerSpec struct {
+	Name       string
+	Config     *container.Config
+	HostConfig *container.HostConfig
+	// Upload lists the local files and directories copied before the container
+	// starts. Other bind mount sources are created empty when missing, so data
+	// directories on the host are never overwritten.
+	Upload []string
+	// Exclude lists local paths inside Upload that are never copied
+	Exclude []string
+}
+
+// Runner places node processes on a remote host. Local paths under the
+// ChainLaunch data directory map to the same relative paths under the host
+// data path, and references to them in uploaded config files are rewritten.
+type Runner struct {
+	client    *Client
+	host      *Host
+	localRoot string
+}
+
+// Host returns the host the runner deploys to
+func (r *Runner) Host() *Host {
+	return r.host
+}
+
+// Close closes the SSH connection
+func (r *Runner) Close() error {
+	return r.client.Close()
+}
+
+// RemotePath maps a local path to its location on the host
+func (r *Runner) RemotePath(localPath string) string {
+	rel, err := filepath.Rel(r.localRoot, localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// Paths outside the data directory keep their layout under "external"
+		return path.Join(r.host.DataPath, "external", filepath.ToSlash(localPath))
+	}
+	return path.Join(r.host.DataPath, filepath.ToSlash(rel))
+}
+
+// rewritePaths replaces references to the local data directory with the host data path
+func (r *Runner) rewritePaths(s string) string {
+	return strings.ReplaceAll(s, r.localRoot, r.host.DataPath)
+}
+
+// rewriteConfigFile rewrites data directory references in text config files
+func (r *Runner) rewriteConfigFile(name string, data []byte) []byte {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json", ".toml", ".conf", ".properties", ".env":
+		return bytes.ReplaceAll(data, []byte(r.localRoot), []byte(r.host.DataPath))
+	default:
+		return data
+	}
+}
+
+// Upload copies local files and directories to their host counterparts
+func (r *Runner) Upload(ctx context.Context, localPaths ...string) error {
+	return r.upload(ctx, localPaths, nil)
+}
+
+func (r *Runner) upload(ctx context.Context, localPaths, exclude []string) error {
+	for _, localPath := range localPaths {
+		if err := r.client.Upload(ctx, localPath, r.RemotePath(localPath), exclude, r.rewriteConfigFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UploadData copies local data files, such as ledger snapshots, to their host
+// counterparts without rewriting their content
+func (r *Runner) UploadData(ctx context.Context, localPath string) error {
+	return r.client.Upload(ctx, localPath, r.RemotePath(localPath), nil, nil)
+}
+
+// Download copies the host counterpart of a local directory back to the local path
+func (r *Runner) Download(ctx context.Context, localPath string) error {
+	return r.client.Download(ctx, r.RemotePath(localPath), localPath)
+}
+
+// ListDir returns the entry names of the host counterpart of a local directory.
+// A missing directory has no entries.
+func (r *Runner) ListDir(ctx context.Context, localPath string) ([]string, error) {
+	dir := shellQuote(r.RemotePath(localPath))
+	out, err := r.client.Run(ctx, r.client.privileged(fmt.Sprintf("if [ -d %s ]; then ls -1 %s; fi", dir, dir)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", r.RemotePath(localPath), err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// ReadFile reads the host counterpart of a local file
+func (r *Runner) ReadFile(ctx context.Context, localPath string) ([]byte, error) {
+	out, err := r.client.Run(ctx, r.client.privileged("cat "+shellQuote(r.RemotePath(localPath))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", r.RemotePath(localPath), err)
+	}
+	return out, nil
+}
+
+// RemoveAll removes the host counterpart of a local path
+func (r *Runner) RemoveAll(ctx context.Context, localPath string) error {
+	if _, err := r.client.Run(ctx, r.client.privileged("rm -rf "+shellQuote(r.RemotePath(localPath)))); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", r.RemotePath(localPath), err)
+	}
+	return nil
+}
+
+// checkPlatform makes sure binaries built for this machine run on the host
+func (r *Runner) checkPlatform(ctx context.Context) error {
+	out, err := r.client.Run(ctx, "uname -sm")
+	if err != nil {
+		return fmt.Errorf("failed to detect host platform: %w", err)
+	}
+	goos, goarch := parseUname(string(out))
+	if goos != runtime.GOOS || goarch != runtime.GOARCH {
+		return fmt.Errorf("host %s is %s/%s but binaries are %s/%s; run the node in docker mode instead",
+			r.host.Name, goos, goarch, runtime.GOOS, runtime.GOARCH)
+	}
+	return nil
+}
+
+// uploadBinary copies an executable to the host unless an identical copy is already there
+func (r *Runner) uploadBinary(ctx context.Context, localBinary string) (string, error) {
+	data, err := os.ReadFile(localBinary)
+	if err != nil {
+		return "", fmt.Errorf("failed to read binary %s: %w", localBinary, err)
+	}
+	sum := sha256.Sum256(data)
+	remoteBinary := r.RemotePath(localBinary)
+	out, err := r.client.Run(ctx, "sha256sum "+shellQuote(remoteBinary)+" 2>/dev/null || true")
+	if err == nil && strings.HasPrefix(string(out), hex.EncodeToString(sum[:])) {
+		return remoteBinary, nil
+	}
+	if err := r.client.WriteFile(ctx, remoteBinary, data, 0755, false); err != nil {
+		return "", err
+	}
+	return remoteBinary, nil
+}
+
+// StartService copies the node files and binary to the host, installs the
+// systemd unit and (re)starts it
+func (r *Runner) StartService(ctx context.Context, spec ServiceSpec) error {
+	if err := r.checkPlatform(ctx); err != nil {
+		return err
+	}
+	remoteBinary, err := r.uploadBinary(ctx, spec.Binary)
+	if err != nil {
+		return err
+	}
+	if err := r.upload(ctx, spec.Upload, spec.Exclude); err != nil {
+		return err
+	}
+	if _, err := r.client.Run(ctx, "mkdir -p "+shellQuote(r.RemotePath(spec.WorkDir))); err != nil {
+		return fmt.Errorf("failed to create node directory: %w", err)
+	}
+
+	unit, err := r.renderSystemdUnit(spec, remoteBinary)
+	if err != nil {
+		return err
+	}
+	unitPath := fmt.Sprintf("/etc/systemd/system/%s.service", spec.Name)
+	if err := r.client.WriteFile(ctx, unitPath, unit, 0644, true); err != nil {
+		return fmt.Errorf("failed to install systemd unit: %w", err)
+	}
+	name := shellQuote(spec.Name)
+	cmd := fmt.Sprintf("systemctl daemon-reload && systemctl enable %s && systemctl restart %s", name, name)
+	if _, err := r.client.Run(ctx, r.client.privileged(cmd)); err != nil {
+		return fmt.Errorf("failed to start systemd unit %s: %w", spec.Name, err)
+	}
+	return nil
+}
+
+// StopService stops a systemd unit on the host. Stopping a unit that doesn't exist succeeds.
+func (r *Runner) StopService(ctx context.Context, name string) error {
+	cmd := fmt.Sprintf("systemctl stop %s", shellQuote(name))
+	out, err := r.client.Run(ctx, r.client.privileged(cmd))
+	if err != nil && !strings.Contains(string(out), "not loaded") {
+		return fmt.Errorf("failed to stop systemd unit %s: %w", name, err)
+	}
+	return nil
+}
+
+var systemdUnitTemplate = template.Must(template.New("unit").Parse(`[Unit]
+Description={{.Description}}
+After=network.target
+
+[Service]
+Type=simple
+WorkingDirectory={{.WorkDir}}
+ExecStart={{.ExecStart}}
+Restart=on-failure
+RestartSec=10
+LimitNOFILE=65536
+StandardOutput=append:{{.LogFile}}
+StandardError=append:{{.LogFile}}
+{{range .Env}}Environment={{.}}
+{{end}}
+[Install]
+WantedBy=multi-user.target
+`))
+
+// renderSystemdUnit renders the unit file with local paths mapped to the host
+func (r *Runner) renderSystemdUnit(spec ServiceSpec, remoteBinary string) ([]byte, error) {
+	execStart := []string{remoteBinary}
+	for _, arg := range spec.Args {
+		execStart = append(execStart, systemdQuote(r.rewritePaths(arg)))
+	}
+	keys := make([]string, 0, len(spec.Env))
+	for k := range spec.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, systemdQuote(k+"="+r.rewritePaths(spec.Env[k])))
+	}
+	logFile := r.RemotePath(spec.LogFile)
+	if spec.LogFile == "" {
+		logFile = path.Join(r.RemotePath(spec.WorkDir), spec.Name+".log")
+	}
+
+	var buf bytes.Buffer
+	if err := systemdUnitTemplate.Execute(&buf, struct {
+		Description string
+		WorkDir     string
+		ExecStart   string
+		LogFile     string
+		Env         []string
+	}{
+		Description: spec.Description,
+		WorkDir:     r.RemotePath(spec.WorkDir),
+		ExecStart:   strings.Join(execStart, " "),
+		LogFile:     logFile,
+		Env:         env,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to render systemd unit: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RunContainer copies the node files to the host and (re)creates the container there
+func (r *Runner) RunContainer(ctx context.Context, spec ContainerSpec) error {
+	if err := r.upload(ctx, spec.Upload, spec.Exclude); err != nil {
+		return err
+	}
+
+	hostConfig := *spec.HostConfig
+	hostConfig.Mounts = make([]mount.Mount, len(spec.HostConfig.Mounts))
+	var mkdirs []string
+	for i, m := range spec.HostConfig.Mounts {
+		if m.Type == mount.TypeBind {
+			m.Source = r.RemotePath(m.Source)
+			mkdirs = append(mkdirs, shellQuote(m.Source))
+		}
+		hostConfig.Mounts[i] = m
+	}
+	if len(mkdirs) > 0 {
+		if _, err := r.client.Run(ctx, "mkdir -p "+strings.Join(mkdirs, " ")); err != nil {
+			return fmt.Errorf("failed to create mount directories: %w", err)
+		}
+	}
+
+	cli, err := r.client.DockerClient()
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	if err := pullImage(ctx, cli, spec.Config.Image); err != nil {
+		return err
+	}
+	if err := cli.ContainerRemove(ctx, spec.Name, container.RemoveOptions{Force: true}); err != nil && !dockerclient.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove existing container %s: %w", spec.Name, err)
+	}
+	resp, err := cli.ContainerCreate(ctx, spec.Config, &hostConfig, nil, nil, spec.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create container %s on %s: %w", spec.Name, r.host.Name, err)
+	}
+	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s on %s: %w", spec.Name, r.host.Name, err)
+	}
+	return nil
+}
+
+// pullImage pulls an image on the host unless it is already present
+func pullImage(ctx context.Context, cli *dockerclient.Client, imageName string) error {
+	if _, err := cli.ImageInspect(ctx, imageName); err == nil {
+		return nil
+	}
+	reader, err := cli.ImagePull(ctx, imageName, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", imageName, err)
+	}
+	defer reader.Close()
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", imageName, err)
+	}
+	return nil
+}
+
+// StopContainer stops and removes a container on the host. Missing containers are ignored.
+func (r *Runner) StopContainer(ctx context.Context, name string) error {
+	cli, err := r.client.DockerClient()
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	if err := cli.ContainerRemove(ctx, name, container.RemoveOptions{Force: true}); err != nil && !dockerclient.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove container %s on %s: %w", name, r.host.Name, err)
+	}
+	return nil
+}
+
+// ContainerRunning reports whether a container runs on the host
+func (r *Runner) ContainerRunning(ctx context.Context, name string) (bool, error) {
+	cli, err := r.client.DockerClient()
+	if err != nil {
+		return false, err
+	}
+	defer cli.Close()
+	info, err := cli.ContainerInspect(ctx, name)
+	if err != nil {
+		if dockerclient.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to inspect container %s on %s: %w", name, r.host.Name, err)
+	}
+	return info.State != nil && info.State.Running, nil
+}
+
+// EnsureNetwork creates a Docker bridge network on the host if it doesn't exist
+func (r *Runner) EnsureNetwork(ctx context.Context, name string) error {
+	cli, err := r.client.DockerClient()
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	if _, err := cli.NetworkInspect(ctx, name, network.InspectOptions{}); err == nil {
+		return nil
+	}
+	if _, err := cli.NetworkCreate(ctx, name, network.CreateOptions{Driver: "bridge"}); err != nil {
+		return fmt.Errorf("failed to create network %s on %s: %w", name, r.host.Name, err)
+	}
+	return nil
+}
+
+// TailFile streams the last lines of the host counterpart of a local log file.
+// The stream ends when ctx is done or, without follow, after the last line.
+func (r *Runner) TailFile(ctx context.Context, localPath string, tail int, follow bool) (<-chan string, error) {
+	cmd := fmt.Sprintf("tail -n %d", tail)
+	if follow {
+		cmd += " -F"
+	}
+	return r.client.Stream(ctx, cmd+" "+shellQuote(r.RemotePath(localPath)))
+}
+
+// TailContainer streams the logs of a container on the host
+func (r *Runner) TailContainer(ctx context.Context, name string, tail int, follow bool) (<-chan string, error) {
+	cli, err := r.client.DockerClient()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := cli.ContainerLogs(ctx, name, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Tail:       fmt.Sprintf("%d", tail),
+	})
+	if err != nil {
+		cli.Close()
+		return nil, fmt.Errorf("failed to get logs of container %s on %s: %w", name, r.host.Name, err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, reader)
+		pw.CloseWithError(err)
+	}()
+	lines := make(chan string, 100)
+	go func() {
+		defer close(lines)
+		defer cli.Close()
+		defer reader.Close()
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text() + "\n":
+			case <-ctx.Done():
+				pr.Close()
+				return
+			}
+		}
+	}()
+	return lines, nil
+}
+
+// parseUname maps `uname -sm` output to Go's GOOS and GOARCH names
+func parseUname(out string) (string, string) {
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return "unknown", "unknown"
+	}
+	goos := strings.ToLower(fields[0])
+	goarch := fields[1]
+	switch goarch {
+	case "x86_64", "amd64":
+		goarch = "amd64"
+	case "aarch64", "arm64":
+		goarch = "arm64"
+	}
+	return goos, goarch
+}
+
+// systemdQuote escapes specifiers and quotes a value for use in unit files
+// when it contains spaces or quotes
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
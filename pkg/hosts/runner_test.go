@@ -0,0 +1,125 @@
+package hosts
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRunner() *Runner {
+	return &Runner{
+		host:      &Host{Name: "host1", DataPath: "/opt/chainlaunch"},
+		localRoot: "/home/user/.chainlaunch",
+	}
+}
+
+func TestRemotePath(t *testing.T) {
+	r := testRunner()
+	assert.Equal(t, "/opt/chainlaunch/peers/peer0/config", r.RemotePath("/home/user/.chainlaunch/peers/peer0/config"))
+	assert.Equal(t, "/opt/chainlaunch", r.RemotePath("/home/user/.chainlaunch"))
+	assert.Equal(t, "/opt/chainlaunch/external/usr/local/bin/peer", r.RemotePath("/usr/local/bin/peer"))
+	assert.Equal(t, "/opt/chainlaunch/external/home/user/.chainlaunch-other/x", r.RemotePath("/home/user/.chainlaunch-other/x"))
+}
+
+func TestRewriteConfigFile(t *testing.T) {
+	r := testRunner()
+	yaml := []byte("fileSystemPath: /home/user/.chainlaunch/peers/peer0/data\n")
+	assert.Equal(t, "fileSystemPath: /opt/chainlaunch/peers/peer0/data\n", string(r.rewriteConfigFile("core.yaml", yaml)))
+
+	pem := []byte("/home/user/.chainlaunch")
+	assert.Equal(t, pem, r.rewriteConfigFile("tls.crt", pem))
+}
+
+func TestRenderSystemdUnit(t *testing.T) {
+	r := testRunner()
+	unit, err := r.renderSystemdUnit(ServiceSpec{
+		Name:        "fabric-peer-peer0",
+		Description: "Hyperledger Fabric Peer - peer0",
+		Args:        []string{"node", "start"},
+		Env: map[string]string{
+			"FABRIC_CFG_PATH":   "/home/user/.chainlaunch/peers/peer0/config",
+			"CORE_PEER_ADDRESS": "peer0:7051",
+			"SPEC":              "info with 100%",
+		},
+		WorkDir: "/home/user/.chainlaunch/peers/peer0",
+		LogFile: "/home/user/.chainlaunch/peers/peer0/fabric-peer-peer0.log",
+	}, "/opt/chainlaunch/bin/peer")
+	require.NoError(t, err)
+
+	content := string(unit)
+	assert.Contains(t, content, "ExecStart=/opt/chainlaunch/bin/peer node start\n")
+	assert.Contains(t, content, "WorkingDirectory=/opt/chainlaunch/peers/peer0\n")
+	assert.Contains(t, content, "StandardOutput=append:/opt/chainlaunch/peers/peer0/fabric-peer-peer0.log\n")
+	assert.Contains(t, content, "Environment=FABRIC_CFG_PATH=/opt/chainlaunch/peers/peer0/config\n")
+	assert.Contains(t, content, "Environment=\"SPEC=info with 100%%\"\n")
+	// Environment lines are sorted so unchanged specs render identical units
+	assert.Less(t, bytes.Index(unit, []byte("CORE_PEER_ADDRESS")), bytes.Index(unit, []byte("FABRIC_CFG_PATH")))
+}
+
+func TestParseUname(t *testing.T) {
+	tests := []struct {
+		out    string
+		goos   string
+		goarch string
+	}{
+		{"Linux x86_64\n", "linux", "amd64"},
+		{"Linux aarch64", "linux", "arm64"},
+		{"Darwin arm64", "darwin", "arm64"},
+		{"", "unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		goos, goarch := parseUname(tt.out)
+		assert.Equal(t, tt.goos, goos, tt.out)
+		assert.Equal(t, tt.goarch, goarch, tt.out)
+	}
+}
+
+func TestSystemdQuote(t *testing.T) {
+	assert.Equal(t, "plain", systemdQuote("plain"))
+	assert.Equal(t, "50%%", systemdQuote("50%"))
+	assert.Equal(t, `"a b"`, systemdQuote("a b"))
+	assert.Equal(t, `"say \"hi\""`, systemdQuote(`say "hi"`))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'/opt/chain launch'`, shellQuote("/opt/chain launch"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
+func TestWriteTarExclude(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "msp", "signcerts"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "data", "chains"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "msp", "signcerts", "cert.pem"), []byte("cert"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "data", "chains", "blockfile"), []byte("ledger"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "core.yaml"), []byte("path: /local"), 0644))
+
+	var buf bytes.Buffer
+	rewrite := func(name string, data []byte) []byte {
+		return bytes.ReplaceAll(data, []byte("/local"), []byte("/remote"))
+	}
+	require.NoError(t, writeTar(&buf, root, []string{filepath.Join(root, "data")}, rewrite))
+
+	files := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+	assert.Equal(t, "cert", files["msp/signcerts/cert.pem"])
+	assert.Equal(t, "path: /remote", files["core.yaml"])
+	assert.NotContains(t, files, "data/")
+	assert.NotContains(t, files, "data/chains/blockfile")
+}
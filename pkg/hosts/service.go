@@ -0,0 +1,380 @@
+// Package hosts manages remote Linux hosts that nodes can be deployed to.
+//
+// Hosts are reached over SSH with a private key held in key management. The
+// server key is pinned on the first successful connection. A node placed on
+// a host keeps its files in the local data directory, as local nodes do, and
+// a Runner copies them to the host before starting the node there as a
+// systemd unit or through the host Docker daemon, tunnelled over SSH.
+package hosts
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+)
+
+// ErrHostInUse is returned when deleting a host that still has nodes placed on it
+var ErrHostInUse = errors.New("host has nodes placed on it")
+
+// Service manages hosts and opens connections to them
+type Service struct {
+	queries  *db.Queries
+	keys     *keymanagement.KeyManagementService
+	logger   *logger.Logger
+	dataPath string
+}
+
+// NewService creates a hosts service. dataPath is the local ChainLaunch data
+// directory whose node files are mirrored onto hosts.
+func NewService(queries *db.Queries, keys *keymanagement.KeyManagementService, dataPath string, logger *logger.Logger) *Service {
+	return &Service{
+		queries:  queries,
+		keys:     keys,
+		logger:   logger,
+		dataPath: dataPath,
+	}
+}
+
+// CreateHost registers a host. The connection is not checked; use CheckHost for that.
+func (s *Service) CreateHost(ctx context.Context, req CreateHostRequest) (*Host, error) {
+	if req.Port == 0 {
+		req.Port = 22
+	}
+	if req.DataPath == "" {
+		req.DataPath = DefaultDataPath
+	}
+	if err := validateDataPath(req.DataPath); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizedKey(ctx, req.SSHKeyID); err != nil {
+		return nil, err
+	}
+
+	host, err := s.queries.CreateHost(ctx, &db.CreateHostParams{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Address:     req.Address,
+		Port:        int64(req.Port),
+		Username:    req.Username,
+		SshKeyID:    req.SSHKeyID,
+		DataPath:    strings.TrimRight(req.DataPath, "/"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create host: %w", err)
+	}
+	return s.mapHost(ctx, host), nil
+}
+
+// GetHost returns a host
+func (s *Service) GetHost(ctx context.Context, id int64) (*Host, error) {
+	host, err := s.queries.GetHost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+	return s.mapHost(ctx, host), nil
+}
+
+// ListHosts returns all hosts ordered by name
+func (s *Service) ListHosts(ctx context.Context) ([]Host, error) {
+	hosts, err := s.queries.ListHosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+	result := make([]Host, 0, len(hosts))
+	for _, host := range hosts {
+		result = append(result, *s.mapHost(ctx, host))
+	}
+	return result, nil
+}
+
+// UpdateHost changes a host
+func (s *Service) UpdateHost(ctx context.Context, id int64, req UpdateHostRequest) (*Host, error) {
+	host, err := s.queries.GetHost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+	params := &db.UpdateHostParams{
+		Name:        host.Name,
+		Description: host.Description,
+		Address:     host.Address,
+		Port:        host.Port,
+		Username:    host.Username,
+		SshKeyID:    host.SshKeyID,
+		DataPath:    host.DataPath,
+		HostKey:     host.HostKey,
+		ID:          id,
+	}
+	if req.Name != nil {
+		params.Name = *req.Name
+	}
+	if req.Description != nil {
+		params.Description = sql.NullString{String: *req.Description, Valid: *req.Description != ""}
+	}
+	if req.Address != nil {
+		params.Address = *req.Address
+	}
+	if req.Port != nil {
+		params.Port = int64(*req.Port)
+	}
+	if req.Username != nil {
+		params.Username = *req.Username
+	}
+	if req.SSHKeyID != nil {
+		if _, err := s.authorizedKey(ctx, *req.SSHKeyID); err != nil {
+			return nil, err
+		}
+		params.SshKeyID = *req.SSHKeyID
+	}
+	if req.DataPath != nil {
+		if err := validateDataPath(*req.DataPath); err != nil {
+			return nil, err
+		}
+		params.DataPath = strings.TrimRight(*req.DataPath, "/")
+	}
+	// A different machine behind the same name presents a different server key
+	if req.ResetHostKey || (req.Address != nil && *req.Address != host.Address) {
+		params.HostKey = sql.NullString{}
+	}
+
+	updated, err := s.queries.UpdateHost(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update host: %w", err)
+	}
+	return s.mapHost(ctx, updated), nil
+}
+
+// DeleteHost removes a host that has no nodes placed on it
+func (s *Service) DeleteHost(ctx context.Context, id int64) error {
+	if _, err := s.queries.GetHost(ctx, id); err != nil {
+		return fmt.Errorf("failed to get host: %w", err)
+	}
+	count, err := s.queries.CountNodeHostsByHost(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count host nodes: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d node(s)", ErrHostInUse, count)
+	}
+	if err := s.queries.DeleteHost(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete host: %w", err)
+	}
+	return nil
+}
+
+// CheckHost connects to a host and reports what it can run. The host status is
+// updated with the outcome.
+func (s *Service) CheckHost(ctx context.Context, id int64) (*CheckResult, error) {
+	runner, err := s.Connect(ctx, id)
+	if err != nil {
+		s.setStatus(ctx, id, StatusUnreachable, err.Error())
+		return nil, err
+	}
+	defer runner.Close()
+
+	platform, err := runner.client.Run(ctx, "uname -sm")
+	if err != nil {
+		s.setStatus(ctx, id, StatusUnreachable, err.Error())
+		return nil, err
+	}
+	result := &CheckResult{Platform: strings.TrimSpace(string(platform))}
+	if _, err := runner.client.Run(ctx, "command -v systemctl"); err == nil {
+		result.Systemd = true
+	}
+	if cli, err := runner.client.DockerClient(); err == nil {
+		if version, err := cli.ServerVersion(ctx); err == nil {
+			result.Docker = version.Version
+		}
+		cli.Close()
+	}
+
+	s.setStatus(ctx, id, StatusReachable, "")
+	if result.Host, err = s.GetHost(ctx, id); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Connect opens an SSH connection to a host and returns a runner for it.
+// The server key is pinned on the first connection. Callers must close the runner.
+func (s *Service) Connect(ctx context.Context, id int64) (*Runner, error) {
+	host, err := s.queries.GetHost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+	signer, err := s.signer(host.SshKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, serverKey, err := dial(ctx, dialConfig{
+		address:  host.Address,
+		port:     int(host.Port),
+		username: host.Username,
+		signer:   signer,
+		hostKey:  host.HostKey.String,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host %s: %w", host.Name, err)
+	}
+	if !host.HostKey.Valid && serverKey != nil {
+		pinned := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(serverKey)))
+		if err := s.queries.UpdateHostKey(ctx, &db.UpdateHostKeyParams{
+			HostKey: sql.NullString{String: pinned, Valid: true},
+			ID:      host.ID,
+		}); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to pin host key: %w", err)
+		}
+		host.HostKey = sql.NullString{String: pinned, Valid: true}
+		s.logger.Info("Pinned host key", "host", host.Name, "fingerprint", ssh.FingerprintSHA256(serverKey))
+	}
+
+	return &Runner{
+		client:    client,
+		host:      s.mapHost(ctx, host),
+		localRoot: strings.TrimRight(s.dataPath, "/"),
+	}, nil
+}
+
+// AssignNode places a node on a host. The node runs there from its next start.
+func (s *Service) AssignNode(ctx context.Context, hostID, nodeID int64) error {
+	if _, err := s.queries.GetHost(ctx, hostID); err != nil {
+		return fmt.Errorf("failed to get host: %w", err)
+	}
+	if _, err := s.queries.GetNode(ctx, nodeID); err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+	if err := s.queries.SetNodeHost(ctx, &db.SetNodeHostParams{NodeID: nodeID, HostID: hostID}); err != nil {
+		return fmt.Errorf("failed to place node on host: %w", err)
+	}
+	return nil
+}
+
+// UnassignNode moves a node back to the local machine from its next start
+func (s *Service) UnassignNode(ctx context.Context, nodeID int64) error {
+	if err := s.queries.DeleteNodeHost(ctx, nodeID); err != nil {
+		return fmt.Errorf("failed to remove node placement: %w", err)
+	}
+	return nil
+}
+
+// ListHostNodes returns the IDs of the nodes placed on a host
+func (s *Service) ListHostNodes(ctx context.Context, hostID int64) ([]int64, error) {
+	rows, err := s.queries.ListNodeHostsByHost(ctx, hostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list host nodes: %w", err)
+	}
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.NodeID)
+	}
+	return ids, nil
+}
+
+// RunnerForNode connects to the host a node is placed on. It returns nil
+// without error for nodes that run locally.
+func (s *Service) RunnerForNode(ctx context.Context, nodeID int64) (*Runner, error) {
+	placement, err := s.queries.GetNodeHost(ctx, nodeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get node placement: %w", err)
+	}
+	return s.Connect(ctx, placement.HostID)
+}
+
+func (s *Service) setStatus(ctx context.Context, id int64, status Status, lastError string) {
+	if err := s.queries.UpdateHostStatus(ctx, &db.UpdateHostStatusParams{
+		Status:    string(status),
+		LastError: sql.NullString{String: lastError, Valid: lastError != ""},
+		ID:        id,
+	}); err != nil {
+		s.logger.Error("Failed to update host status", "hostID", id, "error", err)
+	}
+}
+
+// signer loads the SSH private key of a host from key management
+func (s *Service) signer(keyID int64) (ssh.Signer, error) {
+	privateKey, err := s.keys.GetDecryptedPrivateKey(int(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ssh key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("key %d can't be used for ssh: %w", keyID, err)
+	}
+	return signer, nil
+}
+
+// authorizedKey returns the authorized_keys line for a key management key
+func (s *Service) authorizedKey(ctx context.Context, keyID int64) (string, error) {
+	key, err := s.keys.GetKey(ctx, int(keyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get ssh key %d: %w", keyID, err)
+	}
+	return authorizedKeyFromPEM(key.PublicKey)
+}
+
+// authorizedKeyFromPEM converts a PEM encoded public key to authorized_keys format
+func authorizedKeyFromPEM(publicKeyPEM string) (string, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return "", fmt.Errorf("invalid public key PEM")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+	sshKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("key can't be used for ssh: %w", err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshKey))), nil
+}
+
+func validateDataPath(dataPath string) error {
+	if !strings.HasPrefix(dataPath, "/") || strings.Contains(dataPath, "..") {
+		return fmt.Errorf("data path must be an absolute path, got %q", dataPath)
+	}
+	return nil
+}
+
+func (s *Service) mapHost(ctx context.Context, host *db.Host) *Host {
+	result := &Host{
+		ID:          host.ID,
+		Name:        host.Name,
+		Description: host.Description.String,
+		Address:     host.Address,
+		Port:        int(host.Port),
+		Username:    host.Username,
+		SSHKeyID:    host.SshKeyID,
+		DataPath:    host.DataPath,
+		Status:      Status(host.Status),
+		LastError:   host.LastError.String,
+		CreatedAt:   host.CreatedAt,
+		UpdatedAt:   host.UpdatedAt,
+	}
+	if host.LastCheckedAt.Valid {
+		result.LastCheckedAt = &host.LastCheckedAt.Time
+	}
+	if host.HostKey.Valid {
+		if key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(host.HostKey.String)); err == nil {
+			result.HostKeyFingerprint = ssh.FingerprintSHA256(key)
+		}
+	}
+	if authorizedKey, err := s.authorizedKey(ctx, host.SshKeyID); err == nil {
+		result.AuthorizedKey = authorizedKey
+	}
+	return result
+}
@@ -0,0 +1,380 @@
+package hosts
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	dockerclient "github.com/docker/docker/client"
+	"golang.org/x/crypto/ssh"
+)
+
+// dockerSocket is the Docker daemon socket dialled through the SSH connection
+const dockerSocket = "/var/run/docker.sock"
+
+// dialTimeout bounds the TCP connect and SSH handshake
+const dialTimeout = 15 * time.Second
+
+// Client runs commands and copies files on a host over SSH
+type Client struct {
+	conn     *ssh.Client
+	username string
+}
+
+// dialConfig holds what is needed to open an SSH connection
+type dialConfig struct {
+	address  string
+	port     int
+	username string
+	signer   ssh.Signer
+	// hostKey is the pinned server key in authorized_keys format. When empty,
+	// the key presented by the server is accepted and returned by dial.
+	hostKey string
+}
+
+// dial connects to a host and returns the client with the server key it presented
+func dial(ctx context.Context, cfg dialConfig) (*Client, ssh.PublicKey, error) {
+	var seen ssh.PublicKey
+	hostKeyCallback := func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		seen = key
+		return nil
+	}
+	if cfg.hostKey != "" {
+		pinned, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.hostKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse pinned host key: %w", err)
+		}
+		fixed := ssh.FixedHostKey(pinned)
+		hostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if err := fixed(hostname, remote, key); err != nil {
+				return fmt.Errorf("host key mismatch: expected %s, got %s", ssh.FingerprintSHA256(pinned), ssh.FingerprintSHA256(key))
+			}
+			seen = key
+			return nil
+		}
+	}
+
+	address := net.JoinHostPort(cfg.address, strconv.Itoa(cfg.port))
+	dialer := net.Dialer{Timeout: dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	_ = netConn.SetDeadline(time.Now().Add(dialTimeout))
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, address, &ssh.ClientConfig{
+		User:            cfg.username,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(cfg.signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	})
+	if err != nil {
+		netConn.Close()
+		return nil, nil, fmt.Errorf("ssh handshake with %s failed: %w", address, err)
+	}
+	_ = netConn.SetDeadline(time.Time{})
+
+	return &Client{conn: ssh.NewClient(sshConn, chans, reqs), username: cfg.username}, seen, nil
+}
+
+// Close closes the SSH connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Run runs a shell command on the host and returns its combined output
+func (c *Client) Run(ctx context.Context, cmd string) ([]byte, error) {
+	return c.RunWithInput(ctx, cmd, nil)
+}
+
+// RunWithInput runs a shell command with stdin read from input
+func (c *Client) RunWithInput(ctx context.Context, cmd string, input io.Reader) ([]byte, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	var output bytes.Buffer
+	session.Stdout = &output
+	session.Stderr = &output
+	if input != nil {
+		session.Stdin = input
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(cmd) }()
+	select {
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGTERM)
+		session.Close()
+		return output.Bytes(), ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return output.Bytes(), fmt.Errorf("command %q failed: %w: %s", cmd, err, strings.TrimSpace(output.String()))
+		}
+		return output.Bytes(), nil
+	}
+}
+
+// privileged wraps a command so it runs as root when the SSH user isn't root.
+// The user needs passwordless sudo for that.
+func (c *Client) privileged(cmd string) string {
+	if c.username == "root" {
+		return cmd
+	}
+	return "sudo -n sh -c " + shellQuote(cmd)
+}
+
+// WriteFile writes data to a file on the host, creating its directory
+func (c *Client) WriteFile(ctx context.Context, remotePath string, data []byte, mode os.FileMode, privileged bool) error {
+	cmd := fmt.Sprintf("mkdir -p %s && cat > %s && chmod %o %s",
+		shellQuote(path.Dir(remotePath)), shellQuote(remotePath), mode.Perm(), shellQuote(remotePath))
+	if privileged {
+		cmd = c.privileged(cmd)
+	}
+	if _, err := c.RunWithInput(ctx, cmd, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", remotePath, err)
+	}
+	return nil
+}
+
+// Upload copies a local file or directory tree to remotePath on the host.
+// When rewrite is set, it is applied to the content of every regular file
+// and may return the content unchanged. Local paths in exclude are skipped.
+func (c *Client) Upload(ctx context.Context, localPath, remotePath string, exclude []string, rewrite func(name string, data []byte) []byte) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", localPath, err)
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", localPath, err)
+		}
+		if rewrite != nil {
+			data = rewrite(localPath, data)
+		}
+		return c.WriteFile(ctx, remotePath, data, info.Mode(), false)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, localPath, exclude, rewrite))
+	}()
+	cmd := fmt.Sprintf("mkdir -p %s && tar -xf - -C %s", shellQuote(remotePath), shellQuote(remotePath))
+	if _, err := c.RunWithInput(ctx, cmd, pr); err != nil {
+		pr.CloseWithError(err)
+		return fmt.Errorf("failed to upload %s: %w", localPath, err)
+	}
+	return nil
+}
+
+// writeTar writes the tree under root as a tar stream with paths relative to root
+func writeTar(w io.Writer, root string, exclude []string, rewrite func(name string, data []byte) []byte) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		for _, excluded := range exclude {
+			if filepath.Clean(name) == filepath.Clean(excluded) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case info.IsDir():
+			return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: rel + "/", Mode: int64(info.Mode().Perm()), ModTime: info.ModTime()})
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(name)
+			if err != nil {
+				return err
+			}
+			return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: rel, Linkname: target, ModTime: info.ModTime()})
+		case info.Mode().IsRegular():
+			data, err := os.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if rewrite != nil {
+				data = rewrite(name, data)
+			}
+			if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: rel, Mode: int64(info.Mode().Perm()), Size: int64(len(data)), ModTime: info.ModTime()}); err != nil {
+				return err
+			}
+			_, err = tw.Write(data)
+			return err
+		default:
+			// Sockets, pipes and devices are not copied
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// Download copies the file tree under remotePath on the host to localPath
+func (c *Client) Download(ctx context.Context, remotePath, localPath string) error {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open ssh stdout: %w", err)
+	}
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+	// Ledger files written by node containers are owned by root
+	cmd := c.privileged(fmt.Sprintf("tar -cf - -C %s .", shellQuote(remotePath)))
+	if err := session.Start(cmd); err != nil {
+		return fmt.Errorf("failed to start download of %s: %w", remotePath, err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Signal(ssh.SIGTERM)
+			session.Close()
+		case <-done:
+		}
+	}()
+
+	if err := readTar(stdout, localPath); err != nil {
+		return fmt.Errorf("failed to download %s: %w", remotePath, err)
+	}
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("failed to download %s: %w: %s", remotePath, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// readTar extracts a tar stream under root. Entries escaping root are rejected.
+func readTar(r io.Reader, root string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(root, filepath.FromSlash(header.Name))
+		if name != filepath.Clean(root) && !strings.HasPrefix(name, filepath.Clean(root)+string(filepath.Separator)) {
+			return fmt.Errorf("tar entry %q escapes %s", header.Name, root)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(name, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		default:
+			// Links and special files are not part of the data copied back
+		}
+	}
+}
+
+// Stream runs a long-lived command and returns its output line by line.
+// The command is terminated when ctx is done; the channel is closed when it exits.
+func (c *Client) Stream(ctx context.Context, cmd string) (<-chan string, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	pr, pw := io.Pipe()
+	session.Stdout = pw
+	session.Stderr = pw
+	if err := session.Start(cmd); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("failed to start %q: %w", cmd, err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		pw.CloseWithError(session.Wait())
+		close(exited)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Signal(ssh.SIGTERM)
+			pr.Close()
+		case <-exited:
+		}
+		session.Close()
+	}()
+
+	lines := make(chan string, 100)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text() + "\n":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return lines, nil
+}
+
+// DockerClient returns a Docker API client talking to the host daemon through
+// the SSH connection. The SSH user must be allowed to use the Docker socket.
+func (c *Client) DockerClient() (*dockerclient.Client, error) {
+	cli, err := dockerclient.NewClientWithOpts(
+		dockerclient.WithHost("unix://"+dockerSocket),
+		dockerclient.WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return c.conn.Dial("unix", dockerSocket)
+		}),
+		dockerclient.WithAPIVersionNegotiation(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote docker client: %w", err)
+	}
+	return cli, nil
+}
+
+// shellQuote quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
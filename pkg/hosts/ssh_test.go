@@ -0,0 +1,264 @@
+package hosts
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer runs exec requests with the local shell, standing in for a host
+type testSSHServer struct {
+	addr    *net.TCPAddr
+	hostKey ssh.Signer
+}
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+func startTestSSHServer(t *testing.T, clientKey ssh.PublicKey) *testSSHServer {
+	t.Helper()
+	hostKey := newTestSigner(t)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, assert.AnError
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+	return &testSSHServer{addr: listener.Addr().(*net.TCPAddr), hostKey: hostKey}
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				length := binary.BigEndian.Uint32(req.Payload)
+				cmd := exec.Command("sh", "-c", string(req.Payload[4:4+length]))
+				cmd.Stdin = channel
+				cmd.Stdout = channel
+				cmd.Stderr = channel.Stderr()
+				req.Reply(true, nil)
+				status := uint32(0)
+				if err := cmd.Run(); err != nil {
+					status = 1
+				}
+				channel.CloseWrite()
+				channel.SendRequest("exit-status", false, binary.BigEndian.AppendUint32(nil, status))
+				return
+			}
+		}()
+	}
+}
+
+func TestDialPinsHostKey(t *testing.T) {
+	clientKey := newTestSigner(t)
+	server := startTestSSHServer(t, clientKey.PublicKey())
+	ctx := context.Background()
+
+	client, seen, err := dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+	})
+	require.NoError(t, err)
+	client.Close()
+	require.NotNil(t, seen)
+	assert.Equal(t, ssh.FingerprintSHA256(server.hostKey.PublicKey()), ssh.FingerprintSHA256(seen))
+
+	pinned := string(ssh.MarshalAuthorizedKey(seen))
+	client, _, err = dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+		hostKey:  pinned,
+	})
+	require.NoError(t, err)
+	client.Close()
+
+	other := string(ssh.MarshalAuthorizedKey(newTestSigner(t).PublicKey()))
+	_, _, err = dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+		hostKey:  other,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "host key mismatch")
+}
+
+func TestDialRejectsUnknownKey(t *testing.T) {
+	server := startTestSSHServer(t, newTestSigner(t).PublicKey())
+	_, _, err := dial(context.Background(), dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   newTestSigner(t),
+	})
+	assert.Error(t, err)
+}
+
+func TestClientRunAndUpload(t *testing.T) {
+	clientKey := newTestSigner(t)
+	server := startTestSSHServer(t, clientKey.PublicKey())
+	ctx := context.Background()
+
+	client, _, err := dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	out, err := client.Run(ctx, "echo hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(out))
+
+	_, err = client.Run(ctx, "exit 3")
+	assert.Error(t, err)
+
+	local := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "msp"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "msp", "cert.pem"), []byte("cert"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "core.yaml"), []byte("root: LOCAL"), 0644))
+
+	remote := filepath.Join(t.TempDir(), "node", "config")
+	rewrite := func(name string, data []byte) []byte {
+		return []byte(strings.ReplaceAll(string(data), "LOCAL", "REMOTE"))
+	}
+	require.NoError(t, client.Upload(ctx, local, remote, nil, rewrite))
+
+	data, err := os.ReadFile(filepath.Join(remote, "msp", "cert.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+	data, err = os.ReadFile(filepath.Join(remote, "core.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "root: REMOTE", string(data))
+
+	single := filepath.Join(t.TempDir(), "bin", "peer")
+	require.NoError(t, client.WriteFile(ctx, single, []byte("#!/bin/sh\n"), 0755, false))
+	info, err := os.Stat(single)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
+
+func TestClientStream(t *testing.T) {
+	clientKey := newTestSigner(t)
+	server := startTestSSHServer(t, clientKey.PublicKey())
+	ctx := context.Background()
+
+	client, _, err := dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	lines, err := client.Stream(ctx, "printf 'one\\ntwo\\n'")
+	require.NoError(t, err)
+	var got []string
+	for line := range lines {
+		got = append(got, line)
+	}
+	assert.Equal(t, []string{"one\n", "two\n"}, got)
+}
+
+func TestClientDownload(t *testing.T) {
+	clientKey := newTestSigner(t)
+	server := startTestSSHServer(t, clientKey.PublicKey())
+	ctx := context.Background()
+
+	client, _, err := dial(ctx, dialConfig{
+		address:  "127.0.0.1",
+		port:     server.addr.Port,
+		username: "root",
+		signer:   clientKey,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	remote := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(remote, "snapshots", "100"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(remote, "snapshots", "100", "_snapshot_signable_metadata.json"), []byte(`{"channel_name":"mychannel"}`), 0644))
+
+	local := filepath.Join(t.TempDir(), "copy")
+	require.NoError(t, client.Download(ctx, remote, local))
+	data, err := os.ReadFile(filepath.Join(local, "snapshots", "100", "_snapshot_signable_metadata.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"channel_name":"mychannel"}`, string(data))
+
+	assert.Error(t, client.Download(ctx, filepath.Join(remote, "missing"), local))
+}
+
+func TestReadTarRejectsEscapingEntries(t *testing.T) {
+	var buf strings.Builder
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../escape", Mode: 0644, Size: 1}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	root := t.TempDir()
+	assert.ErrorContains(t, readTar(strings.NewReader(buf.String()), filepath.Join(root, "dir")), "escapes")
+	_, err = os.Stat(filepath.Join(root, "escape"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,76 @@
+package hosts
+
+import "time"
+
+// Status is the result of the last connection check of a host
+type Status string
+
+const (
+	StatusUnknown     Status = "UNKNOWN"
+	StatusReachable   Status = "REACHABLE"
+	StatusUnreachable Status = "UNREACHABLE"
+)
+
+// DefaultDataPath is where node files are placed on a host when no data path is given
+const DefaultDataPath = "/opt/chainlaunch"
+
+// Host is a remote Linux machine nodes can be deployed to
+type Host struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Address     string `json:"address"`
+	Port        int    `json:"port"`
+	Username    string `json:"username"`
+	// SSHKeyID is the key management key used to authenticate
+	SSHKeyID int64 `json:"sshKeyId"`
+	// AuthorizedKey is the public key to add to ~/.ssh/authorized_keys on the host
+	AuthorizedKey string `json:"authorizedKey,omitempty"`
+	// HostKeyFingerprint is the SHA256 fingerprint of the server key pinned on first connect
+	HostKeyFingerprint string `json:"hostKeyFingerprint,omitempty"`
+	// DataPath is the directory node files are copied to on the host
+	DataPath      string     `json:"dataPath"`
+	Status        Status     `json:"status"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastCheckedAt *time.Time `json:"lastCheckedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// CreateHostRequest registers a remote host
+type CreateHostRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+	Address     string `json:"address" validate:"required"`
+	// Port is the SSH port (default: 22)
+	Port     int    `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	Username string `json:"username" validate:"required"`
+	// SSHKeyID is an RSA, EC or ED25519 key from key management
+	SSHKeyID int64 `json:"sshKeyId" validate:"required"`
+	// DataPath is the directory node files are copied to (default: /opt/chainlaunch)
+	DataPath string `json:"dataPath,omitempty"`
+}
+
+// UpdateHostRequest changes a host. Omitted fields keep their value.
+type UpdateHostRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Address     *string `json:"address,omitempty"`
+	Port        *int    `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	Username    *string `json:"username,omitempty"`
+	SSHKeyID    *int64  `json:"sshKeyId,omitempty"`
+	DataPath    *string `json:"dataPath,omitempty"`
+	// ResetHostKey forgets the pinned server key, e.g. after the host was reinstalled
+	ResetHostKey bool `json:"resetHostKey,omitempty"`
+}
+
+// CheckResult describes a host after a successful connection check
+type CheckResult struct {
+	Host *Host `json:"host"`
+	// Platform is the output of `uname -sm`, e.g. "Linux x86_64"
+	Platform string `json:"platform"`
+	// Systemd reports whether systemctl is available for service mode nodes
+	Systemd bool `json:"systemd"`
+	// Docker is the server version of the Docker daemon, empty when Docker isn't usable
+	Docker string `json:"docker,omitempty"`
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/providers"
 	"github.com/chainlaunch/chainlaunch/pkg/keymanagement/providers/types"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/instrumentation"
 )
 
 type KeyManagementService struct {
@@ -534,11 +535,13 @@ func (s *KeyManagementService) SignCertificate(ctx context.Context, keyID int, c
 	}
 
 	// Sign the certificate
-	return provider.SignCertificate(ctx, types.SignCertificateRequest{
+	resp, err := provider.SignCertificate(ctx, types.SignCertificateRequest{
 		KeyID:              keyID,
 		CAKeyID:            caKeyID,
 		CertificateRequest: *ToProviderCertRequest(&certReq),
 	})
+	instrumentation.KeySigningOperations.WithLabelValues("sign_certificate", instrumentation.Status(err)).Inc()
+	return resp, err
 }
 
 // GetDecryptedPrivateKey retrieves and decrypts the private key for a given key ID
@@ -777,11 +780,13 @@ func (s *KeyManagementService) RenewCertificate(ctx context.Context, keyID int,
 	}
 
 	// Sign the certificate with the same CA
-	return provider.SignCertificate(ctx, types.SignCertificateRequest{
+	resp, err := provider.SignCertificate(ctx, types.SignCertificateRequest{
 		KeyID:              keyID,
 		CAKeyID:            caKeyID,
 		CertificateRequest: *ToProviderCertRequest(&certReq),
 	})
+	instrumentation.KeySigningOperations.WithLabelValues("renew_certificate", instrumentation.Status(err)).Inc()
+	return resp, err
 }
 
 // Helper function to parse PEM certificate
@@ -833,6 +838,7 @@ func (s *KeyManagementService) SignData(ctx context.Context, keyID int, req mode
 
 	// Sign the data
 	signature, err := provider.SignData(ctx, keyID, req)
+	instrumentation.KeySigningOperations.WithLabelValues("sign_data", instrumentation.Status(err)).Inc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign data: %w", err)
 	}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/auth"
+)
+
+// Client calls the ChainLaunch API on behalf of a user
+type Client struct {
+	baseURL       string
+	authorization string
+	httpClient    *http.Client
+}
+
+// NewClient creates an API client authenticated with a username and password, or
+// with a session token when token is set
+func NewClient(baseURL, username, password, token string) (*Client, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("API URL is required")
+	}
+	var authorization string
+	switch {
+	case token != "":
+		authorization = "Bearer " + token
+	case username != "" && password != "":
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+	return &Client{
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		authorization: authorization,
+		httpClient:    &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// WithAuthorization returns a client sending the given Authorization header, used to
+// forward the credentials of HTTP callers
+func (c *Client) WithAuthorization(authorization string) *Client {
+	clone := *c
+	clone.authorization = authorization
+	return &clone
+}
+
+// HasCredentials reports whether the client sends credentials
+func (c *Client) HasCredentials() bool {
+	return c.authorization != ""
+}
+
+// APIError is returned when the API answers with an error status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Get calls a GET endpoint and decodes the JSON response into out
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return c.do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Post calls a POST endpoint and decodes the JSON response into out
+func (c *Client) Post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, body, out)
+}
+
+// GetText calls a GET endpoint returning plain text or an event stream
+func (c *Client) GetText(ctx context.Context, path string, query url.Values) (string, error) {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	resp, err := c.send(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return string(data), nil
+}
+
+// CurrentUser returns the user the client is authenticated as
+func (c *Client) CurrentUser(ctx context.Context) (*auth.UserResponse, error) {
+	var user auth.UserResponse
+	if err := c.Get(ctx, "/auth/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	resp, err := c.send(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// send performs a request and returns the response when the status is successful
+func (c *Client) send(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// Resource is a static MCP resource backed by a ChainLaunch API endpoint
+type Resource struct {
+	URI         string
+	Name        string
+	Description string
+	Read        func(ctx context.Context, client *Client) (interface{}, error)
+}
+
+// ResourceTemplate is a parameterized MCP resource, matched against the requested URI
+type ResourceTemplate struct {
+	URITemplate string
+	Name        string
+	Description string
+	pattern     *regexp.Regexp
+	Read        func(ctx context.Context, client *Client, id int64) (interface{}, error)
+}
+
+// DefaultResources returns the curated ChainLaunch resources
+func DefaultResources() []Resource {
+	return []Resource{
+		{
+			URI:         "chainlaunch://organizations",
+			Name:        "Organizations",
+			Description: "Fabric organizations",
+			Read: func(ctx context.Context, client *Client) (interface{}, error) {
+				return getJSON(ctx, client, "/organizations", url.Values{"limit": {"100"}})
+			},
+		},
+		{
+			URI:         "chainlaunch://nodes",
+			Name:        "Nodes",
+			Description: "Nodes of every platform with their status",
+			Read: func(ctx context.Context, client *Client) (interface{}, error) {
+				return getJSON(ctx, client, "/nodes", url.Values{"limit": {"100"}})
+			},
+		},
+		{
+			URI:         "chainlaunch://networks/fabric",
+			Name:        "Fabric networks",
+			Description: "Hyperledger Fabric networks",
+			Read: func(ctx context.Context, client *Client) (interface{}, error) {
+				return getJSON(ctx, client, "/networks/fabric", url.Values{"limit": {"100"}})
+			},
+		},
+		{
+			URI:         "chainlaunch://networks/besu",
+			Name:        "Besu networks",
+			Description: "Hyperledger Besu networks",
+			Read: func(ctx context.Context, client *Client) (interface{}, error) {
+				return getJSON(ctx, client, "/networks/besu", url.Values{"limit": {"100"}})
+			},
+		},
+		{
+			URI:         "chainlaunch://keys",
+			Name:        "Keys",
+			Description: "Key metadata, without key material",
+			Read: func(ctx context.Context, client *Client) (interface{}, error) {
+				return listKeys(ctx, client, map[string]interface{}{})
+			},
+		},
+	}
+}
+
+// DefaultResourceTemplates returns the curated ChainLaunch resource templates
+func DefaultResourceTemplates() []ResourceTemplate {
+	return []ResourceTemplate{
+		{
+			URITemplate: "chainlaunch://nodes/{id}",
+			Name:        "Node",
+			Description: "Configuration and status of a node",
+			pattern:     regexp.MustCompile(`^chainlaunch://nodes/(\d+)$`),
+			Read: func(ctx context.Context, client *Client, id int64) (interface{}, error) {
+				return getJSON(ctx, client, fmt.Sprintf("/nodes/%d", id), nil)
+			},
+		},
+		{
+			URITemplate: "chainlaunch://networks/fabric/{id}/channel-config",
+			Name:        "Fabric channel config",
+			Description: "Current channel configuration of a Fabric network",
+			pattern:     regexp.MustCompile(`^chainlaunch://networks/fabric/(\d+)/channel-config$`),
+			Read: func(ctx context.Context, client *Client, id int64) (interface{}, error) {
+				return getJSON(ctx, client, fmt.Sprintf("/networks/fabric/%d/current-channel-config", id), nil)
+			},
+		},
+	}
+}
+
+// match returns the ID captured from uri when it matches the template
+func (t ResourceTemplate) match(uri string) (int64, bool) {
+	m := t.pattern.FindStringSubmatch(uri)
+	if m == nil {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
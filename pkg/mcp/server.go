@@ -0,0 +1,341 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+)
+
+// maxMessageSize is the maximum size of a JSON-RPC message
+const maxMessageSize = 4 << 20
+
+const serverInstructions = `ChainLaunch manages Hyperledger Fabric and Besu networks.
+Use list_nodes and list_networks to find IDs before calling the other tools.
+Tools changing the state of nodes are only available to managers and admins, and audit logs to admins.`
+
+// Server is an MCP server exposing ChainLaunch operations
+type Server struct {
+	client    *Client
+	tools     []Tool
+	resources []Resource
+	templates []ResourceTemplate
+	logger    *logger.Logger
+	version   string
+}
+
+// NewServer creates an MCP server calling the API with client
+func NewServer(client *Client, logger *logger.Logger, version string) *Server {
+	return &Server{
+		client:    client,
+		tools:     DefaultTools(),
+		resources: DefaultResources(),
+		templates: DefaultResourceTemplates(),
+		logger:    logger,
+		version:   version,
+	}
+}
+
+// ServeStdio reads newline-delimited JSON-RPC messages from in and writes the responses to
+// out until in is closed or ctx is done
+func (s *Server) ServeStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := append([]byte(nil), scanner.Bytes()...)
+		if len(line) == 0 {
+			continue
+		}
+		// Requests are handled concurrently so a slow tool does not block pings
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := s.handle(ctx, s.client, line)
+			if resp == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if _, err := out.Write(append(resp, '\n')); err != nil {
+				s.logger.Error("Failed to write MCP response", "error", err)
+			}
+		}()
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read MCP messages: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP implements the streamable HTTP transport with JSON responses. Every request must
+// carry an Authorization header, which is forwarded to the API: the configured credentials
+// are never used for HTTP callers, who could otherwise act as the configured user.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="chainlaunch"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+	client := s.client.WithAuthorization(authorization)
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	resp := s.handle(r.Context(), client, body)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// handle processes a JSON-RPC message and returns the encoded response, or nil for notifications
+func (s *Server) handle(ctx context.Context, client *Client, raw []byte) []byte {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return encodeResponse(response{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &rpcError{Code: codeParseError, Message: "invalid JSON"},
+		})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if req.isNotification() {
+			return nil
+		}
+		return encodeResponse(response{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC request"},
+		})
+	}
+
+	result, err := s.dispatch(ctx, client, &req)
+	if req.isNotification() {
+		return nil
+	}
+	resp := response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			s.logger.Error("Failed to handle MCP request", "method", req.Method, "error", err)
+			rpcErr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rpcErr
+	}
+	return encodeResponse(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, client *Client, req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		version := protocolVersion
+		if supportedProtocolVersions[params.ProtocolVersion] {
+			version = params.ProtocolVersion
+		}
+		s.logger.Info("MCP client connected", "client", params.ClientInfo.Name, "version", params.ClientInfo.Version)
+		return initializeResult{
+			ProtocolVersion: version,
+			Capabilities: map[string]interface{}{
+				"tools":     map[string]interface{}{},
+				"resources": map[string]interface{}{},
+			},
+			ServerInfo:   implementation{Name: "chainlaunch", Version: s.version},
+			Instructions: serverInstructions,
+		}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return s.listTools(ctx, client)
+	case "tools/call":
+		var params callToolParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, client, params)
+	case "resources/list":
+		result := listResourcesResult{Resources: []resourceInfo{}}
+		for _, r := range s.resources {
+			result.Resources = append(result.Resources, resourceInfo{
+				URI:         r.URI,
+				Name:        r.Name,
+				Description: r.Description,
+				MimeType:    "application/json",
+			})
+		}
+		return result, nil
+	case "resources/templates/list":
+		result := listResourceTemplatesResult{ResourceTemplates: []resourceTemplateInfo{}}
+		for _, t := range s.templates {
+			result.ResourceTemplates = append(result.ResourceTemplates, resourceTemplateInfo{
+				URITemplate: t.URITemplate,
+				Name:        t.Name,
+				Description: t.Description,
+				MimeType:    "application/json",
+			})
+		}
+		return result, nil
+	case "resources/read":
+		var params readResourceParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.readResource(ctx, client, params.URI)
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)}
+}
+
+// listTools returns the tools allowed for the role of the user
+func (s *Server) listTools(ctx context.Context, client *Client) (*listToolsResult, error) {
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	result := &listToolsResult{Tools: []toolInfo{}}
+	for _, t := range s.tools {
+		if hasRole(user.Role, t.MinRole) {
+			result.Tools = append(result.Tools, t.info())
+		}
+	}
+	return result, nil
+}
+
+// callTool runs a tool. Tool failures are returned as error results so the model can see them.
+func (s *Server) callTool(ctx context.Context, client *Client, params callToolParams) (*callToolResult, error) {
+	var tool *Tool
+	for i := range s.tools {
+		if s.tools[i].Name == params.Name {
+			tool = &s.tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %s", params.Name)}
+	}
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if !hasRole(user.Role, tool.MinRole) {
+		return toolError(fmt.Sprintf("tool %s requires the %s role, user %s has the %s role", tool.Name, tool.MinRole, user.Username, user.Role)), nil
+	}
+	args := params.Arguments
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	s.logger.Info("Calling MCP tool", "tool", tool.Name, "user", user.Username)
+	result, err := tool.Handler(ctx, client, args)
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	text, err := resultText(result)
+	if err != nil {
+		return nil, err
+	}
+	return &callToolResult{Content: []content{{Type: "text", Text: text}}}, nil
+}
+
+func (s *Server) readResource(ctx context.Context, client *Client, uri string) (*readResourceResult, error) {
+	var result interface{}
+	var err error
+	found := false
+	for _, r := range s.resources {
+		if r.URI == uri {
+			result, err = r.Read(ctx, client)
+			found = true
+			break
+		}
+	}
+	if !found {
+		for _, t := range s.templates {
+			if id, ok := t.match(uri); ok {
+				result, err = t.Read(ctx, client, id)
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource %s not found", uri)}
+	}
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource %s not found", uri)}
+		}
+		return nil, err
+	}
+	text, err := resultText(result)
+	if err != nil {
+		return nil, err
+	}
+	return &readResourceResult{Contents: []resourceContents{{URI: uri, MimeType: "application/json", Text: text}}}, nil
+}
+
+func toolError(message string) *callToolResult {
+	return &callToolResult{Content: []content{{Type: "text", Text: message}}, IsError: true}
+}
+
+// resultText encodes a tool or resource result as the text sent to the client
+func resultText(result interface{}) (string, error) {
+	if text, ok := result.(string); ok {
+		return text, nil
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode result: %w", err)
+	}
+	return string(data), nil
+}
+
+func decodeParams(raw json.RawMessage, out interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+func encodeResponse(resp response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{
+			JSONRPC: "2.0",
+			ID:      resp.ID,
+			Error:   &rpcError{Code: codeInternalError, Message: "failed to encode response"},
+		})
+	}
+	return data
+}
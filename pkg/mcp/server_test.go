@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/auth"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAPI returns a stub ChainLaunch API where the bearer token is the role of the user
+func newTestAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /auth/me", func(w http.ResponseWriter, r *http.Request) {
+		role := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if role == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(auth.UserResponse{ID: 1, Username: role + "-user", Role: auth.Role(role)})
+	})
+	mux.HandleFunc("GET /nodes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "3" {
+			http.Error(w, `{"message":"node not found"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 3, "name": "peer0-org1", "status": "RUNNING"})
+	})
+	mux.HandleFunc("GET /nodes/{id}/logs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5", r.URL.Query().Get("tail"))
+		assert.Equal(t, "false", r.URL.Query().Get("follow"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: line 1\n\ndata: line 2\n\n"))
+	})
+	mux.HandleFunc("POST /nodes/{id}/restart", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 3, "status": "RUNNING"})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": []map[string]interface{}{{
+				"id":                1,
+				"name":              "peer0-sign",
+				"algorithm":         "EC",
+				"publicKey":         "-----BEGIN PUBLIC KEY-----",
+				"certificate":       "-----BEGIN CERTIFICATE-----",
+				"sha256Fingerprint": "ab:cd",
+			}},
+			"totalItems": 1,
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestServer(t *testing.T, api *httptest.Server, role string) *Server {
+	t.Helper()
+	client, err := NewClient(api.URL, "", "", role)
+	require.NoError(t, err)
+	return NewServer(client, logger.NewDefault(), "test")
+}
+
+// call sends a JSON-RPC request to the server and decodes the result into out
+func call(t *testing.T, s *Server, method string, params interface{}, out interface{}) *rpcError {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	require.NoError(t, err)
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(s.handle(context.Background(), s.client, data), &resp))
+	assert.Equal(t, 1, resp.ID)
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if out != nil {
+		require.NoError(t, json.Unmarshal(resp.Result, out))
+	}
+	return nil
+}
+
+func toolNames(result listToolsResult) []string {
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestInitialize(t *testing.T) {
+	s := newTestServer(t, newTestAPI(t), "viewer")
+
+	var result initializeResult
+	require.Nil(t, call(t, s, "initialize", map[string]interface{}{
+		"protocolVersion": "2025-03-26",
+		"clientInfo":      map[string]string{"name": "client", "version": "1.0"},
+	}, &result))
+	assert.Equal(t, "2025-03-26", result.ProtocolVersion)
+	assert.Equal(t, "chainlaunch", result.ServerInfo.Name)
+	assert.Contains(t, result.Capabilities, "tools")
+	assert.Contains(t, result.Capabilities, "resources")
+
+	require.Nil(t, call(t, s, "initialize", map[string]interface{}{"protocolVersion": "2099-01-01"}, &result))
+	assert.Equal(t, protocolVersion, result.ProtocolVersion)
+
+	assert.Nil(t, s.handle(context.Background(), s.client, []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+
+	rpcErr := call(t, s, "unknown/method", nil, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, codeMethodNotFound, rpcErr.Code)
+}
+
+func TestToolsFollowRole(t *testing.T) {
+	api := newTestAPI(t)
+
+	var viewerTools listToolsResult
+	require.Nil(t, call(t, newTestServer(t, api, "viewer"), "tools/list", nil, &viewerTools))
+	assert.Contains(t, toolNames(viewerTools), "get_node")
+	assert.NotContains(t, toolNames(viewerTools), "restart_node")
+	assert.NotContains(t, toolNames(viewerTools), "list_audit_logs")
+
+	var managerTools listToolsResult
+	require.Nil(t, call(t, newTestServer(t, api, "manager"), "tools/list", nil, &managerTools))
+	assert.Contains(t, toolNames(managerTools), "restart_node")
+	assert.NotContains(t, toolNames(managerTools), "list_audit_logs")
+
+	var adminTools listToolsResult
+	require.Nil(t, call(t, newTestServer(t, api, "admin"), "tools/list", nil, &adminTools))
+	assert.Len(t, adminTools.Tools, len(DefaultTools()))
+
+	t.Run("viewers cannot call manager tools", func(t *testing.T) {
+		var result callToolResult
+		require.Nil(t, call(t, newTestServer(t, api, "viewer"), "tools/call", map[string]interface{}{
+			"name":      "restart_node",
+			"arguments": map[string]interface{}{"node_id": 3},
+		}, &result))
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].Text, "requires the manager role")
+	})
+
+	t.Run("managers can call manager tools", func(t *testing.T) {
+		var result callToolResult
+		require.Nil(t, call(t, newTestServer(t, api, "manager"), "tools/call", map[string]interface{}{
+			"name":      "restart_node",
+			"arguments": map[string]interface{}{"node_id": 3},
+		}, &result))
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].Text, "RUNNING")
+	})
+}
+
+func TestCallTool(t *testing.T) {
+	s := newTestServer(t, newTestAPI(t), "viewer")
+
+	var result callToolResult
+	require.Nil(t, call(t, s, "tools/call", map[string]interface{}{
+		"name":      "get_node_logs",
+		"arguments": map[string]interface{}{"node_id": 3, "lines": 5},
+	}, &result))
+	require.False(t, result.IsError)
+	assert.Equal(t, "line 1\nline 2\n", result.Content[0].Text)
+
+	require.Nil(t, call(t, s, "tools/call", map[string]interface{}{
+		"name":      "get_node",
+		"arguments": map[string]interface{}{"node_id": 9},
+	}, &result))
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "404")
+
+	require.Nil(t, call(t, s, "tools/call", map[string]interface{}{
+		"name":      "get_node",
+		"arguments": map[string]interface{}{},
+	}, &result))
+	assert.True(t, result.IsError)
+	assert.Equal(t, "node_id must be an integer", result.Content[0].Text)
+
+	rpcErr := call(t, s, "tools/call", map[string]interface{}{"name": "drop_network"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, codeInvalidParams, rpcErr.Code)
+}
+
+func TestReadResource(t *testing.T) {
+	s := newTestServer(t, newTestAPI(t), "viewer")
+
+	var result readResourceResult
+	require.Nil(t, call(t, s, "resources/read", map[string]string{"uri": "chainlaunch://keys"}, &result))
+	require.Len(t, result.Contents, 1)
+	assert.Contains(t, result.Contents[0].Text, "ab:cd")
+	assert.NotContains(t, result.Contents[0].Text, "BEGIN")
+
+	require.Nil(t, call(t, s, "resources/read", map[string]string{"uri": "chainlaunch://nodes/3"}, &result))
+	assert.Contains(t, result.Contents[0].Text, "peer0-org1")
+
+	rpcErr := call(t, s, "resources/read", map[string]string{"uri": "chainlaunch://nodes/9"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, codeResourceNotFound, rpcErr.Code)
+
+	rpcErr = call(t, s, "resources/read", map[string]string{"uri": "chainlaunch://unknown"}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, codeResourceNotFound, rpcErr.Code)
+}
+
+func TestServeStdio(t *testing.T) {
+	s := newTestServer(t, newTestAPI(t), "viewer")
+
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}
+{"jsonrpc":"2.0","method":"notifications/initialized"}
+not json
+`)
+	var out bytes.Buffer
+	require.NoError(t, s.ServeStdio(context.Background(), in, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, out.String(), `{"jsonrpc":"2.0","id":1,"result":{}}`)
+	assert.Contains(t, out.String(), `"code":-32700`)
+}
+
+func TestServeHTTP(t *testing.T) {
+	api := newTestAPI(t)
+	client, err := NewClient(api.URL, "", "", "")
+	require.NoError(t, err)
+	s := NewServer(client, logger.NewDefault(), "test")
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin")
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "list_audit_logs")
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	req.Header.Set("Authorization", "Bearer admin")
+	s.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mcp", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeHTTPIgnoresConfiguredCredentials(t *testing.T) {
+	api := newTestAPI(t)
+	client, err := NewClient(api.URL, "", "", "admin")
+	require.NoError(t, err)
+	s := NewServer(client, logger.NewDefault(), "test")
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+
+	// Without an Authorization header the caller would act as the configured admin
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+	// The caller's own credentials decide the tools
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer viewer")
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "list_audit_logs")
+}
@@ -0,0 +1,528 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/auth"
+)
+
+// maxLogLines is the maximum number of node log lines returned by get_node_logs
+const maxLogLines = 1000
+
+// roleRank orders the roles from the least to the most privileged
+var roleRank = map[auth.Role]int{
+	auth.RoleViewer:  1,
+	auth.RoleManager: 2,
+	auth.RoleAdmin:   3,
+}
+
+// hasRole reports whether role grants the permissions of minRole
+func hasRole(role, minRole auth.Role) bool {
+	return roleRank[role] >= roleRank[minRole]
+}
+
+// Tool is an MCP tool backed by the ChainLaunch API
+type Tool struct {
+	Name        string
+	Title       string
+	Description string
+	InputSchema map[string]interface{}
+	// MinRole is the least privileged role allowed to list and call the tool
+	MinRole auth.Role
+	// ReadOnly tools do not change the state of ChainLaunch
+	ReadOnly bool
+	Handler  func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error)
+}
+
+func (t Tool) info() toolInfo {
+	return toolInfo{
+		Name:        t.Name,
+		Title:       t.Title,
+		Description: t.Description,
+		InputSchema: t.InputSchema,
+		Annotations: map[string]interface{}{
+			"readOnlyHint":    t.ReadOnly,
+			"destructiveHint": !t.ReadOnly,
+			"openWorldHint":   false,
+		},
+	}
+}
+
+// objectSchema returns the JSON schema of the tool arguments
+func objectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func integerProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "integer", "description": description}
+}
+
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+var (
+	nodeIDProperty    = integerProperty("ID of the node, as returned by list_nodes")
+	networkIDProperty = integerProperty("ID of the network, as returned by list_networks")
+	platformProperty  = map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{"fabric", "besu", "fabricx"},
+		"description": "Platform of the network",
+	}
+)
+
+// DefaultTools returns the curated ChainLaunch tools
+func DefaultTools() []Tool {
+	return []Tool{
+		{
+			Name:        "list_organizations",
+			Title:       "List organizations",
+			Description: "List the Fabric organizations with their MSP ID and description.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"limit":  integerProperty("Maximum number of organizations, 20 by default"),
+				"offset": integerProperty("Number of organizations to skip"),
+			}),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				query := url.Values{}
+				setQueryInt(query, "limit", args, "limit")
+				setQueryInt(query, "offset", args, "offset")
+				return getJSON(ctx, client, "/organizations", query)
+			},
+		},
+		{
+			Name:        "list_nodes",
+			Title:       "List nodes",
+			Description: "List the nodes with their platform, type, status and endpoint.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"platform": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"FABRIC", "BESU", "FABRICX"},
+					"description": "Only list the nodes of this platform",
+				},
+				"page":  integerProperty("Page number, 1 by default"),
+				"limit": integerProperty("Nodes per page, 100 by default"),
+			}),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				query := url.Values{"limit": {"100"}}
+				if platform, _ := args["platform"].(string); platform != "" {
+					query.Set("platform", strings.ToUpper(platform))
+				}
+				setQueryInt(query, "page", args, "page")
+				setQueryInt(query, "limit", args, "limit")
+				return getJSON(ctx, client, "/nodes", query)
+			},
+		},
+		{
+			Name:        "get_node",
+			Title:       "Get node",
+			Description: "Get the configuration and status of a node.",
+			InputSchema: objectSchema(map[string]interface{}{"node_id": nodeIDProperty}, "node_id"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/nodes/%d", nodeID), nil)
+			},
+		},
+		{
+			Name:        "get_node_events",
+			Title:       "Get node events",
+			Description: "Get the lifecycle events of a node (starts, stops, errors, upgrades, drift), newest first.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"node_id": nodeIDProperty,
+				"limit":   integerProperty("Number of events, 20 by default"),
+			}, "node_id"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				query := url.Values{"limit": {"20"}}
+				setQueryInt(query, "limit", args, "limit")
+				return getJSON(ctx, client, fmt.Sprintf("/nodes/%d/events", nodeID), query)
+			},
+		},
+		{
+			Name:        "get_node_logs",
+			Title:       "Get node logs",
+			Description: "Get the last lines of the logs of a node.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"node_id": nodeIDProperty,
+				"lines":   integerProperty(fmt.Sprintf("Number of lines, 100 by default and %d at most", maxLogLines)),
+			}, "node_id"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				lines := 100
+				if n, err := intArg(args, "lines"); err == nil && n > 0 {
+					lines = int(n)
+				}
+				if lines > maxLogLines {
+					lines = maxLogLines
+				}
+				stream, err := client.GetText(ctx, fmt.Sprintf("/nodes/%d/logs", nodeID), url.Values{
+					"tail":   {strconv.Itoa(lines)},
+					"follow": {"false"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return parseEventStream(stream), nil
+			},
+		},
+		{
+			Name:        "list_node_channels",
+			Title:       "List node channels",
+			Description: "List the channels joined by a Fabric peer or orderer.",
+			InputSchema: objectSchema(map[string]interface{}{"node_id": nodeIDProperty}, "node_id"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/nodes/%d/channels", nodeID), nil)
+			},
+		},
+		{
+			Name:        "list_chaincode_definitions",
+			Title:       "List chaincode definitions",
+			Description: "List the chaincode definitions committed on a channel, as seen by a Fabric peer.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"node_id": integerProperty("ID of a peer joined to the channel"),
+				"channel": stringProperty("Channel name"),
+			}, "node_id", "channel"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				channel, _ := args["channel"].(string)
+				if channel == "" {
+					return nil, fmt.Errorf("channel is required")
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/nodes/%d/channels/%s/chaincodes", nodeID, url.PathEscape(channel)), nil)
+			},
+		},
+		{
+			Name:        "list_networks",
+			Title:       "List networks",
+			Description: "List the networks of a platform with their status.",
+			InputSchema: objectSchema(map[string]interface{}{"platform": platformProperty}, "platform"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				platform, err := platformArg(args)
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, "/networks/"+platform, url.Values{"limit": {"100"}})
+			},
+		},
+		{
+			Name:        "get_network",
+			Title:       "Get network",
+			Description: "Get a network with its configuration and status.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"platform":   platformProperty,
+				"network_id": networkIDProperty,
+			}, "platform", "network_id"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				platform, err := platformArg(args)
+				if err != nil {
+					return nil, err
+				}
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/networks/%s/%d", platform, networkID), nil)
+			},
+		},
+		{
+			Name:        "get_network_nodes",
+			Title:       "Get network nodes",
+			Description: "List the nodes of a network with their role and status.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"platform":   platformProperty,
+				"network_id": networkIDProperty,
+			}, "platform", "network_id"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				platform, err := platformArg(args)
+				if err != nil {
+					return nil, err
+				}
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/networks/%s/%d/nodes", platform, networkID), nil)
+			},
+		},
+		{
+			Name:        "get_chain_info",
+			Title:       "Get chain info",
+			Description: "Get the block height and latest block hashes of a Fabric network.",
+			InputSchema: objectSchema(map[string]interface{}{"network_id": networkIDProperty}, "network_id"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/networks/fabric/%d/info", networkID), nil)
+			},
+		},
+		{
+			Name:        "list_blocks",
+			Title:       "List blocks",
+			Description: "List the blocks of a Fabric network, newest first.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"network_id": networkIDProperty,
+				"limit":      integerProperty("Number of blocks, 10 by default"),
+				"offset":     integerProperty("Number of blocks to skip"),
+			}, "network_id"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				query := url.Values{"reverse": {"true"}}
+				setQueryInt(query, "limit", args, "limit")
+				setQueryInt(query, "offset", args, "offset")
+				return getJSON(ctx, client, fmt.Sprintf("/networks/fabric/%d/blocks", networkID), query)
+			},
+		},
+		{
+			Name:        "get_block",
+			Title:       "Get block",
+			Description: "Get a block of a Fabric network with its transactions.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"network_id":   networkIDProperty,
+				"block_number": integerProperty("Block number"),
+			}, "network_id", "block_number"),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				blockNumber, err := intArg(args, "block_number")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/networks/fabric/%d/blocks/%d", networkID, blockNumber), nil)
+			},
+		},
+		{
+			Name:        "get_channel_config",
+			Title:       "Get channel config",
+			Description: "Get the current channel configuration of a Fabric network.",
+			InputSchema: objectSchema(map[string]interface{}{"network_id": networkIDProperty}, "network_id"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return getJSON(ctx, client, fmt.Sprintf("/networks/fabric/%d/current-channel-config", networkID), nil)
+			},
+		},
+		{
+			Name:        "list_keys",
+			Title:       "List keys",
+			Description: "List the keys with their metadata: algorithm, provider, fingerprints and expiry. Key material is never returned.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"page":      integerProperty("Page number, 1 by default"),
+				"page_size": integerProperty("Keys per page, 50 by default"),
+			}),
+			MinRole:  auth.RoleViewer,
+			ReadOnly: true,
+			Handler:  listKeys,
+		},
+		{
+			Name:        "get_key",
+			Title:       "Get key",
+			Description: "Get the metadata of a key. Key material is never returned.",
+			InputSchema: objectSchema(map[string]interface{}{"key_id": integerProperty("ID of the key")}, "key_id"),
+			MinRole:     auth.RoleViewer,
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				keyID, err := intArg(args, "key_id")
+				if err != nil {
+					return nil, err
+				}
+				var key map[string]interface{}
+				if err := client.Get(ctx, fmt.Sprintf("/keys/%d", keyID), nil, &key); err != nil {
+					return nil, err
+				}
+				return keyMetadata(key), nil
+			},
+		},
+		nodeActionTool("start_node", "Start node", "Start a stopped node."),
+		nodeActionTool("stop_node", "Stop node", "Stop a running node."),
+		nodeActionTool("restart_node", "Restart node", "Restart a node."),
+		{
+			Name:        "list_audit_logs",
+			Title:       "List audit logs",
+			Description: "List the audit logs of the API requests and security events, newest first.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"page":       integerProperty("Page number, 1 by default"),
+				"page_size":  integerProperty("Logs per page, 50 by default"),
+				"start":      stringProperty("Start time, RFC3339"),
+				"end":        stringProperty("End time, RFC3339"),
+				"event_type": stringProperty("Only list the logs of this event type"),
+				"user_id":    stringProperty("Only list the logs of this user"),
+			}),
+			MinRole:  auth.RoleAdmin,
+			ReadOnly: true,
+			Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+				query := url.Values{"page_size": {"50"}}
+				setQueryInt(query, "page", args, "page")
+				setQueryInt(query, "page_size", args, "page_size")
+				for _, name := range []string{"start", "end", "event_type", "user_id"} {
+					if value, _ := args[name].(string); value != "" {
+						query.Set(name, value)
+					}
+				}
+				return getJSON(ctx, client, "/audit/logs", query)
+			},
+		},
+	}
+}
+
+// nodeActionTool returns a tool changing the state of a node, reserved to managers and admins
+func nodeActionTool(action, title, description string) Tool {
+	path := strings.TrimSuffix(action, "_node")
+	return Tool{
+		Name:        action,
+		Title:       title,
+		Description: description,
+		InputSchema: objectSchema(map[string]interface{}{"node_id": nodeIDProperty}, "node_id"),
+		MinRole:     auth.RoleManager,
+		Handler: func(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+			nodeID, err := intArg(args, "node_id")
+			if err != nil {
+				return nil, err
+			}
+			var node interface{}
+			if err := client.Post(ctx, fmt.Sprintf("/nodes/%d/%s", nodeID, path), nil, &node); err != nil {
+				return nil, err
+			}
+			return node, nil
+		},
+	}
+}
+
+func getJSON(ctx context.Context, client *Client, path string, query url.Values) (interface{}, error) {
+	var result interface{}
+	if err := client.Get(ctx, path, query, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// listKeys lists the keys without their key material
+func listKeys(ctx context.Context, client *Client, args map[string]interface{}) (interface{}, error) {
+	query := url.Values{"pageSize": {"50"}}
+	setQueryInt(query, "page", args, "page")
+	setQueryInt(query, "pageSize", args, "page_size")
+	var keys map[string]interface{}
+	if err := client.Get(ctx, "/keys", query, &keys); err != nil {
+		return nil, err
+	}
+	if items, ok := keys["items"].([]interface{}); ok {
+		for _, item := range items {
+			keyMetadata(item)
+		}
+	}
+	return keys, nil
+}
+
+// keyMetadata removes the key material from a key returned by the API
+func keyMetadata(key interface{}) interface{} {
+	if m, ok := key.(map[string]interface{}); ok {
+		delete(m, "publicKey")
+		delete(m, "certificate")
+		delete(m, "privateKey")
+	}
+	return key
+}
+
+// parseEventStream returns the data lines of a server-sent events stream
+func parseEventStream(stream string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(stream, "\n") {
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			sb.WriteString(data)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// intArg reads an integer argument, sent as a JSON number or a string
+func intArg(args map[string]interface{}, name string) (int64, error) {
+	switch v := args[name].(type) {
+	case float64:
+		return int64(v), nil
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%s must be an integer", name)
+}
+
+func platformArg(args map[string]interface{}) (string, error) {
+	platform, _ := args["platform"].(string)
+	platform = strings.ToLower(platform)
+	switch platform {
+	case "fabric", "besu", "fabricx":
+		return platform, nil
+	}
+	return "", fmt.Errorf("platform must be fabric, besu or fabricx")
+}
+
+// setQueryInt sets a query parameter from an integer argument when present
+func setQueryInt(query url.Values, param string, args map[string]interface{}, name string) {
+	if n, err := intArg(args, name); err == nil {
+		query.Set(param, strconv.FormatInt(n, 10))
+	}
+}
@@ -0,0 +1,334 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"gopkg.in/yaml.v2"
+)
+
+// alertRulesFileName is the name of the rules file generated for Prometheus
+const alertRulesFileName = "chainlaunch.rules.yml"
+
+// ErrInvalidWebhookToken is returned when an Alertmanager webhook call carries an invalid token
+var ErrInvalidWebhookToken = errors.New("invalid webhook token")
+
+// validSeverities lists the severities accepted for alerting rules
+var validSeverities = map[string]bool{
+	"info":     true,
+	"warning":  true,
+	"critical": true,
+}
+
+// AlertingConfig represents the alerting section of the Prometheus configuration
+type AlertingConfig struct {
+	Alertmanagers []AlertmanagerTargetConfig `yaml:"alertmanagers"`
+}
+
+// AlertmanagerTargetConfig represents an Alertmanager target in the Prometheus configuration
+type AlertmanagerTargetConfig struct {
+	StaticConfigs []StaticConfig `yaml:"static_configs"`
+}
+
+// RuleFile represents a Prometheus rules file
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup represents a group of Prometheus rules
+type RuleGroup struct {
+	Name  string           `yaml:"name"`
+	Rules []PrometheusRule `yaml:"rules"`
+}
+
+// PrometheusRule represents a single Prometheus alerting rule
+type PrometheusRule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// defaultAlertRules returns the built-in alerting rules for Fabric and Besu nodes
+func defaultAlertRules() []common.AlertRule {
+	return []common.AlertRule{
+		{
+			Group:       "chainlaunch-nodes",
+			Name:        "NodeTargetDown",
+			Expr:        `up{job!="prometheus"} == 0`,
+			For:         "2m",
+			Severity:    "critical",
+			Summary:     "Node {{ $labels.job }} is down",
+			Description: "Prometheus has not been able to scrape {{ $labels.instance }} for more than 2 minutes.",
+		},
+		{
+			Group:       "chainlaunch-fabric",
+			Name:        "FabricPeerEndorsementFailures",
+			Expr:        `rate(endorser_endorsement_failures[5m]) > 0`,
+			For:         "5m",
+			Severity:    "warning",
+			Summary:     "Endorsement failures on {{ $labels.job }}",
+			Description: "Peer {{ $labels.job }} is failing endorsements for chaincode {{ $labels.chaincode }} on channel {{ $labels.channel }}.",
+		},
+		{
+			Group:       "chainlaunch-fabric",
+			Name:        "FabricPeerGossipNoPeers",
+			Expr:        `gossip_membership_total_peers_known == 0`,
+			For:         "5m",
+			Severity:    "warning",
+			Summary:     "Peer {{ $labels.job }} knows no gossip peers",
+			Description: "Peer {{ $labels.job }} has not discovered any gossip peers on channel {{ $labels.channel }} for 5 minutes.",
+		},
+		{
+			Group:       "chainlaunch-fabric",
+			Name:        "FabricRaftLeaderChanges",
+			Expr:        `increase(consensus_etcdraft_leader_changes[15m]) > 3`,
+			Severity:    "warning",
+			Summary:     "Frequent Raft leader changes on {{ $labels.channel }}",
+			Description: "Orderer {{ $labels.job }} observed more than 3 leader changes on channel {{ $labels.channel }} in the last 15 minutes.",
+		},
+		{
+			Group:       "chainlaunch-fabric",
+			Name:        "FabricRaftNoLeader",
+			Expr:        `sum by (channel) (consensus_etcdraft_is_leader) == 0`,
+			For:         "1m",
+			Severity:    "critical",
+			Summary:     "No Raft leader on channel {{ $labels.channel }}",
+			Description: "No orderer reports being the Raft leader for channel {{ $labels.channel }}.",
+		},
+		{
+			Group:       "chainlaunch-fabric",
+			Name:        "FabricRaftNodesMissing",
+			Expr:        `consensus_etcdraft_active_nodes < consensus_etcdraft_cluster_size`,
+			For:         "5m",
+			Severity:    "warning",
+			Summary:     "Raft cluster degraded on {{ $labels.channel }}",
+			Description: "Orderer {{ $labels.job }} sees fewer active nodes than the cluster size on channel {{ $labels.channel }}.",
+		},
+		{
+			Group:       "chainlaunch-besu",
+			Name:        "BesuNoPeers",
+			Expr:        `ethereum_peer_count < 1`,
+			For:         "5m",
+			Severity:    "warning",
+			Summary:     "Besu node {{ $labels.job }} has no peers",
+			Description: "Besu node {{ $labels.job }} has had no connected peers for 5 minutes.",
+		},
+		{
+			Group:       "chainlaunch-besu",
+			Name:        "BesuChainStalled",
+			Expr:        `increase(ethereum_blockchain_height[5m]) == 0`,
+			For:         "5m",
+			Severity:    "critical",
+			Summary:     "Besu node {{ $labels.job }} stopped importing blocks",
+			Description: "The chain height of Besu node {{ $labels.job }} has not increased in the last 10 minutes.",
+		},
+		{
+			Group:       "chainlaunch-besu",
+			Name:        "BesuNodeBehind",
+			Expr:        `ethereum_best_known_block_number - ethereum_blockchain_height > 100`,
+			For:         "10m",
+			Severity:    "warning",
+			Summary:     "Besu node {{ $labels.job }} is falling behind",
+			Description: "Besu node {{ $labels.job }} is more than 100 blocks behind the best known block.",
+		},
+	}
+}
+
+// validateAlertRule validates a custom alerting rule and applies defaults
+func validateAlertRule(rule *common.AlertRule) error {
+	if rule == nil {
+		return fmt.Errorf("rule is required")
+	}
+	rule.Group = strings.TrimSpace(rule.Group)
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Expr = strings.TrimSpace(rule.Expr)
+	if rule.Group == "" {
+		rule.Group = "custom"
+	}
+	if rule.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if strings.ContainsAny(rule.Name, " \t\n") {
+		return fmt.Errorf("rule name must not contain whitespace")
+	}
+	for _, builtIn := range defaultAlertRules() {
+		if builtIn.Name == rule.Name {
+			return fmt.Errorf("rule name %s is used by a built-in rule", rule.Name)
+		}
+	}
+	if rule.Expr == "" {
+		return fmt.Errorf("rule expression is required")
+	}
+	// Prometheus rejects the whole rules file when a single rule is invalid
+	if _, err := parser.ParseExpr(rule.Expr); err != nil {
+		return fmt.Errorf("invalid rule expression: %w", err)
+	}
+	rule.For = strings.TrimSpace(rule.For)
+	if rule.For != "" {
+		if _, err := model.ParseDuration(rule.For); err != nil {
+			return fmt.Errorf("invalid rule duration %q: %w", rule.For, err)
+		}
+	}
+	if rule.Severity == "" {
+		rule.Severity = "warning"
+	}
+	if !validSeverities[rule.Severity] {
+		return fmt.Errorf("invalid severity %q: must be one of info, warning, critical", rule.Severity)
+	}
+	return nil
+}
+
+// alertRuleFromDB converts a database alert rule to its API representation
+func alertRuleFromDB(r *db.PrometheusAlertRule) (*common.AlertRule, error) {
+	rule := &common.AlertRule{
+		ID:          r.ID,
+		Group:       r.GroupName,
+		Name:        r.Name,
+		Expr:        r.Expr,
+		For:         r.ForDuration.String,
+		Severity:    r.Severity,
+		Summary:     r.Summary.String,
+		Description: r.Description.String,
+		Enabled:     r.Enabled,
+	}
+	if r.Labels.Valid && r.Labels.String != "" {
+		if err := json.Unmarshal([]byte(r.Labels.String), &rule.Labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal labels for rule %s: %w", r.Name, err)
+		}
+	}
+	return rule, nil
+}
+
+// loadAlertRules returns the built-in rules (when enabled) followed by the custom rules stored in the database
+func loadAlertRules(ctx context.Context, queries *db.Queries) ([]common.AlertRule, error) {
+	defaultsEnabled := true
+	amConfig, err := queries.GetAlertmanagerConfig(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+	if err == nil {
+		defaultsEnabled = amConfig.DefaultRulesEnabled
+	}
+
+	rules := []common.AlertRule{}
+	for _, rule := range defaultAlertRules() {
+		rule.BuiltIn = true
+		rule.Enabled = defaultsEnabled
+		rules = append(rules, rule)
+	}
+
+	dbRules, err := queries.ListPrometheusAlertRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	for _, dbRule := range dbRules {
+		rule, err := alertRuleFromDB(dbRule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+// buildAlertRulesFile renders the enabled rules into a Prometheus rules file
+func buildAlertRulesFile(rules []common.AlertRule) (string, error) {
+	groups := map[string][]PrometheusRule{}
+	groupNames := []string{}
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		labels := map[string]string{}
+		for k, v := range rule.Labels {
+			labels[k] = v
+		}
+		labels["severity"] = rule.Severity
+
+		annotations := map[string]string{}
+		if rule.Summary != "" {
+			annotations["summary"] = rule.Summary
+		}
+		if rule.Description != "" {
+			annotations["description"] = rule.Description
+		}
+
+		if _, ok := groups[rule.Group]; !ok {
+			groupNames = append(groupNames, rule.Group)
+		}
+		groups[rule.Group] = append(groups[rule.Group], PrometheusRule{
+			Alert:       rule.Name,
+			Expr:        rule.Expr,
+			For:         rule.For,
+			Labels:      labels,
+			Annotations: annotations,
+		})
+	}
+	sort.Strings(groupNames)
+
+	ruleFile := RuleFile{Groups: []RuleGroup{}}
+	for _, name := range groupNames {
+		ruleFile.Groups = append(ruleFile.Groups, RuleGroup{Name: name, Rules: groups[name]})
+	}
+
+	data, err := yaml.Marshal(ruleFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alert rules: %w", err)
+	}
+	return string(data), nil
+}
+
+// applyAlertingConfig writes the rules file to rulesDir and wires the rules and the
+// Alertmanager target (if deployed) into the Prometheus configuration.
+// rulesPath is the rules file path as seen by Prometheus, which differs from the host
+// path when Prometheus runs in a container.
+func applyAlertingConfig(ctx context.Context, queries *db.Queries, config *PrometheusConfig, rulesDir, rulesPath string, useDockerHost bool) error {
+	rules, err := loadAlertRules(ctx, queries)
+	if err != nil {
+		return err
+	}
+	rulesData, err := buildAlertRulesFile(rules)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create rules directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, alertRulesFileName), []byte(rulesData), 0644); err != nil {
+		return fmt.Errorf("failed to write rules file: %w", err)
+	}
+	config.RuleFiles = []string{rulesPath}
+
+	amConfig, err := queries.GetAlertmanagerConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+
+	host := "localhost"
+	if useDockerHost {
+		host = "host.docker.internal"
+	}
+	config.Alerting = &AlertingConfig{
+		Alertmanagers: []AlertmanagerTargetConfig{
+			{StaticConfigs: []StaticConfig{{Targets: []string{fmt.Sprintf("%s:%d", host, amConfig.AlertmanagerPort)}}}},
+		},
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAlertRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    common.AlertRule
+		wantErr string
+	}{
+		{name: "valid", rule: common.AlertRule{Name: "PeerDown", Expr: `up{job="peer0"} == 0`, For: "5m"}},
+		{name: "valid compound duration", rule: common.AlertRule{Name: "PeerDown", Expr: `up == 0`, For: "1h30m"}},
+		{name: "missing name", rule: common.AlertRule{Expr: `up == 0`}, wantErr: "rule name is required"},
+		{name: "whitespace in name", rule: common.AlertRule{Name: "Peer Down", Expr: `up == 0`}, wantErr: "whitespace"},
+		{name: "built-in name", rule: common.AlertRule{Name: "BesuNoPeers", Expr: `up == 0`}, wantErr: "built-in rule"},
+		{name: "missing expression", rule: common.AlertRule{Name: "PeerDown"}, wantErr: "expression is required"},
+		{name: "invalid expression", rule: common.AlertRule{Name: "PeerDown", Expr: `rate(up[5m]`}, wantErr: "invalid rule expression"},
+		{name: "invalid duration", rule: common.AlertRule{Name: "PeerDown", Expr: `up == 0`, For: "5 minutes"}, wantErr: "invalid rule duration"},
+		{name: "invalid severity", rule: common.AlertRule{Name: "PeerDown", Expr: `up == 0`, Severity: "page"}, wantErr: "invalid severity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			err := validateAlertRule(&rule)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "custom", rule.Group)
+			assert.Equal(t, "warning", rule.Severity)
+		})
+	}
+}
+
+func TestDefaultAlertRulesAreValid(t *testing.T) {
+	for _, rule := range defaultAlertRules() {
+		rule.Name = "Custom" + rule.Name
+		assert.NoError(t, validateAlertRule(&rule), rule.Name)
+	}
+}
+
+func TestWriteAlertmanagerConfigIsPrivate(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	configPath := filepath.Join(configDir, "alertmanager.yml")
+	// Files written by earlier versions were world readable
+	require.NoError(t, os.WriteFile(configPath, []byte("route: {}\n"), 0644))
+
+	config := &common.AlertmanagerConfig{WebhookURL: "http://localhost:8100/api/v1/metrics/alerts/webhook"}
+	require.NoError(t, writeAlertmanagerConfig(config, "secret-token", configDir))
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "secret-token")
+}
+
+// fakeAlertmanagerDeployer records the calls of the service
+type fakeAlertmanagerDeployer struct {
+	startErr  error
+	started   []string
+	stopCalls int
+}
+
+func (d *fakeAlertmanagerDeployer) Start(ctx context.Context, webhookToken string) error {
+	d.started = append(d.started, webhookToken)
+	return d.startErr
+}
+
+func (d *fakeAlertmanagerDeployer) Stop(ctx context.Context) error {
+	d.stopCalls++
+	return nil
+}
+
+func (d *fakeAlertmanagerDeployer) GetStatus(ctx context.Context) (string, error) {
+	return "running", nil
+}
+
+func TestRestoreAlertmanager(t *testing.T) {
+	ctx := context.Background()
+	s := &service{}
+	cause := errors.New("failed to start Alertmanager")
+	existing := &db.AlertmanagerConfig{WebhookToken: "previous-token"}
+
+	failed := &fakeAlertmanagerDeployer{}
+	previous := &fakeAlertmanagerDeployer{}
+	err := s.restoreAlertmanager(ctx, failed, previous, existing, cause)
+	assert.Same(t, cause, err)
+	assert.Equal(t, 1, failed.stopCalls)
+	assert.Equal(t, []string{"previous-token"}, previous.started)
+
+	// Nothing to restart on a first deployment
+	failed = &fakeAlertmanagerDeployer{}
+	assert.Same(t, cause, s.restoreAlertmanager(ctx, failed, nil, nil, cause))
+	assert.Equal(t, 1, failed.stopCalls)
+
+	previous = &fakeAlertmanagerDeployer{startErr: errors.New("port in use")}
+	err = s.restoreAlertmanager(ctx, nil, previous, existing, cause)
+	assert.ErrorIs(t, err, cause)
+	assert.Contains(t, err.Error(), "port in use")
+}
+
+func TestHandleAlertWebhookToken(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	queries := db.New(sqlDB)
+	s := &service{db: queries}
+	payload := &common.AlertWebhookPayload{}
+
+	// Without Alertmanager every call is unauthorized
+	assert.ErrorIs(t, s.HandleAlertWebhook(ctx, "any-token", payload), ErrInvalidWebhookToken)
+
+	_, err = queries.CreateAlertmanagerConfig(ctx, &db.CreateAlertmanagerConfigParams{
+		AlertmanagerPort:    9093,
+		AlertmanagerVersion: "v0.28.1",
+		DeploymentMode:      "docker",
+		WebhookUrl:          "http://localhost:8100/api/v1/metrics/alerts/webhook",
+		WebhookToken:        "secret-token",
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.HandleAlertWebhook(ctx, "", payload), ErrInvalidWebhookToken)
+	assert.ErrorIs(t, s.HandleAlertWebhook(ctx, "other-token", payload), ErrInvalidWebhookToken)
+	assert.NoError(t, s.HandleAlertWebhook(ctx, "secret-token", payload))
+}
@@ -0,0 +1,528 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	configservice "github.com/chainlaunch/chainlaunch/pkg/config"
+	"github.com/chainlaunch/chainlaunch/pkg/docker"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v2"
+)
+
+// AlertmanagerDeployer defines the interface for different Alertmanager deployment methods
+type AlertmanagerDeployer interface {
+	// Start writes the configuration and starts the Alertmanager instance
+	Start(ctx context.Context, webhookToken string) error
+	// Stop stops the Alertmanager instance
+	Stop(ctx context.Context) error
+	// GetStatus returns the current status of the Alertmanager instance
+	GetStatus(ctx context.Context) (string, error)
+}
+
+// AlertmanagerFileConfig represents the alertmanager.yml configuration structure
+type AlertmanagerFileConfig struct {
+	Route     AlertmanagerRoute      `yaml:"route"`
+	Receivers []AlertmanagerReceiver `yaml:"receivers"`
+}
+
+// AlertmanagerRoute represents the Alertmanager routing tree root
+type AlertmanagerRoute struct {
+	Receiver       string   `yaml:"receiver"`
+	GroupBy        []string `yaml:"group_by"`
+	GroupWait      string   `yaml:"group_wait"`
+	GroupInterval  string   `yaml:"group_interval"`
+	RepeatInterval string   `yaml:"repeat_interval"`
+}
+
+// AlertmanagerReceiver represents an Alertmanager receiver
+type AlertmanagerReceiver struct {
+	Name           string                `yaml:"name"`
+	WebhookConfigs []AlertmanagerWebhook `yaml:"webhook_configs"`
+}
+
+// AlertmanagerWebhook represents a webhook receiver configuration
+type AlertmanagerWebhook struct {
+	URL          string                 `yaml:"url"`
+	SendResolved bool                   `yaml:"send_resolved"`
+	HTTPConfig   AlertmanagerHTTPConfig `yaml:"http_config"`
+}
+
+// AlertmanagerHTTPConfig represents the HTTP client configuration of a webhook
+type AlertmanagerHTTPConfig struct {
+	Authorization AlertmanagerAuthorization `yaml:"authorization"`
+}
+
+// AlertmanagerAuthorization represents the authorization header sent by Alertmanager
+type AlertmanagerAuthorization struct {
+	Type        string `yaml:"type"`
+	Credentials string `yaml:"credentials"`
+}
+
+// buildAlertmanagerConfig builds the Alertmanager YAML config forwarding every alert to ChainLaunch
+func buildAlertmanagerConfig(webhookURL, webhookToken string) (string, error) {
+	config := AlertmanagerFileConfig{
+		Route: AlertmanagerRoute{
+			Receiver:       "chainlaunch",
+			GroupBy:        []string{"alertname", "job"},
+			GroupWait:      "30s",
+			GroupInterval:  "5m",
+			RepeatInterval: "4h",
+		},
+		Receivers: []AlertmanagerReceiver{
+			{
+				Name: "chainlaunch",
+				WebhookConfigs: []AlertmanagerWebhook{
+					{
+						URL:          webhookURL,
+						SendResolved: true,
+						HTTPConfig: AlertmanagerHTTPConfig{
+							Authorization: AlertmanagerAuthorization{
+								Type:        "Bearer",
+								Credentials: webhookToken,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alertmanager config: %w", err)
+	}
+	return string(data), nil
+}
+
+// alertmanagerDirs returns the config, data and bin directories for Alertmanager
+func alertmanagerDirs(configService *configservice.ConfigService) (string, string, string) {
+	alertmanagerDir := filepath.Join(configService.GetDataPath(), "alertmanager")
+	return filepath.Join(alertmanagerDir, "config"), filepath.Join(alertmanagerDir, "data"), filepath.Join(alertmanagerDir, "bin")
+}
+
+// writeAlertmanagerConfig creates the Alertmanager directories and writes alertmanager.yml
+func writeAlertmanagerConfig(config *common.AlertmanagerConfig, webhookToken string, dirs ...string) error {
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	configData, err := buildAlertmanagerConfig(config.WebhookURL, webhookToken)
+	if err != nil {
+		return err
+	}
+	// The config holds the webhook bearer token, an existing file keeps its mode on write
+	configPath := filepath.Join(dirs[0], "alertmanager.yml")
+	if err := os.WriteFile(configPath, []byte(configData), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(configPath, 0600); err != nil {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+	return nil
+}
+
+// waitForAlertmanagerReady polls the Alertmanager readiness endpoint on the host
+func waitForAlertmanagerReady(ctx context.Context, port int) error {
+	maxWaitTime := 60 * time.Second
+	checkInterval := 2 * time.Second
+	readyURL := fmt.Sprintf("http://localhost:%d/-/ready", port)
+
+	for elapsed := time.Duration(0); elapsed < maxWaitTime; elapsed += checkInterval {
+		req, err := http.NewRequestWithContext(ctx, "GET", readyURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create readiness request: %w", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(checkInterval)
+	}
+
+	return fmt.Errorf("timeout waiting for Alertmanager to be ready after %v", maxWaitTime)
+}
+
+// DockerAlertmanagerDeployer implements AlertmanagerDeployer for Docker deployment
+type DockerAlertmanagerDeployer struct {
+	config    *common.AlertmanagerConfig
+	client    *client.Client
+	configDir string
+	dataDir   string
+}
+
+// NewDockerAlertmanagerDeployer creates a new Docker-based Alertmanager deployer
+func NewDockerAlertmanagerDeployer(config *common.AlertmanagerConfig, configService *configservice.ConfigService) (*DockerAlertmanagerDeployer, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	configDir, dataDir, _ := alertmanagerDirs(configService)
+	return &DockerAlertmanagerDeployer{
+		config:    config,
+		client:    cli,
+		configDir: configDir,
+		dataDir:   dataDir,
+	}, nil
+}
+
+// containerName returns the Alertmanager container name
+func (d *DockerAlertmanagerDeployer) containerName() string {
+	return fmt.Sprintf("chainlaunch-alertmanager-%d", d.config.Port)
+}
+
+// Start starts the Alertmanager container
+func (d *DockerAlertmanagerDeployer) Start(ctx context.Context, webhookToken string) error {
+	// Remove any existing container
+	if err := d.removeContainer(ctx); err != nil {
+		return err
+	}
+
+	if err := writeAlertmanagerConfig(d.config, webhookToken, d.configDir, d.dataDir); err != nil {
+		return err
+	}
+
+	imageName := fmt.Sprintf("prom/alertmanager:%s", d.config.Version)
+	if err := docker.PullImageIfNeeded(ctx, d.client, imageName); err != nil {
+		return err
+	}
+
+	containerConfig := &container.Config{
+		Image: imageName,
+		// Run as the owner of the config file, which is only readable by its owner
+		User: fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		Cmd: []string{
+			"--config.file=/etc/alertmanager/alertmanager.yml",
+			"--storage.path=/alertmanager",
+			fmt.Sprintf("--web.listen-address=0.0.0.0:%d", d.config.Port),
+			"--cluster.listen-address=",
+		},
+		ExposedPorts: nat.PortSet{
+			nat.Port(fmt.Sprintf("%d/tcp", d.config.Port)): struct{}{},
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: d.dataDir,
+				Target: "/alertmanager",
+			},
+			{
+				Type:   mount.TypeBind,
+				Source: d.configDir,
+				Target: "/etc/alertmanager",
+			},
+		},
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyMode("unless-stopped"),
+		},
+	}
+
+	if d.config.DockerConfig != nil && d.config.DockerConfig.NetworkMode == common.NetworkModeHost {
+		hostConfig.NetworkMode = container.NetworkMode("host")
+	} else {
+		hostConfig.PortBindings = nat.PortMap{
+			nat.Port(fmt.Sprintf("%d/tcp", d.config.Port)): []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: fmt.Sprintf("%d", d.config.Port),
+				},
+			},
+		}
+		// Allow the webhook to reach ChainLaunch running on the host
+		hostConfig.ExtraHosts = []string{"host.docker.internal:host-gateway"}
+	}
+
+	resp, err := d.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, &v1.Platform{}, d.containerName())
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := d.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return waitForAlertmanagerReady(ctx, d.config.Port)
+}
+
+// removeContainer stops and removes the Alertmanager container if it exists
+func (d *DockerAlertmanagerDeployer) removeContainer(ctx context.Context) error {
+	_, err := d.client.ContainerInspect(ctx, d.containerName())
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if err := d.client.ContainerRemove(ctx, d.containerName(), container.RemoveOptions{Force: true}); err != nil {
+		return fmt.Errorf("failed to remove existing container: %w", err)
+	}
+	return nil
+}
+
+// Stop stops and removes the Alertmanager container
+func (d *DockerAlertmanagerDeployer) Stop(ctx context.Context) error {
+	return d.removeContainer(ctx)
+}
+
+// GetStatus returns the current status of the Alertmanager container
+func (d *DockerAlertmanagerDeployer) GetStatus(ctx context.Context) (string, error) {
+	info, err := d.client.ContainerInspect(ctx, d.containerName())
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return "not_deployed", nil
+		}
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return info.State.Status, nil
+}
+
+// ServiceAlertmanagerDeployer implements AlertmanagerDeployer for system service deployment
+type ServiceAlertmanagerDeployer struct {
+	config      *common.AlertmanagerConfig
+	serviceType common.ServiceType
+	configDir   string
+	dataDir     string
+	binDir      string
+}
+
+// NewServiceAlertmanagerDeployer creates a new service-based Alertmanager deployer
+func NewServiceAlertmanagerDeployer(config *common.AlertmanagerConfig, configService *configservice.ConfigService) *ServiceAlertmanagerDeployer {
+	configDir, dataDir, binDir := alertmanagerDirs(configService)
+	return &ServiceAlertmanagerDeployer{
+		config:      config,
+		serviceType: common.GetServiceType(),
+		configDir:   configDir,
+		dataDir:     dataDir,
+		binDir:      binDir,
+	}
+}
+
+// getServiceName returns the systemd service name with port
+func (s *ServiceAlertmanagerDeployer) getServiceName() string {
+	return fmt.Sprintf("chainlaunch-alertmanager-%d", s.config.Port)
+}
+
+// getLaunchdServiceName returns the launchd service name with port
+func (s *ServiceAlertmanagerDeployer) getLaunchdServiceName() string {
+	return fmt.Sprintf("dev.chainlaunch.alertmanager.%d", s.config.Port)
+}
+
+// getServiceFilePath returns the path to the service file
+func (s *ServiceAlertmanagerDeployer) getServiceFilePath() string {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		return fmt.Sprintf("/etc/systemd/system/%s.service", s.getServiceName())
+	case common.ServiceTypeLaunchd:
+		homeDir, _ := os.UserHomeDir()
+		return filepath.Join(homeDir, "Library/LaunchAgents", s.getLaunchdServiceName()+".plist")
+	default:
+		return ""
+	}
+}
+
+// getLogPath returns the path to the log file
+func (s *ServiceAlertmanagerDeployer) getLogPath() string {
+	return filepath.Join(s.dataDir, fmt.Sprintf("%s.log", s.getServiceName()))
+}
+
+// Start starts the Alertmanager service
+func (s *ServiceAlertmanagerDeployer) Start(ctx context.Context, webhookToken string) error {
+	if err := writeAlertmanagerConfig(s.config, webhookToken, s.configDir, s.dataDir, s.binDir); err != nil {
+		return err
+	}
+
+	if err := s.downloadAlertmanager(); err != nil {
+		return fmt.Errorf("failed to download Alertmanager: %w", err)
+	}
+
+	// Stop a previous instance so the new configuration is picked up
+	_ = s.Stop(ctx)
+
+	var serviceContent string
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		serviceContent = s.generateSystemdService()
+	case common.ServiceTypeLaunchd:
+		serviceContent = s.generateLaunchdService()
+	default:
+		return fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+	if err := os.WriteFile(s.getServiceFilePath(), []byte(serviceContent), 0644); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
+
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		if err := exec.Command("systemctl", "daemon-reload").Run(); err != nil {
+			return fmt.Errorf("failed to reload systemd: %w", err)
+		}
+		if err := exec.Command("systemctl", "start", s.getServiceName()).Run(); err != nil {
+			return fmt.Errorf("failed to start service: %w", err)
+		}
+	case common.ServiceTypeLaunchd:
+		if err := exec.Command("launchctl", "load", s.getServiceFilePath()).Run(); err != nil {
+			return fmt.Errorf("failed to start service: %w", err)
+		}
+	}
+
+	return waitForAlertmanagerReady(ctx, s.config.Port)
+}
+
+// Stop stops the Alertmanager service
+func (s *ServiceAlertmanagerDeployer) Stop(ctx context.Context) error {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		return exec.Command("systemctl", "stop", s.getServiceName()).Run()
+	case common.ServiceTypeLaunchd:
+		return exec.Command("launchctl", "unload", s.getServiceFilePath()).Run()
+	default:
+		return fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+}
+
+// GetStatus returns the current status of the Alertmanager service
+func (s *ServiceAlertmanagerDeployer) GetStatus(ctx context.Context) (string, error) {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		output, err := exec.Command("systemctl", "is-active", s.getServiceName()).Output()
+		if err != nil {
+			return "inactive", nil
+		}
+		status := strings.TrimSpace(string(output))
+		if status == "active" {
+			return "running", nil
+		}
+		return status, nil
+	case common.ServiceTypeLaunchd:
+		if err := exec.Command("launchctl", "list", s.getLaunchdServiceName()).Run(); err != nil {
+			return "inactive", nil
+		}
+		return "running", nil
+	default:
+		return "unknown", fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+}
+
+// downloadAlertmanager downloads the Alertmanager binary from official releases
+func (s *ServiceAlertmanagerDeployer) downloadAlertmanager() error {
+	version := s.config.Version
+	if version == "" {
+		version = common.DefaultAlertmanagerConfig().Version
+	}
+
+	alertmanagerBin := filepath.Join(s.binDir, "alertmanager")
+	if _, err := os.Stat(alertmanagerBin); err == nil {
+		output, err := exec.Command(alertmanagerBin, "--version").Output()
+		if err == nil && strings.Contains(string(output), strings.TrimPrefix(version, "v")) {
+			return nil // Correct version already installed
+		}
+	}
+
+	arch := runtime.GOARCH
+	if arch == "arm" {
+		arch = "armv7"
+	}
+
+	// Format: https://github.com/prometheus/alertmanager/releases/download/v0.28.1/alertmanager-0.28.1.linux-amd64.tar.gz
+	downloadURL := fmt.Sprintf("https://github.com/prometheus/alertmanager/releases/download/%s/alertmanager-%s.%s-%s.tar.gz",
+		version, strings.TrimPrefix(version, "v"), runtime.GOOS, arch)
+
+	tmpDir, err := os.MkdirTemp("", "alertmanager-download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := filepath.Join(tmpDir, "alertmanager.tar.gz")
+	if err := downloadFile(downloadURL, archivePath); err != nil {
+		return fmt.Errorf("failed to download Alertmanager: %w", err)
+	}
+	if err := extractTarGz(archivePath, tmpDir); err != nil {
+		return fmt.Errorf("failed to extract Alertmanager archive: %w", err)
+	}
+
+	srcBinary := filepath.Join(tmpDir, fmt.Sprintf("alertmanager-%s.%s-%s", strings.TrimPrefix(version, "v"), runtime.GOOS, arch), "alertmanager")
+	if err := copyFile(srcBinary, alertmanagerBin); err != nil {
+		return fmt.Errorf("failed to copy Alertmanager binary: %w", err)
+	}
+	if err := os.Chmod(alertmanagerBin, 0755); err != nil {
+		return fmt.Errorf("failed to make Alertmanager binary executable: %w", err)
+	}
+
+	return nil
+}
+
+// generateSystemdService generates the systemd service file content
+func (s *ServiceAlertmanagerDeployer) generateSystemdService() string {
+	currentUser := os.Getenv("USER")
+	if currentUser == "" {
+		currentUser = "root"
+	}
+
+	return fmt.Sprintf(`[Unit]
+Description=Alertmanager (Port %d)
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+User=%s
+Type=simple
+ExecStart=%s/alertmanager \
+  --config.file=%s/alertmanager.yml \
+  --storage.path=%s \
+  --web.listen-address=:%d \
+  --cluster.listen-address=
+
+StandardOutput=append:%s
+StandardError=append:%s
+
+[Install]
+WantedBy=multi-user.target
+`, s.config.Port, currentUser, s.binDir, s.configDir, s.dataDir, s.config.Port, s.getLogPath(), s.getLogPath())
+}
+
+// generateLaunchdService generates the launchd service file content
+func (s *ServiceAlertmanagerDeployer) generateLaunchdService() string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>%s</string>
+    <key>ProgramArguments</key>
+    <array>
+        <string>%s/alertmanager</string>
+        <string>--config.file=%s/alertmanager.yml</string>
+        <string>--storage.path=%s</string>
+        <string>--web.listen-address=:%d</string>
+        <string>--cluster.listen-address=</string>
+    </array>
+    <key>RunAtLoad</key>
+    <true/>
+    <key>KeepAlive</key>
+    <true/>
+    <key>StandardOutPath</key>
+    <string>%s</string>
+    <key>StandardErrorPath</key>
+    <string>%s</string>
+</dict>
+</plist>
+`, s.getLaunchdServiceName(), s.binDir, s.configDir, s.dataDir, s.config.Port, s.getLogPath(), s.getLogPath())
+}
@@ -42,6 +42,12 @@ type Config struct {
 	DeploymentMode DeploymentMode
 	// DockerConfig contains Docker-specific configuration
 	DockerConfig *DockerConfig
+	// SelfMetricsPort is the port ChainLaunch serves its own /metrics endpoint on (0 disables the scrape target)
+	SelfMetricsPort int
+	// SelfMetricsTLS reports whether ChainLaunch serves the /metrics endpoint over TLS
+	SelfMetricsTLS bool
+	// SelfMetricsToken is the bearer token the /metrics endpoint requires (empty when it is public)
+	SelfMetricsToken string
 }
 
 // DockerConfig contains Docker-specific configuration
@@ -75,6 +81,126 @@ func GetServiceType() ServiceType {
 	}
 }
 
+// AlertmanagerConfig represents the configuration for the Alertmanager instance
+type AlertmanagerConfig struct {
+	// Version is the version of Alertmanager to deploy
+	Version string
+	// Port is the port Alertmanager will listen on
+	Port int
+	// DeploymentMode is the deployment mode (docker or service)
+	DeploymentMode DeploymentMode
+	// DockerConfig contains Docker-specific configuration
+	DockerConfig *DockerConfig
+	// WebhookURL is the ChainLaunch endpoint Alertmanager forwards alerts to
+	WebhookURL string
+	// DefaultRulesEnabled controls whether the built-in Fabric/Besu rules are loaded
+	DefaultRulesEnabled bool
+}
+
+// DefaultAlertmanagerConfig returns a default configuration for Alertmanager
+func DefaultAlertmanagerConfig() *AlertmanagerConfig {
+	return &AlertmanagerConfig{
+		Version:             "v0.28.1",
+		Port:                9093,
+		DeploymentMode:      DeploymentModeDocker,
+		DefaultRulesEnabled: true,
+		DockerConfig: &DockerConfig{
+			NetworkMode: NetworkModeBridge,
+		},
+	}
+}
+
+// GrafanaConfig represents the configuration for the Grafana instance
+type GrafanaConfig struct {
+	// Version is the version of Grafana to deploy
+	Version string
+	// Port is the port Grafana will listen on
+	Port int
+	// DeploymentMode is the deployment mode (docker or service)
+	DeploymentMode DeploymentMode
+	// DockerConfig contains Docker-specific configuration
+	DockerConfig *DockerConfig
+	// AdminUser is the Grafana administrator login
+	AdminUser string
+	// AdminPassword is the Grafana administrator password; generated when empty
+	AdminPassword string
+}
+
+// DefaultGrafanaConfig returns a default configuration for Grafana
+func DefaultGrafanaConfig() *GrafanaConfig {
+	return &GrafanaConfig{
+		Version:        "11.6.0",
+		Port:           3000,
+		DeploymentMode: DeploymentModeDocker,
+		AdminUser:      "admin",
+		DockerConfig: &DockerConfig{
+			NetworkMode: NetworkModeBridge,
+		},
+	}
+}
+
+// GrafanaDeployment describes a deployed Grafana instance and its credentials
+type GrafanaDeployment struct {
+	// URL is the address Grafana is reachable on from the ChainLaunch host
+	URL string `json:"url"`
+	// AdminUser is the Grafana administrator login
+	AdminUser string `json:"admin_user"`
+	// AdminPassword is the Grafana administrator password
+	AdminPassword string `json:"admin_password"`
+	// Dashboards are the UIDs of the provisioned dashboards
+	Dashboards []string `json:"dashboards"`
+}
+
+// AlertRule represents a Prometheus alerting rule
+type AlertRule struct {
+	// ID is the database ID of a custom rule (0 for built-in rules)
+	ID int64 `json:"id,omitempty"`
+	// Group is the rule group the alert belongs to
+	Group string `json:"group"`
+	// Name is the alert name
+	Name string `json:"name"`
+	// Expr is the PromQL expression that triggers the alert
+	Expr string `json:"expr"`
+	// For is how long the expression must hold before firing (e.g. 5m)
+	For string `json:"for,omitempty"`
+	// Severity is the severity label attached to the alert
+	Severity string `json:"severity"`
+	// Summary is a short human-readable summary
+	Summary string `json:"summary,omitempty"`
+	// Description is a longer human-readable description
+	Description string `json:"description,omitempty"`
+	// Labels are additional labels attached to the alert
+	Labels map[string]string `json:"labels,omitempty"`
+	// Enabled reports whether the rule is rendered into the rules file
+	Enabled bool `json:"enabled"`
+	// BuiltIn is true for the default rules shipped with ChainLaunch
+	BuiltIn bool `json:"built_in"`
+}
+
+// Alert represents a single alert delivered by the Alertmanager webhook
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertWebhookPayload is the payload Alertmanager posts to webhook receivers
+type AlertWebhookPayload struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
 // Status represents the status of a Prometheus instance
 type Status struct {
 	// Status is the current status (running, stopped, etc.)
@@ -141,4 +267,28 @@ type Service interface {
 	GetCurrentConfig(ctx context.Context) (*Config, error)
 	// TailLogs retrieves Prometheus logs with optional tail and follow functionality
 	TailLogs(ctx context.Context, tail int, follow bool) (<-chan string, error)
+	// ListAlertRules returns the built-in and custom alerting rules
+	ListAlertRules(ctx context.Context) ([]AlertRule, error)
+	// CreateAlertRule creates a custom alerting rule and reloads Prometheus
+	CreateAlertRule(ctx context.Context, rule *AlertRule) (*AlertRule, error)
+	// UpdateAlertRule updates a custom alerting rule and reloads Prometheus
+	UpdateAlertRule(ctx context.Context, id int64, rule *AlertRule) (*AlertRule, error)
+	// DeleteAlertRule deletes a custom alerting rule and reloads Prometheus
+	DeleteAlertRule(ctx context.Context, id int64) error
+	// DeployAlertmanager deploys Alertmanager and wires it into Prometheus
+	DeployAlertmanager(ctx context.Context, config *AlertmanagerConfig) error
+	// UndeployAlertmanager stops Alertmanager and removes it from Prometheus
+	UndeployAlertmanager(ctx context.Context) error
+	// GetAlertmanagerStatus returns the current status of the Alertmanager instance
+	GetAlertmanagerStatus(ctx context.Context) (*Status, error)
+	// HandleAlertWebhook forwards alerts received from Alertmanager to the notification providers
+	HandleAlertWebhook(ctx context.Context, token string, payload *AlertWebhookPayload) error
+	// DeployGrafana deploys Grafana with the Prometheus datasource and ChainLaunch dashboards provisioned
+	DeployGrafana(ctx context.Context, config *GrafanaConfig) (*GrafanaDeployment, error)
+	// UndeployGrafana stops Grafana
+	UndeployGrafana(ctx context.Context) error
+	// GetGrafanaStatus returns the current status of the Grafana instance
+	GetGrafanaStatus(ctx context.Context) (*Status, error)
+	// RefreshGrafanaDashboards regenerates the provisioned dashboards from the current scrape targets
+	RefreshGrafanaDashboards(ctx context.Context) ([]string, error)
 }
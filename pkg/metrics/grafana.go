@@ -0,0 +1,602 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	configservice "github.com/chainlaunch/chainlaunch/pkg/config"
+	"github.com/chainlaunch/chainlaunch/pkg/docker"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrGrafanaNotDeployed is returned by Grafana operations that require a deployed instance
+var ErrGrafanaNotDeployed = errors.New("grafana is not deployed")
+
+// GrafanaDeployer defines the interface for different Grafana deployment methods
+type GrafanaDeployer interface {
+	// Start starts the Grafana instance. Provisioning files must be written beforehand.
+	Start(ctx context.Context) error
+	// Stop stops the Grafana instance
+	Stop(ctx context.Context) error
+	// GetStatus returns the current status of the Grafana instance
+	GetStatus(ctx context.Context) (string, error)
+	// DashboardsPath returns the dashboards directory as seen by Grafana
+	DashboardsPath() string
+}
+
+// GrafanaDatasourcesFile represents a Grafana datasource provisioning file
+type GrafanaDatasourcesFile struct {
+	APIVersion  int                 `yaml:"apiVersion"`
+	Datasources []GrafanaDatasource `yaml:"datasources"`
+}
+
+// GrafanaDatasource represents a provisioned Grafana datasource
+type GrafanaDatasource struct {
+	Name      string `yaml:"name"`
+	UID       string `yaml:"uid"`
+	Type      string `yaml:"type"`
+	Access    string `yaml:"access"`
+	URL       string `yaml:"url"`
+	IsDefault bool   `yaml:"isDefault"`
+	Editable  bool   `yaml:"editable"`
+}
+
+// GrafanaDashboardsFile represents a Grafana dashboard provider provisioning file
+type GrafanaDashboardsFile struct {
+	APIVersion int                        `yaml:"apiVersion"`
+	Providers  []GrafanaDashboardProvider `yaml:"providers"`
+}
+
+// GrafanaDashboardProvider represents a file-based dashboard provider
+type GrafanaDashboardProvider struct {
+	Name                  string                          `yaml:"name"`
+	Folder                string                          `yaml:"folder"`
+	Type                  string                          `yaml:"type"`
+	DisableDeletion       bool                            `yaml:"disableDeletion"`
+	AllowUIUpdates        bool                            `yaml:"allowUiUpdates"`
+	UpdateIntervalSeconds int                             `yaml:"updateIntervalSeconds"`
+	Options               GrafanaDashboardProviderOptions `yaml:"options"`
+}
+
+// GrafanaDashboardProviderOptions holds the options of a file-based dashboard provider
+type GrafanaDashboardProviderOptions struct {
+	Path string `yaml:"path"`
+}
+
+// grafanaDirs holds the directories used by a Grafana deployment
+type grafanaDirs struct {
+	provisioning string
+	dashboards   string
+	data         string
+	bin          string
+}
+
+// newGrafanaDirs returns the Grafana directories under the ChainLaunch data path
+func newGrafanaDirs(configService *configservice.ConfigService) grafanaDirs {
+	grafanaDir := filepath.Join(configService.GetDataPath(), "grafana")
+	return grafanaDirs{
+		provisioning: filepath.Join(grafanaDir, "provisioning"),
+		dashboards:   filepath.Join(grafanaDir, "dashboards"),
+		data:         filepath.Join(grafanaDir, "data"),
+		bin:          filepath.Join(grafanaDir, "bin"),
+	}
+}
+
+// writeGrafanaProvisioning writes the datasource and dashboard provider files
+func writeGrafanaProvisioning(dirs grafanaDirs, prometheusURL, dashboardsPath string) error {
+	datasourcesDir := filepath.Join(dirs.provisioning, "datasources")
+	dashboardsProviderDir := filepath.Join(dirs.provisioning, "dashboards")
+	// Grafana reports errors on startup when the plugins/alerting provisioning directories are missing
+	pluginsDir := filepath.Join(dirs.provisioning, "plugins")
+	alertingDir := filepath.Join(dirs.provisioning, "alerting")
+	for _, dir := range []string{datasourcesDir, dashboardsProviderDir, pluginsDir, alertingDir, dirs.dashboards, dirs.data, dirs.bin} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	datasources := GrafanaDatasourcesFile{
+		APIVersion: 1,
+		Datasources: []GrafanaDatasource{{
+			Name:      "Prometheus",
+			UID:       grafanaDatasourceUID,
+			Type:      "prometheus",
+			Access:    "proxy",
+			URL:       prometheusURL,
+			IsDefault: true,
+		}},
+	}
+	if err := writeYAMLFile(filepath.Join(datasourcesDir, "chainlaunch.yml"), datasources); err != nil {
+		return err
+	}
+
+	providers := GrafanaDashboardsFile{
+		APIVersion: 1,
+		Providers: []GrafanaDashboardProvider{{
+			Name:                  "chainlaunch",
+			Folder:                "ChainLaunch",
+			Type:                  "file",
+			UpdateIntervalSeconds: 30,
+			Options:               GrafanaDashboardProviderOptions{Path: dashboardsPath},
+		}},
+	}
+	return writeYAMLFile(filepath.Join(dashboardsProviderDir, "chainlaunch.yml"), providers)
+}
+
+// writeYAMLFile marshals value as YAML into path
+func writeYAMLFile(path string, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeGrafanaDashboards replaces the dashboard files in dir. Grafana picks up the
+// changes on its next provisioning scan and removes dashboards whose file is gone.
+func writeGrafanaDashboards(dir string, dashboards []grafanaDashboard) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create dashboards directory: %w", err)
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dashboards: %w", err)
+	}
+	for _, path := range existing {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove dashboard %s: %w", path, err)
+		}
+	}
+
+	uids := make([]string, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		data, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal dashboard %s: %w", dashboard.UID, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, dashboard.UID+".json"), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write dashboard %s: %w", dashboard.UID, err)
+		}
+		uids = append(uids, dashboard.UID)
+	}
+	return uids, nil
+}
+
+// waitForGrafanaReady polls the Grafana health endpoint on the host
+func waitForGrafanaReady(ctx context.Context, port int) error {
+	maxWaitTime := 120 * time.Second
+	checkInterval := 2 * time.Second
+	healthURL := fmt.Sprintf("http://localhost:%d/api/health", port)
+
+	for elapsed := time.Duration(0); elapsed < maxWaitTime; elapsed += checkInterval {
+		req, err := http.NewRequestWithContext(ctx, "GET", healthURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create health request: %w", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(checkInterval)
+	}
+
+	return fmt.Errorf("timeout waiting for Grafana to be ready after %v", maxWaitTime)
+}
+
+// grafanaEnv returns the environment overriding the Grafana settings managed by ChainLaunch
+func grafanaEnv(config *common.GrafanaConfig) []string {
+	return []string{
+		fmt.Sprintf("GF_SERVER_HTTP_PORT=%d", config.Port),
+		fmt.Sprintf("GF_SECURITY_ADMIN_USER=%s", config.AdminUser),
+		fmt.Sprintf("GF_SECURITY_ADMIN_PASSWORD=%s", config.AdminPassword),
+		"GF_USERS_ALLOW_SIGN_UP=false",
+		"GF_ANALYTICS_REPORTING_ENABLED=false",
+	}
+}
+
+// DockerGrafanaDeployer implements GrafanaDeployer for Docker deployment
+type DockerGrafanaDeployer struct {
+	config *common.GrafanaConfig
+	client *client.Client
+	dirs   grafanaDirs
+}
+
+// NewDockerGrafanaDeployer creates a new Docker-based Grafana deployer
+func NewDockerGrafanaDeployer(config *common.GrafanaConfig, configService *configservice.ConfigService) (*DockerGrafanaDeployer, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	return &DockerGrafanaDeployer{
+		config: config,
+		client: cli,
+		dirs:   newGrafanaDirs(configService),
+	}, nil
+}
+
+// containerName returns the Grafana container name
+func (d *DockerGrafanaDeployer) containerName() string {
+	return fmt.Sprintf("chainlaunch-grafana-%d", d.config.Port)
+}
+
+// DashboardsPath returns the dashboards directory mounted in the container
+func (d *DockerGrafanaDeployer) DashboardsPath() string {
+	return "/var/lib/chainlaunch/dashboards"
+}
+
+// Start starts the Grafana container
+func (d *DockerGrafanaDeployer) Start(ctx context.Context) error {
+	// Remove any existing container
+	if err := d.removeContainer(ctx); err != nil {
+		return err
+	}
+
+	imageName := fmt.Sprintf("grafana/grafana:%s", d.config.Version)
+	if err := docker.PullImageIfNeeded(ctx, d.client, imageName); err != nil {
+		return err
+	}
+
+	containerConfig := &container.Config{
+		Image: imageName,
+		Env:   grafanaEnv(d.config),
+		// The data directory is a host bind mount, which the default grafana user cannot write to
+		User: "root",
+		ExposedPorts: nat.PortSet{
+			nat.Port(fmt.Sprintf("%d/tcp", d.config.Port)): struct{}{},
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: d.dirs.data,
+				Target: "/var/lib/grafana",
+			},
+			{
+				Type:   mount.TypeBind,
+				Source: d.dirs.provisioning,
+				Target: "/etc/grafana/provisioning",
+			},
+			{
+				Type:   mount.TypeBind,
+				Source: d.dirs.dashboards,
+				Target: d.DashboardsPath(),
+			},
+		},
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyMode("unless-stopped"),
+		},
+	}
+
+	if d.config.DockerConfig != nil && d.config.DockerConfig.NetworkMode == common.NetworkModeHost {
+		hostConfig.NetworkMode = container.NetworkMode("host")
+	} else {
+		hostConfig.PortBindings = nat.PortMap{
+			nat.Port(fmt.Sprintf("%d/tcp", d.config.Port)): []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: fmt.Sprintf("%d", d.config.Port),
+				},
+			},
+		}
+		// Allow the datasource to reach Prometheus published on the host
+		hostConfig.ExtraHosts = []string{"host.docker.internal:host-gateway"}
+	}
+
+	resp, err := d.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, &v1.Platform{}, d.containerName())
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := d.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return waitForGrafanaReady(ctx, d.config.Port)
+}
+
+// removeContainer stops and removes the Grafana container if it exists
+func (d *DockerGrafanaDeployer) removeContainer(ctx context.Context) error {
+	_, err := d.client.ContainerInspect(ctx, d.containerName())
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if err := d.client.ContainerRemove(ctx, d.containerName(), container.RemoveOptions{Force: true}); err != nil {
+		return fmt.Errorf("failed to remove existing container: %w", err)
+	}
+	return nil
+}
+
+// Stop stops and removes the Grafana container
+func (d *DockerGrafanaDeployer) Stop(ctx context.Context) error {
+	return d.removeContainer(ctx)
+}
+
+// GetStatus returns the current status of the Grafana container
+func (d *DockerGrafanaDeployer) GetStatus(ctx context.Context) (string, error) {
+	info, err := d.client.ContainerInspect(ctx, d.containerName())
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return "not_deployed", nil
+		}
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return info.State.Status, nil
+}
+
+// ServiceGrafanaDeployer implements GrafanaDeployer for system service deployment
+type ServiceGrafanaDeployer struct {
+	config      *common.GrafanaConfig
+	serviceType common.ServiceType
+	dirs        grafanaDirs
+}
+
+// NewServiceGrafanaDeployer creates a new service-based Grafana deployer
+func NewServiceGrafanaDeployer(config *common.GrafanaConfig, configService *configservice.ConfigService) *ServiceGrafanaDeployer {
+	return &ServiceGrafanaDeployer{
+		config:      config,
+		serviceType: common.GetServiceType(),
+		dirs:        newGrafanaDirs(configService),
+	}
+}
+
+// DashboardsPath returns the local dashboards directory
+func (s *ServiceGrafanaDeployer) DashboardsPath() string {
+	return s.dirs.dashboards
+}
+
+// homeDir returns the directory holding the extracted Grafana release
+func (s *ServiceGrafanaDeployer) homeDir() string {
+	return filepath.Join(s.dirs.bin, "grafana-"+s.config.Version)
+}
+
+// getServiceName returns the systemd service name with port
+func (s *ServiceGrafanaDeployer) getServiceName() string {
+	return fmt.Sprintf("chainlaunch-grafana-%d", s.config.Port)
+}
+
+// getLaunchdServiceName returns the launchd service name with port
+func (s *ServiceGrafanaDeployer) getLaunchdServiceName() string {
+	return fmt.Sprintf("dev.chainlaunch.grafana.%d", s.config.Port)
+}
+
+// getServiceFilePath returns the path to the service file
+func (s *ServiceGrafanaDeployer) getServiceFilePath() string {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		return fmt.Sprintf("/etc/systemd/system/%s.service", s.getServiceName())
+	case common.ServiceTypeLaunchd:
+		homeDir, _ := os.UserHomeDir()
+		return filepath.Join(homeDir, "Library/LaunchAgents", s.getLaunchdServiceName()+".plist")
+	default:
+		return ""
+	}
+}
+
+// getLogPath returns the path to the log file
+func (s *ServiceGrafanaDeployer) getLogPath() string {
+	return filepath.Join(s.dirs.data, fmt.Sprintf("%s.log", s.getServiceName()))
+}
+
+// serverArgs returns the grafana server arguments pointing at the ChainLaunch managed directories
+func (s *ServiceGrafanaDeployer) serverArgs() []string {
+	return []string{
+		filepath.Join(s.homeDir(), "bin", "grafana"),
+		"server",
+		"--homepath=" + s.homeDir(),
+		"cfg:default.paths.data=" + s.dirs.data,
+		"cfg:default.paths.logs=" + s.dirs.data,
+		"cfg:default.paths.provisioning=" + s.dirs.provisioning,
+	}
+}
+
+// Start starts the Grafana service
+func (s *ServiceGrafanaDeployer) Start(ctx context.Context) error {
+	if err := s.downloadGrafana(); err != nil {
+		return fmt.Errorf("failed to download Grafana: %w", err)
+	}
+
+	// Stop a previous instance so the new configuration is picked up
+	_ = s.Stop(ctx)
+
+	var serviceContent string
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		serviceContent = s.generateSystemdService()
+	case common.ServiceTypeLaunchd:
+		serviceContent = s.generateLaunchdService()
+	default:
+		return fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+	if err := os.WriteFile(s.getServiceFilePath(), []byte(serviceContent), 0600); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
+
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		if err := exec.Command("systemctl", "daemon-reload").Run(); err != nil {
+			return fmt.Errorf("failed to reload systemd: %w", err)
+		}
+		if err := exec.Command("systemctl", "start", s.getServiceName()).Run(); err != nil {
+			return fmt.Errorf("failed to start service: %w", err)
+		}
+	case common.ServiceTypeLaunchd:
+		if err := exec.Command("launchctl", "load", s.getServiceFilePath()).Run(); err != nil {
+			return fmt.Errorf("failed to start service: %w", err)
+		}
+	}
+
+	return waitForGrafanaReady(ctx, s.config.Port)
+}
+
+// Stop stops the Grafana service
+func (s *ServiceGrafanaDeployer) Stop(ctx context.Context) error {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		return exec.Command("systemctl", "stop", s.getServiceName()).Run()
+	case common.ServiceTypeLaunchd:
+		return exec.Command("launchctl", "unload", s.getServiceFilePath()).Run()
+	default:
+		return fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+}
+
+// GetStatus returns the current status of the Grafana service
+func (s *ServiceGrafanaDeployer) GetStatus(ctx context.Context) (string, error) {
+	switch s.serviceType {
+	case common.ServiceTypeSystemd:
+		output, err := exec.Command("systemctl", "is-active", s.getServiceName()).Output()
+		if err != nil {
+			return "inactive", nil
+		}
+		status := strings.TrimSpace(string(output))
+		if status == "active" {
+			return "running", nil
+		}
+		return status, nil
+	case common.ServiceTypeLaunchd:
+		if err := exec.Command("launchctl", "list", s.getLaunchdServiceName()).Run(); err != nil {
+			return "inactive", nil
+		}
+		return "running", nil
+	default:
+		return "unknown", fmt.Errorf("unsupported service type: %s", s.serviceType)
+	}
+}
+
+// downloadGrafana downloads and extracts the Grafana OSS release
+func (s *ServiceGrafanaDeployer) downloadGrafana() error {
+	if _, err := os.Stat(filepath.Join(s.homeDir(), "bin", "grafana")); err == nil {
+		return nil // Version already installed
+	}
+	if err := os.MkdirAll(s.dirs.bin, 0755); err != nil {
+		return fmt.Errorf("failed to create bin directory: %w", err)
+	}
+
+	// Format: https://dl.grafana.com/oss/release/grafana-11.6.0.linux-amd64.tar.gz
+	downloadURL := fmt.Sprintf("https://dl.grafana.com/oss/release/grafana-%s.%s-%s.tar.gz",
+		s.config.Version, runtime.GOOS, runtime.GOARCH)
+
+	// Extract next to the destination so the release directory can be renamed in place
+	tmpDir, err := os.MkdirTemp(s.dirs.bin, "grafana-download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := filepath.Join(tmpDir, "grafana.tar.gz")
+	if err := downloadFile(downloadURL, archivePath); err != nil {
+		return fmt.Errorf("failed to download Grafana: %w", err)
+	}
+	if err := extractTarGz(archivePath, tmpDir); err != nil {
+		return fmt.Errorf("failed to extract Grafana archive: %w", err)
+	}
+
+	// The archive holds a single grafana-<version> (or grafana-v<version>) directory
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "grafana-*", "bin", "grafana"))
+	if err != nil || len(matches) == 0 {
+		return fmt.Errorf("grafana binary not found in archive")
+	}
+	if err := os.RemoveAll(s.homeDir()); err != nil {
+		return fmt.Errorf("failed to remove previous Grafana installation: %w", err)
+	}
+	if err := os.Rename(filepath.Dir(filepath.Dir(matches[0])), s.homeDir()); err != nil {
+		return fmt.Errorf("failed to install Grafana: %w", err)
+	}
+
+	return nil
+}
+
+// generateSystemdService generates the systemd service file content
+func (s *ServiceGrafanaDeployer) generateSystemdService() string {
+	currentUser := os.Getenv("USER")
+	if currentUser == "" {
+		currentUser = "root"
+	}
+
+	var env strings.Builder
+	for _, kv := range grafanaEnv(s.config) {
+		fmt.Fprintf(&env, "Environment=\"%s\"\n", kv)
+	}
+
+	return fmt.Sprintf(`[Unit]
+Description=Grafana (Port %d)
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+User=%s
+Type=simple
+WorkingDirectory=%s
+%sExecStart=%s
+
+StandardOutput=append:%s
+StandardError=append:%s
+
+[Install]
+WantedBy=multi-user.target
+`, s.config.Port, currentUser, s.homeDir(), env.String(), strings.Join(s.serverArgs(), " "), s.getLogPath(), s.getLogPath())
+}
+
+// generateLaunchdService generates the launchd service file content
+func (s *ServiceGrafanaDeployer) generateLaunchdService() string {
+	var args strings.Builder
+	for _, arg := range s.serverArgs() {
+		fmt.Fprintf(&args, "        <string>%s</string>\n", arg)
+	}
+	var env strings.Builder
+	for _, kv := range grafanaEnv(s.config) {
+		key, value, _ := strings.Cut(kv, "=")
+		fmt.Fprintf(&env, "        <key>%s</key>\n        <string>%s</string>\n", key, value)
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>%s</string>
+    <key>ProgramArguments</key>
+    <array>
+%s    </array>
+    <key>EnvironmentVariables</key>
+    <dict>
+%s    </dict>
+    <key>WorkingDirectory</key>
+    <string>%s</string>
+    <key>RunAtLoad</key>
+    <true/>
+    <key>KeepAlive</key>
+    <true/>
+    <key>StandardOutPath</key>
+    <string>%s</string>
+    <key>StandardErrorPath</key>
+    <string>%s</string>
+</dict>
+</plist>
+`, s.getLaunchdServiceName(), args.String(), env.String(), s.homeDir(), s.getLogPath(), s.getLogPath())
+}
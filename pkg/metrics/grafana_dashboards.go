@@ -0,0 +1,300 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	grafanaDatasourceUID = "chainlaunch-prometheus"
+	// grafanaMaxUIDLength is the maximum dashboard UID length accepted by Grafana
+	grafanaMaxUIDLength = 40
+)
+
+// grafanaDashboard is the subset of the Grafana dashboard JSON model used by the provisioned dashboards
+type grafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	Timezone      string            `json:"timezone"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaDatasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaVariable struct {
+	Name       string               `json:"name"`
+	Label      string               `json:"label"`
+	Type       string               `json:"type"`
+	Datasource grafanaDatasourceRef `json:"datasource"`
+	Query      string               `json:"query"`
+	Definition string               `json:"definition"`
+	Refresh    int                  `json:"refresh"`
+	IncludeAll bool                 `json:"includeAll"`
+	Multi      bool                 `json:"multi"`
+	Sort       int                  `json:"sort"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults  grafanaFieldDefaults `json:"defaults"`
+	Overrides []interface{}        `json:"overrides"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+type grafanaTarget struct {
+	RefID        string               `json:"refId"`
+	Expr         string               `json:"expr"`
+	LegendFormat string               `json:"legendFormat"`
+	Datasource   grafanaDatasourceRef `json:"datasource"`
+}
+
+type grafanaPanel struct {
+	ID          int                  `json:"id"`
+	Type        string               `json:"type"`
+	Title       string               `json:"title"`
+	GridPos     grafanaGridPos       `json:"gridPos"`
+	Datasource  grafanaDatasourceRef `json:"datasource"`
+	FieldConfig grafanaFieldConfig   `json:"fieldConfig"`
+	Targets     []grafanaTarget      `json:"targets"`
+}
+
+// panelSpec describes a single-query panel. Expr contains a %s placeholder for the label selector.
+type panelSpec struct {
+	Title  string
+	Type   string
+	Unit   string
+	Expr   string
+	Legend string
+}
+
+var (
+	upPanel = panelSpec{Title: "Up", Type: "stat", Expr: `up{%s}`, Legend: "{{node_name}}"}
+
+	processPanels = []panelSpec{
+		{Title: "CPU usage", Type: "timeseries", Unit: "percentunit", Expr: `rate(process_cpu_seconds_total{%s}[5m])`, Legend: "{{node_name}}"},
+		{Title: "Resident memory", Type: "timeseries", Unit: "bytes", Expr: `process_resident_memory_bytes{%s}`, Legend: "{{node_name}}"},
+	}
+
+	fabricPeerPanels = []panelSpec{
+		{Title: "Ledger height", Type: "timeseries", Expr: `ledger_blockchain_height{%s}`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Proposals received", Type: "timeseries", Unit: "reqps", Expr: `sum by (node_name) (rate(endorser_proposals_received{%s}[5m]))`, Legend: "{{node_name}}"},
+		{Title: "Endorsement failures", Type: "timeseries", Unit: "reqps", Expr: `sum by (node_name, chaincode) (rate(endorser_endorsement_failures{%s}[5m]))`, Legend: "{{node_name}} {{chaincode}}"},
+		{Title: "Block processing time (p95)", Type: "timeseries", Unit: "s", Expr: `histogram_quantile(0.95, sum by (le, node_name, channel) (rate(ledger_block_processing_time_bucket{%s}[5m])))`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Known gossip peers", Type: "timeseries", Expr: `gossip_membership_total_peers_known{%s}`, Legend: "{{node_name}} {{channel}}"},
+	}
+
+	fabricOrdererPanels = []panelSpec{
+		{Title: "Ledger height", Type: "timeseries", Expr: `ledger_blockchain_height{%s}`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Raft leader", Type: "timeseries", Expr: `consensus_etcdraft_is_leader{%s}`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Raft active nodes", Type: "timeseries", Expr: `consensus_etcdraft_active_nodes{%s}`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Raft leader changes", Type: "timeseries", Expr: `increase(consensus_etcdraft_leader_changes{%s}[15m])`, Legend: "{{node_name}} {{channel}}"},
+		{Title: "Broadcast rate", Type: "timeseries", Unit: "reqps", Expr: `sum by (node_name, channel) (rate(broadcast_processed_count{%s}[5m]))`, Legend: "{{node_name}} {{channel}}"},
+	}
+
+	besuPanels = []panelSpec{
+		{Title: "Chain height", Type: "timeseries", Expr: `ethereum_blockchain_height{%s}`, Legend: "{{node_name}}"},
+		{Title: "Blocks behind", Type: "timeseries", Expr: `ethereum_best_known_block_number{%s} - ethereum_blockchain_height{%s}`, Legend: "{{node_name}}"},
+		{Title: "Peers", Type: "timeseries", Expr: `ethereum_peer_count{%s}`, Legend: "{{node_name}}"},
+		{Title: "Transaction pool", Type: "timeseries", Expr: `besu_transaction_pool_transactions{%s}`, Legend: "{{node_name}}"},
+	}
+
+	fabricXPanels = []panelSpec{
+		{Title: "Goroutines", Type: "timeseries", Expr: `go_goroutines{%s}`, Legend: "{{node_name}} ({{role}})"},
+		{Title: "gRPC requests", Type: "timeseries", Unit: "reqps", Expr: `sum by (node_name, role) (rate(grpc_server_handled_total{%s}[5m]))`, Legend: "{{node_name}} ({{role}})"},
+	}
+)
+
+// buildDashboard lays out the panels two per row, each filtered by selector and the $node variable
+func buildDashboard(uid, title string, tags []string, selector string, specs []panelSpec) grafanaDashboard {
+	datasource := grafanaDatasourceRef{Type: "prometheus", UID: grafanaDatasourceUID}
+	nodeSelector := selector + `,node_name=~"$node"`
+
+	dashboard := grafanaDashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          append([]string{"chainlaunch"}, tags...),
+		Timezone:      "browser",
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          grafanaTimeRange{From: "now-6h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{{
+			Name:       "node",
+			Label:      "Node",
+			Type:       "query",
+			Datasource: datasource,
+			Query:      fmt.Sprintf("label_values(up{%s}, node_name)", selector),
+			Definition: fmt.Sprintf("label_values(up{%s}, node_name)", selector),
+			Refresh:    2,
+			IncludeAll: true,
+			Multi:      true,
+			Sort:       1,
+		}}},
+		Panels: make([]grafanaPanel, 0, len(specs)),
+	}
+
+	for i, spec := range specs {
+		// Some expressions reference the selector more than once
+		args := make([]interface{}, strings.Count(spec.Expr, "%s"))
+		for j := range args {
+			args[j] = nodeSelector
+		}
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:          i + 1,
+			Type:        spec.Type,
+			Title:       spec.Title,
+			GridPos:     grafanaGridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8},
+			Datasource:  datasource,
+			FieldConfig: grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: spec.Unit}, Overrides: []interface{}{}},
+			Targets: []grafanaTarget{{
+				RefID:        "A",
+				Expr:         fmt.Sprintf(spec.Expr, args...),
+				LegendFormat: spec.Legend,
+				Datasource:   datasource,
+			}},
+		})
+	}
+	return dashboard
+}
+
+// platformPanels concatenates the panel groups into a single list
+func platformPanels(groups ...[]panelSpec) []panelSpec {
+	specs := []panelSpec{upPanel}
+	for _, group := range groups {
+		specs = append(specs, group...)
+	}
+	return specs
+}
+
+// defaultDashboards returns the per-platform dashboards shipped with ChainLaunch
+func defaultDashboards() []grafanaDashboard {
+	return []grafanaDashboard{
+		buildDashboard("chainlaunch-fabric-peers", "Fabric Peers", []string{"fabric"},
+			fmt.Sprintf(`%s="FABRIC_PEER"`, labelNodeType), platformPanels(fabricPeerPanels, processPanels)),
+		buildDashboard("chainlaunch-fabric-orderers", "Fabric Orderers", []string{"fabric"},
+			fmt.Sprintf(`%s="FABRIC_ORDERER"`, labelNodeType), platformPanels(fabricOrdererPanels, processPanels)),
+		buildDashboard("chainlaunch-besu", "Besu Nodes", []string{"besu"},
+			fmt.Sprintf(`%s="BESU_FULLNODE"`, labelNodeType), platformPanels(besuPanels, processPanels)),
+		buildDashboard("chainlaunch-fabricx", "FabricX", []string{"fabricx"},
+			fmt.Sprintf(`%s=~"FABRICX_.*"`, labelNodeType), platformPanels(fabricXPanels, processPanels)),
+	}
+}
+
+// networkSelector matches targets whose comma-separated network label contains network
+func networkSelector(network string) string {
+	// PromQL string literals need the regexp escapes themselves escaped
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(network), `\`, `\\`)
+	return fmt.Sprintf(`%s=~"(.*,)?%s(,.*)?"`, labelNetwork, quoted)
+}
+
+// scrapeNetworks returns the node types of the scrape targets of each network,
+// read from the network labels of a rendered Prometheus configuration
+func scrapeNetworks(configData []byte) (map[string]map[string]bool, error) {
+	var config PrometheusConfig
+	if err := yaml.Unmarshal(configData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Prometheus config: %w", err)
+	}
+	networks := make(map[string]map[string]bool)
+	for _, scrapeConfig := range config.ScrapeConfigs {
+		for _, staticConfig := range scrapeConfig.StaticConfigs {
+			value := staticConfig.Labels[labelNetwork]
+			if value == "" {
+				continue
+			}
+			for _, network := range strings.Split(value, ",") {
+				if networks[network] == nil {
+					networks[network] = make(map[string]bool)
+				}
+				networks[network][staticConfig.Labels[labelNodeType]] = true
+			}
+		}
+	}
+	return networks, nil
+}
+
+// networkDashboards returns one dashboard per network, with panels matching the node types it contains
+func networkDashboards(networks map[string]map[string]bool) []grafanaDashboard {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	usedUIDs := make(map[string]bool)
+	dashboards := make([]grafanaDashboard, 0, len(names))
+	for _, name := range names {
+		nodeTypes := networks[name]
+		var groups [][]panelSpec
+		var tags []string
+		if nodeTypes["FABRIC_PEER"] {
+			groups = append(groups, fabricPeerPanels)
+			tags = append(tags, "fabric")
+		}
+		if nodeTypes["FABRIC_ORDERER"] {
+			groups = append(groups, fabricOrdererPanels)
+			if !nodeTypes["FABRIC_PEER"] {
+				tags = append(tags, "fabric")
+			}
+		}
+		if nodeTypes["BESU_FULLNODE"] {
+			groups = append(groups, besuPanels)
+			tags = append(tags, "besu")
+		}
+		for nodeType := range nodeTypes {
+			if strings.HasPrefix(nodeType, "FABRICX_") {
+				groups = append(groups, fabricXPanels)
+				tags = append(tags, "fabricx")
+				break
+			}
+		}
+		groups = append(groups, processPanels)
+
+		uid := networkDashboardUID(name, usedUIDs)
+		dashboards = append(dashboards, buildDashboard(uid, "Network "+name, append([]string{"network"}, tags...),
+			networkSelector(name), platformPanels(groups...)))
+	}
+	return dashboards
+}
+
+// networkDashboardUID derives a unique dashboard UID that fits Grafana's length limit
+func networkDashboardUID(network string, used map[string]bool) string {
+	base := "chainlaunch-network-" + slugify(network)
+	if len(base) > grafanaMaxUIDLength-3 {
+		base = base[:grafanaMaxUIDLength-3]
+	}
+	uid := base
+	for i := 2; used[uid]; i++ {
+		uid = fmt.Sprintf("%s-%d", base, i)
+	}
+	used[uid] = true
+	return uid
+}
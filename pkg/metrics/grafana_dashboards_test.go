@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestScrapeNetworksReadsNetworkLabels(t *testing.T) {
+	config := PrometheusConfig{
+		ScrapeConfigs: []ScrapeConfig{
+			{JobName: "prometheus", StaticConfigs: []StaticConfig{{Targets: []string{"localhost:9090"}}}},
+			{JobName: "1-peer0", StaticConfigs: []StaticConfig{{
+				Targets: []string{"host.docker.internal:9443"},
+				Labels:  map[string]string{labelNodeType: "FABRIC_PEER", labelNetwork: "fabric-a,fabric-b"},
+			}}},
+			{JobName: "2-orderer0", StaticConfigs: []StaticConfig{{
+				Targets: []string{"host.docker.internal:9444"},
+				Labels:  map[string]string{labelNodeType: "FABRIC_ORDERER", labelNetwork: "fabric-a"},
+			}}},
+			{JobName: "3-besu0", StaticConfigs: []StaticConfig{{
+				Targets: []string{"host.docker.internal:9545"},
+				Labels:  map[string]string{labelNodeType: "BESU_FULLNODE"},
+			}}},
+		},
+	}
+	data, err := yaml.Marshal(config)
+	require.NoError(t, err)
+
+	networks, err := scrapeNetworks(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]bool{
+		"fabric-a": {"FABRIC_PEER": true, "FABRIC_ORDERER": true},
+		"fabric-b": {"FABRIC_PEER": true},
+	}, networks)
+}
+
+func TestNetworkDashboardsMatchNodeTypes(t *testing.T) {
+	dashboards := networkDashboards(map[string]map[string]bool{
+		"besu-net": {"BESU_FULLNODE": true},
+		"fabric":   {"FABRIC_PEER": true},
+	})
+	require.Len(t, dashboards, 2)
+
+	besu := dashboards[0]
+	assert.Equal(t, "chainlaunch-network-besu-net", besu.UID)
+	assert.Equal(t, "Network besu-net", besu.Title)
+	for _, panel := range besu.Panels {
+		assert.NotContains(t, panel.Targets[0].Expr, "endorser_")
+		assert.Contains(t, panel.Targets[0].Expr, `network=~"(.*,)?besu-net(,.*)?"`)
+		assert.NotContains(t, panel.Targets[0].Expr, "%s")
+	}
+
+	var fabricExprs []string
+	for _, panel := range dashboards[1].Panels {
+		fabricExprs = append(fabricExprs, panel.Targets[0].Expr)
+	}
+	assert.Contains(t, strings.Join(fabricExprs, "\n"), "endorser_proposals_received")
+	assert.NotContains(t, strings.Join(fabricExprs, "\n"), "ethereum_")
+}
+
+func TestNetworkSelectorEscapesRegexp(t *testing.T) {
+	selector := networkSelector("net.1")
+	assert.Equal(t, `network=~"(.*,)?net\\.1(,.*)?"`, selector)
+
+	// Unescape the PromQL string literal and check the resulting regexp
+	pattern := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(selector, `network=~"`), `"`), `\\`, `\`)
+	re := regexp.MustCompile("^" + pattern + "$")
+	assert.True(t, re.MatchString("net.1"))
+	assert.True(t, re.MatchString("a,net.1,b"))
+	assert.False(t, re.MatchString("netx1"))
+	assert.False(t, re.MatchString("net.10"))
+}
+
+func TestNetworkDashboardUIDIsUniqueAndBounded(t *testing.T) {
+	used := make(map[string]bool)
+	long := strings.Repeat("a", 60)
+	first := networkDashboardUID(long, used)
+	second := networkDashboardUID(long+"!", used)
+
+	assert.LessOrEqual(t, len(first), grafanaMaxUIDLength)
+	assert.LessOrEqual(t, len(second), grafanaMaxUIDLength)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, fmt.Sprintf("%s-2", first), second)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/crypto"
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrafanaAdminPasswordEncryption(t *testing.T) {
+	encryptor, err := crypto.NewEncryptor(strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	s := &service{encryptor: encryptor}
+
+	stored, err := s.encryptSecret("grafana-secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, "grafana-secret", stored)
+	assert.True(t, crypto.IsEncrypted(stored))
+
+	config, err := s.grafanaConfigFromDB(&db.GrafanaConfig{DeploymentMode: "docker", AdminUser: "admin", AdminPassword: stored})
+	require.NoError(t, err)
+	assert.Equal(t, "grafana-secret", config.AdminPassword)
+
+	// Passwords stored before encryption was enabled are read as is
+	config, err = s.grafanaConfigFromDB(&db.GrafanaConfig{DeploymentMode: "docker", AdminUser: "admin", AdminPassword: "legacy-password"})
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-password", config.AdminPassword)
+
+	// Without an encryptor the password is stored in plaintext
+	plain, err := (&service{}).encryptSecret("grafana-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "grafana-secret", plain)
+}
@@ -2,10 +2,14 @@ package metrics
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
@@ -47,9 +51,25 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Post("/node/{id}/query", response.Middleware(h.CustomQuery))
 		r.Get("/status", response.Middleware(h.GetStatus))
 		r.Get("/logs", h.TailLogs)
+		r.Get("/alerts/rules", response.Middleware(h.ListAlertRules))
+		r.Post("/alerts/rules", response.Middleware(h.CreateAlertRule))
+		r.Put("/alerts/rules/{id}", response.Middleware(h.UpdateAlertRule))
+		r.Delete("/alerts/rules/{id}", response.Middleware(h.DeleteAlertRule))
+		r.Post("/alertmanager/deploy", response.Middleware(h.DeployAlertmanager))
+		r.Post("/alertmanager/undeploy", response.Middleware(h.UndeployAlertmanager))
+		r.Get("/alertmanager/status", response.Middleware(h.GetAlertmanagerStatus))
+		r.Post("/grafana/deploy", response.Middleware(h.DeployGrafana))
+		r.Post("/grafana/undeploy", response.Middleware(h.UndeployGrafana))
+		r.Get("/grafana/status", response.Middleware(h.GetGrafanaStatus))
+		r.Post("/grafana/dashboards/refresh", response.Middleware(h.RefreshGrafanaDashboards))
 	})
 }
 
+// RegisterPublicRoutes registers the metrics routes that authenticate with their own token
+func (h *Handler) RegisterPublicRoutes(r chi.Router) {
+	r.Post("/metrics/alerts/webhook", response.Middleware(h.AlertWebhook))
+}
+
 // DeployPrometheus deploys a new Prometheus instance
 // @Summary Deploy a new Prometheus instance
 // @Description Deploys a new Prometheus instance with the specified configuration
@@ -546,3 +566,391 @@ func (h *Handler) TailLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// ListAlertRules lists the alerting rules
+// @Summary List alerting rules
+// @Description Lists the built-in and custom Prometheus alerting rules
+// @Tags Metrics
+// @Produce json
+// @Success 200 {array} common.AlertRule
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alerts/rules [get]
+// @ID listAlertRules
+func (h *Handler) ListAlertRules(w http.ResponseWriter, r *http.Request) error {
+	rules, err := h.service.ListAlertRules(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list alert rules", "error", err)
+		return errors.NewInternalError("Failed to list alert rules", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, rules)
+}
+
+// CreateAlertRule creates a custom alerting rule
+// @Summary Create an alerting rule
+// @Description Creates a custom Prometheus alerting rule and reloads Prometheus
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param request body types.AlertRuleRequest true "Alerting rule"
+// @Success 201 {object} common.AlertRule
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alerts/rules [post]
+// @ID createAlertRule
+func (h *Handler) CreateAlertRule(w http.ResponseWriter, r *http.Request) error {
+	rule, err := decodeAlertRuleRequest(r)
+	if err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateAlertRule(r.Context(), rule)
+	if err != nil {
+		h.logger.Error("Failed to create alert rule", "error", err)
+		return errors.NewInternalError("Failed to create alert rule", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusCreated, created)
+}
+
+// UpdateAlertRule updates a custom alerting rule
+// @Summary Update an alerting rule
+// @Description Updates a custom Prometheus alerting rule and reloads Prometheus
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param request body types.AlertRuleRequest true "Alerting rule"
+// @Success 200 {object} common.AlertRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alerts/rules/{id} [put]
+// @ID updateAlertRule
+func (h *Handler) UpdateAlertRule(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return errors.NewValidationError("Invalid rule ID", nil)
+	}
+	rule, err := decodeAlertRuleRequest(r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.service.UpdateAlertRule(r.Context(), id, rule)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewNotFoundError("Alert rule not found", nil)
+		}
+		h.logger.Error("Failed to update alert rule", "error", err)
+		return errors.NewInternalError("Failed to update alert rule", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, updated)
+}
+
+// DeleteAlertRule deletes a custom alerting rule
+// @Summary Delete an alerting rule
+// @Description Deletes a custom Prometheus alerting rule and reloads Prometheus
+// @Tags Metrics
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} types.MessageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alerts/rules/{id} [delete]
+// @ID deleteAlertRule
+func (h *Handler) DeleteAlertRule(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return errors.NewValidationError("Invalid rule ID", nil)
+	}
+
+	if err := h.service.DeleteAlertRule(r.Context(), id); err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.NewNotFoundError("Alert rule not found", nil)
+		}
+		h.logger.Error("Failed to delete alert rule", "error", err)
+		return errors.NewInternalError("Failed to delete alert rule", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, types.MessageResponse{Message: "Alert rule deleted successfully"})
+}
+
+// decodeAlertRuleRequest decodes and validates an alerting rule request body
+func decodeAlertRuleRequest(r *http.Request) (*common.AlertRule, error) {
+	var req types.AlertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.NewValidationError("Invalid request body", nil)
+	}
+
+	rule := &common.AlertRule{
+		Group:       req.Group,
+		Name:        req.Name,
+		Expr:        req.Expr,
+		For:         req.For,
+		Severity:    req.Severity,
+		Summary:     req.Summary,
+		Description: req.Description,
+		Labels:      req.Labels,
+		Enabled:     true,
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if err := validateAlertRule(rule); err != nil {
+		return nil, errors.NewValidationError(err.Error(), nil)
+	}
+	return rule, nil
+}
+
+// DeployAlertmanager deploys Alertmanager
+// @Summary Deploy Alertmanager
+// @Description Deploys Alertmanager, configures it to forward alerts to ChainLaunch and wires it into Prometheus
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param request body types.DeployAlertmanagerRequest true "Alertmanager deployment configuration"
+// @Success 200 {object} types.MessageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alertmanager/deploy [post]
+// @ID deployAlertmanager
+func (h *Handler) DeployAlertmanager(w http.ResponseWriter, r *http.Request) error {
+	var req types.DeployAlertmanagerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("Invalid request body", nil)
+	}
+
+	config := common.DefaultAlertmanagerConfig()
+	if req.AlertmanagerVersion != "" {
+		config.Version = req.AlertmanagerVersion
+	}
+	if req.AlertmanagerPort != 0 {
+		config.Port = req.AlertmanagerPort
+	}
+	if req.DeploymentMode != "" {
+		config.DeploymentMode = req.DeploymentMode
+	}
+	if req.DockerConfig != nil {
+		config.DockerConfig = &common.DockerConfig{
+			NetworkMode: req.DockerConfig.NetworkMode,
+		}
+	}
+	if config.DeploymentMode == common.DeploymentModeService {
+		config.DockerConfig = nil
+	}
+	if req.DefaultRulesEnabled != nil {
+		config.DefaultRulesEnabled = *req.DefaultRulesEnabled
+	}
+
+	config.WebhookURL = req.WebhookURL
+	if config.WebhookURL == "" {
+		config.WebhookURL = defaultAlertWebhookURL(r, config)
+	}
+
+	if err := h.service.DeployAlertmanager(r.Context(), config); err != nil {
+		h.logger.Error("Failed to deploy Alertmanager", "error", err)
+		return errors.NewInternalError("Failed to deploy Alertmanager", err, nil)
+	}
+
+	return response.WriteJSON(w, http.StatusOK, types.MessageResponse{Message: "Alertmanager deployed successfully"})
+}
+
+// defaultAlertWebhookURL derives the webhook URL Alertmanager uses to reach this ChainLaunch instance
+func defaultAlertWebhookURL(r *http.Request, config *common.AlertmanagerConfig) string {
+	host := r.Host
+	port := ""
+	if h, p, err := net.SplitHostPort(r.Host); err == nil {
+		host, port = h, p
+	}
+	// Containers on the bridge network reach the host through host.docker.internal
+	if config.DeploymentMode == common.DeploymentModeDocker &&
+		(config.DockerConfig == nil || config.DockerConfig.NetworkMode != common.NetworkModeHost) {
+		host = "host.docker.internal"
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/v1/metrics/alerts/webhook", scheme, host)
+}
+
+// UndeployAlertmanager undeploys Alertmanager
+// @Summary Undeploy Alertmanager
+// @Description Stops Alertmanager and removes it from the Prometheus configuration
+// @Tags Metrics
+// @Produce json
+// @Success 200 {object} types.MessageResponse
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alertmanager/undeploy [post]
+// @ID undeployAlertmanager
+func (h *Handler) UndeployAlertmanager(w http.ResponseWriter, r *http.Request) error {
+	if err := h.service.UndeployAlertmanager(r.Context()); err != nil {
+		h.logger.Error("Failed to undeploy Alertmanager", "error", err)
+		return errors.NewInternalError("Failed to undeploy Alertmanager", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, types.MessageResponse{Message: "Alertmanager undeployed successfully"})
+}
+
+// GetAlertmanagerStatus returns the Alertmanager status
+// @Summary Get Alertmanager status
+// @Description Returns the current status of the Alertmanager instance
+// @Tags Metrics
+// @Produce json
+// @Success 200 {object} common.Status
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alertmanager/status [get]
+// @ID getAlertmanagerStatus
+func (h *Handler) GetAlertmanagerStatus(w http.ResponseWriter, r *http.Request) error {
+	status, err := h.service.GetAlertmanagerStatus(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get Alertmanager status", "error", err)
+		return errors.NewInternalError("Failed to get Alertmanager status", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, status)
+}
+
+// AlertWebhook receives alerts from Alertmanager
+// @Summary Receive Alertmanager alerts
+// @Description Webhook receiver for Alertmanager. Authenticated with the bearer token generated when Alertmanager was deployed.
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param request body common.AlertWebhookPayload true "Alertmanager webhook payload"
+// @Success 200 {object} types.MessageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/alerts/webhook [post]
+// @ID alertWebhook
+func (h *Handler) AlertWebhook(w http.ResponseWriter, r *http.Request) error {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == r.Header.Get("Authorization") {
+		return errors.NewAuthenticationError("Missing bearer token", nil)
+	}
+
+	var payload common.AlertWebhookPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return errors.NewValidationError("Invalid request body", nil)
+	}
+
+	if err := h.service.HandleAlertWebhook(r.Context(), token, &payload); err != nil {
+		if goerrors.Is(err, ErrInvalidWebhookToken) {
+			return errors.NewAuthenticationError("Invalid webhook token", nil)
+		}
+		h.logger.Error("Failed to handle alert webhook", "error", err)
+		return errors.NewInternalError("Failed to handle alert webhook", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, types.MessageResponse{Message: "Alerts received"})
+}
+
+// DeployGrafana deploys Grafana
+// @Summary Deploy Grafana
+// @Description Deploys Grafana with the Prometheus datasource and the Fabric, Besu, FabricX and per-network dashboards provisioned
+// @Tags Metrics
+// @Accept json
+// @Produce json
+// @Param request body types.DeployGrafanaRequest true "Grafana deployment configuration"
+// @Success 200 {object} common.GrafanaDeployment
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/grafana/deploy [post]
+// @ID deployGrafana
+func (h *Handler) DeployGrafana(w http.ResponseWriter, r *http.Request) error {
+	var req types.DeployGrafanaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("Invalid request body", nil)
+	}
+
+	config := common.DefaultGrafanaConfig()
+	if req.GrafanaVersion != "" {
+		config.Version = req.GrafanaVersion
+	}
+	if req.GrafanaPort != 0 {
+		config.Port = req.GrafanaPort
+	}
+	if req.DeploymentMode != "" {
+		config.DeploymentMode = req.DeploymentMode
+	}
+	if req.DockerConfig != nil {
+		config.DockerConfig = &common.DockerConfig{
+			NetworkMode: req.DockerConfig.NetworkMode,
+		}
+	}
+	if config.DeploymentMode == common.DeploymentModeService {
+		config.DockerConfig = nil
+	}
+	if req.AdminUser != "" {
+		config.AdminUser = req.AdminUser
+	}
+	config.AdminPassword = req.AdminPassword
+
+	deployment, err := h.service.DeployGrafana(r.Context(), config)
+	if err != nil {
+		h.logger.Error("Failed to deploy Grafana", "error", err)
+		return errors.NewInternalError("Failed to deploy Grafana", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, deployment)
+}
+
+// UndeployGrafana undeploys Grafana
+// @Summary Undeploy Grafana
+// @Description Stops Grafana. Its data directory is kept for later deployments.
+// @Tags Metrics
+// @Produce json
+// @Success 200 {object} types.MessageResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/grafana/undeploy [post]
+// @ID undeployGrafana
+func (h *Handler) UndeployGrafana(w http.ResponseWriter, r *http.Request) error {
+	if err := h.service.UndeployGrafana(r.Context()); err != nil {
+		if goerrors.Is(err, ErrGrafanaNotDeployed) {
+			return errors.NewNotFoundError("Grafana is not deployed", nil)
+		}
+		h.logger.Error("Failed to undeploy Grafana", "error", err)
+		return errors.NewInternalError("Failed to undeploy Grafana", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, types.MessageResponse{Message: "Grafana undeployed successfully"})
+}
+
+// GetGrafanaStatus returns the Grafana status
+// @Summary Get Grafana status
+// @Description Returns the current status of the Grafana instance
+// @Tags Metrics
+// @Produce json
+// @Success 200 {object} common.Status
+// @Failure 500 {object} map[string]string
+// @Router /metrics/grafana/status [get]
+// @ID getGrafanaStatus
+func (h *Handler) GetGrafanaStatus(w http.ResponseWriter, r *http.Request) error {
+	status, err := h.service.GetGrafanaStatus(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get Grafana status", "error", err)
+		return errors.NewInternalError("Failed to get Grafana status", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, status)
+}
+
+// RefreshGrafanaDashboards regenerates the Grafana dashboards
+// @Summary Refresh Grafana dashboards
+// @Description Regenerates the provisioned dashboards, including one dashboard per network found in the Prometheus scrape labels
+// @Tags Metrics
+// @Produce json
+// @Success 200 {object} types.GrafanaDashboardsResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /metrics/grafana/dashboards/refresh [post]
+// @ID refreshGrafanaDashboards
+func (h *Handler) RefreshGrafanaDashboards(w http.ResponseWriter, r *http.Request) error {
+	uids, err := h.service.RefreshGrafanaDashboards(r.Context())
+	if err != nil {
+		if goerrors.Is(err, ErrGrafanaNotDeployed) {
+			return errors.NewNotFoundError("Grafana is not deployed", nil)
+		}
+		h.logger.Error("Failed to refresh Grafana dashboards", "error", err)
+		return errors.NewInternalError("Failed to refresh Grafana dashboards", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, types.GrafanaDashboardsResponse{Dashboards: uids})
+}
@@ -0,0 +1,172 @@
+// Package instrumentation exposes Prometheus metrics about ChainLaunch itself
+// (HTTP API latency, background workers, backups and key operations).
+package instrumentation
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "chainlaunch"
+
+// Registry holds every ChainLaunch operational metric. A dedicated registry is used
+// so that metrics registered by third-party libraries are not exposed by accident.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal counts HTTP requests by method, route pattern and status code
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests handled by the API.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes HTTP request latency by method and route pattern
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests handled by the API.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// AuditEventsDropped counts audit events dropped because the queue was full
+	AuditEventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "audit",
+		Name:      "events_dropped_total",
+		Help:      "Total number of audit events dropped because the queue was full.",
+	})
+
+	// MonitoringCheckLag observes how late node health checks run compared to their interval
+	MonitoringCheckLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "monitoring",
+		Name:      "check_lag_seconds",
+		Help:      "Delay between when a node health check was due and when it started.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	})
+
+	// MonitoringCheckDuration observes node health check durations by result
+	MonitoringCheckDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "monitoring",
+		Name:      "check_duration_seconds",
+		Help:      "Duration of node health checks.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"status"})
+
+	// MonitoredNodes reports the number of nodes tracked by the monitoring service
+	MonitoredNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "monitoring",
+		Name:      "nodes",
+		Help:      "Number of nodes tracked by the monitoring service.",
+	})
+
+	// BackupDuration observes backup durations by target type and result
+	BackupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "duration_seconds",
+		Help:      "Duration of backups.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"target_type", "status"})
+
+	// KeySigningOperations counts key management signing operations by operation and result
+	KeySigningOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "keymanagement",
+		Name:      "signing_operations_total",
+		Help:      "Total number of signing operations performed by the key management service.",
+	}, []string{"operation", "status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		AuditEventsDropped,
+		MonitoringCheckLag,
+		MonitoringCheckDuration,
+		MonitoredNodes,
+		BackupDuration,
+		KeySigningOperations,
+	)
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ProtectedHandler returns the metrics handler, answering only requests that carry token as
+// a bearer token
+func ProtectedHandler(token string) http.Handler {
+	handler := Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RegisterAuditQueueDepth exposes the audit queue depth reported by depthFunc
+func RegisterAuditQueueDepth(depthFunc func() int) error {
+	return Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "audit",
+		Name:      "queue_depth",
+		Help:      "Number of audit events waiting to be persisted.",
+	}, func() float64 {
+		return float64(depthFunc())
+	}))
+}
+
+// Status returns the status label used for operations that either succeed or fail
+func Status(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// HTTPMiddleware records request counts and latencies labelled by the chi route pattern,
+// which keeps cardinality bounded regardless of path parameters.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		HTTPRequestsTotal.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,87 @@
+package instrumentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddlewareUsesRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(HTTPMiddleware)
+	r.Get("/nodes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nodes/"+id, nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// All requests share a single series labelled with the route pattern
+	assert.Equal(t, float64(3), testutil.ToFloat64(HTTPRequestsTotal.WithLabelValues("GET", "/nodes/{id}", "404")))
+}
+
+func TestHandlerExposesRegisteredMetrics(t *testing.T) {
+	require.NoError(t, RegisterAuditQueueDepth(func() int { return 7 }))
+	KeySigningOperations.WithLabelValues("sign_data", Status(nil)).Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.True(t, strings.Contains(body, "chainlaunch_audit_queue_depth 7"))
+	assert.True(t, strings.Contains(body, `chainlaunch_keymanagement_signing_operations_total{operation="sign_data",status="success"}`))
+	assert.True(t, strings.Contains(body, "go_goroutines"))
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, "success", Status(nil))
+	assert.Equal(t, "error", Status(errors.New("boom")))
+}
+
+func TestProtectedHandlerRequiresBearerToken(t *testing.T) {
+	handler := ProtectedHandler("secret")
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no header", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer other", wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic secret", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", authorization: "Bearer secret", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="metrics"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestProtectedHandlerRejectsEmptyToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	ProtectedHandler("").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
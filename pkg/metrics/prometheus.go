@@ -442,8 +442,10 @@ func (d *DockerPrometheusDeployer) waitForPrometheusActive(ctx context.Context)
 
 // PrometheusConfig represents the Prometheus configuration structure
 type PrometheusConfig struct {
-	Global        GlobalConfig   `yaml:"global"`
-	ScrapeConfigs []ScrapeConfig `yaml:"scrape_configs"`
+	Global        GlobalConfig    `yaml:"global"`
+	RuleFiles     []string        `yaml:"rule_files,omitempty"`
+	Alerting      *AlertingConfig `yaml:"alerting,omitempty"`
+	ScrapeConfigs []ScrapeConfig  `yaml:"scrape_configs"`
 }
 
 // GlobalConfig represents the global Prometheus configuration
@@ -453,13 +455,49 @@ type GlobalConfig struct {
 
 // ScrapeConfig represents a Prometheus scrape configuration
 type ScrapeConfig struct {
-	JobName       string         `yaml:"job_name"`
-	StaticConfigs []StaticConfig `yaml:"static_configs"`
+	JobName       string               `yaml:"job_name"`
+	Scheme        string               `yaml:"scheme,omitempty"`
+	TLSConfig     *ScrapeTLSConfig     `yaml:"tls_config,omitempty"`
+	Authorization *ScrapeAuthorization `yaml:"authorization,omitempty"`
+	StaticConfigs []StaticConfig       `yaml:"static_configs"`
+}
+
+// ScrapeAuthorization represents the Authorization header sent by a scrape job
+type ScrapeAuthorization struct {
+	Type        string `yaml:"type"`
+	Credentials string `yaml:"credentials"`
+}
+
+// ScrapeTLSConfig represents the TLS configuration of a scrape job
+type ScrapeTLSConfig struct {
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
 }
 
 // StaticConfig represents a static target configuration
 type StaticConfig struct {
-	Targets []string `yaml:"targets"`
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+}
+
+// selfScrapeConfig returns the scrape configuration for ChainLaunch's own /metrics endpoint,
+// or nil when the port is unknown
+func selfScrapeConfig(config *common.Config, host string) *ScrapeConfig {
+	if config.SelfMetricsPort == 0 {
+		return nil
+	}
+	scrapeConfig := &ScrapeConfig{
+		JobName:       "chainlaunch",
+		StaticConfigs: []StaticConfig{{Targets: []string{fmt.Sprintf("%s:%d", host, config.SelfMetricsPort)}}},
+	}
+	if config.SelfMetricsTLS {
+		// ChainLaunch is scraped on the local host, commonly with a self-signed certificate
+		scrapeConfig.Scheme = "https"
+		scrapeConfig.TLSConfig = &ScrapeTLSConfig{InsecureSkipVerify: true}
+	}
+	if config.SelfMetricsToken != "" {
+		scrapeConfig.Authorization = &ScrapeAuthorization{Type: "Bearer", Credentials: config.SelfMetricsToken}
+	}
+	return scrapeConfig
 }
 
 // PeerNode represents a peer node in the system
@@ -467,6 +505,7 @@ type PeerNode struct {
 	ID               string
 	Name             string
 	OperationAddress string
+	Labels           map[string]string
 }
 
 // getPeerNodes retrieves peer nodes from the database
@@ -509,6 +548,7 @@ func (d *DockerPrometheusDeployer) getPeerNodes(ctx context.Context) ([]PeerNode
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
@@ -556,6 +596,7 @@ func (d *DockerPrometheusDeployer) getOrdererNodes(ctx context.Context) ([]PeerN
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
@@ -603,12 +644,27 @@ func (d *DockerPrometheusDeployer) getBesuNodes(ctx context.Context) ([]PeerNode
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
 	return besuNodes, nil
 }
 
+// getFabricXNodes retrieves the FabricX role nodes that expose a monitoring port
+func (d *DockerPrometheusDeployer) getFabricXNodes(ctx context.Context) ([]PeerNode, error) {
+	nodes, err := d.nodeService.GetAllNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	host := "host.docker.internal"
+	if d.config.DockerConfig.NetworkMode == common.NetworkModeHost {
+		host = "localhost"
+	}
+	return fabricXNodes(nodes.Items, host), nil
+}
+
 // buildPrometheusConfig builds the Prometheus YAML config from current state (peers, orderers, besu, etc.)
 func (d *DockerPrometheusDeployer) buildPrometheusConfig(ctx context.Context) (string, error) {
 	// Get peer, orderer, and besu nodes
@@ -624,6 +680,14 @@ func (d *DockerPrometheusDeployer) buildPrometheusConfig(ctx context.Context) (s
 	if err != nil {
 		return "", fmt.Errorf("failed to get Besu nodes: %w", err)
 	}
+	fabricxNodes, err := d.getFabricXNodes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get FabricX nodes: %w", err)
+	}
+	networks, err := loadNodeNetworks(ctx, d.db)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node networks: %w", err)
+	}
 
 	config := &PrometheusConfig{
 		Global: GlobalConfig{
@@ -636,29 +700,35 @@ func (d *DockerPrometheusDeployer) buildPrometheusConfig(ctx context.Context) (s
 			},
 		},
 	}
+	// Add ChainLaunch's own metrics endpoint
+	selfHost := "host.docker.internal"
+	if d.config.DockerConfig.NetworkMode == common.NetworkModeHost {
+		selfHost = "localhost"
+	}
+	if selfConfig := selfScrapeConfig(d.config, selfHost); selfConfig != nil {
+		config.ScrapeConfigs = append(config.ScrapeConfigs, *selfConfig)
+	}
 	// Add peer node targets
 	for _, node := range peerNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
 	}
 	// Add orderer node targets
 	for _, node := range ordererNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
 	}
 	// Add Besu node targets
 	for _, node := range besuNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
+	}
+	// Add FabricX role targets
+	for _, node := range fabricxNodes {
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
+	}
+	// Add alerting rules and Alertmanager target; the config directory is mounted at /etc/prometheus
+	useDockerHost := d.config.DockerConfig.NetworkMode != common.NetworkModeHost
+	rulesPath := "/etc/prometheus/rules/" + alertRulesFileName
+	if err := applyAlertingConfig(ctx, d.db, config, filepath.Join(d.configDir, "rules"), rulesPath, useDockerHost); err != nil {
+		return "", fmt.Errorf("failed to apply alerting config: %w", err)
 	}
 	configData, err := yaml.Marshal(config)
 	if err != nil {
@@ -1178,12 +1248,12 @@ func (s *ServicePrometheusDeployer) downloadPrometheus() error {
 
 	// Download the archive
 	archivePath := filepath.Join(tmpDir, "prometheus.tar.gz")
-	if err := s.downloadFile(downloadURL, archivePath); err != nil {
+	if err := downloadFile(downloadURL, archivePath); err != nil {
 		return fmt.Errorf("failed to download Prometheus: %w", err)
 	}
 
 	// Extract the archive
-	if err := s.extractTarGz(archivePath, tmpDir); err != nil {
+	if err := extractTarGz(archivePath, tmpDir); err != nil {
 		return fmt.Errorf("failed to extract Prometheus archive: %w", err)
 	}
 
@@ -1211,7 +1281,7 @@ func (s *ServicePrometheusDeployer) downloadPrometheus() error {
 		srcBinary += ".exe"
 	}
 
-	if err := s.copyFile(srcBinary, prometheusBin); err != nil {
+	if err := copyFile(srcBinary, prometheusBin); err != nil {
 		return fmt.Errorf("failed to copy Prometheus binary: %w", err)
 	}
 
@@ -1241,7 +1311,7 @@ func (s *ServicePrometheusDeployer) downloadPrometheus() error {
 }
 
 // downloadFile downloads a file from a URL to a local path
-func (s *ServicePrometheusDeployer) downloadFile(url, destPath string) error {
+func downloadFile(url, destPath string) error {
 	resp, err := http.Get(url)
 	if err != nil {
 		return fmt.Errorf("failed to download file: %w", err)
@@ -1267,7 +1337,7 @@ func (s *ServicePrometheusDeployer) downloadFile(url, destPath string) error {
 }
 
 // extractTarGz extracts a tar.gz file to a destination directory
-func (s *ServicePrometheusDeployer) extractTarGz(archivePath, destDir string) error {
+func extractTarGz(archivePath, destDir string) error {
 	file, err := os.Open(archivePath)
 	if err != nil {
 		return fmt.Errorf("failed to open archive: %w", err)
@@ -1333,7 +1403,7 @@ func (s *ServicePrometheusDeployer) extractTarGz(archivePath, destDir string) er
 }
 
 // copyFile copies a single file from src to dst
-func (s *ServicePrometheusDeployer) copyFile(src, dst string) error {
+func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
 	if err != nil {
 		return fmt.Errorf("failed to open source file: %w", err)
@@ -1586,6 +1656,14 @@ func (s *ServicePrometheusDeployer) buildPrometheusConfig(ctx context.Context) (
 	if err != nil {
 		return "", fmt.Errorf("failed to get Besu nodes: %w", err)
 	}
+	fabricxNodes, err := s.getFabricXNodes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get FabricX nodes: %w", err)
+	}
+	networks, err := loadNodeNetworks(ctx, s.db)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node networks: %w", err)
+	}
 
 	config := &PrometheusConfig{
 		Global: GlobalConfig{
@@ -1599,31 +1677,35 @@ func (s *ServicePrometheusDeployer) buildPrometheusConfig(ctx context.Context) (
 		},
 	}
 
+	// Add ChainLaunch's own metrics endpoint
+	if selfConfig := selfScrapeConfig(s.config, "localhost"); selfConfig != nil {
+		config.ScrapeConfigs = append(config.ScrapeConfigs, *selfConfig)
+	}
+
 	// Add peer node targets
 	for _, node := range peerNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
 	}
 
 	// Add orderer node targets
 	for _, node := range ordererNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
 	}
 
 	// Add Besu node targets
 	for _, node := range besuNodes {
-		jobName := slugify(fmt.Sprintf("%s-%s", node.ID, node.Name))
-		config.ScrapeConfigs = append(config.ScrapeConfigs, ScrapeConfig{
-			JobName:       jobName,
-			StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}}},
-		})
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
+	}
+
+	// Add FabricX role targets
+	for _, node := range fabricxNodes {
+		config.ScrapeConfigs = append(config.ScrapeConfigs, nodeScrapeConfig(node, networks))
+	}
+
+	// Add alerting rules and Alertmanager target
+	rulesDir := filepath.Join(s.configDir, "rules")
+	if err := applyAlertingConfig(ctx, s.db, config, rulesDir, filepath.Join(rulesDir, alertRulesFileName), false); err != nil {
+		return "", fmt.Errorf("failed to apply alerting config: %w", err)
 	}
 
 	configData, err := yaml.Marshal(config)
@@ -1666,6 +1748,7 @@ func (s *ServicePrometheusDeployer) getPeerNodes(ctx context.Context) ([]PeerNod
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
@@ -1706,6 +1789,7 @@ func (s *ServicePrometheusDeployer) getOrdererNodes(ctx context.Context) ([]Peer
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
@@ -1745,12 +1829,22 @@ func (s *ServicePrometheusDeployer) getBesuNodes(ctx context.Context) ([]PeerNod
 			ID:               strconv.FormatInt(node.ID, 10),
 			Name:             node.Name,
 			OperationAddress: formattedAddress,
+			Labels:           nodeScrapeLabels(node),
 		})
 	}
 
 	return besuNodes, nil
 }
 
+// getFabricXNodes retrieves the FabricX role nodes that expose a monitoring port (service version)
+func (s *ServicePrometheusDeployer) getFabricXNodes(ctx context.Context) ([]PeerNode, error) {
+	nodes, err := s.nodeService.GetAllNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+	return fabricXNodes(nodes.Items, "localhost"), nil
+}
+
 // updateDatabaseConfig updates the prometheus_config table with the current configuration
 func (s *ServicePrometheusDeployer) updateDatabaseConfig(ctx context.Context) error {
 	// Get current config from the file
@@ -1926,17 +2020,23 @@ func (s *ServicePrometheusDeployer) getLaunchdPlistPathForPort(port int) string
 
 // PrometheusManager handles the lifecycle of a Prometheus instance
 type PrometheusManager struct {
-	db            *db.Queries
-	nodeService   *nodeservice.NodeService
-	configService *configservice.ConfigService
+	db               *db.Queries
+	nodeService      *nodeservice.NodeService
+	configService    *configservice.ConfigService
+	selfMetricsPort  int
+	selfMetricsTLS   bool
+	selfMetricsToken string
 }
 
 // NewPrometheusManager creates a new PrometheusManager
 func NewPrometheusManager(config *common.Config, db *db.Queries, nodeService *nodeservice.NodeService, configService *configservice.ConfigService) (*PrometheusManager, error) {
 	pm := &PrometheusManager{
-		db:            db,
-		nodeService:   nodeService,
-		configService: configService,
+		db:               db,
+		nodeService:      nodeService,
+		configService:    configService,
+		selfMetricsPort:  config.SelfMetricsPort,
+		selfMetricsTLS:   config.SelfMetricsTLS,
+		selfMetricsToken: config.SelfMetricsToken,
 	}
 
 	return pm, nil
@@ -1944,6 +2044,12 @@ func NewPrometheusManager(config *common.Config, db *db.Queries, nodeService *no
 
 // createDeployer creates a deployer from the given config
 func (pm *PrometheusManager) createDeployer(config *common.Config) (PrometheusDeployer, error) {
+	// Configurations loaded from the database or API requests don't carry the ChainLaunch endpoint
+	if config.SelfMetricsPort == 0 {
+		config.SelfMetricsPort = pm.selfMetricsPort
+		config.SelfMetricsTLS = pm.selfMetricsTLS
+		config.SelfMetricsToken = pm.selfMetricsToken
+	}
 	switch config.DeploymentMode {
 	case common.DeploymentModeDocker:
 		return NewDockerPrometheusDeployer(config, pm.db, pm.nodeService, pm.configService)
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSelfScrapeConfig(t *testing.T) {
+	assert.Nil(t, selfScrapeConfig(&common.Config{}, "localhost"))
+
+	public := selfScrapeConfig(&common.Config{SelfMetricsPort: 8100}, "localhost")
+	require.NotNil(t, public)
+	assert.Nil(t, public.Authorization)
+	assert.Equal(t, []string{"localhost:8100"}, public.StaticConfigs[0].Targets)
+
+	protected := selfScrapeConfig(&common.Config{SelfMetricsPort: 8100, SelfMetricsTLS: true, SelfMetricsToken: "secret"}, "host.docker.internal")
+	require.NotNil(t, protected)
+	assert.Equal(t, "https", protected.Scheme)
+	require.NotNil(t, protected.Authorization)
+
+	out, err := yaml.Marshal(protected)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "authorization:\n  type: Bearer\n  credentials: secret\n")
+}
@@ -2,6 +2,12 @@ package metrics
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,29 +17,38 @@ import (
 	"os/exec"
 
 	configservice "github.com/chainlaunch/chainlaunch/pkg/config"
+	"github.com/chainlaunch/chainlaunch/pkg/crypto"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	"github.com/chainlaunch/chainlaunch/pkg/metrics/common"
 	"github.com/chainlaunch/chainlaunch/pkg/metrics/types"
 	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/chainlaunch/chainlaunch/pkg/notifications"
 )
 
 // service implements the Service interface
 type service struct {
-	manager     *PrometheusManager
-	nodeService *nodeservice.NodeService
-	db          *db.Queries
+	manager             *PrometheusManager
+	nodeService         *nodeservice.NodeService
+	db                  *db.Queries
+	configService       *configservice.ConfigService
+	notificationService notifications.Service
+	encryptor           *crypto.Encryptor
 }
 
-// NewService creates a new metrics service
-func NewService(config *common.Config, db *db.Queries, nodeService *nodeservice.NodeService, configService *configservice.ConfigService) (common.Service, error) {
+// NewService creates a new metrics service. The encryptor is used to encrypt the
+// Grafana admin password at rest; pass nil to store it in plaintext.
+func NewService(config *common.Config, db *db.Queries, nodeService *nodeservice.NodeService, configService *configservice.ConfigService, notificationService notifications.Service, encryptor *crypto.Encryptor) (common.Service, error) {
 	manager, err := NewPrometheusManager(config, db, nodeService, configService)
 	if err != nil {
 		return nil, err
 	}
 	return &service{
-		manager:     manager,
-		nodeService: nodeService,
-		db:          db,
+		manager:             manager,
+		nodeService:         nodeService,
+		db:                  db,
+		configService:       configService,
+		notificationService: notificationService,
+		encryptor:           encryptor,
 	}, nil
 }
 
@@ -54,7 +69,14 @@ func (s *service) GetStatus(ctx context.Context) (*common.Status, error) {
 
 // Reload reloads the Prometheus configuration
 func (s *service) Reload(ctx context.Context) error {
-	return s.manager.Reload(ctx)
+	if err := s.manager.Reload(ctx); err != nil {
+		return err
+	}
+	// Keep the per-network dashboards in line with the new scrape targets
+	if _, err := s.RefreshGrafanaDashboards(ctx); err != nil && !errors.Is(err, ErrGrafanaNotDeployed) {
+		return fmt.Errorf("failed to refresh Grafana dashboards: %w", err)
+	}
+	return nil
 }
 
 // Query executes a PromQL query against Prometheus
@@ -389,3 +411,608 @@ func (s *service) TailLogs(ctx context.Context, tail int, follow bool) (<-chan s
 	// Use the deployer's TailLogs method
 	return deployer.TailLogs(ctx, tail, follow)
 }
+
+// ListAlertRules returns the built-in and custom alerting rules
+func (s *service) ListAlertRules(ctx context.Context) ([]common.AlertRule, error) {
+	return loadAlertRules(ctx, s.db)
+}
+
+// CreateAlertRule creates a custom alerting rule and reloads Prometheus
+func (s *service) CreateAlertRule(ctx context.Context, rule *common.AlertRule) (*common.AlertRule, error) {
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+	labels, err := marshalAlertLabels(rule.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.CreatePrometheusAlertRule(ctx, &db.CreatePrometheusAlertRuleParams{
+		GroupName:   rule.Group,
+		Name:        rule.Name,
+		Expr:        rule.Expr,
+		ForDuration: sql.NullString{String: rule.For, Valid: rule.For != ""},
+		Severity:    rule.Severity,
+		Summary:     sql.NullString{String: rule.Summary, Valid: rule.Summary != ""},
+		Description: sql.NullString{String: rule.Description, Valid: rule.Description != ""},
+		Labels:      labels,
+		Enabled:     rule.Enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	if err := s.reloadIfRunning(ctx); err != nil {
+		return nil, err
+	}
+	return alertRuleFromDB(created)
+}
+
+// UpdateAlertRule updates a custom alerting rule and reloads Prometheus
+func (s *service) UpdateAlertRule(ctx context.Context, id int64, rule *common.AlertRule) (*common.AlertRule, error) {
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+	labels, err := marshalAlertLabels(rule.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.UpdatePrometheusAlertRule(ctx, &db.UpdatePrometheusAlertRuleParams{
+		GroupName:   rule.Group,
+		Name:        rule.Name,
+		Expr:        rule.Expr,
+		ForDuration: sql.NullString{String: rule.For, Valid: rule.For != ""},
+		Severity:    rule.Severity,
+		Summary:     sql.NullString{String: rule.Summary, Valid: rule.Summary != ""},
+		Description: sql.NullString{String: rule.Description, Valid: rule.Description != ""},
+		Labels:      labels,
+		Enabled:     rule.Enabled,
+		ID:          id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	if err := s.reloadIfRunning(ctx); err != nil {
+		return nil, err
+	}
+	return alertRuleFromDB(updated)
+}
+
+// DeleteAlertRule deletes a custom alerting rule and reloads Prometheus
+func (s *service) DeleteAlertRule(ctx context.Context, id int64) error {
+	if _, err := s.db.GetPrometheusAlertRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to get alert rule: %w", err)
+	}
+	if err := s.db.DeletePrometheusAlertRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return s.reloadIfRunning(ctx)
+}
+
+// marshalAlertLabels encodes rule labels for storage
+func marshalAlertLabels(labels map[string]string) (sql.NullString, error) {
+	if len(labels) == 0 {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal labels: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// reloadIfRunning regenerates the Prometheus configuration when Prometheus is running
+func (s *service) reloadIfRunning(ctx context.Context) error {
+	status, err := s.manager.GetStatus(ctx)
+	if err != nil || status.Status != "running" {
+		return nil
+	}
+	if err := s.manager.Reload(ctx); err != nil {
+		return fmt.Errorf("failed to reload Prometheus: %w", err)
+	}
+	return nil
+}
+
+// createAlertmanagerDeployer creates the deployer matching the configured deployment mode
+func (s *service) createAlertmanagerDeployer(config *common.AlertmanagerConfig) (AlertmanagerDeployer, error) {
+	switch config.DeploymentMode {
+	case common.DeploymentModeService:
+		return NewServiceAlertmanagerDeployer(config, s.configService), nil
+	case common.DeploymentModeDocker, "":
+		return NewDockerAlertmanagerDeployer(config, s.configService)
+	default:
+		return nil, fmt.Errorf("unsupported deployment mode: %s", config.DeploymentMode)
+	}
+}
+
+// alertmanagerConfigFromDB converts the stored Alertmanager configuration
+func alertmanagerConfigFromDB(dbConfig *db.AlertmanagerConfig) *common.AlertmanagerConfig {
+	config := &common.AlertmanagerConfig{
+		Version:             dbConfig.AlertmanagerVersion,
+		Port:                int(dbConfig.AlertmanagerPort),
+		DeploymentMode:      common.DeploymentMode(dbConfig.DeploymentMode),
+		WebhookURL:          dbConfig.WebhookUrl,
+		DefaultRulesEnabled: dbConfig.DefaultRulesEnabled,
+	}
+	if dbConfig.NetworkMode.Valid {
+		config.DockerConfig = &common.DockerConfig{
+			NetworkMode: common.NetworkMode(dbConfig.NetworkMode.String),
+		}
+	}
+	return config
+}
+
+// DeployAlertmanager deploys Alertmanager and wires it into Prometheus
+func (s *service) DeployAlertmanager(ctx context.Context, config *common.AlertmanagerConfig) error {
+	if config.WebhookURL == "" {
+		return fmt.Errorf("webhook URL is required")
+	}
+	defaults := common.DefaultAlertmanagerConfig()
+	if config.Version == "" {
+		config.Version = defaults.Version
+	}
+	if config.Port == 0 {
+		config.Port = defaults.Port
+	}
+	if config.DeploymentMode == "" {
+		config.DeploymentMode = defaults.DeploymentMode
+	}
+	if config.DeploymentMode == common.DeploymentModeDocker && config.DockerConfig == nil {
+		config.DockerConfig = defaults.DockerConfig
+	}
+
+	// Stop the previous instance since the port or deployment mode may have changed. It stays
+	// in the database until the new instance is up, so it can be restarted if that fails.
+	existing, err := s.db.GetAlertmanagerConfig(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+	hasExisting := err == nil
+	var previous AlertmanagerDeployer
+	if hasExisting {
+		if previous, err = s.createAlertmanagerDeployer(alertmanagerConfigFromDB(existing)); err == nil {
+			_ = previous.Stop(ctx)
+		} else {
+			previous = nil
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return s.restoreAlertmanager(ctx, nil, previous, existing, fmt.Errorf("failed to generate webhook token: %w", err))
+	}
+	webhookToken := hex.EncodeToString(tokenBytes)
+
+	deployer, err := s.createAlertmanagerDeployer(config)
+	if err != nil {
+		return s.restoreAlertmanager(ctx, nil, previous, existing, err)
+	}
+	if err := deployer.Start(ctx, webhookToken); err != nil {
+		return s.restoreAlertmanager(ctx, deployer, previous, existing, fmt.Errorf("failed to start Alertmanager: %w", err))
+	}
+
+	networkMode := sql.NullString{}
+	if config.DockerConfig != nil {
+		networkMode = sql.NullString{String: string(config.DockerConfig.NetworkMode), Valid: true}
+	}
+	err = s.db.ExecTx(ctx, func(q *db.Queries) error {
+		if hasExisting {
+			if err := q.DeleteAlertmanagerConfig(ctx); err != nil {
+				return fmt.Errorf("failed to delete previous alertmanager config: %w", err)
+			}
+		}
+		if _, err := q.CreateAlertmanagerConfig(ctx, &db.CreateAlertmanagerConfigParams{
+			AlertmanagerPort:    int64(config.Port),
+			AlertmanagerVersion: config.Version,
+			DeploymentMode:      string(config.DeploymentMode),
+			NetworkMode:         networkMode,
+			WebhookUrl:          config.WebhookURL,
+			WebhookToken:        webhookToken,
+			DefaultRulesEnabled: config.DefaultRulesEnabled,
+		}); err != nil {
+			return fmt.Errorf("failed to save alertmanager config: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return s.restoreAlertmanager(ctx, deployer, previous, existing, err)
+	}
+
+	return s.reloadIfRunning(ctx)
+}
+
+// restoreAlertmanager stops a failed Alertmanager deployment and restarts the previous one, whose
+// configuration is still the one stored in the database. It returns cause.
+func (s *service) restoreAlertmanager(ctx context.Context, failed, previous AlertmanagerDeployer, existing *db.AlertmanagerConfig, cause error) error {
+	if failed != nil {
+		_ = failed.Stop(ctx)
+	}
+	if previous == nil {
+		return cause
+	}
+	if err := previous.Start(ctx, existing.WebhookToken); err != nil {
+		return fmt.Errorf("%w (failed to restart the previous Alertmanager: %v)", cause, err)
+	}
+	return cause
+}
+
+// UndeployAlertmanager stops Alertmanager and removes it from Prometheus
+func (s *service) UndeployAlertmanager(ctx context.Context) error {
+	existing, err := s.db.GetAlertmanagerConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("alertmanager is not deployed")
+		}
+		return fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+
+	deployer, err := s.createAlertmanagerDeployer(alertmanagerConfigFromDB(existing))
+	if err != nil {
+		return err
+	}
+	if err := deployer.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop Alertmanager: %w", err)
+	}
+
+	if err := s.db.DeleteAlertmanagerConfig(ctx); err != nil {
+		return fmt.Errorf("failed to delete alertmanager config: %w", err)
+	}
+
+	return s.reloadIfRunning(ctx)
+}
+
+// GetAlertmanagerStatus returns the current status of the Alertmanager instance
+func (s *service) GetAlertmanagerStatus(ctx context.Context) (*common.Status, error) {
+	existing, err := s.db.GetAlertmanagerConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &common.Status{Status: "not_deployed"}, nil
+		}
+		return nil, fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+
+	config := alertmanagerConfigFromDB(existing)
+	status := &common.Status{
+		Version:        config.Version,
+		Port:           config.Port,
+		DeploymentMode: config.DeploymentMode,
+	}
+	if config.DockerConfig != nil {
+		status.NetworkMode = config.DockerConfig.NetworkMode
+	}
+	startedAt := existing.CreatedAt
+	status.StartedAt = &startedAt
+
+	deployer, err := s.createAlertmanagerDeployer(config)
+	if err != nil {
+		status.Status = "error"
+		status.Error = err.Error()
+		return status, nil
+	}
+	state, err := deployer.GetStatus(ctx)
+	if err != nil {
+		status.Status = "error"
+		status.Error = err.Error()
+		return status, nil
+	}
+	status.Status = state
+	return status, nil
+}
+
+// HandleAlertWebhook forwards alerts received from Alertmanager to the notification providers
+func (s *service) HandleAlertWebhook(ctx context.Context, token string, payload *common.AlertWebhookPayload) error {
+	existing, err := s.db.GetAlertmanagerConfig(ctx)
+	if err != nil {
+		// Without Alertmanager no token was issued, so none can be valid
+		if err == sql.ErrNoRows {
+			return ErrInvalidWebhookToken
+		}
+		return fmt.Errorf("failed to get alertmanager config: %w", err)
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(existing.WebhookToken)) != 1 {
+		return ErrInvalidWebhookToken
+	}
+
+	if s.notificationService == nil {
+		return nil
+	}
+	for _, alert := range payload.Alerts {
+		data := notifications.AlertData{
+			AlertName:    alert.Labels["alertname"],
+			Status:       alert.Status,
+			Severity:     alert.Labels["severity"],
+			Summary:      alert.Annotations["summary"],
+			Description:  alert.Annotations["description"],
+			Labels:       alert.Labels,
+			StartsAt:     alert.StartsAt,
+			EndsAt:       alert.EndsAt,
+			GeneratorURL: alert.GeneratorURL,
+		}
+		if err := s.notificationService.SendAlertNotification(ctx, data); err != nil {
+			return fmt.Errorf("failed to send alert notification: %w", err)
+		}
+	}
+	return nil
+}
+
+// createGrafanaDeployer creates the deployer matching the configured deployment mode
+func (s *service) createGrafanaDeployer(config *common.GrafanaConfig) (GrafanaDeployer, error) {
+	switch config.DeploymentMode {
+	case common.DeploymentModeService:
+		return NewServiceGrafanaDeployer(config, s.configService), nil
+	case common.DeploymentModeDocker, "":
+		return NewDockerGrafanaDeployer(config, s.configService)
+	default:
+		return nil, fmt.Errorf("unsupported deployment mode: %s", config.DeploymentMode)
+	}
+}
+
+// grafanaConfigFromDB converts the stored Grafana configuration, decrypting the
+// admin password
+func (s *service) grafanaConfigFromDB(dbConfig *db.GrafanaConfig) (*common.GrafanaConfig, error) {
+	adminPassword, err := s.decryptSecret(dbConfig.AdminPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt grafana admin password: %w", err)
+	}
+	config := &common.GrafanaConfig{
+		Version:        dbConfig.GrafanaVersion,
+		Port:           int(dbConfig.GrafanaPort),
+		DeploymentMode: common.DeploymentMode(dbConfig.DeploymentMode),
+		AdminUser:      dbConfig.AdminUser,
+		AdminPassword:  adminPassword,
+	}
+	if dbConfig.NetworkMode.Valid {
+		config.DockerConfig = &common.DockerConfig{
+			NetworkMode: common.NetworkMode(dbConfig.NetworkMode.String),
+		}
+	}
+	return config, nil
+}
+
+// encryptSecret encrypts a secret string if an encryptor is configured
+func (s *service) encryptSecret(secret string) (string, error) {
+	if s.encryptor == nil || secret == "" {
+		return secret, nil
+	}
+	return s.encryptor.Encrypt(secret)
+}
+
+// decryptSecret decrypts a secret string if an encryptor is configured. Plaintext
+// values stored before encryption was enabled are returned as is.
+func (s *service) decryptSecret(secret string) (string, error) {
+	if s.encryptor == nil || secret == "" || !crypto.IsEncrypted(secret) {
+		return secret, nil
+	}
+	return s.encryptor.Decrypt(secret)
+}
+
+// grafanaPrometheusURL returns the Prometheus URL as reached from Grafana
+func grafanaPrometheusURL(config *common.GrafanaConfig, prometheusPort int64) string {
+	host := "localhost"
+	if config.DeploymentMode != common.DeploymentModeService &&
+		(config.DockerConfig == nil || config.DockerConfig.NetworkMode != common.NetworkModeHost) {
+		host = "host.docker.internal"
+	}
+	return fmt.Sprintf("http://%s:%d", host, prometheusPort)
+}
+
+// grafanaDashboards returns the platform dashboards plus one dashboard per network
+// labelled in the rendered Prometheus scrape configuration
+func (s *service) grafanaDashboards(ctx context.Context) ([]grafanaDashboard, error) {
+	dashboards := defaultDashboards()
+	promConfig, err := s.db.GetPrometheusConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dashboards, nil
+		}
+		return nil, fmt.Errorf("failed to get prometheus config: %w", err)
+	}
+	configData, err := os.ReadFile(filepath.Join(promConfig.ConfigDir, "prometheus.yml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dashboards, nil
+		}
+		return nil, fmt.Errorf("failed to read Prometheus config: %w", err)
+	}
+	networks, err := scrapeNetworks(configData)
+	if err != nil {
+		return nil, err
+	}
+	return append(dashboards, networkDashboards(networks)...), nil
+}
+
+// DeployGrafana deploys Grafana with the Prometheus datasource and ChainLaunch dashboards provisioned
+func (s *service) DeployGrafana(ctx context.Context, config *common.GrafanaConfig) (*common.GrafanaDeployment, error) {
+	defaults := common.DefaultGrafanaConfig()
+	if config.Version == "" {
+		config.Version = defaults.Version
+	}
+	if config.Port == 0 {
+		config.Port = defaults.Port
+	}
+	if config.DeploymentMode == "" {
+		config.DeploymentMode = defaults.DeploymentMode
+	}
+	if config.DeploymentMode == common.DeploymentModeDocker && config.DockerConfig == nil {
+		config.DockerConfig = defaults.DockerConfig
+	}
+	if config.AdminUser == "" {
+		config.AdminUser = defaults.AdminUser
+	}
+
+	promConfig, err := s.db.GetPrometheusConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("prometheus must be deployed before Grafana")
+		}
+		return nil, fmt.Errorf("failed to get prometheus config: %w", err)
+	}
+
+	// Stop the previous instance since the port or deployment mode may have changed
+	existing, err := s.db.GetGrafanaConfig(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get grafana config: %w", err)
+	}
+	hasExisting := err == nil
+	var previousConfig *common.GrafanaConfig
+	if hasExisting {
+		previousConfig, err = s.grafanaConfigFromDB(existing)
+		if err != nil {
+			return nil, err
+		}
+		if previous, err := s.createGrafanaDeployer(previousConfig); err == nil {
+			_ = previous.Stop(ctx)
+		}
+	}
+
+	// Grafana only applies the admin password when it initialises its database,
+	// so keep the stored one unless a new password is given
+	if config.AdminPassword == "" {
+		if hasExisting {
+			config.AdminPassword = previousConfig.AdminPassword
+		} else {
+			passwordBytes := make([]byte, 16)
+			if _, err := rand.Read(passwordBytes); err != nil {
+				return nil, fmt.Errorf("failed to generate admin password: %w", err)
+			}
+			config.AdminPassword = hex.EncodeToString(passwordBytes)
+		}
+	}
+
+	deployer, err := s.createGrafanaDeployer(config)
+	if err != nil {
+		return nil, err
+	}
+	dirs := newGrafanaDirs(s.configService)
+	if err := writeGrafanaProvisioning(dirs, grafanaPrometheusURL(config, promConfig.PrometheusPort), deployer.DashboardsPath()); err != nil {
+		return nil, fmt.Errorf("failed to write Grafana provisioning: %w", err)
+	}
+	dashboards, err := s.grafanaDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uids, err := writeGrafanaDashboards(dirs.dashboards, dashboards)
+	if err != nil {
+		return nil, err
+	}
+	if err := deployer.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start Grafana: %w", err)
+	}
+
+	adminPassword, err := s.encryptSecret(config.AdminPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt grafana admin password: %w", err)
+	}
+	networkMode := sql.NullString{}
+	if config.DockerConfig != nil {
+		networkMode = sql.NullString{String: string(config.DockerConfig.NetworkMode), Valid: true}
+	}
+	if hasExisting {
+		if err := s.db.DeleteGrafanaConfig(ctx); err != nil {
+			return nil, fmt.Errorf("failed to delete previous grafana config: %w", err)
+		}
+	}
+	if _, err := s.db.CreateGrafanaConfig(ctx, &db.CreateGrafanaConfigParams{
+		GrafanaPort:    int64(config.Port),
+		GrafanaVersion: config.Version,
+		DeploymentMode: string(config.DeploymentMode),
+		NetworkMode:    networkMode,
+		AdminUser:      config.AdminUser,
+		AdminPassword:  adminPassword,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save grafana config: %w", err)
+	}
+
+	return &common.GrafanaDeployment{
+		URL:           fmt.Sprintf("http://localhost:%d", config.Port),
+		AdminUser:     config.AdminUser,
+		AdminPassword: config.AdminPassword,
+		Dashboards:    uids,
+	}, nil
+}
+
+// UndeployGrafana stops Grafana
+func (s *service) UndeployGrafana(ctx context.Context) error {
+	existing, err := s.db.GetGrafanaConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrGrafanaNotDeployed
+		}
+		return fmt.Errorf("failed to get grafana config: %w", err)
+	}
+
+	config, err := s.grafanaConfigFromDB(existing)
+	if err != nil {
+		return err
+	}
+	deployer, err := s.createGrafanaDeployer(config)
+	if err != nil {
+		return err
+	}
+	if err := deployer.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop Grafana: %w", err)
+	}
+
+	if err := s.db.DeleteGrafanaConfig(ctx); err != nil {
+		return fmt.Errorf("failed to delete grafana config: %w", err)
+	}
+	return nil
+}
+
+// GetGrafanaStatus returns the current status of the Grafana instance
+func (s *service) GetGrafanaStatus(ctx context.Context) (*common.Status, error) {
+	existing, err := s.db.GetGrafanaConfig(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &common.Status{Status: "not_deployed"}, nil
+		}
+		return nil, fmt.Errorf("failed to get grafana config: %w", err)
+	}
+
+	config, err := s.grafanaConfigFromDB(existing)
+	if err != nil {
+		return nil, err
+	}
+	status := &common.Status{
+		Version:        config.Version,
+		Port:           config.Port,
+		DeploymentMode: config.DeploymentMode,
+	}
+	if config.DockerConfig != nil {
+		status.NetworkMode = config.DockerConfig.NetworkMode
+	}
+	startedAt := existing.CreatedAt
+	status.StartedAt = &startedAt
+
+	deployer, err := s.createGrafanaDeployer(config)
+	if err != nil {
+		status.Status = "error"
+		status.Error = err.Error()
+		return status, nil
+	}
+	state, err := deployer.GetStatus(ctx)
+	if err != nil {
+		status.Status = "error"
+		status.Error = err.Error()
+		return status, nil
+	}
+	status.Status = state
+	return status, nil
+}
+
+// RefreshGrafanaDashboards regenerates the provisioned dashboards from the current scrape targets
+func (s *service) RefreshGrafanaDashboards(ctx context.Context) ([]string, error) {
+	if _, err := s.db.GetGrafanaConfig(ctx); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrGrafanaNotDeployed
+		}
+		return nil, fmt.Errorf("failed to get grafana config: %w", err)
+	}
+	dashboards, err := s.grafanaDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return writeGrafanaDashboards(newGrafanaDirs(s.configService).dashboards, dashboards)
+}
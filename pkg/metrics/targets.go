@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+)
+
+// Labels attached to every node scrape target. Grafana dashboards filter on them.
+const (
+	labelNodeID   = "node_id"
+	labelNodeName = "node_name"
+	labelNodeType = "node_type"
+	labelPlatform = "platform"
+	labelMSPID    = "msp_id"
+	labelRole     = "role"
+	labelNetwork  = "network"
+)
+
+// nodeScrapeLabels returns the static labels describing a node
+func nodeScrapeLabels(node nodeservice.NodeResponse) map[string]string {
+	labels := map[string]string{
+		labelNodeID:   strconv.FormatInt(node.ID, 10),
+		labelNodeName: node.Name,
+		labelNodeType: string(node.NodeType),
+		labelPlatform: node.Platform,
+	}
+	var mspID string
+	switch {
+	case node.FabricPeer != nil:
+		mspID = node.FabricPeer.MSPID
+	case node.FabricOrderer != nil:
+		mspID = node.FabricOrderer.MSPID
+	case node.FabricXOrdererGroup != nil:
+		mspID = node.FabricXOrdererGroup.MSPID
+	case node.FabricXCommitter != nil:
+		mspID = node.FabricXCommitter.MSPID
+	}
+	if mspID != "" {
+		labels[labelMSPID] = mspID
+	}
+	if node.FabricXChild != nil && node.FabricXChild.Role != "" {
+		labels[labelRole] = node.FabricXChild.Role
+	}
+	return labels
+}
+
+// loadNodeNetworks returns the names of the networks each node belongs to, keyed by node ID.
+// Names are sorted and joined with commas since a Prometheus label holds a single value.
+func loadNodeNetworks(ctx context.Context, queries *db.Queries) (map[string]string, error) {
+	networks, err := queries.ListNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	names := make(map[string][]string)
+	for _, network := range networks {
+		networkNodes, err := queries.ListNetworkNodesByNetwork(ctx, network.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes of network %s: %w", network.Name, err)
+		}
+		for _, networkNode := range networkNodes {
+			nodeID := strconv.FormatInt(networkNode.NodeID, 10)
+			names[nodeID] = append(names[nodeID], network.Name)
+		}
+	}
+	result := make(map[string]string, len(names))
+	for nodeID, nodeNetworks := range names {
+		sort.Strings(nodeNetworks)
+		result[nodeID] = strings.Join(nodeNetworks, ",")
+	}
+	return result, nil
+}
+
+// nodeScrapeConfig returns the scrape configuration of a node, labelled with its networks
+func nodeScrapeConfig(node PeerNode, networks map[string]string) ScrapeConfig {
+	labels := make(map[string]string, len(node.Labels)+1)
+	for k, v := range node.Labels {
+		labels[k] = v
+	}
+	if network, ok := networks[node.ID]; ok {
+		labels[labelNetwork] = network
+	}
+	return ScrapeConfig{
+		JobName:       slugify(fmt.Sprintf("%s-%s", node.ID, node.Name)),
+		StaticConfigs: []StaticConfig{{Targets: []string{node.OperationAddress}, Labels: labels}},
+	}
+}
+
+// fabricXNodes returns the FabricX role nodes exposing a monitoring port, reachable on host
+func fabricXNodes(nodes []nodeservice.NodeResponse, host string) []PeerNode {
+	fabricxNodes := make([]PeerNode, 0)
+	for _, node := range nodes {
+		if node.FabricXChild == nil || node.FabricXChild.MonitoringPort == 0 {
+			continue
+		}
+		fabricxNodes = append(fabricxNodes, PeerNode{
+			ID:               strconv.FormatInt(node.ID, 10),
+			Name:             node.Name,
+			OperationAddress: fmt.Sprintf("%s:%d", host, node.FabricXChild.MonitoringPort),
+			Labels:           nodeScrapeLabels(node),
+		})
+	}
+	return fabricxNodes
+}
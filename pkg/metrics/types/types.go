@@ -78,3 +78,43 @@ type MetricsDataResponse struct {
 	Status string      `json:"status"`
 	Data   interface{} `json:"data"`
 }
+
+// AlertRuleRequest represents the request to create or update a custom alerting rule
+type AlertRuleRequest struct {
+	Group       string            `json:"group,omitempty"`
+	Name        string            `json:"name" binding:"required"`
+	Expr        string            `json:"expr" binding:"required"`
+	For         string            `json:"for,omitempty"`
+	Severity    string            `json:"severity,omitempty"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Enabled     *bool             `json:"enabled,omitempty"`
+}
+
+// DeployAlertmanagerRequest represents the request to deploy Alertmanager
+type DeployAlertmanagerRequest struct {
+	AlertmanagerVersion string                `json:"alertmanager_version,omitempty"`
+	AlertmanagerPort    int                   `json:"alertmanager_port,omitempty"`
+	DeploymentMode      common.DeploymentMode `json:"deployment_mode,omitempty"`
+	DockerConfig        *DockerDeployConfig   `json:"docker_config,omitempty"`
+	// WebhookURL is the URL Alertmanager uses to reach ChainLaunch; derived from the request when empty
+	WebhookURL          string `json:"webhook_url,omitempty"`
+	DefaultRulesEnabled *bool  `json:"default_rules_enabled,omitempty"`
+}
+
+// DeployGrafanaRequest represents the request to deploy Grafana
+type DeployGrafanaRequest struct {
+	GrafanaVersion string                `json:"grafana_version,omitempty"`
+	GrafanaPort    int                   `json:"grafana_port,omitempty"`
+	DeploymentMode common.DeploymentMode `json:"deployment_mode,omitempty"`
+	DockerConfig   *DockerDeployConfig   `json:"docker_config,omitempty"`
+	AdminUser      string                `json:"admin_user,omitempty"`
+	// AdminPassword is only applied when Grafana initialises its data directory; generated when empty
+	AdminPassword string `json:"admin_password,omitempty"`
+}
+
+// GrafanaDashboardsResponse lists the UIDs of the provisioned Grafana dashboards
+type GrafanaDashboardsResponse struct {
+	Dashboards []string `json:"dashboards"`
+}
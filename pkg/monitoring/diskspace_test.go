@@ -40,6 +40,14 @@ func (m *mockNotificationService) SendDiskSpaceWarningNotification(ctx context.C
 	return nil
 }
 
+func (m *mockNotificationService) SendAlertNotification(ctx context.Context, data notifications.AlertData) error {
+	return nil
+}
+
+func (m *mockNotificationService) SendPluginHealthNotification(ctx context.Context, data notifications.PluginHealthData) error {
+	return nil
+}
+
 func TestNewDiskSpaceMonitor(t *testing.T) {
 	log := logger.NewDefault()
 	mockSvc := &mockNotificationService{}
@@ -53,3 +53,33 @@ type NodeCheck struct {
 	// Timestamp is when the check was performed
 	Timestamp time.Time
 }
+
+// PluginStatus represents the current health of a deployed plugin
+type PluginStatus string
+
+const (
+	// PluginStatusHealthy indicates all services of the plugin are running and pass their checks
+	PluginStatusHealthy PluginStatus = "healthy"
+	// PluginStatusUnhealthy indicates services of the plugin are down and being restarted
+	PluginStatusUnhealthy PluginStatus = "unhealthy"
+	// PluginStatusFailed indicates the plugin is still unhealthy after the maximum number of restarts
+	PluginStatusFailed PluginStatus = "failed"
+)
+
+// PluginCheck represents the result of a plugin health check reported by the plugin supervisor
+type PluginCheck struct {
+	// Name is the name of the plugin
+	Name string
+	// Status is the status determined by the check
+	Status PluginStatus
+	// UnhealthyServices are the docker-compose services that are down or fail their checks
+	UnhealthyServices []string
+	// RestartCount is the number of restarts since the plugin was last healthy
+	RestartCount int
+	// LastRestart is when the plugin services were last restarted
+	LastRestart time.Time
+	// Error is the failure of the unhealthy services
+	Error error
+	// Timestamp is when the check was performed
+	Timestamp time.Time
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/chainlaunch/chainlaunch/pkg/certutils"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/metrics/instrumentation"
 	nodes "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
 	"github.com/chainlaunch/chainlaunch/pkg/notifications"
 )
@@ -36,6 +37,15 @@ type Service interface {
 	GetNodeStatus(nodeID int64) (*NodeCheck, error)
 	// GetAllNodeStatuses returns the current status of all nodes
 	GetAllNodeStatuses() []*NodeCheck
+
+	// UpdatePluginStatus records the latest health check of a deployed plugin
+	UpdatePluginStatus(check *PluginCheck)
+	// RemovePlugin removes a plugin that is no longer deployed
+	RemovePlugin(name string)
+	// GetPluginStatus returns the current status of a plugin
+	GetPluginStatus(name string) (*PluginCheck, error)
+	// GetAllPluginStatuses returns the current status of all deployed plugins
+	GetAllPluginStatuses() []*PluginCheck
 }
 
 // service implements the Service interface
@@ -53,6 +63,8 @@ type service struct {
 	nodeService      *nodes.NodeService
 	checkingNodes    map[int64]bool // guards against concurrent checks for the same node
 	checkingMutex    sync.Mutex
+	pluginResults    map[string]*PluginCheck
+	pluginMutex      sync.RWMutex
 }
 
 // NewService creates a new monitoring service
@@ -69,6 +81,7 @@ func NewService(logger *logger.Logger, config *Config, notificationSvc notificat
 		stopChan:         make(chan struct{}),
 		lastCheckResults: make(map[int64]*NodeCheck),
 		checkingNodes:    make(map[int64]bool),
+		pluginResults:    make(map[string]*PluginCheck),
 		httpClient: &http.Client{
 			Timeout: config.DefaultTimeout,
 		},
@@ -169,6 +182,46 @@ func (s *service) GetAllNodeStatuses() []*NodeCheck {
 	return results
 }
 
+// UpdatePluginStatus records the latest health check of a deployed plugin
+func (s *service) UpdatePluginStatus(check *PluginCheck) {
+	s.pluginMutex.Lock()
+	s.pluginResults[check.Name] = check
+	s.pluginMutex.Unlock()
+}
+
+// RemovePlugin removes a plugin that is no longer deployed
+func (s *service) RemovePlugin(name string) {
+	s.pluginMutex.Lock()
+	delete(s.pluginResults, name)
+	s.pluginMutex.Unlock()
+}
+
+// GetPluginStatus returns the current status of a plugin
+func (s *service) GetPluginStatus(name string) (*PluginCheck, error) {
+	s.pluginMutex.RLock()
+	defer s.pluginMutex.RUnlock()
+
+	result, exists := s.pluginResults[name]
+	if !exists {
+		return nil, fmt.Errorf("plugin %s not found", name)
+	}
+
+	return result, nil
+}
+
+// GetAllPluginStatuses returns the current status of all deployed plugins
+func (s *service) GetAllPluginStatuses() []*PluginCheck {
+	s.pluginMutex.RLock()
+	defer s.pluginMutex.RUnlock()
+
+	results := make([]*PluginCheck, 0, len(s.pluginResults))
+	for _, result := range s.pluginResults {
+		results = append(results, result)
+	}
+
+	return results
+}
+
 // worker is a background worker that checks nodes periodically
 func (s *service) worker(ctx context.Context, workerID int) {
 	defer s.workerWaitGroup.Done()
@@ -199,8 +252,13 @@ func (s *service) checkNodes(ctx context.Context) {
 	for _, node := range s.nodes {
 		if now.Sub(node.LastChecked) >= node.CheckInterval {
 			nodesToCheck = append(nodesToCheck, node)
+			// Record how late the check is compared to its interval
+			if !node.LastChecked.IsZero() {
+				instrumentation.MonitoringCheckLag.Observe((now.Sub(node.LastChecked) - node.CheckInterval).Seconds())
+			}
 		}
 	}
+	instrumentation.MonitoredNodes.Set(float64(len(s.nodes)))
 	s.nodesMutex.RUnlock()
 
 	// Check each node
@@ -436,6 +494,7 @@ func (s *service) checkBesuNode(ctx context.Context, node *Node, besu *nodes.Bes
 // handleNodeCheckResult processes the result of a node check
 func (s *service) handleNodeCheckResult(node *Node, status NodeStatus, responseTime time.Duration, err error) {
 	now := time.Now()
+	instrumentation.MonitoringCheckDuration.WithLabelValues(string(status)).Observe(responseTime.Seconds())
 
 	// Create the check result
 	checkResult := &NodeCheck{
@@ -0,0 +1,202 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// Orderer consensus types and states as they appear in the channel config
+const (
+	consensusTypeEtcdRaft = "etcdraft"
+	consensusTypeBFT      = "BFT"
+	stateNormal           = "STATE_NORMAL"
+	stateMaintenance      = "STATE_MAINTENANCE"
+)
+
+// minConsenters is the smallest BFT cluster that tolerates a faulty orderer
+const minConsenters = 4
+
+// channelState is the part of the channel config the migration works on
+type channelState struct {
+	ConsensusType string
+	State         string
+	// RaftConsenters are the host:port addresses of the etcdraft consenters
+	RaftConsenters []string
+	// BFTConsenters is the size of the BFT consenter mapping
+	BFTConsenters int
+}
+
+// buildConsenters creates the BFT consenter mapping from the orderers of a network.
+// Consenter IDs follow the node IDs so the mapping is stable between plans.
+func buildConsenters(nodes []*nodeservice.NodeResponse) ([]Consenter, error) {
+	var orderers []*nodeservice.NodeResponse
+	for _, node := range nodes {
+		if node != nil && node.NodeType == nodetypes.NodeTypeFabricOrderer {
+			orderers = append(orderers, node)
+		}
+	}
+	if len(orderers) < minConsenters {
+		return nil, fmt.Errorf("BFT requires at least %d orderers, the network has %d", minConsenters, len(orderers))
+	}
+	sort.Slice(orderers, func(i, j int) bool { return orderers[i].ID < orderers[j].ID })
+
+	consenters := make([]Consenter, 0, len(orderers))
+	for i, node := range orderers {
+		props := node.FabricOrderer
+		if props == nil {
+			return nil, fmt.Errorf("orderer %s has no orderer properties", node.Name)
+		}
+		if props.SignCert == "" {
+			return nil, fmt.Errorf("orderer %s has no identity certificate", node.Name)
+		}
+		if props.TLSCert == "" {
+			return nil, fmt.Errorf("orderer %s has no TLS certificate", node.Name)
+		}
+		host, portStr, err := net.SplitHostPort(props.ExternalEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("orderer %s has an invalid endpoint %q: %w", node.Name, props.ExternalEndpoint, err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("orderer %s has an invalid port %q: %w", node.Name, portStr, err)
+		}
+		consenters = append(consenters, Consenter{
+			ID:       uint32(i + 1),
+			NodeID:   node.ID,
+			Name:     node.Name,
+			Host:     host,
+			Port:     port,
+			MSPID:    props.MSPID,
+			Identity: props.SignCert,
+			TLSCert:  props.TLSCert,
+		})
+	}
+	return consenters, nil
+}
+
+// checkOrderers makes sure the orderers of the network are the planned consenters,
+// with the same certificates, running a Fabric release that supports BFT
+func checkOrderers(consenters []Consenter, nodes []*nodeservice.NodeResponse) error {
+	orderers := make(map[int64]*nodeservice.NodeResponse)
+	for _, node := range nodes {
+		if node != nil && node.NodeType == nodetypes.NodeTypeFabricOrderer {
+			orderers[node.ID] = node
+		}
+	}
+	for _, cons := range consenters {
+		node, ok := orderers[cons.NodeID]
+		if !ok {
+			return fmt.Errorf("orderer %s is no longer part of the network", cons.Name)
+		}
+		delete(orderers, cons.NodeID)
+		if node.FabricOrderer == nil {
+			return fmt.Errorf("orderer %s has no orderer properties", node.Name)
+		}
+		if node.FabricOrderer.SignCert != cons.Identity || node.FabricOrderer.TLSCert != cons.TLSCert {
+			return fmt.Errorf("certificates of orderer %s changed since the migration was planned", node.Name)
+		}
+		if node.Status != string(nodetypes.NodeStatusRunning) {
+			return fmt.Errorf("orderer %s is %s", node.Name, node.Status)
+		}
+		if err := checkOrdererVersion(node.Name, node.FabricOrderer.Version); err != nil {
+			return err
+		}
+	}
+	for _, node := range orderers {
+		return fmt.Errorf("orderer %s joined the network after the migration was planned", node.Name)
+	}
+	return nil
+}
+
+// checkOrdererVersion makes sure an orderer runs Fabric 3.0 or later
+func checkOrdererVersion(name, version string) error {
+	major, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)[0])
+	if err != nil {
+		return fmt.Errorf("orderer %s has an unknown version %q", name, version)
+	}
+	if major < minOrdererMajorVersion {
+		return fmt.Errorf("orderer %s runs Fabric %s, BFT requires %d.0 or later", name, version, minOrdererMajorVersion)
+	}
+	return nil
+}
+
+// checkMigratable makes sure the channel uses etcdraft and every raft consenter is in the mapping
+func checkMigratable(state *channelState, consenters []Consenter) error {
+	if state.ConsensusType != consensusTypeEtcdRaft {
+		return fmt.Errorf("channel uses %s consensus, only %s channels can be migrated", state.ConsensusType, consensusTypeEtcdRaft)
+	}
+	mapped := make(map[string]bool, len(consenters))
+	for _, cons := range consenters {
+		mapped[net.JoinHostPort(cons.Host, strconv.Itoa(cons.Port))] = true
+	}
+	for _, address := range state.RaftConsenters {
+		if !mapped[address] {
+			return fmt.Errorf("raft consenter %s is not an orderer of the network and would be dropped", address)
+		}
+	}
+	return nil
+}
+
+// stepDone reports whether the channel already reflects a step, so a resumed
+// migration doesn't submit the same config update twice
+func stepDone(step StepName, state *channelState, consenters int) bool {
+	switch step {
+	case StepEnterMaintenance:
+		return state.State == stateMaintenance || state.ConsensusType == consensusTypeBFT
+	case StepSwitchConsensus:
+		return state.ConsensusType == consensusTypeBFT && state.BFTConsenters == consenters
+	case StepExitMaintenance:
+		return state.ConsensusType == consensusTypeBFT && state.State == stateNormal
+	default:
+		return false
+	}
+}
+
+// markSmartBFT records the new consensus in the stored network config, keeping the other fields
+func markSmartBFT(config string, consenters []Consenter, options *types.SmartBFTOptions) (string, error) {
+	fields := map[string]json.RawMessage{}
+	if config != "" {
+		if err := json.Unmarshal([]byte(config), &fields); err != nil {
+			return "", fmt.Errorf("failed to parse network config: %w", err)
+		}
+	}
+	mapping := make([]types.SmartBFTConsenter, 0, len(consenters))
+	for _, cons := range consenters {
+		mapping = append(mapping, types.SmartBFTConsenter{
+			Address:       types.HostPort{Host: cons.Host, Port: cons.Port},
+			ClientTLSCert: cons.TLSCert,
+			ServerTLSCert: cons.TLSCert,
+			Identity:      cons.Identity,
+			ID:            uint64(cons.ID),
+			MSPID:         cons.MSPID,
+		})
+	}
+	values := map[string]any{
+		"consensus_type":      TargetSmartBFT,
+		"smartbft_consenters": mapping,
+	}
+	if options != nil {
+		values["smartbft_options"] = options
+	}
+	for key, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		fields[key] = raw
+	}
+	delete(fields, "etcdraft_options")
+	result, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
@@ -0,0 +1,158 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+func testOrderer(id int64) *nodeservice.NodeResponse {
+	return &nodeservice.NodeResponse{
+		ID:       id,
+		Name:     fmt.Sprintf("orderer%d", id),
+		NodeType: nodetypes.NodeTypeFabricOrderer,
+		Status:   string(nodetypes.NodeStatusRunning),
+		FabricOrderer: &nodeservice.FabricOrdererProperties{
+			MSPID:            "OrdererMSP",
+			ExternalEndpoint: fmt.Sprintf("orderer%d.example.com:70%02d", id, id),
+			SignCert:         fmt.Sprintf("sign-%d", id),
+			TLSCert:          fmt.Sprintf("tls-%d", id),
+			Version:          "3.1.0",
+		},
+	}
+}
+
+func testNetwork() []*nodeservice.NodeResponse {
+	return []*nodeservice.NodeResponse{
+		testOrderer(7),
+		{ID: 2, NodeType: nodetypes.NodeTypeFabricPeer},
+		testOrderer(3),
+		testOrderer(5),
+		testOrderer(4),
+	}
+}
+
+func TestBuildConsenters(t *testing.T) {
+	consenters, err := buildConsenters(testNetwork())
+	require.NoError(t, err)
+	require.Len(t, consenters, 4)
+
+	var nodeIDs []int64
+	for i, cons := range consenters {
+		assert.Equal(t, uint32(i+1), cons.ID)
+		nodeIDs = append(nodeIDs, cons.NodeID)
+	}
+	assert.Equal(t, []int64{3, 4, 5, 7}, nodeIDs)
+	assert.Equal(t, "orderer3.example.com", consenters[0].Host)
+	assert.Equal(t, 7003, consenters[0].Port)
+	assert.Equal(t, "sign-3", consenters[0].Identity)
+
+	_, err = buildConsenters(testNetwork()[:4])
+	assert.ErrorContains(t, err, "at least 4 orderers")
+
+	nodes := testNetwork()
+	nodes[0].FabricOrderer.ExternalEndpoint = "orderer7.example.com"
+	_, err = buildConsenters(nodes)
+	assert.ErrorContains(t, err, "invalid endpoint")
+}
+
+func TestCheckOrderers(t *testing.T) {
+	consenters, err := buildConsenters(testNetwork())
+	require.NoError(t, err)
+	assert.NoError(t, checkOrderers(consenters, testNetwork()))
+
+	nodes := testNetwork()
+	nodes[2].FabricOrderer.TLSCert = "renewed"
+	assert.ErrorContains(t, checkOrderers(consenters, nodes), "certificates of orderer orderer3 changed")
+
+	nodes = testNetwork()
+	nodes[3].Status = string(nodetypes.NodeStatusStopped)
+	assert.ErrorContains(t, checkOrderers(consenters, nodes), "orderer orderer5 is")
+
+	nodes = testNetwork()
+	nodes[4].FabricOrderer.Version = "2.5.12"
+	assert.ErrorContains(t, checkOrderers(consenters, nodes), "BFT requires 3.0")
+
+	assert.ErrorContains(t, checkOrderers(consenters, testNetwork()[1:]), "no longer part of the network")
+	assert.ErrorContains(t, checkOrderers(consenters, append(testNetwork(), testOrderer(9))), "joined the network")
+}
+
+func TestCheckOrdererVersion(t *testing.T) {
+	assert.NoError(t, checkOrdererVersion("orderer", "3.0.0"))
+	assert.NoError(t, checkOrdererVersion("orderer", "v3.1.1"))
+	assert.Error(t, checkOrdererVersion("orderer", "2.5.12"))
+	assert.Error(t, checkOrdererVersion("orderer", ""))
+}
+
+func TestCheckMigratable(t *testing.T) {
+	consenters, err := buildConsenters(testNetwork())
+	require.NoError(t, err)
+
+	state := &channelState{
+		ConsensusType:  consensusTypeEtcdRaft,
+		State:          stateNormal,
+		RaftConsenters: []string{"orderer3.example.com:7003", "orderer4.example.com:7004", "orderer5.example.com:7005"},
+	}
+	assert.NoError(t, checkMigratable(state, consenters))
+
+	state.RaftConsenters = append(state.RaftConsenters, "external.example.com:7050")
+	assert.ErrorContains(t, checkMigratable(state, consenters), "external.example.com:7050")
+
+	assert.ErrorContains(t, checkMigratable(&channelState{ConsensusType: consensusTypeBFT}, consenters), "only etcdraft")
+}
+
+func TestStepDone(t *testing.T) {
+	raftNormal := &channelState{ConsensusType: consensusTypeEtcdRaft, State: stateNormal}
+	raftMaintenance := &channelState{ConsensusType: consensusTypeEtcdRaft, State: stateMaintenance}
+	bftMaintenance := &channelState{ConsensusType: consensusTypeBFT, State: stateMaintenance, BFTConsenters: 4}
+	bftNormal := &channelState{ConsensusType: consensusTypeBFT, State: stateNormal, BFTConsenters: 4}
+
+	tests := []struct {
+		step  StepName
+		state *channelState
+		done  bool
+	}{
+		{StepEnterMaintenance, raftNormal, false},
+		{StepEnterMaintenance, raftMaintenance, true},
+		{StepEnterMaintenance, bftNormal, true},
+		{StepSwitchConsensus, raftMaintenance, false},
+		{StepSwitchConsensus, bftMaintenance, true},
+		{StepSwitchConsensus, &channelState{ConsensusType: consensusTypeBFT, BFTConsenters: 5}, false},
+		{StepExitMaintenance, bftMaintenance, false},
+		{StepExitMaintenance, bftNormal, true},
+		{StepExitMaintenance, raftNormal, false},
+		{StepRestartOrderers, bftNormal, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.done, stepDone(tt.step, tt.state, 4), "%s on %s/%s", tt.step, tt.state.ConsensusType, tt.state.State)
+	}
+}
+
+func TestMarkSmartBFT(t *testing.T) {
+	consenters, err := buildConsenters(testNetwork())
+	require.NoError(t, err)
+
+	config, err := markSmartBFT(`{"type":"fabric","channelName":"mychannel","consensus_type":"etcdraft","etcdraft_options":{"tickInterval":"500ms"}}`, consenters, nil)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(config), &fields))
+	assert.JSONEq(t, `"smartbft"`, string(fields["consensus_type"]))
+	assert.JSONEq(t, `"mychannel"`, string(fields["channelName"]))
+	assert.NotContains(t, fields, "etcdraft_options")
+	assert.NotContains(t, fields, "smartbft_options")
+
+	var mapping []map[string]any
+	require.NoError(t, json.Unmarshal(fields["smartbft_consenters"], &mapping))
+	require.Len(t, mapping, 4)
+	assert.Equal(t, "sign-3", mapping[0]["identity"])
+
+	_, err = markSmartBFT("not json", consenters, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,695 @@
+package consensus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/runner"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// Service migrates live Fabric channels from etcdraft to SmartBFT. Every step submits
+// its change through the config update operations and checks the channel reflects it
+// before moving on. A failed step pauses the migration; starting it again resumes from
+// that step, skipping changes the channel already carries.
+type Service struct {
+	queries  *db.Queries
+	networks *service.NetworkService
+	nodes    *nodeservice.NodeService
+	logger   *logger.Logger
+
+	// running holds the migrations being executed
+	running *runner.Registry
+}
+
+// NewService creates a new consensus migration service
+func NewService(queries *db.Queries, networks *service.NetworkService, nodes *nodeservice.NodeService, logger *logger.Logger) *Service {
+	return &Service{
+		queries:  queries,
+		networks: networks,
+		nodes:    nodes,
+		logger:   logger,
+		running:  runner.NewRegistry(),
+	}
+}
+
+// RecoverInterrupted pauses the migrations left running by a previous server process
+// so they can be inspected and resumed
+func (s *Service) RecoverInterrupted(ctx context.Context) error {
+	running := func(ctx context.Context) ([]*db.ConsensusMigration, error) {
+		migrations, err := s.queries.ListConsensusMigrationsByStatus(ctx, string(MigrationStatusRunning))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list running consensus migrations: %w", err)
+		}
+		return migrations, nil
+	}
+	return runner.PauseInterrupted(ctx, running, func(ctx context.Context, migration *db.ConsensusMigration) error {
+		s.logger.Warn("Pausing consensus migration interrupted by restart", "migrationID", migration.ID, "networkID", migration.NetworkID)
+		return s.setMigrationStatus(ctx, migration.ID, MigrationStatusPaused, runner.InterruptedReason)
+	})
+}
+
+// CreateMigration plans the migration of a Fabric channel from etcdraft to SmartBFT.
+// Every orderer of the network becomes a BFT consenter, identified by its enrollment
+// certificate.
+func (s *Service) CreateMigration(ctx context.Context, networkID int64, req CreateMigrationRequest) (*Migration, error) {
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	if network.Platform != string(service.BlockchainTypeFabric) {
+		return nil, fmt.Errorf("consensus migration is only supported for Fabric networks")
+	}
+	if networkConsensus(network) == TargetSmartBFT {
+		return nil, fmt.Errorf("network %s already uses %s", network.Name, TargetSmartBFT)
+	}
+
+	nodes, err := s.networkNodes(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	consenters, err := buildConsenters(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	restartTimeout := int64(req.RestartTimeoutSeconds)
+	if restartTimeout <= 0 {
+		restartTimeout = int64(defaultRestartTimeout / time.Second)
+	}
+	config, err := json.Marshal(migrationConfig{
+		Consenters:            consenters,
+		Options:               req.Options,
+		RestartTimeoutSeconds: restartTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal migration config: %w", err)
+	}
+	var migrationID int64
+	err = s.queries.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		migrationID, err = storeMigration(ctx, q, &db.CreateConsensusMigrationParams{
+			NetworkID:       networkID,
+			TargetConsensus: TargetSmartBFT,
+			Status:          string(MigrationStatusPending),
+			Config:          string(config),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetMigration(ctx, migrationID)
+}
+
+// storeMigration creates a migration with the steps of the workflow, unless the network
+// already has an active migration or upgrade plan. It runs in the transaction of
+// CreateMigration so a failure leaves no orphaned migration behind.
+func storeMigration(ctx context.Context, q *db.Queries, params *db.CreateConsensusMigrationParams) (int64, error) {
+	active, err := q.CountActiveConsensusMigrations(ctx, params.NetworkID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active consensus migrations: %w", err)
+	}
+	if active > 0 {
+		return 0, ErrMigrationActive
+	}
+	// Orderers are restarted during the migration, which an upgrade would race with
+	upgrades, err := q.CountActiveUpgradePlans(ctx, params.NetworkID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active upgrade plans: %w", err)
+	}
+	if upgrades > 0 {
+		return 0, fmt.Errorf("%w: network has an unfinished upgrade plan", ErrMigrationActive)
+	}
+	migration, err := q.CreateConsensusMigration(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create consensus migration: %w", err)
+	}
+	for i, name := range workflow {
+		if _, err := q.CreateConsensusMigrationStep(ctx, &db.CreateConsensusMigrationStepParams{
+			MigrationID: migration.ID,
+			StepOrder:   int64(i + 1),
+			Name:        string(name),
+			Status:      string(StepStatusPending),
+		}); err != nil {
+			return 0, fmt.Errorf("failed to create migration step: %w", err)
+		}
+	}
+	return migration.ID, nil
+}
+
+// GetMigration returns a consensus migration with its steps
+func (s *Service) GetMigration(ctx context.Context, migrationID int64) (*Migration, error) {
+	migration, err := s.queries.GetConsensusMigration(ctx, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus migration: %w", err)
+	}
+	steps, err := s.queries.ListConsensusMigrationSteps(ctx, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration steps: %w", err)
+	}
+	return mapMigration(migration, steps)
+}
+
+// ListMigrations returns the consensus migrations of a network, newest first
+func (s *Service) ListMigrations(ctx context.Context, networkID int64) ([]Migration, error) {
+	migrations, err := s.queries.ListConsensusMigrationsByNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consensus migrations: %w", err)
+	}
+	result := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		steps, err := s.queries.ListConsensusMigrationSteps(ctx, migration.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list migration steps: %w", err)
+		}
+		mapped, err := mapMigration(migration, steps)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapped)
+	}
+	return result, nil
+}
+
+// StartMigration starts a pending migration, or resumes a paused one from its first
+// unfinished step. The migration runs in the background; poll it to follow its progress.
+func (s *Service) StartMigration(ctx context.Context, migrationID int64) (*Migration, error) {
+	migration, err := s.queries.GetConsensusMigration(ctx, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus migration: %w", err)
+	}
+	status := MigrationStatus(migration.Status)
+	if status != MigrationStatusPending && status != MigrationStatusPaused {
+		return nil, fmt.Errorf("%w: migration is %s", ErrInvalidMigrationState, status)
+	}
+	if err := s.claim(migrationID); err != nil {
+		return nil, err
+	}
+	if err := s.queries.StartConsensusMigration(ctx, migrationID); err != nil {
+		s.running.Release(migrationID)
+		return nil, fmt.Errorf("failed to start consensus migration: %w", err)
+	}
+
+	go s.execute(context.Background(), migration)
+	return s.GetMigration(ctx, migrationID)
+}
+
+// CancelMigration cancels a migration whose consensus type was not switched yet. A
+// running migration stops before its next step. When the channel was put in
+// maintenance mode it is returned to normal operation.
+func (s *Service) CancelMigration(ctx context.Context, migrationID int64) (*Migration, error) {
+	migration, err := s.queries.GetConsensusMigration(ctx, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus migration: %w", err)
+	}
+	steps, err := s.queries.ListConsensusMigrationSteps(ctx, migrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration steps: %w", err)
+	}
+	if switched(steps) {
+		return nil, fmt.Errorf("%w: the consensus type was already switched, resume the migration to finish it", ErrInvalidMigrationState)
+	}
+
+	switch MigrationStatus(migration.Status) {
+	case MigrationStatusRunning:
+		s.running.RequestCancel(migrationID)
+	case MigrationStatusPending, MigrationStatusPaused:
+		// Claim the migration so it can't be started while maintenance mode is left
+		if err := s.claim(migrationID); err != nil {
+			return nil, err
+		}
+		defer s.running.Release(migrationID)
+		if err := s.leaveMaintenance(ctx, migration.NetworkID); err != nil {
+			return nil, err
+		}
+		if err := s.setMigrationStatus(ctx, migrationID, MigrationStatusCancelled, ""); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: migration is %s", ErrInvalidMigrationState, migration.Status)
+	}
+	return s.GetMigration(ctx, migrationID)
+}
+
+// claim marks a migration as running so it can't be started twice
+func (s *Service) claim(migrationID int64) error {
+	if !s.running.Claim(migrationID) {
+		return fmt.Errorf("%w: migration is already running", ErrInvalidMigrationState)
+	}
+	return nil
+}
+
+// execute runs the unfinished steps of a migration in order
+func (s *Service) execute(ctx context.Context, migration *db.ConsensusMigration) {
+	defer s.running.Release(migration.ID)
+	logger := s.logger.With("migrationID", migration.ID, "networkID", migration.NetworkID)
+
+	var config migrationConfig
+	if err := json.Unmarshal([]byte(migration.Config), &config); err != nil {
+		s.pause(ctx, migration.ID, fmt.Sprintf("failed to parse migration config: %v", err))
+		return
+	}
+	steps, err := s.queries.ListConsensusMigrationSteps(ctx, migration.ID)
+	if err != nil {
+		s.pause(ctx, migration.ID, fmt.Sprintf("failed to list migration steps: %v", err))
+		return
+	}
+
+	for _, step := range steps {
+		if StepStatus(step.Status) == StepStatusCompleted {
+			continue
+		}
+		if s.running.CancelRequested(migration.ID) && !switched(steps) {
+			logger.Info("Consensus migration cancelled")
+			if err := s.leaveMaintenance(ctx, migration.NetworkID); err != nil {
+				s.pause(ctx, migration.ID, fmt.Sprintf("cancel: %v", err))
+				return
+			}
+			if err := s.setMigrationStatus(ctx, migration.ID, MigrationStatusCancelled, ""); err != nil {
+				logger.Error("Failed to cancel consensus migration", "error", err)
+			}
+			return
+		}
+
+		logger.Info("Running consensus migration step", "step", step.Name)
+		startedAt := time.Now()
+		s.setStepStatus(ctx, step, StepStatusRunning, "", &startedAt, nil)
+		if err := s.runStep(ctx, migration.NetworkID, StepName(step.Name), config, step); err != nil {
+			logger.Warn("Consensus migration step failed", "step", step.Name, "error", err)
+			s.setStepStatus(ctx, step, StepStatusFailed, err.Error(), &startedAt, nil)
+			s.pause(ctx, migration.ID, fmt.Sprintf("step %d (%s): %v", step.StepOrder, step.Name, err))
+			return
+		}
+		completedAt := time.Now()
+		s.setStepStatus(ctx, step, StepStatusCompleted, "", &startedAt, &completedAt)
+	}
+
+	logger.Info("Consensus migration completed")
+	if err := s.setMigrationStatus(ctx, migration.ID, MigrationStatusCompleted, ""); err != nil {
+		logger.Error("Failed to complete consensus migration", "error", err)
+	}
+}
+
+// runStep performs one step. Each step checks the channel first, so a step whose
+// change already reached the channel is not submitted again on resume.
+func (s *Service) runStep(ctx context.Context, networkID int64, name StepName, config migrationConfig, step *db.ConsensusMigrationStep) error {
+	switch name {
+	case StepPreflight:
+		nodes, err := s.networkNodes(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		if err := checkOrderers(config.Consenters, nodes); err != nil {
+			return err
+		}
+		state, err := s.channelState(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		return checkMigratable(state, config.Consenters)
+
+	case StepEnterMaintenance:
+		state, err := s.channelState(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		if stepDone(name, state, len(config.Consenters)) {
+			return nil
+		}
+		if err := checkMigratable(state, config.Consenters); err != nil {
+			return err
+		}
+		if err := s.submit(ctx, networkID, fabric.OpSetConsensusState, fabric.SetConsensusStateOperation{State: stateMaintenance}); err != nil {
+			return err
+		}
+		s.setStepStatus(ctx, step, StepStatusVerifying, "", nil, nil)
+		return s.waitForChannel(ctx, networkID, name, len(config.Consenters))
+
+	case StepSwitchConsensus:
+		state, err := s.channelState(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		if stepDone(name, state, len(config.Consenters)) {
+			return nil
+		}
+		if state.ConsensusType == consensusTypeBFT {
+			return fmt.Errorf("channel uses BFT with %d consenters, expected %d", state.BFTConsenters, len(config.Consenters))
+		}
+		if state.State != stateMaintenance {
+			return fmt.Errorf("ordering service is in %s, expected %s", state.State, stateMaintenance)
+		}
+		if err := s.submit(ctx, networkID, fabric.OpMigrateToBFT, fabric.MigrateToBFTOperation{
+			Consenters: bftConsenters(config.Consenters),
+			Options:    config.Options,
+		}); err != nil {
+			return err
+		}
+		s.setStepStatus(ctx, step, StepStatusVerifying, "", nil, nil)
+		return s.waitForChannel(ctx, networkID, name, len(config.Consenters))
+
+	case StepRestartOrderers:
+		state, err := s.channelState(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		if !stepDone(StepSwitchConsensus, state, len(config.Consenters)) {
+			return fmt.Errorf("channel uses %s consensus, expected BFT", state.ConsensusType)
+		}
+		network, err := s.queries.GetNetwork(ctx, networkID)
+		if err != nil {
+			return fmt.Errorf("failed to get network: %w", err)
+		}
+		timeout := time.Duration(config.RestartTimeoutSeconds) * time.Second
+		refHeight := s.channelHeight(ctx, network, config.Consenters)
+		for _, cons := range config.Consenters {
+			s.logger.Info("Restarting orderer for BFT", "networkID", networkID, "nodeID", cons.NodeID)
+			if _, err := s.nodes.StopNode(ctx, cons.NodeID); err != nil {
+				return fmt.Errorf("failed to stop orderer %s: %w", cons.Name, err)
+			}
+			if _, err := s.nodes.StartNode(ctx, cons.NodeID); err != nil {
+				return fmt.Errorf("failed to start orderer %s: %w", cons.Name, err)
+			}
+			s.setStepStatus(ctx, step, StepStatusVerifying, "", nil, nil)
+			if err := s.waitOrderer(ctx, network, cons.NodeID, refHeight, timeout); err != nil {
+				return fmt.Errorf("orderer %s: %w", cons.Name, err)
+			}
+		}
+		return nil
+
+	case StepExitMaintenance:
+		state, err := s.channelState(ctx, networkID)
+		if err != nil {
+			return err
+		}
+		if !stepDone(name, state, len(config.Consenters)) {
+			if state.ConsensusType != consensusTypeBFT {
+				return fmt.Errorf("channel uses %s consensus, expected BFT", state.ConsensusType)
+			}
+			if err := s.submit(ctx, networkID, fabric.OpSetConsensusState, fabric.SetConsensusStateOperation{State: stateNormal}); err != nil {
+				return err
+			}
+			s.setStepStatus(ctx, step, StepStatusVerifying, "", nil, nil)
+			if err := s.waitForChannel(ctx, networkID, name, len(config.Consenters)); err != nil {
+				return err
+			}
+		}
+		return s.recordConsensus(ctx, networkID, config)
+
+	default:
+		return fmt.Errorf("unknown migration step %s", name)
+	}
+}
+
+// submit signs and sends a single config update operation to the channel
+func (s *Service) submit(ctx context.Context, networkID int64, opType fabric.ConfigUpdateOperationType, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", opType, err)
+	}
+	operation := fabric.ConfigUpdateOperation{Type: opType, Payload: raw}
+	if _, err := s.networks.UpdateFabricNetwork(ctx, networkID, []fabric.ConfigUpdateOperation{operation}); err != nil {
+		return fmt.Errorf("failed to submit %s: %w", opType, err)
+	}
+	return nil
+}
+
+// leaveMaintenance returns an etcdraft channel left in maintenance mode to normal operation
+func (s *Service) leaveMaintenance(ctx context.Context, networkID int64) error {
+	state, err := s.channelState(ctx, networkID)
+	if err != nil {
+		return err
+	}
+	if state.ConsensusType != consensusTypeEtcdRaft || state.State != stateMaintenance {
+		return nil
+	}
+	if err := s.submit(ctx, networkID, fabric.OpSetConsensusState, fabric.SetConsensusStateOperation{State: stateNormal}); err != nil {
+		return fmt.Errorf("failed to leave maintenance mode: %w", err)
+	}
+	return nil
+}
+
+// channelState reads the orderer section of the latest channel config from the orderers
+func (s *Service) channelState(ctx context.Context, networkID int64) (*channelState, error) {
+	cfg, err := s.networks.GetFabricNetworkConfigTX(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch channel config: %w", err)
+	}
+	ordConfig, err := cfg.Orderer().Configuration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orderer configuration: %w", err)
+	}
+	state := &channelState{
+		ConsensusType: ordConfig.OrdererType,
+		State:         string(ordConfig.State),
+		BFTConsenters: len(ordConfig.ConsenterMapping),
+	}
+	for _, consenter := range ordConfig.EtcdRaft.Consenters {
+		state.RaftConsenters = append(state.RaftConsenters, net.JoinHostPort(consenter.Address.Host, strconv.Itoa(consenter.Address.Port)))
+	}
+	return state, nil
+}
+
+// waitForChannel polls the channel config until the change of a step shows up
+func (s *Service) waitForChannel(ctx context.Context, networkID int64, name StepName, consenters int) error {
+	deadline := time.Now().Add(configCommitTimeout)
+	for {
+		state, err := s.channelState(ctx, networkID)
+		if err == nil && stepDone(name, state, consenters) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("config update not visible after %s: %w", configCommitTimeout, err)
+			}
+			return fmt.Errorf("config update not visible after %s: channel uses %s in %s", configCommitTimeout, state.ConsensusType, state.State)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// channelHeight is the highest block height of the channel reported by the orderers
+func (s *Service) channelHeight(ctx context.Context, network *db.Network, consenters []Consenter) int64 {
+	var height int64
+	for _, cons := range consenters {
+		if h, err := s.ordererHeight(ctx, network, cons.NodeID); err == nil && h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+func (s *Service) ordererHeight(ctx context.Context, network *db.Network, nodeID int64) (int64, error) {
+	channels, err := s.nodes.GetNodeChannels(ctx, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	for _, channel := range channels {
+		if channel.Name == network.Name {
+			return channel.BlockNum, nil
+		}
+	}
+	return 0, fmt.Errorf("orderer has not joined channel %s", network.Name)
+}
+
+// waitOrderer waits until a restarted orderer runs and its channel height reaches minHeight
+func (s *Service) waitOrderer(ctx context.Context, network *db.Network, nodeID int64, minHeight int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for {
+		node, err := s.nodes.GetNode(ctx, nodeID)
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("failed to get node: %w", err)
+		case node.Status != string(nodetypes.NodeStatusRunning):
+			lastErr = fmt.Errorf("node is %s", node.Status)
+		default:
+			height, err := s.ordererHeight(ctx, network, nodeID)
+			switch {
+			case err != nil:
+				lastErr = fmt.Errorf("failed to get block height: %w", err)
+			case height < minHeight:
+				lastErr = fmt.Errorf("orderer at block height %d, channel at %d", height, minHeight)
+			default:
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not healthy after %s: %w", timeout, lastErr)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// recordConsensus stores the new consensus in the network config so the rest of
+// the platform, such as upgrade quorum checks, treats the channel as BFT
+func (s *Service) recordConsensus(ctx context.Context, networkID int64, config migrationConfig) error {
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to get network: %w", err)
+	}
+	updated, err := markSmartBFT(network.Config.String, config.Consenters, config.Options)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.UpdateNetworkConfig(ctx, &db.UpdateNetworkConfigParams{
+		Config: sql.NullString{String: updated, Valid: true},
+		ID:     networkID,
+	}); err != nil {
+		return fmt.Errorf("failed to update network config: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) networkNodes(ctx context.Context, networkID int64) ([]*nodeservice.NodeResponse, error) {
+	networkNodes, err := s.networks.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	nodes := make([]*nodeservice.NodeResponse, 0, len(networkNodes))
+	for _, networkNode := range networkNodes {
+		if networkNode.Node != nil {
+			nodes = append(nodes, networkNode.Node)
+		}
+	}
+	return nodes, nil
+}
+
+func (s *Service) pause(ctx context.Context, migrationID int64, reason string) {
+	if err := s.setMigrationStatus(ctx, migrationID, MigrationStatusPaused, reason); err != nil {
+		s.logger.Error("Failed to pause consensus migration", "migrationID", migrationID, "error", err)
+	}
+}
+
+func (s *Service) setMigrationStatus(ctx context.Context, migrationID int64, status MigrationStatus, reason string) error {
+	var completedAt sql.NullTime
+	switch status {
+	case MigrationStatusCompleted, MigrationStatusCancelled:
+		completedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	if err := s.queries.UpdateConsensusMigrationStatus(ctx, &db.UpdateConsensusMigrationStatusParams{
+		Status:      string(status),
+		Error:       sql.NullString{String: reason, Valid: reason != ""},
+		CompletedAt: completedAt,
+		ID:          migrationID,
+	}); err != nil {
+		return fmt.Errorf("failed to update consensus migration status: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) setStepStatus(ctx context.Context, step *db.ConsensusMigrationStep, status StepStatus, reason string, startedAt, completedAt *time.Time) {
+	params := &db.UpdateConsensusMigrationStepParams{
+		Status:      string(status),
+		Error:       sql.NullString{String: reason, Valid: reason != ""},
+		StartedAt:   step.StartedAt,
+		CompletedAt: sql.NullTime{},
+		ID:          step.ID,
+	}
+	if startedAt != nil {
+		params.StartedAt = sql.NullTime{Time: *startedAt, Valid: true}
+	}
+	if completedAt != nil {
+		params.CompletedAt = sql.NullTime{Time: *completedAt, Valid: true}
+	}
+	if err := s.queries.UpdateConsensusMigrationStep(ctx, params); err != nil {
+		s.logger.Error("Failed to update migration step", "stepID", step.ID, "error", err)
+		return
+	}
+	step.Status = params.Status
+	step.Error = params.Error
+	step.StartedAt = params.StartedAt
+	step.CompletedAt = params.CompletedAt
+}
+
+// switched reports whether the consensus type change of a migration was committed
+func switched(steps []*db.ConsensusMigrationStep) bool {
+	for _, step := range steps {
+		if StepName(step.Name) == StepSwitchConsensus {
+			return StepStatus(step.Status) == StepStatusCompleted
+		}
+	}
+	return false
+}
+
+// networkConsensus returns the consensus recorded in the config of a Fabric network
+func networkConsensus(network *db.Network) string {
+	var config struct {
+		ConsensusType string `json:"consensus_type"`
+	}
+	if network.Config.Valid && network.Config.String != "" {
+		_ = json.Unmarshal([]byte(network.Config.String), &config)
+	}
+	if config.ConsensusType == "" {
+		return consensusTypeEtcdRaft
+	}
+	return config.ConsensusType
+}
+
+func bftConsenters(consenters []Consenter) []fabric.BFTConsenter {
+	result := make([]fabric.BFTConsenter, 0, len(consenters))
+	for _, cons := range consenters {
+		result = append(result, fabric.BFTConsenter{
+			ID:            cons.ID,
+			Host:          cons.Host,
+			Port:          cons.Port,
+			MSPID:         cons.MSPID,
+			Identity:      cons.Identity,
+			ClientTLSCert: cons.TLSCert,
+			ServerTLSCert: cons.TLSCert,
+		})
+	}
+	return result
+}
+
+func mapMigration(migration *db.ConsensusMigration, steps []*db.ConsensusMigrationStep) (*Migration, error) {
+	var config migrationConfig
+	if err := json.Unmarshal([]byte(migration.Config), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse migration config: %w", err)
+	}
+	result := &Migration{
+		ID:                    migration.ID,
+		NetworkID:             migration.NetworkID,
+		TargetConsensus:       migration.TargetConsensus,
+		Status:                MigrationStatus(migration.Status),
+		Consenters:            config.Consenters,
+		Options:               config.Options,
+		RestartTimeoutSeconds: config.RestartTimeoutSeconds,
+		Error:                 migration.Error.String,
+		CreatedAt:             migration.CreatedAt,
+		StartedAt:             runner.TimePtr(migration.StartedAt),
+		CompletedAt:           runner.TimePtr(migration.CompletedAt),
+		Steps:                 make([]Step, 0, len(steps)),
+	}
+	for _, step := range steps {
+		result.Steps = append(result.Steps, Step{
+			ID:          step.ID,
+			Order:       step.StepOrder,
+			Name:        StepName(step.Name),
+			Status:      StepStatus(step.Status),
+			Error:       step.Error.String,
+			StartedAt:   runner.TimePtr(step.StartedAt),
+			CompletedAt: runner.TimePtr(step.CompletedAt),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func newTestQueries(t *testing.T) *db.Queries {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	return db.New(sqlDB)
+}
+
+func TestStoreMigration(t *testing.T) {
+	ctx := context.Background()
+	queries := newTestQueries(t)
+
+	createNetwork := func(name string) int64 {
+		network, err := queries.CreateNetwork(ctx, &db.CreateNetworkParams{Name: name, Platform: "FABRIC", Status: "running"})
+		require.NoError(t, err)
+		return network.ID
+	}
+	params := func(networkID int64) *db.CreateConsensusMigrationParams {
+		return &db.CreateConsensusMigrationParams{
+			NetworkID:       networkID,
+			TargetConsensus: TargetSmartBFT,
+			Status:          string(MigrationStatusPending),
+			Config:          "{}",
+		}
+	}
+	store := func(networkID int64) (int64, error) {
+		var migrationID int64
+		err := queries.ExecTx(ctx, func(q *db.Queries) error {
+			var err error
+			migrationID, err = storeMigration(ctx, q, params(networkID))
+			return err
+		})
+		return migrationID, err
+	}
+	networkID := createNetwork("net")
+
+	// A failed transaction leaves neither the migration nor its steps behind
+	errAbort := errors.New("abort")
+	err := queries.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := storeMigration(ctx, q, params(networkID)); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+	migrations, err := queries.ListConsensusMigrationsByNetwork(ctx, networkID)
+	require.NoError(t, err)
+	assert.Empty(t, migrations)
+
+	migrationID, err := store(networkID)
+	require.NoError(t, err)
+	steps, err := queries.ListConsensusMigrationSteps(ctx, migrationID)
+	require.NoError(t, err)
+	require.Len(t, steps, len(workflow))
+	for i, step := range steps {
+		assert.Equal(t, int64(i+1), step.StepOrder)
+		assert.Equal(t, string(workflow[i]), step.Name)
+	}
+
+	// The pending migration blocks a second one
+	_, err = store(networkID)
+	assert.ErrorIs(t, err, ErrMigrationActive)
+
+	// So does an unfinished upgrade plan
+	upgrading := createNetwork("upgrading")
+	_, err = queries.CreateUpgradePlan(ctx, &db.CreateUpgradePlanParams{
+		NetworkID:            upgrading,
+		TargetVersion:        "3.1.1",
+		Status:               "pending",
+		HealthTimeoutSeconds: 300,
+	})
+	require.NoError(t, err)
+	_, err = store(upgrading)
+	assert.ErrorIs(t, err, ErrMigrationActive)
+}
@@ -0,0 +1,136 @@
+package consensus
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
+)
+
+// TargetSmartBFT is the consensus protocol channels are migrated to
+const TargetSmartBFT = "smartbft"
+
+// MigrationStatus is the state of a consensus migration
+type MigrationStatus string
+
+const (
+	MigrationStatusPending MigrationStatus = "pending"
+	MigrationStatusRunning MigrationStatus = "running"
+	// MigrationStatusPaused is set when a step fails or the server restarts mid-migration.
+	// Starting the migration again resumes from the first unfinished step.
+	MigrationStatusPaused    MigrationStatus = "paused"
+	MigrationStatusCompleted MigrationStatus = "completed"
+	MigrationStatusCancelled MigrationStatus = "cancelled"
+)
+
+// StepName identifies a step of the migration workflow
+type StepName string
+
+const (
+	// StepPreflight checks the orderers can run BFT and the mapping covers every raft consenter
+	StepPreflight StepName = "preflight"
+	// StepEnterMaintenance puts the ordering service in maintenance mode
+	StepEnterMaintenance StepName = "enter_maintenance"
+	// StepSwitchConsensus changes the consensus type to BFT with the consenter mapping
+	StepSwitchConsensus StepName = "switch_consensus"
+	// StepRestartOrderers restarts every orderer so it starts the BFT chain
+	StepRestartOrderers StepName = "restart_orderers"
+	// StepExitMaintenance returns the ordering service to normal operation
+	StepExitMaintenance StepName = "exit_maintenance"
+)
+
+// workflow is the ordered list of steps of a migration
+var workflow = []StepName{
+	StepPreflight,
+	StepEnterMaintenance,
+	StepSwitchConsensus,
+	StepRestartOrderers,
+	StepExitMaintenance,
+}
+
+// StepStatus is the state of a migration step
+type StepStatus string
+
+const (
+	StepStatusPending StepStatus = "pending"
+	StepStatusRunning StepStatus = "running"
+	// StepStatusVerifying means the change was submitted and is being checked on the channel
+	StepStatusVerifying StepStatus = "verifying"
+	StepStatusCompleted StepStatus = "completed"
+	// StepStatusFailed means the last attempt failed; resuming the migration retries the step
+	StepStatusFailed StepStatus = "failed"
+)
+
+const (
+	defaultRestartTimeout = 5 * time.Minute
+	// configCommitTimeout is how long a submitted config update may take to show up on the channel
+	configCommitTimeout = 2 * time.Minute
+	pollInterval        = 5 * time.Second
+	// minOrdererMajorVersion is the first Fabric release with BFT ordering
+	minOrdererMajorVersion = 3
+)
+
+var (
+	// ErrMigrationActive is returned when a network already has an unfinished migration
+	ErrMigrationActive = errors.New("network already has an active consensus migration")
+	// ErrInvalidMigrationState is returned when an action is not allowed in the current migration status
+	ErrInvalidMigrationState = errors.New("action not allowed in the current migration status")
+)
+
+// CreateMigrationRequest describes a migration of a Fabric channel from etcdraft to SmartBFT
+type CreateMigrationRequest struct {
+	// Options are the SmartBFT options of the channel after the migration.
+	// Fabric's sample defaults are used when empty.
+	Options *types.SmartBFTOptions `json:"options,omitempty"`
+	// RestartTimeoutSeconds is how long a restarted orderer may take to run
+	// and catch up on block height (default: 300)
+	RestartTimeoutSeconds int `json:"restartTimeoutSeconds,omitempty"`
+}
+
+// Consenter is an orderer of the BFT consenter mapping
+type Consenter struct {
+	// ID is the BFT consenter ID, unique in the channel
+	ID     uint32 `json:"id"`
+	NodeID int64  `json:"nodeId"`
+	Name   string `json:"name"`
+	Host   string `json:"host"`
+	Port   int    `json:"port"`
+	MSPID  string `json:"mspId"`
+	// Identity is the enrollment certificate the orderer signs blocks with
+	Identity string `json:"identity"`
+	TLSCert  string `json:"tlsCert"`
+}
+
+// migrationConfig is the plan stored with a migration
+type migrationConfig struct {
+	Consenters            []Consenter            `json:"consenters"`
+	Options               *types.SmartBFTOptions `json:"options,omitempty"`
+	RestartTimeoutSeconds int64                  `json:"restartTimeoutSeconds"`
+}
+
+// Migration moves a Fabric channel from etcdraft to SmartBFT
+type Migration struct {
+	ID                    int64                  `json:"id"`
+	NetworkID             int64                  `json:"networkId"`
+	TargetConsensus       string                 `json:"targetConsensus"`
+	Status                MigrationStatus        `json:"status"`
+	Consenters            []Consenter            `json:"consenters"`
+	Options               *types.SmartBFTOptions `json:"options,omitempty"`
+	RestartTimeoutSeconds int64                  `json:"restartTimeoutSeconds"`
+	Error                 string                 `json:"error,omitempty"`
+	CreatedAt             time.Time              `json:"createdAt"`
+	StartedAt             *time.Time             `json:"startedAt,omitempty"`
+	CompletedAt           *time.Time             `json:"completedAt,omitempty"`
+	Steps                 []Step                 `json:"steps"`
+}
+
+// Step is one stage of the migration workflow
+type Step struct {
+	ID          int64      `json:"id"`
+	Order       int64      `json:"order"`
+	Name        StepName   `json:"name"`
+	Status      StepStatus `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/consensus"
+)
+
+// writeMigrationError maps consensus migration errors to HTTP responses
+func writeMigrationError(w http.ResponseWriter, err error, code string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "not_found", "Network or consensus migration not found")
+	case errors.Is(err, consensus.ErrMigrationActive), errors.Is(err, consensus.ErrInvalidMigrationState):
+		writeError(w, http.StatusConflict, code, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, code, err.Error())
+	}
+}
+
+// parseMigrationID reads the network and migration IDs from the URL and checks the migration belongs to the network
+func (h *Handler) parseMigrationID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return 0, false
+	}
+	migrationID, err := strconv.ParseInt(chi.URLParam(r, "migrationId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_migration_id", "Invalid consensus migration ID")
+		return 0, false
+	}
+	migration, err := h.migrations.GetMigration(r.Context(), migrationID)
+	if err != nil {
+		writeMigrationError(w, err, "get_consensus_migration_failed")
+		return 0, false
+	}
+	if migration.NetworkID != networkID {
+		writeError(w, http.StatusNotFound, "not_found", "Network or consensus migration not found")
+		return 0, false
+	}
+	return migrationID, true
+}
+
+// @Summary Create a consensus migration
+// @Description Plan the migration of the channel from etcdraft to SmartBFT. Every orderer of the network becomes
+// @Description a BFT consenter; the network needs at least 4 orderers running Fabric 3.0 or later.
+// @Tags Fabric Networks
+// @Accept json
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param request body consensus.CreateMigrationRequest true "Consensus migration"
+// @Success 201 {object} consensus.Migration
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/consensus-migrations [post]
+func (h *Handler) CreateConsensusMigration(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	// The body is optional, an empty one plans the migration with the default options
+	var req consensus.CreateMigrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	migration, err := h.migrations.CreateMigration(r.Context(), networkID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, consensus.ErrMigrationActive):
+			writeMigrationError(w, err, "create_consensus_migration_failed")
+		default:
+			writeError(w, http.StatusBadRequest, "create_consensus_migration_failed", err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, migration)
+}
+
+// @Summary List consensus migrations
+// @Description List the consensus migrations of the network, newest first
+// @Tags Fabric Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Success 200 {array} consensus.Migration
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/consensus-migrations [get]
+func (h *Handler) ListConsensusMigrations(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	migrations, err := h.migrations.ListMigrations(r.Context(), networkID)
+	if err != nil {
+		writeMigrationError(w, err, "list_consensus_migrations_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, migrations)
+}
+
+// @Summary Get a consensus migration
+// @Description Get a consensus migration with the status of each step
+// @Tags Fabric Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param migrationId path int true "Consensus migration ID"
+// @Success 200 {object} consensus.Migration
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /networks/fabric/{id}/consensus-migrations/{migrationId} [get]
+func (h *Handler) GetConsensusMigration(w http.ResponseWriter, r *http.Request) {
+	migrationID, ok := h.parseMigrationID(w, r)
+	if !ok {
+		return
+	}
+	migration, err := h.migrations.GetMigration(r.Context(), migrationID)
+	if err != nil {
+		writeMigrationError(w, err, "get_consensus_migration_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, migration)
+}
+
+// @Summary Start a consensus migration
+// @Description Start a pending migration or resume a paused one from its first unfinished step. The steps run in
+// @Description the background: preflight checks, maintenance mode, consensus switch, orderer restarts and back to normal.
+// @Tags Fabric Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param migrationId path int true "Consensus migration ID"
+// @Success 202 {object} consensus.Migration
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/consensus-migrations/{migrationId}/start [post]
+func (h *Handler) StartConsensusMigration(w http.ResponseWriter, r *http.Request) {
+	migrationID, ok := h.parseMigrationID(w, r)
+	if !ok {
+		return
+	}
+	migration, err := h.migrations.StartMigration(r.Context(), migrationID)
+	if err != nil {
+		writeMigrationError(w, err, "start_consensus_migration_failed")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, migration)
+}
+
+// @Summary Cancel a consensus migration
+// @Description Cancel a migration whose consensus type was not switched yet. The channel leaves maintenance mode
+// @Description when the migration put it there. Once switched to BFT the migration can only be resumed.
+// @Tags Fabric Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param migrationId path int true "Consensus migration ID"
+// @Success 200 {object} consensus.Migration
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/consensus-migrations/{migrationId}/cancel [post]
+func (h *Handler) CancelConsensusMigration(w http.ResponseWriter, r *http.Request) {
+	migrationID, ok := h.parseMigrationID(w, r)
+	if !ok {
+		return
+	}
+	migration, err := h.migrations.CancelMigration(r.Context(), migrationID)
+	if err != nil {
+		writeMigrationError(w, err, "cancel_consensus_migration_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, migration)
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+)
+
+// eventStreamHeartbeat is how often an idle event stream sends a keep-alive
+const eventStreamHeartbeat = 15 * time.Second
+
+var eventStreamUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// parseEventStreamOptions reads the stream options from the request.
+// The SSE Last-Event-ID header takes precedence over the from query parameter so
+// reconnecting EventSource clients resume right after the last event they received.
+func parseEventStreamOptions(r *http.Request) (service.EventStreamOptions, error) {
+	query := r.URL.Query()
+	opts := service.EventStreamOptions{
+		Chaincode: query.Get("chaincode"),
+	}
+	for _, value := range query["event"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				opts.EventNames = append(opts.EventNames, name)
+			}
+		}
+	}
+	if len(opts.EventNames) > 0 && opts.Chaincode == "" {
+		return opts, fmt.Errorf("event filter requires a chaincode")
+	}
+
+	from := r.Header.Get("Last-Event-ID")
+	if from == "" {
+		from = query.Get("from")
+	}
+	if from != "" {
+		checkpoint, err := service.ParseEventCheckpoint(from)
+		if err != nil {
+			return opts, err
+		}
+		opts.From = checkpoint
+	}
+	return opts, nil
+}
+
+// @Summary Stream network events
+// @Description Stream new blocks, or Fabric chaincode events when a chaincode is given, as Server-Sent Events.
+// @Description The connection is upgraded to a WebSocket when requested, sending one JSON event per message.
+// @Description Every event carries a checkpoint, which is also the SSE event ID; pass it back as the from
+// @Description parameter (or Last-Event-ID header) to resume right after that event. Besu networks need a
+// @Description running node with WebSocket RPC enabled.
+// @Tags Fabric Networks, Besu Networks
+// @Produce text/event-stream
+// @Param id path int true "Network ID"
+// @Param from query string false "Checkpoint to resume from: a block number, or <block>:<txId> for chaincode events"
+// @Param chaincode query string false "Stream events of this chaincode instead of blocks (Fabric only)"
+// @Param event query string false "Comma-separated chaincode event names to deliver (default: all)"
+// @Success 200 {object} service.NetworkEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/events [get]
+// @Router /networks/besu/{id}/events [get]
+func (h *Handler) NetworkEvents(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	opts, err := parseEventStreamOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_stream_options", err.Error())
+		return
+	}
+
+	network, err := h.networkService.GetNetwork(r.Context(), networkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "network_not_found", "Network not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "get_network_failed", err.Error())
+		return
+	}
+	if opts.Chaincode != "" && network.Platform != string(service.BlockchainTypeFabric) {
+		writeError(w, http.StatusBadRequest, "invalid_stream_options", "Chaincode events are only available for Fabric networks")
+		return
+	}
+
+	events, err := h.networkService.StreamNetworkEvents(r.Context(), networkID, opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "stream_events_failed", err.Error())
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(r) {
+		h.streamEventsWebSocket(w, r, events)
+		return
+	}
+	h.streamEventsSSE(w, r, events)
+}
+
+// streamEventsSSE writes events as Server-Sent Events until the client disconnects or the stream ends
+func (h *Handler) streamEventsSSE(w http.ResponseWriter, r *http.Request, events <-chan service.NetworkEvent) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming_unsupported", "Streaming not supported")
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Checkpoint, event.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// streamEventsWebSocket upgrades the connection and writes one JSON message per event
+func (h *Handler) streamEventsWebSocket(w http.ResponseWriter, r *http.Request, events <-chan service.NetworkEvent) {
+	conn, err := eventStreamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade already replied with an HTTP error
+		return
+	}
+	defer conn.Close()
+
+	// Drain incoming frames so control messages are processed and a client close is noticed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventStreamHeartbeat)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "stream ended"))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
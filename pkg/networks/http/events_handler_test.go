@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+)
+
+func TestParseEventStreamOptions(t *testing.T) {
+	req := httptest.NewRequest("GET", "/networks/fabric/1/events?chaincode=basic&event=Created,Updated&event=Deleted&from=42", nil)
+	opts, err := parseEventStreamOptions(req)
+	require.NoError(t, err)
+	assert.Equal(t, "basic", opts.Chaincode)
+	assert.Equal(t, []string{"Created", "Updated", "Deleted"}, opts.EventNames)
+	require.NotNil(t, opts.From)
+	assert.Equal(t, service.EventCheckpoint{BlockNumber: 42}, *opts.From)
+
+	// A reconnecting EventSource resumes from its last event ID
+	req.Header.Set("Last-Event-ID", "57:tx1")
+	opts, err = parseEventStreamOptions(req)
+	require.NoError(t, err)
+	assert.Equal(t, service.EventCheckpoint{BlockNumber: 57, TransactionID: "tx1"}, *opts.From)
+
+	opts, err = parseEventStreamOptions(httptest.NewRequest("GET", "/networks/besu/1/events", nil))
+	require.NoError(t, err)
+	assert.Nil(t, opts.From)
+}
+
+func TestParseEventStreamOptionsRejectsInvalidInput(t *testing.T) {
+	_, err := parseEventStreamOptions(httptest.NewRequest("GET", "/networks/fabric/1/events?from=latest", nil))
+	assert.Error(t, err)
+
+	_, err = parseEventStreamOptions(httptest.NewRequest("GET", "/networks/fabric/1/events?event=Created", nil))
+	assert.Error(t, err)
+}
+
+func TestEventCheckpointRoundTrip(t *testing.T) {
+	for _, checkpoint := range []service.EventCheckpoint{
+		{BlockNumber: 0},
+		{BlockNumber: 12},
+		{BlockNumber: 12, TransactionID: "abc123"},
+	} {
+		parsed, err := service.ParseEventCheckpoint(checkpoint.String())
+		require.NoError(t, err)
+		assert.Equal(t, checkpoint, *parsed)
+	}
+}
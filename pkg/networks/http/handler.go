@@ -1,12 +1,15 @@
 package http
 
 import (
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -14,11 +17,15 @@ import (
 	"github.com/hyperledger/fabric-config/configtx"
 
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/fabric/connprofile"
 	httpchainlaunch "github.com/chainlaunch/chainlaunch/pkg/http"
 	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/consensus"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/indexer"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/upgrade"
 	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
 	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
 )
@@ -27,14 +34,20 @@ import (
 type Handler struct {
 	networkService *service.NetworkService
 	nodeService    *nodeservice.NodeService
+	indexer        *indexer.Service
+	upgrades       *upgrade.Service
+	migrations     *consensus.Service
 	validate       *validator.Validate
 }
 
 // NewHandler creates a new network handler
-func NewHandler(networkService *service.NetworkService, nodeService *nodeservice.NodeService) *Handler {
+func NewHandler(networkService *service.NetworkService, nodeService *nodeservice.NodeService, indexer *indexer.Service, upgrades *upgrade.Service, migrations *consensus.Service) *Handler {
 	return &Handler{
 		networkService: networkService,
 		nodeService:    nodeService,
+		indexer:        indexer,
+		upgrades:       upgrades,
+		migrations:     migrations,
 		validate:       validator.New(),
 	}
 }
@@ -50,6 +63,7 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Post("/", h.FabricNetworkCreate)
 		r.Delete("/{id}", h.FabricNetworkDelete)
 		r.Post("/{id}/peers/{peerId}/join", h.FabricNetworkJoinPeer)
+		r.Post("/{id}/peers/{peerId}/join-snapshot", h.FabricNetworkJoinPeerFromSnapshot)
 		r.Post("/{id}/orderers/{ordererId}/join", h.FabricNetworkJoinOrderer)
 		r.Delete("/{id}/peers/{peerId}", h.FabricNetworkRemovePeer)
 		r.Delete("/{id}/orderers/{ordererId}", h.FabricNetworkRemoveOrderer)
@@ -73,6 +87,23 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/{id}/transactions/{txId}", h.FabricGetTransaction)
 		r.Post("/{id}/organization-crl", h.UpdateOrganizationCRL)
 		r.Get("/{id}/map", h.NetworkMap)
+		r.Get("/{id}/events", h.NetworkEvents)
+		r.Get("/{id}/index", h.GetNetworkIndex)
+		r.Post("/{id}/index", h.EnableNetworkIndex)
+		r.Delete("/{id}/index", h.DisableNetworkIndex)
+		r.Get("/{id}/index/transactions", h.SearchIndexedTransactions)
+		r.Get("/{id}/index/transactions/{txId}", h.GetIndexedTransaction)
+		r.Get("/{id}/upgrades", h.ListUpgradePlans)
+		r.Post("/{id}/upgrades", h.CreateUpgradePlan)
+		r.Get("/{id}/upgrades/{planId}", h.GetUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/start", h.StartUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/cancel", h.CancelUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/rollback", h.RollbackUpgradePlan)
+		r.Get("/{id}/consensus-migrations", h.ListConsensusMigrations)
+		r.Post("/{id}/consensus-migrations", h.CreateConsensusMigration)
+		r.Get("/{id}/consensus-migrations/{migrationId}", h.GetConsensusMigration)
+		r.Post("/{id}/consensus-migrations/{migrationId}/start", h.StartConsensusMigration)
+		r.Post("/{id}/consensus-migrations/{migrationId}/cancel", h.CancelConsensusMigration)
 		r.Put("/{id}/genesis", h.UpdateGenesisBlock)
 	})
 
@@ -100,6 +131,13 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/{id}/transactions/{txId}", h.FabricXGetTransaction)
 		r.Get("/{id}/namespace-policies", h.FabricXGetNamespacePolicies)
 		r.Get("/{id}/state/{namespace}", h.FabricXGetNamespaceState)
+
+		// Persistent block index
+		r.Get("/{id}/index", h.GetNetworkIndex)
+		r.Post("/{id}/index", h.EnableNetworkIndex)
+		r.Delete("/{id}/index", h.DisableNetworkIndex)
+		r.Get("/{id}/index/transactions", h.SearchIndexedTransactions)
+		r.Get("/{id}/index/transactions/{txId}", h.GetIndexedTransaction)
 	})
 
 	// Besu network routes with resource middleware
@@ -114,6 +152,13 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Delete("/{id}", h.BesuNetworkDelete)
 		r.Get("/{id}/nodes", h.BesuNetworkGetNodes)
 		r.Get("/{id}/map", h.NetworkMap)
+		r.Get("/{id}/events", h.NetworkEvents)
+		r.Get("/{id}/upgrades", h.ListUpgradePlans)
+		r.Post("/{id}/upgrades", h.CreateUpgradePlan)
+		r.Get("/{id}/upgrades/{planId}", h.GetUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/start", h.StartUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/cancel", h.CancelUpgradePlan)
+		r.Post("/{id}/upgrades/{planId}/rollback", h.RollbackUpgradePlan)
 		r.Put("/{id}/genesis", h.UpdateGenesisBlock)
 	})
 }
@@ -855,12 +900,17 @@ func (h *Handler) FabricNetworkSetAnchorPeers(w http.ResponseWriter, r *http.Req
 }
 
 // @Summary Get network configuration
-// @Description Get the network configuration as YAML
+// @Description Get the connection settings of an organization. The default fabric-sdk format is the YAML network config with the admin identity;
+// @Description gateway, connection-profile, caliper and explorer render Fabric Gateway settings, a common connection profile, a Caliper network config
+// @Description and a Hyperledger Explorer profile. identities selects the identities to embed: admin, client or IDs of keys issued by the organization.
 // @Tags Fabric Networks
 // @Produce text/yaml
+// @Produce json
 // @Param id path int true "Network ID"
 // @Param orgId path int true "Organization ID"
-// @Success 200 {string} string "Network configuration YAML"
+// @Param format query string false "Output format" Enums(fabric-sdk, gateway, connection-profile, caliper, explorer)
+// @Param identities query string false "Comma-separated identities to embed: admin, client or key IDs"
+// @Success 200 {string} string "Network configuration"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -878,20 +928,35 @@ func (h *Handler) FabricNetworkGetOrganizationConfig(w http.ResponseWriter, r *h
 		return
 	}
 
-	configYAML, err := h.networkService.GetNetworkConfig(r.Context(), networkID, orgID)
+	format, err := connprofile.ParseFormat(r.URL.Query().Get("format"))
 	if err != nil {
-		if err.Error() == "sql: no rows in result set" {
+		writeError(w, http.StatusBadRequest, "invalid_format", err.Error())
+		return
+	}
+	var identities []string
+	for _, identity := range strings.Split(r.URL.Query().Get("identities"), ",") {
+		if identity = strings.TrimSpace(identity); identity != "" {
+			identities = append(identities, identity)
+		}
+	}
+
+	config, err := h.networkService.GetConnectionProfile(r.Context(), networkID, orgID, format, identities)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
 			writeError(w, http.StatusNotFound, "network_not_found", "Network or organization not found")
 			return
 		}
+		if stderrors.Is(err, connprofile.ErrIdentity) {
+			writeError(w, http.StatusBadRequest, "invalid_identities", err.Error())
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "get_network_config_failed", err.Error())
 		return
 	}
 
-	// Set content type to YAML
-	w.Header().Set("Content-Type", "text/yaml")
+	w.Header().Set("Content-Type", format.ContentType())
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(configYAML))
+	w.Write(config)
 }
 
 // @Summary Get a Fabric network by slug
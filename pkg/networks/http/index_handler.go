@@ -0,0 +1,216 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/indexer"
+)
+
+// EnableNetworkIndexRequest represents the request to enable block indexing
+type EnableNetworkIndexRequest struct {
+	// FromBlock is the first block indexed by a new indexer (default: 0)
+	FromBlock *uint64 `json:"fromBlock,omitempty"`
+}
+
+// parseIndexSearchFilter reads the transaction search filter from the request query
+func parseIndexSearchFilter(r *http.Request) (indexer.SearchFilter, error) {
+	query := r.URL.Query()
+	filter := indexer.SearchFilter{
+		Chaincode:      query.Get("chaincode"),
+		Function:       query.Get("function"),
+		CreatorMSPID:   query.Get("mspId"),
+		ValidationCode: query.Get("validationCode"),
+		Key:            query.Get("key"),
+	}
+	for name, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s time, expected RFC3339: %w", name, err)
+			}
+			*target = &t
+		}
+	}
+	for name, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := query.Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return filter, fmt.Errorf("invalid %s", name)
+			}
+			*target = n
+		}
+	}
+	return filter, nil
+}
+
+// writeIndexError maps indexer errors to HTTP responses
+func writeIndexError(w http.ResponseWriter, err error, code string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "not_found", "Block indexer or transaction not found")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, code, err.Error())
+}
+
+// @Summary Enable block indexing
+// @Description Start indexing the blocks of the network into the database so its transactions can be searched.
+// @Description A new indexer starts at fromBlock (default: genesis); a disabled one resumes where it stopped.
+// @Tags Fabric Networks, FabricX Networks
+// @Accept json
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param request body EnableNetworkIndexRequest false "Indexer options"
+// @Success 200 {object} indexer.Status
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/index [post]
+// @Router /networks/fabricx/{id}/index [post]
+func (h *Handler) EnableNetworkIndex(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	var req EnableNetworkIndexRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+			return
+		}
+	}
+
+	status, err := h.indexer.EnableNetwork(r.Context(), networkID, req.FromBlock)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "network_not_found", "Network not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "enable_index_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// @Summary Get block indexing status
+// @Description Get the progress of the network block indexer
+// @Tags Fabric Networks, FabricX Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Success 200 {object} indexer.Status
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/index [get]
+// @Router /networks/fabricx/{id}/index [get]
+func (h *Handler) GetNetworkIndex(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	status, err := h.indexer.GetStatus(r.Context(), networkID)
+	if err != nil {
+		writeIndexError(w, err, "get_index_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// @Summary Disable block indexing
+// @Description Stop indexing the network. With purge, the indexed data is deleted as well.
+// @Tags Fabric Networks, FabricX Networks
+// @Param id path int true "Network ID"
+// @Param purge query bool false "Delete the indexed blocks and transactions"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/index [delete]
+// @Router /networks/fabricx/{id}/index [delete]
+func (h *Handler) DisableNetworkIndex(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	purge, _ := strconv.ParseBool(r.URL.Query().Get("purge"))
+	if err := h.indexer.DisableNetwork(r.Context(), networkID, purge); err != nil {
+		writeIndexError(w, err, "disable_index_failed")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Search indexed transactions
+// @Description Search the indexed transactions of the network, newest first. For FabricX networks the
+// @Description chaincode filter matches the first application namespace touched by the transaction.
+// @Tags Fabric Networks, FabricX Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param from query string false "Only transactions at or after this time (RFC3339)"
+// @Param to query string false "Only transactions at or before this time (RFC3339)"
+// @Param chaincode query string false "Chaincode name"
+// @Param function query string false "Invoked chaincode function"
+// @Param mspId query string false "MSP ID of the transaction creator"
+// @Param validationCode query string false "Validation code, e.g. VALID or MVCC_READ_CONFLICT"
+// @Param key query string false "Only transactions that read or wrote this key"
+// @Param limit query int false "Page size (default: 50, max: 500)"
+// @Param offset query int false "Number of transactions to skip"
+// @Success 200 {object} indexer.SearchResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/index/transactions [get]
+// @Router /networks/fabricx/{id}/index/transactions [get]
+func (h *Handler) SearchIndexedTransactions(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	filter, err := parseIndexSearchFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_search_filter", err.Error())
+		return
+	}
+	result, err := h.indexer.Search(r.Context(), networkID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "search_transactions_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary Get indexed transaction
+// @Description Get an indexed transaction by ID, including the keys it read and wrote
+// @Tags Fabric Networks, FabricX Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param txId path string true "Transaction ID"
+// @Success 200 {object} indexer.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/index/transactions/{txId} [get]
+// @Router /networks/fabricx/{id}/index/transactions/{txId} [get]
+func (h *Handler) GetIndexedTransaction(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	tx, err := h.indexer.GetTransaction(r.Context(), networkID, chi.URLParam(r, "txId"))
+	if err != nil {
+		writeIndexError(w, err, "get_transaction_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, tx)
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIndexSearchFilter(t *testing.T) {
+	req := httptest.NewRequest("GET", "/networks/fabric/1/index/transactions?chaincode=basic&function=CreateAsset&mspId=Org1MSP&validationCode=VALID&key=asset1&from=2026-01-01T00:00:00Z&limit=20&offset=40", nil)
+	filter, err := parseIndexSearchFilter(req)
+	require.NoError(t, err)
+	assert.Equal(t, "basic", filter.Chaincode)
+	assert.Equal(t, "CreateAsset", filter.Function)
+	assert.Equal(t, "Org1MSP", filter.CreatorMSPID)
+	assert.Equal(t, "VALID", filter.ValidationCode)
+	assert.Equal(t, "asset1", filter.Key)
+	require.NotNil(t, filter.From)
+	assert.True(t, filter.From.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, filter.To)
+	assert.Equal(t, 20, filter.Limit)
+	assert.Equal(t, 40, filter.Offset)
+}
+
+func TestParseIndexSearchFilterRejectsInvalidInput(t *testing.T) {
+	for _, query := range []string{"from=yesterday", "to=2026-01-01", "limit=ten", "offset=-1"} {
+		_, err := parseIndexSearchFilter(httptest.NewRequest("GET", "/networks/fabric/1/index/transactions?"+query, nil))
+		assert.Error(t, err, query)
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+)
+
+// @Summary Join peer to Fabric network from a ledger snapshot
+// @Description Join a peer to the channel from a ledger snapshot of another peer instead of replaying the chain
+// @Description from the genesis block. The snapshot is requested on the source peer when needed, copied to the
+// @Description peer's host and imported; the request returns once the peer joined.
+// @Tags Fabric Networks
+// @Accept json
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param peerId path int true "Peer ID"
+// @Param request body service.JoinPeerFromSnapshotRequest false "Snapshot source"
+// @Success 200 {object} service.JoinPeerFromSnapshotResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/peers/{peerId}/join-snapshot [post]
+func (h *Handler) FabricNetworkJoinPeerFromSnapshot(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+
+	peerID, err := strconv.ParseInt(chi.URLParam(r, "peerId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_peer_id", "Invalid peer ID")
+		return
+	}
+
+	// The body is optional, an empty one snapshots the most up to date peer at its last block
+	var req service.JoinPeerFromSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	result, err := h.networkService.JoinPeerToNetworkFromSnapshot(r.Context(), networkID, peerID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "join_peer_from_snapshot_failed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
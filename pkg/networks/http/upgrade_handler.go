@@ -0,0 +1,217 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/upgrade"
+)
+
+// writeUpgradeError maps upgrade errors to HTTP responses
+func writeUpgradeError(w http.ResponseWriter, err error, code string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "not_found", "Network or upgrade plan not found")
+	case errors.Is(err, upgrade.ErrPlanActive), errors.Is(err, upgrade.ErrInvalidPlanState):
+		writeError(w, http.StatusConflict, code, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, code, err.Error())
+	}
+}
+
+// parseUpgradePlanID reads the network and plan IDs from the URL and checks the plan belongs to the network
+func (h *Handler) parseUpgradePlanID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return 0, false
+	}
+	planID, err := strconv.ParseInt(chi.URLParam(r, "planId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_plan_id", "Invalid upgrade plan ID")
+		return 0, false
+	}
+	plan, err := h.upgrades.GetPlan(r.Context(), planID)
+	if err != nil {
+		writeUpgradeError(w, err, "get_upgrade_plan_failed")
+		return 0, false
+	}
+	if plan.NetworkID != networkID {
+		writeError(w, http.StatusNotFound, "not_found", "Network or upgrade plan not found")
+		return 0, false
+	}
+	return planID, true
+}
+
+// @Summary Create an upgrade plan
+// @Description Plan a rolling upgrade of the network nodes to a new Fabric or Besu version.
+// @Description Orderers are upgraded before peers, one node at a time. The plan is rejected when taking
+// @Description a single consenter down would break the raft, BFT or QBFT quorum, unless allowDowntime is set.
+// @Tags Fabric Networks, Besu Networks
+// @Accept json
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param request body upgrade.CreatePlanRequest true "Upgrade plan"
+// @Success 201 {object} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades [post]
+// @Router /networks/besu/{id}/upgrades [post]
+func (h *Handler) CreateUpgradePlan(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	var req upgrade.CreatePlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeError(w, http.StatusBadRequest, "validation_failed", err.Error())
+		return
+	}
+
+	plan, err := h.upgrades.CreatePlan(r.Context(), networkID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, upgrade.ErrPlanActive):
+			writeUpgradeError(w, err, "create_upgrade_plan_failed")
+		default:
+			writeError(w, http.StatusBadRequest, "create_upgrade_plan_failed", err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, plan)
+}
+
+// @Summary List upgrade plans
+// @Description List the upgrade plans of the network, newest first
+// @Tags Fabric Networks, Besu Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Success 200 {array} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades [get]
+// @Router /networks/besu/{id}/upgrades [get]
+func (h *Handler) ListUpgradePlans(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_network_id", "Invalid network ID")
+		return
+	}
+	plans, err := h.upgrades.ListPlans(r.Context(), networkID)
+	if err != nil {
+		writeUpgradeError(w, err, "list_upgrade_plans_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, plans)
+}
+
+// @Summary Get an upgrade plan
+// @Description Get an upgrade plan with the status of each node step
+// @Tags Fabric Networks, Besu Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param planId path int true "Upgrade plan ID"
+// @Success 200 {object} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades/{planId} [get]
+// @Router /networks/besu/{id}/upgrades/{planId} [get]
+func (h *Handler) GetUpgradePlan(w http.ResponseWriter, r *http.Request) {
+	planID, ok := h.parseUpgradePlanID(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.upgrades.GetPlan(r.Context(), planID)
+	if err != nil {
+		writeUpgradeError(w, err, "get_upgrade_plan_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// @Summary Start an upgrade plan
+// @Description Start a pending plan or resume a paused one. Nodes are upgraded in the background; a node that
+// @Description doesn't become healthy and catch up on block height is rolled back and the plan is paused.
+// @Tags Fabric Networks, Besu Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param planId path int true "Upgrade plan ID"
+// @Success 202 {object} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades/{planId}/start [post]
+// @Router /networks/besu/{id}/upgrades/{planId}/start [post]
+func (h *Handler) StartUpgradePlan(w http.ResponseWriter, r *http.Request) {
+	planID, ok := h.parseUpgradePlanID(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.upgrades.StartPlan(r.Context(), planID)
+	if err != nil {
+		writeUpgradeError(w, err, "start_upgrade_plan_failed")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, plan)
+}
+
+// @Summary Cancel an upgrade plan
+// @Description Cancel an unfinished plan. A running plan stops after the node being upgraded.
+// @Tags Fabric Networks, Besu Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param planId path int true "Upgrade plan ID"
+// @Success 200 {object} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades/{planId}/cancel [post]
+// @Router /networks/besu/{id}/upgrades/{planId}/cancel [post]
+func (h *Handler) CancelUpgradePlan(w http.ResponseWriter, r *http.Request) {
+	planID, ok := h.parseUpgradePlanID(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.upgrades.CancelPlan(r.Context(), planID)
+	if err != nil {
+		writeUpgradeError(w, err, "cancel_upgrade_plan_failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// @Summary Roll back an upgrade plan
+// @Description Move the upgraded nodes back to their previous version, newest first, in the background
+// @Tags Fabric Networks, Besu Networks
+// @Produce json
+// @Param id path int true "Network ID"
+// @Param planId path int true "Upgrade plan ID"
+// @Success 202 {object} upgrade.Plan
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /networks/fabric/{id}/upgrades/{planId}/rollback [post]
+// @Router /networks/besu/{id}/upgrades/{planId}/rollback [post]
+func (h *Handler) RollbackUpgradePlan(w http.ResponseWriter, r *http.Request) {
+	planID, ok := h.parseUpgradePlanID(w, r)
+	if !ok {
+		return
+	}
+	plan, err := h.upgrades.RollbackPlan(r.Context(), planID)
+	if err != nil {
+		writeUpgradeError(w, err, "rollback_upgrade_plan_failed")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, plan)
+}
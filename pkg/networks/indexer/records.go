@@ -0,0 +1,96 @@
+package indexer
+
+import (
+	"strings"
+
+	fabricblock "github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric/block"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabricx"
+)
+
+// fabricBlockRecord converts a decoded Fabric block into the rows stored by the indexer
+func fabricBlockRecord(blk *fabricblock.Block) blockRecord {
+	rec := blockRecord{
+		Number:   uint64(blk.Number),
+		DataHash: blk.DataHash,
+		Time:     blk.CreatedAt,
+	}
+	for i, tx := range blk.Transactions {
+		if tx == nil {
+			continue
+		}
+		txRec := txRecord{
+			Index:          i,
+			TxID:           tx.ID,
+			Type:           string(tx.Type),
+			Chaincode:      tx.ChaincodeID,
+			Function:       tx.Function,
+			CreatorMSPID:   tx.CreatorMSPID,
+			ValidationCode: tx.ValidationCode,
+			EventName:      tx.Event.Name,
+		}
+		if !tx.CreatedAt.IsZero() {
+			createdAt := tx.CreatedAt
+			txRec.Timestamp = &createdAt
+		}
+		for _, read := range tx.Reads {
+			txRec.Keys = append(txRec.Keys, TransactionKey{Namespace: read.ChaincodeID, Key: read.Key, Access: AccessRead})
+		}
+		for _, write := range tx.Writes {
+			access := AccessWrite
+			if write.Deleted {
+				access = AccessDelete
+			}
+			txRec.Keys = append(txRec.Keys, TransactionKey{Namespace: write.ChaincodeID, Key: write.Key, Access: access})
+		}
+		rec.Transactions = append(rec.Transactions, txRec)
+	}
+	return rec
+}
+
+// fabricXBlockRecord converts a decoded FabricX block into the rows stored by the indexer.
+// FabricX transactions don't invoke a chaincode, so the first application namespace they
+// touch is recorded as the chaincode and the first endorser as the creator.
+func fabricXBlockRecord(blk fabricx.IndexedBlock) blockRecord {
+	rec := blockRecord{
+		Number:   blk.Number,
+		DataHash: blk.DataHash,
+	}
+	for _, tx := range blk.Transactions {
+		txRec := txRecord{
+			Index:          tx.Index,
+			ValidationCode: tx.Status,
+		}
+		if tx.Tx == nil {
+			rec.Transactions = append(rec.Transactions, txRec)
+			continue
+		}
+		txRec.TxID = tx.Tx.TxID
+		txRec.Type = tx.Tx.Type
+		if !tx.Tx.Timestamp.IsZero() {
+			timestamp := tx.Tx.Timestamp
+			txRec.Timestamp = &timestamp
+			if rec.Time == nil {
+				rec.Time = &timestamp
+			}
+		}
+		if len(tx.Tx.Endorsers) > 0 {
+			txRec.CreatorMSPID = tx.Tx.Endorsers[0].MspID
+		}
+		for _, ns := range tx.Tx.Namespaces {
+			if txRec.Chaincode == "" && !strings.HasPrefix(ns.NsID, "_") {
+				txRec.Chaincode = ns.NsID
+			}
+			for _, read := range ns.Reads {
+				txRec.Keys = append(txRec.Keys, TransactionKey{Namespace: ns.NsID, Key: read.Key, Access: AccessRead})
+			}
+			for _, rw := range ns.ReadWrites {
+				txRec.Keys = append(txRec.Keys, TransactionKey{Namespace: ns.NsID, Key: rw.Key, Access: AccessWrite})
+			}
+			for _, write := range ns.BlindWrites {
+				txRec.Keys = append(txRec.Keys, TransactionKey{Namespace: ns.NsID, Key: write.Key, Access: AccessWrite})
+			}
+		}
+		rec.Transactions = append(rec.Transactions, txRec)
+	}
+	return rec
+}
@@ -0,0 +1,108 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fabricblock "github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric/block"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabricx"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabricx/explorer/decoder"
+)
+
+func TestFabricBlockRecord(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	rec := fabricBlockRecord(&fabricblock.Block{
+		Number:    7,
+		DataHash:  "abcd",
+		CreatedAt: &createdAt,
+		Transactions: []*fabricblock.Transaction{
+			{
+				ID:             "tx1",
+				Type:           fabricblock.ENDORSER_TRANSACTION,
+				CreatedAt:      createdAt,
+				ChaincodeID:    "basic",
+				Function:       "TransferAsset",
+				CreatorMSPID:   "Org1MSP",
+				ValidationCode: "VALID",
+				Event:          fabricblock.TransactionEvent{Name: "Transferred"},
+				Reads:          []*fabricblock.TransactionRead{{ChaincodeID: "basic", Key: "asset1"}},
+				Writes: []*fabricblock.TransactionWrite{
+					{ChaincodeID: "basic", Key: "asset1"},
+					{ChaincodeID: "basic", Key: "asset2", Deleted: true},
+				},
+			},
+			{ID: "cfg", Type: fabricblock.CONFIG},
+		},
+	})
+
+	assert.Equal(t, uint64(7), rec.Number)
+	assert.Equal(t, "abcd", rec.DataHash)
+	require.Len(t, rec.Transactions, 2)
+
+	tx := rec.Transactions[0]
+	assert.Equal(t, "tx1", tx.TxID)
+	assert.Equal(t, "ENDORSER_TRANSACTION", tx.Type)
+	assert.Equal(t, "TransferAsset", tx.Function)
+	assert.Equal(t, "Org1MSP", tx.CreatorMSPID)
+	assert.Equal(t, "Transferred", tx.EventName)
+	require.NotNil(t, tx.Timestamp)
+	assert.Equal(t, []TransactionKey{
+		{Namespace: "basic", Key: "asset1", Access: AccessRead},
+		{Namespace: "basic", Key: "asset1", Access: AccessWrite},
+		{Namespace: "basic", Key: "asset2", Access: AccessDelete},
+	}, tx.Keys)
+
+	// Transactions without a creation time are stored without a timestamp
+	assert.Equal(t, 1, rec.Transactions[1].Index)
+	assert.Nil(t, rec.Transactions[1].Timestamp)
+}
+
+func TestFabricXBlockRecord(t *testing.T) {
+	timestamp := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	rec := fabricXBlockRecord(fabricx.IndexedBlock{
+		Number:   3,
+		DataHash: "ef01",
+		Transactions: []fabricx.IndexedTx{
+			{
+				Index:  0,
+				Status: "COMMITTED",
+				Tx: &decoder.DecodedTx{
+					TxID:      "tx1",
+					Type:      "ENDORSER_TRANSACTION",
+					Timestamp: timestamp,
+					Namespaces: []decoder.DecodedNS{
+						{NsID: "_meta", Reads: []decoder.DecodedRead{{Key: "policy"}}},
+						{
+							NsID:        "tokens",
+							ReadWrites:  []decoder.DecodedRW{{Key: "balance/alice"}},
+							BlindWrites: []decoder.DecodedWrite{{Key: "balance/bob"}},
+						},
+					},
+					Endorsers: []decoder.DecodedEndorser{{MspID: "Org1MSP"}, {MspID: "Org2MSP"}},
+				},
+			},
+			{Index: 1, Status: "MALFORMED_BAD_ENVELOPE"},
+		},
+	})
+
+	assert.Equal(t, uint64(3), rec.Number)
+	require.NotNil(t, rec.Time)
+	assert.Equal(t, timestamp, *rec.Time)
+	require.Len(t, rec.Transactions, 2)
+
+	tx := rec.Transactions[0]
+	assert.Equal(t, "tokens", tx.Chaincode, "system namespaces are not used as the chaincode")
+	assert.Equal(t, "Org1MSP", tx.CreatorMSPID)
+	assert.Equal(t, "COMMITTED", tx.ValidationCode)
+	assert.Equal(t, []TransactionKey{
+		{Namespace: "_meta", Key: "policy", Access: AccessRead},
+		{Namespace: "tokens", Key: "balance/alice", Access: AccessWrite},
+		{Namespace: "tokens", Key: "balance/bob", Access: AccessWrite},
+	}, tx.Keys)
+
+	// Undecodable envelopes keep their position and status
+	assert.Equal(t, txRecord{Index: 1, ValidationCode: "MALFORMED_BAD_ENVELOPE"}, rec.Transactions[1])
+}
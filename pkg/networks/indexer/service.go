@@ -0,0 +1,509 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+)
+
+const (
+	// defaultSyncInterval is how often enabled indexers are (re)started and
+	// FabricX ledgers are polled for new blocks
+	defaultSyncInterval = 10 * time.Second
+	// fabricXBatchSize is the number of FabricX blocks fetched per sidecar connection
+	fabricXBatchSize   = 50
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+)
+
+// Service follows the ledgers of the networks that have indexing enabled and
+// stores their blocks, transactions and touched keys in the database
+type Service struct {
+	queries  *db.Queries
+	networks *service.NetworkService
+	logger   *logger.Logger
+	interval time.Duration
+
+	mu      sync.Mutex
+	running map[int64]*runner
+	stopCh  chan struct{}
+}
+
+// runner is the indexing goroutine of a single network
+type runner struct {
+	cancel context.CancelFunc
+}
+
+// NewService creates a new block indexer service
+func NewService(queries *db.Queries, networks *service.NetworkService, logger *logger.Logger) *Service {
+	return &Service{
+		queries:  queries,
+		networks: networks,
+		logger:   logger,
+		interval: defaultSyncInterval,
+		running:  make(map[int64]*runner),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start runs the indexers of all enabled networks until ctx is cancelled or Stop is called.
+// Indexers that fail are restarted from their last checkpoint on the next sync.
+func (s *Service) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer s.stopAll()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sync(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.sync(ctx)
+		}
+	}
+}
+
+// Stop stops the indexer service
+func (s *Service) Stop() {
+	close(s.stopCh)
+}
+
+// sync starts runners for newly enabled indexers and stops the ones that were disabled
+func (s *Service) sync(ctx context.Context) {
+	indexers, err := s.queries.ListEnabledBlockIndexers(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list block indexers", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enabled := make(map[int64]bool, len(indexers))
+	for _, idx := range indexers {
+		enabled[idx.NetworkID] = true
+		if _, ok := s.running[idx.NetworkID]; ok {
+			continue
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		r := &runner{cancel: cancel}
+		s.running[idx.NetworkID] = r
+		go s.run(runCtx, r, idx.NetworkID, idx.NextBlock)
+	}
+	for networkID, r := range s.running {
+		if !enabled[networkID] {
+			r.cancel()
+			delete(s.running, networkID)
+		}
+	}
+}
+
+func (s *Service) stopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for networkID, r := range s.running {
+		r.cancel()
+		delete(s.running, networkID)
+	}
+}
+
+func (s *Service) isRunning(networkID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.running[networkID]
+	return ok
+}
+
+// run indexes a network from nextBlock until ctx is cancelled or indexing fails
+func (s *Service) run(ctx context.Context, r *runner, networkID int64, nextBlock int64) {
+	defer func() {
+		r.cancel()
+		s.mu.Lock()
+		if s.running[networkID] == r {
+			delete(s.running, networkID)
+		}
+		s.mu.Unlock()
+	}()
+
+	err := s.index(ctx, networkID, nextBlock)
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+	s.logger.Warn("Block indexer stopped", "networkID", networkID, "error", err)
+	if err := s.queries.UpdateBlockIndexerError(context.Background(), &db.UpdateBlockIndexerErrorParams{
+		LastError: sql.NullString{String: err.Error(), Valid: true},
+		NetworkID: networkID,
+	}); err != nil {
+		s.logger.Error("Failed to record block indexer error", "networkID", networkID, "error", err)
+	}
+}
+
+func (s *Service) index(ctx context.Context, networkID int64, nextBlock int64) error {
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to get network: %w", err)
+	}
+	switch network.Platform {
+	case string(service.BlockchainTypeFabric):
+		return s.indexFabric(ctx, networkID, uint64(nextBlock))
+	case string(service.BlockchainTypeFabricX):
+		return s.indexFabricX(ctx, networkID, uint64(nextBlock))
+	default:
+		return fmt.Errorf("block indexing is not supported for platform %s", network.Platform)
+	}
+}
+
+// indexFabric follows the Fabric block stream, which also delivers the blocks
+// committed while the indexer was stopped
+func (s *Service) indexFabric(ctx context.Context, networkID int64, nextBlock uint64) error {
+	events, err := s.networks.StreamNetworkEvents(ctx, networkID, service.EventStreamOptions{
+		From: &service.EventCheckpoint{BlockNumber: nextBlock},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream blocks: %w", err)
+	}
+	for event := range events {
+		if event.FabricBlock == nil {
+			continue
+		}
+		if err := s.storeBlock(ctx, networkID, fabricBlockRecord(event.FabricBlock)); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("block stream closed")
+}
+
+// indexFabricX polls the FabricX sidecar for new blocks, fetching them in batches
+func (s *Service) indexFabricX(ctx context.Context, networkID int64, nextBlock uint64) error {
+	for {
+		blocks, height, err := s.networks.GetFabricXIndexedBlocks(ctx, networkID, nextBlock, fabricXBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get blocks: %w", err)
+		}
+		for _, blk := range blocks {
+			if err := s.storeBlock(ctx, networkID, fabricXBlockRecord(blk)); err != nil {
+				return err
+			}
+			nextBlock = blk.Number + 1
+		}
+		if nextBlock < height {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// storeBlock replaces any rows of the block and advances the indexer checkpoint in a single
+// transaction, so a block re-delivered after a restart is stored only once and a failure
+// midway leaves neither a partial block nor a checkpoint past it
+func (s *Service) storeBlock(ctx context.Context, networkID int64, rec blockRecord) error {
+	return s.queries.ExecTx(ctx, func(q *db.Queries) error {
+		return storeBlockRecord(ctx, q, networkID, rec)
+	})
+}
+
+// storeBlockRecord writes the rows of a block with the given queries
+func storeBlockRecord(ctx context.Context, q *db.Queries, networkID int64, rec blockRecord) error {
+	blockNumber := int64(rec.Number)
+	if err := q.DeleteIndexedTransactionsByBlock(ctx, &db.DeleteIndexedTransactionsByBlockParams{
+		NetworkID:   networkID,
+		BlockNumber: blockNumber,
+	}); err != nil {
+		return fmt.Errorf("failed to delete indexed transactions of block %d: %w", rec.Number, err)
+	}
+	if err := q.DeleteIndexedBlock(ctx, &db.DeleteIndexedBlockParams{
+		NetworkID:   networkID,
+		BlockNumber: blockNumber,
+	}); err != nil {
+		return fmt.Errorf("failed to delete indexed block %d: %w", rec.Number, err)
+	}
+
+	if err := q.CreateIndexedBlock(ctx, &db.CreateIndexedBlockParams{
+		NetworkID:   networkID,
+		BlockNumber: blockNumber,
+		DataHash:    rec.DataHash,
+		TxCount:     int64(len(rec.Transactions)),
+		BlockTime:   nullTime(rec.Time),
+	}); err != nil {
+		return fmt.Errorf("failed to store block %d: %w", rec.Number, err)
+	}
+	for _, tx := range rec.Transactions {
+		stored, err := q.CreateIndexedTransaction(ctx, &db.CreateIndexedTransactionParams{
+			NetworkID:      networkID,
+			BlockNumber:    blockNumber,
+			TxIndex:        int64(tx.Index),
+			TxID:           tx.TxID,
+			TxType:         tx.Type,
+			TxTimestamp:    nullTime(tx.Timestamp),
+			Chaincode:      tx.Chaincode,
+			Function:       tx.Function,
+			CreatorMspID:   tx.CreatorMSPID,
+			ValidationCode: tx.ValidationCode,
+			EventName:      tx.EventName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store transaction %s: %w", tx.TxID, err)
+		}
+		for _, key := range tx.Keys {
+			if err := q.CreateIndexedTransactionKey(ctx, &db.CreateIndexedTransactionKeyParams{
+				TransactionID: stored.ID,
+				NetworkID:     networkID,
+				Namespace:     key.Namespace,
+				Key:           key.Key,
+				Access:        key.Access,
+			}); err != nil {
+				return fmt.Errorf("failed to store key of transaction %s: %w", tx.TxID, err)
+			}
+		}
+	}
+
+	if err := q.UpdateBlockIndexerProgress(ctx, &db.UpdateBlockIndexerProgressParams{
+		NextBlock: blockNumber + 1,
+		NetworkID: networkID,
+	}); err != nil {
+		return fmt.Errorf("failed to update indexer progress: %w", err)
+	}
+	return nil
+}
+
+// EnableNetwork turns on indexing for a network. A new indexer starts at fromBlock,
+// or at the genesis block when fromBlock is nil; an existing one resumes where it stopped.
+func (s *Service) EnableNetwork(ctx context.Context, networkID int64, fromBlock *uint64) (*Status, error) {
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	if network.Platform != string(service.BlockchainTypeFabric) && network.Platform != string(service.BlockchainTypeFabricX) {
+		return nil, fmt.Errorf("block indexing is not supported for platform %s", network.Platform)
+	}
+
+	_, err = s.queries.GetBlockIndexer(ctx, networkID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		var nextBlock int64
+		if fromBlock != nil {
+			nextBlock = int64(*fromBlock)
+		}
+		if _, err := s.queries.CreateBlockIndexer(ctx, &db.CreateBlockIndexerParams{
+			NetworkID: networkID,
+			NextBlock: nextBlock,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create block indexer: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get block indexer: %w", err)
+	default:
+		if _, err := s.queries.SetBlockIndexerEnabled(ctx, &db.SetBlockIndexerEnabledParams{
+			Enabled:   true,
+			NetworkID: networkID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to enable block indexer: %w", err)
+		}
+	}
+	return s.GetStatus(ctx, networkID)
+}
+
+// DisableNetwork stops indexing a network. With purge, the indexer and every
+// indexed row of the network are deleted so a later enable starts over.
+func (s *Service) DisableNetwork(ctx context.Context, networkID int64, purge bool) error {
+	if _, err := s.queries.GetBlockIndexer(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to get block indexer: %w", err)
+	}
+	s.mu.Lock()
+	if r, ok := s.running[networkID]; ok {
+		r.cancel()
+		delete(s.running, networkID)
+	}
+	s.mu.Unlock()
+
+	if !purge {
+		if _, err := s.queries.SetBlockIndexerEnabled(ctx, &db.SetBlockIndexerEnabledParams{
+			Enabled:   false,
+			NetworkID: networkID,
+		}); err != nil {
+			return fmt.Errorf("failed to disable block indexer: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.queries.DeleteBlockIndexer(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to delete block indexer: %w", err)
+	}
+	if err := s.queries.DeleteIndexedTransactionKeysByNetwork(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to delete indexed keys: %w", err)
+	}
+	if err := s.queries.DeleteIndexedTransactionsByNetwork(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to delete indexed transactions: %w", err)
+	}
+	if err := s.queries.DeleteIndexedBlocksByNetwork(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to delete indexed blocks: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the indexer status of a network
+func (s *Service) GetStatus(ctx context.Context, networkID int64) (*Status, error) {
+	idx, err := s.queries.GetBlockIndexer(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block indexer: %w", err)
+	}
+	indexedBlocks, err := s.queries.CountIndexedBlocks(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count indexed blocks: %w", err)
+	}
+	status := &Status{
+		NetworkID:     idx.NetworkID,
+		Enabled:       idx.Enabled,
+		Running:       s.isRunning(networkID),
+		NextBlock:     idx.NextBlock,
+		IndexedBlocks: indexedBlocks,
+		LastError:     idx.LastError.String,
+	}
+	if idx.LastIndexedAt.Valid {
+		lastIndexedAt := idx.LastIndexedAt.Time
+		status.LastIndexedAt = &lastIndexedAt
+	}
+	return status, nil
+}
+
+// Search returns the indexed transactions of a network matching filter, newest first
+func (s *Service) Search(ctx context.Context, networkID int64, filter SearchFilter) (*SearchResult, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	from, to := nullTime(filter.From), nullTime(filter.To)
+	rows, err := s.queries.SearchIndexedTransactions(ctx, &db.SearchIndexedTransactionsParams{
+		NetworkID:      networkID,
+		Column2:        nullable(from),
+		TxTimestamp:    from,
+		Column4:        nullable(to),
+		TxTimestamp_2:  to,
+		Column6:        filter.Chaincode,
+		Chaincode:      filter.Chaincode,
+		Column8:        filter.Function,
+		Function:       filter.Function,
+		Column10:       filter.CreatorMSPID,
+		CreatorMspID:   filter.CreatorMSPID,
+		Column12:       filter.ValidationCode,
+		ValidationCode: filter.ValidationCode,
+		Column14:       filter.Key,
+		Key:            filter.Key,
+		Limit:          int64(filter.Limit),
+		Offset:         int64(filter.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	total, err := s.queries.CountSearchIndexedTransactions(ctx, &db.CountSearchIndexedTransactionsParams{
+		NetworkID:      networkID,
+		Column2:        nullable(from),
+		TxTimestamp:    from,
+		Column4:        nullable(to),
+		TxTimestamp_2:  to,
+		Column6:        filter.Chaincode,
+		Chaincode:      filter.Chaincode,
+		Column8:        filter.Function,
+		Function:       filter.Function,
+		Column10:       filter.CreatorMSPID,
+		CreatorMspID:   filter.CreatorMSPID,
+		Column12:       filter.ValidationCode,
+		ValidationCode: filter.ValidationCode,
+		Column14:       filter.Key,
+		Key:            filter.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	result := &SearchResult{
+		Transactions: make([]Transaction, 0, len(rows)),
+		Total:        total,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	}
+	for _, row := range rows {
+		result.Transactions = append(result.Transactions, mapTransaction(row))
+	}
+	return result, nil
+}
+
+// GetTransaction returns an indexed transaction along with the keys it touched
+func (s *Service) GetTransaction(ctx context.Context, networkID int64, txID string) (*Transaction, error) {
+	row, err := s.queries.GetIndexedTransactionByTxID(ctx, &db.GetIndexedTransactionByTxIDParams{
+		NetworkID: networkID,
+		TxID:      txID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	keys, err := s.queries.ListIndexedTransactionKeys(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transaction keys: %w", err)
+	}
+	tx := mapTransaction(row)
+	for _, key := range keys {
+		tx.Keys = append(tx.Keys, TransactionKey{Namespace: key.Namespace, Key: key.Key, Access: key.Access})
+	}
+	return &tx, nil
+}
+
+func mapTransaction(row *db.IndexedTransaction) Transaction {
+	tx := Transaction{
+		BlockNumber:    row.BlockNumber,
+		TxIndex:        row.TxIndex,
+		TxID:           row.TxID,
+		Type:           row.TxType,
+		Chaincode:      row.Chaincode,
+		Function:       row.Function,
+		CreatorMSPID:   row.CreatorMspID,
+		ValidationCode: row.ValidationCode,
+		EventName:      row.EventName,
+	}
+	if row.TxTimestamp.Valid {
+		timestamp := row.TxTimestamp.Time
+		tx.Timestamp = &timestamp
+	}
+	return tx
+}
+
+// nullTime converts t to UTC so stored timestamps compare correctly in range filters
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// nullable returns the value bound to an "? IS NULL" filter guard
+func nullable(t sql.NullTime) interface{} {
+	if !t.Valid {
+		return nil
+	}
+	return t.Time
+}
@@ -0,0 +1,96 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func newTestService(t *testing.T) (*Service, int64) {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+
+	queries := db.New(sqlDB)
+	ctx := context.Background()
+	network, err := queries.CreateNetwork(ctx, &db.CreateNetworkParams{Name: "net", Platform: "FABRIC", Status: "running"})
+	require.NoError(t, err)
+	_, err = queries.CreateBlockIndexer(ctx, &db.CreateBlockIndexerParams{NetworkID: network.ID})
+	require.NoError(t, err)
+	return &Service{queries: queries}, network.ID
+}
+
+func TestStoreBlock(t *testing.T) {
+	ctx := context.Background()
+	s, networkID := newTestService(t)
+
+	block := blockRecord{
+		Number:   0,
+		DataHash: "abcd",
+		Transactions: []txRecord{
+			{Index: 0, TxID: "tx1", Type: "ENDORSER_TRANSACTION", Keys: []TransactionKey{{Namespace: "basic", Key: "asset1", Access: "write"}}},
+		},
+	}
+	require.NoError(t, s.storeBlock(ctx, networkID, block))
+	// A re-delivered block replaces its rows
+	require.NoError(t, s.storeBlock(ctx, networkID, block))
+
+	count, err := s.queries.CountIndexedBlocks(ctx, networkID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	tx, err := s.queries.GetIndexedTransactionByTxID(ctx, &db.GetIndexedTransactionByTxIDParams{NetworkID: networkID, TxID: "tx1"})
+	require.NoError(t, err)
+	keys, err := s.queries.ListIndexedTransactionKeys(ctx, tx.ID)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+	indexer, err := s.queries.GetBlockIndexer(ctx, networkID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), indexer.NextBlock)
+}
+
+func TestStoreBlockRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	s, networkID := newTestService(t)
+
+	require.NoError(t, s.storeBlock(ctx, networkID, blockRecord{
+		Number:       0,
+		DataHash:     "abcd",
+		Transactions: []txRecord{{Index: 0, TxID: "tx1", Type: "ENDORSER_TRANSACTION"}},
+	}))
+
+	// The second transaction reuses the index of the first, so its insert fails after the
+	// old rows of the block were deleted and the new block row was written
+	err := s.storeBlock(ctx, networkID, blockRecord{
+		Number:   0,
+		DataHash: "ef01",
+		Transactions: []txRecord{
+			{Index: 0, TxID: "tx2", Type: "ENDORSER_TRANSACTION"},
+			{Index: 0, TxID: "tx3", Type: "ENDORSER_TRANSACTION"},
+		},
+	})
+	require.Error(t, err)
+
+	// The block stored first is untouched and the checkpoint did not move
+	_, err = s.queries.GetIndexedTransactionByTxID(ctx, &db.GetIndexedTransactionByTxIDParams{NetworkID: networkID, TxID: "tx1"})
+	require.NoError(t, err)
+	_, err = s.queries.GetIndexedTransactionByTxID(ctx, &db.GetIndexedTransactionByTxIDParams{NetworkID: networkID, TxID: "tx2"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	count, err := s.queries.CountIndexedBlocks(ctx, networkID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	indexer, err := s.queries.GetBlockIndexer(ctx, networkID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), indexer.NextBlock)
+}
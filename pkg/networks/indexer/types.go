@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"time"
+)
+
+// Key access kinds recorded for indexed transaction keys
+const (
+	AccessRead   = "read"
+	AccessWrite  = "write"
+	AccessDelete = "delete"
+)
+
+// Status reports the progress of the block indexer of a network
+type Status struct {
+	NetworkID int64 `json:"networkId"`
+	Enabled   bool  `json:"enabled"`
+	// Running is true while the indexer is following the ledger
+	Running bool `json:"running"`
+	// NextBlock is the first block not indexed yet
+	NextBlock     int64      `json:"nextBlock"`
+	IndexedBlocks int64      `json:"indexedBlocks"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastIndexedAt *time.Time `json:"lastIndexedAt,omitempty"`
+}
+
+// SearchFilter selects indexed transactions. Empty fields match everything.
+type SearchFilter struct {
+	From           *time.Time
+	To             *time.Time
+	Chaincode      string
+	Function       string
+	CreatorMSPID   string
+	ValidationCode string
+	Key            string
+	Limit          int
+	Offset         int
+}
+
+// TransactionKey is a key read or written by an indexed transaction
+type TransactionKey struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Access    string `json:"access"`
+}
+
+// Transaction is an indexed transaction
+type Transaction struct {
+	BlockNumber    int64      `json:"blockNumber"`
+	TxIndex        int64      `json:"txIndex"`
+	TxID           string     `json:"txId"`
+	Type           string     `json:"type"`
+	Timestamp      *time.Time `json:"timestamp,omitempty"`
+	Chaincode      string     `json:"chaincode,omitempty"`
+	Function       string     `json:"function,omitempty"`
+	CreatorMSPID   string     `json:"creatorMspId,omitempty"`
+	ValidationCode string     `json:"validationCode,omitempty"`
+	EventName      string     `json:"eventName,omitempty"`
+	// Keys is only filled when a single transaction is requested
+	Keys []TransactionKey `json:"keys,omitempty"`
+}
+
+// SearchResult is a page of indexed transactions
+type SearchResult struct {
+	Transactions []Transaction `json:"transactions"`
+	Total        int64         `json:"total"`
+	Limit        int           `json:"limit"`
+	Offset       int           `json:"offset"`
+}
+
+// blockRecord is a block ready to be stored, independent of the platform it came from
+type blockRecord struct {
+	Number       uint64
+	DataHash     string
+	Time         *time.Time
+	Transactions []txRecord
+}
+
+type txRecord struct {
+	Index          int
+	TxID           string
+	Type           string
+	Timestamp      *time.Time
+	Chaincode      string
+	Function       string
+	CreatorMSPID   string
+	ValidationCode string
+	EventName      string
+	Keys           []TransactionKey
+}
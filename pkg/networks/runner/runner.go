@@ -0,0 +1,88 @@
+// Package runner keeps track of the network operations, such as upgrade plans and
+// consensus migrations, that the server executes in the background.
+package runner
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// InterruptedReason is recorded on the operations paused by PauseInterrupted
+const InterruptedReason = "interrupted by server restart"
+
+// Registry holds the operations being executed, with their cancel request flag
+type Registry struct {
+	mu      sync.Mutex
+	running map[int64]*run
+}
+
+type run struct {
+	cancelRequested bool
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{running: make(map[int64]*run)}
+}
+
+// Claim marks an operation as running. It returns false when the operation is
+// already claimed.
+func (r *Registry) Claim(id int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[id]; ok {
+		return false
+	}
+	r.running[id] = &run{}
+	return true
+}
+
+// Release removes an operation from the registry
+func (r *Registry) Release(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.running, id)
+}
+
+// RequestCancel asks a running operation to stop at its next step. It is a no-op
+// for operations that aren't running.
+func (r *Registry) RequestCancel(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run, ok := r.running[id]; ok {
+		run.cancelRequested = true
+	}
+}
+
+// CancelRequested reports whether a running operation was asked to stop
+func (r *Registry) CancelRequested(id int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.running[id]
+	return ok && run.cancelRequested
+}
+
+// PauseInterrupted pauses the operations left running by a previous server process
+// so they can be inspected and resumed. list returns the operations stored as running.
+func PauseInterrupted[T any](ctx context.Context, list func(context.Context) ([]T, error), pause func(context.Context, T) error) error {
+	items, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := pause(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TimePtr converts a nullable database timestamp to a pointer
+func TimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
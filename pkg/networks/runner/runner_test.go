@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	// Cancelling an operation that isn't running has no effect
+	r.RequestCancel(1)
+	assert.False(t, r.CancelRequested(1))
+
+	require.True(t, r.Claim(1))
+	assert.False(t, r.Claim(1), "an operation can only be claimed once")
+	assert.True(t, r.Claim(2))
+
+	r.RequestCancel(1)
+	assert.True(t, r.CancelRequested(1))
+	assert.False(t, r.CancelRequested(2))
+
+	// A released operation can be claimed again, without its cancel request
+	r.Release(1)
+	assert.False(t, r.CancelRequested(1))
+	require.True(t, r.Claim(1))
+	assert.False(t, r.CancelRequested(1))
+}
+
+func TestPauseInterrupted(t *testing.T) {
+	ctx := context.Background()
+	list := func(context.Context) ([]int64, error) { return []int64{1, 2, 3}, nil }
+
+	var paused []int64
+	require.NoError(t, PauseInterrupted(ctx, list, func(_ context.Context, id int64) error {
+		paused = append(paused, id)
+		return nil
+	}))
+	assert.Equal(t, []int64{1, 2, 3}, paused)
+
+	// The first failure stops the recovery
+	errPause := errors.New("pause failed")
+	paused = nil
+	err := PauseInterrupted(ctx, list, func(_ context.Context, id int64) error {
+		paused = append(paused, id)
+		if id == 2 {
+			return errPause
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errPause)
+	assert.Equal(t, []int64{1, 2}, paused)
+
+	errList := errors.New("list failed")
+	err = PauseInterrupted(ctx, func(context.Context) ([]int64, error) { return nil, errList }, func(context.Context, int64) error {
+		t.Fatal("nothing should be paused")
+		return nil
+	})
+	assert.ErrorIs(t, err, errList)
+}
+
+func TestTimePtr(t *testing.T) {
+	assert.Nil(t, TimePtr(sql.NullTime{}))
+	now := time.Now()
+	assert.Equal(t, &now, TimePtr(sql.NullTime{Time: now, Valid: true}))
+}
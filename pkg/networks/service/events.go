@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric"
+	fabricblock "github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric/block"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// NetworkEventType identifies the kind of a streamed network event
+type NetworkEventType string
+
+const (
+	// NetworkEventBlock is emitted for every committed block
+	NetworkEventBlock NetworkEventType = "block"
+	// NetworkEventChaincode is emitted for every matching Fabric chaincode event
+	NetworkEventChaincode NetworkEventType = "chaincode_event"
+)
+
+// EventCheckpoint is a position in a network event stream.
+// It points at the next block to read and, for chaincode events, the last transaction
+// already processed within that block.
+type EventCheckpoint struct {
+	BlockNumber   uint64
+	TransactionID string
+}
+
+// String encodes the checkpoint as "<block>" or "<block>:<txID>"
+func (c EventCheckpoint) String() string {
+	if c.TransactionID == "" {
+		return strconv.FormatUint(c.BlockNumber, 10)
+	}
+	return fmt.Sprintf("%d:%s", c.BlockNumber, c.TransactionID)
+}
+
+// ParseEventCheckpoint parses a checkpoint encoded by EventCheckpoint.String
+func ParseEventCheckpoint(value string) (*EventCheckpoint, error) {
+	blockStr, txID, _ := strings.Cut(strings.TrimSpace(value), ":")
+	blockNumber, err := strconv.ParseUint(blockStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %q: %w", value, err)
+	}
+	return &EventCheckpoint{BlockNumber: blockNumber, TransactionID: txID}, nil
+}
+
+// EventStreamOptions configures a network event stream
+type EventStreamOptions struct {
+	// From is the position to resume from. When nil, the stream starts with the next committed block.
+	From *EventCheckpoint
+	// Chaincode switches a Fabric stream from blocks to the events of this chaincode
+	Chaincode string
+	// EventNames restricts chaincode events to these names. Empty means all events.
+	EventNames []string
+}
+
+// BesuBlockHeader is the summary of a Besu block delivered by the event stream
+type BesuBlockHeader struct {
+	Number     uint64 `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Miner      string `json:"miner"`
+	Timestamp  uint64 `json:"timestamp"`
+	GasLimit   uint64 `json:"gasLimit"`
+	GasUsed    uint64 `json:"gasUsed"`
+}
+
+// NetworkEvent is a single event delivered by a network event stream
+type NetworkEvent struct {
+	Type        NetworkEventType `json:"type"`
+	NetworkID   int64            `json:"networkId"`
+	BlockNumber uint64           `json:"blockNumber"`
+	// Checkpoint resumes the stream right after this event
+	Checkpoint     string                 `json:"checkpoint"`
+	FabricBlock    *fabricblock.Block     `json:"fabricBlock,omitempty"`
+	ChaincodeEvent *fabric.ChaincodeEvent `json:"chaincodeEvent,omitempty"`
+	BesuBlock      *BesuBlockHeader       `json:"besuBlock,omitempty"`
+}
+
+// StreamNetworkEvents streams new blocks, or chaincode events for Fabric, until ctx is cancelled.
+// The returned channel is closed when the stream ends.
+func (s *NetworkService) StreamNetworkEvents(ctx context.Context, networkID int64, opts EventStreamOptions) (<-chan NetworkEvent, error) {
+	network, err := s.db.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+
+	switch network.Platform {
+	case string(BlockchainTypeFabric):
+		if opts.Chaincode != "" {
+			return s.streamFabricChaincodeEvents(ctx, networkID, opts)
+		}
+		return s.streamFabricBlocks(ctx, networkID, opts)
+	case string(BlockchainTypeBesu):
+		if opts.Chaincode != "" {
+			return nil, fmt.Errorf("chaincode events are only available for Fabric networks")
+		}
+		return s.streamBesuBlocks(ctx, networkID, opts)
+	default:
+		return nil, fmt.Errorf("event streaming is not supported for platform %s", network.Platform)
+	}
+}
+
+func (s *NetworkService) streamFabricBlocks(ctx context.Context, networkID int64, opts EventStreamOptions) (<-chan NetworkEvent, error) {
+	fabricDeployer, err := s.getFabricDeployerForNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric deployer: %w", err)
+	}
+	var startBlock *uint64
+	if opts.From != nil {
+		startBlock = &opts.From.BlockNumber
+	}
+	blocks, err := fabricDeployer.StreamBlocks(ctx, networkID, startBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan NetworkEvent)
+	go func() {
+		defer close(events)
+		for blk := range blocks {
+			number := uint64(blk.Number)
+			event := NetworkEvent{
+				Type:        NetworkEventBlock,
+				NetworkID:   networkID,
+				BlockNumber: number,
+				Checkpoint:  EventCheckpoint{BlockNumber: number + 1}.String(),
+				FabricBlock: blk,
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+func (s *NetworkService) streamFabricChaincodeEvents(ctx context.Context, networkID int64, opts EventStreamOptions) (<-chan NetworkEvent, error) {
+	fabricDeployer, err := s.getFabricDeployerForNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fabric deployer: %w", err)
+	}
+	var startBlock *uint64
+	var afterTxID string
+	if opts.From != nil {
+		startBlock = &opts.From.BlockNumber
+		afterTxID = opts.From.TransactionID
+	}
+	chaincodeEvents, err := fabricDeployer.StreamChaincodeEvents(ctx, networkID, opts.Chaincode, opts.EventNames, startBlock, afterTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan NetworkEvent)
+	go func() {
+		defer close(events)
+		for chaincodeEvent := range chaincodeEvents {
+			event := NetworkEvent{
+				Type:        NetworkEventChaincode,
+				NetworkID:   networkID,
+				BlockNumber: chaincodeEvent.BlockNumber,
+				Checkpoint: EventCheckpoint{
+					BlockNumber:   chaincodeEvent.BlockNumber,
+					TransactionID: chaincodeEvent.TransactionID,
+				}.String(),
+				ChaincodeEvent: chaincodeEvent,
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// besuWebSocketURL returns the WebSocket RPC endpoint of a running node of the Besu network
+func (s *NetworkService) besuWebSocketURL(ctx context.Context, networkID int64) (string, error) {
+	networkNodes, err := s.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return "", err
+	}
+	for _, networkNode := range networkNodes {
+		node := networkNode.Node
+		if node == nil || node.BesuNode == nil || node.BesuNode.WSPort == 0 || node.Status != string(nodetypes.NodeStatusRunning) {
+			continue
+		}
+		host := node.BesuNode.RPCHost
+		if host == "" || host == "0.0.0.0" {
+			host = "127.0.0.1"
+		}
+		return fmt.Sprintf("ws://%s:%d", host, node.BesuNode.WSPort), nil
+	}
+	return "", fmt.Errorf("no running Besu node with WebSocket RPC enabled found in network")
+}
+
+func (s *NetworkService) streamBesuBlocks(ctx context.Context, networkID int64, opts EventStreamOptions) (<-chan NetworkEvent, error) {
+	wsURL, err := s.besuWebSocketURL(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	ethClient, err := ethclient.DialContext(ctx, wsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to besu node: %w", err)
+	}
+	// Subscribe before backfilling so no block is missed in between
+	heads := make(chan *types.Header)
+	sub, err := ethClient.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		ethClient.Close()
+		return nil, fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+
+	events := make(chan NetworkEvent)
+	go func() {
+		defer close(events)
+		defer ethClient.Close()
+		defer sub.Unsubscribe()
+
+		send := func(header *types.Header) bool {
+			number := header.Number.Uint64()
+			event := NetworkEvent{
+				Type:        NetworkEventBlock,
+				NetworkID:   networkID,
+				BlockNumber: number,
+				Checkpoint:  EventCheckpoint{BlockNumber: number + 1}.String(),
+				BesuBlock: &BesuBlockHeader{
+					Number:     number,
+					Hash:       header.Hash().Hex(),
+					ParentHash: header.ParentHash.Hex(),
+					Miner:      header.Coinbase.Hex(),
+					Timestamp:  header.Time,
+					GasLimit:   header.GasLimit,
+					GasUsed:    header.GasUsed,
+				},
+			}
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// next is the first block number not delivered yet; nil until the first block when not resuming
+		var next *uint64
+		if opts.From != nil {
+			from := opts.From.BlockNumber
+			next = &from
+		}
+		deliver := func(header *types.Header) bool {
+			number := header.Number.Uint64()
+			if next != nil {
+				if number < *next {
+					return true
+				}
+				// Backfill blocks committed since the checkpoint
+				for n := *next; n < number; n++ {
+					missed, err := ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+					if err != nil {
+						s.logger.Warn("Failed to backfill besu block", "networkID", networkID, "block", n, "error", err)
+						return false
+					}
+					if !send(missed) {
+						return false
+					}
+				}
+			}
+			if !send(header) {
+				return false
+			}
+			following := number + 1
+			next = &following
+			return true
+		}
+
+		// Catch up to the current head right away when resuming from a checkpoint
+		if next != nil {
+			head, err := ethClient.HeaderByNumber(ctx, nil)
+			if err != nil {
+				s.logger.Warn("Failed to get besu head block", "networkID", networkID, "error", err)
+				return
+			}
+			if head.Number.Uint64() >= *next && !deliver(head) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					s.logger.Warn("Besu block subscription failed", "networkID", networkID, "error", err)
+				}
+				return
+			case header := <-heads:
+				if !deliver(header) {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
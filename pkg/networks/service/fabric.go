@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/fabric/connprofile"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric"
 	fabricblock "github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric/block"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
@@ -526,6 +527,22 @@ func (s *NetworkService) GetNetworkConfig(ctx context.Context, networkID, orgID
 	return configYAML, nil
 }
 
+// GetConnectionProfile renders the connection settings of an organization in
+// the given format, embedding the selected identities ("admin", "client" or key IDs)
+func (s *NetworkService) GetConnectionProfile(ctx context.Context, networkID, orgID int64, format connprofile.Format, identities []string) ([]byte, error) {
+	fabricDeployer, err := s.getFabricDeployerForNetwork(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := fabricDeployer.GenerateConnectionProfile(ctx, networkID, orgID, format, identities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s connection profile: %w", format, err)
+	}
+
+	return profile, nil
+}
+
 // UnjoinPeerFromNetwork removes a peer from a channel but keeps it in the network
 func (s *NetworkService) UnjoinPeerFromNetwork(networkID, peerID int64) error {
 	network, err := s.db.GetNetwork(context.Background(), networkID)
@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric-config/protolator"
 	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
 	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 
 	"time"
@@ -43,6 +44,10 @@ type Transaction struct {
 	Event       TransactionEvent    `json:"event"`
 	Writes      []*TransactionWrite `json:"writes"`
 	Reads       []*TransactionRead  `json:"reads"`
+	// Function is the first argument of the chaincode invocation
+	Function       string `json:"function,omitempty"`
+	CreatorMSPID   string `json:"creatorMspId,omitempty"`
+	ValidationCode string `json:"validationCode,omitempty"`
 }
 type TransactionEvent struct {
 	Name  string `json:"name"`
@@ -99,8 +104,10 @@ func MapBlock(block *common.Block) (*Block, error) {
 	}
 
 	blk.Transactions = []*Transaction{}
-	for _, txData := range block.Data.Data {
-		transaction := &Transaction{}
+	for txIndex, txData := range block.Data.Data {
+		transaction := &Transaction{
+			ValidationCode: TxValidationCode(block, txIndex),
+		}
 		tx, err := UnmarshalTransaction(txData)
 		if err != nil {
 			return nil, err
@@ -126,6 +133,10 @@ func MapBlock(block *common.Block) (*Block, error) {
 			return nil, errors.Wrap(err, "unmarshal payload from envelope failed")
 		}
 		transaction.ID = channelHeader.TxId
+		transaction.CreatorMSPID, err = GetCreatorMSPID(payload)
+		if err != nil {
+			logrus.Debugf("Failed to get creator %v", err)
+		}
 		transaction.ChannelID = chdr.ChannelId
 		txDate, err := ptypes.Timestamp(chdr.Timestamp)
 		if err != nil {
@@ -163,6 +174,10 @@ func MapBlock(block *common.Block) (*Block, error) {
 					Value: string(events.Payload),
 				}
 				transaction.ChaincodeID = action.ChaincodeId.Name
+				transaction.Function, err = GetInvocationFunction(payload)
+				if err != nil {
+					logrus.Debugf("Failed to get invoked function %v", err)
+				}
 				transaction.Version = action.ChaincodeId.Version
 				transaction.Path = action.ChaincodeId.Path
 				transaction.Response = action.Response.Payload
@@ -228,6 +243,62 @@ func MapBlock(block *common.Block) (*Block, error) {
 	}
 	return blk, nil
 }
+
+// TxValidationCode returns the validation code the committing peer recorded for the transaction at txIndex
+func TxValidationCode(block *common.Block, txIndex int) string {
+	metadata := block.GetMetadata().GetMetadata()
+	if len(metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return ""
+	}
+	filter := metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	if txIndex >= len(filter) {
+		return ""
+	}
+	return peer.TxValidationCode(filter[txIndex]).String()
+}
+
+// GetCreatorMSPID returns the MSP ID of the identity that signed the transaction proposal
+func GetCreatorMSPID(payload *common.Payload) (string, error) {
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(payload.GetHeader().GetSignatureHeader(), signatureHeader); err != nil {
+		return "", errors.Wrap(err, "error unmarshaling SignatureHeader")
+	}
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(signatureHeader.Creator, identity); err != nil {
+		return "", errors.Wrap(err, "error unmarshaling SerializedIdentity")
+	}
+	return identity.Mspid, nil
+}
+
+// GetInvocationFunction returns the function invoked by an endorser transaction, which by
+// convention is the first argument of the chaincode input
+func GetInvocationFunction(payload *common.Payload) (string, error) {
+	tx, err := UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return "", err
+	}
+	if len(tx.Actions) == 0 {
+		return "", errors.New("at least one TransactionAction required")
+	}
+	ccPayload, err := UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return "", err
+	}
+	proposalPayload := &peer.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(ccPayload.ChaincodeProposalPayload, proposalPayload); err != nil {
+		return "", errors.Wrap(err, "error unmarshaling ChaincodeProposalPayload")
+	}
+	invocationSpec := &peer.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(proposalPayload.Input, invocationSpec); err != nil {
+		return "", errors.Wrap(err, "error unmarshaling ChaincodeInvocationSpec")
+	}
+	args := invocationSpec.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) == 0 {
+		return "", nil
+	}
+	return string(args[0]), nil
+}
+
 func GetEnvelopeFromBlock(data []byte) (*common.Envelope, error) {
 	// Block always begins with an envelope
 	var err error
@@ -0,0 +1,152 @@
+package fabric
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/fabric/connprofile"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// Identity selectors accepted by GenerateConnectionProfile, besides the ID of a
+// key issued by the organization's signing CA
+const (
+	IdentityAdmin  = "admin"
+	IdentityClient = "client"
+)
+
+// GenerateConnectionProfile renders the connection settings of an organization
+// in the given format, embedding the selected identities. The fabric-sdk format
+// embeds the admin when no identity is selected, like GenerateNetworkConfig.
+func (d *FabricDeployer) GenerateConnectionProfile(ctx context.Context, networkID int64, orgID int64, format connprofile.Format, identities []string) ([]byte, error) {
+	network, err := d.db.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	org, err := d.db.GetFabricOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if format == connprofile.FormatFabricSDK && len(identities) == 0 {
+		identities = []string{IdentityAdmin}
+	}
+	resolved, err := d.resolveIdentities(ctx, org, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == connprofile.FormatFabricSDK {
+		config, err := d.generateNetworkConfig(ctx, network, org, resolved)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(config), nil
+	}
+
+	profile := &connprofile.Network{
+		Name:        network.Name,
+		ChannelName: network.Name,
+		MSPID:       org.MspID,
+		Identities:  resolved,
+	}
+	networkNodes, err := d.db.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	for _, node := range networkNodes {
+		nodeDetails, err := d.nodes.GetNode(ctx, node.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node details: %w", err)
+		}
+		switch nodeDetails.NodeType {
+		case nodetypes.NodeTypeFabricPeer:
+			peer := nodeDetails.FabricPeer
+			profile.Peers = append(profile.Peers, connprofile.Endpoint{
+				Name:      nodeDetails.Name,
+				MSPID:     peer.MSPID,
+				Address:   peer.ExternalEndpoint,
+				TLSCACert: peer.TLSCACert,
+			})
+		case nodetypes.NodeTypeFabricOrderer:
+			orderer := nodeDetails.FabricOrderer
+			profile.Orderers = append(profile.Orderers, connprofile.Endpoint{
+				Name:      nodeDetails.Name,
+				MSPID:     orderer.MSPID,
+				Address:   orderer.ExternalEndpoint,
+				TLSCACert: orderer.TLSCACert,
+			})
+		}
+	}
+
+	return connprofile.Render(format, profile)
+}
+
+// resolveIdentities loads the certificate and private key of each selected
+// identity. A key ID must name a key signed by the organization's signing CA,
+// so a profile can't embed keys of other organizations.
+func (d *FabricDeployer) resolveIdentities(ctx context.Context, org *db.FabricOrganization, selectors []string) ([]connprofile.Identity, error) {
+	var identities []connprofile.Identity
+	seen := make(map[int64]bool)
+	for _, selector := range selectors {
+		var keyID int64
+		name := selector
+		switch selector {
+		case IdentityAdmin:
+			if !org.AdminSignKeyID.Valid {
+				return nil, fmt.Errorf("%w: organization %s has no admin identity", connprofile.ErrIdentity, org.MspID)
+			}
+			keyID = org.AdminSignKeyID.Int64
+		case IdentityClient:
+			if !org.ClientSignKeyID.Valid {
+				return nil, fmt.Errorf("%w: organization %s has no client identity", connprofile.ErrIdentity, org.MspID)
+			}
+			keyID = org.ClientSignKeyID.Int64
+		default:
+			id, err := strconv.ParseInt(selector, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not admin, client or a key ID", connprofile.ErrIdentity, selector)
+			}
+			keyID = id
+		}
+		if seen[keyID] {
+			continue
+		}
+		seen[keyID] = true
+
+		// A missing key is a bad selection, not a missing network or organization
+		if _, err := d.db.GetKey(ctx, keyID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: key %d not found", connprofile.ErrIdentity, keyID)
+			}
+			return nil, fmt.Errorf("failed to get key %d: %w", keyID, err)
+		}
+		key, err := d.keyMgmt.GetKey(ctx, int(keyID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key %d: %w", keyID, err)
+		}
+		if selector != IdentityAdmin && selector != IdentityClient {
+			if key.SigningKeyID == nil || !org.SignKeyID.Valid || int64(*key.SigningKeyID) != org.SignKeyID.Int64 {
+				return nil, fmt.Errorf("%w: key %d was not issued by organization %s", connprofile.ErrIdentity, keyID, org.MspID)
+			}
+			name = key.Name
+		}
+		if key.Certificate == nil {
+			return nil, fmt.Errorf("%w: key %d has no certificate", connprofile.ErrIdentity, keyID)
+		}
+		privateKey, err := d.keyMgmt.GetDecryptedPrivateKey(int(keyID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get private key of key %d: %w", keyID, err)
+		}
+		identities = append(identities, connprofile.Identity{
+			Name:        name,
+			Certificate: *key.Certificate,
+			PrivateKey:  privateKey,
+		})
+	}
+	return identities, nil
+}
@@ -0,0 +1,34 @@
+package fabric
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/fabric/connprofile"
+)
+
+func TestResolveIdentitiesRejectsMissingKeys(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+
+	d := &FabricDeployer{db: db.New(sqlDB)}
+	org := &db.FabricOrganization{
+		MspID:          "Org1MSP",
+		AdminSignKeyID: sql.NullInt64{Int64: 41, Valid: true},
+	}
+	for _, selector := range []string{IdentityAdmin, "42"} {
+		_, err := d.resolveIdentities(context.Background(), org, []string{selector})
+		assert.ErrorIs(t, err, connprofile.ErrIdentity, selector)
+		assert.NotErrorIs(t, err, sql.ErrNoRows, selector)
+	}
+}
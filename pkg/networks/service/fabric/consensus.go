@@ -0,0 +1,269 @@
+package fabric
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/certutils"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
+	"github.com/hyperledger/fabric-config/configtx"
+	"github.com/hyperledger/fabric-config/configtx/orderer"
+	cb "github.com/hyperledger/fabric-protos-go-apiv2/common"
+	sb "github.com/hyperledger/fabric-protos-go-apiv2/orderer/smartbft"
+)
+
+// ChannelCapabilityV3 is the channel capability BFT ordering requires
+const ChannelCapabilityV3 = "V3_0"
+
+// minBFTConsenters is the smallest BFT cluster that tolerates a faulty node
+const minBFTConsenters = 4
+
+// SetConsensusStateOperation moves the ordering service of the channel in or out of
+// maintenance mode. In maintenance mode only orderer admins can submit transactions,
+// which is required to change the consensus type.
+type SetConsensusStateOperation struct {
+	// State is STATE_NORMAL or STATE_MAINTENANCE
+	State string `json:"state"`
+}
+
+// Type returns the type of the operation
+func (op *SetConsensusStateOperation) Type() ConfigUpdateOperationType {
+	return OpSetConsensusState
+}
+
+// Validate validates the operation
+func (op *SetConsensusStateOperation) Validate() error {
+	switch orderer.ConsensusState(op.State) {
+	case orderer.ConsensusStateNormal, orderer.ConsensusStateMaintenance:
+		return nil
+	default:
+		return fmt.Errorf("invalid consensus state %q: must be %s or %s", op.State, orderer.ConsensusStateNormal, orderer.ConsensusStateMaintenance)
+	}
+}
+
+// Modify applies the operation to the given config
+func (op *SetConsensusStateOperation) Modify(ctx context.Context, c *configtx.ConfigTx) error {
+	ordConfig, err := c.Orderer().Configuration()
+	if err != nil {
+		return fmt.Errorf("failed to get orderer configuration: %w", err)
+	}
+	if ordConfig.State == orderer.ConsensusState(op.State) {
+		return fmt.Errorf("ordering service is already in %s", op.State)
+	}
+
+	ordConfig.State = orderer.ConsensusState(op.State)
+	if err := c.Orderer().SetConfiguration(ordConfig); err != nil {
+		return fmt.Errorf("failed to update orderer configuration: %w", err)
+	}
+	return nil
+}
+
+// BFTConsenter is an entry of the BFT consenter mapping
+type BFTConsenter struct {
+	ID    uint32 `json:"id"`
+	Host  string `json:"host"`
+	Port  int    `json:"port"`
+	MSPID string `json:"msp_id"`
+	// Identity is the PEM encoded enrollment (signing) certificate of the orderer
+	Identity      string `json:"identity"`
+	ClientTLSCert string `json:"client_tls_cert"`
+	ServerTLSCert string `json:"server_tls_cert"`
+}
+
+// MigrateToBFTOperation switches the consensus type of an etcdraft channel to BFT.
+// The ordering service must be in maintenance mode. The channel V3_0 capability is
+// enabled when missing.
+type MigrateToBFTOperation struct {
+	Consenters []BFTConsenter `json:"consenters"`
+	// Options are the SmartBFT options; Fabric's sample defaults are used when nil
+	Options *types.SmartBFTOptions `json:"options,omitempty"`
+}
+
+// Type returns the type of the operation
+func (op *MigrateToBFTOperation) Type() ConfigUpdateOperationType {
+	return OpMigrateToBFT
+}
+
+// Validate validates the operation
+func (op *MigrateToBFTOperation) Validate() error {
+	if len(op.Consenters) < minBFTConsenters {
+		return fmt.Errorf("BFT requires at least %d consenters, got %d", minBFTConsenters, len(op.Consenters))
+	}
+	ids := make(map[uint32]bool, len(op.Consenters))
+	addresses := make(map[string]bool, len(op.Consenters))
+	for _, cons := range op.Consenters {
+		if cons.ID == 0 {
+			return fmt.Errorf("consenter %s:%d has no ID", cons.Host, cons.Port)
+		}
+		if ids[cons.ID] {
+			return fmt.Errorf("duplicate consenter ID %d", cons.ID)
+		}
+		ids[cons.ID] = true
+		if cons.Host == "" {
+			return fmt.Errorf("consenter %d: host cannot be empty", cons.ID)
+		}
+		if cons.Port <= 0 {
+			return fmt.Errorf("consenter %d: invalid port: %d", cons.ID, cons.Port)
+		}
+		address := fmt.Sprintf("%s:%d", cons.Host, cons.Port)
+		if addresses[address] {
+			return fmt.Errorf("duplicate consenter address %s", address)
+		}
+		addresses[address] = true
+		if cons.MSPID == "" {
+			return fmt.Errorf("consenter %d: msp id cannot be empty", cons.ID)
+		}
+		if _, err := certutils.ParseX509Certificate([]byte(cons.Identity)); err != nil {
+			return fmt.Errorf("consenter %d: invalid identity cert: %w", cons.ID, err)
+		}
+		if _, err := certutils.ParseX509Certificate([]byte(cons.ClientTLSCert)); err != nil {
+			return fmt.Errorf("consenter %d: invalid client TLS cert: %w", cons.ID, err)
+		}
+		if _, err := certutils.ParseX509Certificate([]byte(cons.ServerTLSCert)); err != nil {
+			return fmt.Errorf("consenter %d: invalid server TLS cert: %w", cons.ID, err)
+		}
+	}
+	return nil
+}
+
+// Modify applies the operation to the given config
+func (op *MigrateToBFTOperation) Modify(ctx context.Context, c *configtx.ConfigTx) error {
+	ordConfig, err := c.Orderer().Configuration()
+	if err != nil {
+		return fmt.Errorf("failed to get orderer configuration: %w", err)
+	}
+	if ordConfig.OrdererType != orderer.ConsensusTypeEtcdRaft {
+		return fmt.Errorf("consensus type is %s, only %s channels can be migrated", ordConfig.OrdererType, orderer.ConsensusTypeEtcdRaft)
+	}
+	if ordConfig.State != orderer.ConsensusStateMaintenance {
+		return fmt.Errorf("ordering service must be in %s to change the consensus type", orderer.ConsensusStateMaintenance)
+	}
+
+	// BFT clients connect to the endpoints of the orderer organizations
+	endpoints := make(map[string]int, len(ordConfig.Organizations))
+	for _, org := range ordConfig.Organizations {
+		endpoints[org.Name] = len(org.OrdererEndpoints)
+	}
+	mapping := make([]cb.Consenter, 0, len(op.Consenters))
+	for _, cons := range op.Consenters {
+		count, ok := endpoints[cons.MSPID]
+		if !ok {
+			return fmt.Errorf("consenter %d: %s is not an orderer organization of the channel", cons.ID, cons.MSPID)
+		}
+		if count == 0 {
+			return fmt.Errorf("consenter %d: orderer organization %s has no orderer endpoints", cons.ID, cons.MSPID)
+		}
+		mapping = append(mapping, cb.Consenter{
+			Id:            cons.ID,
+			Host:          cons.Host,
+			Port:          uint32(cons.Port),
+			MspId:         cons.MSPID,
+			Identity:      []byte(cons.Identity),
+			ClientTlsCert: []byte(cons.ClientTLSCert),
+			ServerTlsCert: []byte(cons.ServerTLSCert),
+		})
+	}
+
+	ordConfig.OrdererType = string(orderer.ConsensusTypeBFT)
+	ordConfig.ConsenterMapping = mapping
+	ordConfig.SmartBFT = smartBFTOptions(op.Options)
+	ordConfig.EtcdRaft = orderer.EtcdRaft{}
+	if err := c.Orderer().SetConfiguration(ordConfig); err != nil {
+		return fmt.Errorf("failed to update orderer configuration: %w", err)
+	}
+
+	capabilities, err := c.Channel().Capabilities()
+	if err != nil {
+		return fmt.Errorf("failed to get channel capabilities: %w", err)
+	}
+	for _, capability := range capabilities {
+		if capability == ChannelCapabilityV3 {
+			return nil
+		}
+	}
+	if err := c.Channel().AddCapability(ChannelCapabilityV3); err != nil {
+		return fmt.Errorf("failed to add channel capability %s: %w", ChannelCapabilityV3, err)
+	}
+	return nil
+}
+
+// smartBFTOptions converts the SmartBFT options of a network, falling back to the
+// defaults of Fabric's sample configtx.yaml
+func smartBFTOptions(opts *types.SmartBFTOptions) *sb.Options {
+	if opts == nil {
+		return &sb.Options{
+			RequestBatchMaxCount:      100,
+			RequestBatchMaxBytes:      10 * 1024 * 1024,
+			RequestBatchMaxInterval:   "50ms",
+			IncomingMessageBufferSize: 200,
+			RequestPoolSize:           100000,
+			RequestForwardTimeout:     "2s",
+			RequestComplainTimeout:    "20s",
+			RequestAutoRemoveTimeout:  "3m0s",
+			RequestMaxBytes:           10 * 1024 * 1024,
+			ViewChangeResendInterval:  "5s",
+			ViewChangeTimeout:         "20s",
+			LeaderHeartbeatTimeout:    "1m0s",
+			LeaderHeartbeatCount:      10,
+			CollectTimeout:            "1s",
+			LeaderRotation:            sb.Options_ROTATION_ON,
+			DecisionsPerLeader:        3,
+		}
+	}
+	leaderRotation := sb.Options_ROTATION_UNSPECIFIED
+	switch opts.LeaderRotation {
+	case "ROTATION_ON":
+		leaderRotation = sb.Options_ROTATION_ON
+	case "ROTATION_OFF":
+		leaderRotation = sb.Options_ROTATION_OFF
+	}
+	return &sb.Options{
+		RequestBatchMaxCount:      opts.RequestBatchMaxCount,
+		RequestBatchMaxBytes:      opts.RequestBatchMaxBytes,
+		RequestBatchMaxInterval:   opts.RequestBatchMaxInterval,
+		IncomingMessageBufferSize: opts.IncomingMessageBufferSize,
+		RequestPoolSize:           opts.RequestPoolSize,
+		RequestForwardTimeout:     opts.RequestForwardTimeout,
+		RequestComplainTimeout:    opts.RequestComplainTimeout,
+		RequestAutoRemoveTimeout:  opts.RequestAutoRemoveTimeout,
+		RequestMaxBytes:           opts.RequestMaxBytes,
+		ViewChangeResendInterval:  opts.ViewChangeResendInterval,
+		ViewChangeTimeout:         opts.ViewChangeTimeout,
+		LeaderHeartbeatTimeout:    opts.LeaderHeartbeatTimeout,
+		LeaderHeartbeatCount:      opts.LeaderHeartbeatCount,
+		CollectTimeout:            opts.CollectTimeout,
+		SyncOnStart:               opts.SyncOnStart,
+		SpeedUpViewChange:         opts.SpeedUpViewChange,
+		LeaderRotation:            leaderRotation,
+		DecisionsPerLeader:        opts.DecisionsPerLeader,
+	}
+}
+
+// ordererConsenters returns the consenters of the channel, read from the etcdraft
+// metadata or from the BFT consenter mapping
+func ordererConsenters(ordererConf configtx.Orderer) []*OrdererInfo {
+	var orderers []*OrdererInfo
+	if ordererConf.OrdererType == string(orderer.ConsensusTypeBFT) {
+		for i := range ordererConf.ConsenterMapping {
+			consenter := &ordererConf.ConsenterMapping[i]
+			orderers = append(orderers, &OrdererInfo{
+				URL:     fmt.Sprintf("grpcs://%s:%d", consenter.Host, consenter.Port),
+				TLSCert: string(consenter.ServerTlsCert),
+			})
+		}
+		return orderers
+	}
+	for _, consent := range ordererConf.EtcdRaft.Consenters {
+		pemPk := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: consent.ServerTLSCert.Raw,
+		})
+		ordererAddress := fmt.Sprintf("%s:%d", consent.Address.Host, consent.Address.Port)
+		orderers = append(orderers, &OrdererInfo{
+			URL:     fmt.Sprintf("grpcs://%s", ordererAddress),
+			TLSCert: string(pemPk),
+		})
+	}
+	return orderers
+}
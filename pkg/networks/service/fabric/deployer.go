@@ -26,6 +26,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/certutils"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	"github.com/chainlaunch/chainlaunch/pkg/fabric/channel"
+	"github.com/chainlaunch/chainlaunch/pkg/fabric/connprofile"
 	orgservicefabric "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -75,6 +76,9 @@ const (
 	ConfigUpdateOperationTypeRemoveOrdererOrg    ConfigUpdateOperationType = "remove_orderer_org"
 	ConfigUpdateOperationTypeUpdateOrdererOrgMSP ConfigUpdateOperationType = "update_orderer_org_msp"
 	OpUpdateApplicationACL                       ConfigUpdateOperationType = "update_application_acl"
+	// Consensus migration operations
+	OpSetConsensusState ConfigUpdateOperationType = "set_consensus_state"
+	OpMigrateToBFT      ConfigUpdateOperationType = "migrate_to_bft"
 )
 
 // ConfigUpdateOperation represents a configuration update operation with its associated data
@@ -713,6 +717,18 @@ func CreateConfigModifier(operation ConfigUpdateOperation) (ConfigModifier, erro
 			return nil, fmt.Errorf("failed to unmarshal update application acl operation: %v", err)
 		}
 		return &op, nil
+	case OpSetConsensusState:
+		var op SetConsensusStateOperation
+		if err := json.Unmarshal(operation.Payload, &op); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal set consensus state payload: %w", err)
+		}
+		modifier = &op
+	case OpMigrateToBFT:
+		var op MigrateToBFTOperation
+		if err := json.Unmarshal(operation.Payload, &op); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal migrate to bft payload: %w", err)
+		}
+		modifier = &op
 	default:
 		return nil, fmt.Errorf("unsupported operation type: %s", operation.Type)
 	}
@@ -920,13 +936,15 @@ organizations:
       {{- end}}
 
     users:
-       admin: 
+      {{- range $name, $user := .Users}}
+       {{$name}}: 
           cert: 
             pem: |
-{{.AdminKey.Certificate | indent 16}}
+{{$user.Certificate | indent 16}}
           key:
             pem: |
-{{.AdminKey.PrivateKey | indent 16}}
+{{$user.PrivateKey | indent 16}}
+      {{- end}}
 
 orderers:
   {{- range $name, $orderer := .Orderers}}
@@ -969,7 +987,8 @@ type NetworkConfigData struct {
 	Name         string
 	Organization string
 	ChannelName  string
-	AdminKey     struct {
+	// Users are the embedded identities by name, the admin by default
+	Users map[string]struct {
 		PrivateKey  string
 		Certificate string
 	}
@@ -1886,17 +1905,16 @@ func (d *FabricDeployer) GenerateNetworkConfig(ctx context.Context, networkID in
 		return "", fmt.Errorf("failed to get organization: %w", err)
 	}
 
-	// Get admin key
-	adminKey, err := d.keyMgmt.GetKey(ctx, int(org.AdminSignKeyID.Int64))
-	if err != nil {
-		return "", fmt.Errorf("failed to get admin key: %w", err)
-	}
-	adminPrivateKey, err := d.keyMgmt.GetDecryptedPrivateKey(int(org.AdminSignKeyID.Int64))
+	identities, err := d.resolveIdentities(ctx, org, []string{IdentityAdmin})
 	if err != nil {
-		return "", fmt.Errorf("failed to get admin private key: %w", err)
+		return "", err
 	}
+	return d.generateNetworkConfig(ctx, network, org, identities)
+}
+
+func (d *FabricDeployer) generateNetworkConfig(ctx context.Context, network *db.Network, org *db.FabricOrganization, identities []connprofile.Identity) (string, error) {
 	// Get network nodes
-	networkNodes, err := d.db.GetNetworkNodes(ctx, networkID)
+	networkNodes, err := d.db.GetNetworkNodes(ctx, network.ID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get network nodes: %w", err)
 	}
@@ -1906,13 +1924,10 @@ func (d *FabricDeployer) GenerateNetworkConfig(ctx context.Context, networkID in
 		Name:         network.Name,
 		Organization: org.MspID,
 		ChannelName:  network.Name,
-		AdminKey: struct {
+		Users: make(map[string]struct {
 			PrivateKey  string
 			Certificate string
-		}{
-			PrivateKey:  string(adminPrivateKey),
-			Certificate: string(*adminKey.Certificate),
-		},
+		}),
 		Orderers: make(map[string]struct {
 			URL       string
 			TLSCert   string
@@ -1925,6 +1940,16 @@ func (d *FabricDeployer) GenerateNetworkConfig(ctx context.Context, networkID in
 		}),
 	}
 
+	for _, identity := range identities {
+		data.Users[identity.Name] = struct {
+			PrivateKey  string
+			Certificate string
+		}{
+			PrivateKey:  identity.PrivateKey,
+			Certificate: identity.Certificate,
+		}
+	}
+
 	// Process all nodes
 	for _, node := range networkNodes {
 		nodeDetails, err := d.nodes.GetNode(ctx, node.NodeID)
@@ -2381,20 +2406,7 @@ func (d *FabricDeployer) GetOrderersFromConfigBlock(ctx context.Context, blockBy
 		return nil, fmt.Errorf("failed to get orderer configuration: %w", err)
 	}
 
-	consenters := ordererConf.EtcdRaft.Consenters
-
-	var orderers []*OrdererInfo
-	for _, consent := range consenters {
-		pemPk := pem.EncodeToMemory(&pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: consent.ServerTLSCert.Raw,
-		})
-		ordererAddress := fmt.Sprintf("%s:%d", consent.Address.Host, consent.Address.Port)
-		orderers = append(orderers, &OrdererInfo{
-			URL:     fmt.Sprintf("grpcs://%s", ordererAddress),
-			TLSCert: string(pemPk),
-		})
-	}
+	orderers := ordererConsenters(ordererConf)
 
 	return orderers, nil
 }
@@ -2435,20 +2447,7 @@ func (d *FabricDeployer) GetOrderersFromGenesisBlock(ctx context.Context, networ
 		return nil, fmt.Errorf("failed to get orderer configuration: %w", err)
 	}
 
-	consenters := ordererConf.EtcdRaft.Consenters
-
-	var orderers []*OrdererInfo
-	for _, consent := range consenters {
-		pemPk := pem.EncodeToMemory(&pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: consent.ServerTLSCert.Raw,
-		})
-		ordererAddress := fmt.Sprintf("%s:%d", consent.Address.Host, consent.Address.Port)
-		orderers = append(orderers, &OrdererInfo{
-			URL:     fmt.Sprintf("grpcs://%s", ordererAddress),
-			TLSCert: string(pemPk),
-		})
-	}
+	orderers := ordererConsenters(ordererConf)
 
 	if len(orderers) == 0 {
 		return nil, fmt.Errorf("no valid orderers found in genesis block")
@@ -0,0 +1,120 @@
+package fabric
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabric/block"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/peer"
+)
+
+// ChaincodeEvent represents an event emitted by a chaincode in a committed transaction
+type ChaincodeEvent struct {
+	BlockNumber   uint64 `json:"blockNumber"`
+	TransactionID string `json:"transactionId"`
+	ChaincodeName string `json:"chaincodeName"`
+	EventName     string `json:"eventName"`
+	Payload       []byte `json:"payload"`
+}
+
+// getJoinedPeer returns a peer that has joined the network channel, along with the channel name
+func (d *FabricDeployer) getJoinedPeer(ctx context.Context, networkID int64) (*peer.LocalPeer, string, error) {
+	network, err := d.db.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get network: %w", err)
+	}
+
+	networkNodes, err := d.db.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	for _, node := range networkNodes {
+		if node.Role != "peer" || node.Status != "joined" {
+			continue
+		}
+		localPeer, err := d.nodes.GetFabricPeer(ctx, node.NodeID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get peer: %w", err)
+		}
+		return localPeer, network.Name, nil
+	}
+	return nil, "", fmt.Errorf("no active peer found in network")
+}
+
+// StreamBlocks streams the blocks committed to the network channel until ctx is cancelled.
+// Delivery starts at startBlock, or at the next committed block when startBlock is nil.
+func (d *FabricDeployer) StreamBlocks(ctx context.Context, networkID int64, startBlock *uint64) (<-chan *block.Block, error) {
+	localPeer, channelID, err := d.getJoinedPeer(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	rawBlocks, err := localPeer.StreamBlocks(ctx, channelID, startBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream blocks: %w", err)
+	}
+
+	blocks := make(chan *block.Block)
+	go func() {
+		defer close(blocks)
+		for rawBlock := range rawBlocks {
+			mapped, err := block.MapBlock(rawBlock)
+			if err != nil {
+				// Still deliver the block position so consumers can checkpoint past it
+				d.logger.Warn("Failed to decode streamed block", "networkID", networkID, "block", rawBlock.Header.Number, "error", err)
+				mapped = &block.Block{
+					Number:   int(rawBlock.Header.Number),
+					DataHash: hex.EncodeToString(rawBlock.Header.DataHash),
+				}
+			}
+			select {
+			case blocks <- mapped:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+// StreamChaincodeEvents streams the events emitted by a chaincode on the network channel until ctx is cancelled.
+// Only events whose name is in eventNames are delivered, unless eventNames is empty. Delivery starts at
+// startBlock, or at the next committed block when startBlock is nil; events in startBlock up to and
+// including afterTxID are skipped.
+func (d *FabricDeployer) StreamChaincodeEvents(ctx context.Context, networkID int64, chaincodeName string, eventNames []string, startBlock *uint64, afterTxID string) (<-chan *ChaincodeEvent, error) {
+	localPeer, channelID, err := d.getJoinedPeer(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	rawEvents, err := localPeer.StreamChaincodeEvents(ctx, channelID, chaincodeName, startBlock, afterTxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream chaincode events: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(eventNames))
+	for _, name := range eventNames {
+		wanted[name] = true
+	}
+	events := make(chan *ChaincodeEvent)
+	go func() {
+		defer close(events)
+		for rawEvent := range rawEvents {
+			if len(wanted) > 0 && !wanted[rawEvent.EventName] {
+				continue
+			}
+			event := &ChaincodeEvent{
+				BlockNumber:   rawEvent.BlockNumber,
+				TransactionID: rawEvent.TransactionID,
+				ChaincodeName: rawEvent.ChaincodeName,
+				EventName:     rawEvent.EventName,
+				Payload:       rawEvent.Payload,
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -140,6 +140,12 @@ func (s *NetworkService) GetFabricXBlock(ctx context.Context, networkID int64, b
 	return s.deployerFactory.GetFabricXDeployer().GetBlock(ctx, networkID, blockNum)
 }
 
+// GetFabricXIndexedBlocks decodes up to limit blocks starting at fromBlock
+// for the block indexer, along with the current ledger height.
+func (s *NetworkService) GetFabricXIndexedBlocks(ctx context.Context, networkID int64, fromBlock uint64, limit int) ([]fabricx.IndexedBlock, uint64, error) {
+	return s.deployerFactory.GetFabricXDeployer().GetIndexedBlocks(ctx, networkID, fromBlock, limit)
+}
+
 // GetFabricXTransaction returns a decoded envelope by txID.
 func (s *NetworkService) GetFabricXTransaction(ctx context.Context, networkID int64, txID string) (any, error) {
 	return s.deployerFactory.GetFabricXDeployer().GetTransaction(ctx, networkID, txID)
@@ -2,15 +2,18 @@ package fabricx
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/fabricx/explorer/decoder"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
 	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	cb "github.com/hyperledger/fabric-protos-go-apiv2/common"
 	"github.com/hyperledger/fabric-x-common/api/committerpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -236,6 +239,64 @@ func (d *FabricXDeployer) GetBlock(ctx context.Context, networkID int64, blockNu
 	return decoder.DecodeBlock(block)
 }
 
+// IndexedBlock is a block whose transactions are fully decoded, as stored by
+// the block indexer.
+type IndexedBlock struct {
+	Number       uint64
+	DataHash     string
+	Transactions []IndexedTx
+}
+
+// IndexedTx is a decoded transaction with its position in the block and its
+// commit status. Tx is nil when the envelope could not be decoded.
+type IndexedTx struct {
+	Index  int
+	Status string
+	Tx     *decoder.DecodedTx
+}
+
+// GetIndexedBlocks decodes up to limit blocks starting at fromBlock over a
+// single sidecar connection. The ledger height is returned so callers know
+// whether more blocks remain.
+func (d *FabricXDeployer) GetIndexedBlocks(ctx context.Context, networkID int64, fromBlock uint64, limit int) ([]IndexedBlock, uint64, error) {
+	clients, err := d.dialExplorer(ctx, networkID)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer clients.cleanup()
+
+	info, err := clients.Blocks.GetBlockchainInfo(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetBlockchainInfo: %w", err)
+	}
+	height := info.GetHeight()
+
+	var out []IndexedBlock
+	for n := fromBlock; n < height && len(out) < limit; n++ {
+		block, err := clients.Blocks.GetBlockByNumber(ctx, &committerpb.BlockNumber{Number: n})
+		if err != nil {
+			return nil, 0, fmt.Errorf("GetBlockByNumber %d: %w", n, err)
+		}
+		indexed := IndexedBlock{
+			Number:   block.GetHeader().GetNumber(),
+			DataHash: hex.EncodeToString(block.GetHeader().GetDataHash()),
+		}
+		statuses := decoder.BlockTxStatuses(block)
+		for i, envBytes := range block.GetData().GetData() {
+			tx := IndexedTx{Index: i, Status: statuses[i]}
+			env := &cb.Envelope{}
+			if err := proto.Unmarshal(envBytes, env); err == nil {
+				if decoded, err := decoder.DecodeEnvelope(env); err == nil {
+					tx.Tx = decoded
+				}
+			}
+			indexed.Transactions = append(indexed.Transactions, tx)
+		}
+		out = append(out, indexed)
+	}
+	return out, height, nil
+}
+
 // GetTransaction returns a decoded envelope by txID.
 func (d *FabricXDeployer) GetTransaction(ctx context.Context, networkID int64, txID string) (*decoder.DecodedTx, error) {
 	clients, err := d.dialExplorer(ctx, networkID)
@@ -1,6 +1,9 @@
 package decoder
 
-import "github.com/hyperledger/fabric-x-common/api/committerpb"
+import (
+	cb "github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-x-common/api/committerpb"
+)
 
 var statusLabels = map[committerpb.Status]string{
 	committerpb.Status_STATUS_UNSPECIFIED:                        "UNSPECIFIED",
@@ -35,3 +38,22 @@ func StatusLabel(s committerpb.Status) string {
 func IsCommitted(s committerpb.Status) bool {
 	return s == committerpb.Status_COMMITTED
 }
+
+// BlockTxStatuses returns the status label of every transaction in the block, read
+// from the transactions filter the committer writes into the block metadata.
+// Transactions without a recorded status are reported as UNSPECIFIED.
+func BlockTxStatuses(block *cb.Block) []string {
+	statuses := make([]string, len(block.GetData().GetData()))
+	var filter []byte
+	if metadata := block.GetMetadata().GetMetadata(); len(metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		filter = metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+	for i := range statuses {
+		status := committerpb.Status_STATUS_UNSPECIFIED
+		if i < len(filter) {
+			status = committerpb.Status(filter[i])
+		}
+		statuses[i] = StatusLabel(status)
+	}
+	return statuses
+}
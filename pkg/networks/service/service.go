@@ -138,6 +138,7 @@ type NetworkService struct {
 	logger          *logger.Logger
 	orgService      *orgservicefabric.OrganizationService
 	configService   *config.ConfigService
+	snapshots       peerSnapshots
 }
 
 // NewNetworkService creates a new NetworkService
@@ -150,6 +151,7 @@ func NewNetworkService(db *db.Queries, nodes *nodeservice.NodeService, keyMgmt *
 		logger:          logger,
 		orgService:      orgService,
 		configService:   configService,
+		snapshots:       nodes,
 	}
 }
 
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/peer"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// defaultSnapshotJoinTimeout bounds snapshot generation, transfer and import
+const defaultSnapshotJoinTimeout = time.Hour
+
+// JoinPeerFromSnapshotRequest describes how a peer joins a channel from a ledger snapshot
+type JoinPeerFromSnapshotRequest struct {
+	// SourcePeerID is the peer the snapshot is taken from. When empty, the running
+	// joined peer of the network with the highest ledger is used.
+	SourcePeerID int64 `json:"sourcePeerId,omitempty"`
+	// BlockNumber selects the snapshot block. A completed snapshot of the source at that
+	// block is reused, otherwise one is requested. When empty, a snapshot of the last
+	// committed block is requested.
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	// TimeoutSeconds bounds snapshot generation, transfer and import (default: 3600)
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// JoinPeerFromSnapshotResult reports the snapshot a peer joined from
+type JoinPeerFromSnapshotResult struct {
+	NetworkID    int64  `json:"networkId"`
+	PeerID       int64  `json:"peerId"`
+	SourcePeerID int64  `json:"sourcePeerId"`
+	BlockNumber  uint64 `json:"blockNumber"`
+}
+
+// peerSnapshots is the part of the node service a snapshot join uses to find the ledger
+// height of peers and to take their snapshots
+type peerSnapshots interface {
+	GetNodeChannels(ctx context.Context, id int64) ([]nodeservice.Channel, error)
+	ListPeerSnapshots(ctx context.Context, nodeID int64, channelID string) (*nodeservice.PeerSnapshots, error)
+	RequestPeerSnapshot(ctx context.Context, nodeID int64, channelID string, blockNumber uint64) error
+	WaitPeerSnapshot(ctx context.Context, nodeID int64, channelID string, minBlock uint64) (*peer.Snapshot, error)
+}
+
+// snapshotSource is a peer able to provide a snapshot, with its ledger height
+type snapshotSource struct {
+	NodeID int64
+	Height int64
+}
+
+// JoinPeerToNetworkFromSnapshot joins a peer to a Fabric channel from a ledger
+// snapshot of another peer instead of replaying the chain from the genesis block
+func (s *NetworkService) JoinPeerToNetworkFromSnapshot(ctx context.Context, networkID, peerID int64, req JoinPeerFromSnapshotRequest) (*JoinPeerFromSnapshotResult, error) {
+	network, err := s.db.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	if network.Platform != string(BlockchainTypeFabric) {
+		return nil, fmt.Errorf("network %d is not a Fabric network", networkID)
+	}
+	channelID := network.Name
+
+	networkNodes, err := s.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+	var target *NetworkNode
+	var sources []snapshotSource
+	for i := range networkNodes {
+		networkNode := &networkNodes[i]
+		if networkNode.NodeID == peerID {
+			target = networkNode
+			continue
+		}
+		node := networkNode.Node
+		if networkNode.Role != "peer" || networkNode.Status != "joined" || node == nil ||
+			node.NodeType != nodetypes.NodeTypeFabricPeer || node.Status != string(nodetypes.NodeStatusRunning) {
+			continue
+		}
+		sources = append(sources, snapshotSource{NodeID: node.ID, Height: s.peerHeight(ctx, node.ID, channelID)})
+	}
+	if target == nil || target.Role != "peer" {
+		return nil, fmt.Errorf("peer %d is not a peer of network %d", peerID, networkID)
+	}
+	if target.Status == "joined" {
+		return nil, fmt.Errorf("peer %d already joined network %d", peerID, networkID)
+	}
+	sourceID, err := pickSnapshotSource(sources, req.SourcePeerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultSnapshotJoinTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	blockNumber, err := s.ensurePeerSnapshot(ctx, sourceID, channelID, req.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.nodeService.JoinPeerFromSnapshot(ctx, sourceID, peerID, channelID, blockNumber); err != nil {
+		return nil, fmt.Errorf("failed to join peer from snapshot: %w", err)
+	}
+
+	if _, err := s.db.UpdateNetworkNodeStatus(ctx, &db.UpdateNetworkNodeStatusParams{
+		NetworkID: networkID,
+		NodeID:    peerID,
+		Status:    "joined",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update network node status: %w", err)
+	}
+	s.logger.Info("Joined peer to network from snapshot", "networkID", networkID, "peerID", peerID, "sourcePeerID", sourceID, "block", blockNumber)
+	return &JoinPeerFromSnapshotResult{
+		NetworkID:    networkID,
+		PeerID:       peerID,
+		SourcePeerID: sourceID,
+		BlockNumber:  blockNumber,
+	}, nil
+}
+
+// ensurePeerSnapshot returns the block of a completed snapshot of the source peer,
+// requesting it and waiting for it to be generated when needed
+func (s *NetworkService) ensurePeerSnapshot(ctx context.Context, sourceID int64, channelID string, blockNumber uint64) (uint64, error) {
+	snapshots, err := s.snapshots.ListPeerSnapshots(ctx, sourceID, channelID)
+	if err != nil {
+		return 0, err
+	}
+
+	minBlock := blockNumber
+	if blockNumber == 0 {
+		height := s.peerHeight(ctx, sourceID, channelID)
+		if height == 0 {
+			return 0, fmt.Errorf("source peer %d has no ledger for channel %s", sourceID, channelID)
+		}
+		// The peer snapshots its last committed block, or a later one if blocks are
+		// committed before the request arrives
+		minBlock = uint64(height - 1)
+		if err := s.snapshots.RequestPeerSnapshot(ctx, sourceID, channelID, 0); err != nil {
+			return 0, err
+		}
+	} else {
+		for _, snapshot := range snapshots.Completed {
+			if snapshot.BlockNumber == blockNumber {
+				return blockNumber, nil
+			}
+		}
+		pending := false
+		for _, block := range snapshots.Pending {
+			pending = pending || block == blockNumber
+		}
+		if !pending {
+			if err := s.snapshots.RequestPeerSnapshot(ctx, sourceID, channelID, blockNumber); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	s.logger.Info("Waiting for ledger snapshot", "peerID", sourceID, "channel", channelID, "block", minBlock)
+	snapshot, err := s.snapshots.WaitPeerSnapshot(ctx, sourceID, channelID, minBlock)
+	if err != nil {
+		return 0, err
+	}
+	return snapshot.BlockNumber, nil
+}
+
+// peerHeight returns the ledger height of a peer for the channel, 0 when unknown
+func (s *NetworkService) peerHeight(ctx context.Context, nodeID int64, channelID string) int64 {
+	channels, err := s.snapshots.GetNodeChannels(ctx, nodeID)
+	if err != nil {
+		return 0
+	}
+	for _, channel := range channels {
+		if channel.Name == channelID {
+			return channel.BlockNum
+		}
+	}
+	return 0
+}
+
+// pickSnapshotSource returns the requested source peer, or the one with the highest ledger
+func pickSnapshotSource(sources []snapshotSource, requested int64) (int64, error) {
+	if requested != 0 {
+		for _, source := range sources {
+			if source.NodeID == requested {
+				if source.Height == 0 {
+					return 0, fmt.Errorf("source peer %d has no ledger for the channel", requested)
+				}
+				return requested, nil
+			}
+		}
+		return 0, fmt.Errorf("source peer %d is not a running peer joined to the network", requested)
+	}
+	var best *snapshotSource
+	for i := range sources {
+		if sources[i].Height > 0 && (best == nil || sources[i].Height > best.Height) {
+			best = &sources[i]
+		}
+	}
+	if best == nil {
+		return 0, fmt.Errorf("no running peer joined to the network can provide a snapshot")
+	}
+	return best.NodeID, nil
+}
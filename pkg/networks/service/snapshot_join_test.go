@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/peer"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+)
+
+func TestPickSnapshotSource(t *testing.T) {
+	sources := []snapshotSource{
+		{NodeID: 1, Height: 10},
+		{NodeID: 2, Height: 25},
+		{NodeID: 3, Height: 0},
+	}
+	tests := []struct {
+		name      string
+		sources   []snapshotSource
+		requested int64
+		want      int64
+		wantErr   bool
+	}{
+		{name: "highest ledger", sources: sources, want: 2},
+		{name: "requested source", sources: sources, requested: 1, want: 1},
+		{name: "requested source without ledger", sources: sources, requested: 3, wantErr: true},
+		{name: "requested source not joined", sources: sources, requested: 4, wantErr: true},
+		{name: "no source with a ledger", sources: []snapshotSource{{NodeID: 3}}, wantErr: true},
+		{name: "no sources", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pickSnapshotSource(tt.sources, tt.requested)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakePeerSnapshots records the snapshot requests of a peer and completes them at once
+type fakePeerSnapshots struct {
+	height    int64
+	completed []uint64
+	pending   []uint64
+	requested []uint64
+}
+
+func (f *fakePeerSnapshots) GetNodeChannels(ctx context.Context, id int64) ([]nodeservice.Channel, error) {
+	return []nodeservice.Channel{{Name: "mychannel", BlockNum: f.height}}, nil
+}
+
+func (f *fakePeerSnapshots) ListPeerSnapshots(ctx context.Context, nodeID int64, channelID string) (*nodeservice.PeerSnapshots, error) {
+	snapshots := &nodeservice.PeerSnapshots{Pending: f.pending}
+	for _, block := range f.completed {
+		snapshots.Completed = append(snapshots.Completed, peer.Snapshot{ChannelID: channelID, BlockNumber: block})
+	}
+	return snapshots, nil
+}
+
+func (f *fakePeerSnapshots) RequestPeerSnapshot(ctx context.Context, nodeID int64, channelID string, blockNumber uint64) error {
+	f.requested = append(f.requested, blockNumber)
+	return nil
+}
+
+func (f *fakePeerSnapshots) WaitPeerSnapshot(ctx context.Context, nodeID int64, channelID string, minBlock uint64) (*peer.Snapshot, error) {
+	for _, block := range append(append(f.completed, f.pending...), f.requested...) {
+		if block >= minBlock {
+			return &peer.Snapshot{ChannelID: channelID, BlockNumber: block}, nil
+		}
+	}
+	// A snapshot of the last committed block lands at the current height
+	if len(f.requested) > 0 && f.height > 0 {
+		return &peer.Snapshot{ChannelID: channelID, BlockNumber: uint64(f.height - 1)}, nil
+	}
+	return nil, fmt.Errorf("snapshot at block %d not generated", minBlock)
+}
+
+func TestEnsurePeerSnapshot(t *testing.T) {
+	tests := []struct {
+		name          string
+		peer          fakePeerSnapshots
+		blockNumber   uint64
+		want          uint64
+		wantRequested []uint64
+		wantErr       bool
+	}{
+		{name: "reuses a completed snapshot", peer: fakePeerSnapshots{height: 30, completed: []uint64{20}}, blockNumber: 20, want: 20},
+		{name: "waits for a pending snapshot", peer: fakePeerSnapshots{height: 30, pending: []uint64{40}}, blockNumber: 40, want: 40},
+		{name: "requests a missing snapshot", peer: fakePeerSnapshots{height: 30}, blockNumber: 25, want: 25, wantRequested: []uint64{25}},
+		{name: "snapshots the last block", peer: fakePeerSnapshots{height: 30}, want: 29, wantRequested: []uint64{0}},
+		{name: "source without a ledger", peer: fakePeerSnapshots{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &NetworkService{snapshots: &tt.peer, logger: logger.NewDefault()}
+			got, err := s.ensurePeerSnapshot(context.Background(), 1, "mychannel", tt.blockNumber)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, tt.peer.requested)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequested, tt.peer.requested)
+		})
+	}
+}
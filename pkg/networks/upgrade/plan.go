@@ -0,0 +1,128 @@
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// Consensus protocols whose quorum is checked before a consenter goes down
+const (
+	consensusEtcdRaft = "etcdraft"
+	consensusSmartBFT = "smartbft"
+	consensusQBFT     = "qbft"
+)
+
+// consensusQuorum returns how many of n consenters must be up for the network to make progress
+func consensusQuorum(consensus string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	switch consensus {
+	case consensusSmartBFT:
+		// BFT quorum is ceil((n+f+1)/2) with f = floor((n-1)/3)
+		f := (n - 1) / 3
+		return (n + f + 2) / 2
+	case consensusQBFT:
+		// QBFT needs ceil(2n/3) validators
+		return (2*n + 2) / 3
+	default:
+		// Raft needs a majority
+		return n/2 + 1
+	}
+}
+
+// toleratesNodeDown reports whether n consenters keep quorum with one of them down
+func toleratesNodeDown(consensus string, n int) bool {
+	return n-1 >= consensusQuorum(consensus, n)
+}
+
+// isConsensusNode reports whether a node takes part in consensus.
+// Fabric orderers are consenters; every Besu node of a network is a QBFT validator.
+func isConsensusNode(nodeType nodetypes.NodeType) bool {
+	return nodeType == nodetypes.NodeTypeFabricOrderer || nodeType == nodetypes.NodeTypeBesuFullnode
+}
+
+// upgradeRank orders node types within a plan. Fabric orderers are upgraded
+// before peers, as recommended for Fabric upgrades.
+func upgradeRank(nodeType nodetypes.NodeType) int {
+	switch nodeType {
+	case nodetypes.NodeTypeFabricOrderer:
+		return 0
+	case nodetypes.NodeTypeFabricPeer:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortUpgradeOrder sorts the nodes in the order they are upgraded
+func sortUpgradeOrder(nodes []*nodeservice.NodeResponse) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ri, rj := upgradeRank(nodes[i].NodeType), upgradeRank(nodes[j].NodeType)
+		if ri != rj {
+			return ri < rj
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+// nodeVersion returns the version a node is configured to run
+func nodeVersion(node *nodeservice.NodeResponse) (string, error) {
+	switch node.NodeType {
+	case nodetypes.NodeTypeFabricPeer:
+		if node.FabricPeer != nil {
+			return node.FabricPeer.Version, nil
+		}
+	case nodetypes.NodeTypeFabricOrderer:
+		if node.FabricOrderer != nil {
+			return node.FabricOrderer.Version, nil
+		}
+	case nodetypes.NodeTypeBesuFullnode:
+		if node.BesuNode != nil {
+			return node.BesuNode.Version, nil
+		}
+	default:
+		return "", fmt.Errorf("node %s of type %s can't be upgraded", node.Name, node.NodeType)
+	}
+	return "", fmt.Errorf("node %s has no %s properties", node.Name, node.NodeType)
+}
+
+// planSteps returns the steps upgrading nodes, in order, to targetVersion. Nodes already
+// running the target version get a skipped step.
+func planSteps(nodes []*nodeservice.NodeResponse, targetVersion string) ([]*db.CreateUpgradePlanStepParams, error) {
+	steps := make([]*db.CreateUpgradePlanStepParams, 0, len(nodes))
+	for i, node := range nodes {
+		fromVersion, err := nodeVersion(node)
+		if err != nil {
+			return nil, err
+		}
+		status := StepStatusPending
+		if fromVersion == targetVersion {
+			status = StepStatusSkipped
+		}
+		steps = append(steps, &db.CreateUpgradePlanStepParams{
+			StepOrder:   int64(i + 1),
+			NodeID:      node.ID,
+			NodeType:    string(node.NodeType),
+			FromVersion: fromVersion,
+			ToVersion:   targetVersion,
+			Status:      string(status),
+		})
+	}
+	return steps, nil
+}
+
+// parseBlockNumber parses a hex encoded block number returned by eth_blockNumber
+func parseBlockNumber(value string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimPrefix(value, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number %q: %w", value, err)
+	}
+	return n, nil
+}
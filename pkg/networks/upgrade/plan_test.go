@@ -0,0 +1,92 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+func TestConsensusQuorum(t *testing.T) {
+	tests := []struct {
+		consensus string
+		n         int
+		quorum    int
+		tolerates bool
+	}{
+		{consensusEtcdRaft, 1, 1, false},
+		{consensusEtcdRaft, 2, 2, false},
+		{consensusEtcdRaft, 3, 2, true},
+		{consensusEtcdRaft, 5, 3, true},
+		{consensusSmartBFT, 1, 1, false},
+		{consensusSmartBFT, 3, 2, true},
+		{consensusSmartBFT, 4, 3, true},
+		{consensusSmartBFT, 7, 5, true},
+		{consensusQBFT, 1, 1, false},
+		{consensusQBFT, 2, 2, false},
+		{consensusQBFT, 4, 3, true},
+		{consensusQBFT, 6, 4, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.quorum, consensusQuorum(tt.consensus, tt.n), "%s with %d nodes", tt.consensus, tt.n)
+		assert.Equal(t, tt.tolerates, toleratesNodeDown(tt.consensus, tt.n), "%s with %d nodes", tt.consensus, tt.n)
+	}
+}
+
+func TestSortUpgradeOrder(t *testing.T) {
+	nodes := []*nodeservice.NodeResponse{
+		{ID: 4, NodeType: nodetypes.NodeTypeFabricPeer},
+		{ID: 3, NodeType: nodetypes.NodeTypeFabricOrderer},
+		{ID: 2, NodeType: nodetypes.NodeTypeFabricPeer},
+		{ID: 1, NodeType: nodetypes.NodeTypeFabricOrderer},
+	}
+	sortUpgradeOrder(nodes)
+
+	var ids []int64
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	assert.Equal(t, []int64{1, 3, 2, 4}, ids)
+}
+
+func TestNodeVersion(t *testing.T) {
+	version, err := nodeVersion(&nodeservice.NodeResponse{
+		NodeType:   nodetypes.NodeTypeFabricPeer,
+		FabricPeer: &nodeservice.FabricPeerProperties{Version: "2.5.12"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2.5.12", version)
+
+	_, err = nodeVersion(&nodeservice.NodeResponse{Name: "orderer0", NodeType: nodetypes.NodeTypeFabricOrderer})
+	assert.Error(t, err)
+}
+
+func TestPlanSteps(t *testing.T) {
+	steps, err := planSteps([]*nodeservice.NodeResponse{
+		{ID: 1, NodeType: nodetypes.NodeTypeFabricOrderer, FabricOrderer: &nodeservice.FabricOrdererProperties{Version: "3.1.1"}},
+		{ID: 2, NodeType: nodetypes.NodeTypeFabricPeer, FabricPeer: &nodeservice.FabricPeerProperties{Version: "2.5.12"}},
+	}, "3.1.1")
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, int64(1), steps[0].StepOrder)
+	assert.Equal(t, string(StepStatusSkipped), steps[0].Status)
+	assert.Equal(t, int64(2), steps[1].StepOrder)
+	assert.Equal(t, "2.5.12", steps[1].FromVersion)
+	assert.Equal(t, string(StepStatusPending), steps[1].Status)
+
+	// A node without a known version fails the plan before anything is stored
+	_, err = planSteps([]*nodeservice.NodeResponse{{Name: "peer0", NodeType: nodetypes.NodeTypeFabricPeer}}, "3.1.1")
+	assert.Error(t, err)
+}
+
+func TestParseBlockNumber(t *testing.T) {
+	n, err := parseBlockNumber("0x1b4")
+	require.NoError(t, err)
+	assert.Equal(t, int64(436), n)
+
+	_, err = parseBlockNumber("latest")
+	assert.Error(t, err)
+}
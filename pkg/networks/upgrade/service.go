@@ -0,0 +1,628 @@
+package upgrade
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/runner"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// Service creates and executes rolling version upgrade plans. Nodes are upgraded one
+// at a time; a node that doesn't become healthy and catch up on block height is rolled
+// back to its previous version and the plan is paused.
+type Service struct {
+	queries  *db.Queries
+	networks *service.NetworkService
+	nodes    *nodeservice.NodeService
+	logger   *logger.Logger
+
+	// running holds the plans being executed or rolled back
+	running *runner.Registry
+}
+
+// NewService creates a new upgrade service
+func NewService(queries *db.Queries, networks *service.NetworkService, nodes *nodeservice.NodeService, logger *logger.Logger) *Service {
+	return &Service{
+		queries:  queries,
+		networks: networks,
+		nodes:    nodes,
+		logger:   logger,
+		running:  runner.NewRegistry(),
+	}
+}
+
+// RecoverInterrupted pauses the plans left running by a previous server process
+// so they can be inspected and resumed
+func (s *Service) RecoverInterrupted(ctx context.Context) error {
+	running := func(ctx context.Context) ([]*db.UpgradePlan, error) {
+		plans, err := s.queries.ListUpgradePlansByStatus(ctx, string(PlanStatusRunning))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list running upgrade plans: %w", err)
+		}
+		return plans, nil
+	}
+	return runner.PauseInterrupted(ctx, running, func(ctx context.Context, plan *db.UpgradePlan) error {
+		s.logger.Warn("Pausing upgrade plan interrupted by restart", "planID", plan.ID, "networkID", plan.NetworkID)
+		return s.setPlanStatus(ctx, plan.ID, PlanStatusPaused, runner.InterruptedReason)
+	})
+}
+
+// CreatePlan creates an upgrade plan for the nodes of a network. Nodes already
+// running the target version are skipped.
+func (s *Service) CreatePlan(ctx context.Context, networkID int64, req CreatePlanRequest) (*Plan, error) {
+	if req.TargetVersion == "" {
+		return nil, fmt.Errorf("target version is required")
+	}
+	network, err := s.queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	consensus, err := networkConsensus(network)
+	if err != nil {
+		return nil, err
+	}
+
+	networkNodes, err := s.networks.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	selected := make(map[int64]bool, len(req.NodeIDs))
+	for _, id := range req.NodeIDs {
+		selected[id] = true
+	}
+	var nodes []*nodeservice.NodeResponse
+	consenters := 0
+	for _, networkNode := range networkNodes {
+		node := networkNode.Node
+		if node == nil {
+			continue
+		}
+		if isConsensusNode(node.NodeType) {
+			consenters++
+		}
+		if len(selected) > 0 && !selected[node.ID] {
+			continue
+		}
+		delete(selected, node.ID)
+		nodes = append(nodes, node)
+	}
+	for id := range selected {
+		return nil, fmt.Errorf("node %d is not part of network %d", id, networkID)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("network has no nodes to upgrade")
+	}
+	sortUpgradeOrder(nodes)
+
+	for _, node := range nodes {
+		if isConsensusNode(node.NodeType) && !req.AllowDowntime && !toleratesNodeDown(consensus, consenters) {
+			return nil, fmt.Errorf("network loses quorum while one of its %d %s consenters is upgraded; set allowDowntime to upgrade anyway", consenters, consensus)
+		}
+	}
+
+	healthTimeout := int64(req.HealthTimeoutSeconds)
+	if healthTimeout <= 0 {
+		healthTimeout = int64(defaultHealthTimeout / time.Second)
+	}
+	steps, err := planSteps(nodes, req.TargetVersion)
+	if err != nil {
+		return nil, err
+	}
+	var planID int64
+	err = s.queries.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		planID, err = storePlan(ctx, q, &db.CreateUpgradePlanParams{
+			NetworkID:            networkID,
+			TargetVersion:        req.TargetVersion,
+			Status:               string(PlanStatusPending),
+			HealthTimeoutSeconds: healthTimeout,
+			AllowDowntime:        req.AllowDowntime,
+		}, steps)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPlan(ctx, planID)
+}
+
+// storePlan creates a plan with its steps, unless the network already has an active plan.
+// It runs in the transaction of CreatePlan so a failure leaves no orphaned plan behind.
+func storePlan(ctx context.Context, q *db.Queries, params *db.CreateUpgradePlanParams, steps []*db.CreateUpgradePlanStepParams) (int64, error) {
+	active, err := q.CountActiveUpgradePlans(ctx, params.NetworkID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active upgrade plans: %w", err)
+	}
+	if active > 0 {
+		return 0, ErrPlanActive
+	}
+	plan, err := q.CreateUpgradePlan(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create upgrade plan: %w", err)
+	}
+	for _, step := range steps {
+		step.PlanID = plan.ID
+		if _, err := q.CreateUpgradePlanStep(ctx, step); err != nil {
+			return 0, fmt.Errorf("failed to create upgrade step: %w", err)
+		}
+	}
+	return plan.ID, nil
+}
+
+// GetPlan returns an upgrade plan with its steps
+func (s *Service) GetPlan(ctx context.Context, planID int64) (*Plan, error) {
+	plan, err := s.queries.GetUpgradePlan(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade plan: %w", err)
+	}
+	steps, err := s.queries.ListUpgradePlanSteps(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upgrade steps: %w", err)
+	}
+	return mapPlan(plan, steps), nil
+}
+
+// ListPlans returns the upgrade plans of a network, newest first
+func (s *Service) ListPlans(ctx context.Context, networkID int64) ([]Plan, error) {
+	plans, err := s.queries.ListUpgradePlansByNetwork(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upgrade plans: %w", err)
+	}
+	result := make([]Plan, 0, len(plans))
+	for _, plan := range plans {
+		steps, err := s.queries.ListUpgradePlanSteps(ctx, plan.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list upgrade steps: %w", err)
+		}
+		result = append(result, *mapPlan(plan, steps))
+	}
+	return result, nil
+}
+
+// StartPlan starts a pending plan, or resumes a paused one from its first unfinished step.
+// The upgrade runs in the background; poll the plan to follow its progress.
+func (s *Service) StartPlan(ctx context.Context, planID int64) (*Plan, error) {
+	plan, err := s.queries.GetUpgradePlan(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade plan: %w", err)
+	}
+	status := PlanStatus(plan.Status)
+	if status != PlanStatusPending && status != PlanStatusPaused {
+		return nil, fmt.Errorf("%w: plan is %s", ErrInvalidPlanState, status)
+	}
+	if err := s.claim(planID); err != nil {
+		return nil, err
+	}
+	if err := s.queries.StartUpgradePlan(ctx, planID); err != nil {
+		s.running.Release(planID)
+		return nil, fmt.Errorf("failed to start upgrade plan: %w", err)
+	}
+
+	go s.execute(context.Background(), plan)
+	return s.GetPlan(ctx, planID)
+}
+
+// CancelPlan cancels a plan that is not finished. A running plan stops once the
+// node being upgraded is done; nodes already upgraded keep the new version.
+func (s *Service) CancelPlan(ctx context.Context, planID int64) (*Plan, error) {
+	plan, err := s.queries.GetUpgradePlan(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade plan: %w", err)
+	}
+	switch PlanStatus(plan.Status) {
+	case PlanStatusRunning:
+		s.running.RequestCancel(planID)
+	case PlanStatusPending, PlanStatusPaused:
+		if err := s.setPlanStatus(ctx, planID, PlanStatusCancelled, ""); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: plan is %s", ErrInvalidPlanState, plan.Status)
+	}
+	return s.GetPlan(ctx, planID)
+}
+
+// RollbackPlan moves the upgraded nodes of a paused, cancelled or completed plan back
+// to their previous version, in reverse order. It runs in the background.
+func (s *Service) RollbackPlan(ctx context.Context, planID int64) (*Plan, error) {
+	plan, err := s.queries.GetUpgradePlan(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade plan: %w", err)
+	}
+	switch PlanStatus(plan.Status) {
+	case PlanStatusPaused, PlanStatusCancelled, PlanStatusCompleted, PlanStatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: plan is %s", ErrInvalidPlanState, plan.Status)
+	}
+	if err := s.claim(planID); err != nil {
+		return nil, err
+	}
+	if err := s.queries.StartUpgradePlan(ctx, planID); err != nil {
+		s.running.Release(planID)
+		return nil, fmt.Errorf("failed to start rollback: %w", err)
+	}
+
+	go s.rollback(context.Background(), plan)
+	return s.GetPlan(ctx, planID)
+}
+
+// claim marks a plan as running so it can't be started twice
+func (s *Service) claim(planID int64) error {
+	if !s.running.Claim(planID) {
+		return fmt.Errorf("%w: plan is already running", ErrInvalidPlanState)
+	}
+	return nil
+}
+
+// execute runs the unfinished steps of a plan in order
+func (s *Service) execute(ctx context.Context, plan *db.UpgradePlan) {
+	defer s.running.Release(plan.ID)
+	logger := s.logger.With("planID", plan.ID, "networkID", plan.NetworkID)
+
+	network, err := s.queries.GetNetwork(ctx, plan.NetworkID)
+	if err != nil {
+		s.pause(ctx, plan.ID, fmt.Sprintf("failed to get network: %v", err))
+		return
+	}
+	steps, err := s.queries.ListUpgradePlanSteps(ctx, plan.ID)
+	if err != nil {
+		s.pause(ctx, plan.ID, fmt.Sprintf("failed to list upgrade steps: %v", err))
+		return
+	}
+	timeout := time.Duration(plan.HealthTimeoutSeconds) * time.Second
+
+	for _, step := range steps {
+		switch StepStatus(step.Status) {
+		case StepStatusCompleted, StepStatusSkipped:
+			continue
+		}
+		if s.running.CancelRequested(plan.ID) {
+			logger.Info("Upgrade plan cancelled")
+			if err := s.setPlanStatus(ctx, plan.ID, PlanStatusCancelled, ""); err != nil {
+				logger.Error("Failed to cancel upgrade plan", "error", err)
+			}
+			return
+		}
+
+		logger.Info("Upgrading node", "nodeID", step.NodeID, "from", step.FromVersion, "to", step.ToVersion)
+		if err := s.upgradeStep(ctx, network, plan, step, timeout); err != nil {
+			logger.Warn("Upgrade step failed", "nodeID", step.NodeID, "error", err)
+			s.pause(ctx, plan.ID, fmt.Sprintf("step %d (node %d): %v", step.StepOrder, step.NodeID, err))
+			return
+		}
+	}
+
+	logger.Info("Upgrade plan completed")
+	if err := s.setPlanStatus(ctx, plan.ID, PlanStatusCompleted, ""); err != nil {
+		logger.Error("Failed to complete upgrade plan", "error", err)
+	}
+}
+
+// upgradeStep upgrades one node, rolling it back when it doesn't become healthy.
+// The returned error is what paused the plan.
+func (s *Service) upgradeStep(ctx context.Context, network *db.Network, plan *db.UpgradePlan, step *db.UpgradePlanStep, timeout time.Duration) error {
+	startedAt := time.Now()
+	s.setStepStatus(ctx, step, StepStatusUpgrading, "", &startedAt, nil)
+
+	// Nothing changed yet when the quorum check fails, so there is nothing to roll back
+	if err := s.checkQuorum(ctx, network, plan, step.NodeID); err != nil {
+		s.setStepStatus(ctx, step, StepStatusPending, err.Error(), nil, nil)
+		return err
+	}
+	refHeight := s.referenceHeight(ctx, network, step.NodeID)
+
+	upgradeErr := func() error {
+		if _, err := s.nodes.UpgradeNodeVersion(ctx, step.NodeID, step.ToVersion); err != nil {
+			return err
+		}
+		s.setStepStatus(ctx, step, StepStatusVerifying, "", &startedAt, nil)
+		return s.waitHealthy(ctx, network, step.NodeID, refHeight, timeout)
+	}()
+	if upgradeErr == nil {
+		completedAt := time.Now()
+		s.setStepStatus(ctx, step, StepStatusCompleted, "", &startedAt, &completedAt)
+		return nil
+	}
+
+	s.logger.Warn("Rolling back node", "planID", plan.ID, "nodeID", step.NodeID, "version", step.FromVersion, "error", upgradeErr)
+	if _, err := s.nodes.RollbackNodeVersion(ctx, step.NodeID, step.FromVersion); err != nil {
+		msg := fmt.Sprintf("upgrade failed: %v; rollback to %s failed: %v", upgradeErr, step.FromVersion, err)
+		s.setStepStatus(ctx, step, StepStatusFailed, msg, &startedAt, nil)
+		return fmt.Errorf("%s", msg)
+	}
+	if err := s.waitHealthy(ctx, network, step.NodeID, 0, timeout); err != nil {
+		msg := fmt.Sprintf("upgrade failed: %v; node unhealthy after rollback to %s: %v", upgradeErr, step.FromVersion, err)
+		s.setStepStatus(ctx, step, StepStatusFailed, msg, &startedAt, nil)
+		return fmt.Errorf("%s", msg)
+	}
+	completedAt := time.Now()
+	s.setStepStatus(ctx, step, StepStatusRolledBack, upgradeErr.Error(), &startedAt, &completedAt)
+	return fmt.Errorf("upgrade failed, node rolled back to %s: %w", step.FromVersion, upgradeErr)
+}
+
+// rollback restores the previous version of every upgraded node, newest step first
+func (s *Service) rollback(ctx context.Context, plan *db.UpgradePlan) {
+	defer s.running.Release(plan.ID)
+	logger := s.logger.With("planID", plan.ID, "networkID", plan.NetworkID)
+
+	network, err := s.queries.GetNetwork(ctx, plan.NetworkID)
+	if err != nil {
+		s.failRollback(ctx, plan.ID, fmt.Sprintf("failed to get network: %v", err))
+		return
+	}
+	steps, err := s.queries.ListUpgradePlanSteps(ctx, plan.ID)
+	if err != nil {
+		s.failRollback(ctx, plan.ID, fmt.Sprintf("failed to list upgrade steps: %v", err))
+		return
+	}
+	timeout := time.Duration(plan.HealthTimeoutSeconds) * time.Second
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		switch StepStatus(step.Status) {
+		case StepStatusCompleted, StepStatusFailed, StepStatusVerifying, StepStatusUpgrading:
+		default:
+			continue
+		}
+		if err := s.checkQuorum(ctx, network, plan, step.NodeID); err != nil {
+			s.failRollback(ctx, plan.ID, fmt.Sprintf("step %d (node %d): %v", step.StepOrder, step.NodeID, err))
+			return
+		}
+
+		logger.Info("Rolling back node", "nodeID", step.NodeID, "version", step.FromVersion)
+		refHeight := s.referenceHeight(ctx, network, step.NodeID)
+		if _, err := s.nodes.RollbackNodeVersion(ctx, step.NodeID, step.FromVersion); err != nil {
+			s.setStepStatus(ctx, step, StepStatusFailed, fmt.Sprintf("rollback failed: %v", err), nil, nil)
+			s.failRollback(ctx, plan.ID, fmt.Sprintf("step %d (node %d): rollback failed: %v", step.StepOrder, step.NodeID, err))
+			return
+		}
+		if err := s.waitHealthy(ctx, network, step.NodeID, refHeight, timeout); err != nil {
+			s.setStepStatus(ctx, step, StepStatusFailed, fmt.Sprintf("unhealthy after rollback: %v", err), nil, nil)
+			s.failRollback(ctx, plan.ID, fmt.Sprintf("step %d (node %d): unhealthy after rollback: %v", step.StepOrder, step.NodeID, err))
+			return
+		}
+		completedAt := time.Now()
+		s.setStepStatus(ctx, step, StepStatusRolledBack, "", nil, &completedAt)
+	}
+
+	logger.Info("Upgrade plan rolled back")
+	if err := s.setPlanStatus(ctx, plan.ID, PlanStatusRolledBack, ""); err != nil {
+		logger.Error("Failed to mark upgrade plan rolled back", "error", err)
+	}
+}
+
+// checkQuorum makes sure taking nodeID down keeps the network consensus working:
+// every other consenter must be running and enough of them must remain for a quorum
+func (s *Service) checkQuorum(ctx context.Context, network *db.Network, plan *db.UpgradePlan, nodeID int64) error {
+	node, err := s.nodes.GetNode(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+	if !isConsensusNode(node.NodeType) || plan.AllowDowntime {
+		return nil
+	}
+	consensus, err := networkConsensus(network)
+	if err != nil {
+		return err
+	}
+	networkNodes, err := s.networks.GetNetworkNodes(ctx, network.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	total, othersUp := 0, 0
+	for _, networkNode := range networkNodes {
+		other := networkNode.Node
+		if other == nil || !isConsensusNode(other.NodeType) {
+			continue
+		}
+		total++
+		if other.ID != nodeID && other.Status == string(nodetypes.NodeStatusRunning) {
+			othersUp++
+		}
+	}
+	if quorum := consensusQuorum(consensus, total); othersUp < quorum {
+		return fmt.Errorf("quorum not safe: %d of %d other %s consenters running, %d needed", othersUp, total-1, consensus, quorum)
+	}
+	return nil
+}
+
+// referenceHeight is the highest block height reported by the other nodes of
+// the same type; an upgraded node must catch up to it. It is 0 when unknown.
+func (s *Service) referenceHeight(ctx context.Context, network *db.Network, nodeID int64) int64 {
+	node, err := s.nodes.GetNode(ctx, nodeID)
+	if err != nil {
+		return 0
+	}
+	networkNodes, err := s.networks.GetNetworkNodes(ctx, network.ID)
+	if err != nil {
+		return 0
+	}
+	var height int64
+	for _, networkNode := range networkNodes {
+		other := networkNode.Node
+		if other == nil || other.ID == nodeID || other.NodeType != node.NodeType || other.Status != string(nodetypes.NodeStatusRunning) {
+			continue
+		}
+		if h, err := s.blockHeight(ctx, network, other); err == nil && h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// blockHeight returns the ledger height of a node for the network
+func (s *Service) blockHeight(ctx context.Context, network *db.Network, node *nodeservice.NodeResponse) (int64, error) {
+	switch node.NodeType {
+	case nodetypes.NodeTypeFabricPeer, nodetypes.NodeTypeFabricOrderer:
+		channels, err := s.nodes.GetNodeChannels(ctx, node.ID)
+		if err != nil {
+			return 0, err
+		}
+		for _, channel := range channels {
+			if channel.Name == network.Name {
+				return channel.BlockNum, nil
+			}
+		}
+		return 0, fmt.Errorf("node %s has not joined channel %s", node.Name, network.Name)
+	case nodetypes.NodeTypeBesuFullnode:
+		rpcClient, err := s.nodes.GetBesuRPCClient(ctx, node.ID)
+		if err != nil {
+			return 0, err
+		}
+		blockNumber, err := rpcClient.GetBlockNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return parseBlockNumber(blockNumber)
+	default:
+		return 0, fmt.Errorf("unsupported node type %s", node.NodeType)
+	}
+}
+
+// waitHealthy waits until the node runs and its block height reaches minHeight
+func (s *Service) waitHealthy(ctx context.Context, network *db.Network, nodeID int64, minHeight int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for {
+		node, err := s.nodes.GetNode(ctx, nodeID)
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("failed to get node: %w", err)
+		case node.Status != string(nodetypes.NodeStatusRunning):
+			lastErr = fmt.Errorf("node is %s", node.Status)
+		default:
+			height, err := s.blockHeight(ctx, network, node)
+			switch {
+			case err != nil:
+				lastErr = fmt.Errorf("failed to get block height: %w", err)
+			case height < minHeight:
+				lastErr = fmt.Errorf("node at block height %d, network at %d", height, minHeight)
+			default:
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node not healthy after %s: %w", timeout, lastErr)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+func (s *Service) pause(ctx context.Context, planID int64, reason string) {
+	if err := s.setPlanStatus(ctx, planID, PlanStatusPaused, reason); err != nil {
+		s.logger.Error("Failed to pause upgrade plan", "planID", planID, "error", err)
+	}
+}
+
+func (s *Service) failRollback(ctx context.Context, planID int64, reason string) {
+	if err := s.setPlanStatus(ctx, planID, PlanStatusFailed, reason); err != nil {
+		s.logger.Error("Failed to mark upgrade plan failed", "planID", planID, "error", err)
+	}
+}
+
+func (s *Service) setPlanStatus(ctx context.Context, planID int64, status PlanStatus, reason string) error {
+	var completedAt sql.NullTime
+	switch status {
+	case PlanStatusCompleted, PlanStatusCancelled, PlanStatusRolledBack:
+		completedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	if err := s.queries.UpdateUpgradePlanStatus(ctx, &db.UpdateUpgradePlanStatusParams{
+		Status:      string(status),
+		Error:       sql.NullString{String: reason, Valid: reason != ""},
+		CompletedAt: completedAt,
+		ID:          planID,
+	}); err != nil {
+		return fmt.Errorf("failed to update upgrade plan status: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) setStepStatus(ctx context.Context, step *db.UpgradePlanStep, status StepStatus, reason string, startedAt, completedAt *time.Time) {
+	params := &db.UpdateUpgradePlanStepParams{
+		Status:      string(status),
+		Error:       sql.NullString{String: reason, Valid: reason != ""},
+		StartedAt:   step.StartedAt,
+		CompletedAt: sql.NullTime{},
+		ID:          step.ID,
+	}
+	if startedAt != nil {
+		params.StartedAt = sql.NullTime{Time: *startedAt, Valid: true}
+	}
+	if completedAt != nil {
+		params.CompletedAt = sql.NullTime{Time: *completedAt, Valid: true}
+	}
+	if err := s.queries.UpdateUpgradePlanStep(ctx, params); err != nil {
+		s.logger.Error("Failed to update upgrade step", "stepID", step.ID, "error", err)
+		return
+	}
+	step.Status = params.Status
+	step.Error = params.Error
+	step.StartedAt = params.StartedAt
+	step.CompletedAt = params.CompletedAt
+}
+
+// networkConsensus returns the consensus protocol of a Fabric or Besu network
+func networkConsensus(network *db.Network) (string, error) {
+	switch network.Platform {
+	case string(service.BlockchainTypeFabric):
+		var config struct {
+			ConsensusType string `json:"consensus_type"`
+		}
+		if network.Config.Valid && network.Config.String != "" {
+			if err := json.Unmarshal([]byte(network.Config.String), &config); err != nil {
+				return "", fmt.Errorf("failed to parse network config: %w", err)
+			}
+		}
+		if config.ConsensusType == consensusSmartBFT {
+			return consensusSmartBFT, nil
+		}
+		return consensusEtcdRaft, nil
+	case string(service.BlockchainTypeBesu):
+		return consensusQBFT, nil
+	default:
+		return "", fmt.Errorf("rolling upgrades are not supported for platform %s", network.Platform)
+	}
+}
+
+func mapPlan(plan *db.UpgradePlan, steps []*db.UpgradePlanStep) *Plan {
+	result := &Plan{
+		ID:                   plan.ID,
+		NetworkID:            plan.NetworkID,
+		TargetVersion:        plan.TargetVersion,
+		Status:               PlanStatus(plan.Status),
+		HealthTimeoutSeconds: plan.HealthTimeoutSeconds,
+		AllowDowntime:        plan.AllowDowntime,
+		Error:                plan.Error.String,
+		CreatedAt:            plan.CreatedAt,
+		StartedAt:            runner.TimePtr(plan.StartedAt),
+		CompletedAt:          runner.TimePtr(plan.CompletedAt),
+		Steps:                make([]Step, 0, len(steps)),
+	}
+	for _, step := range steps {
+		result.Steps = append(result.Steps, Step{
+			ID:          step.ID,
+			Order:       step.StepOrder,
+			NodeID:      step.NodeID,
+			NodeType:    step.NodeType,
+			FromVersion: step.FromVersion,
+			ToVersion:   step.ToVersion,
+			Status:      StepStatus(step.Status),
+			Error:       step.Error.String,
+			StartedAt:   runner.TimePtr(step.StartedAt),
+			CompletedAt: runner.TimePtr(step.CompletedAt),
+		})
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package upgrade
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func newTestQueries(t *testing.T) (*db.Queries, int64, int64) {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+
+	queries := db.New(sqlDB)
+	ctx := context.Background()
+	network, err := queries.CreateNetwork(ctx, &db.CreateNetworkParams{Name: "net", Platform: "FABRIC", Status: "running"})
+	require.NoError(t, err)
+	node, err := queries.CreateNode(ctx, &db.CreateNodeParams{Name: "peer0", Slug: "peer0", Platform: "FABRIC", Status: "RUNNING"})
+	require.NoError(t, err)
+	return queries, network.ID, node.ID
+}
+
+func TestStorePlan(t *testing.T) {
+	ctx := context.Background()
+	queries, networkID, nodeID := newTestQueries(t)
+
+	params := func() *db.CreateUpgradePlanParams {
+		return &db.CreateUpgradePlanParams{
+			NetworkID:            networkID,
+			TargetVersion:        "3.1.1",
+			Status:               string(PlanStatusPending),
+			HealthTimeoutSeconds: 300,
+		}
+	}
+	step := func(nodeID int64) *db.CreateUpgradePlanStepParams {
+		return &db.CreateUpgradePlanStepParams{
+			StepOrder:   1,
+			NodeID:      nodeID,
+			NodeType:    "FABRIC_PEER",
+			FromVersion: "2.5.12",
+			ToVersion:   "3.1.1",
+			Status:      string(StepStatusPending),
+		}
+	}
+
+	// A step that can't be stored rolls back the plan
+	err := queries.ExecTx(ctx, func(q *db.Queries) error {
+		_, err := storePlan(ctx, q, params(), []*db.CreateUpgradePlanStepParams{step(nodeID + 1)})
+		return err
+	})
+	require.Error(t, err)
+	plans, err := queries.ListUpgradePlansByNetwork(ctx, networkID)
+	require.NoError(t, err)
+	assert.Empty(t, plans)
+
+	var planID int64
+	require.NoError(t, queries.ExecTx(ctx, func(q *db.Queries) error {
+		planID, err = storePlan(ctx, q, params(), []*db.CreateUpgradePlanStepParams{step(nodeID)})
+		return err
+	}))
+	steps, err := queries.ListUpgradePlanSteps(ctx, planID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, nodeID, steps[0].NodeID)
+
+	// The pending plan blocks a second one
+	err = queries.ExecTx(ctx, func(q *db.Queries) error {
+		_, err := storePlan(ctx, q, params(), []*db.CreateUpgradePlanStepParams{step(nodeID)})
+		return err
+	})
+	assert.ErrorIs(t, err, ErrPlanActive)
+}
@@ -0,0 +1,95 @@
+package upgrade
+
+import (
+	"errors"
+	"time"
+)
+
+// PlanStatus is the state of an upgrade plan
+type PlanStatus string
+
+const (
+	PlanStatusPending PlanStatus = "pending"
+	PlanStatusRunning PlanStatus = "running"
+	// PlanStatusPaused is set when a step fails or the server restarts mid-upgrade.
+	// Starting the plan again retries from the first unfinished step.
+	PlanStatusPaused     PlanStatus = "paused"
+	PlanStatusCompleted  PlanStatus = "completed"
+	PlanStatusCancelled  PlanStatus = "cancelled"
+	PlanStatusRolledBack PlanStatus = "rolled_back"
+	// PlanStatusFailed is set when rolling a plan back fails
+	PlanStatusFailed PlanStatus = "failed"
+)
+
+// StepStatus is the state of a single node upgrade
+type StepStatus string
+
+const (
+	StepStatusPending   StepStatus = "pending"
+	StepStatusUpgrading StepStatus = "upgrading"
+	// StepStatusVerifying means the node runs the new version and is catching up
+	StepStatusVerifying StepStatus = "verifying"
+	StepStatusCompleted StepStatus = "completed"
+	// StepStatusSkipped is used for nodes already running the target version
+	StepStatusSkipped StepStatus = "skipped"
+	// StepStatusFailed means the upgrade failed and the node could not be rolled back
+	StepStatusFailed StepStatus = "failed"
+	// StepStatusRolledBack means the node runs its previous version again
+	StepStatusRolledBack StepStatus = "rolled_back"
+)
+
+const (
+	defaultHealthTimeout = 5 * time.Minute
+	// healthPollInterval is how often an upgraded node is checked while catching up
+	healthPollInterval = 5 * time.Second
+)
+
+var (
+	// ErrPlanActive is returned when a network already has an unfinished upgrade plan
+	ErrPlanActive = errors.New("network already has an active upgrade plan")
+	// ErrInvalidPlanState is returned when an action is not allowed in the current plan status
+	ErrInvalidPlanState = errors.New("action not allowed in the current plan status")
+)
+
+// CreatePlanRequest describes an upgrade plan to create
+type CreatePlanRequest struct {
+	// TargetVersion is the Fabric or Besu version to move the nodes to
+	TargetVersion string `json:"targetVersion" validate:"required"`
+	// NodeIDs restricts the plan to these network nodes. Empty means all of them.
+	NodeIDs []int64 `json:"nodeIds,omitempty"`
+	// HealthTimeoutSeconds is how long an upgraded node may take to become healthy
+	// and catch up on block height before it is rolled back (default: 300)
+	HealthTimeoutSeconds int `json:"healthTimeoutSeconds,omitempty"`
+	// AllowDowntime permits upgrading consensus nodes of networks that lose
+	// quorum with a single node down, such as single-orderer networks
+	AllowDowntime bool `json:"allowDowntime,omitempty"`
+}
+
+// Plan is a rolling upgrade of the nodes of a network
+type Plan struct {
+	ID                   int64      `json:"id"`
+	NetworkID            int64      `json:"networkId"`
+	TargetVersion        string     `json:"targetVersion"`
+	Status               PlanStatus `json:"status"`
+	HealthTimeoutSeconds int64      `json:"healthTimeoutSeconds"`
+	AllowDowntime        bool       `json:"allowDowntime"`
+	Error                string     `json:"error,omitempty"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	StartedAt            *time.Time `json:"startedAt,omitempty"`
+	CompletedAt          *time.Time `json:"completedAt,omitempty"`
+	Steps                []Step     `json:"steps"`
+}
+
+// Step upgrades a single node
+type Step struct {
+	ID          int64      `json:"id"`
+	Order       int64      `json:"order"`
+	NodeID      int64      `json:"nodeId"`
+	NodeType    string     `json:"nodeType"`
+	FromVersion string     `json:"fromVersion"`
+	ToVersion   string     `json:"toVersion"`
+	Status      StepStatus `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
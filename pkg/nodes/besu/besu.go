@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/chainlaunch/chainlaunch/pkg/config"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service/types"
 	settingsservice "github.com/chainlaunch/chainlaunch/pkg/settings/service"
@@ -32,6 +33,8 @@ type LocalBesu struct {
 	logger          *logger.Logger
 	configService   *config.ConfigService
 	settingsService *settingsservice.SettingsService
+	// remote runs the node on another machine when set
+	remote *hosts.Runner
 }
 
 // NewLocalBesu creates a new LocalBesu instance
@@ -75,6 +78,10 @@ func (b *LocalBesu) Start() (interface{}, error) {
 		}
 	}
 
+	if b.remote != nil {
+		return b.startRemote(dataDir, configDir)
+	}
+
 	// Install Besu if not exists
 	if err := b.installBesu(); err != nil {
 		return nil, fmt.Errorf("failed to install Besu: %w", err)
@@ -267,6 +274,8 @@ func (b *LocalBesu) buildCommand(dataDir string, genesisPath string, configDir s
 		"--profile=ENTERPRISE",
 	}
 
+	cmd = append(cmd, b.wsArgs()...)
+
 	// Add bootnodes if specified
 	if len(b.opts.BootNodes) > 0 {
 		cmd = append(cmd, fmt.Sprintf("--bootnodes=%s", strings.Join(b.opts.BootNodes, ",")))
@@ -275,6 +284,20 @@ func (b *LocalBesu) buildCommand(dataDir string, genesisPath string, configDir s
 	return strings.Join(cmd, " ")
 }
 
+// wsArgs returns the WebSocket RPC flags, or nil when no WebSocket port is configured.
+// Subscriptions such as eth_subscribe are only served over WebSocket.
+func (b *LocalBesu) wsArgs() []string {
+	if b.opts.WSPort == "" || b.opts.WSPort == "0" {
+		return nil
+	}
+	return []string{
+		"--rpc-ws-enabled",
+		"--rpc-ws-api=ETH,NET,QBFT",
+		"--rpc-ws-host=0.0.0.0",
+		fmt.Sprintf("--rpc-ws-port=%s", b.opts.WSPort),
+	}
+}
+
 // buildEnvironment builds the environment variables for Besu
 func (b *LocalBesu) buildEnvironment() map[string]string {
 	env := make(map[string]string)
@@ -317,6 +340,9 @@ func (b *LocalBesu) buildDockerEnvironment() map[string]string {
 
 // Stop stops the Besu node
 func (b *LocalBesu) Stop() error {
+	if b.remote != nil {
+		return b.stopRemote()
+	}
 	b.logger.Info("Stopping Besu node", "opts", b.opts)
 
 	switch b.mode {
@@ -544,6 +570,9 @@ func copyDir(src string, dst string) error {
 
 // TailLogs tails the logs of the besu service
 func (b *LocalBesu) TailLogs(ctx context.Context, tail int, follow bool) (<-chan string, error) {
+	if b.remote != nil {
+		return b.remote.TailContainer(ctx, b.getContainerName(), tail, follow)
+	}
 	logChan := make(chan string, 100)
 
 	if b.mode == "docker" {
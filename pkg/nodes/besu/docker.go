@@ -72,6 +72,32 @@ func (b *LocalBesu) startDocker(env map[string]string, dataDir, configDir string
 	if err := docker.PullImageIfNeeded(ctx, cli, imageName); err != nil {
 		return nil, err
 	}
+	config, hostConfig := b.dockerContainerConfig(imageName, env, dataDir, configDir)
+
+	// Remove existing container if it exists
+	if err := b.removeExistingContainer(ctx, cli, containerName); err != nil {
+		return nil, err
+	}
+
+	// Create container
+	resp, err := cli.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container: %w", err)
+	}
+
+	// Start container
+	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return &StartDockerResponse{
+		Mode:          "docker",
+		ContainerName: containerName,
+	}, nil
+}
+
+// dockerContainerConfig returns the container and host configuration of the besu container
+func (b *LocalBesu) dockerContainerConfig(imageName string, env map[string]string, dataDir, configDir string) (*container.Config, *container.HostConfig) {
 	// Create port bindings
 	portBindings := nat.PortMap{
 		nat.Port(fmt.Sprintf("%s/tcp", b.opts.RPCPort)): []nat.PortBinding{
@@ -88,6 +114,12 @@ func (b *LocalBesu) startDocker(env map[string]string, dataDir, configDir string
 		},
 	}
 
+	if len(b.wsArgs()) > 0 {
+		portBindings[nat.Port(fmt.Sprintf("%s/tcp", b.opts.WSPort))] = []nat.PortBinding{
+			{HostIP: "0.0.0.0", HostPort: b.opts.WSPort},
+		}
+	}
+
 	// Create container config
 	config := &container.Config{
 		Image:        imageName,
@@ -123,26 +155,7 @@ func (b *LocalBesu) startDocker(env map[string]string, dataDir, configDir string
 		},
 	}
 
-	// Remove existing container if it exists
-	if err := b.removeExistingContainer(ctx, cli, containerName); err != nil {
-		return nil, err
-	}
-
-	// Create container
-	resp, err := cli.ContainerCreate(ctx, config, hostConfig, nil, nil, containerName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container: %w", err)
-	}
-
-	// Start container
-	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
-		return nil, fmt.Errorf("failed to start container: %w", err)
-	}
-
-	return &StartDockerResponse{
-		Mode:          "docker",
-		ContainerName: containerName,
-	}, nil
+	return config, hostConfig
 }
 
 // stopDocker stops the besu docker container
@@ -224,6 +237,8 @@ func (b *LocalBesu) buildDockerBesuArgs(dataPath, configPath string) []string {
 		"--profile=ENTERPRISE",
 	}
 
+	cmd = append(cmd, b.wsArgs()...)
+
 	// Add bootnodes if specified
 	if len(b.opts.BootNodes) > 0 {
 		cmd = append(cmd, fmt.Sprintf("--bootnodes=%s", strings.Join(b.opts.BootNodes, ",")))
@@ -0,0 +1,53 @@
+package besu
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
+)
+
+// ExpectedDeployment renders the genesis file, systemd unit and container the node
+// should run with according to its stored configuration. The node key is not
+// compared so it never shows up in a report. Launchd plists are not compared, so on
+// macOS only the genesis file is returned.
+func (b *LocalBesu) ExpectedDeployment() (*drift.Expected, error) {
+	slugifiedID := strings.ReplaceAll(strings.ToLower(b.opts.ID), " ", "-")
+	dirPath := filepath.Join(b.configService.GetDataPath(), "besu", slugifiedID)
+	dataDir := filepath.Join(dirPath, "data")
+	configDir := filepath.Join(dirPath, "config")
+	genesisPath := filepath.Join(configDir, "genesis.json")
+
+	exp := &drift.Expected{
+		Files: []drift.File{
+			{Name: "genesis.json", Path: genesisPath, Content: []byte(b.opts.GenesisFile)},
+		},
+	}
+	switch b.mode {
+	case "service":
+		if runtime.GOOS != "linux" {
+			return exp, nil
+		}
+		exp.Unit = &drift.Unit{
+			Path:      b.getServiceFilePath(),
+			ExecStart: b.buildCommand(dataDir, genesisPath, configDir),
+			Env:       b.buildEnvironment(),
+		}
+	case "docker":
+		env := b.buildDockerEnvironment()
+		imageName := fmt.Sprintf("hyperledger/besu:%s", b.opts.Version)
+		containerConfig, hostConfig := b.dockerContainerConfig(imageName, env, dataDir, configDir)
+		exp.Container = &drift.Container{
+			Name:       b.getContainerName(),
+			Image:      containerConfig.Image,
+			Cmd:        containerConfig.Cmd,
+			Env:        env,
+			HostConfig: hostConfig,
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", b.mode)
+	}
+	return exp, nil
+}
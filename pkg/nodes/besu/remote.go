@@ -0,0 +1,52 @@
+package besu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+)
+
+// SetRemoteHost makes the node start, stop and tail logs on a remote host.
+// The caller owns the runner and closes it.
+func (b *LocalBesu) SetRemoteHost(runner *hosts.Runner) {
+	b.remote = runner
+}
+
+// startRemote writes the node configuration, copies it to the remote host and
+// starts the node container there. Besu is distributed as a JVM application,
+// so remote nodes always run in docker mode.
+func (b *LocalBesu) startRemote(dataDir, configDir string) (interface{}, error) {
+	if b.mode != "docker" {
+		return nil, fmt.Errorf("besu nodes on remote hosts must use docker mode, got %s", b.mode)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "genesis.json"), []byte(b.opts.GenesisFile), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write genesis file: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "key"), []byte(b.opts.NodePrivateKey), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	imageName := fmt.Sprintf("hyperledger/besu:%s", b.opts.Version)
+	config, hostConfig := b.dockerContainerConfig(imageName, b.buildDockerEnvironment(), dataDir, configDir)
+	if err := b.remote.RunContainer(context.Background(), hosts.ContainerSpec{
+		Name:       b.getContainerName(),
+		Config:     config,
+		HostConfig: hostConfig,
+		Upload:     []string{configDir},
+	}); err != nil {
+		return nil, err
+	}
+	return &StartDockerResponse{
+		Mode:          "docker",
+		ContainerName: b.getContainerName(),
+	}, nil
+}
+
+// stopRemote stops the node container on the remote host
+func (b *LocalBesu) stopRemote() error {
+	b.logger.Info("Stopping Besu node on remote host", "opts", b.opts, "host", b.remote.Host().Name)
+	return b.remote.StopContainer(context.Background(), b.getContainerName())
+}
@@ -20,6 +20,7 @@ type StartBesuOpts struct {
 	P2PPort        string            `json:"p2pPort"`
 	RPCHost        string            `json:"rpcHost"`
 	RPCPort        string            `json:"rpcPort"`
+	WSPort         string            `json:"wsPort,omitempty"`
 	ConsensusType  string            `json:"consensusType"`
 	NetworkID      int64             `json:"networkId"`
 	ChainID        int64             `json:"chainId"`
@@ -0,0 +1,360 @@
+// Package drift compares what a node runs with on its host against what
+// ChainLaunch would generate from the node's stored configuration.
+package drift
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	dockerclient "github.com/docker/docker/client"
+)
+
+// Status is the outcome of a drift check
+type Status string
+
+const (
+	StatusInSync      Status = "IN_SYNC"
+	StatusDrifted     Status = "DRIFTED"
+	StatusUnsupported Status = "UNSUPPORTED"
+	StatusError       Status = "ERROR"
+)
+
+// Sources of findings
+const (
+	SourceFile      = "file"
+	SourceUnit      = "unit"
+	SourceContainer = "container"
+)
+
+// ErrNotFound is returned by a Source when a file or container does not exist
+var ErrNotFound = errors.New("not found")
+
+// Finding is a single difference between the expected and the actual deployment
+type Finding struct {
+	Source   string `json:"source"`
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Report is the drift of one node
+type Report struct {
+	NodeID     int64     `json:"nodeId"`
+	NodeName   string    `json:"nodeName"`
+	NodeType   string    `json:"nodeType"`
+	Mode       string    `json:"mode"`
+	Status     Status    `json:"status"`
+	Findings   []Finding `json:"findings"`
+	Error      string    `json:"error,omitempty"`
+	Reconciled bool      `json:"reconciled"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	// Reason explains why the drift of an unsupported node is not checked
+	Reason string `json:"reason,omitempty"`
+}
+
+// File is a rendered configuration file
+type File struct {
+	Name    string
+	Path    string
+	Content []byte
+}
+
+// Unit is the expected content of a systemd unit. An empty ExecStart is not compared.
+type Unit struct {
+	Path      string
+	ExecStart string
+	Env       map[string]string
+}
+
+// Container is the expected configuration of a Docker container. A nil Cmd or
+// HostConfig is not compared.
+type Container struct {
+	Name  string
+	Image string
+	Cmd   []string
+	Env   map[string]string
+	// HostConfig holds the expected port bindings and mounts
+	HostConfig *container.HostConfig
+}
+
+// Expected is everything a node's stored configuration renders to
+type Expected struct {
+	Files     []File
+	Unit      *Unit
+	Container *Container
+}
+
+// Source reads the actual deployment of a node
+type Source interface {
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+	InspectContainer(ctx context.Context, name string) (*container.InspectResponse, error)
+}
+
+// LocalSource reads files and containers on this machine
+type LocalSource struct{}
+
+// ReadFile reads a local file
+func (LocalSource) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// InspectContainer inspects a container of the local Docker daemon
+func (LocalSource) InspectContainer(ctx context.Context, name string) (*container.InspectResponse, error) {
+	cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer cli.Close()
+	info, err := cli.ContainerInspect(ctx, name)
+	if err != nil {
+		if dockerclient.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to inspect container %s: %w", name, err)
+	}
+	return &info, nil
+}
+
+// Compare reads the actual deployment from src and returns how it differs from exp
+func Compare(ctx context.Context, src Source, exp *Expected) ([]Finding, error) {
+	var findings []Finding
+	for _, f := range exp.Files {
+		actual, err := src.ReadFile(ctx, f.Path)
+		if errors.Is(err, ErrNotFound) {
+			findings = append(findings, Finding{Source: SourceFile, Resource: f.Name, Field: "exists", Expected: "true", Actual: "false"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path, err)
+		}
+		findings = append(findings, CompareFile(f.Name, f.Content, actual)...)
+	}
+
+	if exp.Unit != nil {
+		actual, err := src.ReadFile(ctx, exp.Unit.Path)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			findings = append(findings, Finding{Source: SourceUnit, Resource: exp.Unit.Path, Field: "exists", Expected: "true", Actual: "false"})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", exp.Unit.Path, err)
+		default:
+			findings = append(findings, CompareUnit(exp.Unit, actual)...)
+		}
+	}
+
+	if exp.Container != nil {
+		info, err := src.InspectContainer(ctx, exp.Container.Name)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			findings = append(findings, Finding{Source: SourceContainer, Resource: exp.Container.Name, Field: "exists", Expected: "true", Actual: "false"})
+		case err != nil:
+			return nil, err
+		default:
+			findings = append(findings, CompareContainer(exp.Container, info)...)
+		}
+	}
+	return findings, nil
+}
+
+// CompareFile reports the first line where a file differs from its rendered content
+func CompareFile(name string, expected, actual []byte) []Finding {
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	expLines := strings.Split(string(expected), "\n")
+	actLines := strings.Split(string(actual), "\n")
+	for i := 0; i < len(expLines) || i < len(actLines); i++ {
+		var e, a string
+		if i < len(expLines) {
+			e = expLines[i]
+		}
+		if i < len(actLines) {
+			a = actLines[i]
+		}
+		if e != a {
+			return []Finding{{
+				Source:   SourceFile,
+				Resource: name,
+				Field:    fmt.Sprintf("line %d", i+1),
+				Expected: strings.TrimSpace(e),
+				Actual:   strings.TrimSpace(a),
+			}}
+		}
+	}
+	return nil
+}
+
+// CompareUnit compares the command and environment of a systemd unit
+func CompareUnit(exp *Unit, content []byte) []Finding {
+	execStart, env := ParseUnit(content)
+	var findings []Finding
+	if exp.ExecStart != "" && exp.ExecStart != execStart {
+		findings = append(findings, Finding{Source: SourceUnit, Resource: exp.Path, Field: "ExecStart", Expected: exp.ExecStart, Actual: execStart})
+	}
+	for _, f := range compareEnv(exp.Env, env, true) {
+		f.Source = SourceUnit
+		f.Resource = exp.Path
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// CompareContainer compares the image, command, environment, port bindings and
+// mounts of a container. Variables the image itself defines are not reported as extra.
+func CompareContainer(exp *Container, info *container.InspectResponse) []Finding {
+	var findings []Finding
+	add := func(field, expected, actual string) {
+		findings = append(findings, Finding{Source: SourceContainer, Resource: exp.Name, Field: field, Expected: expected, Actual: actual})
+	}
+	if info.Config == nil {
+		add("config", "present", "missing")
+		return findings
+	}
+	if info.Config.Image != exp.Image {
+		add("image", exp.Image, info.Config.Image)
+	}
+	if exp.Cmd != nil && strings.Join(exp.Cmd, " ") != strings.Join(info.Config.Cmd, " ") {
+		add("cmd", strings.Join(exp.Cmd, " "), strings.Join(info.Config.Cmd, " "))
+	}
+	for _, f := range compareEnv(exp.Env, parseEnvSlice(info.Config.Env), false) {
+		f.Source = SourceContainer
+		f.Resource = exp.Name
+		findings = append(findings, f)
+	}
+	if exp.HostConfig != nil {
+		actual := &container.HostConfig{}
+		if info.ContainerJSONBase != nil && info.HostConfig != nil {
+			actual = info.HostConfig
+		}
+		for _, f := range compareHostConfig(exp.HostConfig, actual) {
+			f.Source = SourceContainer
+			f.Resource = exp.Name
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// compareHostConfig reports changed, missing and extra port bindings and mounts
+func compareHostConfig(expected, actual *container.HostConfig) []Finding {
+	findings := compareValues("port.", portBindings(expected), portBindings(actual), true)
+	return append(findings, compareValues("mount.", mountSources(expected), mountSources(actual), true)...)
+}
+
+// portBindings maps each container port to its host bindings. Ports without a
+// protocol are TCP, as Docker reports them.
+func portBindings(hc *container.HostConfig) map[string]string {
+	m := make(map[string]string, len(hc.PortBindings))
+	for port, bindings := range hc.PortBindings {
+		key := string(port)
+		if !strings.Contains(key, "/") {
+			key += "/tcp"
+		}
+		hosts := make([]string, 0, len(bindings))
+		for _, b := range bindings {
+			hosts = append(hosts, b.HostIP+":"+b.HostPort)
+		}
+		sort.Strings(hosts)
+		m[key] = strings.Join(hosts, ",")
+	}
+	return m
+}
+
+// mountSources maps each mount target to its source
+func mountSources(hc *container.HostConfig) map[string]string {
+	m := make(map[string]string, len(hc.Mounts))
+	for _, mnt := range hc.Mounts {
+		m[mnt.Target] = mnt.Source
+	}
+	return m
+}
+
+// compareEnv reports changed and missing variables, and extra ones when strict
+func compareEnv(expected, actual map[string]string, strict bool) []Finding {
+	return compareValues("env.", expected, actual, strict)
+}
+
+// compareValues reports changed and missing keys, and extra ones when strict.
+// Fields are the keys with prefix.
+func compareValues(prefix string, expected, actual map[string]string, strict bool) []Finding {
+	var findings []Finding
+	for _, k := range sortedKeys(expected) {
+		v, ok := actual[k]
+		switch {
+		case !ok:
+			findings = append(findings, Finding{Field: prefix + k, Expected: expected[k], Actual: "<unset>"})
+		case v != expected[k]:
+			findings = append(findings, Finding{Field: prefix + k, Expected: expected[k], Actual: v})
+		}
+	}
+	if strict {
+		for _, k := range sortedKeys(actual) {
+			if _, ok := expected[k]; !ok {
+				findings = append(findings, Finding{Field: prefix + k, Expected: "<unset>", Actual: actual[k]})
+			}
+		}
+	}
+	return findings
+}
+
+// ParseUnit extracts ExecStart and the Environment assignments of a unit file
+func ParseUnit(content []byte) (string, map[string]string) {
+	var execStart string
+	env := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "ExecStart="):
+			execStart = strings.TrimPrefix(line, "ExecStart=")
+		case strings.HasPrefix(line, "Environment="):
+			assignment := unquoteUnitValue(strings.TrimPrefix(line, "Environment="))
+			if k, v, ok := strings.Cut(assignment, "="); ok {
+				env[k] = v
+			}
+		}
+	}
+	return execStart, env
+}
+
+// unquoteUnitValue undoes the quoting and specifier escaping used in unit files
+func unquoteUnitValue(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+		s = strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(s)
+	}
+	return strings.ReplaceAll(s, "%%", "%")
+}
+
+func parseEnvSlice(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, e := range env {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	files      map[string]string
+	containers map[string]*container.InspectResponse
+}
+
+func (f *fakeSource) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	data, ok := f.files[path]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return []byte(data), nil
+}
+
+func (f *fakeSource) InspectContainer(ctx context.Context, name string) (*container.InspectResponse, error) {
+	info, ok := f.containers[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return info, nil
+}
+
+const unit = `
+[Unit]
+Description=Hyperledger Fabric Peer - peer0
+After=network.target
+
+[Service]
+ExecStart=/usr/local/bin/peer node start
+Environment="CORE_PEER_ID=peer0"
+Environment="CORE_PEER_LISTENADDRESS=0.0.0.0:7051"
+Environment="GREETING=hello world \"quoted\" 100%%"
+Environment=PLAIN=value
+
+[Install]
+WantedBy=multi-user.target
+`
+
+func TestParseUnit(t *testing.T) {
+	execStart, env := ParseUnit([]byte(unit))
+	assert.Equal(t, "/usr/local/bin/peer node start", execStart)
+	assert.Equal(t, map[string]string{
+		"CORE_PEER_ID":            "peer0",
+		"CORE_PEER_LISTENADDRESS": "0.0.0.0:7051",
+		"GREETING":                `hello world "quoted" 100%`,
+		"PLAIN":                   "value",
+	}, env)
+}
+
+func TestCompareInSync(t *testing.T) {
+	src := &fakeSource{
+		files: map[string]string{
+			"/data/core.yaml":                   "peer:\n  id: peer0\n",
+			"/etc/systemd/system/peer0.service": unit,
+		},
+		containers: map[string]*container.InspectResponse{},
+	}
+	findings, err := Compare(context.Background(), src, &Expected{
+		Files: []File{{Name: "core.yaml", Path: "/data/core.yaml", Content: []byte("peer:\n  id: peer0\n")}},
+		Unit: &Unit{
+			Path:      "/etc/systemd/system/peer0.service",
+			ExecStart: "/usr/local/bin/peer node start",
+			Env: map[string]string{
+				"CORE_PEER_ID":            "peer0",
+				"CORE_PEER_LISTENADDRESS": "0.0.0.0:7051",
+				"GREETING":                `hello world "quoted" 100%`,
+				"PLAIN":                   "value",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestCompareReportsDrift(t *testing.T) {
+	src := &fakeSource{
+		files: map[string]string{
+			"/data/core.yaml":                   "peer:\n  id: peer0\n  gossip: edited\n",
+			"/etc/systemd/system/peer0.service": unit,
+		},
+		containers: map[string]*container.InspectResponse{},
+	}
+	findings, err := Compare(context.Background(), src, &Expected{
+		Files: []File{
+			{Name: "core.yaml", Path: "/data/core.yaml", Content: []byte("peer:\n  id: peer0\n  gossip: default\n")},
+			{Name: "config.yaml", Path: "/data/config.yaml", Content: []byte("NodeOUs: {}\n")},
+		},
+		Unit: &Unit{
+			Path:      "/etc/systemd/system/peer0.service",
+			ExecStart: "/usr/local/bin/peer node start --verbose",
+			Env: map[string]string{
+				"CORE_PEER_ID":            "peer0",
+				"CORE_PEER_LISTENADDRESS": "0.0.0.0:7052",
+				"GREETING":                `hello world "quoted" 100%`,
+				"MISSING":                 "x",
+			},
+		},
+		Container: &Container{Name: "peer0", Image: "hyperledger/fabric-peer:3.1.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Source: SourceFile, Resource: "core.yaml", Field: "line 3", Expected: "gossip: default", Actual: "gossip: edited"},
+		{Source: SourceFile, Resource: "config.yaml", Field: "exists", Expected: "true", Actual: "false"},
+		{Source: SourceUnit, Resource: "/etc/systemd/system/peer0.service", Field: "ExecStart", Expected: "/usr/local/bin/peer node start --verbose", Actual: "/usr/local/bin/peer node start"},
+		{Source: SourceUnit, Resource: "/etc/systemd/system/peer0.service", Field: "env.CORE_PEER_LISTENADDRESS", Expected: "0.0.0.0:7052", Actual: "0.0.0.0:7051"},
+		{Source: SourceUnit, Resource: "/etc/systemd/system/peer0.service", Field: "env.MISSING", Expected: "x", Actual: "<unset>"},
+		{Source: SourceUnit, Resource: "/etc/systemd/system/peer0.service", Field: "env.PLAIN", Expected: "<unset>", Actual: "value"},
+		{Source: SourceContainer, Resource: "peer0", Field: "exists", Expected: "true", Actual: "false"},
+	}, findings)
+}
+
+func TestCompareContainer(t *testing.T) {
+	info := &container.InspectResponse{
+		Config: &container.Config{
+			Image: "hyperledger/fabric-peer:3.0.0",
+			Cmd:   []string{"peer", "node", "start"},
+			Env:   []string{"PATH=/usr/bin", "CORE_PEER_ID=peer0", "FABRIC_LOGGING_SPEC=debug"},
+		},
+	}
+	findings := CompareContainer(&Container{
+		Name:  "peer0",
+		Image: "hyperledger/fabric-peer:3.1.0",
+		Cmd:   []string{"peer", "node", "start"},
+		Env:   map[string]string{"CORE_PEER_ID": "peer0", "FABRIC_LOGGING_SPEC": "info"},
+	}, info)
+	assert.Equal(t, []Finding{
+		{Source: SourceContainer, Resource: "peer0", Field: "image", Expected: "hyperledger/fabric-peer:3.1.0", Actual: "hyperledger/fabric-peer:3.0.0"},
+		{Source: SourceContainer, Resource: "peer0", Field: "env.FABRIC_LOGGING_SPEC", Expected: "info", Actual: "debug"},
+	}, findings)
+
+	findings = CompareContainer(&Container{Name: "peer0", Image: "hyperledger/fabric-peer:3.0.0", Env: map[string]string{"CORE_PEER_ID": "peer0"}}, info)
+	assert.Empty(t, findings)
+}
+
+func TestCompareContainerHostConfig(t *testing.T) {
+	expected := &Container{
+		Name:  "peer0",
+		Image: "hyperledger/fabric-peer:3.1.0",
+		HostConfig: &container.HostConfig{
+			PortBindings: nat.PortMap{
+				"7051": {{HostIP: "0.0.0.0", HostPort: "7051"}},
+				"9443": {{HostIP: "0.0.0.0", HostPort: "9443"}},
+			},
+			Mounts: []mount.Mount{
+				{Type: mount.TypeBind, Source: "/data/peers/peer0/config", Target: "/etc/hyperledger/fabric/msp"},
+				{Type: mount.TypeBind, Source: "/data/peers/peer0/data", Target: "/var/hyperledger/production"},
+			},
+		},
+	}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				// Docker reports the protocol of the ports
+				PortBindings: nat.PortMap{
+					"7051/tcp": {{HostIP: "0.0.0.0", HostPort: "7051"}},
+					"9443/tcp": {{HostIP: "0.0.0.0", HostPort: "9443"}},
+				},
+				Mounts: []mount.Mount{
+					{Type: mount.TypeBind, Source: "/data/peers/peer0/config", Target: "/etc/hyperledger/fabric/msp"},
+					{Type: mount.TypeBind, Source: "/data/peers/peer0/data", Target: "/var/hyperledger/production"},
+				},
+			},
+		},
+		Config: &container.Config{Image: "hyperledger/fabric-peer:3.1.0"},
+	}
+	assert.Empty(t, CompareContainer(expected, info))
+
+	info.HostConfig.PortBindings = nat.PortMap{
+		"7051/tcp": {{HostIP: "0.0.0.0", HostPort: "17051"}},
+		"7052/tcp": {{HostIP: "0.0.0.0", HostPort: "7052"}},
+	}
+	info.HostConfig.Mounts = info.HostConfig.Mounts[:1]
+	assert.Equal(t, []Finding{
+		{Source: SourceContainer, Resource: "peer0", Field: "port.7051/tcp", Expected: "0.0.0.0:7051", Actual: "0.0.0.0:17051"},
+		{Source: SourceContainer, Resource: "peer0", Field: "port.9443/tcp", Expected: "0.0.0.0:9443", Actual: "<unset>"},
+		{Source: SourceContainer, Resource: "peer0", Field: "port.7052/tcp", Expected: "<unset>", Actual: "0.0.0.0:7052"},
+		{Source: SourceContainer, Resource: "peer0", Field: "mount./var/hyperledger/production", Expected: "/data/peers/peer0/data", Actual: "<unset>"},
+	}, CompareContainer(expected, info))
+}
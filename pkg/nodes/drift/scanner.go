@@ -0,0 +1,103 @@
+package drift
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+)
+
+// Checker checks the drift of all running nodes
+type Checker interface {
+	CheckNodesDrift(ctx context.Context, reconcile bool) ([]*Report, error)
+}
+
+// Scanner periodically checks all nodes for drift and keeps the latest report of each
+type Scanner struct {
+	checker   Checker
+	logger    *logger.Logger
+	interval  time.Duration
+	reconcile bool
+
+	mu      sync.RWMutex
+	reports map[int64]*Report
+	stopCh  chan struct{}
+}
+
+// NewScanner creates a scanner that runs every interval. When reconcile is true
+// drifted nodes are reconciled automatically.
+func NewScanner(checker Checker, logger *logger.Logger, interval time.Duration, reconcile bool) *Scanner {
+	return &Scanner{
+		checker:   checker,
+		logger:    logger,
+		interval:  interval,
+		reconcile: reconcile,
+		reports:   make(map[int64]*Report),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start scans until ctx is cancelled or Stop is called
+func (s *Scanner) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Scan(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.Scan(ctx)
+		}
+	}
+}
+
+// Stop stops the scanner
+func (s *Scanner) Stop() {
+	close(s.stopCh)
+}
+
+// Scan checks all nodes once and replaces the stored reports
+func (s *Scanner) Scan(ctx context.Context) []*Report {
+	reports, err := s.checker.CheckNodesDrift(ctx, s.reconcile)
+	if err != nil {
+		s.logger.Error("Failed to scan nodes for drift", "error", err)
+		return nil
+	}
+
+	latest := make(map[int64]*Report, len(reports))
+	for _, r := range reports {
+		latest[r.NodeID] = r
+		if r.Status == StatusDrifted {
+			s.logger.Warn("Node configuration drifted", "nodeID", r.NodeID, "name", r.NodeName, "findings", len(r.Findings), "reconciled", r.Reconciled)
+		}
+	}
+	s.mu.Lock()
+	s.reports = latest
+	s.mu.Unlock()
+	return reports
+}
+
+// Reports returns the latest report of every scanned node ordered by node ID
+func (s *Scanner) Reports() []*Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	reports := make([]*Report, 0, len(s.reports))
+	for _, r := range s.reports {
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].NodeID < reports[j].NodeID })
+	return reports
+}
+
+// Record stores a report produced outside of a scan, such as an on-demand check
+func (s *Scanner) Record(r *Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[r.NodeID] = r
+}
@@ -0,0 +1,42 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChecker struct {
+	reports   []*Report
+	reconcile bool
+}
+
+func (f *fakeChecker) CheckNodesDrift(ctx context.Context, reconcile bool) ([]*Report, error) {
+	f.reconcile = reconcile
+	return f.reports, nil
+}
+
+func TestScannerKeepsLatestReports(t *testing.T) {
+	checker := &fakeChecker{reports: []*Report{
+		{NodeID: 2, Status: StatusDrifted, Findings: []Finding{{Source: SourceFile, Resource: "core.yaml"}}},
+		{NodeID: 1, Status: StatusInSync},
+	}}
+	s := NewScanner(checker, logger.NewDefault(), 0, true)
+
+	s.Scan(context.Background())
+	assert.True(t, checker.reconcile)
+	reports := s.Reports()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, int64(1), reports[0].NodeID)
+	assert.Equal(t, StatusDrifted, reports[1].Status)
+
+	s.Record(&Report{NodeID: 2, Status: StatusInSync})
+	assert.Equal(t, StatusInSync, s.Reports()[1].Status)
+
+	// Nodes that are no longer scanned drop out
+	checker.reports = []*Report{{NodeID: 1, Status: StatusInSync}}
+	s.Scan(context.Background())
+	assert.Len(t, s.Reports(), 1)
+}
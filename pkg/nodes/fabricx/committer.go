@@ -16,6 +16,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/config"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	kmodels "github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -35,6 +36,8 @@ type Committer struct {
 	organizationID int64
 	mspID          string
 	opts           nodetypes.FabricXCommitterConfig
+	// remote runs the role containers on another machine when set
+	remote *hosts.Runner
 }
 
 func NewCommitter(
@@ -617,7 +620,7 @@ func (c *Committer) startPostgres(ctx context.Context, cfg *nodetypes.FabricXCom
 		containerPort: {{HostIP: "0.0.0.0", HostPort: fmt.Sprintf("%d", cfg.PostgresPort)}},
 	}
 
-	return startContainer(ctx, c.logger, imageName, containerName, nil, env, portBindings, nil, networkName, nil)
+	return startRoleContainer(ctx, c.logger, c.remote, imageName, containerName, nil, env, portBindings, nil, networkName, nil)
 }
 
 // Stop stops all committer containers
@@ -635,7 +638,7 @@ func (c *Committer) Stop(cfg *nodetypes.FabricXCommitterDeploymentConfig) error
 	}
 	var errs []string
 	for _, name := range containers {
-		if err := stopContainer(ctx, name); err != nil {
+		if err := stopRoleContainer(ctx, c.remote, name); err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
 		}
 	}
@@ -656,7 +659,7 @@ func (c *Committer) IsHealthy(cfg *nodetypes.FabricXCommitterDeploymentConfig) (
 		cfg.QueryServiceContainer,
 	}
 	for _, name := range containers {
-		running, err := isContainerRunning(ctx, name)
+		running, err := roleContainerRunning(ctx, c.remote, name)
 		if err != nil {
 			return false, err
 		}
@@ -17,6 +17,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/config"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	kmodels "github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -44,6 +45,8 @@ type OrdererGroup struct {
 	organizationID int64
 	mspID          string
 	opts           nodetypes.FabricXOrdererGroupConfig
+	// remote runs the role containers on another machine when set
+	remote *hosts.Runner
 }
 
 func NewOrdererGroup(
@@ -575,7 +578,7 @@ func (og *OrdererGroup) Stop(cfg *nodetypes.FabricXOrdererGroupDeploymentConfig)
 	}
 	var errs []string
 	for _, name := range containers {
-		if err := stopContainer(ctx, name); err != nil {
+		if err := stopRoleContainer(ctx, og.remote, name); err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
 		}
 	}
@@ -595,7 +598,7 @@ func (og *OrdererGroup) IsHealthy(cfg *nodetypes.FabricXOrdererGroupDeploymentCo
 		cfg.AssemblerContainer,
 	}
 	for _, name := range containers {
-		running, err := isContainerRunning(ctx, name)
+		running, err := roleContainerRunning(ctx, og.remote, name)
 		if err != nil {
 			return false, err
 		}
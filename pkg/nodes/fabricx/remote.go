@@ -0,0 +1,136 @@
+package fabricx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+)
+
+// SetRemoteHost makes the orderer group run its role containers on a remote
+// host. The caller owns the runner and closes it.
+func (og *OrdererGroup) SetRemoteHost(runner *hosts.Runner) {
+	og.remote = runner
+}
+
+// SetRemoteHost makes the committer run its role containers on a remote
+// host. All roles of a committer share its bridge network, so they are
+// placed on the same host. The caller owns the runner and closes it.
+func (c *Committer) SetRemoteHost(runner *hosts.Runner) {
+	c.remote = runner
+}
+
+// startRoleContainer starts a role container locally, or on the remote host
+// when one is set. Read-only bind mounts carry the generated configuration
+// and crypto material and are copied to the host; writable mounts hold
+// state the container owns there and are only created.
+func startRoleContainer(
+	ctx context.Context,
+	log *logger.Logger,
+	remote *hosts.Runner,
+	imageName string,
+	containerName string,
+	cmd []string,
+	env map[string]string,
+	portBindings map[nat.Port][]nat.PortBinding,
+	mounts []mount.Mount,
+	networkName string,
+	extraHosts []string,
+) error {
+	if remote == nil {
+		return startContainer(ctx, log, imageName, containerName, cmd, env, portBindings, mounts, networkName, extraHosts)
+	}
+
+	exposedPorts := make(map[nat.Port]struct{})
+	for port := range portBindings {
+		exposedPorts[port] = struct{}{}
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings: portBindings,
+		Mounts:       mounts,
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyAlways,
+		},
+	}
+	if networkName != "" {
+		hostConfig.NetworkMode = container.NetworkMode(networkName)
+	}
+	var upload []string
+	for _, m := range mounts {
+		if m.Type == mount.TypeBind && m.ReadOnly {
+			upload = append(upload, m.Source)
+		}
+	}
+
+	if err := remote.RunContainer(ctx, hosts.ContainerSpec{
+		Name: containerName,
+		Config: &container.Config{
+			Image:        imageName,
+			Cmd:          cmd,
+			Env:          mapToEnvSlice(env),
+			ExposedPorts: exposedPorts,
+		},
+		HostConfig: hostConfig,
+		Upload:     upload,
+	}); err != nil {
+		return err
+	}
+	log.Info("Started container on remote host", "name", containerName, "host", remote.Host().Name)
+	return nil
+}
+
+// stopRoleContainer stops and removes a role container locally or on the remote host
+func stopRoleContainer(ctx context.Context, remote *hosts.Runner, containerName string) error {
+	if remote == nil {
+		return stopContainer(ctx, containerName)
+	}
+	return remote.StopContainer(ctx, containerName)
+}
+
+// roleContainerRunning checks if a role container runs locally or on the remote host
+func roleContainerRunning(ctx context.Context, remote *hosts.Runner, containerName string) (bool, error) {
+	if remote == nil {
+		return isContainerRunning(ctx, containerName)
+	}
+	return remote.ContainerRunning(ctx, containerName)
+}
+
+// waitForRoleContainer waits for a role container to be running
+func waitForRoleContainer(ctx context.Context, remote *hosts.Runner, containerName string, timeout time.Duration) error {
+	if remote == nil {
+		return waitForContainer(ctx, containerName, timeout)
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		running, err := remote.ContainerRunning(ctx, containerName)
+		if err != nil {
+			return err
+		}
+		if running {
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+	return fmt.Errorf("container %s did not start on %s within %s", containerName, remote.Host().Name, timeout)
+}
+
+// ensureRoleNetwork creates a bridge network locally or on the remote host
+func ensureRoleNetwork(ctx context.Context, remote *hosts.Runner, networkName string) error {
+	if remote == nil {
+		return createDockerNetwork(ctx, networkName)
+	}
+	return remote.EnsureNetwork(ctx, networkName)
+}
+
+// tailRoleLogs streams a role container's logs locally or from the remote host
+func tailRoleLogs(ctx context.Context, log *logger.Logger, remote *hosts.Runner, containerName string, tail int, follow bool) (<-chan string, error) {
+	if remote == nil {
+		return TailContainerLogs(ctx, log, containerName, tail, follow)
+	}
+	return remote.TailContainer(ctx, containerName, tail, follow)
+}
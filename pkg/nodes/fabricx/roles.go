@@ -150,10 +150,10 @@ func (og *OrdererGroup) StartOrdererRole(
 		"role", role, "container", comp.containerName,
 		"hostPort", comp.hostPort, "monitoringPort", comp.monitoringPort)
 
-	if err := startContainer(ctx, og.logger, imageName, comp.containerName, comp.cmd, env, portBindings, mounts, "", extraHosts); err != nil {
+	if err := startRoleContainer(ctx, og.logger, og.remote, imageName, comp.containerName, comp.cmd, env, portBindings, mounts, "", extraHosts); err != nil {
 		return fmt.Errorf("failed to start %s: %w", role, err)
 	}
-	return waitForContainer(ctx, comp.containerName, 30*time.Second)
+	return waitForRoleContainer(ctx, og.remote, comp.containerName, 30*time.Second)
 }
 
 // StopOrdererRole stops and removes one container. Safe to call when the
@@ -163,7 +163,7 @@ func (og *OrdererGroup) StopOrdererRole(ctx context.Context, cfg *nodetypes.Fabr
 	if err != nil {
 		return err
 	}
-	return stopContainer(ctx, comp.containerName)
+	return stopRoleContainer(ctx, og.remote, comp.containerName)
 }
 
 // IsOrdererRoleRunning reports docker-level running state for a single role.
@@ -172,7 +172,7 @@ func (og *OrdererGroup) IsOrdererRoleRunning(ctx context.Context, cfg *nodetypes
 	if err != nil {
 		return false, err
 	}
-	return isContainerRunning(ctx, comp.containerName)
+	return roleContainerRunning(ctx, og.remote, comp.containerName)
 }
 
 // PrepareOrdererStart performs the one-time per-group setup that must run
@@ -344,10 +344,10 @@ func (c *Committer) StartCommitterRole(
 		"role", role, "container", comp.containerName,
 		"hostPort", comp.hostPort, "monitoringPort", comp.monitoringPort)
 
-	if err := startContainer(ctx, c.logger, imageName, comp.containerName, comp.cmd, env, portBindings, mounts, c.CommitterNetworkName(), extraHosts); err != nil {
+	if err := startRoleContainer(ctx, c.logger, c.remote, imageName, comp.containerName, comp.cmd, env, portBindings, mounts, c.CommitterNetworkName(), extraHosts); err != nil {
 		return fmt.Errorf("failed to start %s: %w", role, err)
 	}
-	return waitForContainer(ctx, comp.containerName, 30*time.Second)
+	return waitForRoleContainer(ctx, c.remote, comp.containerName, 30*time.Second)
 }
 
 func (c *Committer) StopCommitterRole(ctx context.Context, cfg *nodetypes.FabricXCommitterDeploymentConfig, role nodetypes.FabricXRole) error {
@@ -355,7 +355,7 @@ func (c *Committer) StopCommitterRole(ctx context.Context, cfg *nodetypes.Fabric
 	if err != nil {
 		return err
 	}
-	return stopContainer(ctx, comp.containerName)
+	return stopRoleContainer(ctx, c.remote, comp.containerName)
 }
 
 func (c *Committer) IsCommitterRoleRunning(ctx context.Context, cfg *nodetypes.FabricXCommitterDeploymentConfig, role nodetypes.FabricXRole) (bool, error) {
@@ -363,7 +363,7 @@ func (c *Committer) IsCommitterRoleRunning(ctx context.Context, cfg *nodetypes.F
 	if err != nil {
 		return false, err
 	}
-	return isContainerRunning(ctx, comp.containerName)
+	return roleContainerRunning(ctx, c.remote, comp.containerName)
 }
 
 // PrepareCommitterStart performs the one-time per-group setup before any
@@ -378,7 +378,7 @@ func (c *Committer) PrepareCommitterStart(ctx context.Context, cfg *nodetypes.Fa
 	if err := c.ensureMaterials(cfg); err != nil {
 		return fmt.Errorf("ensure committer materials: %w", err)
 	}
-	if err := createDockerNetwork(ctx, c.CommitterNetworkName()); err != nil {
+	if err := ensureRoleNetwork(ctx, c.remote, c.CommitterNetworkName()); err != nil {
 		return fmt.Errorf("create committer network: %w", err)
 	}
 	if pgHost != "" {
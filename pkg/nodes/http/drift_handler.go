@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListNodeDrift godoc
+// @Summary List node drift
+// @Description Get the latest drift report of every running node. Reports come from the periodic
+// @Description drift scanner; when the scanner is disabled all nodes are checked on request.
+// @Tags Nodes
+// @Produce json
+// @Success 200 {array} drift.Report
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/drift [get]
+func (h *NodeHandler) ListNodeDrift(w http.ResponseWriter, r *http.Request) error {
+	if h.drift != nil {
+		return response.WriteJSON(w, http.StatusOK, h.drift.Reports())
+	}
+	reports, err := h.service.CheckNodesDrift(r.Context(), false)
+	if err != nil {
+		return errors.NewInternalError("failed to check node drift", err, nil)
+	}
+	if reports == nil {
+		reports = []*drift.Report{}
+	}
+	return response.WriteJSON(w, http.StatusOK, reports)
+}
+
+// GetNodeDrift godoc
+// @Summary Check node drift
+// @Description Compare the node's configuration files, systemd unit and container with what its stored configuration renders to
+// @Tags Nodes
+// @Produce json
+// @Param id path int true "Node ID"
+// @Success 200 {object} drift.Report
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/drift [get]
+func (h *NodeHandler) GetNodeDrift(w http.ResponseWriter, r *http.Request) error {
+	return h.checkNodeDrift(w, r, false)
+}
+
+// ReconcileNodeDrift godoc
+// @Summary Reconcile node drift
+// @Description Check the node for drift and, when it drifted, rewrite its configuration from the database and restart it
+// @Tags Nodes
+// @Produce json
+// @Param id path int true "Node ID"
+// @Success 200 {object} drift.Report
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/drift/reconcile [post]
+func (h *NodeHandler) ReconcileNodeDrift(w http.ResponseWriter, r *http.Request) error {
+	return h.checkNodeDrift(w, r, true)
+}
+
+func (h *NodeHandler) checkNodeDrift(w http.ResponseWriter, r *http.Request, reconcile bool) error {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return errors.NewValidationError("invalid node ID", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	report, err := h.service.CheckNodeDrift(r.Context(), id, reconcile)
+	if err != nil {
+		if errors.IsType(err, errors.NotFoundError) {
+			return errors.NewNotFoundError("node not found", nil)
+		}
+		return errors.NewInternalError("failed to check node drift", err, nil)
+	}
+	if h.drift != nil {
+		h.drift.Record(report)
+	}
+	return response.WriteJSON(w, http.StatusOK, report)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
 	"github.com/chainlaunch/chainlaunch/pkg/http/response"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/service"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
 	"github.com/go-chi/chi/v5"
@@ -23,12 +24,14 @@ import (
 type NodeHandler struct {
 	service *service.NodeService
 	logger  *logger.Logger
+	drift   *drift.Scanner
 }
 
-func NewNodeHandler(service *service.NodeService, logger *logger.Logger) *NodeHandler {
+func NewNodeHandler(service *service.NodeService, logger *logger.Logger, driftScanner *drift.Scanner) *NodeHandler {
 	return &NodeHandler{
 		service: service,
 		logger:  logger,
+		drift:   driftScanner,
 	}
 }
 
@@ -58,6 +61,7 @@ func (h *NodeHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/defaults/fabric", response.Middleware(h.GetFabricNodesDefaults))
 		r.Get("/defaults/besu-node", response.Middleware(h.GetBesuNodeDefaults))
 		r.Get("/readiness/besu", response.Middleware(h.CheckBesuReadiness))
+		r.Get("/drift", response.Middleware(h.ListNodeDrift))
 		r.Get("/{id}", response.Middleware(h.GetNode))
 		r.Post("/{id}/start", response.Middleware(h.StartNode))
 		r.Post("/{id}/stop", response.Middleware(h.StopNode))
@@ -67,7 +71,14 @@ func (h *NodeHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/{id}/events", response.Middleware(h.GetNodeEvents))
 		r.Get("/{id}/channels", response.Middleware(h.GetNodeChannels))
 		r.Get("/{id}/channels/{channelID}/chaincodes", response.Middleware(h.GetNodeChaincodes))
+		r.Get("/{id}/channels/{channelID}/snapshots", response.Middleware(h.ListPeerSnapshots))
+		r.Post("/{id}/channels/{channelID}/snapshots", response.Middleware(h.RequestPeerSnapshot))
+		r.Post("/{id}/channels/{channelID}/snapshots/prune", response.Middleware(h.PrunePeerSnapshots))
+		r.Delete("/{id}/channels/{channelID}/snapshots/{blockNumber}", response.Middleware(h.DeletePeerSnapshot))
+		r.Post("/{id}/channels/{channelID}/snapshots/{blockNumber}/cancel", response.Middleware(h.CancelPeerSnapshot))
 		r.Post("/{id}/certificates/renew", response.Middleware(h.RenewCertificates))
+		r.Get("/{id}/drift", response.Middleware(h.GetNodeDrift))
+		r.Post("/{id}/drift/reconcile", response.Middleware(h.ReconcileNodeDrift))
 		r.Put("/{id}", response.Middleware(h.UpdateNode))
 
 		// Besu RPC endpoints
@@ -889,6 +900,7 @@ func (h *NodeHandler) updateBesuNode(w http.ResponseWriter, r *http.Request, nod
 		P2PPort:        req.P2PPort,
 		RPCHost:        req.RPCHost,
 		RPCPort:        req.RPCPort,
+		WSPort:         req.WSPort,
 		Bootnodes:      req.Bootnodes,
 		ExternalIP:     req.ExternalIP,
 		InternalIP:     req.InternalIP,
@@ -896,6 +908,7 @@ func (h *NodeHandler) updateBesuNode(w http.ResponseWriter, r *http.Request, nod
 		Mode:           req.Mode,
 		MetricsEnabled: req.MetricsEnabled,
 		MetricsPort:    req.MetricsPort,
+		Version:        req.Version,
 	}
 
 	// Call service layer to update the Besu node
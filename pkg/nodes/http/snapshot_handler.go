@@ -0,0 +1,205 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// RequestSnapshotRequest represents the request to generate a ledger snapshot
+type RequestSnapshotRequest struct {
+	// BlockNumber to snapshot, 0 snapshots the last committed block
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// PruneSnapshotsRequest represents the request to remove old ledger snapshots
+type PruneSnapshotsRequest struct {
+	// Keep is the number of newest snapshots to keep
+	Keep int `json:"keep"`
+}
+
+// PruneSnapshotsResponse lists the block numbers of the removed snapshots
+type PruneSnapshotsResponse struct {
+	Removed []uint64 `json:"removed"`
+}
+
+// ListPeerSnapshots godoc
+// @Summary List ledger snapshots
+// @Description List the completed and pending ledger snapshots of a channel on a Fabric peer
+// @Tags Nodes
+// @Produce json
+// @Param id path int true "Node ID"
+// @Param channelID path string true "Channel ID"
+// @Success 200 {object} service.PeerSnapshots
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/channels/{channelID}/snapshots [get]
+func (h *NodeHandler) ListPeerSnapshots(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseNodeID(r)
+	if err != nil {
+		return err
+	}
+	snapshots, err := h.service.ListPeerSnapshots(r.Context(), id, chi.URLParam(r, "channelID"))
+	if err != nil {
+		return snapshotError(err, "failed to list snapshots")
+	}
+	return response.WriteJSON(w, http.StatusOK, snapshots)
+}
+
+// RequestPeerSnapshot godoc
+// @Summary Request a ledger snapshot
+// @Description Ask a Fabric peer to generate a ledger snapshot of the channel once the block is committed
+// @Tags Nodes
+// @Accept json
+// @Produce json
+// @Param id path int true "Node ID"
+// @Param channelID path string true "Channel ID"
+// @Param request body RequestSnapshotRequest true "Snapshot block"
+// @Success 202 {object} service.PeerSnapshots
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/channels/{channelID}/snapshots [post]
+func (h *NodeHandler) RequestPeerSnapshot(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseNodeID(r)
+	if err != nil {
+		return err
+	}
+	var req RequestSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	channelID := chi.URLParam(r, "channelID")
+	if err := h.service.RequestPeerSnapshot(r.Context(), id, channelID, req.BlockNumber); err != nil {
+		return snapshotError(err, "failed to request snapshot")
+	}
+	snapshots, err := h.service.ListPeerSnapshots(r.Context(), id, channelID)
+	if err != nil {
+		return snapshotError(err, "failed to list snapshots")
+	}
+	return response.WriteJSON(w, http.StatusAccepted, snapshots)
+}
+
+// CancelPeerSnapshot godoc
+// @Summary Cancel a snapshot request
+// @Description Cancel a pending ledger snapshot request of a Fabric peer
+// @Tags Nodes
+// @Produce json
+// @Param id path int true "Node ID"
+// @Param channelID path string true "Channel ID"
+// @Param blockNumber path int true "Block number"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/channels/{channelID}/snapshots/{blockNumber}/cancel [post]
+func (h *NodeHandler) CancelPeerSnapshot(w http.ResponseWriter, r *http.Request) error {
+	id, blockNumber, err := parseSnapshotParams(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.CancelPeerSnapshot(r.Context(), id, chi.URLParam(r, "channelID"), blockNumber); err != nil {
+		return snapshotError(err, "failed to cancel snapshot request")
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// DeletePeerSnapshot godoc
+// @Summary Delete a ledger snapshot
+// @Description Remove a completed ledger snapshot from a Fabric peer
+// @Tags Nodes
+// @Produce json
+// @Param id path int true "Node ID"
+// @Param channelID path string true "Channel ID"
+// @Param blockNumber path int true "Block number"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node or snapshot not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/channels/{channelID}/snapshots/{blockNumber} [delete]
+func (h *NodeHandler) DeletePeerSnapshot(w http.ResponseWriter, r *http.Request) error {
+	id, blockNumber, err := parseSnapshotParams(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.DeletePeerSnapshot(r.Context(), id, chi.URLParam(r, "channelID"), blockNumber); err != nil {
+		return snapshotError(err, "failed to delete snapshot")
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// PrunePeerSnapshots godoc
+// @Summary Prune ledger snapshots
+// @Description Remove the completed ledger snapshots of a channel on a Fabric peer except the newest ones
+// @Tags Nodes
+// @Accept json
+// @Produce json
+// @Param id path int true "Node ID"
+// @Param channelID path string true "Channel ID"
+// @Param request body PruneSnapshotsRequest true "Snapshots to keep"
+// @Success 200 {object} PruneSnapshotsResponse
+// @Failure 400 {object} response.ErrorResponse "Validation error"
+// @Failure 404 {object} response.ErrorResponse "Node not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /nodes/{id}/channels/{channelID}/snapshots/prune [post]
+func (h *NodeHandler) PrunePeerSnapshots(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseNodeID(r)
+	if err != nil {
+		return err
+	}
+	var req PruneSnapshotsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	removed, err := h.service.PrunePeerSnapshots(r.Context(), id, chi.URLParam(r, "channelID"), req.Keep)
+	if err != nil {
+		return snapshotError(err, "failed to prune snapshots")
+	}
+	return response.WriteJSON(w, http.StatusOK, PruneSnapshotsResponse{Removed: removed})
+}
+
+func parseNodeID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, errors.NewValidationError("invalid node ID", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return id, nil
+}
+
+func parseSnapshotParams(r *http.Request) (int64, uint64, error) {
+	id, err := parseNodeID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	blockNumber, err := strconv.ParseUint(chi.URLParam(r, "blockNumber"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.NewValidationError("invalid block number", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return id, blockNumber, nil
+}
+
+// snapshotError keeps the not found and validation errors of the service and
+// reports anything else as an internal error
+func snapshotError(err error, msg string) error {
+	if errors.IsType(err, errors.NotFoundError) || errors.IsType(err, errors.ValidationError) {
+		return err
+	}
+	return errors.NewInternalError(msg, err, nil)
+}
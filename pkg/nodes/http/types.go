@@ -245,6 +245,7 @@ type UpdateBesuNodeRequest struct {
 	P2PPort        uint              `json:"p2pPort" validate:"required"`
 	RPCHost        string            `json:"rpcHost" validate:"required"`
 	RPCPort        uint              `json:"rpcPort" validate:"required"`
+	WSPort         *uint             `json:"wsPort,omitempty"` // keeps the current port when omitted, 0 disables WebSocket RPC
 	Bootnodes      []string          `json:"bootnodes,omitempty"`
 	ExternalIP     string            `json:"externalIp,omitempty"`
 	InternalIP     string            `json:"internalIp,omitempty"`
@@ -252,6 +253,7 @@ type UpdateBesuNodeRequest struct {
 	MetricsEnabled bool              `json:"metricsEnabled"`
 	MetricsPort    int64             `json:"metricsPort"`
 	Mode           string            `json:"mode,omitempty"`
+	Version        string            `json:"version,omitempty"`
 }
 
 type BesuNodeDefaultsResponse struct {
@@ -0,0 +1,83 @@
+package orderer
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
+)
+
+// ExpectedDeployment renders the files, systemd unit and container the orderer
+// should run with according to its stored configuration. Launchd plists are not
+// compared, so on macOS only the configuration files are returned.
+func (o *LocalOrderer) ExpectedDeployment() (*drift.Expected, error) {
+	slugifiedID := strings.ReplaceAll(strings.ToLower(o.opts.ID), " ", "-")
+	dirPath := filepath.Join(o.configService.GetDataPath(), "orderers", slugifiedID)
+	mspConfigPath := filepath.Join(dirPath, "config")
+	dataConfigPath := filepath.Join(dirPath, "data")
+
+	ordererYaml, err := o.renderOrdererYaml(dataConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	exp := &drift.Expected{
+		Files: []drift.File{
+			{Name: "config.yaml", Path: filepath.Join(mspConfigPath, "config.yaml"), Content: []byte(configYamlContent)},
+			{Name: "orderer.yaml", Path: filepath.Join(mspConfigPath, "orderer.yaml"), Content: ordererYaml},
+		},
+	}
+
+	switch o.mode {
+	case "service":
+		if runtime.GOOS != "linux" {
+			return exp, nil
+		}
+		ordererBinary, err := o.findOrdererBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find orderer binary: %w", err)
+		}
+		exp.Unit = &drift.Unit{
+			Path:      o.getServiceFilePath(),
+			ExecStart: ordererBinary,
+			Env:       o.buildOrdererEnvironment(mspConfigPath),
+		}
+	case "docker":
+		env := o.buildDockerOrdererEnvironment(mspConfigPath)
+		imageName := fmt.Sprintf("hyperledger/fabric-orderer:%s", o.opts.Version)
+		containerConfig, hostConfig := o.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
+		exp.Container = &drift.Container{
+			Name:       o.getContainerName(),
+			Image:      containerConfig.Image,
+			Cmd:        containerConfig.Cmd,
+			Env:        env,
+			HostConfig: hostConfig,
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", o.mode)
+	}
+	return exp, nil
+}
+
+// SynchronizeConfig rewrites the orderer's configuration files and restarts it
+// so the service or container is recreated from the stored configuration
+func (o *LocalOrderer) SynchronizeConfig() error {
+	slugifiedID := strings.ReplaceAll(strings.ToLower(o.opts.ID), " ", "-")
+	dirPath := filepath.Join(o.configService.GetDataPath(), "orderers", slugifiedID)
+	mspConfigPath := filepath.Join(dirPath, "config")
+	dataConfigPath := filepath.Join(dirPath, "data")
+
+	if err := o.writeConfigFiles(mspConfigPath, dataConfigPath); err != nil {
+		return fmt.Errorf("failed to write config files: %w", err)
+	}
+
+	// Stop the orderer if it's running
+	if err := o.Stop(); err != nil {
+		return fmt.Errorf("failed to stop orderer before regenerating config: %w", err)
+	}
+	if _, err := o.Start(); err != nil {
+		return fmt.Errorf("failed to restart orderer after regenerating config: %w", err)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/config"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	kmodels "github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -49,6 +50,8 @@ type LocalOrderer struct {
 	logger          *logger.Logger
 	configService   *config.ConfigService
 	settingsService *settingsservice.SettingsService
+	// remote runs the orderer on another machine when set
+	remote *hosts.Runner
 }
 
 // NewLocalOrderer creates a new LocalOrderer instance
@@ -148,6 +151,10 @@ func (o *LocalOrderer) Start() (interface{}, error) {
 		"dirPath", dirPath,
 	)
 
+	if o.remote != nil {
+		return o.startRemote(ordererBinary, dirPath, mspConfigPath, dataConfigPath)
+	}
+
 	switch o.mode {
 	case "service":
 		env := o.buildOrdererEnvironment(mspConfigPath)
@@ -162,6 +169,9 @@ func (o *LocalOrderer) Start() (interface{}, error) {
 
 // Stop stops the orderer node
 func (o *LocalOrderer) Stop() error {
+	if o.remote != nil {
+		return o.stopRemote()
+	}
 	o.logger.Info("Stopping orderer", "opts", o.opts)
 
 	switch o.mode {
@@ -274,6 +284,9 @@ func (o *LocalOrderer) getLogPath() string {
 
 // TailLogs tails the logs of the orderer service
 func (o *LocalOrderer) TailLogs(ctx context.Context, tail int, follow bool) (<-chan string, error) {
+	if o.remote != nil {
+		return o.tailRemoteLogs(ctx, tail, follow)
+	}
 	logChan := make(chan string, 100)
 
 	if o.mode == "docker" {
@@ -626,10 +639,7 @@ func (o *LocalOrderer) writeCertificatesAndKeys(
 	return nil
 }
 
-// writeConfigFiles writes the config.yaml and orderer.yaml files
-func (o *LocalOrderer) writeConfigFiles(mspConfigPath, dataConfigPath string) error {
-	// Write config.yaml
-	configYamlContent := `NodeOUs:
+const configYamlContent = `NodeOUs:
   Enable: true
   ClientOUIdentifier:
     Certificate: cacerts/cacert.pem
@@ -644,11 +654,28 @@ func (o *LocalOrderer) writeConfigFiles(mspConfigPath, dataConfigPath string) er
     Certificate: cacerts/cacert.pem
     OrganizationalUnitIdentifier: orderer
 `
+
+// writeConfigFiles writes the config.yaml and orderer.yaml files
+func (o *LocalOrderer) writeConfigFiles(mspConfigPath, dataConfigPath string) error {
+	// Write config.yaml
 	if err := os.WriteFile(filepath.Join(mspConfigPath, "config.yaml"), []byte(configYamlContent), 0644); err != nil {
 		return fmt.Errorf("failed to write config.yaml: %w", err)
 	}
 
 	// Write orderer.yaml
+	ordererYaml, err := o.renderOrdererYaml(dataConfigPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(mspConfigPath, "orderer.yaml"), ordererYaml, 0644); err != nil {
+		return fmt.Errorf("failed to write orderer.yaml: %w", err)
+	}
+
+	return nil
+}
+
+// renderOrdererYaml renders orderer.yaml with the given ledger data path
+func (o *LocalOrderer) renderOrdererYaml(dataConfigPath string) ([]byte, error) {
 	ordererYamlTemplate := `
 # Copyright IBM Corp. All Rights Reserved.
 #
@@ -994,14 +1021,10 @@ Consensus:
 	var buf bytes.Buffer
 	tmpl := template.Must(template.New("orderer.yaml").Parse(ordererYamlTemplate))
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute orderer.yaml template: %w", err)
+		return nil, fmt.Errorf("failed to execute orderer.yaml template: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Join(mspConfigPath, "orderer.yaml"), buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed to write orderer.yaml: %w", err)
-	}
-
-	return nil
+	return buf.Bytes(), nil
 }
 
 // JoinChannel joins the orderer to a channel using the channel participation API
@@ -0,0 +1,82 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+)
+
+// SetRemoteHost makes the orderer start, stop and tail logs on a remote host.
+// The caller owns the runner and closes it.
+func (o *LocalOrderer) SetRemoteHost(runner *hosts.Runner) {
+	o.remote = runner
+}
+
+// startRemote copies the orderer configuration to the remote host and starts the orderer there
+func (o *LocalOrderer) startRemote(ordererBinary, dirPath, mspConfigPath, dataConfigPath string) (interface{}, error) {
+	ctx := context.Background()
+	switch o.mode {
+	case "service":
+		if err := o.remote.StartService(ctx, hosts.ServiceSpec{
+			Name:        o.getServiceName(),
+			Description: fmt.Sprintf("Hyperledger Fabric Orderer - %s", o.opts.ID),
+			Binary:      ordererBinary,
+			Env:         o.buildOrdererEnvironment(mspConfigPath),
+			WorkDir:     dirPath,
+			LogFile:     o.GetStdOutPath(),
+			Upload:      []string{mspConfigPath},
+			// The service mode ledger lives inside the config directory
+			Exclude: []string{filepath.Join(mspConfigPath, "data")},
+		}); err != nil {
+			return nil, err
+		}
+		return &StartServiceResponse{
+			Mode:        "service",
+			Type:        "systemd",
+			ServiceName: o.getServiceName(),
+		}, nil
+	case "docker":
+		imageName := fmt.Sprintf("hyperledger/fabric-orderer:%s", o.opts.Version)
+		env := o.buildDockerOrdererEnvironment(mspConfigPath)
+		containerConfig, hostConfig := o.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
+		if err := o.remote.RunContainer(ctx, hosts.ContainerSpec{
+			Name:       o.getContainerName(),
+			Config:     containerConfig,
+			HostConfig: hostConfig,
+			Upload:     []string{mspConfigPath},
+			Exclude:    []string{filepath.Join(mspConfigPath, "data")},
+		}); err != nil {
+			return nil, err
+		}
+		return &StartDockerResponse{
+			Mode:          "docker",
+			ContainerName: o.getContainerName(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", o.mode)
+	}
+}
+
+// stopRemote stops the orderer on the remote host
+func (o *LocalOrderer) stopRemote() error {
+	o.logger.Info("Stopping orderer on remote host", "opts", o.opts, "host", o.remote.Host().Name)
+	ctx := context.Background()
+	switch o.mode {
+	case "service":
+		return o.remote.StopService(ctx, o.getServiceName())
+	case "docker":
+		return o.remote.StopContainer(ctx, o.getContainerName())
+	default:
+		return fmt.Errorf("invalid mode: %s", o.mode)
+	}
+}
+
+// tailRemoteLogs tails the orderer logs on the remote host
+func (o *LocalOrderer) tailRemoteLogs(ctx context.Context, tail int, follow bool) (<-chan string, error) {
+	if o.mode == "docker" {
+		return o.remote.TailContainer(ctx, o.getContainerName(), tail, follow)
+	}
+	return o.remote.TailFile(ctx, o.GetStdOutPath(), tail, follow)
+}
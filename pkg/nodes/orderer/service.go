@@ -304,7 +304,33 @@ func (o *LocalOrderer) startDocker(env map[string]string, mspConfigPath, dataCon
 	}
 
 	containerName := o.getContainerName()
+	containerConfig, hostConfig := o.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
 
+	// Create container
+	resp, err := cli.ContainerCreate(context.Background(),
+		containerConfig,
+		hostConfig,
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container: %w", err)
+	}
+
+	// Start container
+	if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return &StartDockerResponse{
+		Mode:          "docker",
+		ContainerName: containerName,
+	}, nil
+}
+
+// dockerContainerConfig returns the container and host configuration of the orderer container
+func (o *LocalOrderer) dockerContainerConfig(imageName string, env map[string]string, mspConfigPath, dataConfigPath string) (*container.Config, *container.HostConfig) {
 	// Helper to extract port from address (host:port or just :port)
 	extractPort := func(addr string) string {
 		parts := strings.Split(addr, ":")
@@ -347,30 +373,11 @@ func (o *LocalOrderer) startDocker(env map[string]string, mspConfigPath, dataCon
 	for port := range portBindings {
 		containerConfig.ExposedPorts[port] = struct{}{}
 	}
-	// Create container
-	resp, err := cli.ContainerCreate(context.Background(),
-		containerConfig,
-		&container.HostConfig{
-			PortBindings: portBindings,
-			Mounts:       mounts,
-		},
-		nil,
-		nil,
-		containerName,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container: %w", err)
-	}
 
-	// Start container
-	if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
-		return nil, fmt.Errorf("failed to start container: %w", err)
+	return containerConfig, &container.HostConfig{
+		PortBindings: portBindings,
+		Mounts:       mounts,
 	}
-
-	return &StartDockerResponse{
-		Mode:          "docker",
-		ContainerName: containerName,
-	}, nil
 }
 
 func mapToEnvSlice(m map[string]string) []string {
@@ -0,0 +1,78 @@
+package peer
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// ExpectedDeployment renders the files, systemd unit and container the peer
+// should run with according to its stored configuration. Launchd plists are not
+// compared, so on macOS only the configuration files are returned.
+func (p *LocalPeer) ExpectedDeployment(deployConfig *nodetypes.FabricPeerDeploymentConfig) (*drift.Expected, error) {
+	slugifiedID := strings.ReplaceAll(strings.ToLower(p.opts.ID), " ", "-")
+	dirPath := filepath.Join(p.configService.GetDataPath(), "peers", slugifiedID)
+	mspConfigPath := filepath.Join(dirPath, "config")
+	dataConfigPath := filepath.Join(dirPath, "data")
+
+	overrides := addressOverridePaths(mspConfigPath, deployConfig.AddressOverrides)
+	coreYaml, err := p.renderCoreYaml(mspConfigPath, dataConfigPath, overrides)
+	if err != nil {
+		return nil, err
+	}
+	exp := &drift.Expected{
+		Files: []drift.File{
+			{Name: "config.yaml", Path: filepath.Join(mspConfigPath, "config.yaml"), Content: []byte(configYamlContent)},
+			{Name: "core.yaml", Path: filepath.Join(mspConfigPath, "core.yaml"), Content: coreYaml},
+		},
+	}
+	for i, override := range deployConfig.AddressOverrides {
+		exp.Files = append(exp.Files, drift.File{
+			Name:    filepath.Join("orderer-overrides", filepath.Base(overrides[i].TLSCAPath)),
+			Path:    overrides[i].TLSCAPath,
+			Content: []byte(override.TLSCACert),
+		})
+	}
+
+	env := p.buildPeerEnvironment(mspConfigPath)
+	switch p.mode {
+	case "service":
+		if runtime.GOOS != "linux" {
+			return exp, nil
+		}
+		peerBinary, err := p.findPeerBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find peer binary: %w", err)
+		}
+		cmd, err := p.buildCommand(peerBinary)
+		if err != nil {
+			return nil, err
+		}
+		exp.Unit = &drift.Unit{
+			Path:      p.getServiceFilePath(),
+			ExecStart: cmd,
+			Env:       env,
+		}
+	case "docker":
+		containerName, err := p.getContainerName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container name: %w", err)
+		}
+		imageName := fmt.Sprintf("hyperledger/fabric-peer:%s", p.opts.Version)
+		containerConfig, hostConfig := p.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
+		exp.Container = &drift.Container{
+			Name:       containerName,
+			Image:      containerConfig.Image,
+			Cmd:        containerConfig.Cmd,
+			Env:        env,
+			HostConfig: hostConfig,
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", p.mode)
+	}
+	return exp, nil
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	"github.com/chainlaunch/chainlaunch/pkg/docker"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	kmodels "github.com/chainlaunch/chainlaunch/pkg/keymanagement/models"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -808,6 +809,8 @@ type LocalPeer struct {
 	logger          *logger.Logger
 	configService   *config.ConfigService
 	settingsService *settingsservice.SettingsService
+	// remote runs the peer on another machine when set
+	remote *hosts.Runner
 }
 
 // NewLocalPeer creates a new LocalPeer instance
@@ -1098,9 +1101,38 @@ func (p *LocalPeer) Start() (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find peer binary: %w", err)
 	}
+	cmd, err := p.buildCommand(peerBinary)
+	if err != nil {
+		return nil, err
+	}
+	env := p.buildPeerEnvironment(mspConfigPath)
+
+	p.logger.Debug("Starting peer",
+		"mode", p.mode,
+		"cmd", cmd,
+		"env", env,
+		"dirPath", dirPath,
+	)
+
+	if p.remote != nil {
+		return p.startRemote(peerBinary, env, dirPath, mspConfigPath, dataConfigPath)
+	}
+
+	switch p.mode {
+	case "service":
+		return p.startService(cmd, env, dirPath)
+	case "docker":
+		return p.startDocker(env, mspConfigPath, dataConfigPath)
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", p.mode)
+	}
+}
+
+// buildCommand renders the peer command template from the settings
+func (p *LocalPeer) buildCommand(peerBinary string) (string, error) {
 	setting, err := p.settingsService.GetSetting(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get setting: %w", err)
+		return "", fmt.Errorf("failed to get setting: %w", err)
 	}
 	var peerTemplateCMD string
 	if setting.Config.PeerTemplateCMD == "" {
@@ -1112,33 +1144,16 @@ func (p *LocalPeer) Start() (interface{}, error) {
 	// Parse template and build command
 	tmpl, err := template.New("peer").Parse(peerTemplateCMD)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse peer template: %w", err)
+		return "", fmt.Errorf("failed to parse peer template: %w", err)
 	}
 
 	var cmdBuf bytes.Buffer
 	if err := tmpl.Execute(&cmdBuf, struct{ Cmd string }{
 		Cmd: fmt.Sprintf("%s node start", peerBinary),
 	}); err != nil {
-		return nil, fmt.Errorf("failed to execute peer template: %w", err)
-	}
-	cmd := cmdBuf.String()
-	env := p.buildPeerEnvironment(mspConfigPath)
-
-	p.logger.Debug("Starting peer",
-		"mode", p.mode,
-		"cmd", cmd,
-		"env", env,
-		"dirPath", dirPath,
-	)
-
-	switch p.mode {
-	case "service":
-		return p.startService(cmd, env, dirPath)
-	case "docker":
-		return p.startDocker(env, mspConfigPath, dataConfigPath)
-	default:
-		return nil, fmt.Errorf("invalid mode: %s", p.mode)
+		return "", fmt.Errorf("failed to execute peer template: %w", err)
 	}
+	return cmdBuf.String(), nil
 }
 
 // buildPeerEnvironment builds the environment variables for the peer
@@ -1229,7 +1244,33 @@ func (p *LocalPeer) startDocker(env map[string]string, mspConfigPath, dataConfig
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container name: %w", err)
 	}
+	containerConfig, hostConfig := p.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
 
+	// Create container
+	resp, err := cli.ContainerCreate(context.Background(),
+		containerConfig,
+		hostConfig,
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container: %w", err)
+	}
+
+	// Start container
+	if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return &StartDockerResponse{
+		Mode:          "docker",
+		ContainerName: containerName,
+	}, nil
+}
+
+// dockerContainerConfig returns the container and host configuration of the peer container
+func (p *LocalPeer) dockerContainerConfig(imageName string, env map[string]string, mspConfigPath, dataConfigPath string) (*container.Config, *container.HostConfig) {
 	// Helper to extract port from address (host:port or just :port)
 	extractPort := func(addr string) string {
 		parts := strings.Split(addr, ":")
@@ -1275,30 +1316,10 @@ func (p *LocalPeer) startDocker(env map[string]string, mspConfigPath, dataConfig
 		containerConfig.ExposedPorts[port] = struct{}{}
 	}
 
-	// Create container
-	resp, err := cli.ContainerCreate(context.Background(),
-		containerConfig,
-		&container.HostConfig{
-			PortBindings: portBindings,
-			Mounts:       mounts,
-		},
-		nil,
-		nil,
-		containerName,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container: %w", err)
+	return containerConfig, &container.HostConfig{
+		PortBindings: portBindings,
+		Mounts:       mounts,
 	}
-
-	// Start container
-	if err := cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{}); err != nil {
-		return nil, fmt.Errorf("failed to start container: %w", err)
-	}
-
-	return &StartDockerResponse{
-		Mode:          "docker",
-		ContainerName: containerName,
-	}, nil
 }
 
 // Helper function to convert map to env slice
@@ -1312,6 +1333,9 @@ func mapToEnvSlice(m map[string]string) []string {
 
 // Stop stops the peer node
 func (p *LocalPeer) Stop() error {
+	if p.remote != nil {
+		return p.stopRemote()
+	}
 	if p.mode == "service" {
 		var cmd *exec.Cmd
 		if runtime.GOOS == "darwin" {
@@ -1846,6 +1870,21 @@ func (p *LocalPeer) writeConfigFiles(mspConfigPath, dataConfigPath string) error
 	if err != nil {
 		return fmt.Errorf("failed to convert address overrides: %w", err)
 	}
+	coreYaml, err := p.renderCoreYaml(mspConfigPath, dataConfigPath, convertedOverrides)
+	if err != nil {
+		return err
+	}
+
+	// Write core.yaml
+	if err := os.WriteFile(filepath.Join(mspConfigPath, "core.yaml"), coreYaml, 0644); err != nil {
+		return fmt.Errorf("failed to write core.yaml: %w", err)
+	}
+
+	return nil
+}
+
+// renderCoreYaml renders core.yaml for the peer's deployment mode
+func (p *LocalPeer) renderCoreYaml(mspConfigPath, dataConfigPath string, convertedOverrides []AddressOverridePath) ([]byte, error) {
 	var data CoreTemplateData
 	if p.mode == "docker" {
 		data = CoreTemplateData{
@@ -1876,25 +1915,23 @@ func (p *LocalPeer) writeConfigFiles(mspConfigPath, dataConfigPath string) error
 	// Create template
 	tmpl, err := template.New("core.yaml").Parse(coreYamlTemplate)
 	if err != nil {
-		return fmt.Errorf("failed to parse core.yaml template: %w", err)
+		return nil, fmt.Errorf("failed to parse core.yaml template: %w", err)
 	}
 
 	// Execute template
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute core.yaml template: %w", err)
-	}
-
-	// Write core.yaml
-	if err := os.WriteFile(filepath.Join(mspConfigPath, "core.yaml"), buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed to write core.yaml: %w", err)
+		return nil, fmt.Errorf("failed to execute core.yaml template: %w", err)
 	}
 
-	return nil
+	return buf.Bytes(), nil
 }
 
 // TailLogs tails the logs of the peer service
 func (p *LocalPeer) TailLogs(ctx context.Context, tail int, follow bool) (<-chan string, error) {
+	if p.remote != nil {
+		return p.tailRemoteLogs(ctx, tail, follow)
+	}
 	logChan := make(chan string, 100)
 
 	if p.mode == "docker" {
@@ -2916,6 +2953,106 @@ func (p *LocalPeer) GetBlocksInRange(ctx context.Context, channelID string, star
 	return blocks, nil
 }
 
+// connectGateway opens a gateway connection to the peer as the organization admin.
+// The returned function closes both the gateway and the underlying gRPC connection.
+func (p *LocalPeer) connectGateway(ctx context.Context) (*client.Gateway, func(), error) {
+	tlsCACert, err := p.GetTLSRootCACert(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get TLS CA cert: %w", err)
+	}
+	peerConn, err := p.CreatePeerConnection(ctx, p.GetPeerAddress(), tlsCACert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create peer connection: %w", err)
+	}
+	adminIdentity, signer, err := p.GetAdminIdentity(ctx)
+	if err != nil {
+		peerConn.Close()
+		return nil, nil, fmt.Errorf("failed to get admin identity: %w", err)
+	}
+	gateway, err := client.Connect(adminIdentity, client.WithClientConnection(peerConn), client.WithSign(signer))
+	if err != nil {
+		peerConn.Close()
+		return nil, nil, fmt.Errorf("failed to connect to gateway: %w", err)
+	}
+	return gateway, func() {
+		gateway.Close()
+		peerConn.Close()
+	}, nil
+}
+
+// StreamBlocks delivers the channel blocks committed by the peer until ctx is cancelled.
+// Delivery starts at startBlock, or at the next committed block when startBlock is nil.
+// The returned channel is closed when the stream ends.
+func (p *LocalPeer) StreamBlocks(ctx context.Context, channelID string, startBlock *uint64) (<-chan *cb.Block, error) {
+	gateway, closeGateway, err := p.connectGateway(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var opts []client.BlockEventsOption
+	if startBlock != nil {
+		opts = append(opts, client.WithStartBlock(*startBlock))
+	}
+	events, err := gateway.GetNetwork(channelID).BlockEvents(ctx, opts...)
+	if err != nil {
+		closeGateway()
+		return nil, fmt.Errorf("failed to get block events: %w", err)
+	}
+
+	blocks := make(chan *cb.Block)
+	go func() {
+		defer closeGateway()
+		defer close(blocks)
+		for blk := range events {
+			select {
+			case blocks <- blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+// StreamChaincodeEvents delivers the events emitted by a chaincode on the channel until ctx is cancelled.
+// Delivery starts at startBlock, or at the next committed block when startBlock is nil. When afterTxID
+// is set, events in startBlock up to and including that transaction are skipped, which allows a
+// client to resume exactly after the last event it processed.
+func (p *LocalPeer) StreamChaincodeEvents(ctx context.Context, channelID, chaincodeName string, startBlock *uint64, afterTxID string) (<-chan *client.ChaincodeEvent, error) {
+	gateway, closeGateway, err := p.connectGateway(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var opts []client.ChaincodeEventsOption
+	if startBlock != nil {
+		if afterTxID != "" {
+			checkpoint := &client.InMemoryCheckpointer{}
+			checkpoint.CheckpointTransaction(*startBlock, afterTxID)
+			opts = append(opts, client.WithCheckpoint(checkpoint))
+		} else {
+			opts = append(opts, client.WithStartBlock(*startBlock))
+		}
+	}
+	events, err := gateway.GetNetwork(channelID).ChaincodeEvents(ctx, chaincodeName, opts...)
+	if err != nil {
+		closeGateway()
+		return nil, fmt.Errorf("failed to get chaincode events: %w", err)
+	}
+
+	chaincodeEvents := make(chan *client.ChaincodeEvent)
+	go func() {
+		defer closeGateway()
+		defer close(chaincodeEvents)
+		for event := range events {
+			select {
+			case chaincodeEvents <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return chaincodeEvents, nil
+}
+
 // GetChannelBlockInfo retrieves information about the blockchain for a specific channel
 func (p *LocalPeer) GetChannelBlockInfo(ctx context.Context, channelID string) (*BlockInfo, error) {
 	peerUrl := p.GetPeerAddress()
@@ -3109,49 +3246,13 @@ func (p *LocalPeer) SynchronizeConfig(deployConfig *nodetypes.FabricPeerDeployme
 	if err != nil {
 		return fmt.Errorf("failed to convert address overrides: %w", err)
 	}
-
-	var data CoreTemplateData
-	if p.mode == "docker" {
-		data = CoreTemplateData{
-			PeerID:                  p.opts.ID,
-			ListenAddress:           p.opts.ListenAddress,
-			ChaincodeAddress:        p.opts.ChaincodeAddress,
-			ExternalEndpoint:        p.opts.ExternalEndpoint,
-			DataPath:                "/var/hyperledger/production",
-			ExternalBuilderPath:     "/opt/hyperledger/ccaas_builder",
-			OperationsListenAddress: p.opts.OperationsListenAddress,
-			AddressOverrides:        convertedOverrides,
-			MSPID:                   p.mspID,
-		}
-	} else {
-		// Define template data
-		data = CoreTemplateData{
-			PeerID:                  p.opts.ID,
-			ListenAddress:           p.opts.ListenAddress,
-			ChaincodeAddress:        p.opts.ChaincodeAddress,
-			ExternalEndpoint:        p.opts.ExternalEndpoint,
-			DataPath:                dataConfigPath,
-			MSPID:                   p.mspID,
-			ExternalBuilderPath:     filepath.Join(mspConfigPath, "ccaas"),
-			OperationsListenAddress: p.opts.OperationsListenAddress,
-			AddressOverrides:        convertedOverrides,
-		}
-	}
-
-	// Create template
-	tmpl, err := template.New("core.yaml").Parse(coreYamlTemplate)
+	coreYaml, err := p.renderCoreYaml(mspConfigPath, dataConfigPath, convertedOverrides)
 	if err != nil {
-		return fmt.Errorf("failed to parse core.yaml template: %w", err)
-	}
-
-	// Execute template
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute core.yaml template: %w", err)
+		return err
 	}
 
 	// Write core.yaml
-	if err := os.WriteFile(filepath.Join(mspConfigPath, "core.yaml"), buf.Bytes(), 0644); err != nil {
+	if err := os.WriteFile(filepath.Join(mspConfigPath, "core.yaml"), coreYaml, 0644); err != nil {
 		return fmt.Errorf("failed to write core.yaml: %w", err)
 	}
 
@@ -3175,23 +3276,28 @@ func (p *LocalPeer) convertAddressOverrides(mspConfigPath string, overrides []no
 		return nil, fmt.Errorf("failed to create orderer overrides directory: %w", err)
 	}
 
-	var convertedOverrides []AddressOverridePath
+	convertedOverrides := addressOverridePaths(mspConfigPath, overrides)
 	for i, override := range overrides {
 		// Write TLS CA certificate to file
-		certPath := filepath.Join(tmpDir, fmt.Sprintf("tlsca-%d.pem", i))
-		if err := os.WriteFile(certPath, []byte(override.TLSCACert), 0644); err != nil {
+		if err := os.WriteFile(convertedOverrides[i].TLSCAPath, []byte(override.TLSCACert), 0644); err != nil {
 			return nil, fmt.Errorf("failed to write orderer TLS CA certificate: %w", err)
 		}
+	}
+
+	return convertedOverrides, nil
+}
 
-		// Add converted override
+// addressOverridePaths maps address overrides to the TLS CA files convertAddressOverrides writes
+func addressOverridePaths(mspConfigPath string, overrides []nodetypes.AddressOverride) []AddressOverridePath {
+	var convertedOverrides []AddressOverridePath
+	for i, override := range overrides {
 		convertedOverrides = append(convertedOverrides, AddressOverridePath{
 			From:      override.From,
 			To:        override.To,
-			TLSCAPath: certPath,
+			TLSCAPath: filepath.Join(mspConfigPath, "orderer-overrides", fmt.Sprintf("tlsca-%d.pem", i)),
 		})
 	}
-
-	return convertedOverrides, nil
+	return convertedOverrides
 }
 
 func (p *LocalPeer) GetCommittedChaincodes(ctx context.Context, channelID string) ([]*lifecycle.QueryChaincodeDefinitionsResult_ChaincodeDefinition, error) {
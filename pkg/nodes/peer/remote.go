@@ -0,0 +1,96 @@
+package peer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+)
+
+// SetRemoteHost makes the peer start, stop and tail logs on a remote host.
+// The caller owns the runner and closes it.
+func (p *LocalPeer) SetRemoteHost(runner *hosts.Runner) {
+	p.remote = runner
+}
+
+// IsRemote reports whether the peer runs on a remote host
+func (p *LocalPeer) IsRemote() bool {
+	return p.remote != nil
+}
+
+// startRemote copies the peer configuration to the remote host and starts the peer there
+func (p *LocalPeer) startRemote(peerBinary string, env map[string]string, dirPath, mspConfigPath, dataConfigPath string) (interface{}, error) {
+	ctx := context.Background()
+	switch p.mode {
+	case "service":
+		// The peer command template is a local concern; remote units run the binary directly
+		if err := p.remote.StartService(ctx, hosts.ServiceSpec{
+			Name:        p.getServiceName(),
+			Description: fmt.Sprintf("Hyperledger Fabric Peer - %s", p.opts.ID),
+			Binary:      peerBinary,
+			Args:        []string{"node", "start"},
+			Env:         env,
+			WorkDir:     dirPath,
+			LogFile:     p.GetStdOutPath(),
+			Upload:      []string{mspConfigPath},
+		}); err != nil {
+			return nil, err
+		}
+		return &StartServiceResponse{
+			Mode:        "service",
+			Type:        "systemd",
+			ServiceName: p.getServiceName(),
+		}, nil
+	case "docker":
+		containerName, err := p.getContainerName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container name: %w", err)
+		}
+		imageName := fmt.Sprintf("hyperledger/fabric-peer:%s", p.opts.Version)
+		containerConfig, hostConfig := p.dockerContainerConfig(imageName, env, mspConfigPath, dataConfigPath)
+		if err := p.remote.RunContainer(ctx, hosts.ContainerSpec{
+			Name:       containerName,
+			Config:     containerConfig,
+			HostConfig: hostConfig,
+			Upload:     []string{mspConfigPath},
+		}); err != nil {
+			return nil, err
+		}
+		return &StartDockerResponse{
+			Mode:          "docker",
+			ContainerName: containerName,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid mode: %s", p.mode)
+	}
+}
+
+// stopRemote stops the peer on the remote host
+func (p *LocalPeer) stopRemote() error {
+	p.logger.Info("Stopping peer on remote host", "opts", p.opts, "host", p.remote.Host().Name)
+	ctx := context.Background()
+	switch p.mode {
+	case "service":
+		return p.remote.StopService(ctx, p.getServiceName())
+	case "docker":
+		containerName, err := p.getContainerName()
+		if err != nil {
+			return fmt.Errorf("failed to get container name: %w", err)
+		}
+		return p.remote.StopContainer(ctx, containerName)
+	default:
+		return fmt.Errorf("invalid mode: %s", p.mode)
+	}
+}
+
+// tailRemoteLogs tails the peer logs on the remote host
+func (p *LocalPeer) tailRemoteLogs(ctx context.Context, tail int, follow bool) (<-chan string, error) {
+	if p.mode == "docker" {
+		containerName, err := p.getContainerName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container name: %w", err)
+		}
+		return p.remote.TailContainer(ctx, containerName, tail, follow)
+	}
+	return p.remote.TailFile(ctx, p.GetStdOutPath(), tail, follow)
+}
@@ -0,0 +1,450 @@
+package peer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go-apiv2/common"
+	pb "github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chainlaunch/chainlaunch/internal/protoutil"
+)
+
+const (
+	cscc                       = "cscc"
+	csccJoinChainBySnapshot    = "JoinChainBySnapshot"
+	csccJoinBySnapshotStatus   = "JoinBySnapshotStatus"
+	snapshotSignableMetadata   = "_snapshot_signable_metadata.json"
+	snapshotAdditionalMetadata = "_snapshot_additional_metadata.json"
+	containerDataPath          = "/var/hyperledger/production"
+	snapshotImportsDir         = "imports"
+	snapshotCompletedDir       = "completed"
+	joinBySnapshotPollInterval = 5 * time.Second
+	snapshotRequestTimeout     = 30 * time.Second
+)
+
+// Snapshot is a completed ledger snapshot of a channel on the peer
+type Snapshot struct {
+	ChannelID         string `json:"channelId"`
+	BlockNumber       uint64 `json:"blockNumber"`
+	LastBlockHash     string `json:"lastBlockHash"`
+	PreviousBlockHash string `json:"previousBlockHash"`
+	StateDBType       string `json:"stateDbType"`
+	// Path is the snapshot directory under the ChainLaunch data directory
+	Path string `json:"path"`
+}
+
+// snapshotMetadata is the signable metadata file Fabric writes with every snapshot
+type snapshotMetadata struct {
+	ChannelName       string `json:"channel_name"`
+	LastBlockNumber   uint64 `json:"last_block_number"`
+	LastBlockHash     string `json:"last_block_hash"`
+	PreviousBlockHash string `json:"previous_block_hash"`
+	StateDBType       string `json:"state_db_type"`
+}
+
+// snapshotsPath is the snapshot root directory of the peer, as set in core.yaml
+func (p *LocalPeer) snapshotsPath() string {
+	return filepath.Join(p.getPeerPath(), "data", "snapshots")
+}
+
+// SnapshotPath returns the directory of a completed snapshot of the peer
+func (p *LocalPeer) SnapshotPath(channelID string, blockNumber uint64) string {
+	return filepath.Join(p.snapshotsPath(), snapshotCompletedDir, channelID, strconv.FormatUint(blockNumber, 10))
+}
+
+// importPath returns where a snapshot copied from another peer is placed before joining
+func (p *LocalPeer) importPath(channelID string, blockNumber uint64) string {
+	return filepath.Join(p.snapshotsPath(), snapshotImportsDir, channelID, strconv.FormatUint(blockNumber, 10))
+}
+
+// peerVisiblePath maps a path under the peer data directory to the path the peer process sees
+func (p *LocalPeer) peerVisiblePath(localPath string) (string, error) {
+	if p.mode == "docker" {
+		rel, err := filepath.Rel(filepath.Join(p.getPeerPath(), "data"), localPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to map %s into the peer container: %w", localPath, err)
+		}
+		return path.Join(containerDataPath, filepath.ToSlash(rel)), nil
+	}
+	if p.remote != nil {
+		return p.remote.RemotePath(localPath), nil
+	}
+	return localPath, nil
+}
+
+// SubmitSnapshotRequest asks the peer to generate a snapshot of the channel when it
+// commits the given block. Block number 0 snapshots the last committed block.
+func (p *LocalPeer) SubmitSnapshotRequest(ctx context.Context, channelID string, blockNumber uint64) error {
+	p.logger.Info("Requesting ledger snapshot", "peer", p.opts.ID, "channel", channelID, "block", blockNumber)
+	client, closeConn, signed, err := p.snapshotClient(ctx, func(header *cb.SignatureHeader) proto.Message {
+		return &pb.SnapshotRequest{SignatureHeader: header, ChannelId: channelID, BlockNumber: blockNumber}
+	})
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+	ctx, cancel := context.WithTimeout(ctx, snapshotRequestTimeout)
+	defer cancel()
+	if _, err := client.Generate(ctx, signed); err != nil {
+		return fmt.Errorf("failed to submit snapshot request: %w", err)
+	}
+	return nil
+}
+
+// CancelSnapshotRequest cancels a pending snapshot request
+func (p *LocalPeer) CancelSnapshotRequest(ctx context.Context, channelID string, blockNumber uint64) error {
+	client, closeConn, signed, err := p.snapshotClient(ctx, func(header *cb.SignatureHeader) proto.Message {
+		return &pb.SnapshotRequest{SignatureHeader: header, ChannelId: channelID, BlockNumber: blockNumber}
+	})
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+	ctx, cancel := context.WithTimeout(ctx, snapshotRequestTimeout)
+	defer cancel()
+	if _, err := client.Cancel(ctx, signed); err != nil {
+		return fmt.Errorf("failed to cancel snapshot request: %w", err)
+	}
+	return nil
+}
+
+// PendingSnapshots returns the block numbers of the snapshot requests not generated yet
+func (p *LocalPeer) PendingSnapshots(ctx context.Context, channelID string) ([]uint64, error) {
+	client, closeConn, signed, err := p.snapshotClient(ctx, func(header *cb.SignatureHeader) proto.Message {
+		return &pb.SnapshotQuery{SignatureHeader: header, ChannelId: channelID}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+	ctx, cancel := context.WithTimeout(ctx, snapshotRequestTimeout)
+	defer cancel()
+	resp, err := client.QueryPendings(ctx, signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending snapshots: %w", err)
+	}
+	return resp.BlockNumbers, nil
+}
+
+// snapshotClient connects to the snapshot service of the peer and signs the request
+// built by newRequest with the organization admin identity
+func (p *LocalPeer) snapshotClient(ctx context.Context, newRequest func(*cb.SignatureHeader) proto.Message) (pb.SnapshotClient, func(), *pb.SignedSnapshotRequest, error) {
+	tlsCACert, err := p.GetTLSRootCACert(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get TLS root CA cert: %w", err)
+	}
+	adminIdentity, _, err := p.GetAdminIdentity(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get admin identity: %w", err)
+	}
+	header, err := protoutil.NewSignatureHeader(adminIdentity)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create signature header: %w", err)
+	}
+	request, err := proto.Marshal(newRequest(header))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal snapshot request: %w", err)
+	}
+	signature, err := adminIdentity.Sign(request)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to sign snapshot request: %w", err)
+	}
+
+	peerConn, err := p.CreatePeerConnection(ctx, p.GetPeerAddress(), tlsCACert)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create peer connection: %w", err)
+	}
+	closeConn := func() { peerConn.Close() }
+	return pb.NewSnapshotClient(peerConn), closeConn, &pb.SignedSnapshotRequest{Request: request, Signature: signature}, nil
+}
+
+// ListSnapshots returns the completed snapshots of the channel, newest first
+func (p *LocalPeer) ListSnapshots(ctx context.Context, channelID string) ([]Snapshot, error) {
+	dir := filepath.Join(p.snapshotsPath(), snapshotCompletedDir, channelID)
+	names, err := p.listDir(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(names))
+	for _, name := range names {
+		blockNumber, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		snapshotPath := p.SnapshotPath(channelID, blockNumber)
+		data, err := p.readFile(ctx, filepath.Join(snapshotPath, snapshotSignableMetadata))
+		if err != nil {
+			// Snapshots are moved to the completed directory once written, so a missing
+			// metadata file means the directory was tampered with
+			p.logger.Warn("Skipping snapshot without metadata", "peer", p.opts.ID, "path", snapshotPath, "error", err)
+			continue
+		}
+		var metadata snapshotMetadata
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			p.logger.Warn("Skipping snapshot with invalid metadata", "peer", p.opts.ID, "path", snapshotPath, "error", err)
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{
+			ChannelID:         metadata.ChannelName,
+			BlockNumber:       metadata.LastBlockNumber,
+			LastBlockHash:     metadata.LastBlockHash,
+			PreviousBlockHash: metadata.PreviousBlockHash,
+			StateDBType:       metadata.StateDBType,
+			Path:              snapshotPath,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].BlockNumber > snapshots[j].BlockNumber })
+	return snapshots, nil
+}
+
+// DeleteSnapshot removes a completed snapshot of the channel
+func (p *LocalPeer) DeleteSnapshot(ctx context.Context, channelID string, blockNumber uint64) error {
+	p.logger.Info("Deleting ledger snapshot", "peer", p.opts.ID, "channel", channelID, "block", blockNumber)
+	return p.removeAll(ctx, p.SnapshotPath(channelID, blockNumber))
+}
+
+// FetchSnapshot makes a completed snapshot available on the ChainLaunch host and
+// returns its local directory. Snapshots of remote peers are downloaded.
+func (p *LocalPeer) FetchSnapshot(ctx context.Context, channelID string, blockNumber uint64) (string, error) {
+	snapshotPath := p.SnapshotPath(channelID, blockNumber)
+	if p.remote != nil {
+		if err := os.RemoveAll(snapshotPath); err != nil {
+			return "", fmt.Errorf("failed to clear local snapshot copy: %w", err)
+		}
+		if err := p.remote.Download(ctx, snapshotPath); err != nil {
+			return "", fmt.Errorf("failed to download snapshot from %s: %w", p.remote.Host().Name, err)
+		}
+	}
+	if err := checkSnapshotDir(snapshotPath); err != nil {
+		return "", err
+	}
+	return snapshotPath, nil
+}
+
+// ImportSnapshot copies a snapshot fetched from another peer next to this peer's
+// ledger, uploading it when the peer runs on a remote host, and returns its local path
+func (p *LocalPeer) ImportSnapshot(ctx context.Context, sourcePath, channelID string, blockNumber uint64) (string, error) {
+	if err := checkSnapshotDir(sourcePath); err != nil {
+		return "", err
+	}
+	importPath := p.importPath(channelID, blockNumber)
+	if err := os.RemoveAll(importPath); err != nil {
+		return "", fmt.Errorf("failed to clear snapshot import directory: %w", err)
+	}
+	if err := copyDir(sourcePath, importPath); err != nil {
+		return "", fmt.Errorf("failed to copy snapshot: %w", err)
+	}
+	if p.remote != nil {
+		if err := p.remote.UploadData(ctx, importPath); err != nil {
+			return "", fmt.Errorf("failed to upload snapshot to %s: %w", p.remote.Host().Name, err)
+		}
+	}
+	return importPath, nil
+}
+
+// RemoveImportedSnapshot removes a snapshot copied in by ImportSnapshot once the peer joined
+func (p *LocalPeer) RemoveImportedSnapshot(ctx context.Context, channelID string, blockNumber uint64) error {
+	importPath := p.importPath(channelID, blockNumber)
+	if p.remote != nil {
+		if err := os.RemoveAll(importPath); err != nil {
+			return fmt.Errorf("failed to remove local snapshot copy: %w", err)
+		}
+	}
+	return p.removeAll(ctx, importPath)
+}
+
+// JoinChannelBySnapshot joins the peer to the channel of a snapshot under its data
+// directory. The peer imports the snapshot in the background; JoinBySnapshotInProgress
+// reports when it is done.
+func (p *LocalPeer) JoinChannelBySnapshot(ctx context.Context, snapshotPath string) error {
+	visiblePath, err := p.peerVisiblePath(snapshotPath)
+	if err != nil {
+		return err
+	}
+	p.logger.Info("Joining peer to channel by snapshot", "peer", p.opts.ID, "snapshot", visiblePath)
+	if _, err := p.invokeCSCC(ctx, []byte(csccJoinChainBySnapshot), []byte(visiblePath)); err != nil {
+		return fmt.Errorf("failed to join channel by snapshot: %w", err)
+	}
+	return nil
+}
+
+// JoinBySnapshotInProgress reports whether the peer is still importing a snapshot
+func (p *LocalPeer) JoinBySnapshotInProgress(ctx context.Context) (bool, error) {
+	payload, err := p.invokeCSCC(ctx, []byte(csccJoinBySnapshotStatus))
+	if err != nil {
+		return false, fmt.Errorf("failed to get join by snapshot status: %w", err)
+	}
+	status := &pb.JoinBySnapshotStatus{}
+	if err := proto.Unmarshal(payload, status); err != nil {
+		return false, fmt.Errorf("failed to unmarshal join by snapshot status: %w", err)
+	}
+	return status.InProgress, nil
+}
+
+// WaitJoinBySnapshot waits until the peer finished importing a snapshot
+func (p *LocalPeer) WaitJoinBySnapshot(ctx context.Context) error {
+	for {
+		inProgress, err := p.JoinBySnapshotInProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if !inProgress {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("peer is still importing the snapshot: %w", ctx.Err())
+		case <-time.After(joinBySnapshotPollInterval):
+		}
+	}
+}
+
+// invokeCSCC sends a proposal to the configuration system chaincode of the peer,
+// signed by the organization admin, and returns the response payload
+func (p *LocalPeer) invokeCSCC(ctx context.Context, args ...[]byte) ([]byte, error) {
+	tlsCACert, err := p.GetTLSRootCACert(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TLS root CA cert: %w", err)
+	}
+	adminIdentity, _, err := p.GetAdminIdentity(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin identity: %w", err)
+	}
+
+	sigHeader, err := protoutil.NewSignatureHeader(adminIdentity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature header: %w", err)
+	}
+	chaincodeID := &pb.ChaincodeID{Name: cscc}
+	chHeader := protoutil.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, "", 0)
+	chHeader.Extension = protoutil.MarshalOrPanic(&pb.ChaincodeHeaderExtension{ChaincodeId: chaincodeID})
+	protoutil.SetTxID(chHeader, sigHeader)
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: chaincodeID,
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+	proposal := &pb.Proposal{
+		Header:  protoutil.MarshalOrPanic(protoutil.MakePayloadHeader(chHeader, sigHeader)),
+		Payload: protoutil.MarshalOrPanic(&pb.ChaincodeProposalPayload{Input: protoutil.MarshalOrPanic(invocation)}),
+	}
+	proposalBytes, err := proto.Marshal(proposal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proposal: %w", err)
+	}
+	signature, err := adminIdentity.Sign(proposalBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign proposal: %w", err)
+	}
+
+	peerConn, err := p.CreatePeerConnection(ctx, p.GetPeerAddress(), tlsCACert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create peer connection: %w", err)
+	}
+	defer peerConn.Close()
+	resp, err := pb.NewEndorserClient(peerConn).ProcessProposal(ctx, &pb.SignedProposal{ProposalBytes: proposalBytes, Signature: signature})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process proposal: %w", err)
+	}
+	if resp.Response == nil {
+		return nil, fmt.Errorf("empty proposal response")
+	}
+	if resp.Response.Status != int32(cb.Status_SUCCESS) {
+		return nil, fmt.Errorf("proposal failed with status %d: %s", resp.Response.Status, resp.Response.Message)
+	}
+	return resp.Response.Payload, nil
+}
+
+func (p *LocalPeer) listDir(ctx context.Context, dir string) ([]string, error) {
+	if p.remote != nil {
+		return p.remote.ListDir(ctx, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (p *LocalPeer) readFile(ctx context.Context, name string) ([]byte, error) {
+	if p.remote != nil {
+		return p.remote.ReadFile(ctx, name)
+	}
+	return os.ReadFile(name)
+}
+
+func (p *LocalPeer) removeAll(ctx context.Context, dir string) error {
+	if p.remote != nil {
+		return p.remote.RemoveAll(ctx, dir)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", dir, err)
+	}
+	return nil
+}
+
+// checkSnapshotDir makes sure a directory holds a complete snapshot
+func checkSnapshotDir(dir string) error {
+	for _, name := range []string{snapshotSignableMetadata, snapshotAdditionalMetadata} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("%s is not a complete snapshot: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// copyDir copies the regular files and directories under src to dst
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, name)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		in, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
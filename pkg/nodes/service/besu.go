@@ -52,6 +52,14 @@ func decodeBesuGenesisFile(b64 string) (string, error) {
 	return "", fmt.Errorf("decode genesis block (expected base64-encoded JSON): %w", err)
 }
 
+// besuWSPort formats the WebSocket RPC port for the node options, empty when disabled
+func besuWSPort(port uint) string {
+	if port == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", port)
+}
+
 // GetBesuPorts attempts to find available ports for P2P and RPC, starting from default ports
 func GetBesuPorts(baseP2PPort, baseRPCPort uint) (p2pPort uint, rpcPort uint, err error) {
 	// Bounds check for base ports
@@ -183,6 +191,10 @@ func (s *NodeService) getBesuFromConfig(ctx context.Context, dbNode *db.Node, co
 		return nil, fmt.Errorf("failed to unmarshal network config: %w", err)
 	}
 
+	version := config.Version
+	if version == "" {
+		version = "25.7.0"
+	}
 	genesisJSON, err := decodeBesuGenesisFile(network.GenesisBlockB64.String)
 	if err != nil {
 		return nil, err
@@ -193,20 +205,22 @@ func (s *NodeService) getBesuFromConfig(ctx context.Context, dbNode *db.Node, co
 			ID:              dbNode.Slug,
 			GenesisFile:     genesisJSON,
 			NetworkID:       deployConfig.NetworkID,
+			ChainID:         networkConfig.ChainID,
 			P2PPort:         fmt.Sprintf("%d", deployConfig.P2PPort),
 			RPCPort:         fmt.Sprintf("%d", deployConfig.RPCPort),
+			WSPort:          besuWSPort(deployConfig.WSPort),
 			ListenAddress:   deployConfig.P2PHost,
 			MinerAddress:    key.EthereumAddress,
 			ConsensusType:   "qbft", // TODO: get consensus type from network
 			BootNodes:       config.BootNodes,
-			Version:         config.Version,
+			Version:         version,
 			NodePrivateKey:  strings.TrimPrefix(privateKeyDecrypted, "0x"),
 			Env:             config.Env,
 			P2PHost:         config.P2PHost,
 			RPCHost:         config.RPCHost,
-			MetricsEnabled:  config.MetricsEnabled,
-			MetricsPort:     config.MetricsPort,
-			MetricsProtocol: config.MetricsProtocol,
+			MetricsEnabled:  deployConfig.MetricsEnabled,
+			MetricsPort:     deployConfig.MetricsPort,
+			MetricsProtocol: "PROMETHEUS",
 			MinGasPrice:     config.MinGasPrice,
 			HostAllowList:   config.HostAllowList,
 		},
@@ -249,6 +263,15 @@ func (s *NodeService) stopBesuNode(ctx context.Context, dbNode *db.Node) error {
 		return fmt.Errorf("failed to get besu instance: %w", err)
 	}
 
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localBesu.SetRemoteHost(runner)
+	}
+
 	// Stop the node
 	err = localBesu.Stop()
 	if err != nil {
@@ -280,62 +303,20 @@ func (s *NodeService) startBesuNode(ctx context.Context, dbNode *db.Node) error
 		return fmt.Errorf("failed to assert deployment config to BesuNodeDeploymentConfig")
 	}
 
-	// Get key for node
-	key, err := s.keymanagementService.GetKey(ctx, int(besuNodeConfig.KeyID))
-	if err != nil {
-		return fmt.Errorf("failed to get key: %w", err)
-	}
-	network, err := s.db.GetNetwork(ctx, besuDeployConfig.NetworkID)
-	if err != nil {
-		return fmt.Errorf("failed to get network: %w", err)
-	}
-	privateKeyDecrypted, err := s.keymanagementService.GetDecryptedPrivateKey(int(besuNodeConfig.KeyID))
+	// Get Besu instance
+	localBesu, err := s.getBesuFromConfig(ctx, dbNode, besuNodeConfig, besuDeployConfig)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt key: %w", err)
-	}
-	var networkConfig networktypes.BesuNetworkConfig
-	if err := json.Unmarshal([]byte(network.Config.String), &networkConfig); err != nil {
-		return fmt.Errorf("failed to unmarshal network config: %w", err)
-	}
-	version := besuNodeConfig.Version
-	if version == "" {
-		version = "25.7.0"
+		return fmt.Errorf("failed to get besu instance: %w", err)
 	}
-	genesisJSON, err := decodeBesuGenesisFile(network.GenesisBlockB64.String)
+
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
 	if err != nil {
 		return err
 	}
-	// Create LocalBesu instance
-	localBesu := besu.NewLocalBesu(
-		besu.StartBesuOpts{
-			ID:              dbNode.Slug,
-			GenesisFile:     genesisJSON,
-			NetworkID:       besuDeployConfig.NetworkID,
-			ChainID:         networkConfig.ChainID,
-			P2PPort:         fmt.Sprintf("%d", besuDeployConfig.P2PPort),
-			RPCPort:         fmt.Sprintf("%d", besuDeployConfig.RPCPort),
-			ListenAddress:   besuDeployConfig.P2PHost,
-			MinerAddress:    key.EthereumAddress,
-			ConsensusType:   "qbft", // TODO: get consensus type from network
-			BootNodes:       besuNodeConfig.BootNodes,
-			Version:         version,
-			NodePrivateKey:  strings.TrimPrefix(privateKeyDecrypted, "0x"),
-			Env:             besuNodeConfig.Env,
-			P2PHost:         besuNodeConfig.P2PHost,
-			RPCHost:         besuNodeConfig.RPCHost,
-			MetricsEnabled:  besuDeployConfig.MetricsEnabled,
-			MetricsPort:     besuDeployConfig.MetricsPort,
-			MetricsProtocol: "PROMETHEUS",
-			MinGasPrice:     besuNodeConfig.MinGasPrice,
-			HostAllowList:   besuNodeConfig.HostAllowList,
-		},
-		string(besuNodeConfig.Mode),
-		dbNode.ID,
-		s.logger,
-		s.configService,
-		s.settingsService,
-		networkConfig,
-	)
+	if runner != nil {
+		defer runner.Close()
+		localBesu.SetRemoteHost(runner)
+	}
 
 	// Start the node
 	_, err = localBesu.Start()
@@ -360,6 +341,7 @@ type UpdateBesuNodeRequest struct {
 	P2PPort    uint              `json:"p2pPort" validate:"required"`
 	RPCHost    string            `json:"rpcHost" validate:"required"`
 	RPCPort    uint              `json:"rpcPort" validate:"required"`
+	WSPort     *uint             `json:"wsPort,omitempty"` // nil keeps the current port, 0 disables WebSocket RPC
 	Bootnodes  []string          `json:"bootnodes,omitempty"`
 	ExternalIP string            `json:"externalIp,omitempty"`
 	InternalIP string            `json:"internalIp,omitempty"`
@@ -367,6 +349,8 @@ type UpdateBesuNodeRequest struct {
 	// Metrics configuration
 	MetricsEnabled bool  `json:"metricsEnabled"`
 	MetricsPort    int64 `json:"metricsPort"`
+	// Besu version, applied the next time the node starts
+	Version string `json:"version,omitempty"`
 }
 
 // UpdateBesuNode updates an existing Besu node configuration
@@ -438,6 +422,10 @@ func (s *NodeService) UpdateBesuNode(ctx context.Context, nodeID int64, req Upda
 	deployBesuConfig.RPCPort = req.RPCPort
 	deployBesuConfig.P2PHost = req.P2PHost
 	deployBesuConfig.RPCHost = req.RPCHost
+	if req.WSPort != nil {
+		besuConfig.WSPort = *req.WSPort
+		deployBesuConfig.WSPort = *req.WSPort
+	}
 	if req.Bootnodes != nil {
 		besuConfig.BootNodes = req.Bootnodes
 	}
@@ -462,6 +450,9 @@ func (s *NodeService) UpdateBesuNode(ctx context.Context, nodeID int64, req Upda
 		besuConfig.Env = req.Env
 		deployBesuConfig.Env = req.Env
 	}
+	if req.Version != "" {
+		besuConfig.Version = req.Version
+	}
 
 	// Validate the updated configuration
 	if err := s.validateBesuNodeConfig(besuConfig); err != nil {
@@ -724,6 +715,7 @@ func (s *NodeService) initializeBesuNode(ctx context.Context, dbNode *db.Node, c
 		ChainID:                    networkConfig.ChainID,
 		P2PPort:                    fmt.Sprintf("%d", config.P2PPort),
 		RPCPort:                    fmt.Sprintf("%d", config.RPCPort),
+		WSPort:                     besuWSPort(config.WSPort),
 		P2PHost:                    config.P2PHost,
 		RPCHost:                    config.RPCHost,
 		MinerAddress:               key.EthereumAddress,
@@ -763,6 +755,11 @@ func (s *NodeService) initializeBesuNode(ctx context.Context, dbNode *db.Node, c
 	if err := s.validatePort(config.RPCHost, int(config.RPCPort)); err != nil {
 		return nil, fmt.Errorf("invalid RPC port: %w", err)
 	}
+	if config.WSPort != 0 {
+		if err := s.validatePort(config.RPCHost, int(config.WSPort)); err != nil {
+			return nil, fmt.Errorf("invalid WebSocket port: %w", err)
+		}
+	}
 
 	// Create deployment config
 	deploymentConfig := &types.BesuNodeDeploymentConfig{
@@ -773,6 +770,7 @@ func (s *NodeService) initializeBesuNode(ctx context.Context, dbNode *db.Node, c
 		KeyID:           config.KeyID,
 		P2PPort:         config.P2PPort,
 		RPCPort:         config.RPCPort,
+		WSPort:          config.WSPort,
 		NetworkID:       config.NetworkID,
 		ExternalIP:      config.ExternalIP,
 		P2PHost:         config.P2PHost,
@@ -1405,6 +1403,66 @@ func (c *RPCClient) GetSyncing(ctx context.Context) (interface{}, error) {
 	return syncing, nil
 }
 
+// Call executes a message call without creating a transaction (eth_call)
+func (c *RPCClient) Call(ctx context.Context, call map[string]interface{}, blockTag string) (string, error) {
+	result, err := c.callRPC(ctx, "eth_call", []interface{}{call, blockTag})
+	if err != nil {
+		return "", err
+	}
+
+	var data string
+	if err := json.Unmarshal(result, &data); err != nil {
+		return "", fmt.Errorf("failed to unmarshal call result: %w", err)
+	}
+
+	return data, nil
+}
+
+// EstimateGas estimates the gas a transaction needs
+func (c *RPCClient) EstimateGas(ctx context.Context, call map[string]interface{}) (string, error) {
+	result, err := c.callRPC(ctx, "eth_estimateGas", []interface{}{call})
+	if err != nil {
+		return "", err
+	}
+
+	var gas string
+	if err := json.Unmarshal(result, &gas); err != nil {
+		return "", fmt.Errorf("failed to unmarshal gas estimate: %w", err)
+	}
+
+	return gas, nil
+}
+
+// GetGasPrice gets the current gas price in Wei
+func (c *RPCClient) GetGasPrice(ctx context.Context) (string, error) {
+	result, err := c.callRPC(ctx, "eth_gasPrice", []interface{}{})
+	if err != nil {
+		return "", err
+	}
+
+	var price string
+	if err := json.Unmarshal(result, &price); err != nil {
+		return "", fmt.Errorf("failed to unmarshal gas price: %w", err)
+	}
+
+	return price, nil
+}
+
+// SendRawTransaction submits a signed transaction and returns its hash
+func (c *RPCClient) SendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	result, err := c.callRPC(ctx, "eth_sendRawTransaction", []interface{}{rawTx})
+	if err != nil {
+		return "", err
+	}
+
+	var txHash string
+	if err := json.Unmarshal(result, &txHash); err != nil {
+		return "", fmt.Errorf("failed to unmarshal transaction hash: %w", err)
+	}
+
+	return txHash, nil
+}
+
 // GetBesuRPCClient creates an RPC client for a Besu node
 func (s *NodeService) GetBesuRPCClient(ctx context.Context, nodeID int64) (*RPCClient, error) {
 	node, err := s.db.GetNode(ctx, nodeID)
@@ -106,6 +106,7 @@ type BesuNodeProperties struct {
 	NetworkID  int64  `json:"networkId"`
 	P2PPort    uint   `json:"p2pPort"`
 	RPCPort    uint   `json:"rpcPort"`
+	WSPort     uint   `json:"wsPort,omitempty"`
 	ExternalIP string `json:"externalIp"`
 	InternalIP string `json:"internalIp"`
 	EnodeURL   string `json:"enodeUrl"`
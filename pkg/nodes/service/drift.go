@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/drift"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/utils"
+)
+
+// CheckNodeDrift compares a node's configuration files, systemd unit and container
+// with what its stored deployment configuration renders to. When reconcile is true
+// a drifted node has its configuration rewritten and is restarted. Nodes whose
+// drift can't be checked are reported as unsupported with the reason.
+func (s *NodeService) CheckNodeDrift(ctx context.Context, nodeID int64, reconcile bool) (*drift.Report, error) {
+	node, err := s.db.GetNode(ctx, nodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("node not found", map[string]interface{}{
+				"id": nodeID,
+			})
+		}
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	return s.checkNodeDrift(ctx, node, reconcile), nil
+}
+
+// CheckNodesDrift checks the drift of every running node
+func (s *NodeService) CheckNodesDrift(ctx context.Context, reconcile bool) ([]*drift.Report, error) {
+	nodes, err := s.db.GetAllNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var reports []*drift.Report
+	for _, node := range nodes {
+		if types.NodeStatus(node.Status) != types.NodeStatusRunning {
+			continue
+		}
+		reports = append(reports, s.checkNodeDrift(ctx, node, reconcile))
+	}
+	return reports, nil
+}
+
+func (s *NodeService) checkNodeDrift(ctx context.Context, node *db.Node, reconcile bool) *drift.Report {
+	report := &drift.Report{
+		NodeID:    node.ID,
+		NodeName:  node.Name,
+		NodeType:  node.NodeType.String,
+		Findings:  []drift.Finding{},
+		CheckedAt: time.Now(),
+	}
+
+	_, err := s.db.GetNodeHost(ctx, node.ID)
+	remote := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		report.Status = drift.StatusError
+		report.Error = fmt.Sprintf("failed to get node placement: %v", err)
+		return report
+	}
+	if reason := unsupportedDriftReason(types.NodeType(node.NodeType.String), remote); reason != "" {
+		report.Status = drift.StatusUnsupported
+		report.Reason = reason
+		return report
+	}
+
+	exp, mode, err := s.expectedDeployment(ctx, node)
+	report.Mode = mode
+	if err != nil {
+		report.Status = drift.StatusError
+		report.Error = err.Error()
+		return report
+	}
+
+	findings, err := drift.Compare(ctx, drift.LocalSource{}, exp)
+	if err != nil {
+		report.Status = drift.StatusError
+		report.Error = err.Error()
+		return report
+	}
+	if len(findings) == 0 {
+		report.Status = drift.StatusInSync
+		return report
+	}
+	report.Status = drift.StatusDrifted
+	report.Findings = findings
+	if err := s.eventService.CreateEvent(ctx, node.ID, NodeEventDriftDetected, map[string]interface{}{
+		"node_id":  node.ID,
+		"name":     node.Name,
+		"findings": findings,
+	}); err != nil {
+		s.logger.Error("Failed to create drift event", "error", err)
+	}
+
+	if !reconcile {
+		return report
+	}
+	if err := s.reconcileNode(ctx, node); err != nil {
+		report.Error = fmt.Sprintf("failed to reconcile: %v", err)
+		return report
+	}
+	report.Reconciled = true
+	if err := s.eventService.CreateEvent(ctx, node.ID, NodeEventDriftReconciled, map[string]interface{}{
+		"node_id": node.ID,
+		"name":    node.Name,
+	}); err != nil {
+		s.logger.Error("Failed to create drift event", "error", err)
+	}
+	return report
+}
+
+// unsupportedDriftReason returns why the drift of a node can't be checked, or an
+// empty string when it can. Drift is only checked for Fabric peers and orderers and
+// Besu nodes running on this machine: FabricX nodes are made of several containers
+// configured when they join a network, and remote hosts are only reachable through
+// the runner, which can't read files back yet.
+func unsupportedDriftReason(nodeType types.NodeType, remote bool) string {
+	switch nodeType {
+	case types.NodeTypeFabricPeer, types.NodeTypeFabricOrderer, types.NodeTypeBesuFullnode:
+	default:
+		return fmt.Sprintf("drift checks are not supported for %s nodes", nodeType)
+	}
+	if remote {
+		return "drift checks are not supported for nodes on remote hosts"
+	}
+	return ""
+}
+
+// expectedDeployment renders the deployment of a node whose drift can be checked
+func (s *NodeService) expectedDeployment(ctx context.Context, node *db.Node) (*drift.Expected, string, error) {
+	nodeConfig, err := utils.LoadNodeConfig([]byte(node.NodeConfig.String))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load node config: %w", err)
+	}
+	switch types.NodeType(node.NodeType.String) {
+	case types.NodeTypeFabricPeer:
+		peerConfig, ok := nodeConfig.(*types.FabricPeerConfig)
+		if !ok {
+			return nil, "", fmt.Errorf("invalid peer config type")
+		}
+		deploymentConfig, err := utils.DeserializeDeploymentConfig(node.DeploymentConfig.String)
+		if err != nil {
+			return nil, peerConfig.Mode, fmt.Errorf("failed to deserialize deployment config: %w", err)
+		}
+		peerDeployConfig, ok := deploymentConfig.(*types.FabricPeerDeploymentConfig)
+		if !ok {
+			return nil, peerConfig.Mode, fmt.Errorf("invalid peer deployment config type")
+		}
+		org, err := s.orgService.GetOrganization(ctx, peerConfig.OrganizationID)
+		if err != nil {
+			return nil, peerConfig.Mode, fmt.Errorf("failed to get organization: %w", err)
+		}
+		exp, err := s.getPeerFromConfig(node, org, peerConfig).ExpectedDeployment(peerDeployConfig)
+		return exp, peerConfig.Mode, err
+	case types.NodeTypeFabricOrderer:
+		ordererConfig, ok := nodeConfig.(*types.FabricOrdererConfig)
+		if !ok {
+			return nil, "", fmt.Errorf("invalid orderer config type")
+		}
+		org, err := s.orgService.GetOrganization(ctx, ordererConfig.OrganizationID)
+		if err != nil {
+			return nil, ordererConfig.Mode, fmt.Errorf("failed to get organization: %w", err)
+		}
+		exp, err := s.getOrdererFromConfig(node, org, ordererConfig).ExpectedDeployment()
+		return exp, ordererConfig.Mode, err
+	case types.NodeTypeBesuFullnode:
+		besuConfig, ok := nodeConfig.(*types.BesuNodeConfig)
+		if !ok {
+			return nil, "", fmt.Errorf("invalid besu config type")
+		}
+		mode := string(besuConfig.Mode)
+		deploymentConfig, err := utils.DeserializeDeploymentConfig(node.DeploymentConfig.String)
+		if err != nil {
+			return nil, mode, fmt.Errorf("failed to deserialize deployment config: %w", err)
+		}
+		besuDeployConfig, ok := deploymentConfig.(*types.BesuNodeDeploymentConfig)
+		if !ok {
+			return nil, mode, fmt.Errorf("invalid besu deployment config type")
+		}
+		localBesu, err := s.getBesuFromConfig(ctx, node, besuConfig, besuDeployConfig)
+		if err != nil {
+			return nil, mode, err
+		}
+		exp, err := localBesu.ExpectedDeployment()
+		return exp, mode, err
+	default:
+		return nil, "", fmt.Errorf("drift checks are not supported for %s nodes", node.NodeType.String)
+	}
+}
+
+// reconcileNode rewrites a node's configuration from the database and restarts it
+func (s *NodeService) reconcileNode(ctx context.Context, node *db.Node) error {
+	switch types.NodeType(node.NodeType.String) {
+	case types.NodeTypeFabricPeer:
+		return s.SynchronizePeerConfig(ctx, node.ID)
+	case types.NodeTypeFabricOrderer:
+		return s.SynchronizeOrdererConfig(ctx, node.ID)
+	case types.NodeTypeBesuFullnode:
+		// Starting a Besu node rewrites its genesis file, key, unit and container
+		return s.restartNode(ctx, node)
+	default:
+		return fmt.Errorf("reconciliation is not supported for node type %s", node.NodeType.String)
+	}
+}
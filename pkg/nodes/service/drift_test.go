@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+func TestUnsupportedDriftReason(t *testing.T) {
+	tests := []struct {
+		nodeType types.NodeType
+		remote   bool
+		want     string
+	}{
+		{types.NodeTypeFabricPeer, false, ""},
+		{types.NodeTypeFabricOrderer, false, ""},
+		{types.NodeTypeBesuFullnode, false, ""},
+		{types.NodeTypeFabricPeer, true, "drift checks are not supported for nodes on remote hosts"},
+		{types.NodeTypeBesuFullnode, true, "drift checks are not supported for nodes on remote hosts"},
+		{types.NodeTypeFabricXOrdererGroup, false, "drift checks are not supported for FABRICX_ORDERER_GROUP nodes"},
+		{types.NodeTypeFabricXCommitter, true, "drift checks are not supported for FABRICX_COMMITTER nodes"},
+		{types.NodeTypeFabricXCommitterSidecar, false, "drift checks are not supported for FABRICX_COMMITTER_SIDECAR nodes"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, unsupportedDriftReason(tt.nodeType, tt.remote), "%s remote=%t", tt.nodeType, tt.remote)
+	}
+}
@@ -22,6 +22,12 @@ const (
 	NodeEventError                NodeEventType = "ERROR"
 	NodeEventRenewingCertificates NodeEventType = "RENEWING_CERTIFICATES"
 	NodeEventRenewedCertificates  NodeEventType = "RENEWED_CERTIFICATES"
+	NodeEventUpgrading            NodeEventType = "UPGRADING"
+	NodeEventUpgraded             NodeEventType = "UPGRADED"
+	NodeEventRollingBack          NodeEventType = "ROLLING_BACK"
+	NodeEventRolledBack           NodeEventType = "ROLLED_BACK"
+	NodeEventDriftDetected        NodeEventType = "DRIFT_DETECTED"
+	NodeEventDriftReconciled      NodeEventType = "DRIFT_RECONCILED"
 )
 
 // NodeEvent represents a node event in the service layer
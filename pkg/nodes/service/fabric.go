@@ -388,6 +388,14 @@ func (s *NodeService) startFabricPeer(ctx context.Context, dbNode *db.Node) erro
 	}
 
 	localPeer := s.getPeerFromConfig(dbNode, org, peerNodeConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localPeer.SetRemoteHost(runner)
+	}
 
 	_, err = localPeer.Start()
 	if err != nil {
@@ -420,6 +428,14 @@ func (s *NodeService) stopFabricPeer(ctx context.Context, dbNode *db.Node) error
 	}
 
 	localPeer := s.getPeerFromConfig(dbNode, org, peerNodeConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localPeer.SetRemoteHost(runner)
+	}
 
 	err = localPeer.Stop()
 	if err != nil {
@@ -450,6 +466,14 @@ func (s *NodeService) stopFabricPeerWithConfig(ctx context.Context, dbNode *db.N
 	tempConfig.Mode = deployConfig.Mode
 
 	localPeer := s.getPeerFromConfig(dbNode, org, &tempConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localPeer.SetRemoteHost(runner)
+	}
 	if err := localPeer.Stop(); err != nil {
 		return fmt.Errorf("failed to stop peer: %w", err)
 	}
@@ -474,6 +498,14 @@ func (s *NodeService) startFabricOrderer(ctx context.Context, dbNode *db.Node) e
 	}
 
 	localOrderer := s.getOrdererFromConfig(dbNode, org, ordererNodeConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localOrderer.SetRemoteHost(runner)
+	}
 
 	_, err = localOrderer.Start()
 	if err != nil {
@@ -500,6 +532,14 @@ func (s *NodeService) stopFabricOrderer(ctx context.Context, dbNode *db.Node) er
 	}
 
 	localOrderer := s.getOrdererFromConfig(dbNode, org, ordererNodeConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localOrderer.SetRemoteHost(runner)
+	}
 
 	err = localOrderer.Stop()
 	if err != nil {
@@ -530,6 +570,14 @@ func (s *NodeService) stopFabricOrdererWithConfig(ctx context.Context, dbNode *d
 	tempConfig.Mode = deployConfig.Mode
 
 	localOrderer := s.getOrdererFromConfig(dbNode, org, &tempConfig)
+	runner, err := s.remoteRunner(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+	if runner != nil {
+		defer runner.Close()
+		localOrderer.SetRemoteHost(runner)
+	}
 	if err := localOrderer.Stop(); err != nil {
 		return fmt.Errorf("failed to stop orderer: %w", err)
 	}
@@ -987,6 +1035,18 @@ func (s *NodeService) SynchronizePeerConfig(ctx context.Context, nodeID int64) e
 	return nil
 }
 
+// SynchronizeOrdererConfig rewrites the orderer's configuration files and recreates its service
+func (s *NodeService) SynchronizeOrdererConfig(ctx context.Context, nodeID int64) error {
+	localOrderer, err := s.GetFabricOrderer(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	if err := localOrderer.SynchronizeConfig(); err != nil {
+		return fmt.Errorf("failed to synchronize orderer config: %w", err)
+	}
+	return nil
+}
+
 // validateFabricPeerAddresses validates all addresses used by a Fabric peer
 func (s *NodeService) validateFabricPeerAddresses(config *types.FabricPeerConfig) error {
 	// Get current addresses to compare against
@@ -114,6 +114,14 @@ func (s *NodeService) startFabricXChild(ctx context.Context, dbNode *db.Node) er
 				PartyID:        groupCfg.PartyID,
 			},
 		)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return err
+		}
+		if runner != nil {
+			defer runner.Close()
+			og.SetRemoteHost(runner)
+		}
 		return og.StartOrdererRole(ctx, groupCfg, child.Role)
 
 	case types.FabricXRoleCommitterSidecar,
@@ -142,6 +150,14 @@ func (s *NodeService) startFabricXChild(ctx context.Context, dbNode *db.Node) er
 				ChannelID:        groupCfg.ChannelID,
 			},
 		)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return err
+		}
+		if runner != nil {
+			defer runner.Close()
+			c.SetRemoteHost(runner)
+		}
 		// StartCommitterRole requires the per-group docker bridge network to
 		// already exist (committer roles dial each other by container name
 		// on that network). PrepareCommitterStart is idempotent: if the
@@ -185,6 +201,14 @@ func (s *NodeService) stopFabricXChild(ctx context.Context, dbNode *db.Node) err
 				MSPID:          groupCfg.MSPID,
 			},
 		)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return err
+		}
+		if runner != nil {
+			defer runner.Close()
+			og.SetRemoteHost(runner)
+		}
 		return og.StopOrdererRole(ctx, groupCfg, child.Role)
 
 	case types.FabricXRoleCommitterSidecar,
@@ -205,6 +229,14 @@ func (s *NodeService) stopFabricXChild(ctx context.Context, dbNode *db.Node) err
 				MSPID:          groupCfg.MSPID,
 			},
 		)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return err
+		}
+		if runner != nil {
+			defer runner.Close()
+			c.SetRemoteHost(runner)
+		}
 		return c.StopCommitterRole(ctx, groupCfg, child.Role)
 
 	default:
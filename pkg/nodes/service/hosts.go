@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/fabricx"
+)
+
+// SetHostService enables placing nodes on remote hosts
+func (s *NodeService) SetHostService(hostService *hosts.Service) {
+	s.hostService = hostService
+}
+
+// remoteRunner connects to the host a node is placed on. It returns nil for
+// nodes that run locally. Callers must close a returned runner.
+func (s *NodeService) remoteRunner(ctx context.Context, nodeID int64) (*hosts.Runner, error) {
+	if s.hostService == nil {
+		return nil, nil
+	}
+	runner, err := s.hostService.RunnerForNode(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to node host: %w", err)
+	}
+	return runner, nil
+}
+
+// closeRunnerAfter closes the runner once a log stream ends. The runner is
+// closed right away when the stream failed to start.
+func closeRunnerAfter(runner *hosts.Runner, logs <-chan string, err error) (<-chan string, error) {
+	if runner == nil {
+		return logs, err
+	}
+	if err != nil {
+		runner.Close()
+		return logs, err
+	}
+	out := make(chan string, 100)
+	go func() {
+		defer runner.Close()
+		defer close(out)
+		for line := range logs {
+			out <- line
+		}
+	}()
+	return out, nil
+}
+
+// tailFabricXContainer streams a FabricX container's logs from wherever the node runs
+func (s *NodeService) tailFabricXContainer(ctx context.Context, nodeID int64, containerName string, tail int, follow bool) (<-chan string, error) {
+	runner, err := s.remoteRunner(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if runner == nil {
+		return fabricx.TailContainerLogs(ctx, s.logger, containerName, tail, follow)
+	}
+	logs, err := runner.TailContainer(ctx, containerName, tail, follow)
+	return closeRunnerAfter(runner, logs, err)
+}
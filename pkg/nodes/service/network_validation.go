@@ -130,6 +130,7 @@ func (v *NetworkLevelValidator) ValidateBesuPorts(ctx context.Context, opts *bes
 	portsToCheck := map[string]string{
 		"p2p": opts.P2PPort,
 		"rpc": opts.RPCPort,
+		"ws":  opts.WSPort,
 	}
 
 	if opts.MetricsEnabled {
@@ -324,6 +325,16 @@ func extractBesuPorts(nodeID int64, nodeName string, config *types.BesuNodeConfi
 		Purpose:  "rpc",
 	})
 
+	if config.WSPort != 0 {
+		ports = append(ports, PortUsage{
+			NodeID:   nodeID,
+			NodeName: nodeName,
+			NodeType: types.NodeTypeBesuFullnode,
+			Port:     fmt.Sprintf("%d", config.WSPort),
+			Purpose:  "ws",
+		})
+	}
+
 	if config.MetricsEnabled {
 		ports = append(ports, PortUsage{
 			NodeID:   nodeID,
@@ -17,11 +17,11 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/config"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/hosts"
 	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
 	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
 	metricscommon "github.com/chainlaunch/chainlaunch/pkg/metrics/common"
-	"github.com/chainlaunch/chainlaunch/pkg/nodes/fabricx"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
 	"github.com/chainlaunch/chainlaunch/pkg/nodes/utils"
 	settingsservice "github.com/chainlaunch/chainlaunch/pkg/settings/service"
@@ -46,6 +46,7 @@ type NodeService struct {
 	settingsService      *settingsservice.SettingsService
 	metricsService       metricscommon.Service
 	monitoringService    MonitoringService
+	hostService          *hosts.Service
 }
 
 // CreateNodeRequest represents the service-layer request to create a node
@@ -755,6 +756,7 @@ func (s *NodeService) createNodeConfig(req CreateNodeRequest) (types.NodeConfig,
 				},
 				P2PPort:         req.BesuNode.P2PPort,
 				RPCPort:         req.BesuNode.RPCPort,
+				WSPort:          req.BesuNode.WSPort,
 				NetworkID:       req.BesuNode.NetworkID,
 				ExternalIP:      req.BesuNode.ExternalIP,
 				Env:             req.BesuNode.Env,
@@ -1101,6 +1103,7 @@ func (s *NodeService) mapDBNodeToServiceNode(dbNode *db.Node) (*Node, *NodeRespo
 				NetworkID:  config.NetworkID,
 				P2PPort:    config.P2PPort,
 				RPCPort:    config.RPCPort,
+				WSPort:     config.WSPort,
 				ExternalIP: config.ExternalIP,
 				InternalIP: config.InternalIP,
 				P2PHost:    config.P2PHost,
@@ -1792,8 +1795,17 @@ func (s *NodeService) TailLogs(ctx context.Context, nodeID int64, tail int, foll
 		// Create peer instance
 		localPeer := s.getPeerFromConfig(dbNode, org, peerNodeConfig)
 
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return nil, err
+		}
+		if runner != nil {
+			localPeer.SetRemoteHost(runner)
+		}
+
 		// Tail logs from peer
-		return localPeer.TailLogs(ctx, tail, follow)
+		logs, err := localPeer.TailLogs(ctx, tail, follow)
+		return closeRunnerAfter(runner, logs, err)
 	case types.NodeTypeFabricOrderer:
 		// Convert to FabricOrdererDeploymentConfig
 		nodeConfig, err := utils.LoadNodeConfig([]byte(dbNode.NodeConfig.String))
@@ -1812,8 +1824,16 @@ func (s *NodeService) TailLogs(ctx context.Context, nodeID int64, tail int, foll
 		}
 		// Create orderer instance
 		localOrderer := s.getOrdererFromConfig(dbNode, org, ordererNodeConfig)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return nil, err
+		}
+		if runner != nil {
+			localOrderer.SetRemoteHost(runner)
+		}
 		// Tail logs from orderer
-		return localOrderer.TailLogs(ctx, tail, follow)
+		logs, err := localOrderer.TailLogs(ctx, tail, follow)
+		return closeRunnerAfter(runner, logs, err)
 	case types.NodeTypeBesuFullnode:
 		nodeConfig, err := utils.LoadNodeConfig([]byte(dbNode.NodeConfig.String))
 		if err != nil {
@@ -1829,7 +1849,15 @@ func (s *NodeService) TailLogs(ctx context.Context, nodeID int64, tail int, foll
 		if err != nil {
 			return nil, fmt.Errorf("failed to get besu from config: %w", err)
 		}
-		return localBesu.TailLogs(ctx, tail, follow)
+		runner, err := s.remoteRunner(ctx, dbNode.ID)
+		if err != nil {
+			return nil, err
+		}
+		if runner != nil {
+			localBesu.SetRemoteHost(runner)
+		}
+		logs, err := localBesu.TailLogs(ctx, tail, follow)
+		return closeRunnerAfter(runner, logs, err)
 	case types.NodeTypeFabricXOrdererRouter,
 		types.NodeTypeFabricXOrdererBatcher,
 		types.NodeTypeFabricXOrdererConsenter,
@@ -1852,7 +1880,7 @@ func (s *NodeService) TailLogs(ctx context.Context, nodeID int64, tail int, foll
 		if childCfg.ContainerName == "" {
 			return nil, fmt.Errorf("node %d has no container name recorded — was it started?", nodeID)
 		}
-		return fabricx.TailContainerLogs(ctx, s.logger, childCfg.ContainerName, tail, follow)
+		return s.tailFabricXContainer(ctx, dbNode.ID, childCfg.ContainerName, tail, follow)
 
 	case types.NodeTypeFabricXCommitter:
 		// A committer is a single logical node that runs 5 containers
@@ -1870,7 +1898,7 @@ func (s *NodeService) TailLogs(ctx context.Context, nodeID int64, tail int, foll
 		if containerName == "" {
 			return nil, fmt.Errorf("committer %d has no container name for role %q — was it started?", nodeID, role)
 		}
-		return fabricx.TailContainerLogs(ctx, s.logger, containerName, tail, follow)
+		return s.tailFabricXContainer(ctx, dbNode.ID, containerName, tail, follow)
 
 	case types.NodeTypeFabricXOrdererGroup:
 		// Legacy monolithic orderer-group node row (pre node_groups). Retained
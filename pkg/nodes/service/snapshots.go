@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/peer"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+// snapshotPollInterval is how often a requested snapshot is checked for completion
+const snapshotPollInterval = 5 * time.Second
+
+// maxChannelIDLength is the length Fabric limits channel names to
+const maxChannelIDLength = 249
+
+// channelIDPattern matches the channel names Fabric accepts
+var channelIDPattern = regexp.MustCompile(`^[a-z][a-z0-9.-]*$`)
+
+// validateChannelID rejects channel IDs Fabric doesn't accept. Snapshot directories are
+// named after the channel, so this keeps their paths inside the peer snapshot directory.
+func validateChannelID(channelID string) error {
+	if len(channelID) > maxChannelIDLength || !channelIDPattern.MatchString(channelID) {
+		return errors.NewValidationError("invalid channel ID", map[string]interface{}{
+			"channel": channelID,
+		})
+	}
+	return nil
+}
+
+// PeerSnapshots lists the ledger snapshots of a channel on a peer
+type PeerSnapshots struct {
+	Completed []peer.Snapshot `json:"completed"`
+	// Pending are the block numbers of requested snapshots not generated yet
+	Pending []uint64 `json:"pending"`
+}
+
+// snapshotPeer returns a Fabric peer set up to reach its ledger files, wherever it
+// runs. The returned release function closes the connection to a remote host.
+func (s *NodeService) snapshotPeer(ctx context.Context, nodeID int64) (*peer.LocalPeer, func(), error) {
+	node, err := s.db.GetNode(ctx, nodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, errors.NewNotFoundError("node not found", map[string]interface{}{
+				"id": nodeID,
+			})
+		}
+		return nil, nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	if types.NodeType(node.NodeType.String) != types.NodeTypeFabricPeer {
+		return nil, nil, errors.NewValidationError("node is not a Fabric peer", nil)
+	}
+
+	localPeer, err := s.GetFabricPeer(ctx, nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	runner, err := s.remoteRunner(ctx, nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if runner == nil {
+		return localPeer, func() {}, nil
+	}
+	localPeer.SetRemoteHost(runner)
+	return localPeer, func() { runner.Close() }, nil
+}
+
+// ListPeerSnapshots returns the completed and pending ledger snapshots of a channel on a peer
+func (s *NodeService) ListPeerSnapshots(ctx context.Context, nodeID int64, channelID string) (*PeerSnapshots, error) {
+	if err := validateChannelID(channelID); err != nil {
+		return nil, err
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	completed, err := localPeer.ListSnapshots(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	pending, err := localPeer.PendingSnapshots(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending snapshots: %w", err)
+	}
+	if pending == nil {
+		pending = []uint64{}
+	}
+	return &PeerSnapshots{Completed: completed, Pending: pending}, nil
+}
+
+// RequestPeerSnapshot asks a peer to generate a ledger snapshot of the channel at the
+// given block. Block number 0 snapshots the last committed block.
+func (s *NodeService) RequestPeerSnapshot(ctx context.Context, nodeID int64, channelID string, blockNumber uint64) error {
+	if err := validateChannelID(channelID); err != nil {
+		return err
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return localPeer.SubmitSnapshotRequest(ctx, channelID, blockNumber)
+}
+
+// CancelPeerSnapshot cancels a pending snapshot request of a peer
+func (s *NodeService) CancelPeerSnapshot(ctx context.Context, nodeID int64, channelID string, blockNumber uint64) error {
+	if err := validateChannelID(channelID); err != nil {
+		return err
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return localPeer.CancelSnapshotRequest(ctx, channelID, blockNumber)
+}
+
+// DeletePeerSnapshot removes a completed ledger snapshot from a peer
+func (s *NodeService) DeletePeerSnapshot(ctx context.Context, nodeID int64, channelID string, blockNumber uint64) error {
+	if err := validateChannelID(channelID); err != nil {
+		return err
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	snapshots, err := localPeer.ListSnapshots(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.BlockNumber == blockNumber {
+			return localPeer.DeleteSnapshot(ctx, channelID, blockNumber)
+		}
+	}
+	return errors.NewNotFoundError("snapshot not found", map[string]interface{}{
+		"channel": channelID,
+		"block":   blockNumber,
+	})
+}
+
+// PrunePeerSnapshots removes the completed snapshots of a channel on a peer except
+// the newest keep ones, and returns the block numbers of the removed snapshots
+func (s *NodeService) PrunePeerSnapshots(ctx context.Context, nodeID int64, channelID string, keep int) ([]uint64, error) {
+	if err := validateChannelID(channelID); err != nil {
+		return nil, err
+	}
+	if keep < 0 {
+		return nil, errors.NewValidationError("keep cannot be negative", nil)
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	snapshots, err := localPeer.ListSnapshots(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	removed := []uint64{}
+	for i, snapshot := range snapshots {
+		if i < keep {
+			continue
+		}
+		if err := localPeer.DeleteSnapshot(ctx, channelID, snapshot.BlockNumber); err != nil {
+			return removed, err
+		}
+		removed = append(removed, snapshot.BlockNumber)
+	}
+	return removed, nil
+}
+
+// WaitPeerSnapshot waits until a peer completed a snapshot of the channel at
+// minBlock or later and returns the oldest such snapshot
+func (s *NodeService) WaitPeerSnapshot(ctx context.Context, nodeID int64, channelID string, minBlock uint64) (*peer.Snapshot, error) {
+	if err := validateChannelID(channelID); err != nil {
+		return nil, err
+	}
+	localPeer, release, err := s.snapshotPeer(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	for {
+		snapshots, err := localPeer.ListSnapshots(ctx, channelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		// Snapshots are listed newest first
+		var found *peer.Snapshot
+		for i := range snapshots {
+			if snapshots[i].BlockNumber >= minBlock {
+				found = &snapshots[i]
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("snapshot at block %d not generated: %w", minBlock, ctx.Err())
+		case <-time.After(snapshotPollInterval):
+		}
+	}
+}
+
+// JoinPeerFromSnapshot copies a completed snapshot of the source peer to the target
+// peer's host, joins the target to the channel with it and waits until the ledger is
+// imported. The copy given to the target is removed afterwards; the source keeps its snapshot.
+func (s *NodeService) JoinPeerFromSnapshot(ctx context.Context, sourceID, targetID int64, channelID string, blockNumber uint64) error {
+	if err := validateChannelID(channelID); err != nil {
+		return err
+	}
+	source, releaseSource, err := s.snapshotPeer(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	defer releaseSource()
+	target, releaseTarget, err := s.snapshotPeer(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	defer releaseTarget()
+
+	snapshotPath, err := source.FetchSnapshot(ctx, channelID, blockNumber)
+	if err != nil {
+		return err
+	}
+	if source.IsRemote() {
+		// The downloaded copy is only needed until the target has its own
+		defer os.RemoveAll(snapshotPath)
+	}
+
+	importPath, err := target.ImportSnapshot(ctx, snapshotPath, channelID, blockNumber)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := target.RemoveImportedSnapshot(context.Background(), channelID, blockNumber); err != nil {
+			s.logger.Warn("Failed to remove imported snapshot", "nodeID", targetID, "error", err)
+		}
+	}()
+
+	if err := target.JoinChannelBySnapshot(ctx, importPath); err != nil {
+		return err
+	}
+	if err := target.WaitJoinBySnapshot(ctx); err != nil {
+		return err
+	}
+	s.logger.Info("Peer joined channel from snapshot", "nodeID", targetID, "sourceNodeID", sourceID, "channel", channelID, "block", blockNumber)
+	return nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChannelID(t *testing.T) {
+	tests := []struct {
+		channelID string
+		valid     bool
+	}{
+		{"mychannel", true},
+		{"my-channel.v2", true},
+		{"c" + strings.Repeat("a", maxChannelIDLength-1), true},
+		{"c" + strings.Repeat("a", maxChannelIDLength), false},
+		{"", false},
+		{"MyChannel", false},
+		{"1channel", false},
+		{"../../etc", false},
+		{"channel/../other", false},
+		{"channel\x00", false},
+	}
+	for _, tt := range tests {
+		err := validateChannelID(tt.channelID)
+		if tt.valid {
+			assert.NoError(t, err, tt.channelID)
+			continue
+		}
+		assert.True(t, errors.IsType(err, errors.ValidationError), tt.channelID)
+	}
+}
+
+func TestSnapshotOperationsValidateChannelFirst(t *testing.T) {
+	// The service has no database: the channel is rejected before the node is looked up
+	s := &NodeService{}
+	ctx := context.Background()
+	channelID := "../../etc"
+
+	_, err := s.ListPeerSnapshots(ctx, 1, channelID)
+	assert.True(t, errors.IsType(err, errors.ValidationError))
+	err = s.DeletePeerSnapshot(ctx, 1, channelID, 10)
+	assert.True(t, errors.IsType(err, errors.ValidationError))
+	_, err = s.PrunePeerSnapshots(ctx, 1, channelID, 1)
+	assert.True(t, errors.IsType(err, errors.ValidationError))
+	err = s.JoinPeerFromSnapshot(ctx, 1, 2, channelID, 10)
+	assert.True(t, errors.IsType(err, errors.ValidationError))
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/utils"
+)
+
+// UpgradeNodeVersion moves a Fabric peer, Fabric orderer or Besu node to another
+// version and restarts it so the new binary or image is used
+func (s *NodeService) UpgradeNodeVersion(ctx context.Context, nodeID int64, version string) (*NodeResponse, error) {
+	return s.changeNodeVersion(ctx, nodeID, version, NodeEventUpgrading, NodeEventUpgraded)
+}
+
+// RollbackNodeVersion restores the version a node ran before an upgrade and restarts it
+func (s *NodeService) RollbackNodeVersion(ctx context.Context, nodeID int64, version string) (*NodeResponse, error) {
+	return s.changeNodeVersion(ctx, nodeID, version, NodeEventRollingBack, NodeEventRolledBack)
+}
+
+func (s *NodeService) changeNodeVersion(ctx context.Context, nodeID int64, version string, startEvent, doneEvent NodeEventType) (*NodeResponse, error) {
+	node, err := s.db.GetNode(ctx, nodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFoundError("node not found", nil)
+		}
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	nodeType := types.NodeType(node.NodeType.String)
+	if version == "" && nodeType != types.NodeTypeBesuFullnode {
+		return nil, errors.NewValidationError("version is required", nil)
+	}
+
+	if err := s.eventService.CreateEvent(ctx, nodeID, startEvent, map[string]interface{}{
+		"node_id": nodeID,
+		"name":    node.Name,
+		"version": version,
+	}); err != nil {
+		s.logger.Error("Failed to create version change event", "error", err)
+	}
+
+	fail := func(err error) (*NodeResponse, error) {
+		if eventErr := s.eventService.CreateEvent(ctx, nodeID, NodeEventError, map[string]interface{}{
+			"node_id": nodeID,
+			"name":    node.Name,
+			"version": version,
+			"error":   err.Error(),
+		}); eventErr != nil {
+			s.logger.Error("Failed to create error event", "error", eventErr)
+		}
+		return nil, err
+	}
+
+	switch nodeType {
+	case types.NodeTypeFabricPeer:
+		_, err = s.UpdateFabricPeer(ctx, UpdateFabricPeerOpts{NodeID: nodeID, Version: version})
+	case types.NodeTypeFabricOrderer:
+		_, err = s.UpdateFabricOrderer(ctx, UpdateFabricOrdererOpts{NodeID: nodeID, Version: version})
+	case types.NodeTypeBesuFullnode:
+		err = s.setBesuNodeVersion(ctx, node, version)
+	default:
+		return fail(errors.NewValidationError(fmt.Sprintf("version changes are not supported for node type %s", nodeType), nil))
+	}
+	if err != nil {
+		return fail(fmt.Errorf("failed to update node version: %w", err))
+	}
+
+	// Stop may fail when the node was already down; starting is what matters
+	if _, err := s.StopNode(ctx, nodeID); err != nil {
+		s.logger.Warn("Failed to stop node before version change", "nodeID", nodeID, "error", err)
+	}
+	nodeResponse, err := s.StartNode(ctx, nodeID)
+	if err != nil {
+		return fail(fmt.Errorf("failed to start node with version %s: %w", version, err))
+	}
+
+	if err := s.eventService.CreateEvent(ctx, nodeID, doneEvent, map[string]interface{}{
+		"node_id": nodeID,
+		"name":    node.Name,
+		"version": version,
+	}); err != nil {
+		s.logger.Error("Failed to create version change event", "error", err)
+	}
+	return nodeResponse, nil
+}
+
+// setBesuNodeVersion stores the Besu version of a node. An empty version selects the default one.
+func (s *NodeService) setBesuNodeVersion(ctx context.Context, node *db.Node, version string) error {
+	nodeConfig, err := utils.LoadNodeConfig([]byte(node.NodeConfig.String))
+	if err != nil {
+		return fmt.Errorf("failed to load besu config: %w", err)
+	}
+	besuConfig, ok := nodeConfig.(*types.BesuNodeConfig)
+	if !ok {
+		return fmt.Errorf("invalid besu config type")
+	}
+
+	// Service mode runs a downloaded binary, make sure it exists before stopping the node
+	if version != "" && besuConfig.Mode != "docker" {
+		verifyResp, err := s.VerifyBesuVersion(ctx, BesuVersionVerificationRequest{Version: version})
+		if err != nil {
+			return fmt.Errorf("failed to verify Besu binary: %w", err)
+		}
+		if !verifyResp.Success {
+			return fmt.Errorf("Besu binary verification failed: %s", verifyResp.Error)
+		}
+	}
+
+	besuConfig.Version = version
+	configBytes, err := utils.StoreNodeConfig(besuConfig)
+	if err != nil {
+		return fmt.Errorf("failed to store node config: %w", err)
+	}
+	if _, err := s.db.UpdateNodeConfig(ctx, &db.UpdateNodeConfigParams{
+		ID: node.ID,
+		NodeConfig: sql.NullString{
+			String: string(configBytes),
+			Valid:  true,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to update node config: %w", err)
+	}
+	return nil
+}
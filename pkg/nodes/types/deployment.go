@@ -214,6 +214,8 @@ type BesuNodeDeploymentConfig struct {
 	P2PPort uint `json:"p2pPort" validate:"required" example:"30303"`
 	// @Description RPC port for API access
 	RPCPort uint `json:"rpcPort" validate:"required" example:"8545"`
+	// @Description WebSocket RPC port, required for event subscriptions (0 disables WebSocket RPC)
+	WSPort uint `json:"wsPort,omitempty" example:"8546"`
 	// @Description P2P host address
 	P2PHost string `json:"p2pHost" validate:"required" example:"0.0.0.0"`
 	// @Description RPC host address
@@ -252,6 +254,7 @@ func (c *BesuNodeDeploymentConfig) ToBesuNodeConfig() *BesuNodeDeploymentConfig
 		KeyID:                c.KeyID,
 		P2PPort:              c.P2PPort,
 		RPCPort:              c.RPCPort,
+		WSPort:               c.WSPort,
 		P2PHost:              c.P2PHost,
 		RPCHost:              c.RPCHost,
 		ExternalIP:           c.ExternalIP,
@@ -334,6 +337,7 @@ type BesuNodeConfig struct {
 	KeyID           int64             `json:"keyId" validate:"required"`
 	P2PPort         uint              `json:"p2pPort" validate:"required"`
 	RPCPort         uint              `json:"rpcPort" validate:"required"`
+	WSPort          uint              `json:"wsPort,omitempty"`
 	P2PHost         string            `json:"p2pHost" validate:"required"`
 	RPCHost         string            `json:"rpcHost" validate:"required"`
 	ExternalIP      string            `json:"externalIp" validate:"required"`
@@ -480,6 +484,7 @@ func MapToNodeConfig(deploymentConfig NodeDeploymentConfig) (NodeConfig, error)
 				},
 				P2PPort:    besuConfig.P2PPort,
 				RPCPort:    besuConfig.RPCPort,
+				WSPort:     besuConfig.WSPort,
 				ExternalIP: besuConfig.ExternalIP,
 				KeyID:      besuConfig.KeyID,
 				P2PHost:    besuConfig.P2PHost,
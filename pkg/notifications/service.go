@@ -23,4 +23,11 @@ type Service interface {
 
 	// SendDiskSpaceWarningNotification sends a notification about disk space usage
 	SendDiskSpaceWarningNotification(ctx context.Context, data DiskSpaceWarningData) error
+
+	// SendAlertNotification sends a notification about a firing or resolved Prometheus alert
+	SendAlertNotification(ctx context.Context, data AlertData) error
+
+	// SendPluginHealthNotification sends a notification about a plugin that became unhealthy,
+	// recovered or could not be restarted
+	SendPluginHealthNotification(ctx context.Context, data PluginHealthData) error
 }
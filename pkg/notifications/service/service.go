@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"html"
+	"sort"
+	"strings"
 	"time"
 
 	"crypto/tls"
@@ -1409,3 +1412,213 @@ Please take action to free up disk space to avoid service interruptions.`,
 		HTML:      html,
 	}
 }
+
+// SendAlertNotification sends a notification for a Prometheus alert forwarded by Alertmanager.
+// Alerts are delivered through the default SMTP provider since Alertmanager already handles
+// grouping, inhibition and repeat intervals.
+func (s *NotificationService) SendAlertNotification(ctx context.Context, data notifications.AlertData) error {
+	provider, err := s.queries.GetDefaultNotificationProvider(ctx, string(notifications.ProviderTypeSMTP))
+	if err != nil {
+		s.logger.Warn("Failed to get default notification provider for alerts", "error", err)
+		return nil
+	}
+
+	var config notifications.SMTPConfig
+	if err := json.Unmarshal([]byte(provider.Config), &config); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// Create notification content
+	content := s.createAlertContent(data)
+
+	// Send the email
+	if err := s.sendEmail(config, config.From, getRecipients(config), content); err != nil {
+		return fmt.Errorf("failed to send alert notification: %w", err)
+	}
+
+	s.logger.Info("Sent alert notification", "alert", data.AlertName, "status", data.Status)
+	return nil
+}
+
+// createAlertContent creates the email content for Prometheus alert notifications
+func (s *NotificationService) createAlertContent(data notifications.AlertData) EmailContent {
+	resolved := data.Status == "resolved"
+	statusText := "FIRING"
+	color := "#dc3545"
+	if resolved {
+		statusText = "RESOLVED"
+		color = "#28a745"
+	}
+
+	labelKeys := make([]string, 0, len(data.Labels))
+	for k := range data.Labels {
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+
+	var plainLabels, htmlLabels strings.Builder
+	for _, k := range labelKeys {
+		plainLabels.WriteString(fmt.Sprintf("- %s: %s\n", k, data.Labels[k]))
+		htmlLabels.WriteString(fmt.Sprintf(`
+					<tr>
+						<td style="padding: 8px; font-weight: bold;">%s:</td>
+						<td style="padding: 8px;">%s</td>
+					</tr>`, html.EscapeString(k), html.EscapeString(data.Labels[k])))
+	}
+
+	endedAt := "-"
+	if resolved && !data.EndsAt.IsZero() {
+		endedAt = data.EndsAt.Format(time.RFC3339)
+	}
+
+	// Create plain text content
+	plainText := fmt.Sprintf(`[%s] %s
+
+%s
+
+%s
+
+Severity: %s
+Started at: %s
+Resolved at: %s
+
+Labels:
+%s
+Source: %s`,
+		statusText, data.AlertName, data.Summary, data.Description, data.Severity,
+		data.StartsAt.Format(time.RFC3339), endedAt, plainLabels.String(), data.GeneratorURL)
+
+	// Create HTML content
+	htmlContent := fmt.Sprintf(`
+	<html>
+		<body>
+			<h2 style="color: %s;">[%s] %s</h2>
+			<p><strong>%s</strong></p>
+			<p>%s</p>
+			<div style="background: #f8f9fa; padding: 15px; border-radius: 5px; margin-bottom: 20px;">
+				<h3>Details:</h3>
+				<table style="width: 100%%;">
+					<tr>
+						<td style="padding: 8px; font-weight: bold;">Severity:</td>
+						<td style="padding: 8px;">%s</td>
+					</tr>
+					<tr>
+						<td style="padding: 8px; font-weight: bold;">Started at:</td>
+						<td style="padding: 8px;">%s</td>
+					</tr>
+					<tr>
+						<td style="padding: 8px; font-weight: bold;">Resolved at:</td>
+						<td style="padding: 8px;">%s</td>
+					</tr>%s
+				</table>
+			</div>
+			<hr>
+			<small>Sent from ChainDeploy</small>
+		</body>
+	</html>`, color, statusText, html.EscapeString(data.AlertName),
+		html.EscapeString(data.Summary), html.EscapeString(data.Description),
+		html.EscapeString(data.Severity), data.StartsAt.Format(time.RFC3339), endedAt,
+		htmlLabels.String())
+
+	return EmailContent{
+		Subject:   fmt.Sprintf("[%s] %s - ChainDeploy Alert", statusText, data.AlertName),
+		PlainText: plainText,
+		HTML:      htmlContent,
+	}
+}
+
+// SendPluginHealthNotification sends a notification about the health of a deployed plugin
+func (s *NotificationService) SendPluginHealthNotification(ctx context.Context, data notifications.PluginHealthData) error {
+	// Plugin services are reported like nodes, through the node downtime provider
+	provider, err := s.queries.GetDefaultNotificationProviderForType(ctx, "NODE_DOWNTIME")
+	if err != nil {
+		s.logger.Warn("Failed to get default notification provider for plugin health", "error", err)
+		return nil
+	}
+
+	if !provider.NotifyNodeDowntime {
+		// Provider is configured to not notify for downtime
+		return nil
+	}
+
+	var config notifications.SMTPConfig
+	if err := json.Unmarshal([]byte(provider.Config), &config); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// Create notification content
+	content := s.createPluginHealthContent(data)
+
+	// Send the email
+	if err := s.sendEmail(config, config.From, getRecipients(config), content); err != nil {
+		return fmt.Errorf("failed to send plugin health notification: %w", err)
+	}
+
+	s.logger.Info("Sent plugin health notification", "plugin", data.PluginName, "status", data.Status)
+	return nil
+}
+
+// createPluginHealthContent creates the email content for plugin health notifications
+func (s *NotificationService) createPluginHealthContent(data notifications.PluginHealthData) EmailContent {
+	var title, message, color string
+	switch data.Status {
+	case notifications.PluginHealthRecovered:
+		title = "Plugin Recovered"
+		message = "The plugin is healthy again."
+		color = "#28a745"
+	case notifications.PluginHealthGaveUp:
+		title = "Plugin Restart Failed"
+		message = "The plugin is still unhealthy after the maximum number of automatic restarts. Please check it and resume it manually."
+		color = "#dc3545"
+	default:
+		title = "Plugin Unhealthy"
+		message = "The plugin is unhealthy and will be restarted automatically."
+		color = "#ff8800"
+	}
+
+	services := strings.Join(data.Services, ", ")
+	errorMessage := data.Error
+	if errorMessage == "" {
+		errorMessage = "-"
+	}
+
+	// Create plain text content
+	plainText := fmt.Sprintf(`%s: %s
+
+%s
+
+Details:
+- Services: %s
+- Restarts: %d
+- Detected At: %s
+- Error: %s`,
+		title, data.PluginName, message, services, data.RestartCount,
+		data.DetectedAt.Format(time.RFC3339), errorMessage)
+
+	// Create HTML content
+	htmlContent := fmt.Sprintf(`
+	<html>
+		<body>
+			<h2 style="color: %s;">%s: %s</h2>
+			<p>%s</p>
+
+			<h3>Details:</h3>
+			<ul>
+				<li><strong>Services:</strong> %s</li>
+				<li><strong>Restarts:</strong> %d</li>
+				<li><strong>Detected At:</strong> %s</li>
+				<li><strong>Error:</strong> %s</li>
+			</ul>
+			<hr>
+			<small>Sent from ChainDeploy</small>
+		</body>
+	</html>`, color, title, html.EscapeString(data.PluginName), message,
+		html.EscapeString(services), data.RestartCount,
+		data.DetectedAt.Format(time.RFC3339), html.EscapeString(errorMessage))
+
+	return EmailContent{
+		Subject:   fmt.Sprintf("%s: %s", title, data.PluginName),
+		PlainText: plainText,
+		HTML:      htmlContent,
+	}
+}
@@ -11,6 +11,7 @@ const (
 	NotificationTypeBackupFailure    NotificationType = "BACKUP_FAILURE"
 	NotificationTypeS3ConnIssue      NotificationType = "S3_CONNECTION_ISSUE"
 	NotificationTypeDiskSpaceWarning NotificationType = "DISK_SPACE_WARNING"
+	NotificationTypePrometheusAlert  NotificationType = "PROMETHEUS_ALERT"
 )
 
 // NotificationDeliveryType represents different notification providers
@@ -176,3 +177,33 @@ type DiskSpaceWarningData struct {
 	DetectedTime   time.Time `json:"detectedTime"`
 	MountPoint     string    `json:"mountPoint"`
 }
+
+// AlertData represents data for Prometheus alert notifications forwarded by Alertmanager
+type AlertData struct {
+	AlertName    string            `json:"alertName"`
+	Status       string            `json:"status"`
+	Severity     string            `json:"severity"`
+	Summary      string            `json:"summary"`
+	Description  string            `json:"description"`
+	Labels       map[string]string `json:"labels"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+}
+
+// Plugin health statuses reported by the plugin supervisor
+const (
+	PluginHealthUnhealthy = "unhealthy"
+	PluginHealthRecovered = "recovered"
+	PluginHealthGaveUp    = "gave_up"
+)
+
+// PluginHealthData represents data for plugin health notifications
+type PluginHealthData struct {
+	PluginName   string    `json:"pluginName"`
+	Status       string    `json:"status"`
+	Services     []string  `json:"services"`
+	Error        string    `json:"error"`
+	RestartCount int       `json:"restartCount"`
+	DetectedAt   time.Time `json:"detectedAt"`
+}
@@ -1,12 +1,16 @@
 package plugin
 
 import (
+	"database/sql"
 	"encoding/json"
+	stderrors "errors"
+	"io"
 	"math/rand"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/chainlaunch/chainlaunch/pkg/auth"
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
 	"github.com/chainlaunch/chainlaunch/pkg/http/response"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
@@ -14,6 +18,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/plugin/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -50,6 +55,7 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/available", response.Middleware(h.listAvailablePlugins))
 		r.Post("/available/refresh", response.Middleware(h.refreshAvailablePlugins))
 		r.Post("/", response.Middleware(h.createPlugin))
+		r.Post("/install", response.Middleware(h.installPlugin))
 		r.Route("/{name}", func(r chi.Router) {
 			r.Get("/", response.Middleware(h.getPlugin))
 			r.Put("/", response.Middleware(h.updatePlugin))
@@ -60,6 +66,10 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 			r.Get("/status", response.Middleware(h.getPluginStatus))
 			r.Get("/deployment-status", response.Middleware(h.getDeploymentStatus))
 			r.Get("/services", response.Middleware(h.getDockerComposeServices))
+			r.Get("/versions", response.Middleware(h.listPluginVersions))
+			r.Get("/upgrade", response.Middleware(h.checkPluginUpgrade))
+			r.Post("/upgrade", response.Middleware(h.upgradePlugin))
+			r.Post("/rollback", response.Middleware(h.rollbackPlugin))
 		})
 	})
 }
@@ -109,18 +119,20 @@ func (h *Handler) getPlugin(w http.ResponseWriter, r *http.Request) error {
 }
 
 // @Summary Create a plugin
-// @Description Create a new plugin
+// @Description Create a new plugin from a JSON or YAML manifest. The manifest must be signed by a trusted publisher of one of the registry sources, the X-Plugin-Signature header carries the detached signature of the request body.
 // @Tags Plugins
 // @Accept json
 // @Produce json
 // @Param plugin body types.Plugin true "Plugin to create"
+// @Param X-Plugin-Signature header string false "Detached signature of the manifest in the request body"
+// @Param allowUnsigned query bool false "Skip the signature verification (admin only)"
 // @Success 201 {object} types.Plugin
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /plugins [post]
 func (h *Handler) createPlugin(w http.ResponseWriter, r *http.Request) error {
-	var plugin types.Plugin
-	if err := json.NewDecoder(r.Body).Decode(&plugin); err != nil {
+	plugin, err := decodeUploadedPlugin(r)
+	if err != nil {
 		return errors.NewValidationError("invalid request body", map[string]interface{}{
 			"detail": err.Error(),
 			"code":   "INVALID_REQUEST_BODY",
@@ -139,14 +151,105 @@ func (h *Handler) createPlugin(w http.ResponseWriter, r *http.Request) error {
 		})
 	}
 
-	if err := h.pm.ValidatePlugin(&plugin); err != nil {
+	if err := h.pm.ValidatePlugin(plugin); err != nil {
+		return errors.NewValidationError("invalid plugin", map[string]interface{}{
+			"detail": err.Error(),
+			"code":   "INVALID_PLUGIN",
+		})
+	}
+
+	if err := h.verifySignature(r, plugin, h.verifyUploadedPlugin); err != nil {
+		return err
+	}
+
+	if err := h.store.CreatePlugin(r.Context(), plugin); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return errors.NewConflictError("plugin already exists", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "PLUGIN_ALREADY_EXISTS",
+			})
+		}
+		return errors.NewInternalError("failed to create plugin", err, nil)
+	}
+	if err := h.store.RecordPluginVersion(r.Context(), plugin, ""); err != nil {
+		h.logger.Warnf("Failed to record version of plugin %s: %v", plugin.Metadata.Name, err)
+	}
+
+	return response.WriteJSON(w, http.StatusCreated, plugin)
+}
+
+// InstallPluginRequest is the body of a plugin install from a registry source
+type InstallPluginRequest struct {
+	Source string `json:"source" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+}
+
+// @Summary Install a plugin from a registry source
+// @Description Fetch a plugin from a registry source, verify its signature against the publisher keys of the source and install it
+// @Tags Plugins
+// @Accept json
+// @Produce json
+// @Param request body InstallPluginRequest true "Source and name of the plugin"
+// @Param allowUnsigned query bool false "Skip the signature verification (admin only)"
+// @Success 201 {object} types.Plugin
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /plugins/install [post]
+func (h *Handler) installPlugin(w http.ResponseWriter, r *http.Request) error {
+	var req InstallPluginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"detail": err.Error(),
+			"code":   "INVALID_REQUEST_BODY",
+		})
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return errors.NewValidationError("validation failed", map[string]interface{}{
+			"detail": err.Error(),
+			"code":   "VALIDATION_ERROR",
+		})
+	}
+
+	if h.registry == nil {
+		return errors.NewNotFoundError("plugin source not found", map[string]interface{}{
+			"code":   "SOURCE_NOT_FOUND",
+			"source": req.Source,
+		})
+	}
+	source, ok := h.registry.Source(req.Source)
+	if !ok {
+		return errors.NewNotFoundError("plugin source not found", map[string]interface{}{
+			"code":   "SOURCE_NOT_FOUND",
+			"source": req.Source,
+		})
+	}
+	plugin, err := source.Get(req.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.NewNotFoundError("plugin not found", map[string]interface{}{
+				"detail":      err.Error(),
+				"code":        "PLUGIN_NOT_FOUND",
+				"plugin_name": req.Name,
+			})
+		}
+		return errors.NewInternalError("failed to fetch plugin", err, nil)
+	}
+
+	if err := h.verifySignature(r, plugin, source.Verify); err != nil {
+		return err
+	}
+
+	if err := h.pm.ValidatePlugin(plugin); err != nil {
 		return errors.NewValidationError("invalid plugin", map[string]interface{}{
 			"detail": err.Error(),
 			"code":   "INVALID_PLUGIN",
 		})
 	}
 
-	if err := h.store.CreatePlugin(r.Context(), &plugin); err != nil {
+	if err := h.store.CreatePlugin(r.Context(), plugin); err != nil {
 		if strings.Contains(err.Error(), "already exists") {
 			return errors.NewConflictError("plugin already exists", map[string]interface{}{
 				"detail": err.Error(),
@@ -155,17 +258,104 @@ func (h *Handler) createPlugin(w http.ResponseWriter, r *http.Request) error {
 		}
 		return errors.NewInternalError("failed to create plugin", err, nil)
 	}
+	if err := h.store.RecordPluginVersion(r.Context(), plugin, req.Source); err != nil {
+		h.logger.Warnf("Failed to record version of plugin %s: %v", plugin.Metadata.Name, err)
+	}
 
+	h.logger.Infof("Installed plugin %s from source %s", plugin.Metadata.Name, req.Source)
 	return response.WriteJSON(w, http.StatusCreated, plugin)
 }
 
+// verifySignature runs the signature verification of a plugin, unless an admin skips it with
+// the allowUnsigned query parameter
+func (h *Handler) verifySignature(r *http.Request, plugin *types.Plugin, verify func(*types.Plugin) error) error {
+	if r.URL.Query().Get("allowUnsigned") == "true" {
+		session, ok := auth.SessionFromContext(r.Context())
+		if !ok || session.Role != auth.RoleAdmin {
+			return errors.NewAuthorizationError("only admins can install unverified plugins", map[string]interface{}{
+				"code": "ADMIN_REQUIRED",
+			})
+		}
+		h.logger.Warnf("Signature verification of plugin %s skipped by admin %s", plugin.Metadata.Name, session.Username)
+		return nil
+	}
+
+	if err := verify(plugin); err != nil {
+		switch {
+		case stderrors.Is(err, registry.ErrUnsignedPlugin):
+			return errors.NewValidationError("plugin is not signed", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "PLUGIN_UNSIGNED",
+			})
+		case stderrors.Is(err, registry.ErrInvalidSignature):
+			return errors.NewValidationError("invalid plugin signature", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "INVALID_PLUGIN_SIGNATURE",
+			})
+		default:
+			return errors.NewValidationError("invalid plugin", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "INVALID_PLUGIN",
+			})
+		}
+	}
+	return nil
+}
+
+// verifyUploadedPlugin checks the signature of a plugin sent in a request body against the
+// publisher keys of every registry source
+func (h *Handler) verifyUploadedPlugin(plugin *types.Plugin) error {
+	if h.registry == nil {
+		return registry.VerifySignature(plugin, nil)
+	}
+	return h.registry.VerifyPlugin(plugin)
+}
+
+// verifyPluginVersion checks the signature of a recorded plugin version against the publisher
+// keys of the source it was installed from, or of every source for uploaded manifests
+func (h *Handler) verifyPluginVersion(r *http.Request, version *types.PluginVersion) error {
+	verify := h.verifyUploadedPlugin
+	if h.registry != nil && version.Source != "" {
+		if source, ok := h.registry.Source(version.Source); ok {
+			verify = source.Verify
+		}
+	}
+	return h.verifySignature(r, version.Plugin, verify)
+}
+
+// pluginSignatureHeader carries the detached signature of a manifest uploaded in a request body
+const pluginSignatureHeader = "X-Plugin-Signature"
+
+// decodeUploadedPlugin reads a plugin from a request body, as YAML when the content type says
+// so and as JSON otherwise. The body is kept as the manifest its signature header covers.
+func decodeUploadedPlugin(r *http.Request) (*types.Plugin, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var plugin types.Plugin
+	if strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+		err = yaml.Unmarshal(data, &plugin)
+	} else {
+		err = json.Unmarshal(data, &plugin)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plugin.Manifest = data
+	plugin.Signature = strings.TrimSpace(r.Header.Get(pluginSignatureHeader))
+	return &plugin, nil
+}
+
 // @Summary Update a plugin
-// @Description Update an existing plugin
+// @Description Update an existing plugin from a JSON or YAML manifest. The manifest must be signed by a trusted publisher of one of the registry sources, the X-Plugin-Signature header carries the detached signature of the request body.
 // @Tags Plugins
 // @Accept json
 // @Produce json
 // @Param name path string true "Plugin name"
 // @Param plugin body types.Plugin true "Plugin to update"
+// @Param X-Plugin-Signature header string false "Detached signature of the manifest in the request body"
+// @Param allowUnsigned query bool false "Skip the signature verification (admin only)"
 // @Success 200 {object} types.Plugin
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
@@ -173,8 +363,8 @@ func (h *Handler) createPlugin(w http.ResponseWriter, r *http.Request) error {
 // @Router /plugins/{name} [put]
 func (h *Handler) updatePlugin(w http.ResponseWriter, r *http.Request) error {
 	name := chi.URLParam(r, "name")
-	var plugin types.Plugin
-	if err := json.NewDecoder(r.Body).Decode(&plugin); err != nil {
+	plugin, err := decodeUploadedPlugin(r)
+	if err != nil {
 		return errors.NewValidationError("invalid request body", map[string]interface{}{
 			"detail": err.Error(),
 			"code":   "INVALID_REQUEST_BODY",
@@ -216,14 +406,18 @@ func (h *Handler) updatePlugin(w http.ResponseWriter, r *http.Request) error {
 		})
 	}
 
-	if err := h.pm.ValidatePlugin(&plugin); err != nil {
+	if err := h.pm.ValidatePlugin(plugin); err != nil {
 		return errors.NewValidationError("invalid plugin", map[string]interface{}{
 			"detail": err.Error(),
 			"code":   "INVALID_PLUGIN",
 		})
 	}
 
-	if err := h.store.UpdatePlugin(r.Context(), &plugin); err != nil {
+	if err := h.verifySignature(r, plugin, h.verifyUploadedPlugin); err != nil {
+		return err
+	}
+
+	if err := h.store.UpdatePlugin(r.Context(), plugin); err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return errors.NewNotFoundError("plugin not found", map[string]interface{}{
 				"detail":      "The requested plugin does not exist",
@@ -233,10 +427,281 @@ func (h *Handler) updatePlugin(w http.ResponseWriter, r *http.Request) error {
 		}
 		return errors.NewInternalError("failed to update plugin", err, nil)
 	}
+	if err := h.store.RecordPluginVersion(r.Context(), plugin, ""); err != nil {
+		h.logger.Warnf("Failed to record version of plugin %s: %v", plugin.Metadata.Name, err)
+	}
 
 	return response.WriteJSON(w, http.StatusOK, plugin)
 }
 
+// @Summary List plugin versions
+// @Description Get the recorded versions of an installed plugin, newest first. Versions with a deployment date were deployed and keep the parameters they were deployed with.
+// @Tags Plugins
+// @Accept json
+// @Produce json
+// @Param name path string true "Plugin name"
+// @Success 200 {array} types.PluginVersion
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /plugins/{name}/versions [get]
+func (h *Handler) listPluginVersions(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	if _, err := h.getInstalledPlugin(r, name); err != nil {
+		return err
+	}
+
+	versions, err := h.store.ListPluginVersions(r.Context(), name)
+	if err != nil {
+		return errors.NewInternalError("failed to list plugin versions", err, nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, versions)
+}
+
+// PluginUpgradeCheck compares the installed version of a plugin with the version available
+// in a registry source
+type PluginUpgradeCheck struct {
+	Name             string `json:"name"`
+	InstalledVersion string `json:"installedVersion"`
+	AvailableVersion string `json:"availableVersion"`
+	Source           string `json:"source"`
+	UpgradeAvailable bool   `json:"upgradeAvailable"`
+}
+
+// UpgradePluginRequest is the body of a plugin upgrade, every field is optional
+type UpgradePluginRequest struct {
+	// Source is the registry source to upgrade from, by default the source the plugin was
+	// installed from or the first source that has it
+	Source string `json:"source"`
+	// Version pins the version to upgrade to, the upgrade is refused if the source has another one
+	Version string `json:"version"`
+}
+
+// @Summary Check for a plugin upgrade
+// @Description Compare the installed version of a plugin with the version available in the registry source it was installed from, or the first source that has it
+// @Tags Plugins
+// @Accept json
+// @Produce json
+// @Param name path string true "Plugin name"
+// @Param source query string false "Registry source to check"
+// @Success 200 {object} PluginUpgradeCheck
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /plugins/{name}/upgrade [get]
+func (h *Handler) checkPluginUpgrade(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	installed, err := h.getInstalledPlugin(r, name)
+	if err != nil {
+		return err
+	}
+
+	sourceName, _, available, err := h.findUpgrade(r, name, r.URL.Query().Get("source"))
+	if err != nil {
+		return err
+	}
+
+	return response.WriteJSON(w, http.StatusOK, PluginUpgradeCheck{
+		Name:             name,
+		InstalledVersion: installed.Metadata.Version,
+		AvailableVersion: available.Metadata.Version,
+		Source:           sourceName,
+		UpgradeAvailable: types.CompareVersions(available.Metadata.Version, installed.Metadata.Version) > 0,
+	})
+}
+
+// @Summary Upgrade a plugin
+// @Description Upgrade an installed plugin to the newer version available in a registry source. The manifest must be signed by a trusted publisher of the source. A deployed plugin is redeployed with the parameters of its current deployment.
+// @Tags Plugins
+// @Accept json
+// @Produce json
+// @Param name path string true "Plugin name"
+// @Param request body UpgradePluginRequest false "Source and pinned version of the upgrade"
+// @Param allowUnsigned query bool false "Skip the signature verification (admin only)"
+// @Success 200 {object} types.Plugin
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /plugins/{name}/upgrade [post]
+func (h *Handler) upgradePlugin(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	var req UpgradePluginRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return errors.NewValidationError("invalid request body", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "INVALID_REQUEST_BODY",
+			})
+		}
+	}
+
+	installed, err := h.getInstalledPlugin(r, name)
+	if err != nil {
+		return err
+	}
+
+	sourceName, source, plugin, err := h.findUpgrade(r, name, req.Source)
+	if err != nil {
+		return err
+	}
+
+	if req.Version != "" && types.CompareVersions(plugin.Metadata.Version, req.Version) != 0 {
+		return errors.NewConflictError("pinned version not available", map[string]interface{}{
+			"detail":            "The source does not provide the requested version",
+			"code":              "VERSION_NOT_AVAILABLE",
+			"requested_version": req.Version,
+			"available_version": plugin.Metadata.Version,
+			"source":            sourceName,
+		})
+	}
+	if types.CompareVersions(plugin.Metadata.Version, installed.Metadata.Version) <= 0 {
+		return errors.NewConflictError("no newer version available", map[string]interface{}{
+			"detail":            "The available version is not newer than the installed version",
+			"code":              "NO_UPGRADE_AVAILABLE",
+			"installed_version": installed.Metadata.Version,
+			"available_version": plugin.Metadata.Version,
+			"source":            sourceName,
+		})
+	}
+
+	if err := h.verifySignature(r, plugin, source.Verify); err != nil {
+		return err
+	}
+
+	if err := h.pm.ValidatePlugin(plugin); err != nil {
+		return errors.NewValidationError("invalid plugin", map[string]interface{}{
+			"detail": err.Error(),
+			"code":   "INVALID_PLUGIN",
+		})
+	}
+
+	if err := h.pm.UpgradePlugin(r.Context(), plugin, sourceName, h.store); err != nil {
+		if strings.Contains(err.Error(), "x-source parameter validation failed") {
+			return errors.NewValidationError("invalid x-source parameter", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "INVALID_XSOURCE_PARAMETER",
+			})
+		}
+		return errors.NewInternalError("failed to upgrade plugin", err, nil)
+	}
+
+	h.logger.Infof("Upgraded plugin %s from %s to %s", name, installed.Metadata.Version, plugin.Metadata.Version)
+	return response.WriteJSON(w, http.StatusOK, plugin)
+}
+
+// @Summary Roll back a plugin
+// @Description Restore the previously deployed version of a plugin and drop the newer versions. The manifest recorded with the restored version must still be signed by a trusted publisher of its source. A deployed plugin, or one whose deployment failed, is redeployed with the parameters the restored version was deployed with.
+// @Tags Plugins
+// @Accept json
+// @Produce json
+// @Param name path string true "Plugin name"
+// @Param allowUnsigned query bool false "Skip the signature verification (admin only)"
+// @Success 200 {object} types.Plugin
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /plugins/{name}/rollback [post]
+func (h *Handler) rollbackPlugin(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	if _, err := h.getInstalledPlugin(r, name); err != nil {
+		return err
+	}
+
+	version, err := h.pm.RollbackPlugin(r.Context(), name, h.store, func(version *types.PluginVersion) error {
+		return h.verifyPluginVersion(r, version)
+	})
+	if err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			return appErr
+		}
+		if stderrors.Is(err, ErrNoRollbackVersion) {
+			return errors.NewConflictError("no version to roll back to", map[string]interface{}{
+				"detail": err.Error(),
+				"code":   "NO_ROLLBACK_VERSION",
+			})
+		}
+		return errors.NewInternalError("failed to roll back plugin", err, nil)
+	}
+
+	h.logger.Infof("Rolled back plugin %s to version %s", name, version.Version)
+	return response.WriteJSON(w, http.StatusOK, version.Plugin)
+}
+
+// getInstalledPlugin returns an installed plugin, or a not found error
+func (h *Handler) getInstalledPlugin(r *http.Request, name string) (*types.Plugin, error) {
+	plugin, err := h.store.GetPlugin(r.Context(), name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") || stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("plugin not found", map[string]interface{}{
+				"detail":      "The requested plugin does not exist",
+				"code":        "PLUGIN_NOT_FOUND",
+				"plugin_name": name,
+			})
+		}
+		return nil, errors.NewInternalError("failed to get plugin", err, nil)
+	}
+	return plugin, nil
+}
+
+// findUpgrade fetches the available version of a plugin from the requested registry source,
+// by default from the source the installed version comes from or the first source that has it
+func (h *Handler) findUpgrade(r *http.Request, name string, sourceName string) (string, registry.PluginSource, *types.Plugin, error) {
+	if h.registry == nil {
+		return "", nil, nil, errors.NewNotFoundError("plugin source not found", map[string]interface{}{
+			"code":   "SOURCE_NOT_FOUND",
+			"source": sourceName,
+		})
+	}
+
+	if sourceName == "" {
+		current, err := h.store.GetCurrentPluginVersion(r.Context(), name)
+		if err != nil {
+			return "", nil, nil, errors.NewInternalError("failed to get plugin version", err, nil)
+		}
+		if current != nil && current.Source != "" {
+			if _, ok := h.registry.Source(current.Source); ok {
+				sourceName = current.Source
+			}
+		}
+	}
+
+	if sourceName == "" {
+		found, plugin, err := h.registry.FindPlugin(name)
+		if err != nil {
+			return "", nil, nil, errors.NewNotFoundError("plugin not found in any source", map[string]interface{}{
+				"detail":      err.Error(),
+				"code":        "PLUGIN_NOT_FOUND",
+				"plugin_name": name,
+			})
+		}
+		source, _ := h.registry.Source(found)
+		return found, source, plugin, nil
+	}
+
+	source, ok := h.registry.Source(sourceName)
+	if !ok {
+		return "", nil, nil, errors.NewNotFoundError("plugin source not found", map[string]interface{}{
+			"code":   "SOURCE_NOT_FOUND",
+			"source": sourceName,
+		})
+	}
+	plugin, err := source.Get(name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "", nil, nil, errors.NewNotFoundError("plugin not found", map[string]interface{}{
+				"detail":      err.Error(),
+				"code":        "PLUGIN_NOT_FOUND",
+				"plugin_name": name,
+			})
+		}
+		return "", nil, nil, errors.NewInternalError("failed to fetch plugin", err, nil)
+	}
+	return sourceName, source, plugin, nil
+}
+
 // @Summary Delete a plugin
 // @Description Delete a plugin (will stop it first if running)
 // @Tags Plugins
@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -317,6 +318,11 @@ func (pm *PluginManager) DeployPlugin(ctx context.Context, plugin *plugintypes.P
 		return fmt.Errorf("failed to update deployment status: %w", err)
 	}
 
+	// Keep the parameters with the deployed version so that it can be rolled back to
+	if err := store.MarkPluginVersionDeployed(ctx, plugin, parameters); err != nil {
+		pm.logger.Warnf("Failed to record deployed version of plugin %s: %v", plugin.Metadata.Name, err)
+	}
+
 	return nil
 }
 
@@ -369,6 +375,12 @@ func (pm *PluginManager) ValidatePlugin(plugin *plugintypes.Plugin) error {
 		}
 	}
 
+	for i := range plugin.Spec.HealthChecks {
+		if err := plugin.Spec.HealthChecks[i].Validate(); err != nil {
+			return fmt.Errorf("spec.healthChecks[%d]: %w", i, err)
+		}
+	}
+
 	return nil
 }
 
@@ -776,3 +788,101 @@ func (pm *PluginManager) ResumePlugin(ctx context.Context, plugin *plugintypes.P
 
 	return nil
 }
+
+// GetContainerStates returns the containers of the docker-compose project of a deployed plugin,
+// including the stopped and exited ones that GetDockerComposeServices leaves out
+func (pm *PluginManager) GetContainerStates(ctx context.Context, plugin *plugintypes.Plugin) ([]api.ContainerSummary, error) {
+	containers, err := pm.compose.Ps(ctx, plugin.Metadata.Name, api.PsOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+	return containers, nil
+}
+
+// RestartPluginServices restarts the given docker-compose services of a deployed plugin, or all
+// of them when services is empty
+func (pm *PluginManager) RestartPluginServices(ctx context.Context, plugin *plugintypes.Plugin, services []string) error {
+	if err := pm.compose.Restart(ctx, plugin.Metadata.Name, api.RestartOptions{Services: services}); err != nil {
+		return fmt.Errorf("failed to restart services: %w", err)
+	}
+	return nil
+}
+
+// ErrNoRollbackVersion is returned when a plugin has no previously deployed version to roll back to
+var ErrNoRollbackVersion = errors.New("no previously deployed version to roll back to")
+
+// UpgradePlugin replaces the definition of an installed plugin with a new version from a
+// registry source and records it. A deployed plugin is redeployed with the parameters of
+// its current deployment.
+func (pm *PluginManager) UpgradePlugin(ctx context.Context, plugin *plugintypes.Plugin, source string, store Store) error {
+	status, err := store.GetDeploymentStatus(ctx, plugin.Metadata.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment status: %w", err)
+	}
+
+	var parameters map[string]interface{}
+	if status == "deployed" {
+		deploymentMetadata, err := store.GetDeploymentMetadata(ctx, plugin.Metadata.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get deployment metadata: %w", err)
+		}
+		parameters, _ = deploymentMetadata["parameters"].(map[string]interface{})
+	}
+
+	if err := store.UpdatePlugin(ctx, plugin); err != nil {
+		return err
+	}
+	if err := store.RecordPluginVersion(ctx, plugin, source); err != nil {
+		return err
+	}
+
+	if status != "deployed" {
+		return nil
+	}
+	pm.logger.Infof("Redeploying plugin %s at version %s", plugin.Metadata.Name, plugin.Metadata.Version)
+	return pm.DeployPlugin(ctx, plugin, parameters, store)
+}
+
+// RollbackPlugin restores the previously deployed version of a plugin and drops the newer
+// versions, once verify accepts the version to restore. A plugin that is deployed, or whose
+// deployment failed, is redeployed with the parameters the restored version was deployed with.
+func (pm *PluginManager) RollbackPlugin(ctx context.Context, name string, store Store, verify func(*plugintypes.PluginVersion) error) (*plugintypes.PluginVersion, error) {
+	current, err := store.GetCurrentPluginVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, ErrNoRollbackVersion
+	}
+	previous, err := store.GetPreviousDeployedPluginVersion(ctx, name, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, ErrNoRollbackVersion
+	}
+	if err := verify(previous); err != nil {
+		return nil, err
+	}
+
+	status, err := store.GetDeploymentStatus(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment status: %w", err)
+	}
+
+	if err := store.UpdatePlugin(ctx, previous.Plugin); err != nil {
+		return nil, err
+	}
+	if err := store.DeletePluginVersionsAfter(ctx, name, previous.ID); err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case "deployed", "deploying", "failed":
+		pm.logger.Infof("Rolling back plugin %s from version %s to %s", name, current.Version, previous.Version)
+		if err := pm.DeployPlugin(ctx, previous.Plugin, previous.Parameters, store); err != nil {
+			return nil, err
+		}
+	}
+	return previous, nil
+}
@@ -16,6 +16,12 @@ type RegistrySource struct {
 	Enabled     bool              `json:"enabled" yaml:"enabled"`
 	Trust       bool              `json:"trust" yaml:"trust"` // Whether to trust plugins from this source
 	Credentials map[string]string `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	// PublisherKeys are the public keys of the publishers trusted to sign the plugins of this
+	// source, PEM encoded or base64 encoded raw Ed25519 keys
+	PublisherKeys []string `json:"publisherKeys,omitempty" yaml:"publisherKeys,omitempty"`
+	// AllowUnsigned accepts plugins without signature from this source. Plugins with a
+	// signature that does not match are refused anyway.
+	AllowUnsigned bool `json:"allowUnsigned" yaml:"allowUnsigned"`
 }
 
 // RegistryConfig represents the configuration for the plugin registry
@@ -57,6 +63,8 @@ type PluginMetadata struct {
 	Updated     time.Time         `json:"updated" yaml:"updated"`
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 	RawYAML     string            `json:"raw_yaml" yaml:"raw_yaml"`
+	// Signature is the detached signature of RawYAML, to send along with it on install
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty"`
 }
 
 // AvailablePluginsCache caches available plugins from GitHub sources
@@ -96,6 +104,11 @@ func NewRegistry(config *RegistryConfig) (*Registry, error) {
 		if !source.Enabled {
 			continue
 		}
+		for _, key := range source.PublisherKeys {
+			if _, err := ParsePublicKey(key); err != nil {
+				return nil, fmt.Errorf("invalid publisher key for source %s: %w", source.Name, err)
+			}
+		}
 
 		src, err := newSource(source)
 		if err != nil {
@@ -134,6 +147,39 @@ func (r *Registry) Sources() []PluginSource {
 	return s
 }
 
+// Source returns the enabled source with the given name
+func (r *Registry) Source(name string) (PluginSource, bool) {
+	src, ok := r.sources[name]
+	return src, ok
+}
+
+// FindPlugin fetches a plugin from the first enabled source that has it, in the order of the
+// configuration, and returns the name of that source
+func (r *Registry) FindPlugin(name string) (string, *types.Plugin, error) {
+	for _, config := range r.config.Sources {
+		source, ok := r.sources[config.Name]
+		if !ok {
+			continue
+		}
+		if plugin, err := source.Get(name); err == nil {
+			return config.Name, plugin, nil
+		}
+	}
+	return "", nil, fmt.Errorf("plugin %s not found in any source", name)
+}
+
+// VerifyPlugin checks the signature of a plugin that was not fetched from a source, such as a
+// manifest uploaded by a user, against the publisher keys of every enabled source
+func (r *Registry) VerifyPlugin(p *types.Plugin) error {
+	var publisherKeys []string
+	for _, source := range r.config.Sources {
+		if source.Enabled {
+			publisherKeys = append(publisherKeys, source.PublisherKeys...)
+		}
+	}
+	return VerifySignature(p, publisherKeys)
+}
+
 // ListAvailablePluginsFromGitHub returns all available plugins from GitHub sources
 func (r *Registry) ListAvailablePluginsFromGitHub() ([]PluginMetadata, error) {
 	result := []PluginMetadata{}
@@ -157,20 +157,9 @@ func (s *GitSource) Search(query string) ([]PluginMetadata, error) {
 	return results, nil
 }
 
-// Verify checks if a plugin is valid and its signature if required
+// Verify checks if a plugin is valid and signed by a trusted publisher of the source
 func (s *GitSource) Verify(p *types.Plugin) error {
-	// Basic validation
-	if p.APIVersion == "" {
-		return fmt.Errorf("apiVersion is required")
-	}
-	if p.Kind == "" {
-		return fmt.Errorf("kind is required")
-	}
-	if p.Metadata.Name == "" {
-		return fmt.Errorf("metadata.name is required")
-	}
-
-	return nil
+	return verifyManifest(s.config, p)
 }
 
 // loadPlugin loads a plugin from a file
@@ -184,6 +173,13 @@ func (s *GitSource) loadPlugin(path string) (*types.Plugin, error) {
 	if err := yaml.Unmarshal(data, &p); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal plugin: %w", err)
 	}
+	p.Manifest = data
+
+	signature, err := os.ReadFile(path + signatureExtension)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read plugin signature: %w", err)
+	}
+	p.Signature = strings.TrimSpace(string(signature))
 
 	return &p, nil
 }
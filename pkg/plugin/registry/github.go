@@ -89,6 +89,7 @@ func (s *GitHubSource) List() ([]PluginMetadata, error) {
 			Updated:     time.Time{},
 			Labels:      map[string]string{},
 			RawYAML:     string(data),
+			Signature:   readZipSignature(zipReader.File, f.Name),
 		})
 		break
 	}
@@ -132,6 +133,8 @@ func (s *GitHubSource) Get(name string) (*types.Plugin, error) {
 					continue
 				}
 				if p.Metadata.Name == name {
+					p.Manifest = data
+					p.Signature = readZipSignature(zipReader.File, f.Name)
 					return &p, nil
 				}
 			}
@@ -144,16 +147,28 @@ func (s *GitHubSource) Search(query string) ([]PluginMetadata, error) {
 	return nil, fmt.Errorf("not implemented yet")
 }
 
+// Verify checks if a plugin is valid and signed by a trusted publisher of the source
 func (s *GitHubSource) Verify(p *types.Plugin) error {
-	// Basic validation
-	if p.APIVersion == "" {
-		return fmt.Errorf("apiVersion is required")
-	}
-	if p.Kind == "" {
-		return fmt.Errorf("kind is required")
-	}
-	if p.Metadata.Name == "" {
-		return fmt.Errorf("metadata.name is required")
+	return verifyManifest(s.config, p)
+}
+
+// readZipSignature returns the content of the detached signature file of a manifest in the
+// repository archive, or an empty string when the manifest is not signed
+func readZipSignature(files []*zip.File, manifestName string) string {
+	for _, f := range files {
+		if f.Name != manifestName+signatureExtension {
+			continue
+		}
+		file, err := f.Open()
+		if err != nil {
+			return ""
+		}
+		defer file.Close()
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
 	}
-	return nil
+	return ""
 }
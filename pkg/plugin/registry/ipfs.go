@@ -82,7 +82,21 @@ func (s *IPFSSource) Get(name string) (*types.Plugin, error) {
 	for _, f := range files {
 		if strings.HasPrefix(f.Name, name+".") &&
 			(strings.HasSuffix(f.Name, ".yaml") || strings.HasSuffix(f.Name, ".yml")) {
-			return s.loadPlugin(f.Hash)
+			p, err := s.loadPlugin(f.Hash)
+			if err != nil {
+				return nil, err
+			}
+			for _, sig := range files {
+				if sig.Name == f.Name+signatureExtension {
+					signature, err := s.cat(sig.Hash)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read plugin signature: %w", err)
+					}
+					p.Signature = strings.TrimSpace(string(signature))
+					break
+				}
+			}
+			return p, nil
 		}
 	}
 
@@ -116,24 +130,29 @@ func (s *IPFSSource) Search(query string) ([]PluginMetadata, error) {
 	return results, nil
 }
 
-// Verify checks if a plugin is valid
+// Verify checks if a plugin is valid and signed by a trusted publisher of the source
 func (s *IPFSSource) Verify(p *types.Plugin) error {
-	// Basic validation
-	if p.APIVersion == "" {
-		return fmt.Errorf("apiVersion is required")
-	}
-	if p.Kind == "" {
-		return fmt.Errorf("kind is required")
+	return verifyManifest(s.config, p)
+}
+
+// loadPlugin loads a plugin from IPFS
+func (s *IPFSSource) loadPlugin(cid string) (*types.Plugin, error) {
+	data, err := s.cat(cid)
+	if err != nil {
+		return nil, err
 	}
-	if p.Metadata.Name == "" {
-		return fmt.Errorf("metadata.name is required")
+
+	var p types.Plugin
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plugin: %w", err)
 	}
+	p.Manifest = data
 
-	return nil
+	return &p, nil
 }
 
-// loadPlugin loads a plugin from IPFS
-func (s *IPFSSource) loadPlugin(cid string) (*types.Plugin, error) {
+// cat reads a file from IPFS
+func (s *IPFSSource) cat(cid string) ([]byte, error) {
 	reader, err := s.sh.Cat(cid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read from IPFS: %w", err)
@@ -144,13 +163,7 @@ func (s *IPFSSource) loadPlugin(cid string) (*types.Plugin, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read data: %w", err)
 	}
-
-	var p types.Plugin
-	if err := yaml.Unmarshal(data, &p); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal plugin: %w", err)
-	}
-
-	return &p, nil
+	return data, nil
 }
 
 // getMetadata returns metadata for a plugin
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+	"gopkg.in/yaml.v3"
 )
 
 // MarketplaceSource implements PluginSource for a centralized marketplace
@@ -31,6 +32,10 @@ type MarketplacePlugin struct {
 	Updated     time.Time         `json:"updated"`
 	Labels      map[string]string `json:"labels"`
 	Plugin      types.Plugin      `json:"plugin"`
+	// Manifest is the manifest file as published, the plugin is read from it when it is set
+	Manifest string `json:"manifest,omitempty"`
+	// Signature is the detached signature of the manifest file by its publisher
+	Signature string `json:"signature,omitempty"`
 }
 
 // NewMarketplaceSource creates a new marketplace source
@@ -113,7 +118,20 @@ func (s *MarketplaceSource) Get(name string) (*types.Plugin, error) {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &marketplacePlugin.Plugin, nil
+	// The signature covers the published manifest file, so the plugin installed is the one
+	// decoded from it and not the JSON copy of the response
+	p := &marketplacePlugin.Plugin
+	if marketplacePlugin.Manifest != "" {
+		p = &types.Plugin{}
+		if err := yaml.Unmarshal([]byte(marketplacePlugin.Manifest), p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plugin manifest: %w", err)
+		}
+		p.Manifest = []byte(marketplacePlugin.Manifest)
+	}
+	if marketplacePlugin.Signature != "" {
+		p.Signature = marketplacePlugin.Signature
+	}
+	return p, nil
 }
 
 // Search finds plugins matching the query
@@ -154,17 +172,11 @@ func (s *MarketplaceSource) Search(query string) ([]PluginMetadata, error) {
 	return plugins, nil
 }
 
-// Verify checks if a plugin is valid and verifies its signature if required
+// Verify checks if a plugin is valid and signed by a trusted publisher of the source, and
+// verifies it with the marketplace for trusted sources
 func (s *MarketplaceSource) Verify(p *types.Plugin) error {
-	// Basic validation
-	if p.APIVersion == "" {
-		return fmt.Errorf("apiVersion is required")
-	}
-	if p.Kind == "" {
-		return fmt.Errorf("kind is required")
-	}
-	if p.Metadata.Name == "" {
-		return fmt.Errorf("metadata.name is required")
+	if err := verifyManifest(s.config, p); err != nil {
+		return err
 	}
 
 	// Verify plugin signature with marketplace
@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+)
+
+// signatureExtension is the extension of the detached signature file of a manifest
+const signatureExtension = ".sig"
+
+var (
+	// ErrUnsignedPlugin is returned when a plugin without signature is verified by a source
+	// that requires one
+	ErrUnsignedPlugin = errors.New("plugin is not signed")
+	// ErrInvalidSignature is returned when the signature of a plugin does not match its
+	// manifest for any of the trusted publisher keys
+	ErrInvalidSignature = errors.New("plugin signature does not match any trusted publisher key")
+)
+
+// ParsePublicKey parses a publisher key. Keys are either PEM encoded PKIX public keys
+// (Ed25519 or ECDSA, as written by cosign) or base64 encoded raw Ed25519 keys.
+func ParsePublicKey(key string) (crypto.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		switch pub.(type) {
+		case ed25519.PublicKey, *ecdsa.PublicKey:
+			return pub, nil
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", pub)
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("public key is neither PEM nor base64: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 public key size %d", len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// SignManifest signs the raw bytes of a manifest file with an Ed25519 or ECDSA private key and
+// returns the base64 encoded signature, the content of the detached signature file
+func SignManifest(manifest []byte, key crypto.Signer) (string, error) {
+	var signature []byte
+	var err error
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		signature, err = key.Sign(rand.Reader, manifest, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(manifest)
+		signature, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign manifest: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifySignature checks that the detached signature of a plugin was made over its raw
+// manifest by one of the given publisher keys. It returns ErrUnsignedPlugin for a plugin
+// without signature and ErrInvalidSignature when no key matches.
+func VerifySignature(p *types.Plugin, publisherKeys []string) error {
+	if strings.TrimSpace(p.Signature) == "" {
+		return ErrUnsignedPlugin
+	}
+	if len(p.Manifest) == 0 {
+		return fmt.Errorf("%w: the manifest the signature covers is missing", ErrInvalidSignature)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(p.Signature))
+	if err != nil {
+		return fmt.Errorf("%w: signature is not base64 encoded", ErrInvalidSignature)
+	}
+	digest := sha256.Sum256(p.Manifest)
+	for _, publisherKey := range publisherKeys {
+		pub, err := ParsePublicKey(publisherKey)
+		if err != nil {
+			return fmt.Errorf("invalid publisher key: %w", err)
+		}
+		switch key := pub.(type) {
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, p.Manifest, signature) {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest[:], signature) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// verifyManifest checks the required fields of a plugin and its signature against the
+// publisher keys of the source. A plugin without signature is only accepted from a source
+// that allows unsigned plugins, a signature that does not match is never accepted.
+func verifyManifest(config RegistrySource, p *types.Plugin) error {
+	if p.APIVersion == "" {
+		return fmt.Errorf("apiVersion is required")
+	}
+	if p.Kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+	if p.Metadata.Name == "" {
+		return fmt.Errorf("metadata.name is required")
+	}
+
+	if strings.TrimSpace(p.Signature) == "" {
+		if config.AllowUnsigned {
+			return nil
+		}
+		return fmt.Errorf("plugin %s from source %s: %w", p.Metadata.Name, config.Name, ErrUnsignedPlugin)
+	}
+	if len(config.PublisherKeys) == 0 {
+		return fmt.Errorf("plugin %s from source %s: %w: the source has no publisher keys", p.Metadata.Name, config.Name, ErrInvalidSignature)
+	}
+	if err := VerifySignature(p, config.PublisherKeys); err != nil {
+		return fmt.Errorf("plugin %s from source %s: %w", p.Metadata.Name, config.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+)
+
+const signedManifest = `apiVersion: dev.chainlaunch/v1
+kind: Plugin
+metadata:
+  name: hlf-api
+  version: 1.0.0
+spec:
+  dockerCompose:
+    contents: |
+      services:
+        api:
+          image: ghcr.io/example/hlf-api:1.0.0
+`
+
+func TestPluginSignatures(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "hlf-api.yaml")
+	if err := os.WriteFile(manifestPath, []byte(signedManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalPKIXPublicKey(&ecPriv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecDER}))
+	edKey := base64.StdEncoding.EncodeToString(edPub)
+
+	source, err := NewLocalSource(RegistrySource{Name: "local", URL: dir, PublisherKeys: []string{edKey, ecPEM}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unsigned manifests are refused unless the source allows them
+	plugin, err := source.Get("hlf-api")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := source.Verify(plugin); !errors.Is(err, ErrUnsignedPlugin) {
+		t.Fatalf("unsigned plugin: got %v, want ErrUnsignedPlugin", err)
+	}
+	permissive := *source
+	permissive.config.AllowUnsigned = true
+	if err := permissive.Verify(plugin); err != nil {
+		t.Fatalf("unsigned plugin from a source allowing them: %v", err)
+	}
+
+	for name, sign := range map[string]func() (string, error){
+		"ed25519": func() (string, error) { return SignManifest([]byte(signedManifest), edPriv) },
+		"ecdsa":   func() (string, error) { return SignManifest([]byte(signedManifest), ecPriv) },
+	} {
+		signature, err := sign()
+		if err != nil {
+			t.Fatalf("%s: SignManifest: %v", name, err)
+		}
+		if err := os.WriteFile(manifestPath+signatureExtension, []byte(signature+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		signed, err := source.Get("hlf-api")
+		if err != nil {
+			t.Fatalf("%s: Get: %v", name, err)
+		}
+		if signed.Signature != signature || string(signed.Manifest) != signedManifest {
+			t.Fatalf("%s: detached signature or raw manifest not loaded", name)
+		}
+		if err := source.Verify(signed); err != nil {
+			t.Fatalf("%s: signed plugin: %v", name, err)
+		}
+
+		// A signature that does not match is refused even from a source allowing unsigned plugins
+		if err := os.WriteFile(manifestPath, []byte(signedManifest+"        privileged: true\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tampered, err := permissive.Get("hlf-api")
+		if err != nil {
+			t.Fatalf("%s: Get: %v", name, err)
+		}
+		if err := permissive.Verify(tampered); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: tampered plugin: got %v, want ErrInvalidSignature", name, err)
+		}
+		if err := os.WriteFile(manifestPath, []byte(signedManifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// The signature covers the manifest bytes, not the plugin decoded from them
+		reencoded := *signed
+		reencoded.Manifest = []byte(strings.Replace(signedManifest, "version: 1.0.0", "version: \"1.0.0\"", 1))
+		if err := source.Verify(&reencoded); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: re-encoded manifest: got %v, want ErrInvalidSignature", name, err)
+		}
+		detached := *signed
+		detached.Manifest = nil
+		if err := source.Verify(&detached); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: signature without manifest: got %v, want ErrInvalidSignature", name, err)
+		}
+	}
+
+	// Keys of other publishers do not verify the signature
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := source.Get("hlf-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(signed, []string{base64.StdEncoding.EncodeToString(otherPub)}); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("other publisher: got %v, want ErrInvalidSignature", err)
+	}
+
+	// The signature covers the manifest, not its deployment status
+	signed.DeploymentStatus = &types.DeploymentStatus{Status: "deployed"}
+	if err := source.Verify(signed); err != nil {
+		t.Fatalf("deployed plugin: %v", err)
+	}
+
+	if _, err := ParsePublicKey("not a key"); err == nil {
+		t.Fatalf("ParsePublicKey accepted an invalid key")
+	}
+	if _, err := NewRegistry(&RegistryConfig{Sources: []RegistrySource{
+		{Name: "local", Type: "local", URL: dir, Enabled: true, PublisherKeys: []string{"bad"}},
+	}}); err == nil {
+		t.Fatalf("NewRegistry accepted an invalid publisher key")
+	}
+}
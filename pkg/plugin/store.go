@@ -25,6 +25,13 @@ type Store interface {
 	UpdateDeploymentStatus(ctx context.Context, name string, status string) error
 	GetDeploymentMetadata(ctx context.Context, name string) (map[string]interface{}, error)
 	GetDeploymentStatus(ctx context.Context, name string) (string, error)
+	// Version history of the installed plugins
+	RecordPluginVersion(ctx context.Context, plugin *types.Plugin, source string) error
+	MarkPluginVersionDeployed(ctx context.Context, plugin *types.Plugin, parameters map[string]interface{}) error
+	ListPluginVersions(ctx context.Context, name string) ([]*types.PluginVersion, error)
+	GetCurrentPluginVersion(ctx context.Context, name string) (*types.PluginVersion, error)
+	GetPreviousDeployedPluginVersion(ctx context.Context, name string, versionID int64) (*types.PluginVersion, error)
+	DeletePluginVersionsAfter(ctx context.Context, name string, versionID int64) error
 	ListKeyStoreIDs(ctx context.Context) ([]string, error)
 	ListFabricOrgs(ctx context.Context) ([]string, error)
 	ListKeyStoreOptions(ctx context.Context) ([]types.OptionItem, error)
@@ -335,6 +342,161 @@ func (s *SQLStore) GetDeploymentStatus(ctx context.Context, name string) (string
 	return status.String, nil
 }
 
+// RecordPluginVersion records the current definition of a plugin as its latest version.
+// source is the registry source it was installed from, empty for uploaded manifests.
+func (s *SQLStore) RecordPluginVersion(ctx context.Context, plugin *types.Plugin, source string) error {
+	metadataJSON, err := json.Marshal(plugin.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	specJSON, err := json.Marshal(plugin.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	_, err = s.queries.CreatePluginVersion(ctx, &db.CreatePluginVersionParams{
+		PluginName: plugin.Metadata.Name,
+		Version:    plugin.Metadata.Version,
+		Source:     sql.NullString{String: source, Valid: source != ""},
+		ApiVersion: plugin.APIVersion,
+		Kind:       plugin.Kind,
+		Metadata:   metadataJSON,
+		Spec:       specJSON,
+		Manifest:   plugin.Manifest,
+		Signature:  sql.NullString{String: plugin.Signature, Valid: plugin.Signature != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record plugin version: %w", err)
+	}
+	return nil
+}
+
+// MarkPluginVersionDeployed marks the current version of a plugin as deployed with the given
+// parameters, recording the version first for plugins installed without version history
+func (s *SQLStore) MarkPluginVersionDeployed(ctx context.Context, plugin *types.Plugin, parameters map[string]interface{}) error {
+	current, err := s.queries.GetCurrentPluginVersion(ctx, plugin.Metadata.Name)
+	if err == sql.ErrNoRows {
+		if err := s.RecordPluginVersion(ctx, plugin, ""); err != nil {
+			return err
+		}
+		current, err = s.queries.GetCurrentPluginVersion(ctx, plugin.Metadata.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get current plugin version: %w", err)
+	}
+
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+
+	err = s.queries.MarkPluginVersionDeployed(ctx, &db.MarkPluginVersionDeployedParams{
+		Parameters: parametersJSON,
+		ID:         current.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark plugin version deployed: %w", err)
+	}
+	return nil
+}
+
+// ListPluginVersions retrieves the versions of a plugin, newest first
+func (s *SQLStore) ListPluginVersions(ctx context.Context, name string) ([]*types.PluginVersion, error) {
+	dbVersions, err := s.queries.ListPluginVersions(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugin versions: %w", err)
+	}
+
+	versions := make([]*types.PluginVersion, len(dbVersions))
+	for i, dbVersion := range dbVersions {
+		version, err := toPluginVersion(dbVersion)
+		if err != nil {
+			return nil, err
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
+
+// GetCurrentPluginVersion retrieves the latest version of a plugin, nil if it has none
+func (s *SQLStore) GetCurrentPluginVersion(ctx context.Context, name string) (*types.PluginVersion, error) {
+	dbVersion, err := s.queries.GetCurrentPluginVersion(ctx, name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current plugin version: %w", err)
+	}
+	return toPluginVersion(dbVersion)
+}
+
+// GetPreviousDeployedPluginVersion retrieves the latest deployed version of a plugin older
+// than the given version, nil if there is none
+func (s *SQLStore) GetPreviousDeployedPluginVersion(ctx context.Context, name string, versionID int64) (*types.PluginVersion, error) {
+	dbVersion, err := s.queries.GetPreviousDeployedPluginVersion(ctx, &db.GetPreviousDeployedPluginVersionParams{
+		PluginName: name,
+		ID:         versionID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous plugin version: %w", err)
+	}
+	return toPluginVersion(dbVersion)
+}
+
+// DeletePluginVersionsAfter removes the versions of a plugin newer than the given version
+func (s *SQLStore) DeletePluginVersionsAfter(ctx context.Context, name string, versionID int64) error {
+	err := s.queries.DeletePluginVersionsAfter(ctx, &db.DeletePluginVersionsAfterParams{
+		PluginName: name,
+		ID:         versionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete plugin versions: %w", err)
+	}
+	return nil
+}
+
+// toPluginVersion converts a database plugin version with its plugin definition
+func toPluginVersion(dbVersion *db.PluginVersion) (*types.PluginVersion, error) {
+	var metadata types.Metadata
+	if err := json.Unmarshal(dbVersion.Metadata, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	var spec types.Spec
+	if err := json.Unmarshal(dbVersion.Spec, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal(dbVersion.Parameters, &parameters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %w", err)
+	}
+
+	version := &types.PluginVersion{
+		ID:         dbVersion.ID,
+		Version:    dbVersion.Version,
+		Source:     dbVersion.Source.String,
+		Parameters: parameters,
+		CreatedAt:  dbVersion.CreatedAt,
+		Plugin: &types.Plugin{
+			APIVersion: dbVersion.ApiVersion,
+			Kind:       dbVersion.Kind,
+			Metadata:   metadata,
+			Spec:       spec,
+			Signature:  dbVersion.Signature.String,
+			Manifest:   dbVersion.Manifest,
+		},
+	}
+	if dbVersion.DeployedAt.Valid {
+		version.DeployedAt = &dbVersion.DeployedAt.Time
+	}
+	return version, nil
+}
+
 // ListKeyStoreIDs fetches valid key IDs for x-source validation
 func (s *SQLStore) ListKeyStoreIDs(ctx context.Context) ([]string, error) {
 	// TODO: Query your DB for available key IDs
@@ -552,6 +714,30 @@ func (s *RegistryStore) GetDeploymentStatus(ctx context.Context, name string) (s
 	return s.SQLStore.GetDeploymentStatus(ctx, name)
 }
 
+func (s *RegistryStore) RecordPluginVersion(ctx context.Context, plugin *types.Plugin, source string) error {
+	return s.SQLStore.RecordPluginVersion(ctx, plugin, source)
+}
+
+func (s *RegistryStore) MarkPluginVersionDeployed(ctx context.Context, plugin *types.Plugin, parameters map[string]interface{}) error {
+	return s.SQLStore.MarkPluginVersionDeployed(ctx, plugin, parameters)
+}
+
+func (s *RegistryStore) ListPluginVersions(ctx context.Context, name string) ([]*types.PluginVersion, error) {
+	return s.SQLStore.ListPluginVersions(ctx, name)
+}
+
+func (s *RegistryStore) GetCurrentPluginVersion(ctx context.Context, name string) (*types.PluginVersion, error) {
+	return s.SQLStore.GetCurrentPluginVersion(ctx, name)
+}
+
+func (s *RegistryStore) GetPreviousDeployedPluginVersion(ctx context.Context, name string, versionID int64) (*types.PluginVersion, error) {
+	return s.SQLStore.GetPreviousDeployedPluginVersion(ctx, name, versionID)
+}
+
+func (s *RegistryStore) DeletePluginVersionsAfter(ctx context.Context, name string, versionID int64) error {
+	return s.SQLStore.DeletePluginVersionsAfter(ctx, name, versionID)
+}
+
 func (s *RegistryStore) GetFabricKeyDetails(ctx context.Context, keyIdString string, orgIdString string) (*types.FabricKeyDetails, error) {
 	return s.SQLStore.GetFabricKeyDetails(ctx, keyIdString, orgIdString)
 }
@@ -0,0 +1,409 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/monitoring"
+	"github.com/chainlaunch/chainlaunch/pkg/notifications"
+	plugintypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// SupervisorConfig holds the configuration of the plugin supervisor
+type SupervisorConfig struct {
+	// PollInterval is how often the deployed plugins are checked
+	PollInterval time.Duration
+	// InitialBackoff is the delay before the second restart of an unhealthy plugin, the first
+	// restart happens as soon as the plugin is found unhealthy
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between restarts, which doubles after every restart
+	MaxBackoff time.Duration
+	// MaxRestarts is the number of restarts after which the supervisor gives up until the
+	// plugin is healthy again or redeployed
+	MaxRestarts int
+}
+
+// DefaultSupervisorConfig returns the default configuration of the plugin supervisor
+func DefaultSupervisorConfig() *SupervisorConfig {
+	return &SupervisorConfig{
+		PollInterval:   15 * time.Second,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+		MaxRestarts:    5,
+	}
+}
+
+// pluginHealth is the supervision state of a deployed plugin
+type pluginHealth struct {
+	unhealthy   bool
+	gaveUp      bool
+	restarts    int
+	backoff     time.Duration
+	nextRestart time.Time
+	lastRestart time.Time
+	// failures and lastRun are indexed like the health checks of the plugin spec
+	failures map[int]int
+	lastRun  map[int]time.Time
+	lastErr  map[int]error
+}
+
+// pluginRuntime gives access to the docker-compose projects of the deployed plugins, it is
+// implemented by the PluginManager
+type pluginRuntime interface {
+	GetContainerStates(ctx context.Context, plugin *plugintypes.Plugin) ([]api.ContainerSummary, error)
+	RestartPluginServices(ctx context.Context, plugin *plugintypes.Plugin, services []string) error
+	ResumePlugin(ctx context.Context, plugin *plugintypes.Plugin, store Store) error
+}
+
+// Supervisor polls the containers and the declared health checks of the deployed plugins and
+// restarts unhealthy services with an exponential backoff
+type Supervisor struct {
+	runtime         pluginRuntime
+	store           Store
+	notificationSvc notifications.Service
+	monitoringSvc   monitoring.Service
+	logger          *logger.Logger
+	config          *SupervisorConfig
+	httpClient      *http.Client
+	now             func() time.Time
+	states          map[string]*pluginHealth
+	statesMutex     sync.Mutex
+	stopChan        chan struct{}
+	waitGroup       sync.WaitGroup
+}
+
+// NewSupervisor creates a new plugin supervisor. The monitoring service is optional, when set
+// the supervisor reports the health of the plugins to it.
+func NewSupervisor(pm *PluginManager, store Store, notificationSvc notifications.Service, monitoringSvc monitoring.Service, logger *logger.Logger, config *SupervisorConfig) *Supervisor {
+	if config == nil {
+		config = DefaultSupervisorConfig()
+	}
+
+	return &Supervisor{
+		runtime:         pm,
+		store:           store,
+		notificationSvc: notificationSvc,
+		monitoringSvc:   monitoringSvc,
+		logger:          logger,
+		config:          config,
+		httpClient:      &http.Client{},
+		now:             time.Now,
+		states:          make(map[string]*pluginHealth),
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins supervising the deployed plugins
+func (s *Supervisor) Start(ctx context.Context) {
+	s.waitGroup.Add(1)
+	go func() {
+		defer s.waitGroup.Done()
+
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkPlugins(ctx)
+			}
+		}
+	}()
+}
+
+// Stop stops supervising the deployed plugins
+func (s *Supervisor) Stop() {
+	close(s.stopChan)
+	s.waitGroup.Wait()
+}
+
+// checkPlugins checks every deployed plugin and forgets the plugins that are no longer deployed
+func (s *Supervisor) checkPlugins(ctx context.Context) {
+	plugins, err := s.store.ListPlugins(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to list plugins: %v", err)
+		return
+	}
+
+	deployed := make(map[string]bool)
+	for _, plugin := range plugins {
+		if plugin.DeploymentStatus == nil || plugin.DeploymentStatus.Status != "deployed" {
+			continue
+		}
+		deployed[plugin.Metadata.Name] = true
+		s.checkPlugin(ctx, plugin)
+	}
+
+	s.statesMutex.Lock()
+	defer s.statesMutex.Unlock()
+	for name := range s.states {
+		if deployed[name] {
+			continue
+		}
+		delete(s.states, name)
+		if s.monitoringSvc != nil {
+			s.monitoringSvc.RemovePlugin(name)
+		}
+	}
+}
+
+// checkPlugin checks a deployed plugin and restarts its unhealthy services
+func (s *Supervisor) checkPlugin(ctx context.Context, plugin *plugintypes.Plugin) {
+	name := plugin.Metadata.Name
+
+	s.statesMutex.Lock()
+	state, ok := s.states[name]
+	if !ok {
+		state = &pluginHealth{
+			failures: make(map[int]int),
+			lastRun:  make(map[int]time.Time),
+			lastErr:  make(map[int]error),
+		}
+		s.states[name] = state
+	}
+	s.statesMutex.Unlock()
+
+	containers, err := s.runtime.GetContainerStates(ctx, plugin)
+	if err != nil {
+		// Docker itself is unreachable, there is nothing to restart
+		s.logger.Warnf("Failed to get containers of plugin %s: %v", name, err)
+		return
+	}
+
+	// Services that exited successfully are one-off jobs, such as migrations, and are not restarted
+	unhealthy := make(map[string]string)
+	for _, container := range containers {
+		switch {
+		case container.State == "exited" && container.ExitCode == 0:
+		case container.State != "running":
+			unhealthy[container.Service] = fmt.Sprintf("container %s is %s (exit code %d)", container.Name, container.State, container.ExitCode)
+		case container.Health == "unhealthy":
+			unhealthy[container.Service] = fmt.Sprintf("container %s is unhealthy", container.Name)
+		}
+	}
+	missing := len(containers) == 0
+	if missing {
+		unhealthy[name] = "no containers found"
+	}
+
+	for service, checkErr := range s.runHealthChecks(ctx, plugin, state) {
+		if _, ok := unhealthy[service]; !ok {
+			unhealthy[service] = checkErr.Error()
+		}
+	}
+
+	now := s.now()
+	if len(unhealthy) == 0 {
+		if state.unhealthy {
+			s.logger.Infof("Plugin %s is healthy again after %d restarts", name, state.restarts)
+			s.notify(ctx, notifications.PluginHealthData{
+				PluginName:   name,
+				Status:       notifications.PluginHealthRecovered,
+				RestartCount: state.restarts,
+				DetectedAt:   now,
+			})
+		}
+		state.unhealthy = false
+		state.gaveUp = false
+		state.restarts = 0
+		state.backoff = 0
+		s.report(name, monitoring.PluginStatusHealthy, nil, state, nil)
+		return
+	}
+
+	services := make([]string, 0, len(unhealthy))
+	messages := make([]string, 0, len(unhealthy))
+	for service := range unhealthy {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		messages = append(messages, fmt.Sprintf("%s: %s", service, unhealthy[service]))
+	}
+	healthErr := errors.New(strings.Join(messages, "; "))
+
+	if !state.unhealthy {
+		state.unhealthy = true
+		state.nextRestart = now
+		s.logger.Warnf("Plugin %s is unhealthy: %v", name, healthErr)
+		s.notify(ctx, notifications.PluginHealthData{
+			PluginName: name,
+			Status:     notifications.PluginHealthUnhealthy,
+			Services:   services,
+			Error:      healthErr.Error(),
+			DetectedAt: now,
+		})
+	}
+
+	if state.gaveUp {
+		s.report(name, monitoring.PluginStatusFailed, services, state, healthErr)
+		return
+	}
+	if state.restarts >= s.config.MaxRestarts {
+		state.gaveUp = true
+		s.logger.Errorf("Giving up on plugin %s after %d restarts: %v", name, state.restarts, healthErr)
+		s.notify(ctx, notifications.PluginHealthData{
+			PluginName:   name,
+			Status:       notifications.PluginHealthGaveUp,
+			Services:     services,
+			Error:        healthErr.Error(),
+			RestartCount: state.restarts,
+			DetectedAt:   now,
+		})
+		s.report(name, monitoring.PluginStatusFailed, services, state, healthErr)
+		return
+	}
+	if now.Before(state.nextRestart) {
+		s.report(name, monitoring.PluginStatusUnhealthy, services, state, healthErr)
+		return
+	}
+
+	// The plugin may have been stopped since it was listed
+	if status, err := s.store.GetDeploymentStatus(ctx, name); err != nil || status != "deployed" {
+		return
+	}
+
+	// A project without containers was removed outside of ChainLaunch and is brought up again
+	if missing {
+		err = s.runtime.ResumePlugin(ctx, plugin, s.store)
+	} else {
+		err = s.runtime.RestartPluginServices(ctx, plugin, services)
+	}
+	if err != nil {
+		s.logger.Errorf("Failed to restart plugin %s: %v", name, err)
+	} else {
+		s.logger.Infof("Restarted services %s of plugin %s", strings.Join(services, ", "), name)
+	}
+
+	state.restarts++
+	state.lastRestart = now
+	if state.backoff == 0 {
+		state.backoff = s.config.InitialBackoff
+	} else {
+		state.backoff *= 2
+	}
+	if state.backoff > s.config.MaxBackoff {
+		state.backoff = s.config.MaxBackoff
+	}
+	// The failures of the health checks are kept: a restarted service stays unhealthy until
+	// one of its checks passes, so that the backoff applies to failing checks like to crashes
+	state.nextRestart = now.Add(state.backoff)
+
+	s.report(name, monitoring.PluginStatusUnhealthy, services, state, healthErr)
+}
+
+// runHealthChecks runs the health checks of the plugin that are due and returns the services
+// whose checks failed at least their failure threshold in a row
+func (s *Supervisor) runHealthChecks(ctx context.Context, plugin *plugintypes.Plugin, state *pluginHealth) map[string]error {
+	failed := make(map[string]error)
+	if len(plugin.Spec.HealthChecks) == 0 {
+		return failed
+	}
+
+	var parameters map[string]interface{}
+	deploymentMetadata, err := s.store.GetDeploymentMetadata(ctx, plugin.Metadata.Name)
+	if err != nil {
+		s.logger.Warnf("Failed to get deployment metadata of plugin %s: %v", plugin.Metadata.Name, err)
+	} else {
+		parameters, _ = deploymentMetadata["parameters"].(map[string]interface{})
+	}
+
+	now := s.now()
+	for i := range plugin.Spec.HealthChecks {
+		check := &plugin.Spec.HealthChecks[i]
+		if now.Sub(state.lastRun[i]) >= check.IntervalDuration() {
+			state.lastRun[i] = now
+			if err := s.probe(ctx, check, parameters); err != nil {
+				state.failures[i]++
+				state.lastErr[i] = err
+			} else {
+				state.failures[i] = 0
+				delete(state.lastErr, i)
+			}
+		}
+		if state.failures[i] >= check.Threshold() {
+			failed[check.Service] = fmt.Errorf("%s check failed %d times: %w", check.Type, state.failures[i], state.lastErr[i])
+		}
+	}
+	return failed
+}
+
+// probe runs a single health check
+func (s *Supervisor) probe(ctx context.Context, check *plugintypes.HealthCheck, parameters map[string]interface{}) error {
+	target, err := check.RenderTarget(parameters)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.TimeoutDuration())
+	defer cancel()
+
+	if check.Type == plugintypes.HealthCheckTCP {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("invalid url %s: %w", target, err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if check.ExpectedStatus != 0 {
+		if resp.StatusCode != check.ExpectedStatus {
+			return fmt.Errorf("unexpected status %d, expected %d", resp.StatusCode, check.ExpectedStatus)
+		}
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notify sends a plugin health notification
+func (s *Supervisor) notify(ctx context.Context, data notifications.PluginHealthData) {
+	if s.notificationSvc == nil {
+		return
+	}
+	if err := s.notificationSvc.SendPluginHealthNotification(ctx, data); err != nil {
+		s.logger.Errorf("Failed to send plugin health notification: %v", err)
+	}
+}
+
+// report records the health of a plugin in the monitoring service
+func (s *Supervisor) report(name string, status monitoring.PluginStatus, services []string, state *pluginHealth, err error) {
+	if s.monitoringSvc == nil {
+		return
+	}
+	s.monitoringSvc.UpdatePluginStatus(&monitoring.PluginCheck{
+		Name:              name,
+		Status:            status,
+		UnhealthyServices: services,
+		RestartCount:      state.restarts,
+		LastRestart:       state.lastRestart,
+		Error:             err,
+		Timestamp:         s.now(),
+	})
+}
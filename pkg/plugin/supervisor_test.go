@@ -0,0 +1,287 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/monitoring"
+	"github.com/chainlaunch/chainlaunch/pkg/notifications"
+	plugintypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// fakeRuntime returns the configured containers and records the restarts
+type fakeRuntime struct {
+	containers []api.ContainerSummary
+	err        error
+	restarted  [][]string
+	resumed    int
+}
+
+func (r *fakeRuntime) GetContainerStates(ctx context.Context, plugin *plugintypes.Plugin) ([]api.ContainerSummary, error) {
+	return r.containers, r.err
+}
+
+func (r *fakeRuntime) RestartPluginServices(ctx context.Context, plugin *plugintypes.Plugin, services []string) error {
+	r.restarted = append(r.restarted, services)
+	return nil
+}
+
+func (r *fakeRuntime) ResumePlugin(ctx context.Context, plugin *plugintypes.Plugin, store Store) error {
+	r.resumed++
+	return nil
+}
+
+// fakeSupervisorStore implements the deployment lookups of the supervisor
+type fakeSupervisorStore struct {
+	Store
+	status     string
+	parameters map[string]interface{}
+}
+
+func (s *fakeSupervisorStore) GetDeploymentStatus(ctx context.Context, name string) (string, error) {
+	return s.status, nil
+}
+
+func (s *fakeSupervisorStore) GetDeploymentMetadata(ctx context.Context, name string) (map[string]interface{}, error) {
+	return map[string]interface{}{"parameters": s.parameters}, nil
+}
+
+// fakeNotifier records the plugin health notifications
+type fakeNotifier struct {
+	notifications.Service
+	sent []notifications.PluginHealthData
+}
+
+func (n *fakeNotifier) SendPluginHealthNotification(ctx context.Context, data notifications.PluginHealthData) error {
+	n.sent = append(n.sent, data)
+	return nil
+}
+
+// fakeMonitoring records the reported plugin health
+type fakeMonitoring struct {
+	monitoring.Service
+	checks []*monitoring.PluginCheck
+}
+
+func (m *fakeMonitoring) UpdatePluginStatus(check *monitoring.PluginCheck) {
+	m.checks = append(m.checks, check)
+}
+
+func (m *fakeMonitoring) RemovePlugin(name string) {}
+
+func runningContainer(service string) api.ContainerSummary {
+	return api.ContainerSummary{Name: "hlf-api-" + service + "-1", Service: service, State: "running"}
+}
+
+func exitedContainer(service string, exitCode int) api.ContainerSummary {
+	return api.ContainerSummary{Name: "hlf-api-" + service + "-1", Service: service, State: "exited", ExitCode: exitCode}
+}
+
+// supervisorStep is a poll of the supervisor, after moving the clock by after
+type supervisorStep struct {
+	after      time.Duration
+	containers []api.ContainerSummary
+	status     string // deployment status, deployed when empty
+	// checkStatus is the HTTP status returned to the health checks
+	checkStatus int
+	// restarted are the services restarted by the poll, nil when none are
+	restarted []string
+	resumed   bool
+	// notified is the status of the notification sent by the poll, empty when none is
+	notified string
+	health   monitoring.PluginStatus
+	restarts int
+	backoff  time.Duration
+}
+
+func TestSupervisorCheckPlugin(t *testing.T) {
+	var checkStatus atomic.Int32
+	checkServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(checkStatus.Load()))
+	}))
+	defer checkServer.Close()
+
+	config := &SupervisorConfig{
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     60 * time.Second,
+		MaxRestarts:    5,
+	}
+	crashed := []api.ContainerSummary{runningContainer("db"), exitedContainer("api", 1)}
+	healthy := []api.ContainerSummary{runningContainer("db"), runningContainer("api")}
+
+	tests := []struct {
+		name         string
+		maxRestarts  int
+		healthChecks []plugintypes.HealthCheck
+		steps        []supervisorStep
+	}{
+		{
+			name: "healthy plugin",
+			steps: []supervisorStep{
+				{containers: healthy, health: monitoring.PluginStatusHealthy},
+			},
+		},
+		{
+			name: "exited one-off job",
+			steps: []supervisorStep{
+				{containers: []api.ContainerSummary{runningContainer("api"), exitedContainer("migrate", 0)}, health: monitoring.PluginStatusHealthy},
+			},
+		},
+		{
+			name: "unhealthy container",
+			steps: []supervisorStep{
+				{
+					containers: []api.ContainerSummary{runningContainer("db"), {Name: "hlf-api-api-1", Service: "api", State: "running", Health: "unhealthy"}},
+					restarted:  []string{"api"}, notified: notifications.PluginHealthUnhealthy,
+					health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second,
+				},
+			},
+		},
+		{
+			name: "backoff doubles up to the cap and resets on recovery",
+			steps: []supervisorStep{
+				{containers: crashed, restarted: []string{"api"}, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				{after: 5 * time.Second, containers: crashed, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				{after: 5 * time.Second, containers: crashed, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 2, backoff: 20 * time.Second},
+				{after: 20 * time.Second, containers: crashed, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 3, backoff: 40 * time.Second},
+				{after: 40 * time.Second, containers: crashed, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 4, backoff: 60 * time.Second},
+				{after: 60 * time.Second, containers: crashed, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 5, backoff: 60 * time.Second},
+				{after: time.Second, containers: healthy, notified: notifications.PluginHealthRecovered, health: monitoring.PluginStatusHealthy},
+				{after: time.Second, containers: crashed, restarted: []string{"api"}, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+			},
+		},
+		{
+			name:        "gives up after the maximum restarts until recovery",
+			maxRestarts: 2,
+			steps: []supervisorStep{
+				{containers: crashed, restarted: []string{"api"}, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				{after: 10 * time.Second, containers: crashed, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 2, backoff: 20 * time.Second},
+				{after: 20 * time.Second, containers: crashed, notified: notifications.PluginHealthGaveUp, health: monitoring.PluginStatusFailed, restarts: 2, backoff: 20 * time.Second},
+				{after: time.Hour, containers: crashed, health: monitoring.PluginStatusFailed, restarts: 2, backoff: 20 * time.Second},
+				{after: time.Second, containers: healthy, notified: notifications.PluginHealthRecovered, health: monitoring.PluginStatusHealthy},
+				{after: time.Second, containers: crashed, restarted: []string{"api"}, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+			},
+		},
+		{
+			name: "missing containers resume the plugin",
+			steps: []supervisorStep{
+				{resumed: true, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				{after: 10 * time.Second, containers: healthy, notified: notifications.PluginHealthRecovered, health: monitoring.PluginStatusHealthy},
+			},
+		},
+		{
+			name: "plugin stopped since it was listed",
+			steps: []supervisorStep{
+				{containers: crashed, status: "stopped", notified: notifications.PluginHealthUnhealthy},
+			},
+		},
+		{
+			name: "failing health check",
+			healthChecks: []plugintypes.HealthCheck{
+				{Service: "api", Type: plugintypes.HealthCheckHTTP, URL: checkServer.URL, Interval: "5s", FailureThreshold: 2},
+			},
+			steps: []supervisorStep{
+				{containers: healthy, checkStatus: http.StatusServiceUnavailable, health: monitoring.PluginStatusHealthy},
+				// The check is not due yet
+				{after: time.Second, containers: healthy, checkStatus: http.StatusServiceUnavailable, health: monitoring.PluginStatusHealthy},
+				{after: 5 * time.Second, containers: healthy, checkStatus: http.StatusServiceUnavailable, restarted: []string{"api"}, notified: notifications.PluginHealthUnhealthy, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				// The restarted service stays unhealthy until its check passes
+				{after: 5 * time.Second, containers: healthy, checkStatus: http.StatusServiceUnavailable, health: monitoring.PluginStatusUnhealthy, restarts: 1, backoff: 10 * time.Second},
+				{after: 5 * time.Second, containers: healthy, checkStatus: http.StatusServiceUnavailable, restarted: []string{"api"}, health: monitoring.PluginStatusUnhealthy, restarts: 2, backoff: 20 * time.Second},
+				{after: 5 * time.Second, containers: healthy, checkStatus: http.StatusOK, notified: notifications.PluginHealthRecovered, health: monitoring.PluginStatusHealthy},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := &fakeRuntime{}
+			store := &fakeSupervisorStore{}
+			notifier := &fakeNotifier{}
+			monitor := &fakeMonitoring{}
+			cfg := *config
+			if tt.maxRestarts != 0 {
+				cfg.MaxRestarts = tt.maxRestarts
+			}
+			s := NewSupervisor(nil, store, notifier, monitor, logger.NewDefault(), &cfg)
+			s.runtime = runtime
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			s.now = func() time.Time { return now }
+
+			plugin := &plugintypes.Plugin{
+				Metadata: plugintypes.Metadata{Name: "hlf-api"},
+				Spec:     plugintypes.Spec{HealthChecks: tt.healthChecks},
+			}
+			for i, step := range tt.steps {
+				now = now.Add(step.after)
+				runtime.containers = step.containers
+				runtime.restarted = nil
+				runtime.resumed = 0
+				checkStatus.Store(int32(step.checkStatus))
+				store.status = step.status
+				if store.status == "" {
+					store.status = "deployed"
+				}
+				notifier.sent = nil
+				monitor.checks = nil
+
+				s.checkPlugin(context.Background(), plugin)
+
+				if step.restarted == nil && len(runtime.restarted) != 0 {
+					t.Errorf("step %d: restarted %v, want no restart", i, runtime.restarted)
+				}
+				if step.restarted != nil && (len(runtime.restarted) != 1 || !reflect.DeepEqual(runtime.restarted[0], step.restarted)) {
+					t.Errorf("step %d: restarted %v, want %v", i, runtime.restarted, step.restarted)
+				}
+				if got := runtime.resumed == 1; got != step.resumed {
+					t.Errorf("step %d: resumed %d times, want resumed %v", i, runtime.resumed, step.resumed)
+				}
+
+				var notified string
+				if len(notifier.sent) > 1 {
+					t.Errorf("step %d: sent %d notifications, want at most one", i, len(notifier.sent))
+				} else if len(notifier.sent) == 1 {
+					notified = notifier.sent[0].Status
+				}
+				if notified != step.notified {
+					t.Errorf("step %d: notified %q, want %q", i, notified, step.notified)
+				}
+
+				var health monitoring.PluginStatus
+				if len(monitor.checks) > 0 {
+					health = monitor.checks[len(monitor.checks)-1].Status
+				}
+				if health != step.health {
+					t.Errorf("step %d: reported %q, want %q", i, health, step.health)
+				}
+
+				state := s.states["hlf-api"]
+				if state.restarts != step.restarts || state.backoff != step.backoff {
+					t.Errorf("step %d: %d restarts with backoff %v, want %d with %v", i, state.restarts, state.backoff, step.restarts, step.backoff)
+				}
+			}
+		})
+	}
+}
+
+func TestSupervisorCheckPluginDockerUnreachable(t *testing.T) {
+	runtime := &fakeRuntime{err: errors.New("cannot connect to the docker daemon")}
+	notifier := &fakeNotifier{}
+	monitor := &fakeMonitoring{}
+	s := NewSupervisor(nil, &fakeSupervisorStore{status: "deployed"}, notifier, monitor, logger.NewDefault(), nil)
+	s.runtime = runtime
+
+	s.checkPlugin(context.Background(), &plugintypes.Plugin{Metadata: plugintypes.Metadata{Name: "hlf-api"}})
+
+	if len(runtime.restarted) != 0 || runtime.resumed != 0 || len(notifier.sent) != 0 || len(monitor.checks) != 0 {
+		t.Fatalf("unreachable docker: restarted %v, resumed %d, notified %v, reported %v", runtime.restarted, runtime.resumed, notifier.sent, monitor.checks)
+	}
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// Health check types
+const (
+	HealthCheckHTTP = "http"
+	HealthCheckTCP  = "tcp"
+)
+
+// Defaults of the optional health check fields
+const (
+	DefaultHealthCheckInterval         = 30 * time.Second
+	DefaultHealthCheckTimeout          = 5 * time.Second
+	DefaultHealthCheckFailureThreshold = 3
+)
+
+// HealthCheck declares a probe the plugin supervisor runs against a service of a deployed
+// plugin, on top of the container state it always watches. The URL and address are Go
+// templates over the deployment parameters, like the docker-compose contents, and are dialed
+// from the ChainLaunch host, so they target the published ports of the service.
+type HealthCheck struct {
+	// Service is the docker-compose service restarted when the check fails
+	Service string `json:"service" yaml:"service"`
+	// Type is http or tcp
+	Type string `json:"type" yaml:"type"`
+	// URL is requested by http checks, which pass on a 2xx or 3xx status unless
+	// ExpectedStatus is set
+	URL            string `json:"url,omitempty" yaml:"url,omitempty"`
+	ExpectedStatus int    `json:"expectedStatus,omitempty" yaml:"expectedStatus,omitempty"`
+	// Address is the host:port dialed by tcp checks
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Interval and Timeout are durations such as 30s, 30s and 5s by default
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout  string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the service is
+	// unhealthy, 3 by default
+	FailureThreshold int `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+}
+
+// Validate checks the fields of a health check
+func (h *HealthCheck) Validate() error {
+	if h.Service == "" {
+		return fmt.Errorf("service is required")
+	}
+	switch h.Type {
+	case HealthCheckHTTP:
+		if h.URL == "" {
+			return fmt.Errorf("url is required for http checks")
+		}
+	case HealthCheckTCP:
+		if h.Address == "" {
+			return fmt.Errorf("address is required for tcp checks")
+		}
+	default:
+		return fmt.Errorf("unsupported type %q, must be http or tcp", h.Type)
+	}
+	for field, value := range map[string]string{"interval": h.Interval, "timeout": h.Timeout} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid %s %q", field, value)
+		}
+	}
+	if h.FailureThreshold < 0 {
+		return fmt.Errorf("failureThreshold must not be negative")
+	}
+	if _, err := template.New("health-check").Parse(h.Target()); err != nil {
+		return fmt.Errorf("invalid target template: %w", err)
+	}
+	return nil
+}
+
+// Target returns the URL of an http check or the address of a tcp check
+func (h *HealthCheck) Target() string {
+	if h.Type == HealthCheckTCP {
+		return h.Address
+	}
+	return h.URL
+}
+
+// RenderTarget renders the target of the check with the deployment parameters of the plugin
+func (h *HealthCheck) RenderTarget(parameters map[string]interface{}) (string, error) {
+	tmpl, err := template.New("health-check").Parse(h.Target())
+	if err != nil {
+		return "", fmt.Errorf("failed to parse health check target: %w", err)
+	}
+	var target bytes.Buffer
+	if err := tmpl.Execute(&target, map[string]interface{}{"parameters": parameters}); err != nil {
+		return "", fmt.Errorf("failed to render health check target: %w", err)
+	}
+	return target.String(), nil
+}
+
+// IntervalDuration returns the interval between two runs of the check
+func (h *HealthCheck) IntervalDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Interval); err == nil && d > 0 {
+		return d
+	}
+	return DefaultHealthCheckInterval
+}
+
+// TimeoutDuration returns the timeout of a run of the check
+func (h *HealthCheck) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultHealthCheckTimeout
+}
+
+// Threshold returns the number of consecutive failures after which the service is unhealthy
+func (h *HealthCheck) Threshold() int {
+	if h.FailureThreshold > 0 {
+		return h.FailureThreshold
+	}
+	return DefaultHealthCheckFailureThreshold
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHealthChecks(t *testing.T) {
+	var spec Spec
+	err := yaml.Unmarshal([]byte(`
+healthChecks:
+  - service: api
+    type: http
+    url: http://localhost:{{.parameters.API_PORT}}/health
+    interval: 10s
+  - service: db
+    type: tcp
+    address: localhost:5432
+    timeout: 2s
+    failureThreshold: 5
+`), &spec)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(spec.HealthChecks) != 2 {
+		t.Fatalf("expected 2 health checks, got %d", len(spec.HealthChecks))
+	}
+
+	api, db := spec.HealthChecks[0], spec.HealthChecks[1]
+	for _, check := range spec.HealthChecks {
+		if err := check.Validate(); err != nil {
+			t.Fatalf("Validate %s: %v", check.Service, err)
+		}
+	}
+	if api.IntervalDuration() != 10*time.Second || api.TimeoutDuration() != DefaultHealthCheckTimeout || api.Threshold() != DefaultHealthCheckFailureThreshold {
+		t.Errorf("api defaults: interval %s timeout %s threshold %d", api.IntervalDuration(), api.TimeoutDuration(), api.Threshold())
+	}
+	if db.IntervalDuration() != DefaultHealthCheckInterval || db.TimeoutDuration() != 2*time.Second || db.Threshold() != 5 {
+		t.Errorf("db settings: interval %s timeout %s threshold %d", db.IntervalDuration(), db.TimeoutDuration(), db.Threshold())
+	}
+
+	target, err := api.RenderTarget(map[string]interface{}{"API_PORT": "8080"})
+	if err != nil || target != "http://localhost:8080/health" {
+		t.Errorf("RenderTarget: got %q, err=%v", target, err)
+	}
+	if target, err := db.RenderTarget(nil); err != nil || target != "localhost:5432" {
+		t.Errorf("RenderTarget tcp: got %q, err=%v", target, err)
+	}
+
+	for name, check := range map[string]HealthCheck{
+		"missing service":  {Type: HealthCheckHTTP, URL: "http://localhost"},
+		"unknown type":     {Service: "api", Type: "grpc"},
+		"http without url": {Service: "api", Type: HealthCheckHTTP},
+		"tcp without addr": {Service: "api", Type: HealthCheckTCP},
+		"invalid interval": {Service: "api", Type: HealthCheckTCP, Address: "localhost:80", Interval: "often"},
+		"invalid template": {Service: "api", Type: HealthCheckHTTP, URL: "http://{{.parameters"},
+	} {
+		if err := check.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
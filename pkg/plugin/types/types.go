@@ -13,6 +13,12 @@ type Plugin struct {
 	Metadata         Metadata          `json:"metadata" yaml:"metadata"`
 	Spec             Spec              `json:"spec" yaml:"spec"`
 	DeploymentStatus *DeploymentStatus `json:"deploymentStatus,omitempty" yaml:"deploymentStatus,omitempty"`
+	// Signature is the base64 encoded detached signature of the manifest by its publisher.
+	// Registries read it from the <manifest>.sig file next to the manifest.
+	Signature string `json:"signature,omitempty" yaml:"-"`
+	// Manifest is the manifest exactly as it was fetched or uploaded, the bytes the signature
+	// covers. It is kept with the recorded versions of the plugin to verify them again.
+	Manifest []byte `json:"-" yaml:"-"`
 }
 
 // Metadata contains plugin metadata
@@ -31,6 +37,8 @@ type Spec struct {
 	DockerCompose DockerCompose `json:"dockerCompose" yaml:"dockerCompose"`
 	Parameters    Parameters    `json:"parameters" yaml:"parameters"`
 	Documentation Documentation `json:"documentation" yaml:"documentation"`
+	// HealthChecks are the probes the supervisor runs against the services of the deployed plugin
+	HealthChecks []HealthCheck `json:"healthChecks,omitempty" yaml:"healthChecks,omitempty"`
 }
 
 // DockerCompose contains the docker-compose configuration
@@ -42,11 +50,14 @@ type DockerCompose struct {
 type XSourceType string
 
 const (
-	XSourceFabricPeer    XSourceType = "fabric-peer"
-	XSourceKey           XSourceType = "key"
-	XSourceFabricOrg     XSourceType = "fabric-org"
-	XSourceFabricNetwork XSourceType = "fabric-network"
-	XSourceFabricKey     XSourceType = "fabric-key"
+	XSourceFabricPeer      XSourceType = "fabric-peer"
+	XSourceKey             XSourceType = "key"
+	XSourceFabricOrg       XSourceType = "fabric-org"
+	XSourceFabricNetwork   XSourceType = "fabric-network"
+	XSourceFabricKey       XSourceType = "fabric-key"
+	XSourceBesuNode        XSourceType = "besu-node"
+	XSourceBesuNetwork     XSourceType = "besu-network"
+	XSourcePostgresService XSourceType = "postgres-service"
 )
 
 // Parameters defines the plugin parameters schema
@@ -56,7 +67,8 @@ type Parameters struct {
 	Properties map[string]ParameterSpec `json:"properties" yaml:"properties"`
 	Required   []string                 `json:"required" yaml:"required"`
 	// XSource defines the source type for plugin parameters
-	// Can be one of: fabric-peer, key, fabric-org, fabric-network
+	// Can be one of: fabric-peer, key, fabric-org, fabric-network, fabric-key, besu-node,
+	// besu-network, postgres-service
 }
 
 // ParameterSpec defines a single parameter specification
@@ -146,6 +158,12 @@ func (p *Plugin) Validate() error {
 		}
 	}
 
+	for i := range p.Spec.HealthChecks {
+		if err := p.Spec.HealthChecks[i].Validate(); err != nil {
+			return fmt.Errorf("spec.healthChecks[%d]: %w", i, err)
+		}
+	}
+
 	return nil
 }
 
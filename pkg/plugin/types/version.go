@@ -0,0 +1,100 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PluginVersion is a recorded version of an installed plugin
+type PluginVersion struct {
+	ID      int64  `json:"id"`
+	Version string `json:"version"`
+	// Source is the registry source the version was installed from, empty for uploaded manifests
+	Source string `json:"source,omitempty"`
+	// Parameters are the parameters the version was last deployed with
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	DeployedAt *time.Time             `json:"deployedAt,omitempty"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	// Plugin is the definition of the plugin at this version
+	Plugin *Plugin `json:"-"`
+}
+
+// CompareVersions compares two plugin versions in the MAJOR.MINOR.PATCH[-PRERELEASE] form,
+// with an optional "v" prefix. It returns -1 if a is older than b, 1 if it is newer and 0 if
+// they are equal. Missing parts count as 0, a pre-release is older than its release and
+// non numeric parts are compared as strings.
+func CompareVersions(a, b string) int {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if c := compareVersionPart(aPart, bPart); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	aParts = strings.Split(aPre, ".")
+	bParts = strings.Split(bPre, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := compareVersionPart(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aParts), len(bParts))
+}
+
+// splitVersion splits a version into its core and pre-release parts, dropping the "v" prefix
+// and the build metadata
+func splitVersion(version string) (string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	if i := strings.Index(version, "-"); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+	return version, ""
+}
+
+func compareVersionPart(a, b string) int {
+	aNum, aErr := strconv.Atoi(a)
+	bNum, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(aNum, bNum)
+	case aErr == nil:
+		// Numeric identifiers are older than alphanumeric ones
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
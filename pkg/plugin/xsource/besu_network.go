@@ -0,0 +1,202 @@
+package xsource
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	ptypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+)
+
+// besuNetworkPlatform is the platform of the Besu networks in the networks table
+const besuNetworkPlatform = "besu"
+
+// BesuNetworkValue represents a besu-network x-source value
+type BesuNetworkValue struct {
+	BaseXSourceValue
+	NetworkID   int64
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+// NewBesuNetworkHandler creates a new handler for besu-network x-source type
+func NewBesuNetworkHandler(queries *db.Queries, nodeService *nodeservice.NodeService) XSourceHandler {
+	return &besuNetworkHandler{
+		queries:     queries,
+		nodeService: nodeService,
+	}
+}
+
+type besuNetworkHandler struct {
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+func (h *besuNetworkHandler) GetType() XSourceType {
+	return BesuNetwork
+}
+
+func (h *besuNetworkHandler) CreateValue(key string, rawValue interface{}) (XSourceValue, error) {
+	networkID, err := parseID(BesuNetwork, rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BesuNetworkValue{
+		BaseXSourceValue: BaseXSourceValue{RawValue: rawValue, Key: key},
+		NetworkID:        networkID,
+		queries:          h.queries,
+		nodeService:      h.nodeService,
+	}, nil
+}
+
+func (h *besuNetworkHandler) ListOptions(ctx context.Context) ([]OptionItem, error) {
+	networks, err := h.queries.ListNetworksByPlatform(ctx, besuNetworkPlatform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list besu networks: %w", err)
+	}
+
+	opts := make([]OptionItem, len(networks))
+	for i, network := range networks {
+		opts[i] = OptionItem{
+			Label: network.Name,
+			Value: fmt.Sprintf("%d", network.ID),
+		}
+	}
+	return opts, nil
+}
+
+func (v *BesuNetworkValue) Validate(ctx context.Context) error {
+	network, err := v.queries.GetNetwork(ctx, v.NetworkID)
+	if err != nil {
+		return fmt.Errorf("invalid besu network ID %d: %w", v.NetworkID, err)
+	}
+	if network.Platform != besuNetworkPlatform {
+		return fmt.Errorf("network %s is not a Besu network", network.Name)
+	}
+	if _, err := besuGenesis(network); err != nil {
+		return err
+	}
+	return nil
+}
+
+// besuGenesis returns the genesis file of a Besu network. Created networks store the genesis
+// JSON as is, imported networks store it base64 encoded.
+func besuGenesis(network *db.Network) ([]byte, error) {
+	if !network.GenesisBlockB64.Valid || network.GenesisBlockB64.String == "" {
+		return nil, fmt.Errorf("besu network %s has no genesis", network.Name)
+	}
+	genesis := strings.TrimSpace(network.GenesisBlockB64.String)
+	if strings.HasPrefix(genesis, "{") {
+		return []byte(genesis), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode genesis of besu network %s: %w", network.Name, err)
+	}
+	return decoded, nil
+}
+
+func (v *BesuNetworkValue) GetValue(ctx context.Context, spec ptypes.ParameterSpec) (interface{}, error) {
+	network, err := v.queries.GetNetwork(ctx, v.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+
+	genesis, err := besuGenesis(network)
+	if err != nil {
+		return nil, err
+	}
+	var genesisConfig struct {
+		Config struct {
+			ChainID int64 `json:"chainId"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(genesis, &genesisConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis of besu network %s: %w", network.Name, err)
+	}
+
+	details := &BesuNetworkDetails{
+		ID:          network.ID,
+		Name:        network.Name,
+		ChainID:     genesisConfig.Config.ChainID,
+		Genesis:     string(genesis),
+		GenesisPath: fmt.Sprintf("/etc/chainlaunch/networks/%d/genesis.json", network.ID),
+	}
+
+	networkNodes, err := v.queries.GetNetworkNodes(ctx, v.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	for _, networkNode := range networkNodes {
+		if networkNode.NodeType.String != string(types.NodeTypeBesuFullnode) || networkNode.Status_2 != string(types.NodeStatusRunning) {
+			continue
+		}
+		node, err := v.nodeService.GetNode(ctx, networkNode.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get besu node: %w", err)
+		}
+		if node.BesuNode == nil {
+			continue
+		}
+		details.Nodes = append(details.Nodes, besuNodeDetails(node))
+	}
+	if len(details.Nodes) > 0 {
+		details.RPCURL = details.Nodes[0].RPCURL
+		details.WSURL = details.Nodes[0].WSURL
+	}
+
+	return details, nil
+}
+
+// GetVolumeMounts returns the volume mount of the genesis file of the network
+func (v *BesuNetworkValue) GetVolumeMounts(ctx context.Context) ([]VolumeMount, error) {
+	network, err := v.queries.GetNetwork(ctx, v.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	genesis, err := besuGenesis(network)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir := fmt.Sprintf("/tmp/chainlaunch/networks/%d", network.ID)
+	if err := os.MkdirAll(tempDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	genesisPath := filepath.Join(tempDir, "genesis.json")
+	if err := os.WriteFile(genesisPath, genesis, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write genesis: %w", err)
+	}
+
+	return []VolumeMount{
+		{
+			Source:      genesisPath,
+			Target:      fmt.Sprintf("/etc/chainlaunch/networks/%d/genesis.json", network.ID),
+			Type:        "bind",
+			ReadOnly:    true,
+			Description: fmt.Sprintf("Genesis file of besu network %s", network.Name),
+		},
+	}, nil
+}
+
+// BesuNetworkDetails represents the details of a Besu network
+type BesuNetworkDetails struct {
+	ID          int64
+	Name        string
+	ChainID     int64
+	Genesis     string
+	GenesisPath string // Path inside the container
+	// RPCURL and WSURL are the endpoints of the first running node of the network
+	RPCURL string
+	WSURL  string
+	Nodes  []*BesuNodeDetails
+}
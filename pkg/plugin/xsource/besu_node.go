@@ -0,0 +1,141 @@
+package xsource
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	ptypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+)
+
+// BesuNodeValue represents a besu-node x-source value
+type BesuNodeValue struct {
+	BaseXSourceValue
+	NodeIDs     []int64
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+// NewBesuNodeHandler creates a new handler for besu-node x-source type
+func NewBesuNodeHandler(queries *db.Queries, nodeService *nodeservice.NodeService) XSourceHandler {
+	return &besuNodeHandler{
+		queries:     queries,
+		nodeService: nodeService,
+	}
+}
+
+type besuNodeHandler struct {
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+func (h *besuNodeHandler) GetType() XSourceType {
+	return BesuNode
+}
+
+func (h *besuNodeHandler) CreateValue(key string, rawValue interface{}) (XSourceValue, error) {
+	nodeIDs, err := parseIDs(BesuNode, rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BesuNodeValue{
+		BaseXSourceValue: BaseXSourceValue{RawValue: rawValue, Key: key},
+		NodeIDs:          nodeIDs,
+		queries:          h.queries,
+		nodeService:      h.nodeService,
+	}, nil
+}
+
+func (h *besuNodeHandler) ListOptions(ctx context.Context) ([]OptionItem, error) {
+	platform := types.PlatformBesu
+	nodes, err := h.nodeService.ListNodes(ctx, &platform, 1, 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list besu nodes: %w", err)
+	}
+
+	var opts []OptionItem
+	for _, node := range nodes.Items {
+		if node.BesuNode == nil {
+			continue
+		}
+		opts = append(opts, OptionItem{
+			Label: node.Name,
+			Value: fmt.Sprintf("%d", node.ID),
+		})
+	}
+	return opts, nil
+}
+
+func (v *BesuNodeValue) Validate(ctx context.Context) error {
+	for _, nodeID := range v.NodeIDs {
+		node, err := v.nodeService.GetNode(ctx, nodeID)
+		if err != nil {
+			return fmt.Errorf("invalid besu node ID %d: %w", nodeID, err)
+		}
+		if node.BesuNode == nil {
+			return fmt.Errorf("node %s is not a Besu node", node.Name)
+		}
+	}
+	return nil
+}
+
+func (v *BesuNodeValue) GetValue(ctx context.Context, spec ptypes.ParameterSpec) (interface{}, error) {
+	var details []*BesuNodeDetails
+
+	for _, nodeID := range v.NodeIDs {
+		node, err := v.nodeService.GetNode(ctx, nodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get besu node: %w", err)
+		}
+		if node.BesuNode == nil {
+			return nil, fmt.Errorf("node %s is not a Besu node", node.Name)
+		}
+		details = append(details, besuNodeDetails(node))
+	}
+
+	// Return array if spec type is array, otherwise return single node if available
+	if spec.Type == "array" {
+		return details, nil
+	}
+	if len(details) == 1 {
+		return details[0], nil
+	}
+	return details, nil
+}
+
+// besuNodeDetails returns the endpoints of a Besu node. Like the external endpoint of a Fabric
+// peer, they use the address the node advertises to its peers, which plugin containers reach
+// as any other host, and fall back to the RPC host of nodes without external IP.
+func besuNodeDetails(node *nodeservice.NodeResponse) *BesuNodeDetails {
+	host := node.BesuNode.ExternalIP
+	if host == "" || host == "0.0.0.0" {
+		host = node.BesuNode.RPCHost
+	}
+
+	details := &BesuNodeDetails{
+		ID:        node.ID,
+		Name:      node.Name,
+		NetworkID: node.BesuNode.NetworkID,
+		EnodeURL:  node.BesuNode.EnodeURL,
+		RPCURL:    "http://" + net.JoinHostPort(host, strconv.Itoa(int(node.BesuNode.RPCPort))),
+	}
+	if node.BesuNode.WSPort != 0 {
+		details.WSURL = "ws://" + net.JoinHostPort(host, strconv.Itoa(int(node.BesuNode.WSPort)))
+	}
+	return details
+}
+
+// BesuNodeDetails represents the details of a Besu node
+type BesuNodeDetails struct {
+	ID        int64
+	Name      string
+	NetworkID int64
+	EnodeURL  string
+	RPCURL    string
+	WSURL     string // Empty when the WebSocket RPC is disabled
+}
@@ -0,0 +1,63 @@
+package xsource
+
+import (
+	"testing"
+
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+)
+
+func TestBesuNodeDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		node   nodeservice.BesuNodeProperties
+		rpcURL string
+		wsURL  string
+	}{
+		{
+			name:   "external IP",
+			node:   nodeservice.BesuNodeProperties{ExternalIP: "10.0.0.5", RPCHost: "0.0.0.0", RPCPort: 8545, WSPort: 8546},
+			rpcURL: "http://10.0.0.5:8545",
+			wsURL:  "ws://10.0.0.5:8546",
+		},
+		{
+			name:   "no external IP",
+			node:   nodeservice.BesuNodeProperties{RPCHost: "192.168.1.20", RPCPort: 8545, WSPort: 8546},
+			rpcURL: "http://192.168.1.20:8545",
+			wsURL:  "ws://192.168.1.20:8546",
+		},
+		{
+			name:   "wildcard external IP",
+			node:   nodeservice.BesuNodeProperties{ExternalIP: "0.0.0.0", RPCHost: "192.168.1.20", RPCPort: 8545},
+			rpcURL: "http://192.168.1.20:8545",
+		},
+		{
+			name:   "IPv6 external IP",
+			node:   nodeservice.BesuNodeProperties{ExternalIP: "fd00::5", RPCPort: 8545, WSPort: 8546},
+			rpcURL: "http://[fd00::5]:8545",
+			wsURL:  "ws://[fd00::5]:8546",
+		},
+		{
+			name:   "WebSocket disabled",
+			node:   nodeservice.BesuNodeProperties{ExternalIP: "10.0.0.5", RPCPort: 8545},
+			rpcURL: "http://10.0.0.5:8545",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			besu := tt.node
+			besu.NetworkID = 1337
+			besu.EnodeURL = "enode://abc@10.0.0.5:30303"
+			details := besuNodeDetails(&nodeservice.NodeResponse{ID: 7, Name: "besu-1", BesuNode: &besu})
+
+			if details.RPCURL != tt.rpcURL {
+				t.Errorf("RPCURL = %q, want %q", details.RPCURL, tt.rpcURL)
+			}
+			if details.WSURL != tt.wsURL {
+				t.Errorf("WSURL = %q, want %q", details.WSURL, tt.wsURL)
+			}
+			if details.ID != 7 || details.Name != "besu-1" || details.NetworkID != 1337 || details.EnodeURL != besu.EnodeURL {
+				t.Errorf("node fields not copied: %+v", details)
+			}
+		})
+	}
+}
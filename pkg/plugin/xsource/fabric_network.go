@@ -0,0 +1,193 @@
+package xsource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	nodeservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	"github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	ptypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+)
+
+// fabricNetworkPlatform is the platform of the Fabric networks in the networks table
+const fabricNetworkPlatform = "fabric"
+
+// FabricNetworkValue represents a fabric-network x-source value
+type FabricNetworkValue struct {
+	BaseXSourceValue
+	NetworkID   int64
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+// NewFabricNetworkHandler creates a new handler for fabric-network x-source type
+func NewFabricNetworkHandler(queries *db.Queries, nodeService *nodeservice.NodeService) XSourceHandler {
+	return &fabricNetworkHandler{
+		queries:     queries,
+		nodeService: nodeService,
+	}
+}
+
+type fabricNetworkHandler struct {
+	queries     *db.Queries
+	nodeService *nodeservice.NodeService
+}
+
+func (h *fabricNetworkHandler) GetType() XSourceType {
+	return FabricNetwork
+}
+
+func (h *fabricNetworkHandler) CreateValue(key string, rawValue interface{}) (XSourceValue, error) {
+	networkID, err := parseID(FabricNetwork, rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FabricNetworkValue{
+		BaseXSourceValue: BaseXSourceValue{RawValue: rawValue, Key: key},
+		NetworkID:        networkID,
+		queries:          h.queries,
+		nodeService:      h.nodeService,
+	}, nil
+}
+
+func (h *fabricNetworkHandler) ListOptions(ctx context.Context) ([]OptionItem, error) {
+	networks, err := h.queries.ListNetworksByPlatform(ctx, fabricNetworkPlatform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fabric networks: %w", err)
+	}
+
+	opts := make([]OptionItem, len(networks))
+	for i, network := range networks {
+		opts[i] = OptionItem{
+			Label: network.Name,
+			Value: fmt.Sprintf("%d", network.ID),
+		}
+	}
+	return opts, nil
+}
+
+func (v *FabricNetworkValue) Validate(ctx context.Context) error {
+	network, err := v.queries.GetNetwork(ctx, v.NetworkID)
+	if err != nil {
+		return fmt.Errorf("invalid fabric network ID %d: %w", v.NetworkID, err)
+	}
+	if network.Platform != fabricNetworkPlatform {
+		return fmt.Errorf("network %s is not a Fabric network", network.Name)
+	}
+
+	orderers, err := v.getOrderers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(orderers) == 0 {
+		return fmt.Errorf("fabric network %s has no orderer nodes", network.Name)
+	}
+	return nil
+}
+
+// getOrderers returns the orderer nodes of the network
+func (v *FabricNetworkValue) getOrderers(ctx context.Context) ([]*nodeservice.NodeResponse, error) {
+	networkNodes, err := v.queries.GetNetworkNodes(ctx, v.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+
+	var orderers []*nodeservice.NodeResponse
+	for _, networkNode := range networkNodes {
+		if networkNode.NodeType.String != string(types.NodeTypeFabricOrderer) {
+			continue
+		}
+		node, err := v.nodeService.GetNode(ctx, networkNode.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orderer: %w", err)
+		}
+		if node.FabricOrderer == nil {
+			return nil, fmt.Errorf("node %s is not a Fabric orderer", node.Name)
+		}
+		orderers = append(orderers, node)
+	}
+	return orderers, nil
+}
+
+func (v *FabricNetworkValue) GetValue(ctx context.Context, spec ptypes.ParameterSpec) (interface{}, error) {
+	network, err := v.queries.GetNetwork(ctx, v.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+
+	orderers, err := v.getOrderers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &FabricNetworkDetails{
+		ID:          network.ID,
+		Name:        network.Name,
+		ChannelName: network.Name,
+	}
+	for _, orderer := range orderers {
+		details.OrdererEndpoints = append(details.OrdererEndpoints, orderer.FabricOrderer.ExternalEndpoint)
+		details.Orderers = append(details.Orderers, FabricOrdererDetails{
+			ID:               orderer.ID,
+			Name:             orderer.Name,
+			ExternalEndpoint: orderer.FabricOrderer.ExternalEndpoint,
+			MspID:            orderer.FabricOrderer.MSPID,
+			TLSCACertPath:    fmt.Sprintf("/etc/chainlaunch/orderers/%d/tls/ca.pem", orderer.ID),
+		})
+	}
+	return details, nil
+}
+
+// GetVolumeMounts returns the volume mounts of the TLS CA certificates of the orderers
+func (v *FabricNetworkValue) GetVolumeMounts(ctx context.Context) ([]VolumeMount, error) {
+	orderers, err := v.getOrderers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mounts []VolumeMount
+	for _, orderer := range orderers {
+		// Create a temporary file for the TLS CA cert
+		tempDir := fmt.Sprintf("/tmp/chainlaunch/orderers/%d/tls", orderer.ID)
+		if err := os.MkdirAll(tempDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+
+		caCertPath := filepath.Join(tempDir, "ca.pem")
+		if err := os.WriteFile(caCertPath, []byte(orderer.FabricOrderer.TLSCACert), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write TLS CA cert: %w", err)
+		}
+
+		mounts = append(mounts, VolumeMount{
+			Source:      caCertPath,
+			Target:      fmt.Sprintf("/etc/chainlaunch/orderers/%d/tls/ca.pem", orderer.ID),
+			Type:        "bind",
+			ReadOnly:    true,
+			Description: fmt.Sprintf("TLS CA certificate for orderer %s", orderer.Name),
+		})
+	}
+
+	return mounts, nil
+}
+
+// FabricNetworkDetails represents the details of a Fabric network
+type FabricNetworkDetails struct {
+	ID               int64
+	Name             string
+	ChannelName      string
+	OrdererEndpoints []string
+	Orderers         []FabricOrdererDetails
+}
+
+// FabricOrdererDetails represents the details of an orderer of a Fabric network
+type FabricOrdererDetails struct {
+	ID               int64
+	Name             string
+	ExternalEndpoint string
+	MspID            string
+	TLSCACertPath    string // Path inside the container
+}
@@ -0,0 +1,127 @@
+package xsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	ptypes "github.com/chainlaunch/chainlaunch/pkg/plugin/types"
+	svctypes "github.com/chainlaunch/chainlaunch/pkg/services/types"
+)
+
+// PostgresServiceValue represents a postgres-service x-source value
+type PostgresServiceValue struct {
+	BaseXSourceValue
+	ServiceID int64
+	queries   *db.Queries
+}
+
+// NewPostgresServiceHandler creates a new handler for postgres-service x-source type
+func NewPostgresServiceHandler(queries *db.Queries) XSourceHandler {
+	return &postgresServiceHandler{
+		queries: queries,
+	}
+}
+
+type postgresServiceHandler struct {
+	queries *db.Queries
+}
+
+func (h *postgresServiceHandler) GetType() XSourceType {
+	return PostgresService
+}
+
+func (h *postgresServiceHandler) CreateValue(key string, rawValue interface{}) (XSourceValue, error) {
+	serviceID, err := parseID(PostgresService, rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresServiceValue{
+		BaseXSourceValue: BaseXSourceValue{RawValue: rawValue, Key: key},
+		ServiceID:        serviceID,
+		queries:          h.queries,
+	}, nil
+}
+
+func (h *postgresServiceHandler) ListOptions(ctx context.Context) ([]OptionItem, error) {
+	services, err := h.queries.ListServices(ctx, &db.ListServicesParams{Limit: 100, Offset: 0})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var opts []OptionItem
+	for _, service := range services {
+		if service.ServiceType != string(svctypes.ServiceTypePostgres) {
+			continue
+		}
+		opts = append(opts, OptionItem{
+			Label: service.Name,
+			Value: fmt.Sprintf("%d", service.ID),
+		})
+	}
+	return opts, nil
+}
+
+func (v *PostgresServiceValue) Validate(ctx context.Context) error {
+	_, err := v.getDetails(ctx)
+	return err
+}
+
+func (v *PostgresServiceValue) GetValue(ctx context.Context, spec ptypes.ParameterSpec) (interface{}, error) {
+	return v.getDetails(ctx)
+}
+
+// getDetails returns the connection details of the service. The service must have been
+// started, its deployment config holds the host and docker network to reach it.
+func (v *PostgresServiceValue) getDetails(ctx context.Context) (*PostgresServiceDetails, error) {
+	service, err := v.queries.GetService(ctx, v.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid postgres service ID %d: %w", v.ServiceID, err)
+	}
+	if service.ServiceType != string(svctypes.ServiceTypePostgres) {
+		return nil, fmt.Errorf("service %s is not a PostgreSQL service", service.Name)
+	}
+	if !service.Config.Valid || service.Config.String == "" {
+		return nil, fmt.Errorf("postgres service %s has no config", service.Name)
+	}
+	if !service.DeploymentConfig.Valid || service.DeploymentConfig.String == "" {
+		return nil, fmt.Errorf("postgres service %s has not been started", service.Name)
+	}
+
+	var config svctypes.PostgresConfig
+	if err := json.Unmarshal([]byte(service.Config.String), &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal postgres service config: %w", err)
+	}
+	var deployment svctypes.PostgresDeployment
+	if err := json.Unmarshal([]byte(service.DeploymentConfig.String), &deployment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal postgres service deployment: %w", err)
+	}
+
+	return &PostgresServiceDetails{
+		ID:          service.ID,
+		Name:        service.Name,
+		Host:        deployment.Host,
+		Port:        deployment.Port,
+		Database:    config.DB,
+		User:        config.User,
+		Password:    config.Password,
+		DSN:         svctypes.PostgresDSN(config, deployment),
+		NetworkName: deployment.NetworkName,
+	}, nil
+}
+
+// PostgresServiceDetails represents the connection details of a PostgreSQL service. The host
+// is the container name, plugins reach it by joining NetworkName as an external network.
+type PostgresServiceDetails struct {
+	ID          int64
+	Name        string
+	Host        string
+	Port        int
+	Database    string
+	User        string
+	Password    string
+	DSN         string
+	NetworkName string
+}
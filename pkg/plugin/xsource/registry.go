@@ -24,6 +24,10 @@ func NewRegistry(queries *db.Queries, nodeService *nodeservice.NodeService, keyM
 	// Register default handlers
 	r.Register(NewFabricKeyHandler(queries, nodeService, keyManagement))
 	r.Register(NewFabricPeerHandler(queries, nodeService))
+	r.Register(NewFabricNetworkHandler(queries, nodeService))
+	r.Register(NewBesuNodeHandler(queries, nodeService))
+	r.Register(NewBesuNetworkHandler(queries, nodeService))
+	r.Register(NewPostgresServiceHandler(queries))
 	r.Register(NewFileHandler())
 
 	return r
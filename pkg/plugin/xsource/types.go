@@ -3,6 +3,7 @@ package xsource
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/chainlaunch/chainlaunch/pkg/plugin/types"
 )
@@ -11,11 +12,15 @@ import (
 type XSourceType string
 
 const (
-	FabricKey  XSourceType = "fabric-key"
-	FabricPeer XSourceType = "fabric-peer"
-	FabricOrg  XSourceType = "fabric-org"
-	KeyStore   XSourceType = "keyStore"
-	File       XSourceType = "file"
+	FabricKey       XSourceType = "fabric-key"
+	FabricPeer      XSourceType = "fabric-peer"
+	FabricOrg       XSourceType = "fabric-org"
+	FabricNetwork   XSourceType = "fabric-network"
+	BesuNode        XSourceType = "besu-node"
+	BesuNetwork     XSourceType = "besu-network"
+	PostgresService XSourceType = "postgres-service"
+	KeyStore        XSourceType = "keyStore"
+	File            XSourceType = "file"
 )
 
 // VolumeMount represents a volume mount configuration
@@ -70,3 +75,45 @@ func (b *BaseXSourceValue) GetValidationValue() string {
 func (b *BaseXSourceValue) GetVolumeMounts(ctx context.Context) ([]VolumeMount, error) {
 	return []VolumeMount{}, nil
 }
+
+// parseIDs parses the ID, or array of IDs, selected for an x-source parameter. IDs are sent
+// as strings or numbers.
+func parseIDs(xSourceType XSourceType, rawValue interface{}) ([]int64, error) {
+	items, ok := rawValue.([]interface{})
+	if !ok {
+		items = []interface{}{rawValue}
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s ID: %s", xSourceType, v)
+			}
+			ids[i] = id
+		case float64:
+			ids[i] = int64(v)
+		case int:
+			ids[i] = int64(v)
+		case int64:
+			ids[i] = v
+		default:
+			return nil, fmt.Errorf("%s value must be a string, number, or array of strings/numbers", xSourceType)
+		}
+	}
+	return ids, nil
+}
+
+// parseID parses the single ID selected for an x-source parameter
+func parseID(xSourceType XSourceType, rawValue interface{}) (int64, error) {
+	if _, ok := rawValue.([]interface{}); ok {
+		return 0, fmt.Errorf("%s value must be a single ID", xSourceType)
+	}
+	ids, err := parseIDs(xSourceType, rawValue)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
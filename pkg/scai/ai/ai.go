@@ -14,6 +14,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/boilerplates"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/projectrunner"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projecttests"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/sessionchanges"
 	"github.com/sashabaranov/go-openai"
 )
@@ -116,6 +117,10 @@ type AIChatService struct {
 	AIProvider         AIProviderInterface
 	Model              string
 	BoilerplateService *boilerplates.BoilerplateService
+	// OpsTools are the tools of the operations assistant, nil when it is disabled
+	OpsTools *OpsTools
+	// TestService runs the unit tests of the projects, the run_tests tool is disabled when nil
+	TestService *projecttests.Service
 }
 
 // NewAIChatService creates a new generic AI chat service
@@ -1266,6 +1271,9 @@ func newFunction() {
 			},
 		},
 	}
+	if s.TestService != nil {
+		allTools = append(allTools, s.runTestsTool(projectRoot))
+	}
 
 	return allTools
 }
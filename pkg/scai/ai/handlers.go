@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,6 +29,11 @@ type Model struct {
 	MaxTokens   int    `json:"maxTokens"`
 }
 
+// ModelLister is implemented by the AI providers serving a configurable list of models
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]Model, error)
+}
+
 // Template represents a project template
 type Template struct {
 	Name        string `json:"name"`
@@ -68,6 +74,10 @@ func (h *AIHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/ai", func(r chi.Router) {
 		r.Get("/boilerplates", response.Middleware(h.GetBoilerplates))
 		r.Get("/models", response.Middleware(h.GetModels))
+		r.Post("/ops/chat", response.Middleware(h.OpsChat))
+		r.Get("/ops/actions", response.Middleware(h.ListOpsActions))
+		r.Post("/ops/actions/{actionId}/confirm", response.Middleware(h.ConfirmOpsAction))
+		r.Post("/ops/actions/{actionId}/reject", response.Middleware(h.RejectOpsAction))
 		r.Post("/{projectId}/chat", response.Middleware(h.Chat))
 		r.Get("/{projectId}/conversations", response.Middleware(h.GetConversations))
 		r.Post("/{projectId}/conversations", response.Middleware(h.CreateConversation))
@@ -122,6 +132,13 @@ func (h *AIHandler) GetBoilerplates(w http.ResponseWriter, r *http.Request) erro
 // @Failure      500 {object} response.ErrorResponse
 // @Router       /ai/models [get]
 func (h *AIHandler) GetModels(w http.ResponseWriter, r *http.Request) error {
+	if lister, ok := h.AIChatService.AIProvider.(ModelLister); ok {
+		models, err := lister.ListModels(r.Context())
+		if err != nil {
+			return errors.NewInternalError("failed to list models", err, nil)
+		}
+		return response.WriteJSON(w, http.StatusOK, models)
+	}
 	models := []Model{
 		{
 			Name:        "GPT-4",
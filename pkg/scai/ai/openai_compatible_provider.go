@@ -0,0 +1,526 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/sashabaranov/go-openai"
+)
+
+// ToolCallingMode selects how tools are offered to a self-hosted model
+type ToolCallingMode string
+
+const (
+	// ToolCallingAuto uses native tool calling and falls back to the prompt protocol
+	// for the models the server rejects tools for
+	ToolCallingAuto ToolCallingMode = "auto"
+	// ToolCallingNative always sends tools in the request
+	ToolCallingNative ToolCallingMode = "native"
+	// ToolCallingPrompt describes the tools in the system prompt and parses the
+	// tool calls from the model output
+	ToolCallingPrompt ToolCallingMode = "prompt"
+)
+
+const (
+	toolCallOpenTag      = "<tool_call>"
+	toolCallCloseTag     = "</tool_call>"
+	toolResponseOpenTag  = "<tool_response>"
+	toolResponseCloseTag = "</tool_response>"
+)
+
+// OpenAICompatibleConfig configures a provider for servers exposing the OpenAI
+// chat completions API, such as Ollama, vLLM or LocalAI
+type OpenAICompatibleConfig struct {
+	// BaseURL of the API, e.g. http://localhost:11434/v1
+	BaseURL string
+	// Models offered to the users, the models of the server when empty
+	Models []string
+	// AuthHeader is the header sent with every request, e.g. Authorization
+	AuthHeader string
+	// AuthValue is the value of AuthHeader, e.g. "Bearer <token>"
+	AuthValue string
+	// ToolCalling is the tool calling mode, ToolCallingAuto when empty
+	ToolCalling ToolCallingMode
+	// ContextWindow is the context size of the models, 32768 when unset
+	ContextWindow int
+}
+
+// OpenAICompatibleProvider implements AIProviderInterface for self-hosted models
+// served through an OpenAI-compatible API
+type OpenAICompatibleProvider struct {
+	*OpenAIProvider
+	Config OpenAICompatibleConfig
+
+	mu sync.RWMutex
+	// nativeTools caches whether the server accepted tools for a model
+	nativeTools map[string]bool
+}
+
+// headerTransport adds a fixed header to every request
+type headerTransport struct {
+	name  string
+	value string
+	base  http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.value)
+	return t.base.RoundTrip(req)
+}
+
+// NewOpenAICompatibleProvider creates a new provider for an OpenAI-compatible server
+func NewOpenAICompatibleProvider(config OpenAICompatibleConfig, logger *logger.Logger) (*OpenAICompatibleProvider, error) {
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("base URL is required")
+	}
+	switch config.ToolCalling {
+	case "":
+		config.ToolCalling = ToolCallingAuto
+	case ToolCallingAuto, ToolCallingNative, ToolCallingPrompt:
+	default:
+		return nil, fmt.Errorf("invalid tool calling mode %q, must be auto, native or prompt", config.ToolCalling)
+	}
+	if config.AuthValue != "" && config.AuthHeader == "" {
+		config.AuthHeader = "Authorization"
+	}
+
+	clientConfig := openai.DefaultConfig("")
+	clientConfig.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.AuthValue != "" {
+		clientConfig.HTTPClient = &http.Client{
+			Transport: &headerTransport{
+				name:  config.AuthHeader,
+				value: config.AuthValue,
+				base:  http.DefaultTransport,
+			},
+		}
+	}
+
+	return &OpenAICompatibleProvider{
+		OpenAIProvider: &OpenAIProvider{
+			Client: openai.NewClientWithConfig(clientConfig),
+			Logger: logger,
+		},
+		Config:      config,
+		nativeTools: make(map[string]bool),
+	}, nil
+}
+
+// StreamAgentStep streams a single agent step. Tools are sent natively when the model
+// supports them, and through the prompt protocol otherwise.
+func (p *OpenAICompatibleProvider) StreamAgentStep(
+	ctx context.Context,
+	messages []AIMessage,
+	model string,
+	tools []AITool,
+	toolSchemas map[string]ToolSchema,
+	observer AgentStepObserver,
+) (*AIMessage, []AIToolCall, []ToolCallResult, error) {
+	if len(tools) == 0 {
+		return p.OpenAIProvider.StreamAgentStep(ctx, messages, model, nil, toolSchemas, observer)
+	}
+	if !p.SupportsNativeTools(model) {
+		return p.streamPromptToolsStep(ctx, messages, model, tools, toolSchemas, observer)
+	}
+
+	msg, toolCalls, results, err := p.OpenAIProvider.StreamAgentStep(ctx, messages, model, tools, toolSchemas, observer)
+	if err != nil && p.Config.ToolCalling == ToolCallingAuto && isToolsUnsupportedError(err) {
+		p.Logger.Infof("Model %s does not support native tool calling, using the prompt protocol: %v", model, err)
+		p.setNativeTools(model, false)
+		return p.streamPromptToolsStep(ctx, messages, model, tools, toolSchemas, observer)
+	}
+	if err == nil && p.Config.ToolCalling == ToolCallingAuto {
+		p.setNativeTools(model, true)
+	}
+	return msg, toolCalls, results, err
+}
+
+// SupportsNativeTools reports whether tools are sent natively to the model. In auto
+// mode models are assumed to support them until the server rejects a request with tools.
+func (p *OpenAICompatibleProvider) SupportsNativeTools(model string) bool {
+	switch p.Config.ToolCalling {
+	case ToolCallingNative:
+		return true
+	case ToolCallingPrompt:
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	supported, ok := p.nativeTools[model]
+	return !ok || supported
+}
+
+func (p *OpenAICompatibleProvider) setNativeTools(model string, supported bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nativeTools[model] = supported
+}
+
+// isToolsUnsupportedError reports whether the server rejected a request because of its tools.
+// Ollama answers "<model> does not support tools" and vLLM asks for --enable-auto-tool-choice.
+func isToolsUnsupportedError(err error) bool {
+	var statusCode int
+	var message string
+	var apiErr *openai.APIError
+	var reqErr *openai.RequestError
+	switch {
+	case errors.As(err, &apiErr):
+		statusCode, message = apiErr.HTTPStatusCode, apiErr.Message
+	case errors.As(err, &reqErr):
+		statusCode, message = reqErr.HTTPStatusCode, string(reqErr.Body)
+		if reqErr.Err != nil {
+			message += " " + reqErr.Err.Error()
+		}
+	default:
+		return false
+	}
+	if statusCode != http.StatusBadRequest && statusCode != http.StatusUnprocessableEntity &&
+		statusCode != http.StatusNotImplemented && statusCode != http.StatusInternalServerError {
+		return false
+	}
+	message = strings.ToLower(message)
+	return strings.Contains(message, "tool")
+}
+
+// streamPromptToolsStep runs an agent step without native tools: the tools are described in
+// the system prompt and the calls are read from the <tool_call> blocks of the response
+func (p *OpenAICompatibleProvider) streamPromptToolsStep(
+	ctx context.Context,
+	messages []AIMessage,
+	model string,
+	tools []AITool,
+	toolSchemas map[string]ToolSchema,
+	observer AgentStepObserver,
+) (*AIMessage, []AIToolCall, []ToolCallResult, error) {
+	if len(messages) == 0 {
+		return nil, nil, nil, fmt.Errorf("no messages provided for AI processing")
+	}
+
+	stream, err := p.Client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:    model,
+		Messages: promptToolsMessages(messages, tools),
+		Stream:   true,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer stream.Close()
+
+	var contentBuilder strings.Builder
+	filter := &toolCallStreamFilter{}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, nil, err
+		}
+		for _, choice := range response.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			contentBuilder.WriteString(choice.Delta.Content)
+			if visible := filter.Write(choice.Delta.Content); visible != "" && observer != nil {
+				observer.OnLLMContent(visible)
+			}
+		}
+	}
+	if visible := filter.Flush(); visible != "" && observer != nil {
+		observer.OnLLMContent(visible)
+	}
+
+	content, toolCalls := parsePromptToolCalls(contentBuilder.String())
+	for _, toolCall := range toolCalls {
+		if observer != nil {
+			observer.OnToolCallStart(toolCall.ID, toolCall.Function.Name)
+			observer.OnToolCallUpdate(toolCall.ID, toolCall.Function.Name, toolCall.Function.Arguments)
+		}
+	}
+	toolCallResults := executeToolCalls(toolCalls, toolSchemas, observer, p.Logger)
+
+	return &AIMessage{
+		Role:      "assistant",
+		Content:   content,
+		ToolCalls: toolCalls,
+	}, toolCalls, toolCallResults, nil
+}
+
+// promptToolsMessages converts the conversation for a model without native tools. The tools
+// and the call protocol are added to the system prompt, past tool calls are written back as
+// <tool_call> blocks and tool results are sent as user messages.
+func promptToolsMessages(messages []AIMessage, tools []AITool) []openai.ChatCompletionMessage {
+	toolNames := make(map[string]string)
+	var result []openai.ChatCompletionMessage
+	hasSystem := false
+	for _, msg := range messages {
+		switch {
+		case msg.Role == openai.ChatMessageRoleSystem:
+			hasSystem = true
+			result = append(result, openai.ChatCompletionMessage{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: msg.Content + promptToolsInstructions(tools),
+			})
+		case msg.Role == openai.ChatMessageRoleTool:
+			name := toolNames[msg.ToolCallID]
+			result = append(result, openai.ChatCompletionMessage{
+				Role:    openai.ChatMessageRoleUser,
+				Content: fmt.Sprintf("%s\n{\"name\": %q, \"content\": %s}\n%s", toolResponseOpenTag, name, jsonOrString(msg.Content), toolResponseCloseTag),
+			})
+		case len(msg.ToolCalls) > 0:
+			var sb strings.Builder
+			sb.WriteString(msg.Content)
+			for _, tc := range msg.ToolCalls {
+				toolNames[tc.ID] = tc.Function.Name
+				fmt.Fprintf(&sb, "\n%s\n{\"name\": %q, \"arguments\": %s}\n%s", toolCallOpenTag, tc.Function.Name, jsonOrString(tc.Function.Arguments), toolCallCloseTag)
+			}
+			result = append(result, openai.ChatCompletionMessage{
+				Role:    openai.ChatMessageRoleAssistant,
+				Content: strings.TrimSpace(sb.String()),
+			})
+		default:
+			result = append(result, openai.ChatCompletionMessage{
+				Role:    msg.Role,
+				Content: msg.Content,
+			})
+		}
+	}
+	if !hasSystem && len(tools) > 0 {
+		result = append([]openai.ChatCompletionMessage{{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: strings.TrimSpace(promptToolsInstructions(tools)),
+		}}, result...)
+	}
+	return result
+}
+
+// promptToolsInstructions describes the tools and how to call them
+func promptToolsInstructions(tools []AITool) string {
+	if len(tools) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n# Tools\n\nYou may call one or more of the following tools. Their signatures are provided as JSON schemas:\n")
+	for _, tool := range tools {
+		if tool.Function == nil {
+			continue
+		}
+		definition, err := json.Marshal(map[string]interface{}{
+			"name":        tool.Function.Name,
+			"description": tool.Function.Description,
+			"parameters":  tool.Function.Parameters,
+		})
+		if err != nil {
+			continue
+		}
+		sb.WriteString(string(definition))
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "\nTo call a tool, write a JSON object with its name and arguments inside %s %s tags, one block per call, and stop writing:\n", toolCallOpenTag, toolCallCloseTag)
+	fmt.Fprintf(&sb, "%s\n{\"name\": \"<tool name>\", \"arguments\": {<arguments>}}\n%s\n", toolCallOpenTag, toolCallCloseTag)
+	fmt.Fprintf(&sb, "The results are sent back inside %s %s tags. Answer without any tool call block when you are done.", toolResponseOpenTag, toolResponseCloseTag)
+	return sb.String()
+}
+
+// parsePromptToolCalls removes the <tool_call> blocks from the model output and returns them
+// as tool calls. Blocks that are not valid JSON are left in the content.
+func parsePromptToolCalls(output string) (string, []AIToolCall) {
+	var content strings.Builder
+	var toolCalls []AIToolCall
+	rest := output
+	for {
+		start := strings.Index(rest, toolCallOpenTag)
+		if start < 0 {
+			content.WriteString(rest)
+			break
+		}
+		content.WriteString(rest[:start])
+		block := rest[start+len(toolCallOpenTag):]
+		end := strings.Index(block, toolCallCloseTag)
+		next := ""
+		if end >= 0 {
+			next = block[end+len(toolCallCloseTag):]
+			block = block[:end]
+		}
+
+		var call struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(block)), &call); err != nil || call.Name == "" {
+			content.WriteString(rest[start : len(rest)-len(next)])
+		} else {
+			toolCalls = append(toolCalls, AIToolCall{
+				ID:   fmt.Sprintf("call_%d_%d", time.Now().UnixNano(), len(toolCalls)),
+				Type: string(openai.ToolTypeFunction),
+				Function: AIFunctionCall{
+					Name:      call.Name,
+					Arguments: promptToolArguments(call.Arguments),
+				},
+			})
+		}
+		rest = next
+	}
+	return strings.TrimSpace(content.String()), toolCalls
+}
+
+// promptToolArguments returns the arguments as a JSON object string. Some models write
+// them as an encoded string like the native API does.
+func promptToolArguments(raw json.RawMessage) string {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		return encoded
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// jsonOrString returns value when it is valid JSON, and value as a JSON string otherwise
+func jsonOrString(value string) string {
+	if json.Valid([]byte(value)) {
+		return value
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// toolCallStreamFilter hides the <tool_call> blocks from the streamed content. A tail that
+// could be the start of the tag is held back until the next chunk.
+type toolCallStreamFilter struct {
+	pending string
+	inBlock bool
+}
+
+// Write returns the part of chunk that can be shown
+func (f *toolCallStreamFilter) Write(chunk string) string {
+	f.pending += chunk
+	var visible strings.Builder
+	for {
+		if f.inBlock {
+			end := strings.Index(f.pending, toolCallCloseTag)
+			if end < 0 {
+				// Keep only what may be the start of the closing tag
+				f.pending = f.pending[len(f.pending)-partialTagLen(f.pending, toolCallCloseTag):]
+				return visible.String()
+			}
+			f.pending = f.pending[end+len(toolCallCloseTag):]
+			f.inBlock = false
+			continue
+		}
+		start := strings.Index(f.pending, toolCallOpenTag)
+		if start < 0 {
+			keep := partialTagLen(f.pending, toolCallOpenTag)
+			visible.WriteString(f.pending[:len(f.pending)-keep])
+			f.pending = f.pending[len(f.pending)-keep:]
+			return visible.String()
+		}
+		visible.WriteString(f.pending[:start])
+		f.pending = f.pending[start+len(toolCallOpenTag):]
+		f.inBlock = true
+	}
+}
+
+// Flush returns the held back content at the end of the stream
+func (f *toolCallStreamFilter) Flush() string {
+	if f.inBlock {
+		return ""
+	}
+	pending := f.pending
+	f.pending = ""
+	return pending
+}
+
+// partialTagLen returns the length of the longest suffix of s that is a prefix of tag
+func partialTagLen(s, tag string) int {
+	for n := len(tag) - 1; n > 0; n-- {
+		if strings.HasSuffix(s, tag[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+// GetMaxTokens returns the configured context window of the models
+func (p *OpenAICompatibleProvider) GetMaxTokens(model string) int {
+	if p.Config.ContextWindow > 0 {
+		return p.Config.ContextWindow
+	}
+	return 32768
+}
+
+// GenerateJSONSchemaFromMessage asks the model for a JSON object matching the schema. Function
+// calling is not available on every server, so the schema is passed in the prompt and the
+// server JSON mode is used.
+func (p *OpenAICompatibleProvider) GenerateJSONSchemaFromMessage(ctx context.Context, message string, model string, schema string) (string, error) {
+	var schemaMap map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &schemaMap); err != nil {
+		return "", fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	resp, err := p.Client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: model,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "You are a helpful assistant that generates JSON objects based on a provided schema. Answer only with a JSON object matching this schema:\n" + schema,
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: message,
+			},
+		},
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no result returned by the model")
+	}
+
+	content := strings.TrimSpace(resp.Choices[0].Message.Content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSpace(strings.TrimSuffix(content, "```"))
+	if !json.Valid([]byte(content)) {
+		return "", fmt.Errorf("model returned invalid JSON: %s", content)
+	}
+	return content, nil
+}
+
+// ListModels returns the configured models, or the models of the server when none is configured
+func (p *OpenAICompatibleProvider) ListModels(ctx context.Context) ([]Model, error) {
+	names := p.Config.Models
+	if len(names) == 0 {
+		list, err := p.Client.ListModels(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+		for _, model := range list.Models {
+			names = append(names, model.ID)
+		}
+	}
+
+	models := make([]Model, 0, len(names))
+	for _, name := range names {
+		models = append(models, Model{
+			Name:        name,
+			Description: fmt.Sprintf("Self-hosted model served by %s", p.Config.BaseURL),
+			MaxTokens:   p.GetMaxTokens(name),
+		})
+	}
+	return models, nil
+}
@@ -0,0 +1,272 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLLMServer is an OpenAI-compatible server answering chat completions with canned chunks
+type stubLLMServer struct {
+	nativeTools bool
+	chunks      []map[string]interface{}
+	requests    []map[string]interface{}
+	headers     []http.Header
+}
+
+func (s *stubLLMServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.headers = append(s.headers, r.Header.Clone())
+	switch r.URL.Path {
+	case "/v1/models":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"llama3.1:8b","object":"model"},{"id":"qwen2.5-coder:7b","object":"model"}]}`)
+		return
+	case "/v1/chat/completions":
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	var req map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	s.requests = append(s.requests, req)
+	if _, ok := req["tools"]; ok && !s.nativeTools {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error":{"message":"registry.ollama.ai/library/%s does not support tools","type":"api_error"}}`, req["model"])
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, chunk := range s.chunks {
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+func contentChunk(content string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      "chatcmpl-1",
+		"object":  "chat.completion.chunk",
+		"choices": []interface{}{map[string]interface{}{"index": 0, "delta": map[string]interface{}{"content": content}}},
+	}
+}
+
+type recordingObserver struct {
+	content  strings.Builder
+	executed []string
+}
+
+func (o *recordingObserver) OnLLMContent(content string)             { o.content.WriteString(content) }
+func (o *recordingObserver) OnToolCallStart(toolCallID, name string) {}
+func (o *recordingObserver) OnToolCallUpdate(toolCallID, name, arguments string) {
+}
+func (o *recordingObserver) OnToolCallExecute(toolCallID, name string, args map[string]interface{}) {
+	o.executed = append(o.executed, name)
+}
+func (o *recordingObserver) OnToolCallResult(toolCallID, name string, result interface{}, err error) {
+}
+func (o *recordingObserver) OnMaxStepsReached() {}
+
+func readFileTool() ([]AITool, map[string]ToolSchema, *[]map[string]interface{}) {
+	var calls []map[string]interface{}
+	schema := ToolSchema{
+		Name:        "read_file",
+		Description: "Read a file of the project",
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"path": map[string]interface{}{"type": "string"}},
+		},
+		Handler: func(projectRoot string, args map[string]interface{}) (interface{}, error) {
+			calls = append(calls, args)
+			return "pragma solidity ^0.8.0;", nil
+		},
+	}
+	tools := []AITool{{
+		Type: "function",
+		Function: &AIFunctionDefinition{
+			Name:        schema.Name,
+			Description: schema.Description,
+			Parameters:  schema.Parameters,
+		},
+	}}
+	return tools, map[string]ToolSchema{schema.Name: schema}, &calls
+}
+
+func newStubProvider(t *testing.T, stub *stubLLMServer, config OpenAICompatibleConfig) *OpenAICompatibleProvider {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	config.BaseURL = server.URL + "/v1"
+	provider, err := NewOpenAICompatibleProvider(config, logger.NewDefault())
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOpenAICompatibleProviderNativeTools(t *testing.T) {
+	stub := &stubLLMServer{
+		nativeTools: true,
+		chunks: []map[string]interface{}{
+			{
+				"id":     "chatcmpl-1",
+				"object": "chat.completion.chunk",
+				"choices": []interface{}{map[string]interface{}{
+					"index": 0,
+					"delta": map[string]interface{}{
+						"tool_calls": []interface{}{map[string]interface{}{
+							"index":    0,
+							"id":       "call_1",
+							"type":     "function",
+							"function": map[string]interface{}{"name": "read_file", "arguments": `{"path":"contracts/Counter.sol"}`},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	provider := newStubProvider(t, stub, OpenAICompatibleConfig{AuthHeader: "X-Api-Key", AuthValue: "secret"})
+	tools, schemas, calls := readFileTool()
+	observer := &recordingObserver{}
+
+	msg, toolCalls, results, err := provider.StreamAgentStep(context.Background(), []AIMessage{
+		{Role: "system", Content: "You are a Solidity assistant"},
+		{Role: "user", Content: "Show me the counter contract"},
+	}, "llama3.1:8b", tools, schemas, observer)
+	require.NoError(t, err)
+
+	require.Len(t, toolCalls, 1)
+	assert.Equal(t, "read_file", toolCalls[0].Function.Name)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, []map[string]interface{}{{"path": "contracts/Counter.sol"}}, *calls)
+	assert.Len(t, msg.ToolCalls, 1)
+
+	assert.Equal(t, "secret", stub.headers[0].Get("X-Api-Key"))
+	assert.Empty(t, stub.headers[0].Get("Authorization"))
+	assert.Contains(t, stub.requests[0], "tools")
+	assert.NotContains(t, stub.requests[0], "max_tokens")
+	assert.True(t, provider.SupportsNativeTools("llama3.1:8b"))
+}
+
+func TestOpenAICompatibleProviderPromptFallback(t *testing.T) {
+	stub := &stubLLMServer{
+		chunks: []map[string]interface{}{
+			contentChunk("Let me read it.\n<tool_"),
+			contentChunk("call>\n{\"name\": \"read_file\", \"arguments\": {\"path\": \"contracts/Counter.sol\"}}\n</tool_call>"),
+		},
+	}
+	provider := newStubProvider(t, stub, OpenAICompatibleConfig{})
+	tools, schemas, calls := readFileTool()
+	observer := &recordingObserver{}
+	messages := []AIMessage{
+		{Role: "system", Content: "You are a Solidity assistant"},
+		{Role: "user", Content: "Show me the counter contract"},
+	}
+
+	msg, toolCalls, results, err := provider.StreamAgentStep(context.Background(), messages, "gemma:2b", tools, schemas, observer)
+	require.NoError(t, err)
+
+	assert.False(t, provider.SupportsNativeTools("gemma:2b"))
+	assert.Equal(t, "Let me read it.", msg.Content)
+	assert.Equal(t, "Let me read it.\n", observer.content.String())
+	require.Len(t, toolCalls, 1)
+	assert.Equal(t, "read_file", toolCalls[0].Function.Name)
+	assert.JSONEq(t, `{"path": "contracts/Counter.sol"}`, toolCalls[0].Function.Arguments)
+	require.Len(t, results, 1)
+	assert.Equal(t, "pragma solidity ^0.8.0;", results[0].Result)
+	assert.Equal(t, []string{"read_file"}, observer.executed)
+	assert.Len(t, *calls, 1)
+
+	// The rejected request with tools is retried with the tools in the prompt
+	require.Len(t, stub.requests, 2)
+	assert.NotContains(t, stub.requests[1], "tools")
+	system := stub.requests[1]["messages"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, system["content"], `"name":"read_file"`)
+
+	// Following steps send the tool calls and results back as text
+	messages = append(messages, *msg, AIMessage{Role: "tool", Content: `"pragma solidity ^0.8.0;"`, ToolCallID: toolCalls[0].ID})
+	stub.chunks = []map[string]interface{}{contentChunk("The contract uses Solidity 0.8.")}
+	msg, toolCalls, _, err = provider.StreamAgentStep(context.Background(), messages, "gemma:2b", tools, schemas, observer)
+	require.NoError(t, err)
+	assert.Empty(t, toolCalls)
+	assert.Equal(t, "The contract uses Solidity 0.8.", msg.Content)
+
+	require.Len(t, stub.requests, 3)
+	sent := stub.requests[2]["messages"].([]interface{})
+	require.Len(t, sent, 4)
+	assistant := sent[2].(map[string]interface{})
+	assert.Contains(t, assistant["content"], toolCallOpenTag)
+	toolResponse := sent[3].(map[string]interface{})
+	assert.Equal(t, "user", toolResponse["role"])
+	assert.Contains(t, toolResponse["content"], `"name": "read_file"`)
+}
+
+func TestOpenAICompatibleProviderPromptMode(t *testing.T) {
+	stub := &stubLLMServer{nativeTools: true, chunks: []map[string]interface{}{contentChunk("Done")}}
+	provider := newStubProvider(t, stub, OpenAICompatibleConfig{ToolCalling: ToolCallingPrompt})
+	tools, schemas, _ := readFileTool()
+
+	_, _, _, err := provider.StreamAgentStep(context.Background(), []AIMessage{{Role: "user", Content: "Hello"}}, "llama3.1:8b", tools, schemas, nil)
+	require.NoError(t, err)
+	require.Len(t, stub.requests, 1)
+	assert.NotContains(t, stub.requests[0], "tools")
+	first := stub.requests[0]["messages"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "system", first["role"])
+}
+
+func TestOpenAICompatibleProviderListModels(t *testing.T) {
+	stub := &stubLLMServer{}
+
+	provider := newStubProvider(t, stub, OpenAICompatibleConfig{ContextWindow: 8192})
+	models, err := provider.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	assert.Equal(t, "llama3.1:8b", models[0].Name)
+	assert.Equal(t, 8192, models[0].MaxTokens)
+
+	provider = newStubProvider(t, stub, OpenAICompatibleConfig{Models: []string{"mistral:7b"}})
+	models, err = provider.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	assert.Equal(t, "mistral:7b", models[0].Name)
+}
+
+func TestNewOpenAICompatibleProviderValidation(t *testing.T) {
+	_, err := NewOpenAICompatibleProvider(OpenAICompatibleConfig{}, logger.NewDefault())
+	assert.Error(t, err)
+	_, err = NewOpenAICompatibleProvider(OpenAICompatibleConfig{BaseURL: "http://localhost:8000/v1", ToolCalling: "json"}, logger.NewDefault())
+	assert.Error(t, err)
+}
+
+func TestParsePromptToolCalls(t *testing.T) {
+	content, toolCalls := parsePromptToolCalls("Reading both.\n<tool_call>\n{\"name\": \"read_file\", \"arguments\": \"{\\\"path\\\": \\\"a.sol\\\"}\"}\n</tool_call>\n<tool_call>{\"name\": \"list_dir\"}")
+	assert.Equal(t, "Reading both.", content)
+	require.Len(t, toolCalls, 2)
+	assert.Equal(t, `{"path": "a.sol"}`, toolCalls[0].Function.Arguments)
+	assert.Equal(t, "list_dir", toolCalls[1].Function.Name)
+	assert.Equal(t, "{}", toolCalls[1].Function.Arguments)
+	assert.NotEqual(t, toolCalls[0].ID, toolCalls[1].ID)
+
+	content, toolCalls = parsePromptToolCalls("Use <tool_call>not json</tool_call> blocks")
+	assert.Empty(t, toolCalls)
+	assert.Equal(t, "Use <tool_call>not json</tool_call> blocks", content)
+}
+
+func TestToolCallStreamFilter(t *testing.T) {
+	filter := &toolCallStreamFilter{}
+	var visible strings.Builder
+	for _, chunk := range []string{"a <", "b> <tool", "_call>{\"name\":", "\"x\"}</tool_", "call> done <"} {
+		visible.WriteString(filter.Write(chunk))
+	}
+	visible.WriteString(filter.Flush())
+	assert.Equal(t, "a <b>  done <", visible.String())
+}
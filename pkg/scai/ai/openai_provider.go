@@ -16,13 +16,16 @@ import (
 type OpenAIProvider struct {
 	Client *openai.Client
 	Logger *logger.Logger
+	// MaxCompletionTokens limits the tokens generated per step, left to the server when zero
+	MaxCompletionTokens int
 }
 
 // NewOpenAIProvider creates a new OpenAI provider
 func NewOpenAIProvider(apiKey string, logger *logger.Logger) *OpenAIProvider {
 	return &OpenAIProvider{
-		Client: openai.NewClient(apiKey),
-		Logger: logger,
+		Client:              openai.NewClient(apiKey),
+		Logger:              logger,
+		MaxCompletionTokens: 32768,
 	}
 }
 
@@ -88,7 +91,7 @@ func (p *OpenAIProvider) StreamAgentStep(
 		Messages:  openAIMessages,
 		Tools:     openAITools,
 		Stream:    true,
-		MaxTokens: 32768,
+		MaxTokens: p.MaxCompletionTokens,
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -187,12 +190,30 @@ func (p *OpenAIProvider) StreamAgentStep(
 	}
 
 	// If there are tool calls, execute them and stream progress
+	toolCallResults := executeToolCalls(aiToolCalls, toolSchemas, observer, p.Logger)
+
+	// Always create one assistant message with content and tool calls
+	content := contentBuilder.String()
+
+	// Create the single assistant message with all tool calls linked to it
+	assistantMsg := &AIMessage{
+		Role:       "assistant",
+		Content:    content,
+		ToolCalls:  aiToolCalls, // All tool calls are linked to this single assistant message
+		ToolCallID: "",
+	}
+
+	return assistantMsg, aiToolCalls, toolCallResults, nil
+}
+
+// executeToolCalls runs the tool calls of an agent step with their handlers and streams the progress
+func executeToolCalls(toolCalls []AIToolCall, toolSchemas map[string]ToolSchema, observer AgentStepObserver, logger *logger.Logger) []ToolCallResult {
 	var toolCallResults []ToolCallResult
 	for _, toolCall := range toolCalls {
 		toolSchema, ok := toolSchemas[toolCall.Function.Name]
 		if !ok {
 			result := ToolCallResult{
-				ToolCall: openAIToolCallToAIToolCall(toolCall),
+				ToolCall: toolCall,
 				Result:   nil,
 				Error:    fmt.Errorf("Unknown tool function: %s", toolCall.Function.Name),
 			}
@@ -206,7 +227,7 @@ func (p *OpenAIProvider) StreamAgentStep(
 		err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args)
 		if err != nil {
 			result := ToolCallResult{
-				ToolCall: openAIToolCallToAIToolCall(toolCall),
+				ToolCall: toolCall,
 				Result:   nil,
 				Error:    err,
 			}
@@ -220,33 +241,19 @@ func (p *OpenAIProvider) StreamAgentStep(
 			observer.OnToolCallExecute(toolCall.ID, toolCall.Function.Name, args)
 		}
 		result, err := toolSchema.Handler(toolCall.Function.Name, args)
-		toolCallResult := ToolCallResult{
-			ToolCall: openAIToolCallToAIToolCall(toolCall),
+		toolCallResults = append(toolCallResults, ToolCallResult{
+			ToolCall: toolCall,
 			Result:   result,
 			Error:    err,
-		}
-		toolCallResults = append(toolCallResults, toolCallResult)
+		})
 		if observer != nil {
 			observer.OnToolCallResult(toolCall.ID, toolCall.Function.Name, result, err)
 		}
 		if err != nil {
-			p.Logger.Debugf("[StreamChat] Error in tool call: %v", err)
-			continue
+			logger.Debugf("[StreamChat] Error in tool call: %v", err)
 		}
 	}
-
-	// Always create one assistant message with content and tool calls
-	content := contentBuilder.String()
-
-	// Create the single assistant message with all tool calls linked to it
-	assistantMsg := &AIMessage{
-		Role:       "assistant",
-		Content:    content,
-		ToolCalls:  aiToolCalls, // All tool calls are linked to this single assistant message
-		ToolCallID: "",
-	}
-
-	return assistantMsg, aiToolCalls, toolCallResults, nil
+	return toolCallResults
 }
 
 // GetMaxTokens returns the max tokens for a given OpenAI model
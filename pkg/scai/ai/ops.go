@@ -0,0 +1,134 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// opsSystemPrompt is the system prompt of the operations assistant
+const opsSystemPrompt = `You are the operations assistant of ChainLaunch, a platform deploying and managing Hyperledger Fabric and Besu networks.
+You help operators understand the state of their nodes and networks and diagnose problems.
+
+Rules:
+- Ground every statement about nodes and networks on the output of your tools. Never guess a status, a block height or a log line.
+- Start by locating the nodes and networks the user refers to with list_nodes, list_networks or get_network_nodes, then look at their status, events, logs, metrics, channel configuration and blocks.
+- When diagnosing, compare the suspect node with healthy nodes of the same network, e.g. the block height of each peer.
+- Quote the log lines, events or metric values supporting your diagnosis and end with the most likely cause and the suggested fix.
+- Tools that change the state of a node (start_node, stop_node, restart_node) are NOT executed when you call them: they return an action that the user has to confirm. Only propose them when needed, explain why, and tell the user the action is waiting for confirmation. Never claim such an action was performed.`
+
+// SetOpsTools enables the operations assistant with the given tools
+func (s *AIChatService) SetOpsTools(opsTools *OpsTools) {
+	s.OpsTools = opsTools
+}
+
+// StreamOpsChat runs the operations assistant on a conversation. The assistant is not bound
+// to a project: the tools call the ChainLaunch services and the conversation is kept by the client.
+func (s *AIChatService) StreamOpsChat(ctx context.Context, messages []Message, observer AgentStepObserver, maxSteps int) error {
+	if s.OpsTools == nil {
+		return fmt.Errorf("operations assistant is not configured")
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("no messages provided for AI processing")
+	}
+
+	toolSchemas := s.OpsTools.ToolSchemas()
+	toolSchemasMap := make(map[string]ToolSchema, len(toolSchemas))
+	tools := make([]AITool, 0, len(toolSchemas))
+	for _, tool := range toolSchemas {
+		toolSchemasMap[tool.Name] = tool
+		tools = append(tools, AITool{
+			Type: "function",
+			Function: &AIFunctionDefinition{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+
+	chatMsgs := []AIMessage{{Role: "system", Content: opsSystemPrompt}}
+	for _, m := range messages {
+		if strings.TrimSpace(m.Content) == "" {
+			continue
+		}
+		role := "user"
+		if m.Sender == "assistant" {
+			role = "assistant"
+		}
+		chatMsgs = append(chatMsgs, AIMessage{Role: role, Content: m.Content})
+	}
+	if len(chatMsgs) <= 1 {
+		return fmt.Errorf("no valid messages found after filtering empty content")
+	}
+
+	tokenCount := estimateTokenCount(chatMsgs)
+	maxTokens := s.AIProvider.GetMaxTokens(s.Model)
+	if tokenCount > maxTokens {
+		return &MaxTokensExceededError{
+			Model:      s.Model,
+			TokenCount: tokenCount,
+			MaxTokens:  maxTokens,
+		}
+	}
+
+	if maxSteps <= 0 {
+		maxSteps = maxAgentSteps
+	}
+	for step := 0; step < maxSteps; step++ {
+		s.Logger.Debugf("[StreamOpsChat] Agent step: %d", step)
+		msg, toolCalls, toolCallResults, err := s.AIProvider.StreamAgentStep(ctx, chatMsgs, s.Model, tools, toolSchemasMap, observer)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			msg = &AIMessage{Role: "assistant"}
+		}
+		if msg.Content == "" {
+			msg.Content = "No content generated"
+		}
+		chatMsgs = append(chatMsgs, *msg)
+
+		if len(toolCalls) == 0 {
+			return nil
+		}
+		for i, toolCall := range toolCalls {
+			var result *ToolCallResult
+			if i < len(toolCallResults) {
+				result = &toolCallResults[i]
+			}
+			chatMsgs = append(chatMsgs, AIMessage{
+				Role:       "tool",
+				Content:    toolResultContent(result),
+				ToolCallID: toolCall.ID,
+			})
+		}
+	}
+
+	if observer != nil {
+		observer.OnMaxStepsReached()
+	}
+	return nil
+}
+
+// toolResultContent serializes the result of a tool call for the next agent step
+func toolResultContent(result *ToolCallResult) string {
+	if result == nil {
+		return "Tool call failed: Tool call result not available"
+	}
+	if result.Error != nil {
+		return fmt.Sprintf("Tool call failed: %s", result.Error.Error())
+	}
+	if result.Result == nil {
+		return "Tool call executed successfully"
+	}
+	if s, ok := result.Result.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(result.Result)
+	if err != nil {
+		return fmt.Sprintf("Tool call failed: could not encode result: %v", err)
+	}
+	return string(b)
+}
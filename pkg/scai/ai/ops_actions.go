@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// opsActionTTL is how long a mutating action waits for the user's confirmation
+const opsActionTTL = 10 * time.Minute
+
+// ErrOpsActionNotFound is returned when an action is unknown, expired or already handled
+var ErrOpsActionNotFound = fmt.Errorf("action not found or expired")
+
+// OpsAction is a mutating tool call of the operations assistant waiting for the user's confirmation
+type OpsAction struct {
+	ID          string                 `json:"id"`
+	Tool        string                 `json:"tool"`
+	Arguments   map[string]interface{} `json:"arguments"`
+	Description string                 `json:"description"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	ExpiresAt   time.Time              `json:"expiresAt"`
+
+	run func(ctx context.Context) (interface{}, error)
+}
+
+// opsActions keeps the pending actions in memory until they are confirmed, rejected or expire
+type opsActions struct {
+	mu      sync.Mutex
+	pending map[string]*OpsAction
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func newOpsActions(ttl time.Duration) *opsActions {
+	return &opsActions{
+		pending: make(map[string]*OpsAction),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// add registers an action and returns it
+func (a *opsActions) add(tool, description string, args map[string]interface{}, run func(ctx context.Context) (interface{}, error)) *OpsAction {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+
+	now := a.now()
+	action := &OpsAction{
+		ID:          uuid.New().String(),
+		Tool:        tool,
+		Arguments:   args,
+		Description: description,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(a.ttl),
+		run:         run,
+	}
+	a.pending[action.ID] = action
+	return action
+}
+
+// take removes a pending action so it is handled only once
+func (a *opsActions) take(id string) (*OpsAction, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+
+	action, ok := a.pending[id]
+	if !ok {
+		return nil, ErrOpsActionNotFound
+	}
+	delete(a.pending, id)
+	return action, nil
+}
+
+// list returns the pending actions, oldest first
+func (a *opsActions) list() []OpsAction {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+
+	actions := make([]OpsAction, 0, len(a.pending))
+	for _, action := range a.pending {
+		actions = append(actions, *action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].CreatedAt.Before(actions[j].CreatedAt)
+	})
+	return actions
+}
+
+// expire drops the actions past their deadline, the lock must be held
+func (a *opsActions) expire() {
+	now := a.now()
+	for id, action := range a.pending {
+		if now.After(action.ExpiresAt) {
+			delete(a.pending, id)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsActions(t *testing.T) {
+	actions := newOpsActions(time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	actions.now = func() time.Time { return now }
+
+	runs := 0
+	action := actions.add("restart_node", "restart node peer0-org2 (node 3)", map[string]interface{}{"node_id": float64(3)}, func(ctx context.Context) (interface{}, error) {
+		runs++
+		return "restarted", nil
+	})
+	require.NotEmpty(t, action.ID)
+	assert.Equal(t, now.Add(time.Minute), action.ExpiresAt)
+
+	pending := actions.list()
+	require.Len(t, pending, 1)
+	assert.Equal(t, "restart_node", pending[0].Tool)
+
+	t.Run("an action is taken once", func(t *testing.T) {
+		taken, err := actions.take(action.ID)
+		require.NoError(t, err)
+		result, err := taken.run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "restarted", result)
+		assert.Equal(t, 1, runs)
+
+		_, err = actions.take(action.ID)
+		assert.Equal(t, ErrOpsActionNotFound, err)
+		assert.Empty(t, actions.list())
+	})
+
+	t.Run("expired actions are dropped", func(t *testing.T) {
+		expired := actions.add("stop_node", "stop node orderer0 (node 1)", nil, func(ctx context.Context) (interface{}, error) {
+			return nil, errors.New("must not run")
+		})
+		now = now.Add(2 * time.Minute)
+		_, err := actions.take(expired.ID)
+		assert.Equal(t, ErrOpsActionNotFound, err)
+		assert.Empty(t, actions.list())
+	})
+}
+
+func TestToolResultContent(t *testing.T) {
+	assert.Equal(t, "Tool call failed: Tool call result not available", toolResultContent(nil))
+	assert.Equal(t, "Tool call failed: node not found", toolResultContent(&ToolCallResult{Error: errors.New("node not found")}))
+	assert.Equal(t, "line 1\nline 2", toolResultContent(&ToolCallResult{Result: "line 1\nline 2"}))
+	assert.Equal(t, `{"status":"RUNNING"}`, toolResultContent(&ToolCallResult{Result: map[string]string{"status": "RUNNING"}}))
+}
+
+func TestIntArg(t *testing.T) {
+	n, err := intArg(map[string]interface{}{"node_id": float64(7)}, "node_id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	n, err = intArg(map[string]interface{}{"node_id": "12"}, "node_id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+
+	_, err = intArg(map[string]interface{}{}, "node_id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// OpsChatRequest is a conversation with the operations assistant
+type OpsChatRequest struct {
+	Messages []ChatMessage `json:"messages"`
+}
+
+// OpsActionResult is the outcome of a confirmed action
+type OpsActionResult struct {
+	Action OpsAction   `json:"action"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// OpsChat godoc
+// @Summary      Chat with the operations assistant
+// @Description  Stream a conversation with the operations assistant using Server-Sent Events (SSE). Its tools read the nodes, logs, events, metrics, channel configuration and blocks; node start, stop and restart return an action to confirm.
+// @Tags         ai
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        request body OpsChatRequest true "Conversation messages"
+// @Success      200 {string} string "SSE stream of chat responses"
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /ai/ops/chat [post]
+func (h *AIHandler) OpsChat(w http.ResponseWriter, r *http.Request) error {
+	if h.AIChatService.OpsTools == nil {
+		return errors.NewValidationError("operations assistant is not configured", nil)
+	}
+	var req OpsChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	var messages []Message
+	for _, m := range req.Messages {
+		messages = append(messages, Message{Sender: m.Role, Content: m.Content})
+	}
+	if len(messages) == 0 || messages[len(messages)-1].Sender != "user" {
+		return errors.NewValidationError("the last message must be a user message", nil)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("streaming unsupported")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	observer := &sseAgentStepObserver{w: w, flusher: flusher}
+	err := h.AIChatService.StreamOpsChat(r.Context(), messages, observer, 0)
+	if err != nil && err != io.EOF {
+		if maxErr, ok := err.(*MaxTokensExceededError); ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":      "max_tokens_exceeded",
+				"message":    "The conversation is too long for the selected model.",
+				"model":      maxErr.Model,
+				"tokenCount": maxErr.TokenCount,
+				"maxTokens":  maxErr.MaxTokens,
+			})
+			return nil
+		}
+		return fmt.Errorf("chat error: %w", err)
+	}
+	return nil
+}
+
+// ListOpsActions godoc
+// @Summary      List pending operations actions
+// @Description  List the actions proposed by the operations assistant that wait for confirmation
+// @Tags         ai
+// @Produce      json
+// @Success      200 {array} OpsAction
+// @Failure      400 {object} response.ErrorResponse
+// @Router       /ai/ops/actions [get]
+func (h *AIHandler) ListOpsActions(w http.ResponseWriter, r *http.Request) error {
+	if h.AIChatService.OpsTools == nil {
+		return errors.NewValidationError("operations assistant is not configured", nil)
+	}
+	return response.WriteJSON(w, http.StatusOK, h.AIChatService.OpsTools.PendingActions())
+}
+
+// ConfirmOpsAction godoc
+// @Summary      Confirm an operations action
+// @Description  Run an action proposed by the operations assistant
+// @Tags         ai
+// @Produce      json
+// @Param        actionId path string true "Action ID"
+// @Success      200 {object} OpsActionResult
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /ai/ops/actions/{actionId}/confirm [post]
+func (h *AIHandler) ConfirmOpsAction(w http.ResponseWriter, r *http.Request) error {
+	if h.AIChatService.OpsTools == nil {
+		return errors.NewValidationError("operations assistant is not configured", nil)
+	}
+	action, result, err := h.AIChatService.OpsTools.ConfirmAction(r.Context(), chi.URLParam(r, "actionId"))
+	if err != nil {
+		if err == ErrOpsActionNotFound {
+			return errors.NewNotFoundError(err.Error(), nil)
+		}
+		return errors.NewInternalError("failed to run action", err, map[string]interface{}{
+			"action": action.Description,
+		})
+	}
+	return response.WriteJSON(w, http.StatusOK, OpsActionResult{
+		Action: *action,
+		Result: result,
+	})
+}
+
+// RejectOpsAction godoc
+// @Summary      Reject an operations action
+// @Description  Drop an action proposed by the operations assistant without running it
+// @Tags         ai
+// @Param        actionId path string true "Action ID"
+// @Success      204
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Router       /ai/ops/actions/{actionId}/reject [post]
+func (h *AIHandler) RejectOpsAction(w http.ResponseWriter, r *http.Request) error {
+	if h.AIChatService.OpsTools == nil {
+		return errors.NewValidationError("operations assistant is not configured", nil)
+	}
+	if err := h.AIChatService.OpsTools.RejectAction(chi.URLParam(r, "actionId")); err != nil {
+		return errors.NewNotFoundError(err.Error(), nil)
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
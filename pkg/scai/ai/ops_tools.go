@@ -0,0 +1,501 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	metricscommon "github.com/chainlaunch/chainlaunch/pkg/metrics/common"
+	networksservice "github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	nodesservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+)
+
+const (
+	// opsToolTimeout bounds the service calls of a read tool
+	opsToolTimeout = 30 * time.Second
+	// opsActionTimeout bounds a confirmed action, starting a node can take a while
+	opsActionTimeout = 5 * time.Minute
+	// opsMaxLogLines is the maximum number of log lines returned to the model
+	opsMaxLogLines = 500
+	// opsMaxOutputLength keeps large results from filling the model context
+	opsMaxOutputLength = 20000
+)
+
+// OpsTools exposes the ChainLaunch node, metrics and network services as tools of the
+// operations assistant. Mutating tools do not run directly: they register an action
+// that has to be confirmed by the user.
+type OpsTools struct {
+	Nodes    *nodesservice.NodeService
+	Metrics  metricscommon.Service
+	Networks *networksservice.NetworkService
+	Logger   *logger.Logger
+
+	actions *opsActions
+}
+
+// NewOpsTools creates the operations assistant tools. The metrics service is optional.
+func NewOpsTools(nodes *nodesservice.NodeService, metrics metricscommon.Service, networks *networksservice.NetworkService, logger *logger.Logger) *OpsTools {
+	return &OpsTools{
+		Nodes:    nodes,
+		Metrics:  metrics,
+		Networks: networks,
+		Logger:   logger,
+		actions:  newOpsActions(opsActionTTL),
+	}
+}
+
+// opsNodeSummary is the part of a node the model needs to find it and check its health
+type opsNodeSummary struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Platform     string `json:"platform"`
+	NodeType     string `json:"nodeType"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	MSPID        string `json:"mspId,omitempty"`
+}
+
+func summarizeNode(node *nodesservice.NodeResponse) opsNodeSummary {
+	summary := opsNodeSummary{
+		ID:           node.ID,
+		Name:         node.Name,
+		Platform:     node.Platform,
+		NodeType:     string(node.NodeType),
+		Status:       node.Status,
+		ErrorMessage: node.ErrorMessage,
+		Endpoint:     node.Endpoint,
+	}
+	if node.FabricPeer != nil {
+		summary.MSPID = node.FabricPeer.MSPID
+	}
+	if node.FabricOrderer != nil {
+		summary.MSPID = node.FabricOrderer.MSPID
+	}
+	return summary
+}
+
+// ToolSchemas returns the tools of the operations assistant
+func (t *OpsTools) ToolSchemas() []ToolSchema {
+	nodeIDParam := map[string]interface{}{
+		"type":        "integer",
+		"description": "ID of the node, as returned by list_nodes.",
+	}
+	networkIDParam := map[string]interface{}{
+		"type":        "integer",
+		"description": "ID of the network, as returned by list_networks.",
+	}
+
+	tools := []ToolSchema{
+		{
+			Name:        "list_networks",
+			Description: "List the blockchain networks managed by ChainLaunch with their platform and status.",
+			Parameters: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				result, err := t.Networks.ListNetworks(ctx, networksservice.ListNetworksParams{})
+				if err != nil {
+					return nil, err
+				}
+				networks := make([]map[string]interface{}, 0, len(result.Networks))
+				for _, network := range result.Networks {
+					networks = append(networks, map[string]interface{}{
+						"id":       network.ID,
+						"name":     network.Name,
+						"platform": network.Platform,
+						"status":   network.Status,
+					})
+				}
+				return networks, nil
+			}),
+		},
+		{
+			Name:        "list_nodes",
+			Description: "List the nodes managed by ChainLaunch with their type, status, last error and MSP ID. Use it to find the ID of a node from its name or organization.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"platform": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{string(nodetypes.PlatformFabric), string(nodetypes.PlatformBesu), string(nodetypes.PlatformFabricX)},
+						"description": "Only list the nodes of this platform.",
+					},
+				},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				var platform *nodetypes.BlockchainPlatform
+				if p, ok := args["platform"].(string); ok && p != "" {
+					value := nodetypes.BlockchainPlatform(strings.ToUpper(p))
+					platform = &value
+				}
+				result, err := t.Nodes.ListNodes(ctx, platform, 1, 1000)
+				if err != nil {
+					return nil, err
+				}
+				nodes := make([]opsNodeSummary, 0, len(result.Items))
+				for i := range result.Items {
+					nodes = append(nodes, summarizeNode(&result.Items[i]))
+				}
+				return nodes, nil
+			}),
+		},
+		{
+			Name:        "get_network_nodes",
+			Description: "List the nodes of a network with their role and status in the network.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"network_id": networkIDParam,
+				},
+				"required": []string{"network_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				networkNodes, err := t.Networks.GetNetworkNodes(ctx, networkID)
+				if err != nil {
+					return nil, err
+				}
+				nodes := make([]map[string]interface{}, 0, len(networkNodes))
+				for _, networkNode := range networkNodes {
+					entry := map[string]interface{}{
+						"nodeId":        networkNode.NodeID,
+						"role":          networkNode.Role,
+						"networkStatus": networkNode.Status,
+					}
+					if networkNode.Node != nil {
+						entry["node"] = summarizeNode(networkNode.Node)
+					}
+					nodes = append(nodes, entry)
+				}
+				return nodes, nil
+			}),
+		},
+		{
+			Name:        "get_node",
+			Description: "Get the full configuration and status of a node.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_id": nodeIDParam,
+				},
+				"required": []string{"node_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				return t.Nodes.GetNode(ctx, nodeID)
+			}),
+		},
+		{
+			Name:        "get_node_logs",
+			Description: "Get the last lines of the logs of a node.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_id": nodeIDParam,
+					"lines": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Number of lines to return, 100 by default and %d at most.", opsMaxLogLines),
+					},
+				},
+				"required": []string{"node_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				lines := 100
+				if n, ok := args["lines"].(float64); ok && n > 0 {
+					lines = int(n)
+				}
+				if lines > opsMaxLogLines {
+					lines = opsMaxLogLines
+				}
+				logChan, err := t.Nodes.TailLogs(ctx, nodeID, lines, false, "")
+				if err != nil {
+					return nil, err
+				}
+				var sb strings.Builder
+				for {
+					select {
+					case line, ok := <-logChan:
+						if !ok {
+							return truncateOpsOutput(sb.String()), nil
+						}
+						sb.WriteString(line)
+						if !strings.HasSuffix(line, "\n") {
+							sb.WriteString("\n")
+						}
+					case <-ctx.Done():
+						return truncateOpsOutput(sb.String()), nil
+					}
+				}
+			}),
+		},
+		{
+			Name:        "get_node_events",
+			Description: "Get the latest lifecycle events of a node (starts, stops, errors, upgrades, configuration drift), newest first.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_id": nodeIDParam,
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of events to return, 20 by default.",
+					},
+				},
+				"required": []string{"node_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				limit := 20
+				if n, ok := args["limit"].(float64); ok && n > 0 {
+					limit = int(n)
+				}
+				return t.Nodes.GetNodeEvents(ctx, nodeID, 1, limit)
+			}),
+		},
+		{
+			Name:        "get_channel_config",
+			Description: "Get the current channel configuration of a Fabric network: organizations, orderers, policies and batch settings.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"network_id": networkIDParam,
+				},
+				"required": []string{"network_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return t.Networks.GetFabricCurrentChannelConfig(networkID)
+			}),
+		},
+		{
+			Name:        "get_chain_info",
+			Description: "Get the block height and the latest block hashes of a Fabric network.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"network_id": networkIDParam,
+				},
+				"required": []string{"network_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				return t.Networks.GetFabricChainInfo(ctx, networkID)
+			}),
+		},
+		{
+			Name:        "get_blocks",
+			Description: "Inspect the blocks of a Fabric network. Returns the given block, or the latest blocks when no block number is set.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"network_id": networkIDParam,
+					"block_number": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of the block to inspect.",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of latest blocks to return when no block number is set, 5 by default.",
+					},
+				},
+				"required": []string{"network_id"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				networkID, err := intArg(args, "network_id")
+				if err != nil {
+					return nil, err
+				}
+				if n, ok := args["block_number"].(float64); ok && n >= 0 {
+					return t.Networks.GetFabricBlock(ctx, networkID, uint64(n))
+				}
+				limit := int32(5)
+				if n, ok := args["limit"].(float64); ok && n > 0 {
+					limit = int32(n)
+				}
+				blocks, total, err := t.Networks.GetFabricBlocks(ctx, networkID, limit, 0, true)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{
+					"total":  total,
+					"blocks": blocks,
+				}, nil
+			}),
+		},
+	}
+
+	if t.Metrics != nil {
+		tools = append(tools, ToolSchema{
+			Name:        "query_node_metrics",
+			Description: "Run a PromQL query against the Prometheus metrics of a node, e.g. ledger_blockchain_height or rate(endorser_proposals_received[5m]). The node job filter is added to the query.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_id": nodeIDParam,
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "PromQL metric name or expression without job selector.",
+					},
+				},
+				"required": []string{"node_id", "query"},
+			},
+			Handler: t.readTool(func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+				nodeID, err := intArg(args, "node_id")
+				if err != nil {
+					return nil, err
+				}
+				query, _ := args["query"].(string)
+				if query == "" {
+					return nil, fmt.Errorf("query is required")
+				}
+				return t.Metrics.QueryForNode(ctx, nodeID, query)
+			}),
+		})
+	}
+
+	tools = append(tools,
+		t.nodeActionTool("start_node", "Start a stopped node.", func(ctx context.Context, nodeID int64) (interface{}, error) {
+			return t.Nodes.StartNode(ctx, nodeID)
+		}),
+		t.nodeActionTool("stop_node", "Stop a running node.", func(ctx context.Context, nodeID int64) (interface{}, error) {
+			return t.Nodes.StopNode(ctx, nodeID)
+		}),
+		t.nodeActionTool("restart_node", "Stop and start a node.", func(ctx context.Context, nodeID int64) (interface{}, error) {
+			if _, err := t.Nodes.StopNode(ctx, nodeID); err != nil {
+				return nil, fmt.Errorf("failed to stop node: %w", err)
+			}
+			return t.Nodes.StartNode(ctx, nodeID)
+		}),
+	)
+	return tools
+}
+
+// readTool runs a read-only tool with a bounded context
+func (t *OpsTools) readTool(fn func(ctx context.Context, args map[string]interface{}) (interface{}, error)) func(string, map[string]interface{}) (interface{}, error) {
+	return func(_ string, args map[string]interface{}) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), opsToolTimeout)
+		defer cancel()
+		return fn(ctx, args)
+	}
+}
+
+// nodeActionTool returns a mutating tool on a node. Calling it only registers an action,
+// which runs once the user confirms it.
+func (t *OpsTools) nodeActionTool(name, description string, run func(ctx context.Context, nodeID int64) (interface{}, error)) ToolSchema {
+	return ToolSchema{
+		Name:        name,
+		Description: description + " This action requires the confirmation of the user: the call returns an action that is only executed once the user confirms it.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"node_id": map[string]interface{}{
+					"type":        "integer",
+					"description": "ID of the node, as returned by list_nodes.",
+				},
+				"reason": map[string]interface{}{
+					"type":        "string",
+					"description": "Why this action is needed, shown to the user.",
+				},
+			},
+			"required": []string{"node_id", "reason"},
+		},
+		Handler: func(_ string, args map[string]interface{}) (interface{}, error) {
+			nodeID, err := intArg(args, "node_id")
+			if err != nil {
+				return nil, err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), opsToolTimeout)
+			defer cancel()
+			node, err := t.Nodes.GetNode(ctx, nodeID)
+			if err != nil {
+				return nil, err
+			}
+
+			reason, _ := args["reason"].(string)
+			description := fmt.Sprintf("%s %s (node %d)", strings.ReplaceAll(name, "_", " "), node.Name, node.ID)
+			if reason != "" {
+				description += ": " + reason
+			}
+			action := t.actions.add(name, description, args, func(ctx context.Context) (interface{}, error) {
+				return run(ctx, nodeID)
+			})
+			return map[string]interface{}{
+				"status":      "confirmation_required",
+				"actionId":    action.ID,
+				"description": action.Description,
+				"expiresAt":   action.ExpiresAt,
+				"message":     "The action has NOT been executed. Ask the user to confirm it.",
+			}, nil
+		},
+	}
+}
+
+// PendingActions returns the actions waiting for the user's confirmation
+func (t *OpsTools) PendingActions() []OpsAction {
+	return t.actions.list()
+}
+
+// ConfirmAction runs a pending action
+func (t *OpsTools) ConfirmAction(ctx context.Context, actionID string) (*OpsAction, interface{}, error) {
+	action, err := t.actions.take(actionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	t.Logger.Infof("Running confirmed ops action %s: %s", action.ID, action.Description)
+
+	ctx, cancel := context.WithTimeout(ctx, opsActionTimeout)
+	defer cancel()
+	result, err := action.run(ctx)
+	return action, result, err
+}
+
+// RejectAction drops a pending action without running it
+func (t *OpsTools) RejectAction(actionID string) error {
+	_, err := t.actions.take(actionID)
+	return err
+}
+
+// intArg reads an integer tool argument, sent as a JSON number
+func intArg(args map[string]interface{}, name string) (int64, error) {
+	switch v := args[name].(type) {
+	case float64:
+		return int64(v), nil
+	case string:
+		var n int64
+		if _, err := fmt.Sscan(v, &n); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%s must be an integer", name)
+}
+
+// truncateOpsOutput keeps the end of long outputs, the most recent log lines
+func truncateOpsOutput(output string) string {
+	if len(output) <= opsMaxOutputLength {
+		return output
+	}
+	return "[truncated]\n" + output[len(output)-opsMaxOutputLength:]
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projecttests"
+)
+
+// maxReportedFailures is the number of failing tests returned by the run_tests tool
+const maxReportedFailures = 20
+
+// SetTestService enables the run_tests tool with the given test service
+func (s *AIChatService) SetTestService(testService *projecttests.Service) {
+	s.TestService = testService
+}
+
+// runTestsTool returns the tool running the unit tests of the project and comparing
+// the results with the previously tested commit
+func (s *AIChatService) runTestsTool(projectRoot string) ToolSchema {
+	return ToolSchema{
+		Name:        "run_tests",
+		Description: "Run the unit tests of the project in its container. Returns the number of passed, failed and skipped tests, the coverage, the failing tests with their message, and the regressions and coverage change compared to the previously tested commit.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"explanation": map[string]interface{}{
+					"type":        "string",
+					"description": "One sentence explanation as to why the tests are run.",
+				},
+			},
+		},
+		Handler: func(toolName string, args map[string]interface{}) (interface{}, error) {
+			ctx := context.Background()
+			project, err := s.Queries.GetProjectBySlug(ctx, filepath.Base(projectRoot))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get project: %w", err)
+			}
+			run, err := s.TestService.RunTests(ctx, project.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			failures := []map[string]interface{}{}
+			for _, c := range run.Cases {
+				if c.Status != projecttests.StatusFailed {
+					continue
+				}
+				if len(failures) == maxReportedFailures {
+					break
+				}
+				failures = append(failures, map[string]interface{}{
+					"suite":   c.Suite,
+					"name":    c.Name,
+					"message": c.Message,
+				})
+			}
+			result := map[string]interface{}{
+				"status":   run.Status,
+				"commit":   run.CommitHash,
+				"dirty":    run.Dirty,
+				"summary":  run.Summary,
+				"failures": failures,
+				"exitCode": run.ExitCode,
+			}
+			if run.Coverage != nil {
+				result["coverage"] = *run.Coverage
+			}
+			if run.Error != "" {
+				result["error"] = run.Error
+				// Without parsed results the output is the only way to understand what happened
+				if run.Status == projecttests.StatusError {
+					result["output"] = run.Output
+				}
+			}
+
+			comparison, err := s.TestService.Compare(ctx, project.ID, "", run.CommitHash)
+			if err != nil && !errors.Is(err, projecttests.ErrRunNotFound) {
+				return nil, err
+			}
+			if comparison != nil {
+				previous := map[string]interface{}{
+					"commit":      comparison.From.CommitHash,
+					"summary":     comparison.From.Summary,
+					"regressions": comparison.Changes.Regressions,
+					"fixed":       comparison.Changes.Fixed,
+					"newFailures": comparison.Changes.NewFailures,
+				}
+				if comparison.CoverageDelta != nil {
+					previous["coverageDelta"] = *comparison.CoverageDelta
+				}
+				result["comparedToPrevious"] = previous
+			}
+			return result, nil
+		},
+	}
+}
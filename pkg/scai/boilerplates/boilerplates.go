@@ -32,7 +32,20 @@ type BoilerplateConfig struct {
 	RepoName        string   `yaml:"repoName" json:"repoName"`
 	RepoPath        string   `yaml:"repoPath,omitempty" json:"repoPath,omitempty"`
 	ValidateCommand string   `yaml:"validateCommand,omitempty" json:"validateCommand,omitempty"`
-	SystemPrompt    string   `yaml:"systemPrompt" json:"systemPrompt"`
+	// TestCommand runs the unit tests of the project inside the container
+	TestCommand string `yaml:"testCommand,omitempty" json:"testCommand,omitempty"`
+	// TestReportFormat is the format of the test results: go-test-json (read from the
+	// output of the test command) or junit (read from TestReportPath)
+	TestReportFormat string `yaml:"testReportFormat,omitempty" json:"testReportFormat,omitempty"`
+	// TestReportPath is the JUnit XML report written by the test command, relative to the project
+	TestReportPath string `yaml:"testReportPath,omitempty" json:"testReportPath,omitempty"`
+	// CoverageFile is the coverage report written by the test command, relative to the project.
+	// Go cover profiles, Istanbul JSON summaries and LCOV files are supported.
+	CoverageFile string `yaml:"coverageFile,omitempty" json:"coverageFile,omitempty"`
+	// ProductionDockerfile builds the chaincode-as-a-service image of a promoted project
+	// that has no Dockerfile of its own
+	ProductionDockerfile string `yaml:"productionDockerfile,omitempty" json:"productionDockerfile,omitempty"`
+	SystemPrompt         string `yaml:"systemPrompt" json:"systemPrompt"`
 }
 
 // BoilerplatesConfig represents the top-level configuration structure
@@ -268,6 +268,34 @@ type ValidationResult struct {
 
 // ValidateProject executes the validation command in the project container
 func (r *Runner) ValidateProject(ctx context.Context, projectID string, validateCommand string) (*ValidationResult, error) {
+	out, err := r.ExecCommand(ctx, projectID, validateCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	success := out.ExitCode == 0
+	result := &ValidationResult{
+		Success:  success,
+		Output:   out.Output,
+		ExitCode: out.ExitCode,
+	}
+
+	if !success {
+		result.Error = fmt.Sprintf("Validation command failed with exit code %d", out.ExitCode)
+	}
+
+	return result, nil
+}
+
+// CommandOutput is the output and exit code of a command run in the project container
+type CommandOutput struct {
+	Output   string `json:"output"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// ExecCommand runs a command in the project container and waits for it to exit.
+// A non-zero exit code is not an error.
+func (r *Runner) ExecCommand(ctx context.Context, projectID string, command string) (*CommandOutput, error) {
 	idInt64, _ := parseProjectID(projectID)
 	proj, err := r.queries.GetProject(ctx, idInt64)
 	if err != nil {
@@ -284,9 +312,9 @@ func (r *Runner) ValidateProject(ctx context.Context, projectID string, validate
 		return nil, fmt.Errorf("container not found for project %s: %w", projectID, err)
 	}
 
-	// Execute the validation command in the container
+	// Execute the command in the container
 	execResp, err := r.docker.ContainerExecCreate(ctx, proj.ContainerID.String, container.ExecOptions{
-		Cmd:          []string{"sh", "-c", validateCommand},
+		Cmd:          []string{"sh", "-c", command},
 		WorkingDir:   "/app",
 		AttachStdout: true,
 		AttachStderr: true,
@@ -337,20 +365,10 @@ func (r *Runner) ValidateProject(ctx context.Context, projectID string, validate
 		return nil, fmt.Errorf("failed to inspect exec instance: %w", err)
 	}
 
-	outputStr := output.String()
-	success := execInspectResp.ExitCode == 0
-
-	result := &ValidationResult{
-		Success:  success,
-		Output:   outputStr,
+	return &CommandOutput{
+		Output:   output.String(),
 		ExitCode: execInspectResp.ExitCode,
-	}
-
-	if !success {
-		result.Error = fmt.Sprintf("Validation command failed with exit code %d", execInspectResp.ExitCode)
-	}
-
-	return result, nil
+	}, nil
 }
 
 // CommandResult represents the result of a command execution
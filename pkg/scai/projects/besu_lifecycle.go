@@ -0,0 +1,338 @@
+package projects
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projectrunner"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// besuDeployConfigFile is the optional project file listing the contracts deployed
+// on start, in order, with their constructor arguments
+const besuDeployConfigFile = "chainlaunch.deploy.json"
+
+// besuArtifactsDir is where Hardhat writes the compiled contracts of the project sources
+const besuArtifactsDir = "artifacts/contracts"
+
+// besuDeployConfig is the content of besuDeployConfigFile
+type besuDeployConfig struct {
+	// KeyID is the key signing the deployments, the key of a network node when unset
+	KeyID     int64                    `json:"keyId,omitempty"`
+	Contracts []besuContractDeployment `json:"contracts"`
+}
+
+type besuContractDeployment struct {
+	Name string            `json:"name"`
+	Args []json.RawMessage `json:"args"`
+}
+
+// besuArtifact is a contract compiled by Hardhat
+type besuArtifact struct {
+	ContractName string          `json:"contractName"`
+	SourceName   string          `json:"sourceName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+	// DeployedBytecode is the runtime code the contract leaves on chain
+	DeployedBytecode string `json:"deployedBytecode"`
+}
+
+// BesuLifecycle implements PlatformLifecycle for Besu (EVM) networks
+type BesuLifecycle struct {
+	queries         *db.Queries
+	logger          *zap.Logger
+	runner          *projectrunner.Runner
+	networkService  *service.NetworkService
+	contractService *chainlaunchdeploy.ContractService
+}
+
+// NewBesuLifecycle creates a new BesuLifecycle instance
+func NewBesuLifecycle(queries *db.Queries, logger *zap.Logger, runner *projectrunner.Runner, networkService *service.NetworkService, contractService *chainlaunchdeploy.ContractService) *BesuLifecycle {
+	return &BesuLifecycle{
+		queries:         queries,
+		logger:          logger,
+		runner:          runner,
+		networkService:  networkService,
+		contractService: contractService,
+	}
+}
+
+// PreStart checks the network has a running node and passes its JSON-RPC endpoint
+// to the container as BESU_RPC_URL
+func (b *BesuLifecycle) PreStart(ctx context.Context, params PreStartParams) (*PreStartResult, error) {
+	b.logger.Info("PreStart hook for Besu project",
+		zap.Int64("projectID", params.ProjectID),
+		zap.String("projectName", params.ProjectName),
+		zap.String("boilerplate", params.Boilerplate),
+	)
+
+	if params.Platform != "besu" {
+		return nil, fmt.Errorf("project is not associated with a Besu network")
+	}
+	if b.contractService == nil {
+		return nil, fmt.Errorf("contract service is not configured")
+	}
+
+	rpcURL, err := b.contractService.RPCURL(ctx, params.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network RPC endpoint: %w", err)
+	}
+	// The loopback address of the node is not reachable from the container
+	if u, err := url.Parse(rpcURL); err == nil && u.Hostname() == "127.0.0.1" && params.HostIP != "" {
+		u.Host = fmt.Sprintf("%s:%s", params.HostIP, u.Port())
+		rpcURL = u.String()
+	}
+
+	env := make(map[string]string, len(params.Environment)+1)
+	for k, v := range params.Environment {
+		env[k] = v
+	}
+	env["BESU_RPC_URL"] = rpcURL
+	return &PreStartResult{Environment: env}, nil
+}
+
+// PostStart compiles and tests the project inside the container, then deploys the
+// compiled contracts to the network and registers them for the project. Contracts whose
+// code didn't change since their last deployment by the project are not deployed again.
+func (b *BesuLifecycle) PostStart(ctx context.Context, params PostStartParams) error {
+	b.logger.Info("PostStart hook for Besu project",
+		zap.Int64("projectID", params.ProjectID),
+		zap.String("projectName", params.ProjectName),
+		zap.String("containerID", params.ContainerID),
+	)
+
+	projectID := fmt.Sprintf("%d", params.ProjectID)
+	if _, err := os.Stat(filepath.Join(params.ProjectDir, "node_modules")); os.IsNotExist(err) {
+		result, err := b.runner.RunCommandInContainer(ctx, projectID, "npm install --no-audit --no-fund", false)
+		if err != nil {
+			return fmt.Errorf("failed to install dependencies: %w", err)
+		}
+		if success, _ := result["success"].(bool); !success {
+			return fmt.Errorf("failed to install dependencies: %v", result["output"])
+		}
+	}
+	if params.ValidateCommand != "" {
+		result, err := b.runner.ValidateProject(ctx, projectID, params.ValidateCommand)
+		if err != nil {
+			return fmt.Errorf("failed to build project: %w", err)
+		}
+		if !result.Success {
+			return fmt.Errorf("%s: %s", result.Error, result.Output)
+		}
+	}
+
+	artifacts, err := loadBesuArtifacts(filepath.Join(params.ProjectDir, besuArtifactsDir))
+	if err != nil {
+		return err
+	}
+	config, err := loadBesuDeployConfig(params.ProjectDir)
+	if err != nil {
+		return err
+	}
+	deployments, err := besuDeployments(artifacts, config)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		b.logger.Info("No contract to deploy", zap.Int64("projectID", params.ProjectID))
+		return nil
+	}
+
+	keyID := config.KeyID
+	if keyID == 0 {
+		keyID, err = b.nodeKeyID(ctx, params.NetworkID)
+		if err != nil {
+			return err
+		}
+	}
+
+	registered, err := b.queries.ListBesuContractsByProject(ctx, sql.NullInt64{Int64: params.ProjectID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to list project contracts: %w", err)
+	}
+
+	for _, deployment := range deployments {
+		artifact := artifacts[deployment.Name]
+		if existing := unchangedBesuContract(registered, params.NetworkID, artifact); existing != nil {
+			b.logger.Info("Project contract unchanged, skipping deployment",
+				zap.Int64("projectID", params.ProjectID),
+				zap.String("contract", existing.Name),
+				zap.String("address", existing.Address),
+			)
+			continue
+		}
+		// The source is only kept for reference
+		source, _ := os.ReadFile(filepath.Join(params.ProjectDir, artifact.SourceName))
+		contract, err := b.contractService.DeployContract(ctx, chainlaunchdeploy.DeployContractParams{
+			NetworkID:       params.NetworkID,
+			Name:            artifact.ContractName,
+			ABI:             string(artifact.ABI),
+			Bytecode:        artifact.Bytecode,
+			ConstructorArgs: deployment.Args,
+			KeyID:           keyID,
+			SourceCode:      string(source),
+			ProjectID:       &params.ProjectID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to deploy contract %s: %w", deployment.Name, err)
+		}
+		b.logger.Info("Deployed project contract",
+			zap.Int64("projectID", params.ProjectID),
+			zap.String("contract", contract.Name),
+			zap.String("address", contract.Address),
+		)
+	}
+	return nil
+}
+
+// PreStop is called before stopping the project container
+func (b *BesuLifecycle) PreStop(ctx context.Context, params PreStopParams) error {
+	b.logger.Info("PreStop hook for Besu project",
+		zap.Int64("projectID", params.ProjectID),
+		zap.String("projectName", params.ProjectName),
+		zap.String("containerID", params.ContainerID),
+	)
+	return nil
+}
+
+// PostStop is called after the project container has stopped. Deployed contracts
+// stay on chain and in the registry.
+func (b *BesuLifecycle) PostStop(ctx context.Context, params PostStopParams) error {
+	b.logger.Info("PostStop hook for Besu project",
+		zap.Int64("projectID", params.ProjectID),
+		zap.String("projectName", params.ProjectName),
+		zap.String("containerID", params.ContainerID),
+	)
+	return nil
+}
+
+// nodeKeyID returns the key of the first Besu node of the network
+func (b *BesuLifecycle) nodeKeyID(ctx context.Context, networkID int64) (int64, error) {
+	nodes, err := b.networkService.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	for _, node := range nodes {
+		if node.Node != nil && node.Node.BesuNode != nil && node.Node.BesuNode.KeyID != 0 {
+			return node.Node.BesuNode.KeyID, nil
+		}
+	}
+	return 0, fmt.Errorf("no Besu node key found in network, set keyId in %s", besuDeployConfigFile)
+}
+
+// unchangedBesuContract returns the contract the project last deployed on the network
+// under the artifact's name, when its code hash matches the runtime code of the artifact.
+// registered is ordered newest first. Runtime code with immutable variables differs from
+// the artifact once deployed, so such contracts are always deployed again.
+func unchangedBesuContract(registered []*db.BesuContract, networkID int64, artifact besuArtifact) *db.BesuContract {
+	code, err := hex.DecodeString(strings.TrimPrefix(artifact.DeployedBytecode, "0x"))
+	if err != nil || len(code) == 0 {
+		return nil
+	}
+	hash := crypto.Keccak256Hash(code).Hex()
+	for _, contract := range registered {
+		if contract.NetworkID != networkID || contract.Name != artifact.ContractName {
+			continue
+		}
+		if contract.BytecodeHash.Valid && strings.EqualFold(contract.BytecodeHash.String, hash) {
+			return contract
+		}
+		return nil
+	}
+	return nil
+}
+
+// loadBesuArtifacts reads the Hardhat artifacts of the project contracts, by contract name.
+// Interfaces and abstract contracts have no bytecode and are left out.
+func loadBesuArtifacts(dir string) (map[string]besuArtifact, error) {
+	artifacts := make(map[string]besuArtifact)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".json") || strings.HasSuffix(path, ".dbg.json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var artifact besuArtifact
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return fmt.Errorf("failed to parse artifact %s: %w", path, err)
+		}
+		if artifact.ContractName == "" || artifact.Bytecode == "" || artifact.Bytecode == "0x" {
+			return nil
+		}
+		if existing, ok := artifacts[artifact.ContractName]; ok {
+			return fmt.Errorf("contract %s is defined in %s and %s", artifact.ContractName, existing.SourceName, artifact.SourceName)
+		}
+		artifacts[artifact.ContractName] = artifact
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no compiled contracts found in %s", besuArtifactsDir)
+		}
+		return nil, fmt.Errorf("failed to read compiled contracts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// loadBesuDeployConfig reads besuDeployConfigFile, an empty config when the project has none
+func loadBesuDeployConfig(projectDir string) (besuDeployConfig, error) {
+	var config besuDeployConfig
+	data, err := os.ReadFile(filepath.Join(projectDir, besuDeployConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, fmt.Errorf("failed to read %s: %w", besuDeployConfigFile, err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %w", besuDeployConfigFile, err)
+	}
+	return config, nil
+}
+
+// besuDeployments returns the contracts listed in the deploy config, or every compiled
+// contract without constructor arguments when the config lists none
+func besuDeployments(artifacts map[string]besuArtifact, config besuDeployConfig) ([]besuContractDeployment, error) {
+	if len(config.Contracts) > 0 {
+		for _, deployment := range config.Contracts {
+			if _, ok := artifacts[deployment.Name]; !ok {
+				return nil, fmt.Errorf("contract %s listed in %s is not compiled", deployment.Name, besuDeployConfigFile)
+			}
+		}
+		return config.Contracts, nil
+	}
+
+	var deployments []besuContractDeployment
+	for name, artifact := range artifacts {
+		parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABI for contract %s: %w", name, err)
+		}
+		if len(parsed.Constructor.Inputs) > 0 {
+			continue
+		}
+		deployments = append(deployments, besuContractDeployment{Name: name})
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Name < deployments[j].Name
+	})
+	return deployments, nil
+}
@@ -0,0 +1,108 @@
+package projects
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArtifact(t *testing.T, dir, source, name, abiJSON, bytecode string) {
+	t.Helper()
+	artifact := map[string]interface{}{
+		"contractName": name,
+		"sourceName":   "contracts/" + source,
+		"abi":          json.RawMessage(abiJSON),
+		"bytecode":     bytecode,
+	}
+	data, err := json.Marshal(artifact)
+	require.NoError(t, err)
+	path := filepath.Join(dir, source, name+".json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestBesuDeployments(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "Counter.sol", "Counter", `[{"type":"function","name":"inc","inputs":[],"outputs":[]}]`, "0x6080")
+	writeArtifact(t, dir, "Token.sol", "Token", `[{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]}]`, "0x6081")
+	writeArtifact(t, dir, "IToken.sol", "IToken", `[]`, "0x")
+	// Hardhat debug files only point to the build info
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Counter.sol", "Counter.dbg.json"), []byte(`{"buildInfo":"x"}`), 0644))
+
+	artifacts, err := loadBesuArtifacts(dir)
+	require.NoError(t, err)
+	assert.Len(t, artifacts, 2)
+	assert.Equal(t, "contracts/Token.sol", artifacts["Token"].SourceName)
+
+	t.Run("contracts without constructor arguments by default", func(t *testing.T) {
+		deployments, err := besuDeployments(artifacts, besuDeployConfig{})
+		require.NoError(t, err)
+		require.Len(t, deployments, 1)
+		assert.Equal(t, "Counter", deployments[0].Name)
+	})
+
+	t.Run("contracts listed in the config", func(t *testing.T) {
+		config := besuDeployConfig{Contracts: []besuContractDeployment{
+			{Name: "Token", Args: []json.RawMessage{json.RawMessage(`"1000"`)}},
+			{Name: "Counter"},
+		}}
+		deployments, err := besuDeployments(artifacts, config)
+		require.NoError(t, err)
+		require.Len(t, deployments, 2)
+		assert.Equal(t, "Token", deployments[0].Name)
+	})
+
+	t.Run("unknown contract in the config", func(t *testing.T) {
+		_, err := besuDeployments(artifacts, besuDeployConfig{Contracts: []besuContractDeployment{{Name: "Missing"}}})
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadBesuArtifactsNotCompiled(t *testing.T) {
+	_, err := loadBesuArtifacts(filepath.Join(t.TempDir(), "artifacts"))
+	assert.Error(t, err)
+}
+
+func TestUnchangedBesuContract(t *testing.T) {
+	artifact := besuArtifact{ContractName: "Counter", DeployedBytecode: "0x6080604052"}
+	hash := crypto.Keccak256Hash([]byte{0x60, 0x80, 0x60, 0x40, 0x52}).Hex()
+	contract := func(id, networkID int64, name, bytecodeHash string) *db.BesuContract {
+		return &db.BesuContract{ID: id, NetworkID: networkID, Name: name, BytecodeHash: sql.NullString{String: bytecodeHash, Valid: bytecodeHash != ""}}
+	}
+
+	tests := []struct {
+		name       string
+		registered []*db.BesuContract
+		artifact   besuArtifact
+		wantID     int64
+	}{
+		{name: "never deployed"},
+		{name: "same code", registered: []*db.BesuContract{contract(1, 1, "Counter", hash)}, artifact: artifact, wantID: 1},
+		{name: "hash case differs", registered: []*db.BesuContract{contract(1, 1, "Counter", strings.ToUpper(hash))}, artifact: artifact, wantID: 1},
+		{name: "changed code", registered: []*db.BesuContract{contract(1, 1, "Counter", "0x1234")}, artifact: artifact},
+		{name: "code changed since an older deployment", registered: []*db.BesuContract{contract(2, 1, "Counter", "0x1234"), contract(1, 1, "Counter", hash)}, artifact: artifact},
+		{name: "latest deployment of the contract matches", registered: []*db.BesuContract{contract(3, 1, "Token", "0x1234"), contract(2, 1, "Counter", hash)}, artifact: artifact, wantID: 2},
+		{name: "deployed on another network", registered: []*db.BesuContract{contract(1, 2, "Counter", hash)}, artifact: artifact},
+		{name: "unknown code hash", registered: []*db.BesuContract{contract(1, 1, "Counter", "")}, artifact: artifact},
+		{name: "artifact without runtime code", registered: []*db.BesuContract{contract(1, 1, "Counter", hash)}, artifact: besuArtifact{ContractName: "Counter"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unchangedBesuContract(tt.registered, 1, tt.artifact)
+			if tt.wantID == 0 {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+)
+
+// ListProjectContracts lists the contracts deployed by a Besu project, newest first
+func (s *ProjectsService) ListProjectContracts(ctx context.Context, projectID int64) ([]*chainlaunchdeploy.Contract, error) {
+	if _, err := s.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	if s.ContractService == nil {
+		return nil, fmt.Errorf("contract service is not configured")
+	}
+	return s.ContractService.ListProjectContracts(ctx, projectID)
+}
+
+// QueryProjectContract calls a read method of the latest deployment of a project contract
+func (s *ProjectsService) QueryProjectContract(ctx context.Context, projectID int64, name string, params chainlaunchdeploy.CallContractParams) (*chainlaunchdeploy.CallResult, error) {
+	contract, err := s.projectContract(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.ContractService.CallContract(ctx, contract.ID, params)
+}
+
+// InvokeProjectContract sends a transaction to the latest deployment of a project contract.
+// It is signed with the deployer key when no key is given.
+func (s *ProjectsService) InvokeProjectContract(ctx context.Context, projectID int64, name string, params chainlaunchdeploy.TransactContractParams) (*chainlaunchdeploy.TransactResult, error) {
+	contract, err := s.projectContract(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	if params.KeyID == 0 {
+		if contract.DeployerKeyID == nil {
+			return nil, errors.NewValidationError("keyId is required", nil)
+		}
+		params.KeyID = *contract.DeployerKeyID
+	}
+	return s.ContractService.TransactContract(ctx, contract.ID, params)
+}
+
+// projectContract returns the latest deployment of the named project contract
+func (s *ProjectsService) projectContract(ctx context.Context, projectID int64, name string) (*chainlaunchdeploy.Contract, error) {
+	contracts, err := s.ListProjectContracts(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, contract := range contracts {
+		if contract.Name == name {
+			return contract, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("contract not deployed by project", map[string]interface{}{
+		"project_id": projectID,
+		"contract":   name,
+	})
+}
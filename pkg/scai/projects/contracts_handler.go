@@ -0,0 +1,155 @@
+package projects
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// ContractQueryRequest represents a read call of a project contract method
+type ContractQueryRequest struct {
+	Method string            `json:"method" example:"balanceOf" description:"Method name, or signature for overloaded methods"`
+	Args   []json.RawMessage `json:"args" swaggertype:"array,object" description:"Arguments matching the ABI inputs"`
+	From   string            `json:"from,omitempty" description:"Caller address of the read call"`
+}
+
+// ContractInvokeRequest represents a transaction calling a project contract method
+type ContractInvokeRequest struct {
+	Method   string            `json:"method" example:"transfer" description:"Method name, or signature for overloaded methods"`
+	Args     []json.RawMessage `json:"args" swaggertype:"array,object" description:"Arguments matching the ABI inputs"`
+	KeyID    int64             `json:"keyId,omitempty" example:"1" description:"ID of the key signing the transaction, the deployer key by default"`
+	Value    string            `json:"value,omitempty" description:"Amount of wei sent with the transaction"`
+	GasLimit uint64            `json:"gasLimit,omitempty"`
+}
+
+// ListProjectContracts godoc
+// @Summary      List project contracts
+// @Description  List the contracts deployed on the Besu network when the project was started, newest first
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Success      200 {array} chainlaunchdeploy.Contract
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/contracts [get]
+func (h *ProjectsHandler) ListProjectContracts(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	contracts, err := h.Service.ListProjectContracts(r.Context(), id)
+	if err != nil {
+		return projectContractError(err, "failed to list project contracts")
+	}
+	return response.WriteJSON(w, http.StatusOK, contracts)
+}
+
+// QueryProjectContract godoc
+// @Summary      Query a project contract
+// @Description  Call a read method of the latest deployment of a project contract and decode the outputs with its ABI
+// @Tags         Chaincode Projects
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        name path string true "Contract name"
+// @Param        request body ContractQueryRequest true "Method call"
+// @Success      200 {object} chainlaunchdeploy.CallResult
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/contracts/{name}/query [post]
+func (h *ProjectsHandler) QueryProjectContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	var req ContractQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	if req.Method == "" {
+		return errors.NewValidationError("method is required", nil)
+	}
+
+	result, err := h.Service.QueryProjectContract(r.Context(), id, chi.URLParam(r, "name"), chainlaunchdeploy.CallContractParams{
+		Method: req.Method,
+		Args:   req.Args,
+		From:   req.From,
+	})
+	if err != nil {
+		return projectContractError(err, "failed to query contract")
+	}
+	return response.WriteJSON(w, http.StatusOK, result)
+}
+
+// InvokeProjectContract godoc
+// @Summary      Invoke a project contract
+// @Description  Send a transaction calling a method of the latest deployment of a project contract and wait until it is mined
+// @Tags         Chaincode Projects
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        name path string true "Contract name"
+// @Param        request body ContractInvokeRequest true "Method call"
+// @Success      200 {object} chainlaunchdeploy.TransactResult
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/contracts/{name}/invoke [post]
+func (h *ProjectsHandler) InvokeProjectContract(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	var req ContractInvokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.NewValidationError("invalid request body", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	if req.Method == "" {
+		return errors.NewValidationError("method is required", nil)
+	}
+
+	result, err := h.Service.InvokeProjectContract(r.Context(), id, chi.URLParam(r, "name"), chainlaunchdeploy.TransactContractParams{
+		Method:   req.Method,
+		Args:     req.Args,
+		KeyID:    req.KeyID,
+		Value:    req.Value,
+		GasLimit: req.GasLimit,
+	})
+	if err != nil {
+		return projectContractError(err, "failed to invoke contract")
+	}
+	return response.WriteJSON(w, http.StatusOK, result)
+}
+
+func parseProjectID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, errors.NewValidationError("invalid project id", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return id, nil
+}
+
+// projectContractError keeps the errors of the contract registry and reports
+// anything else as an internal error
+func projectContractError(err error, msg string) error {
+	if err == ErrNotFound {
+		return errors.NewNotFoundError("project not found", nil)
+	}
+	if _, ok := err.(*errors.AppError); ok {
+		return err
+	}
+	return errors.NewInternalError(msg, err, nil)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/chainlaunch/chainlaunch/pkg/errors"
 	"github.com/chainlaunch/chainlaunch/pkg/http/response"
 	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projecttests"
 	"github.com/chainlaunch/chainlaunch/pkg/scai/versionmanagement"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -30,6 +31,8 @@ type ProjectsHandler struct {
 	Service          *ProjectsService
 	chaincodeService *ChaincodeService
 	logger           *logger.Logger
+	// Tests runs the unit tests of the projects, the test routes fail when it is not set
+	Tests *projecttests.Service
 }
 
 type CreateProjectRequest struct {
@@ -110,8 +113,18 @@ func (h *ProjectsHandler) RegisterRoutes(r chi.Router) {
 		r.Post("/{id}/invoke", response.Middleware(h.InvokeTransaction))
 		r.Post("/{id}/query", response.Middleware(h.QueryTransaction))
 		r.Get("/{id}/metadata", response.Middleware(h.GetProjectMetadata))
+		r.Get("/{id}/contracts", response.Middleware(h.ListProjectContracts))
+		r.Post("/{id}/contracts/{name}/query", response.Middleware(h.QueryProjectContract))
+		r.Post("/{id}/contracts/{name}/invoke", response.Middleware(h.InvokeProjectContract))
 		r.Put("/{id}/endorsement-policy", response.Middleware(h.UpdateProjectEndorsementPolicy))
 		r.Get("/{id}/download", response.Middleware(h.DownloadProject))
+		r.Post("/{id}/tests/run", response.Middleware(h.RunProjectTests))
+		r.Get("/{id}/tests/runs", response.Middleware(h.ListProjectTestRuns))
+		r.Get("/{id}/tests/runs/{runId}", response.Middleware(h.GetProjectTestRun))
+		r.Get("/{id}/tests/compare", response.Middleware(h.CompareProjectTestRuns))
+		r.Post("/{id}/promotions", response.Middleware(h.PromoteProject))
+		r.Get("/{id}/promotions", response.Middleware(h.ListProjectPromotions))
+		r.Get("/{id}/promotions/{promotionId}", response.Middleware(h.GetProjectPromotion))
 	})
 }
 
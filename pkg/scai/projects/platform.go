@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabricService "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
 	keyMgmtService "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
 	"github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projectrunner"
 	"go.uber.org/zap"
 )
 
@@ -49,6 +51,9 @@ type PostStartParams struct {
 	StartedAt   time.Time
 	Status      string
 	HostIP      string // IP address where the smart contract will be deployed
+	ProjectDir  string // Host directory mounted in the container
+	// ValidateCommand builds and tests the project inside the container
+	ValidateCommand string
 }
 
 // PreStopParams contains parameters for the PreStop hook
@@ -86,10 +91,12 @@ type PlatformLifecycle interface {
 }
 
 // GetPlatformLifecycle returns the appropriate lifecycle implementation for the given platform
-func GetPlatformLifecycle(platform string, queries *db.Queries, orgService *fabricService.OrganizationService, keyMgmtService *keyMgmtService.KeyManagementService, networkService *service.NetworkService, logger *zap.Logger) (PlatformLifecycle, error) {
+func GetPlatformLifecycle(platform string, queries *db.Queries, orgService *fabricService.OrganizationService, keyMgmtService *keyMgmtService.KeyManagementService, networkService *service.NetworkService, runner *projectrunner.Runner, contractService *chainlaunchdeploy.ContractService, logger *zap.Logger) (PlatformLifecycle, error) {
 	switch platform {
 	case "fabric":
 		return NewFabricLifecycle(queries, logger, orgService, keyMgmtService, networkService), nil
+	case "besu":
+		return NewBesuLifecycle(queries, logger, runner, networkService, contractService), nil
 	// Add more platform cases here as needed
 	default:
 		return nil, fmt.Errorf("unsupported platform: %s", platform)
@@ -0,0 +1,561 @@
+package projects
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/common/addresses"
+	"github.com/chainlaunch/chainlaunch/pkg/common/ports"
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/docker"
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/versionmanagement"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// Statuses of a promotion, in the order of its steps
+const (
+	PromotionPending    = "pending"
+	PromotionBuilding   = "building"
+	PromotionPushing    = "pushing"
+	PromotionInstalling = "installing"
+	PromotionApproving  = "approving"
+	PromotionCommitting = "committing"
+	PromotionDeploying  = "deploying"
+	PromotionCompleted  = "completed"
+	PromotionFailed     = "failed"
+)
+
+// maxBuildOutputInError is the length of the end of the build output kept in the error of a failed build
+const maxBuildOutputInError = 2000
+
+var imageTagInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// runningPromotions tracks the projects being promoted, one promotion at a time per project
+var runningPromotions = struct {
+	mu  sync.Mutex
+	ids map[int64]bool
+}{ids: make(map[int64]bool)}
+
+// ImageRegistry is the registry the images of promoted projects are pushed to
+type ImageRegistry struct {
+	// URL is the registry host with an optional path, e.g. localhost:5000 or registry.example.com/chaincodes.
+	// Images are only built on the local Docker daemon when it is empty.
+	URL      string
+	Username string
+	Password string
+}
+
+// PromoteProjectParams are the parameters of a promotion. Every field is optional.
+type PromoteProjectParams struct {
+	// CommitHash is the commit to promote, full or abbreviated, HEAD by default
+	CommitHash string
+	// NetworkID is the Fabric network to deploy to, the project network by default
+	NetworkID int64
+	// ChaincodeName is the name of the chaincode on the channel, the project name by default
+	ChaincodeName string
+	// Version is the chaincode version, the abbreviated commit hash by default
+	Version string
+	// EndorsementPolicy is the endorsement policy of the definition, the project policy by default
+	EndorsementPolicy string
+	// SkipTests promotes a commit without a passing test run
+	SkipTests bool
+}
+
+// Promotion is the deployment of a project commit as a chaincode definition of a Fabric network
+type Promotion struct {
+	ID            int64     `json:"id"`
+	ProjectID     int64     `json:"projectId"`
+	CommitHash    string    `json:"commitHash"`
+	NetworkID     int64     `json:"networkId"`
+	ChaincodeName string    `json:"chaincodeName"`
+	Version       string    `json:"version"`
+	DockerImage   string    `json:"dockerImage"`
+	ChaincodeID   *int64    `json:"chaincodeId,omitempty"`
+	DefinitionID  *int64    `json:"definitionId,omitempty"`
+	Sequence      *int64    `json:"sequence,omitempty"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// promotionJob holds what the steps of a promotion need once it is accepted
+type promotionJob struct {
+	promotion         *db.ProjectPromotion
+	projectDir        string
+	dockerfile        string
+	endorsementPolicy string
+}
+
+// PromoteProject builds a versioned image of a project commit and deploys it to a Fabric network
+// as a new chaincode definition: install, approve by every organization and commit. The promotion
+// runs in the background, its status is returned by GetPromotion.
+func (s *ProjectsService) PromoteProject(ctx context.Context, projectID int64, params PromoteProjectParams) (*Promotion, error) {
+	if s.DeployService == nil {
+		return nil, fmt.Errorf("chaincode deploy service is not configured")
+	}
+	project, err := s.Queries.GetProject(ctx, projectID)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	networkID := params.NetworkID
+	if networkID == 0 {
+		if !project.NetworkID.Valid {
+			return nil, errors.NewValidationError("networkId is required for a project without network", nil)
+		}
+		networkID = project.NetworkID.Int64
+	}
+	network, err := s.Queries.GetNetwork(ctx, networkID)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("network not found", map[string]interface{}{"network_id": networkID})
+		}
+		return nil, fmt.Errorf("failed to get network: %w", err)
+	}
+	if !strings.EqualFold(network.Platform, "fabric") {
+		return nil, errors.NewValidationError("projects can only be promoted to Fabric networks", map[string]interface{}{
+			"platform": network.Platform,
+		})
+	}
+
+	endorsementPolicy := params.EndorsementPolicy
+	if endorsementPolicy == "" {
+		endorsementPolicy = project.EndorsementPolicy.String
+	}
+	if endorsementPolicy == "" {
+		return nil, errors.NewValidationError("endorsementPolicy is required for a project without endorsement policy", nil)
+	}
+
+	projectDir, err := s.safeJoinPath(project.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build safe project path: %w", err)
+	}
+	revision := params.CommitHash
+	if revision == "" {
+		revision = "HEAD"
+	}
+	commit, err := versionmanagement.ResolveCommit(ctx, projectDir, revision)
+	if err != nil {
+		return nil, errors.NewValidationError("commit not found", map[string]interface{}{
+			"commit": revision,
+			"error":  err.Error(),
+		})
+	}
+
+	var config struct{ testCommand, dockerfile string }
+	if project.Boilerplate.Valid {
+		boilerplateConfig, err := s.BoilerplateService.GetBoilerplateConfig(project.Boilerplate.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get boilerplate config: %w", err)
+		}
+		config.testCommand = boilerplateConfig.TestCommand
+		config.dockerfile = boilerplateConfig.ProductionDockerfile
+	}
+	// The project Dockerfile takes precedence over the one of the boilerplate
+	if _, err := versionmanagement.GetFileAtCommit(ctx, projectDir, "Dockerfile", commit.ID); err == nil {
+		config.dockerfile = ""
+	} else if config.dockerfile == "" {
+		return nil, errors.NewValidationError("the commit has no Dockerfile and the boilerplate has no production Dockerfile", map[string]interface{}{
+			"commit": commit.ID,
+		})
+	}
+
+	if config.testCommand != "" && !params.SkipTests {
+		if err := s.checkTestsPassed(ctx, projectID, commit.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	chaincodeName := params.ChaincodeName
+	if chaincodeName == "" {
+		chaincodeName = project.Name
+	}
+	version := params.Version
+	if version == "" {
+		version = commit.ID[:8]
+	}
+
+	runningPromotions.mu.Lock()
+	if runningPromotions.ids[projectID] {
+		runningPromotions.mu.Unlock()
+		return nil, errors.NewConflictError("a promotion of the project is already running", nil)
+	}
+	runningPromotions.ids[projectID] = true
+	runningPromotions.mu.Unlock()
+
+	promotion, err := s.Queries.CreateProjectPromotion(ctx, &db.CreateProjectPromotionParams{
+		ProjectID:     projectID,
+		CommitHash:    commit.ID,
+		NetworkID:     networkID,
+		ChaincodeName: chaincodeName,
+		Version:       version,
+		DockerImage:   s.promotionImage(project.Slug, version),
+		Status:        PromotionPending,
+	})
+	if err != nil {
+		runningPromotions.mu.Lock()
+		delete(runningPromotions.ids, projectID)
+		runningPromotions.mu.Unlock()
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	job := &promotionJob{
+		promotion:         promotion,
+		projectDir:        projectDir,
+		dockerfile:        config.dockerfile,
+		endorsementPolicy: endorsementPolicy,
+	}
+	go func() {
+		defer func() {
+			runningPromotions.mu.Lock()
+			delete(runningPromotions.ids, projectID)
+			runningPromotions.mu.Unlock()
+		}()
+		s.runPromotion(context.Background(), job)
+	}()
+
+	return dbPromotionToAPI(promotion), nil
+}
+
+// checkTestsPassed ensures the latest test run of a commit passed on a clean working tree
+func (s *ProjectsService) checkTestsPassed(ctx context.Context, projectID int64, commitHash string) error {
+	run, err := s.Queries.GetLatestProjectTestRunForCommit(ctx, &db.GetLatestProjectTestRunForCommitParams{
+		ProjectID:  projectID,
+		CommitHash: commitHash,
+	})
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewValidationError("the commit has not been tested, run the project tests or skip them", map[string]interface{}{
+				"commit": commitHash,
+			})
+		}
+		return fmt.Errorf("failed to get test run: %w", err)
+	}
+	if run.Status != "passed" || run.Dirty {
+		return errors.NewValidationError("the latest test run of the commit did not pass on a clean working tree", map[string]interface{}{
+			"commit":  commitHash,
+			"run_id":  run.ID,
+			"status":  run.Status,
+			"dirty":   run.Dirty,
+			"failed":  run.Failed,
+			"skipped": run.Skipped,
+		})
+	}
+	return nil
+}
+
+// promotionImage returns the name of the image of a project version
+func (s *ProjectsService) promotionImage(slug, version string) string {
+	repository := "chaincode-" + strings.ToLower(slug)
+	if s.ImageRegistry.URL != "" {
+		repository = strings.TrimSuffix(s.ImageRegistry.URL, "/") + "/" + repository
+	}
+	tag := imageTagInvalidChars.ReplaceAllString(version, "-")
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return repository + ":" + tag
+}
+
+func (s *ProjectsService) runPromotion(ctx context.Context, job *promotionJob) {
+	logger := zap.L().With(zap.Int64("promotionID", job.promotion.ID), zap.Int64("projectID", job.promotion.ProjectID))
+	if err := s.executePromotion(ctx, job, logger); err != nil {
+		logger.Error("Project promotion failed", zap.Error(err))
+		if err := s.Queries.UpdateProjectPromotionStatus(ctx, &db.UpdateProjectPromotionStatusParams{
+			Status: PromotionFailed,
+			Error:  sql.NullString{String: err.Error(), Valid: true},
+			ID:     job.promotion.ID,
+		}); err != nil {
+			logger.Error("Failed to update promotion status", zap.Error(err))
+		}
+		return
+	}
+	if err := s.setPromotionStatus(ctx, job.promotion.ID, PromotionCompleted); err != nil {
+		logger.Error("Failed to update promotion status", zap.Error(err))
+	}
+	logger.Info("Project promoted", zap.String("image", job.promotion.DockerImage))
+}
+
+func (s *ProjectsService) executePromotion(ctx context.Context, job *promotionJob, logger *zap.Logger) error {
+	promotion := job.promotion
+
+	if err := s.setPromotionStatus(ctx, promotion.ID, PromotionBuilding); err != nil {
+		return err
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer cli.Close()
+	if err := s.buildPromotionImage(ctx, cli, job); err != nil {
+		return err
+	}
+	if s.ImageRegistry.URL != "" {
+		if err := s.setPromotionStatus(ctx, promotion.ID, PromotionPushing); err != nil {
+			return err
+		}
+		if err := docker.PushImage(ctx, cli, promotion.DockerImage, docker.RegistryAuth{
+			Username: s.ImageRegistry.Username,
+			Password: s.ImageRegistry.Password,
+		}); err != nil {
+			return err
+		}
+	}
+
+	peers, err := s.promotionPeers(ctx, promotion.NetworkID)
+	if err != nil {
+		return err
+	}
+	chaincodeID, err := s.promotionChaincode(ctx, promotion.ChaincodeName, promotion.NetworkID)
+	if err != nil {
+		return err
+	}
+	sequence, err := s.nextChaincodeSequence(ctx, chaincodeID, peers.orgPeers[0])
+	if err != nil {
+		return err
+	}
+
+	hostIP, err := addresses.GetExternalIP()
+	if err != nil {
+		logger.Warn("Failed to get host IP, using localhost", zap.Error(err))
+		hostIP = "127.0.0.1"
+	}
+	port, err := ports.GetFreePort("fabric-chaincode")
+	if err != nil {
+		return fmt.Errorf("no free ports available for chaincode: %w", err)
+	}
+	definition, err := s.DeployService.CreateChaincodeDefinition(ctx, chaincodeID, promotion.Version, sequence, promotion.DockerImage, job.endorsementPolicy, fmt.Sprintf("%s:%d", hostIP, port.Port))
+	if err != nil {
+		return fmt.Errorf("failed to create chaincode definition: %w", err)
+	}
+	if err := s.Queries.SetProjectPromotionDefinition(ctx, &db.SetProjectPromotionDefinitionParams{
+		ChaincodeID:  sql.NullInt64{Int64: chaincodeID, Valid: true},
+		DefinitionID: sql.NullInt64{Int64: definition.ID, Valid: true},
+		Sequence:     sql.NullInt64{Int64: sequence, Valid: true},
+		ID:           promotion.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	if err := s.setPromotionStatus(ctx, promotion.ID, PromotionInstalling); err != nil {
+		return err
+	}
+	if err := s.DeployService.InstallChaincodeByDefinition(ctx, definition.ID, peers.all); err != nil {
+		return fmt.Errorf("failed to install chaincode: %w", err)
+	}
+	if err := s.setPromotionStatus(ctx, promotion.ID, PromotionApproving); err != nil {
+		return err
+	}
+	for _, peerID := range peers.orgPeers {
+		if err := s.DeployService.ApproveChaincodeByDefinition(ctx, definition.ID, peerID); err != nil {
+			return fmt.Errorf("failed to approve chaincode with peer %d: %w", peerID, err)
+		}
+	}
+	if err := s.setPromotionStatus(ctx, promotion.ID, PromotionCommitting); err != nil {
+		return err
+	}
+	if err := s.DeployService.CommitChaincodeByDefinition(ctx, definition.ID, peers.orgPeers[0]); err != nil {
+		return fmt.Errorf("failed to commit chaincode: %w", err)
+	}
+	if err := s.setPromotionStatus(ctx, promotion.ID, PromotionDeploying); err != nil {
+		return err
+	}
+	if err := s.DeployService.DeployChaincodeByDefinition(ctx, definition.ID, nil); err != nil {
+		return fmt.Errorf("failed to deploy chaincode: %w", err)
+	}
+	return nil
+}
+
+// buildPromotionImage builds the image of the promoted commit from the files of the commit
+func (s *ProjectsService) buildPromotionImage(ctx context.Context, cli *client.Client, job *promotionJob) error {
+	var extra map[string][]byte
+	if job.dockerfile != "" {
+		extra = map[string][]byte{"Dockerfile": []byte(job.dockerfile)}
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(versionmanagement.ArchiveCommit(ctx, job.projectDir, job.promotion.CommitHash, writer, extra))
+	}()
+	defer reader.Close()
+
+	output, err := docker.BuildImage(ctx, cli, reader, []string{job.promotion.DockerImage}, map[string]string{
+		"chainlaunch.project.id":     fmt.Sprintf("%d", job.promotion.ProjectID),
+		"chainlaunch.project.commit": job.promotion.CommitHash,
+	})
+	if err != nil {
+		if len(output) > maxBuildOutputInError {
+			output = output[len(output)-maxBuildOutputInError:]
+		}
+		return fmt.Errorf("%w\n%s", err, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// promotionPeerSet lists the running peers of a network
+type promotionPeerSet struct {
+	all []int64
+	// orgPeers holds one peer per organization, used to approve the definition on its behalf
+	orgPeers []int64
+}
+
+func (s *ProjectsService) promotionPeers(ctx context.Context, networkID int64) (*promotionPeerSet, error) {
+	nodes, err := s.NetworkService.GetNetworkNodes(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network nodes: %w", err)
+	}
+	peers := &promotionPeerSet{}
+	orgPeer := map[string]int64{}
+	for _, node := range nodes {
+		if node.Node == nil || node.Node.NodeType != nodetypes.NodeTypeFabricPeer || node.Node.FabricPeer == nil || node.Node.Status != string(nodetypes.NodeStatusRunning) {
+			continue
+		}
+		peers.all = append(peers.all, node.Node.ID)
+		if _, ok := orgPeer[node.Node.FabricPeer.MSPID]; !ok {
+			orgPeer[node.Node.FabricPeer.MSPID] = node.Node.ID
+		}
+	}
+	if len(peers.all) == 0 {
+		return nil, fmt.Errorf("the network has no running peer")
+	}
+	mspIDs := make([]string, 0, len(orgPeer))
+	for mspID := range orgPeer {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	for _, mspID := range mspIDs {
+		peers.orgPeers = append(peers.orgPeers, orgPeer[mspID])
+	}
+	return peers, nil
+}
+
+// promotionChaincode returns the chaincode of the network with the given name, creating it if needed
+func (s *ProjectsService) promotionChaincode(ctx context.Context, name string, networkID int64) (int64, error) {
+	chaincode, err := s.Queries.GetFabricChaincodeByNameAndNetwork(ctx, &db.GetFabricChaincodeByNameAndNetworkParams{
+		Name:      name,
+		NetworkID: networkID,
+	})
+	if err == nil {
+		return chaincode.ID, nil
+	}
+	if !stderrors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get chaincode: %w", err)
+	}
+	created, err := s.DeployService.CreateChaincode(ctx, name, networkID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create chaincode: %w", err)
+	}
+	return created.ID, nil
+}
+
+// nextChaincodeSequence returns the sequence following both the definitions of the chaincode and
+// the definition committed on the channel, which the dev mode of the project may have upgraded
+func (s *ProjectsService) nextChaincodeSequence(ctx context.Context, chaincodeID, peerID int64) (int64, error) {
+	definitions, err := s.DeployService.ListChaincodeDefinitions(ctx, chaincodeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list chaincode definitions: %w", err)
+	}
+	sequence := int64(0)
+	for _, definition := range definitions {
+		if definition.Sequence > sequence {
+			sequence = definition.Sequence
+		}
+	}
+	committed, err := s.DeployService.QueryCommittedSequence(ctx, chaincodeID, peerID)
+	if err != nil {
+		return 0, err
+	}
+	if committed > sequence {
+		sequence = committed
+	}
+	return sequence + 1, nil
+}
+
+func (s *ProjectsService) setPromotionStatus(ctx context.Context, promotionID int64, status string) error {
+	if err := s.Queries.UpdateProjectPromotionStatus(ctx, &db.UpdateProjectPromotionStatusParams{
+		Status: status,
+		ID:     promotionID,
+	}); err != nil {
+		return fmt.Errorf("failed to update promotion status: %w", err)
+	}
+	return nil
+}
+
+// ListPromotions lists the promotions of a project, newest first
+func (s *ProjectsService) ListPromotions(ctx context.Context, projectID int64) ([]*Promotion, error) {
+	if _, err := s.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	promotions, err := s.Queries.ListProjectPromotions(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+	result := make([]*Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		result = append(result, dbPromotionToAPI(promotion))
+	}
+	return result, nil
+}
+
+// GetPromotion returns a promotion of a project
+func (s *ProjectsService) GetPromotion(ctx context.Context, projectID, promotionID int64) (*Promotion, error) {
+	promotion, err := s.Queries.GetProjectPromotion(ctx, &db.GetProjectPromotionParams{
+		ID:        promotionID,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("promotion not found", map[string]interface{}{
+				"project_id":   projectID,
+				"promotion_id": promotionID,
+			})
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	return dbPromotionToAPI(promotion), nil
+}
+
+// FailInterruptedPromotions marks the promotions that were running when the server stopped as failed
+func (s *ProjectsService) FailInterruptedPromotions(ctx context.Context) error {
+	return s.Queries.FailInterruptedProjectPromotions(ctx)
+}
+
+func dbPromotionToAPI(p *db.ProjectPromotion) *Promotion {
+	promotion := &Promotion{
+		ID:            p.ID,
+		ProjectID:     p.ProjectID,
+		CommitHash:    p.CommitHash,
+		NetworkID:     p.NetworkID,
+		ChaincodeName: p.ChaincodeName,
+		Version:       p.Version,
+		DockerImage:   p.DockerImage,
+		Status:        p.Status,
+		Error:         p.Error.String,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if p.ChaincodeID.Valid {
+		promotion.ChaincodeID = &p.ChaincodeID.Int64
+	}
+	if p.DefinitionID.Valid {
+		promotion.DefinitionID = &p.DefinitionID.Int64
+	}
+	if p.Sequence.Valid {
+		promotion.Sequence = &p.Sequence.Int64
+	}
+	return promotion
+}
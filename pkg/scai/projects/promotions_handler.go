@@ -0,0 +1,115 @@
+package projects
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// PromoteProjectRequest is the body of a project promotion, every field is optional
+type PromoteProjectRequest struct {
+	CommitHash        string `json:"commitHash" example:"3f2c1a9b" description:"Commit to promote, HEAD by default"`
+	NetworkID         int64  `json:"networkId" example:"2" description:"Fabric network to deploy to, the project network by default"`
+	ChaincodeName     string `json:"chaincodeName" example:"asset-transfer" description:"Chaincode name, the project name by default"`
+	Version           string `json:"version" example:"1.2.0" description:"Chaincode version, the abbreviated commit hash by default"`
+	EndorsementPolicy string `json:"endorsementPolicy" example:"OR('Org1MSP.member')" description:"Endorsement policy, the project policy by default"`
+	SkipTests         bool   `json:"skipTests" example:"false" description:"Promote the commit without a passing test run"`
+}
+
+// PromoteProject godoc
+// @Summary      Promote a project commit
+// @Description  Build a versioned image of a committed project version, push it to the chaincode registry and deploy it to a Fabric network as a new chaincode definition (install, approve, commit). The commit must have a passing test run unless skipTests is set. The promotion runs in the background.
+// @Tags         Chaincode Projects
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        request body PromoteProjectRequest false "Promotion parameters"
+// @Success      202 {object} Promotion
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      409 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/promotions [post]
+func (h *ProjectsHandler) PromoteProject(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	var req PromoteProjectRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return errors.NewValidationError("invalid request body", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+	promotion, err := h.Service.PromoteProject(r.Context(), id, PromoteProjectParams{
+		CommitHash:        req.CommitHash,
+		NetworkID:         req.NetworkID,
+		ChaincodeName:     req.ChaincodeName,
+		Version:           req.Version,
+		EndorsementPolicy: req.EndorsementPolicy,
+		SkipTests:         req.SkipTests,
+	})
+	if err != nil {
+		return projectContractError(err, "failed to promote project")
+	}
+	return response.WriteJSON(w, http.StatusAccepted, promotion)
+}
+
+// ListProjectPromotions godoc
+// @Summary      List project promotions
+// @Description  List the promotions of a project, newest first
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Success      200 {array} Promotion
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/promotions [get]
+func (h *ProjectsHandler) ListProjectPromotions(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	promotions, err := h.Service.ListPromotions(r.Context(), id)
+	if err != nil {
+		return projectContractError(err, "failed to list project promotions")
+	}
+	return response.WriteJSON(w, http.StatusOK, promotions)
+}
+
+// GetProjectPromotion godoc
+// @Summary      Get a project promotion
+// @Description  Get a promotion of a project with its current step, or its error when it failed
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        promotionId path int true "Promotion ID"
+// @Success      200 {object} Promotion
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/promotions/{promotionId} [get]
+func (h *ProjectsHandler) GetProjectPromotion(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	promotionID, err := strconv.ParseInt(chi.URLParam(r, "promotionId"), 10, 64)
+	if err != nil {
+		return errors.NewValidationError("invalid promotion id", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	promotion, err := h.Service.GetPromotion(r.Context(), id, promotionID)
+	if err != nil {
+		return projectContractError(err, "failed to get project promotion")
+	}
+	return response.WriteJSON(w, http.StatusOK, promotion)
+}
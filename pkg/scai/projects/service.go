@@ -20,6 +20,7 @@ import (
 
 	"archive/zip"
 
+	"github.com/chainlaunch/chainlaunch/pkg/chainlaunchdeploy"
 	"github.com/chainlaunch/chainlaunch/pkg/common/addresses"
 	"github.com/chainlaunch/chainlaunch/pkg/db"
 	fabricService "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
@@ -40,6 +41,9 @@ type ProjectsService struct {
 	OrgService         *fabricService.OrganizationService
 	KeyMgmtService     *keyMgmtService.KeyManagementService
 	NetworkService     *networkservice.NetworkService
+	ContractService    *chainlaunchdeploy.ContractService
+	DeployService      *chainlaunchdeploy.ChaincodeService
+	ImageRegistry      ImageRegistry
 }
 
 type Project struct {
@@ -65,7 +69,7 @@ var projectProcessManager = struct {
 }{servers: make(map[int64]*exec.Cmd)}
 
 // NewProjectsService creates a new ProjectsService instance
-func NewProjectsService(queries *db.Queries, runner *projectrunner.Runner, projectsDir string, orgService *fabricService.OrganizationService, keyMgmtService *keyMgmtService.KeyManagementService, networkService *networkservice.NetworkService) (*ProjectsService, error) {
+func NewProjectsService(queries *db.Queries, runner *projectrunner.Runner, projectsDir string, orgService *fabricService.OrganizationService, keyMgmtService *keyMgmtService.KeyManagementService, networkService *networkservice.NetworkService, contractService *chainlaunchdeploy.ContractService) (*ProjectsService, error) {
 	boilerplateService, err := boilerplates.NewBoilerplateService(queries)
 	if err != nil {
 		return nil, err
@@ -79,6 +83,7 @@ func NewProjectsService(queries *db.Queries, runner *projectrunner.Runner, proje
 		OrgService:         orgService,
 		KeyMgmtService:     keyMgmtService,
 		NetworkService:     networkService,
+		ContractService:    contractService,
 	}, nil
 }
 
@@ -459,7 +464,7 @@ func (s *ProjectsService) StartProjectServer(ctx context.Context, projectID int6
 	}
 
 	// Get the appropriate lifecycle implementation for the platform
-	lifecycle, err := GetPlatformLifecycle(networkDB.Platform, s.Queries, s.OrgService, s.KeyMgmtService, s.NetworkService, zap.L())
+	lifecycle, err := GetPlatformLifecycle(networkDB.Platform, s.Queries, s.OrgService, s.KeyMgmtService, s.NetworkService, s.Runner, s.ContractService, zap.L())
 	if err != nil {
 		zap.L().Warn("failed to get platform lifecycle, continuing without lifecycle hooks",
 			zap.String("platform", project.Boilerplate.String),
@@ -471,6 +476,10 @@ func (s *ProjectsService) StartProjectServer(ctx context.Context, projectID int6
 	if err != nil {
 		return fmt.Errorf("failed to get boilerplate runner: %w", err)
 	}
+	boilerplateConfig, err := s.BoilerplateService.GetBoilerplateConfig(project.Boilerplate.String)
+	if err != nil {
+		return fmt.Errorf("failed to get boilerplate config: %w", err)
+	}
 
 	projectDir, err := s.safeJoinPath(project.Slug)
 	if err != nil {
@@ -542,12 +551,14 @@ func (s *ProjectsService) StartProjectServer(ctx context.Context, projectID int6
 				Boilerplate:       project.Boilerplate.String,
 				EndorsementPolicy: project.EndorsementPolicy.String,
 			},
-			ContainerID: project.ContainerID.String,
-			Image:       image,
-			Port:        port,
-			StartedAt:   time.Now(),
-			Status:      "running",
-			HostIP:      hostIP,
+			ContainerID:     project.ContainerID.String,
+			Image:           image,
+			Port:            port,
+			StartedAt:       time.Now(),
+			Status:          "running",
+			HostIP:          hostIP,
+			ProjectDir:      projectDir,
+			ValidateCommand: boilerplateConfig.ValidateCommand,
 		}
 		if err := lifecycle.PostStart(ctx, postStartParams); err != nil {
 			// Log the error but don't fail the start operation
@@ -579,7 +590,7 @@ func (s *ProjectsService) StopProjectServer(ctx context.Context, projectID int64
 	}
 
 	// Get the appropriate lifecycle implementation for the platform
-	lifecycle, err := GetPlatformLifecycle(networkDB.Platform, s.Queries, s.OrgService, s.KeyMgmtService, s.NetworkService, zap.L())
+	lifecycle, err := GetPlatformLifecycle(networkDB.Platform, s.Queries, s.OrgService, s.KeyMgmtService, s.NetworkService, s.Runner, s.ContractService, zap.L())
 	if err != nil {
 		zap.L().Warn("failed to get platform lifecycle, continuing without lifecycle hooks",
 			zap.String("platform", project.Boilerplate.String),
@@ -0,0 +1,151 @@
+package projects
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/errors"
+	"github.com/chainlaunch/chainlaunch/pkg/http/response"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projecttests"
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultTestRunsLimit is the number of test runs listed when no limit is given
+const defaultTestRunsLimit = 20
+
+// RunProjectTests godoc
+// @Summary      Run project tests
+// @Description  Run the unit tests of a project in its container and store the results and coverage for the current commit. Failing tests are reported in the run.
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Success      200 {object} projecttests.TestRun
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      409 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/tests/run [post]
+func (h *ProjectsHandler) RunProjectTests(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	if h.Tests == nil {
+		return errors.NewInternalError("test service not configured", nil, nil)
+	}
+	run, err := h.Tests.RunTests(r.Context(), id)
+	if err != nil {
+		return projectTestsError(err, "failed to run project tests")
+	}
+	return response.WriteJSON(w, http.StatusOK, run)
+}
+
+// ListProjectTestRuns godoc
+// @Summary      List project test runs
+// @Description  List the latest test runs of a project, newest first, without their test cases
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        limit query int false "Maximum number of runs" default(20)
+// @Success      200 {array} projecttests.TestRun
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/tests/runs [get]
+func (h *ProjectsHandler) ListProjectTestRuns(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	limit := defaultTestRunsLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return errors.NewValidationError("invalid limit", nil)
+		}
+	}
+	if h.Tests == nil {
+		return errors.NewInternalError("test service not configured", nil, nil)
+	}
+	runs, err := h.Tests.ListRuns(r.Context(), id, limit)
+	if err != nil {
+		return projectTestsError(err, "failed to list project test runs")
+	}
+	return response.WriteJSON(w, http.StatusOK, runs)
+}
+
+// GetProjectTestRun godoc
+// @Summary      Get a project test run
+// @Description  Get a test run of a project with the result of every test case
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        runId path int true "Test run ID"
+// @Success      200 {object} projecttests.TestRun
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/tests/runs/{runId} [get]
+func (h *ProjectsHandler) GetProjectTestRun(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	runID, err := strconv.ParseInt(chi.URLParam(r, "runId"), 10, 64)
+	if err != nil {
+		return errors.NewValidationError("invalid test run id", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	if h.Tests == nil {
+		return errors.NewInternalError("test service not configured", nil, nil)
+	}
+	run, err := h.Tests.GetRun(r.Context(), id, runID)
+	if err != nil {
+		return projectTestsError(err, "failed to get project test run")
+	}
+	return response.WriteJSON(w, http.StatusOK, run)
+}
+
+// CompareProjectTestRuns godoc
+// @Summary      Compare project test runs
+// @Description  Compare the latest test runs of two commits of a project, listing regressions, fixed, new and removed tests and the coverage change. Without "to" the latest run is used, without "from" the run of the previously tested commit.
+// @Tags         Chaincode Projects
+// @Produce      json
+// @Param        id path int true "Project ID"
+// @Param        from query string false "Commit hash of the base version"
+// @Param        to query string false "Commit hash of the compared version"
+// @Success      200 {object} projecttests.Comparison
+// @Failure      400 {object} response.ErrorResponse
+// @Failure      404 {object} response.ErrorResponse
+// @Failure      500 {object} response.ErrorResponse
+// @Router       /chaincode-projects/{id}/tests/compare [get]
+func (h *ProjectsHandler) CompareProjectTestRuns(w http.ResponseWriter, r *http.Request) error {
+	id, err := parseProjectID(r)
+	if err != nil {
+		return err
+	}
+	if h.Tests == nil {
+		return errors.NewInternalError("test service not configured", nil, nil)
+	}
+	comparison, err := h.Tests.Compare(r.Context(), id, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		return projectTestsError(err, "failed to compare project test runs")
+	}
+	return response.WriteJSON(w, http.StatusOK, comparison)
+}
+
+// projectTestsError maps the errors of the test service to API errors
+func projectTestsError(err error, msg string) error {
+	switch {
+	case stderrors.Is(err, projecttests.ErrProjectNotFound):
+		return errors.NewNotFoundError("project not found", nil)
+	case stderrors.Is(err, projecttests.ErrRunNotFound):
+		return errors.NewNotFoundError("test run not found", nil)
+	case stderrors.Is(err, projecttests.ErrNoTestCommand):
+		return errors.NewValidationError(err.Error(), nil)
+	case stderrors.Is(err, projecttests.ErrTestsRunning):
+		return errors.NewConflictError(err.Error(), nil)
+	}
+	return errors.NewInternalError(msg, err, nil)
+}
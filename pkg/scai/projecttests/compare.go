@@ -0,0 +1,66 @@
+package projecttests
+
+// TestChange is a test whose status differs between two runs. From or To is empty when
+// the test does not exist in that run.
+type TestChange struct {
+	Suite   string `json:"suite"`
+	Name    string `json:"name"`
+	From    Status `json:"from,omitempty"`
+	To      Status `json:"to,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Changes lists the tests whose status differs between two runs
+type Changes struct {
+	// Regressions passed in the first run and fail in the second one
+	Regressions []TestChange `json:"regressions"`
+	// Fixed failed in the first run and pass in the second one
+	Fixed []TestChange `json:"fixed"`
+	// NewFailures are failing tests that did not exist in the first run
+	NewFailures []TestChange `json:"newFailures"`
+	Added       []TestChange `json:"added"`
+	Removed     []TestChange `json:"removed"`
+}
+
+// CompareCases compares the test cases of two runs
+func CompareCases(from, to []TestCase) Changes {
+	type key struct{ suite, name string }
+	changes := Changes{
+		Regressions: []TestChange{},
+		Fixed:       []TestChange{},
+		NewFailures: []TestChange{},
+		Added:       []TestChange{},
+		Removed:     []TestChange{},
+	}
+	before := make(map[key]TestCase, len(from))
+	for _, c := range from {
+		before[key{c.Suite, c.Name}] = c
+	}
+	after := make(map[key]bool, len(to))
+	for _, c := range to {
+		k := key{c.Suite, c.Name}
+		after[k] = true
+		prev, existed := before[k]
+		change := TestChange{Suite: c.Suite, Name: c.Name, From: prev.Status, To: c.Status}
+		if c.Status == StatusFailed {
+			change.Message = c.Message
+		}
+		switch {
+		case !existed:
+			changes.Added = append(changes.Added, change)
+			if c.Status == StatusFailed {
+				changes.NewFailures = append(changes.NewFailures, change)
+			}
+		case prev.Status == StatusPassed && c.Status == StatusFailed:
+			changes.Regressions = append(changes.Regressions, change)
+		case prev.Status == StatusFailed && c.Status == StatusPassed:
+			changes.Fixed = append(changes.Fixed, change)
+		}
+	}
+	for _, c := range from {
+		if !after[key{c.Suite, c.Name}] {
+			changes.Removed = append(changes.Removed, TestChange{Suite: c.Suite, Name: c.Name, From: c.Status})
+		}
+	}
+	return changes
+}
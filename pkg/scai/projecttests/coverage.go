@@ -0,0 +1,128 @@
+package projecttests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseCoverage returns the percentage of covered statements or lines of a coverage report.
+// The format is detected from the content: Go cover profile, Istanbul JSON summary
+// (json-summary reporter) or LCOV.
+func ParseCoverage(data []byte) (float64, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return parseGoCoverProfile(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return parseIstanbulSummary(trimmed)
+	case bytes.Contains(trimmed, []byte("end_of_record")):
+		return parseLCOV(trimmed)
+	}
+	return 0, fmt.Errorf("unknown coverage report format")
+}
+
+// parseGoCoverProfile computes the statement coverage of a Go cover profile. A block
+// listed several times, as happens with -coverpkg, is counted once.
+func parseGoCoverProfile(data []byte) (float64, error) {
+	type block struct {
+		statements int
+		covered    bool
+	}
+	blocks := map[string]*block{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// file.go:12.34,15.2 3 1
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return 0, fmt.Errorf("invalid cover profile line %q", line)
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid cover profile line %q", line)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid cover profile line %q", line)
+		}
+		b, ok := blocks[fields[0]]
+		if !ok {
+			b = &block{statements: statements}
+			blocks[fields[0]] = b
+		}
+		b.covered = b.covered || count > 0
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	var total, covered int
+	for _, b := range blocks {
+		total += b.statements
+		if b.covered {
+			covered += b.statements
+		}
+	}
+	return percentage(covered, total), nil
+}
+
+// parseIstanbulSummary reads the line coverage of an Istanbul coverage-summary.json
+func parseIstanbulSummary(data []byte) (float64, error) {
+	var summary struct {
+		Total *struct {
+			Lines struct {
+				Total   int `json:"total"`
+				Covered int `json:"covered"`
+			} `json:"lines"`
+		} `json:"total"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return 0, fmt.Errorf("failed to parse coverage summary: %w", err)
+	}
+	if summary.Total == nil {
+		return 0, fmt.Errorf("coverage summary has no total")
+	}
+	return percentage(summary.Total.Lines.Covered, summary.Total.Lines.Total), nil
+}
+
+// parseLCOV sums the found and hit lines of every file of an LCOV report
+func parseLCOV(data []byte) (float64, error) {
+	var found, hit int
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if v, ok := strings.CutPrefix(line, "LF:"); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid LCOV line %q", line)
+			}
+			found += n
+		} else if v, ok := strings.CutPrefix(line, "LH:"); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid LCOV line %q", line)
+			}
+			hit += n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return percentage(hit, found), nil
+}
+
+// percentage returns covered/total as a percentage rounded to two decimals, 100 when there
+// is nothing to cover
+func percentage(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return math.Round(float64(covered)/float64(total)*10000) / 100
+}
@@ -0,0 +1,162 @@
+package projecttests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoTestJSON(t *testing.T) {
+	output := strings.Join([]string{
+		`go: downloading github.com/hyperledger/fabric-contract-api-go v1.2.2`,
+		`{"Action":"run","Package":"example.com/cc","Test":"TestCreate"}`,
+		`{"Action":"output","Package":"example.com/cc","Test":"TestCreate","Output":"=== RUN   TestCreate\n"}`,
+		`{"Action":"pass","Package":"example.com/cc","Test":"TestCreate","Elapsed":0.01}`,
+		`{"Action":"run","Package":"example.com/cc","Test":"TestDelete"}`,
+		`{"Action":"output","Package":"example.com/cc","Test":"TestDelete","Output":"    cc_test.go:20: asset not found\n"}`,
+		`{"Action":"fail","Package":"example.com/cc","Test":"TestDelete","Elapsed":0.02}`,
+		`{"Action":"skip","Package":"example.com/cc","Test":"TestQuery","Elapsed":0}`,
+		`{"Action":"fail","Package":"example.com/cc","Elapsed":0.5}`,
+		`{"Action":"output","Package":"example.com/cc/broken","Output":"broken.go:3: undefined: x\n"}`,
+		`{"Action":"fail","Package":"example.com/cc/broken","Elapsed":0}`,
+	}, "\n")
+
+	cases, err := ParseGoTestJSON(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, cases, 4)
+
+	assert.Equal(t, "TestCreate", cases[0].Name)
+	assert.Equal(t, StatusPassed, cases[0].Status)
+	assert.Equal(t, int64(10), cases[0].DurationMs)
+	assert.Equal(t, "TestDelete", cases[1].Name)
+	assert.Equal(t, StatusFailed, cases[1].Status)
+	assert.Contains(t, cases[1].Message, "asset not found")
+	assert.Equal(t, StatusSkipped, cases[2].Status)
+	// The package without tests that failed to build is reported as a test case
+	assert.Equal(t, "example.com/cc/broken", cases[3].Name)
+	assert.Contains(t, cases[3].Message, "undefined: x")
+
+	assert.Equal(t, Summary{Total: 4, Passed: 1, Failed: 2, Skipped: 1}, Summarize(cases))
+
+	_, err = ParseGoTestJSON(strings.NewReader("no test files"))
+	assert.Error(t, err)
+}
+
+func TestParseJUnit(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="jest tests" tests="4">
+  <testsuite name="AssetContract">
+    <testcase classname="AssetContract creates an asset" name="creates an asset" time="0.012"/>
+    <testcase classname="AssetContract rejects duplicates" name="rejects duplicates" time="0.003">
+      <failure message="expected error">Error: expected error
+    at Object.test (src/asset.test.ts:20:5)</failure>
+    </testcase>
+    <testcase name="reads an asset"><skipped/></testcase>
+    <testsuite name="nested">
+      <testcase name="errors"><error message="boom"/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+	cases, err := ParseJUnit([]byte(report))
+	require.NoError(t, err)
+	require.Len(t, cases, 4)
+	assert.Equal(t, TestCase{Suite: "AssetContract", Name: "creates an asset", Status: StatusPassed, DurationMs: 12}, cases[0])
+	assert.Equal(t, StatusSkipped, cases[1].Status)
+	assert.Equal(t, "rejects duplicates", cases[2].Name)
+	assert.Equal(t, StatusFailed, cases[2].Status)
+	assert.Contains(t, cases[2].Message, "asset.test.ts:20")
+	assert.Equal(t, TestCase{Suite: "nested", Name: "errors", Status: StatusFailed, Message: "boom"}, cases[3])
+
+	// mocha-junit-reporter can write a single suite as the root element
+	cases, err = ParseJUnit([]byte(`<testsuite name="Token"><testcase name="mints"/></testsuite>`))
+	require.NoError(t, err)
+	require.Len(t, cases, 1)
+	assert.Equal(t, "Token", cases[0].Suite)
+
+	_, err = ParseJUnit([]byte(`<html></html>`))
+	assert.Error(t, err)
+}
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected float64
+	}{
+		{
+			name: "go cover profile",
+			data: "mode: set\n" +
+				"example.com/cc/asset.go:10.2,12.3 3 1\n" +
+				"example.com/cc/asset.go:14.2,15.3 1 0\n" +
+				// The same block listed again by another test binary
+				"example.com/cc/asset.go:14.2,15.3 1 1\n" +
+				"example.com/cc/asset.go:20.2,24.3 4 0\n",
+			expected: 50,
+		},
+		{
+			name:     "istanbul summary",
+			data:     `{"total":{"lines":{"total":3,"covered":2,"skipped":0,"pct":66.66}}}`,
+			expected: 66.67,
+		},
+		{
+			name:     "lcov",
+			data:     "SF:contracts/Token.sol\nLF:10\nLH:9\nend_of_record\nSF:contracts/Vault.sol\nLF:10\nLH:7\nend_of_record\n",
+			expected: 80,
+		},
+		{
+			name:     "nothing to cover",
+			data:     "mode: atomic\n",
+			expected: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverage, err := ParseCoverage([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, coverage)
+		})
+	}
+
+	_, err := ParseCoverage([]byte("coverage: 80%"))
+	assert.Error(t, err)
+}
+
+func TestCompareCases(t *testing.T) {
+	from := []TestCase{
+		{Suite: "s", Name: "stays passing", Status: StatusPassed},
+		{Suite: "s", Name: "regresses", Status: StatusPassed},
+		{Suite: "s", Name: "gets fixed", Status: StatusFailed},
+		{Suite: "s", Name: "removed", Status: StatusPassed},
+	}
+	to := []TestCase{
+		{Suite: "s", Name: "stays passing", Status: StatusPassed},
+		{Suite: "s", Name: "regresses", Status: StatusFailed, Message: "boom"},
+		{Suite: "s", Name: "gets fixed", Status: StatusPassed},
+		{Suite: "s", Name: "new passing", Status: StatusPassed},
+		{Suite: "s", Name: "new failing", Status: StatusFailed},
+	}
+
+	changes := CompareCases(from, to)
+	assert.Equal(t, []TestChange{{Suite: "s", Name: "regresses", From: StatusPassed, To: StatusFailed, Message: "boom"}}, changes.Regressions)
+	assert.Equal(t, []TestChange{{Suite: "s", Name: "gets fixed", From: StatusFailed, To: StatusPassed}}, changes.Fixed)
+	assert.Equal(t, []TestChange{{Suite: "s", Name: "new failing", To: StatusFailed}}, changes.NewFailures)
+	assert.Len(t, changes.Added, 2)
+	assert.Equal(t, []TestChange{{Suite: "s", Name: "removed", From: StatusPassed}}, changes.Removed)
+}
+
+func TestProjectFile(t *testing.T) {
+	dir := t.TempDir()
+	path, err := projectFile(dir, "test-results/junit.xml")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, dir))
+
+	path, err = projectFile(dir, "")
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	_, err = projectFile(dir, "../other/junit.xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+// Package projecttests runs the unit tests of chaincode projects in their container, parses
+// the results and coverage, and stores them per git commit to compare versions.
+package projecttests
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Report formats supported for test results
+const (
+	FormatGoTestJSON = "go-test-json"
+	FormatJUnit      = "junit"
+)
+
+// Status is the outcome of a test case or a test run
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+	// StatusError is the status of a run whose results could not be collected
+	StatusError Status = "error"
+)
+
+// maxMessageLength is the maximum length of the failure message kept for a test case
+const maxMessageLength = 4096
+
+// TestCase is the result of a single test
+type TestCase struct {
+	Suite      string `json:"suite"`
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Summary counts the test cases by status
+type Summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// Summarize counts the test cases by status
+func Summarize(cases []TestCase) Summary {
+	summary := Summary{Total: len(cases)}
+	for _, c := range cases {
+		switch c.Status {
+		case StatusPassed:
+			summary.Passed++
+		case StatusFailed:
+			summary.Failed++
+		case StatusSkipped:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+// goTestEvent is a line of the output of go test -json
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// ParseGoTestJSON parses the output of go test -json. Lines that are not test events,
+// such as build errors printed by the go command, are ignored. A package that fails
+// without a failing test, because it does not build for example, is reported as a
+// failed test case named after the package.
+func ParseGoTestJSON(r io.Reader) ([]TestCase, error) {
+	type testKey struct{ pkg, test string }
+	outputs := map[testKey]*strings.Builder{}
+	var cases []TestCase
+	failedTests := map[string]bool{}
+	events := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var event goTestEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Action == "" {
+			continue
+		}
+		events++
+		key := testKey{event.Package, event.Test}
+
+		switch event.Action {
+		case "output", "build-output":
+			b, ok := outputs[key]
+			if !ok {
+				b = &strings.Builder{}
+				outputs[key] = b
+			}
+			if b.Len() < maxMessageLength {
+				b.WriteString(event.Output)
+			}
+		case "pass", "fail", "skip":
+			if event.Test == "" {
+				// Package result, only reported when the package failed without a failing test
+				if event.Action == "fail" && !failedTests[event.Package] {
+					cases = append(cases, TestCase{
+						Suite:      event.Package,
+						Name:       event.Package,
+						Status:     StatusFailed,
+						DurationMs: int64(event.Elapsed * 1000),
+						Message:    truncate(outputString(outputs[key])),
+					})
+				}
+				continue
+			}
+			c := TestCase{
+				Suite:      event.Package,
+				Name:       event.Test,
+				Status:     goTestStatus(event.Action),
+				DurationMs: int64(event.Elapsed * 1000),
+			}
+			if c.Status == StatusFailed {
+				failedTests[event.Package] = true
+				c.Message = truncate(outputString(outputs[key]))
+			}
+			cases = append(cases, c)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go test output: %w", err)
+	}
+	if events == 0 {
+		return nil, fmt.Errorf("no go test events found in the output")
+	}
+	sortCases(cases)
+	return cases, nil
+}
+
+func goTestStatus(action string) Status {
+	switch action {
+	case "pass":
+		return StatusPassed
+	case "skip":
+		return StatusSkipped
+	}
+	return StatusFailed
+}
+
+func outputString(b *strings.Builder) string {
+	if b == nil {
+		return ""
+	}
+	return b.String()
+}
+
+// junitTestSuites is the root element of a JUnit report. Some reporters write a single
+// testsuite as the root element, and suites can be nested.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:""`
+	Name       string           `xml:"name,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnit parses a JUnit XML report, as written by jest-junit or mocha-junit-reporter
+func ParseJUnit(data []byte) ([]TestCase, error) {
+	var root junitTestSuites
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, fmt.Errorf("unexpected JUnit root element %q", root.XMLName.Local)
+	}
+
+	var cases []TestCase
+	var walk func(suiteName string, suites []junitTestSuite, testCases []junitTestCase)
+	walk = func(suiteName string, suites []junitTestSuite, testCases []junitTestCase) {
+		for _, tc := range testCases {
+			cases = append(cases, junitCase(suiteName, tc))
+		}
+		for _, suite := range suites {
+			walk(suite.Name, suite.TestSuites, suite.TestCases)
+		}
+	}
+	walk(root.Name, root.TestSuites, root.TestCases)
+	sortCases(cases)
+	return cases, nil
+}
+
+func junitCase(suiteName string, tc junitTestCase) TestCase {
+	suite := suiteName
+	if suite == "" {
+		suite = tc.ClassName
+	}
+	c := TestCase{
+		Suite:  suite,
+		Name:   tc.Name,
+		Status: StatusPassed,
+	}
+	if seconds, err := strconv.ParseFloat(tc.Time, 64); err == nil {
+		c.DurationMs = int64(seconds * 1000)
+	}
+	failure := tc.Failure
+	if failure == nil {
+		failure = tc.Error
+	}
+	switch {
+	case failure != nil:
+		c.Status = StatusFailed
+		c.Message = failure.Message
+		if text := strings.TrimSpace(failure.Text); text != "" {
+			if c.Message != "" && !strings.Contains(text, c.Message) {
+				text = c.Message + "\n" + text
+			}
+			c.Message = text
+		}
+		c.Message = truncate(c.Message)
+	case tc.Skipped != nil:
+		c.Status = StatusSkipped
+	}
+	return c
+}
+
+// sortCases orders the cases by suite and name, keeping the order of cases with the same name
+func sortCases(cases []TestCase) {
+	sort.SliceStable(cases, func(i, j int) bool {
+		if cases[i].Suite != cases[j].Suite {
+			return cases[i].Suite < cases[j].Suite
+		}
+		return cases[i].Name < cases[j].Name
+	})
+}
+
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxMessageLength {
+		return s
+	}
+	return s[:maxMessageLength] + "\n... (truncated)"
+}
@@ -0,0 +1,425 @@
+package projecttests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/boilerplates"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/projectrunner"
+	"github.com/chainlaunch/chainlaunch/pkg/scai/versionmanagement"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrRunNotFound     = errors.New("test run not found")
+	ErrNoTestCommand   = errors.New("no test command configured for the project boilerplate")
+	ErrTestsRunning    = errors.New("tests are already running for the project")
+)
+
+// maxOutputLength is the length of the end of the test output kept with a run
+const maxOutputLength = 64 * 1024
+
+// TestRun is a run of the unit tests of a project at a commit
+type TestRun struct {
+	ID            int64  `json:"id"`
+	ProjectID     int64  `json:"projectId"`
+	CommitHash    string `json:"commitHash"`
+	CommitMessage string `json:"commitMessage,omitempty"`
+	// Dirty is set when the working tree had uncommitted changes
+	Dirty   bool    `json:"dirty"`
+	Status  Status  `json:"status"`
+	Summary Summary `json:"summary"`
+	// Coverage is the percentage of covered statements or lines, nil without a coverage report
+	Coverage   *float64   `json:"coverage,omitempty"`
+	DurationMs int64      `json:"durationMs"`
+	ExitCode   int        `json:"exitCode"`
+	Output     string     `json:"output,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Cases      []TestCase `json:"cases,omitempty"`
+}
+
+// Comparison is the difference between the test runs of two versions of a project
+type Comparison struct {
+	From *TestRun `json:"from"`
+	To   *TestRun `json:"to"`
+	// CoverageDelta is the coverage change in percentage points, nil when a run has no coverage
+	CoverageDelta *float64 `json:"coverageDelta,omitempty"`
+	Changes       Changes  `json:"changes"`
+}
+
+// Service runs the unit tests of projects and stores the results
+type Service struct {
+	queries            *db.Queries
+	runner             *projectrunner.Runner
+	boilerplateService *boilerplates.BoilerplateService
+	projectsDir        string
+	logger             *logger.Logger
+
+	mu sync.Mutex
+	// running holds the projects whose tests are running, one run at a time per project
+	running map[int64]bool
+}
+
+// NewService creates a new test service
+func NewService(queries *db.Queries, runner *projectrunner.Runner, boilerplateService *boilerplates.BoilerplateService, projectsDir string, logger *logger.Logger) *Service {
+	return &Service{
+		queries:            queries,
+		runner:             runner,
+		boilerplateService: boilerplateService,
+		projectsDir:        projectsDir,
+		logger:             logger,
+		running:            make(map[int64]bool),
+	}
+}
+
+// RunTests runs the test command of the project boilerplate in the project container,
+// parses the test report and coverage, and stores the run for the current commit.
+// Failing tests are reported in the run, not as an error.
+func (s *Service) RunTests(ctx context.Context, projectID int64) (*TestRun, error) {
+	project, err := s.queries.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if !project.Boilerplate.Valid || project.Boilerplate.String == "" {
+		return nil, ErrNoTestCommand
+	}
+	config, err := s.boilerplateService.GetBoilerplateConfig(project.Boilerplate.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get boilerplate config: %w", err)
+	}
+	if config.TestCommand == "" {
+		return nil, ErrNoTestCommand
+	}
+	projectDir, err := s.projectDir(project.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.running[projectID] {
+		s.mu.Unlock()
+		return nil, ErrTestsRunning
+	}
+	s.running[projectID] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, projectID)
+		s.mu.Unlock()
+	}()
+
+	version, err := versionmanagement.NewDefaultManager().GetCurrentVersion(ctx, projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project version: %w", err)
+	}
+	dirty, err := versionmanagement.HasUncommittedChanges(ctx, projectDir)
+	if err != nil {
+		s.logger.Warn("Failed to check uncommitted changes", "projectID", projectID, "error", err)
+	}
+
+	// Remove the reports of the previous run so stale results are never collected
+	reportPath, err := projectFile(projectDir, config.TestReportPath)
+	if err != nil {
+		return nil, err
+	}
+	coveragePath, err := projectFile(projectDir, config.CoverageFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range []string{reportPath, coveragePath} {
+		if path != "" {
+			_ = os.Remove(path)
+		}
+	}
+
+	started := time.Now()
+	out, err := s.runner.ExecCommand(ctx, strconv.FormatInt(projectID, 10), config.TestCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run tests: %w", err)
+	}
+
+	run := &TestRun{
+		ProjectID:     projectID,
+		CommitHash:    version.ID,
+		CommitMessage: strings.TrimSpace(version.Message),
+		Dirty:         dirty,
+		DurationMs:    time.Since(started).Milliseconds(),
+		ExitCode:      out.ExitCode,
+		Output:        tail(out.Output, maxOutputLength),
+	}
+	cases, err := collectCases(config, reportPath, out.Output)
+	if err != nil {
+		run.Status = StatusError
+		run.Error = err.Error()
+	} else {
+		run.Cases = cases
+		run.Summary = Summarize(cases)
+		run.Status = StatusPassed
+		if run.Summary.Failed > 0 {
+			run.Status = StatusFailed
+		} else if out.ExitCode != 0 {
+			run.Status = StatusFailed
+			run.Error = fmt.Sprintf("test command exited with code %d", out.ExitCode)
+		}
+	}
+	if coveragePath != "" {
+		if data, err := os.ReadFile(coveragePath); err != nil {
+			s.logger.Warn("Failed to read coverage report", "projectID", projectID, "error", err)
+		} else if coverage, err := ParseCoverage(data); err != nil {
+			s.logger.Warn("Failed to parse coverage report", "projectID", projectID, "error", err)
+		} else {
+			run.Coverage = &coverage
+		}
+	}
+
+	if err := s.saveRun(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// collectCases reads the test cases from the output of the test command or from the JUnit report
+func collectCases(config boilerplates.BoilerplateConfig, reportPath, output string) ([]TestCase, error) {
+	switch config.TestReportFormat {
+	case FormatGoTestJSON:
+		return ParseGoTestJSON(strings.NewReader(output))
+	case FormatJUnit:
+		if reportPath == "" {
+			return nil, fmt.Errorf("no JUnit report path configured")
+		}
+		data, err := os.ReadFile(reportPath)
+		if err != nil {
+			return nil, fmt.Errorf("test report not found: %w", err)
+		}
+		return ParseJUnit(data)
+	}
+	return nil, fmt.Errorf("unsupported test report format %q", config.TestReportFormat)
+}
+
+func (s *Service) saveRun(ctx context.Context, run *TestRun) error {
+	params := &db.CreateProjectTestRunParams{
+		ProjectID:     run.ProjectID,
+		CommitHash:    run.CommitHash,
+		CommitMessage: sql.NullString{String: run.CommitMessage, Valid: run.CommitMessage != ""},
+		Dirty:         run.Dirty,
+		Status:        string(run.Status),
+		Total:         int64(run.Summary.Total),
+		Passed:        int64(run.Summary.Passed),
+		Failed:        int64(run.Summary.Failed),
+		Skipped:       int64(run.Summary.Skipped),
+		DurationMs:    run.DurationMs,
+		ExitCode:      int64(run.ExitCode),
+		Output:        sql.NullString{String: run.Output, Valid: run.Output != ""},
+		Error:         sql.NullString{String: run.Error, Valid: run.Error != ""},
+	}
+	if run.Coverage != nil {
+		params.Coverage = sql.NullFloat64{Float64: *run.Coverage, Valid: true}
+	}
+	return s.queries.ExecTx(ctx, func(q *db.Queries) error {
+		return storeRun(ctx, q, params, run)
+	})
+}
+
+// storeRun creates a test run with its test cases and sets the stored ID on run. It
+// runs in the transaction of saveRun so a failure leaves no run without its cases.
+func storeRun(ctx context.Context, q *db.Queries, params *db.CreateProjectTestRunParams, run *TestRun) error {
+	dbRun, err := q.CreateProjectTestRun(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to save test run: %w", err)
+	}
+	for _, c := range run.Cases {
+		if err := q.CreateProjectTestResult(ctx, &db.CreateProjectTestResultParams{
+			RunID:      dbRun.ID,
+			Suite:      c.Suite,
+			Name:       c.Name,
+			Status:     string(c.Status),
+			DurationMs: c.DurationMs,
+			Message:    sql.NullString{String: c.Message, Valid: c.Message != ""},
+		}); err != nil {
+			return fmt.Errorf("failed to save test result %s: %w", c.Name, err)
+		}
+	}
+	run.ID = dbRun.ID
+	run.CreatedAt = dbRun.CreatedAt
+	return nil
+}
+
+// ListRuns returns the latest test runs of a project, without their test cases
+func (s *Service) ListRuns(ctx context.Context, projectID int64, limit int) ([]TestRun, error) {
+	runs, err := s.queries.ListProjectTestRuns(ctx, &db.ListProjectTestRunsParams{
+		ProjectID: projectID,
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list test runs: %w", err)
+	}
+	result := make([]TestRun, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, *mapRun(run))
+	}
+	return result, nil
+}
+
+// GetRun returns a test run of a project with its test cases
+func (s *Service) GetRun(ctx context.Context, projectID, runID int64) (*TestRun, error) {
+	run, err := s.queries.GetProjectTestRun(ctx, &db.GetProjectTestRunParams{ID: runID, ProjectID: projectID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get test run: %w", err)
+	}
+	return s.withCases(ctx, run)
+}
+
+// Compare compares the latest test runs of two commits of a project. When toCommit is
+// empty the latest run is used, and when fromCommit is empty the run of the previous
+// tested commit is used.
+func (s *Service) Compare(ctx context.Context, projectID int64, fromCommit, toCommit string) (*Comparison, error) {
+	var toRun *db.ProjectTestRun
+	var err error
+	if toCommit == "" {
+		toRun, err = s.queries.GetLatestProjectTestRun(ctx, projectID)
+	} else {
+		toRun, err = s.queries.GetLatestProjectTestRunForCommit(ctx, &db.GetLatestProjectTestRunForCommitParams{
+			ProjectID:  projectID,
+			CommitHash: toCommit,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get test run: %w", err)
+	}
+
+	var fromRun *db.ProjectTestRun
+	if fromCommit == "" {
+		fromRun, err = s.queries.GetPreviousProjectTestRun(ctx, &db.GetPreviousProjectTestRunParams{
+			ProjectID:  projectID,
+			CommitHash: toRun.CommitHash,
+			ID:         toRun.ID,
+		})
+	} else {
+		fromRun, err = s.queries.GetLatestProjectTestRunForCommit(ctx, &db.GetLatestProjectTestRunForCommitParams{
+			ProjectID:  projectID,
+			CommitHash: fromCommit,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get test run: %w", err)
+	}
+
+	from, err := s.withCases(ctx, fromRun)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.withCases(ctx, toRun)
+	if err != nil {
+		return nil, err
+	}
+	comparison := &Comparison{
+		From:    from,
+		To:      to,
+		Changes: CompareCases(from.Cases, to.Cases),
+	}
+	if from.Coverage != nil && to.Coverage != nil {
+		delta := math.Round((*to.Coverage-*from.Coverage)*100) / 100
+		comparison.CoverageDelta = &delta
+	}
+	return comparison, nil
+}
+
+func (s *Service) withCases(ctx context.Context, dbRun *db.ProjectTestRun) (*TestRun, error) {
+	results, err := s.queries.ListProjectTestResults(ctx, dbRun.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list test results: %w", err)
+	}
+	run := mapRun(dbRun)
+	run.Cases = make([]TestCase, 0, len(results))
+	for _, r := range results {
+		run.Cases = append(run.Cases, TestCase{
+			Suite:      r.Suite,
+			Name:       r.Name,
+			Status:     Status(r.Status),
+			DurationMs: r.DurationMs,
+			Message:    r.Message.String,
+		})
+	}
+	return run, nil
+}
+
+func mapRun(run *db.ProjectTestRun) *TestRun {
+	result := &TestRun{
+		ID:            run.ID,
+		ProjectID:     run.ProjectID,
+		CommitHash:    run.CommitHash,
+		CommitMessage: run.CommitMessage.String,
+		Dirty:         run.Dirty,
+		Status:        Status(run.Status),
+		Summary: Summary{
+			Total:   int(run.Total),
+			Passed:  int(run.Passed),
+			Failed:  int(run.Failed),
+			Skipped: int(run.Skipped),
+		},
+		DurationMs: run.DurationMs,
+		ExitCode:   int(run.ExitCode),
+		Output:     run.Output.String,
+		Error:      run.Error.String,
+		CreatedAt:  run.CreatedAt,
+	}
+	if run.Coverage.Valid {
+		coverage := run.Coverage.Float64
+		result.Coverage = &coverage
+	}
+	return result
+}
+
+// projectDir returns the directory of a project, ensuring it stays within the projects directory
+func (s *Service) projectDir(slug string) (string, error) {
+	return projectFile(s.projectsDir, slug)
+}
+
+// projectFile joins a relative path to a directory, ensuring the result stays within the directory.
+// An empty path returns an empty string.
+func projectFile(dir, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	joined := filepath.Join(absDir, path)
+	if joined != absDir && !strings.HasPrefix(joined, absDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of %s", path, dir)
+	}
+	return joined, nil
+}
+
+// tail returns the last n bytes of s
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "... (truncated)\n" + s[len(s)-n:]
+}
@@ -0,0 +1,74 @@
+package projecttests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+)
+
+func TestSaveRun(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sqlDB, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	queries := db.New(sqlDB)
+
+	res, err := sqlDB.ExecContext(ctx, `INSERT INTO chaincode_projects (name, slug) VALUES ('asset', 'asset')`)
+	require.NoError(t, err)
+	projectID, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	newRun := func() *TestRun {
+		return &TestRun{
+			ProjectID:  projectID,
+			CommitHash: "abc123",
+			Status:     StatusFailed,
+			Summary:    Summary{Total: 2, Passed: 1, Failed: 1},
+			Cases: []TestCase{
+				{Suite: "example.com/cc", Name: "TestCreate", Status: StatusPassed},
+				{Suite: "example.com/cc", Name: "TestDelete", Status: StatusFailed, Message: "asset not found"},
+			},
+		}
+	}
+	listRuns := func() []*db.ProjectTestRun {
+		runs, err := queries.ListProjectTestRuns(ctx, &db.ListProjectTestRunsParams{ProjectID: projectID, Limit: 10})
+		require.NoError(t, err)
+		return runs
+	}
+
+	// A failed transaction leaves no run without its test cases
+	errAbort := errors.New("abort")
+	err = queries.ExecTx(ctx, func(q *db.Queries) error {
+		if err := storeRun(ctx, q, &db.CreateProjectTestRunParams{ProjectID: projectID, CommitHash: "abc123", Status: string(StatusFailed)}, newRun()); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+	assert.Empty(t, listRuns())
+
+	s := &Service{queries: queries}
+	run := newRun()
+	require.NoError(t, s.saveRun(ctx, run))
+	require.NotZero(t, run.ID)
+	runs := listRuns()
+	require.Len(t, runs, 1)
+	assert.Equal(t, run.ID, runs[0].ID)
+	assert.Equal(t, int64(1), runs[0].Failed)
+	results, err := queries.ListProjectTestResults(ctx, run.ID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "TestDelete", results[1].Name)
+	assert.Equal(t, "asset not found", results[1].Message.String)
+}
@@ -1,8 +1,10 @@
 package versionmanagement
 
 import (
+	"archive/tar"
 	"context"
 	"io"
+	"os"
 	"time"
 
 	"bytes"
@@ -202,3 +204,121 @@ func GetFileAtCommit(ctx context.Context, repoDir, filePath, commitHash string)
 	}
 	return content, nil
 }
+
+// HasUncommittedChanges reports whether the working tree of the repository in the given directory has uncommitted changes.
+func HasUncommittedChanges(ctx context.Context, repoDir string) (bool, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return false, err
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := w.Status()
+	if err != nil {
+		return false, err
+	}
+	return !status.IsClean(), nil
+}
+
+// ResolveCommit returns the commit of a revision (full or abbreviated hash, branch or HEAD) in the given repo directory.
+func ResolveCommit(ctx context.Context, repoDir, revision string) (VersionEntry, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	return VersionEntry{
+		ID:        commit.Hash.String(),
+		Author:    commit.Author.Name,
+		Timestamp: commit.Author.When.Format(time.RFC3339),
+		Message:   commit.Message,
+	}, nil
+}
+
+// ArchiveCommit writes the files of a commit in the given repo directory to w as a tar stream.
+// The extra files are added at the root of the archive, replacing committed files with the same path.
+func ArchiveCommit(ctx context.Context, repoDir, commitHash string, w io.Writer, extra map[string][]byte) error {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return err
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	modTime := commit.Committer.When
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if _, ok := extra[f.Name]; ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		mode, err := f.Mode.ToOSFileMode()
+		if err != nil {
+			return err
+		}
+		if mode&os.ModeSymlink != 0 {
+			target, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			return tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeSymlink,
+				Name:     f.Name,
+				Linkname: target,
+				Mode:     0777,
+				ModTime:  modTime,
+			})
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.Name,
+			Size:     f.Size,
+			Mode:     int64(mode.Perm()),
+			ModTime:  modTime,
+		}); err != nil {
+			return err
+		}
+		reader, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		_, err = io.Copy(tw, reader)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for name, content := range extra {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(content)),
+			Mode:     0644,
+			ModTime:  modTime,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
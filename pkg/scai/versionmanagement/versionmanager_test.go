@@ -0,0 +1,63 @@
+package versionmanagement
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCommit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, err := InitRepo(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0644))
+	manager := NewDefaultManager()
+	require.NoError(t, manager.CommitChange(ctx, dir, "initial"))
+	version, err := manager.GetCurrentVersion(ctx, dir)
+	require.NoError(t, err)
+
+	// Uncommitted changes are not part of the archive
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package broken\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("draft"), 0644))
+	dirty, err := HasUncommittedChanges(ctx, dir)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	resolved, err := ResolveCommit(ctx, dir, version.ID[:8])
+	require.NoError(t, err)
+	assert.Equal(t, version.ID, resolved.ID)
+	_, err = ResolveCommit(ctx, dir, "0000000000000000000000000000000000000000")
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, ArchiveCommit(ctx, dir, version.ID, &buf, map[string][]byte{
+		"Dockerfile": []byte("FROM alpine\n"),
+	}))
+	files := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{
+		"src/main.go": "package main\n",
+		"Dockerfile":  "FROM alpine\n",
+	}, files)
+}
@@ -9,6 +9,9 @@ package types
 
 import (
 	"encoding/json"
+	"net"
+	"net/url"
+	"strconv"
 	"time"
 
 	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
@@ -148,3 +151,17 @@ type PostgresDeployment struct {
 	ContainerName string `json:"containerName,omitempty"`
 	NetworkName   string `json:"networkName,omitempty"`
 }
+
+// PostgresDSN is the connection string of a running POSTGRES service as
+// seen from a container on the service's docker network. TLS is disabled:
+// the container is only reachable on that network or the published port.
+func PostgresDSN(cfg PostgresConfig, dep PostgresDeployment) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(dep.Host, strconv.Itoa(dep.Port)),
+		Path:     "/" + cfg.DB,
+		RawQuery: "sslmode=disable",
+	}
+	return u.String()
+}
@@ -0,0 +1,223 @@
+// Package http exposes REST endpoints for testnets.
+//
+// Routes mounted under /testnets. {ref} is a testnet ID or name:
+//
+//	GET    /testnets                   list
+//	POST   /testnets                   register a testnet before creating its resources
+//	GET    /testnets/{ref}             get, with the live status of each resource
+//	POST   /testnets/{ref}/resources   record created resources
+//	PUT    /testnets/{ref}/status      mark creation READY or FAILED
+//	DELETE /testnets/{ref}             stop and delete every resource, then the testnet
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/chainlaunch/chainlaunch/pkg/testnets"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	service  *testnets.Service
+	validate *validator.Validate
+}
+
+func NewHandler(svc *testnets.Service) *Handler {
+	return &Handler{service: svc, validate: validator.New()}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/testnets", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", h.Get)
+			r.Post("/resources", h.AddResources)
+			r.Put("/status", h.UpdateStatus)
+			r.Delete("/", h.Destroy)
+		})
+	})
+}
+
+// @Summary List testnets
+// @Tags Testnets
+// @Produce json
+// @Success 200 {array} testnets.Testnet
+// @Router /testnets [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.List(r.Context())
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// @Summary Register a testnet
+// @Description The testnet starts in CREATING; record its resources as they are created
+// @Tags Testnets
+// @Accept json
+// @Produce json
+// @Param request body testnets.CreateTestnetRequest true "Testnet"
+// @Success 201 {object} testnets.Testnet
+// @Router /testnets [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req testnets.CreateTestnetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	testnet, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, testnet)
+}
+
+// @Summary Get a testnet
+// @Tags Testnets
+// @Produce json
+// @Param ref path string true "Testnet ID or name"
+// @Success 200 {object} testnets.Testnet
+// @Router /testnets/{ref} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	testnet, ok := h.lookup(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, testnet)
+}
+
+// @Summary Record testnet resources
+// @Tags Testnets
+// @Accept json
+// @Produce json
+// @Param ref path string true "Testnet ID or name"
+// @Param request body testnets.AddResourcesRequest true "Resources"
+// @Success 200 {object} testnets.Testnet
+// @Router /testnets/{ref}/resources [post]
+func (h *Handler) AddResources(w http.ResponseWriter, r *http.Request) {
+	testnet, ok := h.lookup(w, r)
+	if !ok {
+		return
+	}
+	var req testnets.AddResourcesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated, err := h.service.AddResources(r.Context(), testnet.ID, req.Resources)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// @Summary Mark the outcome of creating a testnet
+// @Tags Testnets
+// @Accept json
+// @Produce json
+// @Param ref path string true "Testnet ID or name"
+// @Param request body testnets.UpdateStatusRequest true "Status"
+// @Success 200 {object} testnets.Testnet
+// @Router /testnets/{ref}/status [put]
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	testnet, ok := h.lookup(w, r)
+	if !ok {
+		return
+	}
+	var req testnets.UpdateStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated, err := h.service.UpdateStatus(r.Context(), testnet.ID, req)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// @Summary Destroy a testnet
+// @Description Stops and deletes networks, nodes, node groups, services, organizations and keys in that order. When a resource cannot be removed the testnet is kept as FAILED and the destroy can be retried.
+// @Tags Testnets
+// @Produce json
+// @Param ref path string true "Testnet ID or name"
+// @Success 200 {object} testnets.DestroyResult
+// @Router /testnets/{ref} [delete]
+func (h *Handler) Destroy(w http.ResponseWriter, r *http.Request) {
+	testnet, ok := h.lookup(w, r)
+	if !ok {
+		return
+	}
+	result, err := h.service.Destroy(r.Context(), testnet.ID)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// lookup resolves the {ref} path parameter, trying it as an ID before a name
+func (h *Handler) lookup(w http.ResponseWriter, r *http.Request) (*testnets.Testnet, bool) {
+	ref := chi.URLParam(r, "ref")
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		testnet, err := h.service.Get(r.Context(), id)
+		if err == nil {
+			return testnet, true
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeErr(w, statusFor(err), err.Error())
+			return nil, false
+		}
+	}
+	testnet, err := h.service.GetByName(r.Context(), ref)
+	if err != nil {
+		writeErr(w, statusFor(err), err.Error())
+		return nil, false
+	}
+	return testnet, true
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, testnets.ErrNameTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeErr(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
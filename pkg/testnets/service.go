@@ -0,0 +1,330 @@
+// Package testnets records the resources created for a test network so the
+// whole network can be listed, inspected and destroyed as one unit.
+//
+// The testnet commands register a testnet before creating anything and record
+// each organization, key, node, node group, service and network as it is
+// created. Destroy removes them in dependency order and forgets each resource
+// as soon as it is gone, so a destroy that fails half way can be retried.
+package testnets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	fabricservice "github.com/chainlaunch/chainlaunch/pkg/fabric/service"
+	keymanagement "github.com/chainlaunch/chainlaunch/pkg/keymanagement/service"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+	networksservice "github.com/chainlaunch/chainlaunch/pkg/networks/service"
+	ngroupsservice "github.com/chainlaunch/chainlaunch/pkg/nodegroups/service"
+	nodesservice "github.com/chainlaunch/chainlaunch/pkg/nodes/service"
+	nodetypes "github.com/chainlaunch/chainlaunch/pkg/nodes/types"
+	svcservice "github.com/chainlaunch/chainlaunch/pkg/services/service"
+)
+
+// ErrNameTaken is returned when creating a testnet with the name of an existing one
+var ErrNameTaken = errors.New("a testnet with this name already exists")
+
+// removeFunc stops and deletes a single resource
+type removeFunc func(ctx context.Context, id int64) error
+
+// Service records testnets and tears them down
+type Service struct {
+	queries  *db.Queries
+	logger   *logger.Logger
+	removers map[ResourceType]removeFunc
+}
+
+// NewService creates a testnets service that removes resources through the
+// services that own them
+func NewService(
+	queries *db.Queries,
+	nodes *nodesservice.NodeService,
+	networks *networksservice.NetworkService,
+	nodeGroups *ngroupsservice.Service,
+	services *svcservice.Service,
+	organizations *fabricservice.OrganizationService,
+	keys *keymanagement.KeyManagementService,
+	logger *logger.Logger,
+) *Service {
+	return &Service{
+		queries: queries,
+		logger:  logger,
+		removers: map[ResourceType]removeFunc{
+			ResourceNetwork: networks.DeleteNetwork,
+			// DeleteNode stops a running node before deleting it
+			ResourceNode: nodes.DeleteNode,
+			ResourceNodeGroup: func(ctx context.Context, id int64) error {
+				if err := nodeGroups.StopGroup(ctx, id); err != nil {
+					logger.Warn("Failed to stop node group before deleting it", "id", id, "error", err)
+				}
+				return nodeGroups.Delete(ctx, id)
+			},
+			ResourceService: func(ctx context.Context, id int64) error {
+				svc, err := services.Get(ctx, id)
+				if err != nil {
+					return err
+				}
+				switch nodetypes.NodeStatus(svc.Status) {
+				case nodetypes.NodeStatusRunning, nodetypes.NodeStatusStarting, nodetypes.NodeStatusError:
+					if err := services.Stop(ctx, id); err != nil {
+						return fmt.Errorf("failed to stop service: %w", err)
+					}
+				}
+				return services.Delete(ctx, id)
+			},
+			ResourceOrganization: organizations.DeleteOrganization,
+			ResourceKey: func(ctx context.Context, id int64) error {
+				return keys.DeleteKey(ctx, int(id))
+			},
+		},
+	}
+}
+
+// Create registers a testnet in the CREATING state
+func (s *Service) Create(ctx context.Context, req CreateTestnetRequest) (*Testnet, error) {
+	if _, err := s.queries.GetTestnetByName(ctx, req.Name); err == nil {
+		return nil, ErrNameTaken
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check testnet name: %w", err)
+	}
+	testnet, err := s.queries.CreateTestnet(ctx, &db.CreateTestnetParams{
+		Name:     req.Name,
+		Platform: string(req.Platform),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create testnet: %w", err)
+	}
+	return s.mapTestnet(ctx, testnet)
+}
+
+// AddResources records resources created for a testnet. Recording a resource twice is a no-op.
+func (s *Service) AddResources(ctx context.Context, id int64, refs []ResourceRef) (*Testnet, error) {
+	if _, err := s.queries.GetTestnet(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get testnet: %w", err)
+	}
+	for _, ref := range refs {
+		if !ref.Type.Valid() {
+			return nil, fmt.Errorf("unknown resource type %q", ref.Type)
+		}
+		if err := s.queries.AddTestnetResource(ctx, &db.AddTestnetResourceParams{
+			TestnetID:    id,
+			ResourceType: string(ref.Type),
+			ResourceID:   ref.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record %s %d: %w", ref.Type, ref.ID, err)
+		}
+	}
+	return s.Get(ctx, id)
+}
+
+// UpdateStatus records the outcome of creating a testnet
+func (s *Service) UpdateStatus(ctx context.Context, id int64, req UpdateStatusRequest) (*Testnet, error) {
+	if _, err := s.queries.GetTestnet(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get testnet: %w", err)
+	}
+	s.setStatus(ctx, id, req.Status, req.Error)
+	return s.Get(ctx, id)
+}
+
+// List returns all testnets, newest first, with their resources
+func (s *Service) List(ctx context.Context) ([]*Testnet, error) {
+	rows, err := s.queries.ListTestnets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list testnets: %w", err)
+	}
+	testnets := make([]*Testnet, 0, len(rows))
+	for _, row := range rows {
+		testnet, err := s.mapTestnet(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		testnets = append(testnets, testnet)
+	}
+	return testnets, nil
+}
+
+// Get returns a testnet with the live name and status of each of its resources
+func (s *Service) Get(ctx context.Context, id int64) (*Testnet, error) {
+	testnet, err := s.queries.GetTestnet(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get testnet: %w", err)
+	}
+	return s.mapTestnet(ctx, testnet)
+}
+
+// GetByName returns a testnet by name
+func (s *Service) GetByName(ctx context.Context, name string) (*Testnet, error) {
+	testnet, err := s.queries.GetTestnetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get testnet %q: %w", name, err)
+	}
+	return s.mapTestnet(ctx, testnet)
+}
+
+// Destroy stops and deletes every resource of a testnet, networks first and keys
+// last. Resources that are already gone are skipped. A resource that cannot be
+// removed does not stop the rest of its type, but the types it depends on are
+// kept and the testnet is marked FAILED so the destroy can be retried. The
+// testnet record is deleted once nothing is left.
+func (s *Service) Destroy(ctx context.Context, id int64) (*DestroyResult, error) {
+	if _, err := s.queries.GetTestnet(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get testnet: %w", err)
+	}
+	rows, err := s.queries.ListTestnetResources(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list testnet resources: %w", err)
+	}
+	s.setStatus(ctx, id, StatusDestroying, "")
+
+	byType := make(map[ResourceType][]int64)
+	for _, row := range rows {
+		t := ResourceType(row.ResourceType)
+		byType[t] = append(byType[t], row.ResourceID)
+	}
+
+	result := &DestroyResult{Removed: []ResourceRef{}}
+	for _, t := range destroyOrder {
+		// Later resources in the same type (e.g. child nodes) are usually
+		// created after what they depend on, so remove newest first
+		ids := byType[t]
+		for i := len(ids) - 1; i >= 0; i-- {
+			ref := ResourceRef{Type: t, ID: ids[i]}
+			if err := s.remove(ctx, ref); err != nil {
+				s.logger.Warn("Failed to remove testnet resource", "testnet", id, "type", t, "id", ref.ID, "error", err)
+				result.Failed = append(result.Failed, FailedResource{ResourceRef: ref, Error: err.Error()})
+				continue
+			}
+			if err := s.queries.DeleteTestnetResource(ctx, &db.DeleteTestnetResourceParams{
+				TestnetID:    id,
+				ResourceType: string(t),
+				ResourceID:   ref.ID,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to forget %s %d: %w", t, ref.ID, err)
+			}
+			result.Removed = append(result.Removed, ref)
+		}
+		if len(result.Failed) > 0 {
+			// Keep what the failed resources depend on
+			break
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		msgs := make([]string, 0, len(result.Failed))
+		for _, f := range result.Failed {
+			msgs = append(msgs, fmt.Sprintf("%s %d: %s", f.Type, f.ID, f.Error))
+		}
+		s.setStatus(ctx, id, StatusFailed, "destroy incomplete: "+strings.Join(msgs, "; "))
+		return result, nil
+	}
+	if err := s.queries.DeleteTestnet(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to delete testnet: %w", err)
+	}
+	result.Deleted = true
+	return result, nil
+}
+
+// remove deletes a resource unless it no longer exists
+func (s *Service) remove(ctx context.Context, ref ResourceRef) error {
+	if _, _, err := s.lookup(ctx, ref); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	remove, ok := s.removers[ref.Type]
+	if !ok {
+		return fmt.Errorf("unknown resource type %q", ref.Type)
+	}
+	return remove(ctx, ref.ID)
+}
+
+// lookup returns the name and status of a resource, or sql.ErrNoRows when it does not exist
+func (s *Service) lookup(ctx context.Context, ref ResourceRef) (string, string, error) {
+	switch ref.Type {
+	case ResourceNetwork:
+		row, err := s.queries.GetNetwork(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.Name, row.Status, nil
+	case ResourceNode:
+		row, err := s.queries.GetNode(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.Name, row.Status, nil
+	case ResourceNodeGroup:
+		row, err := s.queries.GetNodeGroup(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.Name, row.Status, nil
+	case ResourceService:
+		row, err := s.queries.GetService(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.Name, row.Status, nil
+	case ResourceOrganization:
+		row, err := s.queries.GetFabricOrganization(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.MspID, "", nil
+	case ResourceKey:
+		row, err := s.queries.GetKey(ctx, ref.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return row.Name, row.Status, nil
+	default:
+		return "", "", fmt.Errorf("unknown resource type %q", ref.Type)
+	}
+}
+
+func (s *Service) setStatus(ctx context.Context, id int64, status Status, lastError string) {
+	if err := s.queries.UpdateTestnetStatus(ctx, &db.UpdateTestnetStatusParams{
+		Status:    string(status),
+		LastError: sql.NullString{String: lastError, Valid: lastError != ""},
+		ID:        id,
+	}); err != nil {
+		s.logger.Error("Failed to update testnet status", "id", id, "error", err)
+	}
+}
+
+func (s *Service) mapTestnet(ctx context.Context, testnet *db.Testnet) (*Testnet, error) {
+	rows, err := s.queries.ListTestnetResources(ctx, testnet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list testnet resources: %w", err)
+	}
+	resources := make([]Resource, 0, len(rows))
+	for _, row := range rows {
+		res := Resource{Type: ResourceType(row.ResourceType), ID: row.ResourceID}
+		name, status, err := s.lookup(ctx, ResourceRef{Type: res.Type, ID: res.ID})
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			res.Missing = true
+		case err != nil:
+			s.logger.Warn("Failed to look up testnet resource", "type", res.Type, "id", res.ID, "error", err)
+		default:
+			res.Name = name
+			res.Status = status
+		}
+		resources = append(resources, res)
+	}
+	return &Testnet{
+		ID:        testnet.ID,
+		Name:      testnet.Name,
+		Platform:  Platform(testnet.Platform),
+		Status:    Status(testnet.Status),
+		LastError: testnet.LastError.String,
+		Resources: resources,
+		CreatedAt: testnet.CreatedAt,
+		UpdatedAt: testnet.UpdatedAt,
+	}, nil
+}
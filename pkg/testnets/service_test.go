@@ -0,0 +1,112 @@
+package testnets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chainlaunch/chainlaunch/pkg/db"
+	"github.com/chainlaunch/chainlaunch/pkg/logger"
+)
+
+func newTestService(t *testing.T) (*Service, *db.Queries) {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.RunMigrations(sqlDB))
+	queries := db.New(sqlDB)
+	return &Service{queries: queries, logger: logger.NewDefault(), removers: map[ResourceType]removeFunc{}}, queries
+}
+
+func TestDestroyKeepsDependenciesOfFailedResources(t *testing.T) {
+	ctx := context.Background()
+	s, q := newTestService(t)
+
+	grp, err := q.CreateNodeGroup(ctx, &db.CreateNodeGroupParams{
+		Name:      "grp1",
+		Platform:  "FABRICX",
+		GroupType: "FABRICX_COMMITTER",
+		Status:    "RUNNING",
+	})
+	require.NoError(t, err)
+	svc, err := q.CreateService(ctx, &db.CreateServiceParams{
+		Name:        "pg1",
+		ServiceType: "POSTGRES",
+		Status:      "RUNNING",
+	})
+	require.NoError(t, err)
+
+	var removed []ResourceRef
+	groupErr := errors.New("container busy")
+	s.removers[ResourceNodeGroup] = func(ctx context.Context, id int64) error {
+		if groupErr != nil {
+			return groupErr
+		}
+		removed = append(removed, ResourceRef{Type: ResourceNodeGroup, ID: id})
+		return q.DeleteNodeGroup(ctx, id)
+	}
+	s.removers[ResourceService] = func(ctx context.Context, id int64) error {
+		removed = append(removed, ResourceRef{Type: ResourceService, ID: id})
+		return q.DeleteService(ctx, id)
+	}
+
+	testnet, err := s.Create(ctx, CreateTestnetRequest{Name: "tn", Platform: PlatformFabricX})
+	require.NoError(t, err)
+	_, err = s.AddResources(ctx, testnet.ID, []ResourceRef{
+		{Type: ResourceService, ID: svc.ID},
+		{Type: ResourceNodeGroup, ID: grp.ID},
+		// Deleted outside of the testnet
+		{Type: ResourceNode, ID: 999},
+	})
+	require.NoError(t, err)
+
+	got, err := s.Get(ctx, testnet.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Resources, 3)
+	assert.Equal(t, "pg1", got.Resources[0].Name)
+	assert.Equal(t, "RUNNING", got.Resources[0].Status)
+	assert.True(t, got.Resources[2].Missing)
+
+	result, err := s.Destroy(ctx, testnet.ID)
+	require.NoError(t, err)
+	assert.False(t, result.Deleted)
+	assert.Equal(t, []ResourceRef{{Type: ResourceNode, ID: 999}}, result.Removed)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, ResourceNodeGroup, result.Failed[0].Type)
+	assert.Empty(t, removed, "the service is used by the group and must be kept")
+
+	got, err = s.Get(ctx, testnet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, got.Status)
+	assert.Contains(t, got.LastError, "container busy")
+	assert.Len(t, got.Resources, 2)
+
+	groupErr = nil
+	result, err = s.Destroy(ctx, testnet.ID)
+	require.NoError(t, err)
+	assert.True(t, result.Deleted)
+	assert.Equal(t, []ResourceRef{
+		{Type: ResourceNodeGroup, ID: grp.ID},
+		{Type: ResourceService, ID: svc.ID},
+	}, removed)
+
+	_, err = s.Get(ctx, testnet.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateRejectsDuplicateName(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t)
+
+	_, err := s.Create(ctx, CreateTestnetRequest{Name: "tn", Platform: PlatformBesu})
+	require.NoError(t, err)
+	_, err = s.Create(ctx, CreateTestnetRequest{Name: "tn", Platform: PlatformFabric})
+	assert.ErrorIs(t, err, ErrNameTaken)
+}
@@ -0,0 +1,119 @@
+package testnets
+
+import "time"
+
+// Platform is the kind of network a testnet runs
+type Platform string
+
+const (
+	PlatformFabric  Platform = "FABRIC"
+	PlatformBesu    Platform = "BESU"
+	PlatformFabricX Platform = "FABRICX"
+)
+
+// Status is the lifecycle state of a testnet
+type Status string
+
+const (
+	// StatusCreating is set while the creator is still adding resources
+	StatusCreating Status = "CREATING"
+	StatusReady    Status = "READY"
+	// StatusFailed is set when creation or a destroy did not complete; see LastError
+	StatusFailed     Status = "FAILED"
+	StatusDestroying Status = "DESTROYING"
+)
+
+// ResourceType is the kind of resource a testnet owns
+type ResourceType string
+
+const (
+	ResourceNetwork      ResourceType = "NETWORK"
+	ResourceNode         ResourceType = "NODE"
+	ResourceNodeGroup    ResourceType = "NODE_GROUP"
+	ResourceService      ResourceType = "SERVICE"
+	ResourceOrganization ResourceType = "ORGANIZATION"
+	ResourceKey          ResourceType = "KEY"
+)
+
+// destroyOrder removes dependents before what they depend on: networks reference
+// nodes, nodes belong to node groups, node groups use services, and nodes and
+// organizations are built from keys.
+var destroyOrder = []ResourceType{
+	ResourceNetwork,
+	ResourceNode,
+	ResourceNodeGroup,
+	ResourceService,
+	ResourceOrganization,
+	ResourceKey,
+}
+
+// Valid reports whether t is a known resource type
+func (t ResourceType) Valid() bool {
+	for _, known := range destroyOrder {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Testnet is a named bundle of the resources created for a test network
+type Testnet struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Platform  Platform   `json:"platform"`
+	Status    Status     `json:"status"`
+	LastError string     `json:"lastError,omitempty"`
+	Resources []Resource `json:"resources"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Resource is a resource owned by a testnet
+type Resource struct {
+	Type ResourceType `json:"type"`
+	ID   int64        `json:"id"`
+	// Name and Status are read from the resource itself and are empty when it no longer exists
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status,omitempty"`
+	// Missing is true when the resource was deleted outside of the testnet
+	Missing bool `json:"missing,omitempty"`
+}
+
+// ResourceRef identifies a resource
+type ResourceRef struct {
+	Type ResourceType `json:"type" validate:"required"`
+	ID   int64        `json:"id" validate:"required"`
+}
+
+// CreateTestnetRequest registers a testnet before its resources are created
+type CreateTestnetRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Platform Platform `json:"platform" validate:"required,oneof=FABRIC BESU FABRICX"`
+}
+
+// AddResourcesRequest records resources created for a testnet
+type AddResourcesRequest struct {
+	Resources []ResourceRef `json:"resources" validate:"required,dive"`
+}
+
+// UpdateStatusRequest marks the outcome of creating a testnet
+type UpdateStatusRequest struct {
+	Status Status `json:"status" validate:"required,oneof=READY FAILED"`
+	// Error is stored as the last error when Status is FAILED
+	Error string `json:"error,omitempty"`
+}
+
+// DestroyResult lists what a destroy removed and what it could not
+type DestroyResult struct {
+	Removed []ResourceRef    `json:"removed"`
+	Failed  []FailedResource `json:"failed,omitempty"`
+	// Deleted is true when every resource was removed and the testnet record is gone
+	Deleted bool `json:"deleted"`
+}
+
+// FailedResource is a resource a destroy could not remove
+type FailedResource struct {
+	ResourceRef
+	Error string `json:"error"`
+}